@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"os"
+
 	"github.com/RevCBH/choo/internal/feature"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +24,9 @@ type App struct {
 	debug    bool
 	shutdown chan struct{}
 
+	// Daemon context to target (see choo context); empty uses the current one
+	contextName string
+
 	// Version information
 	versionInfo VersionInfo
 
@@ -72,6 +77,8 @@ managing git worktrees and the full PR lifecycle.`,
 	a.rootCmd.PersistentFlags().BoolVarP(&a.verbose, "verbose", "v", false,
 		"Verbose output")
 	a.rootCmd.PersistentFlags().BoolVar(&a.debug, "debug", false, "Debug output (includes assistant text in streaming mode)")
+	a.rootCmd.PersistentFlags().StringVar(&a.contextName, "context", os.Getenv("CHOO_CONTEXT"),
+		"Daemon context to use (default: the current context, see choo context)")
 
 	// Add subcommands
 	a.rootCmd.AddCommand(
@@ -86,8 +93,12 @@ managing git worktrees and the full PR lifecycle.`,
 		NewNextFeatureCmd(a),
 		NewFeatureCmd(a),
 		NewDaemonCmd(a),
+		NewContextCmd(a),
+		NewWorkspaceCmd(a),
 		NewJobsCmd(a),
 		NewWatchCmd(a),
 		NewStopJobCmd(a),
+		NewStackCmd(a),
+		NewDevCmd(a),
 	)
 }
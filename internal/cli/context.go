@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/RevCBH/choo/internal/client"
+)
+
+// NewContextCmd creates the context command group for choosing which
+// daemon commands such as jobs, watch and stop-job talk to
+func NewContextCmd(a *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "Manage daemon contexts",
+		Long: `Manage the daemons that choo commands talk to.
+
+The local context is the daemon on this host, reached over its Unix socket.
+Other contexts reach a remote daemon's TCP listener (choo daemon start
+--remote-addr) over TLS, authenticating with a client certificate or a
+token from choo daemon token create. Contexts are kept in
+~/.choo/contexts.yaml.
+
+Commands use the current context unless --context or CHOO_CONTEXT names
+another.`,
+	}
+
+	cmd.AddCommand(
+		newContextListCmd(a),
+		newContextUseCmd(a),
+		newContextSetCmd(a),
+		newContextRemoveCmd(a),
+	)
+
+	return cmd
+}
+
+func newContextListCmd(a *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List daemon contexts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contexts, err := client.LoadContexts(client.DefaultContextsPath())
+			if err != nil {
+				return err
+			}
+			current, _, err := contexts.Resolve(a.contextName)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "CURRENT\tNAME\tADDRESS\tAUTH")
+			for _, name := range contexts.Names() {
+				marker := ""
+				if name == current {
+					marker = "*"
+				}
+				address, auth := defaultSocketPath(), "socket"
+				if c := contexts.Contexts[name]; c != nil {
+					address, auth = c.Address, contextAuth(c)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", marker, name, address, auth)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// contextAuth describes how a context authenticates
+func contextAuth(c *client.Context) string {
+	switch {
+	case c.ClientCert != "" && c.TokenEnv != "":
+		return "certificate, token"
+	case c.ClientCert != "":
+		return "certificate"
+	case c.TokenEnv != "":
+		return "token ($" + c.TokenEnv + ")"
+	}
+	return "none"
+}
+
+func newContextUseCmd(a *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Make a context the current one",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := client.DefaultContextsPath()
+			contexts, err := client.LoadContexts(path)
+			if err != nil {
+				return err
+			}
+			if _, _, err := contexts.Resolve(args[0]); err != nil {
+				return err
+			}
+			contexts.Current = args[0]
+			if args[0] == client.LocalContext {
+				contexts.Current = ""
+			}
+			if err := contexts.Save(path); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Switched to context %q\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newContextSetCmd(a *App) *cobra.Command {
+	var c client.Context
+
+	cmd := &cobra.Command{
+		Use:   "set <name>",
+		Short: "Add or update a remote daemon context",
+		Example: `  choo context set build --address build.example.com:7443 \
+    --ca-cert ~/.choo/build-ca.pem --token-env CHOO_BUILD_TOKEN
+  choo context set build --address build.example.com:7443 \
+    --ca-cert ~/.choo/build-ca.pem --client-cert ~/.choo/me.pem --client-key ~/.choo/me-key.pem`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if name == client.LocalContext {
+				return fmt.Errorf("the %s context cannot be changed", client.LocalContext)
+			}
+			if c.Address == "" {
+				return fmt.Errorf("--address is required")
+			}
+			if (c.ClientCert == "") != (c.ClientKey == "") {
+				return fmt.Errorf("--client-cert and --client-key must be given together")
+			}
+			if c.ClientCert == "" && c.TokenEnv == "" {
+				return fmt.Errorf("a client certificate or --token-env is required")
+			}
+
+			path := client.DefaultContextsPath()
+			contexts, err := client.LoadContexts(path)
+			if err != nil {
+				return err
+			}
+			contexts.Contexts[name] = &c
+			if err := contexts.Save(path); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Saved context %q; run choo context use %s to make it current\n", name, name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&c.Address, "address", "", "Daemon TCP address, host:port")
+	cmd.Flags().StringVar(&c.CACert, "ca-cert", "", "CA certificate for the daemon (default: system roots)")
+	cmd.Flags().StringVar(&c.ClientCert, "client-cert", "", "Client certificate for mutual TLS")
+	cmd.Flags().StringVar(&c.ClientKey, "client-key", "", "Client key for mutual TLS")
+	cmd.Flags().StringVar(&c.TokenEnv, "token-env", "", "Environment variable holding the access token")
+	cmd.Flags().StringVar(&c.ServerName, "server-name", "", "Name to verify the daemon's certificate against")
+
+	return cmd
+}
+
+func newContextRemoveCmd(a *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a remote daemon context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := client.DefaultContextsPath()
+			contexts, err := client.LoadContexts(path)
+			if err != nil {
+				return err
+			}
+			if _, ok := contexts.Contexts[args[0]]; !ok {
+				return fmt.Errorf("unknown context %q", args[0])
+			}
+			delete(contexts.Contexts, args[0])
+			if contexts.Current == args[0] {
+				contexts.Current = ""
+			}
+			return contexts.Save(path)
+		},
+	}
+}
+
+// daemonContext returns the context commands target; nil means the local
+// daemon
+func (a *App) daemonContext() (*client.Context, error) {
+	contexts, err := client.LoadContexts(client.DefaultContextsPath())
+	if err != nil {
+		return nil, err
+	}
+	_, c, err := contexts.Resolve(a.contextName)
+	return c, err
+}
+
+// dialDaemon connects to the daemon of the current context
+func (a *App) dialDaemon() (*client.Client, error) {
+	c, err := a.daemonContext()
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return client.New(defaultSocketPath())
+	}
+	return c.Dial()
+}
+
+// isRemoteDaemon reports whether commands target a remote daemon
+func (a *App) isRemoteDaemon() bool {
+	c, err := a.daemonContext()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return c != nil
+}
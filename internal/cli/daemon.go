@@ -6,12 +6,13 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"syscall"
 	"time"
 
-	"github.com/RevCBH/choo/internal/client"
 	"github.com/RevCBH/choo/internal/daemon"
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +27,9 @@ func NewDaemonCmd(a *App) *cobra.Command {
 	cmd.AddCommand(newDaemonStopCmd(a))
 	cmd.AddCommand(newDaemonStatusCmd(a))
 	cmd.AddCommand(newDaemonLogsCmd(a))
+	cmd.AddCommand(newDaemonGCCmd(a))
+	cmd.AddCommand(newDaemonTokenCmd(a))
+	cmd.AddCommand(newDaemonRunJobCmd())
 
 	return cmd
 }
@@ -38,6 +42,27 @@ type DaemonStartOptions struct {
 	ContainerMode    bool
 	ContainerImage   string
 	ContainerRuntime string
+
+	GCInterval        time.Duration
+	WorktreeRetention time.Duration
+	WorktreeQuota     string
+	GCDryRun          bool
+
+	FeatureWatchInterval time.Duration
+
+	EventRetention    time.Duration
+	MaxRuns           int
+	EventCompactAfter time.Duration
+
+	OTLPEndpoint string
+
+	MaxRepoJobs   int
+	InProcessJobs bool
+
+	RemoteAddr  string
+	TLSCert     string
+	TLSKey      string
+	TLSClientCA string
 }
 
 // newDaemonStartCmd creates the 'daemon start' command
@@ -64,6 +89,9 @@ func newDaemonStartCmd(a *App) *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
+			if a.versionInfo.Version != "" {
+				cfg.Version = a.versionInfo.Version
+			}
 
 			if opts.Foreground {
 				// Run in foreground (original behavior)
@@ -94,6 +122,36 @@ func registerDaemonStartFlags(cmd *cobra.Command, opts *DaemonStartOptions) {
 		"Container image to use for jobs")
 	cmd.Flags().StringVar(&opts.ContainerRuntime, "container-runtime", "auto",
 		"Container runtime: auto, docker, or podman")
+	cmd.Flags().DurationVar(&opts.GCInterval, "gc-interval", time.Hour,
+		"How often to garbage collect worktrees (0 disables)")
+	cmd.Flags().DurationVar(&opts.WorktreeRetention, "worktree-retention", 72*time.Hour,
+		"How long to keep worktrees of finished runs")
+	cmd.Flags().StringVar(&opts.WorktreeQuota, "worktree-quota", "",
+		"Maximum disk usage for worktrees, e.g. 20GB (empty = unlimited)")
+	cmd.Flags().BoolVar(&opts.GCDryRun, "gc-dry-run", false,
+		"Log what worktree GC would remove without deleting anything")
+	cmd.Flags().DurationVar(&opts.FeatureWatchInterval, "feature-watch-interval", 5*time.Minute,
+		"How often to check feature PRs and close out merged features (0 disables)")
+	cmd.Flags().DurationVar(&opts.EventRetention, "event-retention", 720*time.Hour,
+		"How long to keep finished runs and their event logs (0 keeps them)")
+	cmd.Flags().IntVar(&opts.MaxRuns, "max-runs", 0,
+		"Number of finished runs to keep, newest first (0 = unlimited)")
+	cmd.Flags().DurationVar(&opts.EventCompactAfter, "event-compact-after", 24*time.Hour,
+		"Summarize the task events of runs finished this long ago (0 disables)")
+	cmd.Flags().StringVar(&opts.OTLPEndpoint, "otlp-endpoint", "",
+		"Send job traces to this OTLP/HTTP collector, e.g. http://localhost:4318")
+	cmd.Flags().BoolVar(&opts.InProcessJobs, "in-process-jobs", false,
+		"Run jobs inside the daemon process, so they stop with it, instead of in job runners")
+	cmd.Flags().IntVar(&opts.MaxRepoJobs, "max-repo-jobs", 0,
+		"Max concurrent jobs per repository; workspaces may set their own (0 = no per-repository limit)")
+	cmd.Flags().StringVar(&opts.RemoteAddr, "remote-addr", "",
+		"Also accept remote clients over TLS on this TCP address, e.g. :7443")
+	cmd.Flags().StringVar(&opts.TLSCert, "tls-cert", "",
+		"Server certificate for --remote-addr and the web UI")
+	cmd.Flags().StringVar(&opts.TLSKey, "tls-key", "",
+		"Server key for --remote-addr and the web UI")
+	cmd.Flags().StringVar(&opts.TLSClientCA, "tls-client-ca", "",
+		"Accept remote clients with certificates signed by this CA (mutual TLS)")
 }
 
 // buildDaemonConfig creates a daemon.Config from CLI options.
@@ -107,6 +165,30 @@ func buildDaemonConfig(opts DaemonStartOptions) (*daemon.Config, error) {
 	cfg.ContainerImage = opts.ContainerImage
 	cfg.ContainerRuntime = opts.ContainerRuntime
 
+	cfg.GCInterval = opts.GCInterval
+	cfg.WorktreeRetention = opts.WorktreeRetention
+	cfg.GCDryRun = opts.GCDryRun
+	cfg.FeatureWatchInterval = opts.FeatureWatchInterval
+	cfg.EventRetention = opts.EventRetention
+	cfg.MaxRuns = opts.MaxRuns
+	cfg.EventCompactAfter = opts.EventCompactAfter
+	cfg.OTLPEndpoint = opts.OTLPEndpoint
+	cfg.MaxRepoJobs = opts.MaxRepoJobs
+	if opts.InProcessJobs {
+		cfg.JobsDir = ""
+	}
+	cfg.RemoteAddr = opts.RemoteAddr
+	cfg.TLSCert = opts.TLSCert
+	cfg.TLSKey = opts.TLSKey
+	cfg.TLSClientCA = opts.TLSClientCA
+	if opts.WorktreeQuota != "" {
+		quota, err := humanize.ParseBytes(opts.WorktreeQuota)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --worktree-quota %q: %w", opts.WorktreeQuota, err)
+		}
+		cfg.WorktreeQuota = int64(quota)
+	}
+
 	return cfg, nil
 }
 
@@ -169,6 +251,45 @@ func startDaemonBackground(opts DaemonStartOptions) error {
 	if opts.ContainerRuntime != "" {
 		args = append(args, "--container-runtime", opts.ContainerRuntime)
 	}
+	args = append(args, "--gc-interval", opts.GCInterval.String())
+	args = append(args, "--worktree-retention", opts.WorktreeRetention.String())
+	if opts.WorktreeQuota != "" {
+		args = append(args, "--worktree-quota", opts.WorktreeQuota)
+	}
+	if opts.GCDryRun {
+		args = append(args, "--gc-dry-run")
+	}
+	args = append(args, "--feature-watch-interval", opts.FeatureWatchInterval.String())
+	args = append(args, "--event-retention", opts.EventRetention.String())
+	if opts.MaxRuns > 0 {
+		args = append(args, "--max-runs", fmt.Sprint(opts.MaxRuns))
+	}
+	args = append(args, "--event-compact-after", opts.EventCompactAfter.String())
+	if opts.OTLPEndpoint != "" {
+		args = append(args, "--otlp-endpoint", opts.OTLPEndpoint)
+	}
+	if opts.MaxRepoJobs > 0 {
+		args = append(args, "--max-repo-jobs", fmt.Sprint(opts.MaxRepoJobs))
+	}
+	if opts.InProcessJobs {
+		args = append(args, "--in-process-jobs")
+	}
+	if opts.RemoteAddr != "" {
+		args = append(args, "--remote-addr", opts.RemoteAddr)
+	}
+	for _, f := range [][2]string{
+		{"--tls-cert", opts.TLSCert},
+		{"--tls-key", opts.TLSKey},
+		{"--tls-client-ca", opts.TLSClientCA},
+	} {
+		if path := f[1]; path != "" {
+			// The daemon does not run in this directory
+			if abs, err := filepath.Abs(path); err == nil {
+				path = abs
+			}
+			args = append(args, f[0], path)
+		}
+	}
 	if opts.Verbose {
 		args = append(args, "--verbose")
 	}
@@ -221,6 +342,24 @@ func startDaemonBackground(opts DaemonStartOptions) error {
 	return fmt.Errorf("daemon failed to start - check %s for details", logPath)
 }
 
+// newDaemonRunJobCmd creates the hidden 'daemon run-job' command the
+// daemon starts a job runner with
+func newDaemonRunJobCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:    "run-job <job-dir>",
+		Short:  "Run a daemon job in a job runner process",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Stopping the daemon does not stop the job; only a signal to
+			// the runner itself or a cancellation from the daemon does
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return daemon.RunJobRunner(ctx, args[0])
+		},
+	}
+}
+
 // newDaemonStopCmd creates the 'daemon stop' command
 // Flags: --wait (bool, default: true), --timeout (int, default: 30)
 func newDaemonStopCmd(a *App) *cobra.Command {
@@ -234,12 +373,12 @@ func newDaemonStopCmd(a *App) *cobra.Command {
 		Short: "Stop the daemon gracefully",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Check if daemon is running first
-			if !isDaemonRunning() {
+			if !a.isRemoteDaemon() && !isDaemonRunning() {
 				fmt.Println("Daemon is not running")
 				return nil
 			}
 
-			c, err := client.New(defaultSocketPath())
+			c, err := a.dialDaemon()
 			if err != nil {
 				// Connection failed - daemon probably not running
 				fmt.Println("Daemon is not running")
@@ -268,7 +407,7 @@ func newDaemonStatusCmd(a *App) *cobra.Command {
 		Use:   "status",
 		Short: "Show daemon status",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			c, err := client.New(defaultSocketPath())
+			c, err := a.dialDaemon()
 			if err != nil {
 				return fmt.Errorf("daemon not running: %w", err)
 			}
@@ -287,6 +426,41 @@ func newDaemonStatusCmd(a *App) *cobra.Command {
 	}
 }
 
+// newDaemonGCCmd creates the 'daemon gc' command
+// Runs a worktree garbage collection pass and prints the report.
+func newDaemonGCCmd(a *App) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Garbage collect worktrees of finished runs",
+		Long: `Remove worktrees and stale unit branches left behind by completed,
+failed, cancelled or abandoned runs, according to the daemon's retention
+policy and disk quota. Unit branches are only deleted once merged or
+once their run completed; unmerged work of failed runs is kept. Use
+--dry-run to see what would be removed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.dialDaemon()
+			if err != nil {
+				return fmt.Errorf("daemon not running: %w", err)
+			}
+			defer c.Close()
+
+			report, err := c.RunGC(cmd.Context(), dryRun)
+			if err != nil {
+				return err
+			}
+
+			displayGCReport(report)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be removed without deleting anything")
+
+	return cmd
+}
+
 // newDaemonLogsCmd creates the 'daemon logs' command
 // Shows daemon log output with optional follow mode
 func newDaemonLogsCmd(a *App) *cobra.Command {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/RevCBH/choo/internal/client"
 	"github.com/RevCBH/choo/internal/events"
+	"github.com/dustin/go-humanize"
 )
 
 // displayEvent renders an event to the terminal with appropriate formatting
@@ -20,6 +21,9 @@ func displayEvent(e events.Event) {
 	// Build the event message based on type
 	var msg string
 	switch e.Type {
+	case events.TaskOutput:
+		// Transcripts are for the web UI; they would drown out the rest
+		return
 	case events.UnitStarted:
 		msg = fmt.Sprintf("[%s] Unit started: %s", timestamp, e.Unit)
 	case events.UnitCompleted:
@@ -59,6 +63,10 @@ func displayEvent(e events.Event) {
 		if e.Error != "" {
 			msg += fmt.Sprintf(" - %s", e.Error)
 		}
+	case events.OrchPaused:
+		msg = fmt.Sprintf("[%s] Orchestrator paused", timestamp)
+	case events.OrchResumed:
+		msg = fmt.Sprintf("[%s] Orchestrator resumed", timestamp)
 	default:
 		// Generic format for unknown event types
 		msg = fmt.Sprintf("[%s] %s: %s", timestamp, e.Type, e.Unit)
@@ -90,6 +98,47 @@ func displayJobs(jobs []*client.JobSummary) {
 	}
 }
 
+// displayGCReport renders a worktree garbage collection report.
+// Columns: Reason, Size, Branch, Path
+func displayGCReport(report *client.GCReport) {
+	verb := "Removed"
+	if report.DryRun {
+		verb = "Would remove"
+	}
+
+	if len(report.Removed) == 0 {
+		fmt.Println("No worktrees to remove")
+	} else {
+		fmt.Printf("%s %d worktree(s):\n", verb, len(report.Removed))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "REASON\tSIZE\tBRANCH\tPATH")
+		for _, wt := range report.Removed {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				wt.Reason,
+				humanize.IBytes(uint64(wt.Bytes)),
+				wt.Branch,
+				wt.Path,
+			)
+		}
+		w.Flush()
+	}
+
+	if len(report.PrunedBranches) > 0 {
+		fmt.Printf("%s %d branch(es):\n", verb, len(report.PrunedBranches))
+		for _, b := range report.PrunedBranches {
+			fmt.Printf("  - %s (%s)\n", b.Name, b.RepoPath)
+		}
+	}
+
+	fmt.Printf("Worktree usage: %s -> %s\n",
+		humanize.IBytes(uint64(report.UsageBefore)),
+		humanize.IBytes(uint64(report.UsageAfter)))
+
+	for _, msg := range report.Errors {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+	}
+}
+
 // boolToStatus converts a health boolean to a human-readable status string.
 func boolToStatus(healthy bool) string {
 	if healthy {
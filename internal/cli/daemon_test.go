@@ -2,10 +2,12 @@ package cli
 
 import (
 	"testing"
+	"time"
 )
 
 func TestDaemonCmd_Structure(t *testing.T) {
-	// Verifies daemon has start, stop, status, logs subcommands
+	// Verifies daemon has start, stop, status, logs, gc, token subcommands,
+	// plus the hidden run-job command job runners run
 	app := New()
 	cmd := NewDaemonCmd(app)
 
@@ -15,18 +17,21 @@ func TestDaemonCmd_Structure(t *testing.T) {
 
 	// Check for subcommands
 	subcommands := cmd.Commands()
-	if len(subcommands) != 4 {
-		t.Errorf("Expected 4 subcommands, got %d", len(subcommands))
+	if len(subcommands) != 7 {
+		t.Errorf("Expected 7 subcommands, got %d", len(subcommands))
 	}
 
 	// Map subcommands by name
 	subcmdMap := make(map[string]bool)
 	for _, subcmd := range subcommands {
-		subcmdMap[subcmd.Use] = true
+		subcmdMap[subcmd.Name()] = true
+		if subcmd.Name() == "run-job" && !subcmd.Hidden {
+			t.Error("Expected run-job to be hidden")
+		}
 	}
 
 	// Verify required subcommands
-	requiredSubcmds := []string{"start", "stop", "status", "logs"}
+	requiredSubcmds := []string{"start", "stop", "status", "logs", "gc", "token", "run-job"}
 	for _, required := range requiredSubcmds {
 		if !subcmdMap[required] {
 			t.Errorf("Expected subcommand '%s' not found", required)
@@ -116,6 +121,103 @@ func TestDaemonStartCmd_ForegroundFlag(t *testing.T) {
 	}
 }
 
+func TestBuildDaemonConfig_GCOptions(t *testing.T) {
+	// Verifies GC flags are parsed into the daemon config
+	opts := DaemonStartOptions{
+		GCInterval:        30 * time.Minute,
+		WorktreeRetention: 24 * time.Hour,
+		WorktreeQuota:     "2GiB",
+		GCDryRun:          true,
+
+		FeatureWatchInterval: 10 * time.Minute,
+
+		EventRetention:    240 * time.Hour,
+		MaxRuns:           50,
+		EventCompactAfter: 6 * time.Hour,
+
+		OTLPEndpoint: "http://collector:4318",
+	}
+
+	cfg, err := buildDaemonConfig(opts)
+	if err != nil {
+		t.Fatalf("buildDaemonConfig returned error: %v", err)
+	}
+
+	if cfg.GCInterval != 30*time.Minute {
+		t.Errorf("Expected GCInterval 30m, got: %s", cfg.GCInterval)
+	}
+	if cfg.WorktreeRetention != 24*time.Hour {
+		t.Errorf("Expected WorktreeRetention 24h, got: %s", cfg.WorktreeRetention)
+	}
+	if cfg.WorktreeQuota != 2<<30 {
+		t.Errorf("Expected WorktreeQuota %d, got: %d", int64(2<<30), cfg.WorktreeQuota)
+	}
+	if !cfg.GCDryRun {
+		t.Error("Expected GCDryRun to be true")
+	}
+	if cfg.FeatureWatchInterval != 10*time.Minute {
+		t.Errorf("Expected FeatureWatchInterval 10m, got: %s", cfg.FeatureWatchInterval)
+	}
+	if cfg.EventRetention != 240*time.Hour || cfg.MaxRuns != 50 || cfg.EventCompactAfter != 6*time.Hour {
+		t.Errorf("Retention options not applied: %s, %d, %s", cfg.EventRetention, cfg.MaxRuns, cfg.EventCompactAfter)
+	}
+	if cfg.OTLPEndpoint != "http://collector:4318" {
+		t.Errorf("Expected OTLPEndpoint http://collector:4318, got: %s", cfg.OTLPEndpoint)
+	}
+}
+
+func TestBuildDaemonConfig_InProcessJobs(t *testing.T) {
+	// Jobs run in job runners unless --in-process-jobs is set
+	cfg, err := buildDaemonConfig(DaemonStartOptions{})
+	if err != nil {
+		t.Fatalf("buildDaemonConfig returned error: %v", err)
+	}
+	if cfg.JobsDir == "" {
+		t.Error("Expected a JobsDir by default")
+	}
+
+	cfg, err = buildDaemonConfig(DaemonStartOptions{InProcessJobs: true})
+	if err != nil {
+		t.Fatalf("buildDaemonConfig returned error: %v", err)
+	}
+	if cfg.JobsDir != "" {
+		t.Errorf("Expected no JobsDir with --in-process-jobs, got: %s", cfg.JobsDir)
+	}
+}
+
+func TestBuildDaemonConfig_RemoteOptions(t *testing.T) {
+	opts := DaemonStartOptions{
+		RemoteAddr:  ":7443",
+		TLSCert:     "/etc/choo/server.pem",
+		TLSKey:      "/etc/choo/server-key.pem",
+		TLSClientCA: "/etc/choo/clients-ca.pem",
+	}
+
+	cfg, err := buildDaemonConfig(opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.RemoteAddr != ":7443" || cfg.TLSCert != opts.TLSCert || cfg.TLSKey != opts.TLSKey || cfg.TLSClientCA != opts.TLSClientCA {
+		t.Errorf("Remote options not applied: %+v", cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected valid config, got: %v", err)
+	}
+
+	cfg.TLSKey = ""
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for --remote-addr without a TLS key")
+	}
+}
+
+func TestBuildDaemonConfig_InvalidQuota(t *testing.T) {
+	opts := DaemonStartOptions{WorktreeQuota: "lots"}
+
+	if _, err := buildDaemonConfig(opts); err == nil {
+		t.Error("Expected error for invalid --worktree-quota")
+	}
+}
+
 func TestIsDaemonRunning_NoDaemon(t *testing.T) {
 	// When no daemon is running, isDaemonRunning should return false
 	// This is the normal case in tests where no daemon is started
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/RevCBH/choo/internal/daemon"
+)
+
+// newDaemonTokenCmd creates the 'daemon token' command group for managing
+// the access tokens of remote clients
+func newDaemonTokenCmd(a *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage access tokens for remote clients",
+		Long: `Manage who may use the daemon's TCP listener (daemon start --remote-addr).
+
+Tokens are stored hashed in ~/.choo/remote-access.yaml on the daemon's host,
+each with a permission: read (list, inspect and watch jobs) or control
+(also start and stop jobs, run GC and stop the daemon). Changes apply
+without restarting the daemon.
+
+Clients with a certificate signed by --tls-client-ca need no token. They
+get control unless the access file lists their certificate's common name
+with another permission.`,
+	}
+
+	cmd.AddCommand(newDaemonTokenCreateCmd(a))
+	cmd.AddCommand(newDaemonTokenListCmd(a))
+	cmd.AddCommand(newDaemonTokenRevokeCmd(a))
+
+	return cmd
+}
+
+// newDaemonTokenCreateCmd creates the 'daemon token create' command
+func newDaemonTokenCreateCmd(a *App) *cobra.Command {
+	var readOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create an access token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, list, err := loadAccessList()
+			if err != nil {
+				return err
+			}
+			perm := daemon.PermissionControl
+			if readOnly {
+				perm = daemon.PermissionRead
+			}
+			token, err := list.AddToken(args[0], perm)
+			if err != nil {
+				return err
+			}
+			if err := list.Save(path); err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Created %s token for %s. It is not shown again:\n\n%s\n\n", perm, args[0], token)
+			fmt.Fprintln(out, "On the client, export it and point a context at this daemon:")
+			fmt.Fprintln(out, "  choo context set <name> --address <host:port> --ca-cert <ca.pem> --token-env <VAR>")
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&readOnly, "read-only", false, "Only allow listing, inspecting and watching jobs")
+
+	return cmd
+}
+
+// newDaemonTokenListCmd creates the 'daemon token list' command
+func newDaemonTokenListCmd(a *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List remote clients",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, list, err := loadAccessList()
+			if err != nil {
+				return err
+			}
+			if len(list.Clients) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No remote clients")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tAUTH\tPERMISSION\tCREATED")
+			for _, c := range list.Clients {
+				auth := "token"
+				if c.CommonName != "" {
+					auth = "certificate CN=" + c.CommonName
+				}
+				created := ""
+				if !c.CreatedAt.IsZero() {
+					created = c.CreatedAt.Local().Format("2006-01-02 15:04")
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Name, auth, c.Permission, created)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// newDaemonTokenRevokeCmd creates the 'daemon token revoke' command
+func newDaemonTokenRevokeCmd(a *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <name>",
+		Short: "Revoke a remote client's access",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, list, err := loadAccessList()
+			if err != nil {
+				return err
+			}
+			if !list.Remove(args[0]) {
+				return fmt.Errorf("no remote client named %q", args[0])
+			}
+			if err := list.Save(path); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Revoked %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+// loadAccessList reads the daemon's access file from its default location
+func loadAccessList() (string, *daemon.AccessList, error) {
+	cfg, err := daemon.DefaultConfig()
+	if err != nil {
+		return "", nil, err
+	}
+	if err := cfg.EnsureDirectories(); err != nil {
+		return "", nil, err
+	}
+	list, err := daemon.LoadAccessList(cfg.AccessFile)
+	return cfg.AccessFile, list, err
+}
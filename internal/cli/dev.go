@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/RevCBH/choo/internal/forge/fake"
+	"github.com/spf13/cobra"
+)
+
+// DevForgeOptions holds flags for the dev forge command
+type DevForgeOptions struct {
+	Addr  string // Listen address
+	Bare  string // Bare repository the fake forge merges into
+	Owner string // Repository owner served by the fake forge
+	Repo  string // Repository name (default: bare directory name)
+	Init  bool   // Create the bare repository if it does not exist
+}
+
+// NewDevCmd creates the dev parent command for local development tools
+func NewDevCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Local development and testing tools",
+	}
+
+	opts := DevForgeOptions{}
+	forgeCmd := &cobra.Command{
+		Use:   "forge",
+		Short: "Run a fake GitHub API backed by a local bare repository",
+		Long: `Run an in-process fake of the GitHub REST API for offline PR workflows.
+
+The server hosts one repository, backed by a bare git repo that choo pushes
+to and merges into. Point the repo under test at it with origin set to the
+bare repo and, in .choo.yaml:
+
+  github:
+    owner: local
+    repo: <name>
+  forge:
+    type: github
+    url: http://127.0.0.1:8787
+
+Any token is accepted and is used as the caller's login, so reviewers and CI
+can be simulated with curl, e.g. approving PR #1 as alice:
+
+  curl -H "Authorization: Bearer alice" -d '{"content":"+1"}' \
+    http://127.0.0.1:8787/repos/local/<name>/issues/1/reactions`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.DevForge(cmd.Context(), opts)
+		},
+	}
+	forgeCmd.Flags().StringVar(&opts.Addr, "addr", "127.0.0.1:8787", "Address to listen on")
+	forgeCmd.Flags().StringVar(&opts.Bare, "bare", "", "Path to the bare repository (required)")
+	forgeCmd.Flags().StringVar(&opts.Owner, "owner", "local", "Repository owner")
+	forgeCmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository name (default: bare directory name)")
+	forgeCmd.Flags().BoolVar(&opts.Init, "init", false, "Create the bare repository if it does not exist")
+	forgeCmd.MarkFlagRequired("bare")
+
+	cmd.AddCommand(forgeCmd)
+	return cmd
+}
+
+// DevForge serves the fake forge until interrupted
+func (a *App) DevForge(ctx context.Context, opts DevForgeOptions) error {
+	bare, err := filepath.Abs(opts.Bare)
+	if err != nil {
+		return fmt.Errorf("failed to resolve bare repository path: %w", err)
+	}
+	if opts.Repo == "" {
+		opts.Repo = strings.TrimSuffix(filepath.Base(bare), ".git")
+	}
+
+	if _, err := os.Stat(bare); os.IsNotExist(err) && opts.Init {
+		if out, err := exec.CommandContext(ctx, "git", "init", "--bare", "-b", "main", bare).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to create bare repository: %w\n%s", err, out)
+		}
+	}
+
+	srv, err := fake.New(fake.Config{Owner: opts.Owner, Repo: opts.Repo, BarePath: bare})
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.Addr, err)
+	}
+	httpSrv := &http.Server{Handler: srv}
+
+	url := "http://" + ln.Addr().String()
+	fmt.Printf("Fake forge listening on %s\n", url)
+	fmt.Printf("Serving %s/%s from %s\n\n", opts.Owner, opts.Repo, bare)
+	fmt.Println("Point a repository at it with:")
+	fmt.Printf("  git remote set-url origin %s\n", bare)
+	fmt.Printf("  .choo.yaml: github: {owner: %s, repo: %s}\n", opts.Owner, opts.Repo)
+	fmt.Printf("              forge: {type: github, url: %s}\n", url)
+	fmt.Println("  GITHUB_TOKEN=<any login>")
+	fmt.Println("\nPress Ctrl+C to stop")
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- httpSrv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return httpSrv.Shutdown(shutdownCtx)
+}
@@ -20,6 +20,9 @@ feature workflow state.`,
 		NewFeatureStatusCmd(app),
 		NewFeatureResumeCmd(app),
 		NewFeatureSpecCmd(app),
+		NewFeatureImportCmd(app),
+		NewFeatureDraftCmd(app),
+		NewFeatureGraphCmd(app),
 	)
 
 	return cmd
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/feature"
+	"github.com/RevCBH/choo/internal/provider"
+)
+
+// FeatureDraftOptions holds flags for the feature draft command
+type FeatureDraftOptions struct {
+	Idea      string
+	PRDDir    string
+	MaxRounds int
+	Provider  string
+}
+
+// NewFeatureDraftCmd creates the feature draft command
+func NewFeatureDraftCmd(app *App) *cobra.Command {
+	opts := &FeatureDraftOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "draft [idea...]",
+		Short: "Write a PRD interactively with an agent",
+		Long: `Turn a feature idea into a PRD through a conversation with an agent.
+
+The agent reads the repository, asks clarifying questions, then proposes
+a scope and an estimate of units and tasks. Answer yes to write the PRD,
+or describe what to change. The PRD is written to the PRD directory with
+status draft, and the conversation is saved next to it as
+<prd-id>.draft.yaml.
+
+Set status: approved in the PRD's frontmatter once it is ready, then run
+choo feature start <prd-id>.`,
+		Example: `  choo feature draft "Let admins export reports as CSV"
+  choo feature draft --provider codex`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Idea = strings.Join(args, " ")
+			return app.RunFeatureDraft(cmd, *opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.PRDDir, "prd-dir", "", "PRDs directory (default: feature.prd_dir)")
+	cmd.Flags().IntVar(&opts.MaxRounds, "max-rounds", feature.DefaultDraftRounds, "Maximum agent turns before giving up")
+	cmd.Flags().StringVar(&opts.Provider, "provider", "", "Provider for the drafting agent: claude or codex")
+
+	return cmd
+}
+
+// RunFeatureDraft drafts a PRD through an interview in the terminal
+func (a *App) RunFeatureDraft(cmd *cobra.Command, opts FeatureDraftOptions) error {
+	repoRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	cfg, err := config.LoadConfig(repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	prdDir := opts.PRDDir
+	if prdDir == "" {
+		prdDir = cfg.Feature.PRDDir
+	}
+	if !filepath.IsAbs(prdDir) {
+		prdDir = filepath.Join(repoRoot, prdDir)
+	}
+
+	in := newTerminalInterviewer(cmd.InOrStdin(), cmd.OutOrStdout())
+	if opts.Idea == "" {
+		if opts.Idea, err = in.Ask(cmd.Context(), "What feature would you like to build?"); err != nil {
+			return err
+		}
+	}
+
+	invoker := a.agentInvoker
+	if invoker == nil {
+		resolved := config.ResolveProvider(config.ProviderResolutionContext{
+			CLIProvider: opts.Provider,
+			EnvProvider: os.Getenv(config.EnvProvider),
+		}, cfg)
+		prov, err := provider.FromConfig(provider.Config{
+			Type:    provider.ProviderType(resolved.Type),
+			Command: resolved.Command,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create provider: %w", err)
+		}
+		invoker = &providerInvoker{provider: prov, workdir: repoRoot}
+	}
+
+	if err := os.MkdirAll(prdDir, 0755); err != nil {
+		return fmt.Errorf("failed to create PRD directory: %w", err)
+	}
+	in.Show("Drafting a PRD. The agent may take a moment to read the repository between questions.")
+	result, err := feature.Draft(cmd.Context(), invoker, in, feature.DraftOptions{
+		Idea:      opts.Idea,
+		PRDDir:    prdDir,
+		MaxRounds: opts.MaxRounds,
+	})
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "\nWrote %s (conversation: %s)\n", relPath(repoRoot, result.PRD.FilePath), relPath(repoRoot, result.TranscriptPath))
+	fmt.Fprintf(out, "Review it, set status: approved, then run: choo feature start %s\n", result.PRD.ID)
+	return nil
+}
+
+// relPath returns path relative to root when possible
+func relPath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+// terminalInterviewer asks questions on out and reads answers from in. An
+// answer is one line; end a line with a backslash to continue it.
+type terminalInterviewer struct {
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+func newTerminalInterviewer(in io.Reader, out io.Writer) *terminalInterviewer {
+	return &terminalInterviewer{in: bufio.NewScanner(in), out: out}
+}
+
+func (t *terminalInterviewer) Ask(ctx context.Context, question string) (string, error) {
+	fmt.Fprintf(t.out, "\n%s\n> ", question)
+	var lines []string
+	for t.in.Scan() {
+		line := t.in.Text()
+		if strings.HasSuffix(line, "\\") {
+			lines = append(lines, strings.TrimSuffix(line, "\\"))
+			fmt.Fprint(t.out, "  ")
+			continue
+		}
+		if strings.TrimSpace(line) == "" && len(lines) == 0 {
+			fmt.Fprint(t.out, "> ")
+			continue
+		}
+		lines = append(lines, line)
+		break
+	}
+	if err := t.in.Err(); err != nil {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	answer := strings.TrimSpace(strings.Join(lines, "\n"))
+	if answer == "" {
+		return "", fmt.Errorf("no answer given: input closed")
+	}
+	return answer, nil
+}
+
+func (t *terminalInterviewer) Show(message string) {
+	fmt.Fprintf(t.out, "\n%s\n", message)
+}
+
+// providerInvoker adapts a provider to feature.AgentInvoker, returning what
+// the provider prints as its response
+type providerInvoker struct {
+	provider provider.Provider
+	workdir  string
+}
+
+func (p *providerInvoker) Invoke(ctx context.Context, prompt string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	if err := p.provider.Invoke(ctx, prompt, p.workdir, &stdout, &stderr); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type draftTestInvoker struct {
+	responses []string
+}
+
+func (d *draftTestInvoker) Invoke(ctx context.Context, prompt string) (string, error) {
+	response := d.responses[0]
+	d.responses = d.responses[1:]
+	return response, nil
+}
+
+func TestTerminalInterviewer_Ask(t *testing.T) {
+	var out bytes.Buffer
+	in := newTerminalInterviewer(strings.NewReader("\nfirst line\\\nsecond line\nnext\n"), &out)
+
+	answer, err := in.Ask(context.Background(), "Who uses it?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer != "first line\nsecond line" {
+		t.Errorf("unexpected answer %q", answer)
+	}
+	if !strings.Contains(out.String(), "Who uses it?") {
+		t.Error("question not shown")
+	}
+
+	if answer, _ := in.Ask(context.Background(), "Anything else?"); answer != "next" {
+		t.Errorf("unexpected answer %q", answer)
+	}
+	if _, err := in.Ask(context.Background(), "More?"); err == nil {
+		t.Error("expected error once input is closed")
+	}
+}
+
+func TestFeatureDraftCmd_WritesPRD(t *testing.T) {
+	tmpDir := t.TempDir()
+	// Create a minimal config to avoid auto-detect
+	os.WriteFile(filepath.Join(tmpDir, ".choo.yaml"), []byte(`github:
+  owner: testowner
+  repo: testrepo
+`), 0644)
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	app := New()
+	app.SetAgentInvoker(&draftTestInvoker{responses: []string{
+		`{"questions": ["Which formats?"]}`,
+		`{"proposal": {"id": "csv-export", "title": "CSV Export", "estimated_units": 1, "estimated_tasks": 3, "body": "Export reports."}}`,
+	}})
+
+	cmd := NewFeatureDraftCmd(app)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(strings.NewReader("CSV only\nyes\n"))
+	cmd.SetArgs([]string{"Export", "reports"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("draft failed: %v", err)
+	}
+
+	for _, name := range []string{"csv-export.md", "csv-export.draft.yaml"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, "docs", "prd", name)); err != nil {
+			t.Errorf("%s not written: %v", name, err)
+		}
+	}
+	if !strings.Contains(out.String(), "choo feature start csv-export") {
+		t.Errorf("expected next step hint, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/RevCBH/choo/internal/feature"
+)
+
+// FeatureGraphOptions holds flags for the feature graph command
+type FeatureGraphOptions struct {
+	PRDDir string
+	Format string
+}
+
+// NewFeatureGraphCmd creates the feature graph command
+func NewFeatureGraphCmd(app *App) *cobra.Command {
+	opts := &FeatureGraphOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Show the dependency graph of PRDs",
+		Long: `Show how PRDs depend on each other through their depends_on frontmatter.
+
+The text format lists PRDs by dependency level with their status, so each
+PRD appears after everything it depends on. The dot and mermaid formats
+render the same graph for Graphviz or Markdown, colored by status.
+
+Fails if the PRDs depend on each other in a cycle or name a PRD that
+does not exist.`,
+		Example: `  choo feature graph
+  choo feature graph --format dot | dot -Tsvg > roadmap.svg
+  choo feature graph --format mermaid`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.RunFeatureGraph(cmd, *opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.PRDDir, "prd-dir", "docs/prd", "PRDs directory")
+	cmd.Flags().StringVar(&opts.Format, "format", feature.GraphFormatText, "Output format: text, dot or mermaid")
+
+	return cmd
+}
+
+// RunFeatureGraph renders the PRD dependency graph
+func (a *App) RunFeatureGraph(cmd *cobra.Command, opts FeatureGraphOptions) error {
+	prds, err := feature.DiscoverPRDs(opts.PRDDir)
+	if err != nil {
+		return err
+	}
+	if len(prds) == 0 {
+		return fmt.Errorf("no PRDs found in %s", opts.PRDDir)
+	}
+	graph, err := feature.NewGraph(prds)
+	if err != nil {
+		return err
+	}
+	out, err := graph.Render(opts.Format)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(cmd.OutOrStdout(), out)
+	return nil
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFeatureGraphCmd_Formats(t *testing.T) {
+	prdDir := t.TempDir()
+	writeDependentPRDs(t, prdDir)
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"text", "[approved] billing: Billing (depends on auth)"},
+		{"dot", `"auth" -> "billing";`},
+		{"mermaid", "prd_auth --> prd_billing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			cmd := NewFeatureGraphCmd(New())
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetArgs([]string{"--prd-dir", prdDir, "--format", tt.format})
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("graph failed: %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("Expected %q in output:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/intake"
+)
+
+// FeatureImportOptions holds flags for the feature import command
+type FeatureImportOptions struct {
+	Source string
+	Label  string
+	PRDDir string
+	DryRun bool
+}
+
+// NewFeatureImportCmd creates the feature import command
+func NewFeatureImportCmd(app *App) *cobra.Command {
+	opts := &FeatureImportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create PRDs from labeled tracker issues",
+		Long: `Create draft PRDs from issues in GitHub, Jira or Linear.
+
+Each selected issue becomes a PRD in the PRD directory whose frontmatter
+links back to the issue. Issues that were imported before are skipped.
+The issue gets a comment naming the PRD, and later comments as the
+feature started from it progresses; GitHub issues are closed when the
+feature completes.
+
+Issues are selected by the intake label (GitHub, Linear) or JQL (Jira)
+configured under intake in .choo.yaml.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.RunFeatureImport(cmd, *opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Source, "source", intake.SourceGitHub, "Issue tracker: github, jira or linear")
+	cmd.Flags().StringVar(&opts.Label, "label", "", "Import issues with this label (default: intake.label)")
+	cmd.Flags().StringVar(&opts.PRDDir, "prd-dir", "", "PRDs directory (default: feature.prd_dir)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "List the PRDs that would be created")
+
+	return cmd
+}
+
+// RunFeatureImport imports tracker issues as PRDs
+func (a *App) RunFeatureImport(cmd *cobra.Command, opts FeatureImportOptions) error {
+	repoRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	cfg, err := config.LoadConfig(repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if opts.Label != "" {
+		cfg.Intake.Label = opts.Label
+	}
+	prdDir := opts.PRDDir
+	if prdDir == "" {
+		prdDir = cfg.Feature.PRDDir
+	}
+	if !filepath.IsAbs(prdDir) {
+		prdDir = filepath.Join(repoRoot, prdDir)
+	}
+
+	src, err := intake.SourceFromConfig(cfg, opts.Source)
+	if err != nil {
+		return err
+	}
+	result, err := intake.Import(cmd.Context(), src, prdDir, opts.DryRun)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	verb := "Imported"
+	if opts.DryRun {
+		verb = "Would import"
+	}
+	for _, prd := range result.Imported {
+		rel, err := filepath.Rel(repoRoot, prd.FilePath)
+		if err != nil {
+			rel = prd.FilePath
+		}
+		fmt.Fprintf(out, "%s %s %s as %s\n", verb, src.Type(), prd.Source.Key, rel)
+	}
+	for _, issue := range result.Skipped {
+		fmt.Fprintf(out, "Skipped %s %s (already imported)\n", src.Type(), issue.Key)
+	}
+	if len(result.Imported) == 0 && len(result.Skipped) == 0 {
+		fmt.Fprintf(out, "No %s issues to import\n", src.Type())
+	}
+	return nil
+}
+
+// trackFeatureIssue reports the run's progress on the issue that prdID was
+// imported from, if any. The returned function flushes pending updates.
+func trackFeatureIssue(cfg *config.Config, repoRoot, prdID string, bus *events.Bus) func() {
+	prdDir := cfg.Feature.PRDDir
+	if !filepath.IsAbs(prdDir) {
+		prdDir = filepath.Join(repoRoot, prdDir)
+	}
+	prd := intake.ImportedPRD(prdDir, prdID)
+	if prd == nil {
+		return func() {}
+	}
+
+	src, err := intake.SourceFromConfig(cfg, prd.Source.Type)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not updating %s issue %s: %v\n", prd.Source.Type, prd.Source.Key, err)
+		return func() {}
+	}
+	tracker := intake.NewTracker(src, prd)
+	bus.Subscribe(tracker.Handler())
+	return func() {
+		bus.Wait()
+		tracker.Close()
+	}
+}
@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/RevCBH/choo/internal/feature"
 )
 
 // FeatureStartOptions holds flags for the feature start command
@@ -14,6 +19,7 @@ type FeatureStartOptions struct {
 	SkipSpecReview bool
 	MaxReviewIter  int
 	DryRun         bool
+	Force          bool
 }
 
 // NewFeatureStartCmd creates the feature start command
@@ -32,7 +38,10 @@ The start command initiates the feature workflow from a PRD. It will:
 4. Review specs with the spec-reviewer agent (unless --skip-spec-review)
 5. Validate specs using the spec-validator agent
 6. Generate tasks using the task-generator agent
-7. Commit specs and tasks to the feature branch`,
+7. Commit specs and tasks to the feature branch
+
+A PRD whose depends_on PRDs are not complete is refused unless --force
+is given; see choo feature graph.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.PRDID = args[0]
@@ -46,12 +55,17 @@ The start command initiates the feature workflow from a PRD. It will:
 	cmd.Flags().BoolVar(&opts.SkipSpecReview, "skip-spec-review", false, "Skip automated spec review loop")
 	cmd.Flags().IntVar(&opts.MaxReviewIter, "max-review-iter", 3, "Max spec review iterations")
 	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show plan without executing")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Start even if PRDs it depends on are not complete")
 
 	return cmd
 }
 
 // RunFeatureStart executes the feature start workflow
 func (a *App) RunFeatureStart(cmd *cobra.Command, opts FeatureStartOptions) error {
+	if err := checkPRDDependencies(cmd, opts); err != nil {
+		return err
+	}
+
 	// If dry-run, call runDryRun and return
 	if opts.DryRun {
 		return a.runDryRun(cmd, opts)
@@ -97,3 +111,69 @@ func (a *App) runDryRun(cmd *cobra.Command, opts FeatureStartOptions) error {
 
 	return nil
 }
+
+// checkPRDDependencies refuses to start a PRD while PRDs it depends on are
+// incomplete, unless forced. A missing PRD directory or PRD is left for the
+// workflow to report.
+func checkPRDDependencies(cmd *cobra.Command, opts FeatureStartOptions) error {
+	if opts.PRDID == "" {
+		return nil
+	}
+	prds, err := feature.DiscoverPRDs(opts.PRDDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	closure := dependencyClosure(prds, opts.PRDID)
+	if len(closure) == 0 {
+		return nil
+	}
+	graph, err := feature.NewGraph(closure)
+	if err != nil {
+		if !opts.Force {
+			return fmt.Errorf("cannot check the dependencies of PRD %s: %w (use --force to start anyway)", opts.PRDID, err)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: cannot check the dependencies of %s: %v\n", opts.PRDID, err)
+		return nil
+	}
+
+	unmet := graph.UnmetDependencies(opts.PRDID)
+	if len(unmet) == 0 {
+		return nil
+	}
+	if !opts.Force {
+		return fmt.Errorf("PRD %s depends on incomplete PRDs: %s (use --force to start anyway)",
+			opts.PRDID, strings.Join(unmet, ", "))
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Warning: starting %s before its dependencies are complete: %s\n",
+		opts.PRDID, strings.Join(unmet, ", "))
+	return nil
+}
+
+// dependencyClosure returns the PRD id and the PRDs it depends on, directly
+// or transitively, so problems in unrelated PRDs do not block it
+func dependencyClosure(prds []*feature.PRD, id string) []*feature.PRD {
+	byID := make(map[string]*feature.PRD, len(prds))
+	for _, prd := range prds {
+		byID[prd.ID] = prd
+	}
+
+	seen := make(map[string]bool)
+	var closure []*feature.PRD
+	var visit func(string)
+	visit = func(id string) {
+		prd := byID[id]
+		if prd == nil || seen[id] {
+			return
+		}
+		seen[id] = true
+		closure = append(closure, prd)
+		for _, dep := range prd.DependsOn {
+			visit(dep)
+		}
+	}
+	visit(id)
+	return closure
+}
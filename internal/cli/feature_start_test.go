@@ -243,3 +243,79 @@ func TestRunFeatureStart_ValidatesInput(t *testing.T) {
 		t.Errorf("Expected 'cannot be empty' error, got: %v", err)
 	}
 }
+
+func TestRunFeatureStart_RefusesIncompleteDependencies(t *testing.T) {
+	app := New()
+
+	prdDir := t.TempDir()
+	writeDependentPRDs(t, prdDir)
+
+	cmd := &cobra.Command{}
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	opts := FeatureStartOptions{PRDID: "billing", PRDDir: prdDir, DryRun: true}
+	err := app.RunFeatureStart(cmd, opts)
+	if err == nil || !strings.Contains(err.Error(), "depends on incomplete PRDs: auth") {
+		t.Fatalf("Expected dependency error, got: %v", err)
+	}
+
+	opts.Force = true
+	if err := app.RunFeatureStart(cmd, opts); err != nil {
+		t.Fatalf("Forced start should pass the dependency check: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Warning: starting billing before its dependencies are complete: auth") {
+		t.Errorf("Expected warning, got: %s", buf.String())
+	}
+}
+
+func TestRunFeatureStart_IgnoresUnrelatedGraphErrors(t *testing.T) {
+	app := New()
+
+	prdDir := t.TempDir()
+	writeDependentPRDs(t, prdDir)
+	broken := "---\nprd_id: search\ntitle: Search\nstatus: approved\ndepends_on:\n  - index\n---\n\n# Search\n"
+	if err := os.WriteFile(filepath.Join(prdDir, "search.md"), []byte(broken), 0644); err != nil {
+		t.Fatalf("Failed to write search.md: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	// search's missing dependency does not concern billing
+	opts := FeatureStartOptions{PRDID: "billing", PRDDir: prdDir, DryRun: true}
+	err := app.RunFeatureStart(cmd, opts)
+	if err == nil || !strings.Contains(err.Error(), "depends on incomplete PRDs: auth") {
+		t.Fatalf("Expected dependency error, got: %v", err)
+	}
+
+	// A broken dependency of the PRD itself is only a warning when forced
+	opts = FeatureStartOptions{PRDID: "search", PRDDir: prdDir, DryRun: true}
+	err = app.RunFeatureStart(cmd, opts)
+	if err == nil || !strings.Contains(err.Error(), `depends on non-existent PRD "index"`) {
+		t.Fatalf("Expected missing dependency error, got: %v", err)
+	}
+	opts.Force = true
+	if err := app.RunFeatureStart(cmd, opts); err != nil {
+		t.Fatalf("Forced start should pass the dependency check: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Warning: cannot check the dependencies of search") {
+		t.Errorf("Expected warning, got: %s", buf.String())
+	}
+}
+
+func writeDependentPRDs(t *testing.T, prdDir string) {
+	t.Helper()
+	files := map[string]string{
+		"auth.md":    "---\nprd_id: auth\ntitle: Auth\nstatus: approved\n---\n\n# Auth\n",
+		"billing.md": "---\nprd_id: billing\ntitle: Billing\nstatus: approved\ndepends_on:\n  - auth\n---\n\n# Billing\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(prdDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package cli
 import (
 	"strings"
 
-	"github.com/RevCBH/choo/internal/client"
 	"github.com/spf13/cobra"
 )
 
@@ -18,9 +17,11 @@ func NewJobsCmd(a *App) *cobra.Command {
 		Long: `List all jobs managed by the daemon.
 
 Use --status to filter by job status (comma-separated values).
-Valid statuses: pending, running, completed, failed`,
+Valid statuses: pending, running, completed, failed
+
+See choo jobs queue for jobs waiting for a free slot.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			c, err := client.New(defaultSocketPath())
+			c, err := a.dialDaemon()
 			if err != nil {
 				return err
 			}
@@ -43,6 +44,11 @@ Valid statuses: pending, running, completed, failed`,
 
 	cmd.Flags().StringVar(&statusFilter, "status", "", "Filter by status (comma-separated)")
 
+	cmd.AddCommand(newJobsQueueCmd(a))
+	cmd.AddCommand(newJobsScheduleCmd(a))
+	cmd.AddCommand(newJobsExportCmd(a))
+	cmd.AddCommand(newJobsImportCmd(a))
+
 	return cmd
 }
 
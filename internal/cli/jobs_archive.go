@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// newJobsExportCmd creates the 'jobs export' command for writing a job's
+// archive
+func newJobsExportCmd(a *App) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export <job-id>",
+		Short: "Write a self-contained archive of a job",
+		Long: `Write a job's archive: its run record, units, event log (as JSONL),
+provider logs and the diffs of its branches, as a gzipped tar.
+
+Load the archive into another daemon with choo jobs import for
+post-mortems.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobID := args[0]
+			if output == "" {
+				output = jobID + ".tar.gz"
+			}
+
+			c, err := a.dialDaemon()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			if output == "-" {
+				return c.ExportJob(cmd.Context(), jobID, cmd.OutOrStdout())
+			}
+
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", output, err)
+			}
+			if err := c.ExportJob(cmd.Context(), jobID, f); err != nil {
+				f.Close()
+				os.Remove(output)
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("failed to write %s: %w", output, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Exported job %s to %s\n", jobID, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Archive path, or - for stdout (default <job-id>.tar.gz)")
+
+	return cmd
+}
+
+// newJobsImportCmd creates the 'jobs import' command for loading a job
+// archive into the daemon
+func newJobsImportCmd(a *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <archive>",
+		Short: "Load a job archive exported by another daemon",
+		Long: `Load a job archive written by choo jobs export. The job appears in
+choo jobs with its units and event log; a job exported while running is
+recorded as cancelled. Its logs and diffs are extracted on the daemon's
+host, under ~/.choo/imports/<job-id>.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open archive: %w", err)
+			}
+			defer f.Close()
+
+			c, err := a.dialDaemon()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			imported, err := c.ImportJob(cmd.Context(), f)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Imported job %s (%d units, %d events)\n", imported.JobID, imported.Units, imported.Events)
+			if imported.FilesDir != "" {
+				fmt.Fprintf(out, "Logs and diffs: %s\n", imported.FilesDir)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJobsCmd_ArchiveSubcommands(t *testing.T) {
+	cmd := NewJobsCmd(New())
+
+	export, _, err := cmd.Find([]string{"export"})
+	if err != nil || export.Name() != "export" {
+		t.Fatalf("Expected jobs export, got %v (%v)", export, err)
+	}
+	if export.Flags().ShorthandLookup("o") == nil {
+		t.Error("Expected export flag -o")
+	}
+
+	imp, _, err := cmd.Find([]string{"import"})
+	if err != nil || imp.Name() != "import" {
+		t.Fatalf("Expected jobs import, got %v (%v)", imp, err)
+	}
+}
+
+func TestJobsImport_MissingArchive(t *testing.T) {
+	cmd := NewJobsCmd(New())
+	cmd.SetArgs([]string{"import", filepath.Join(t.TempDir(), "missing.tar.gz")})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "failed to open archive") {
+		t.Errorf("Expected open error, got %v", err)
+	}
+}
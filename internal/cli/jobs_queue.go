@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/RevCBH/choo/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// newJobsQueueCmd creates the 'jobs queue' command for listing the
+// daemon's job queue
+func newJobsQueueCmd(a *App) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "List jobs waiting in the daemon's queue",
+		Long: `List the daemon's job queue: jobs waiting for a free slot, highest
+priority first, and queued jobs that have started.
+
+Jobs are queued with choo run --queue or by schedules (see choo jobs
+schedule). The queue survives daemon restarts.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.dialDaemon()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			jobs, err := c.ListQueue(cmd.Context(), all)
+			if err != nil {
+				return err
+			}
+			return displayQueue(cmd.OutOrStdout(), jobs)
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include finished and cancelled entries")
+	cmd.AddCommand(newJobsQueueCancelCmd(a))
+
+	return cmd
+}
+
+// newJobsQueueCancelCmd creates the 'jobs queue cancel' command
+func newJobsQueueCancelCmd(a *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Remove a job from the queue before it starts",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.dialDaemon()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			if err := c.CancelQueuedJob(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Cancelled queued job %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+// displayQueue prints queue entries as a table
+func displayQueue(out io.Writer, jobs []*client.QueuedJob) error {
+	if len(jobs) == 0 {
+		fmt.Fprintln(out, "Queue is empty")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tPRIORITY\tJOB\tQUEUED\tDETAIL")
+	for _, q := range jobs {
+		detail := ""
+		switch {
+		case q.Error != "":
+			detail = q.Error
+		case q.JobID != "":
+			detail = "job " + q.JobID
+		case q.Schedule != "":
+			detail = "schedule " + q.Schedule
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n",
+			q.ID, q.Status, q.Priority, describeJobConfig(q.Job), q.QueuedAt.Local().Format("2006-01-02 15:04"), detail)
+	}
+	return w.Flush()
+}
+
+// describeJobConfig summarizes where a job runs and on which branch
+func describeJobConfig(cfg client.JobConfig) string {
+	where := cfg.RepoPath
+	if cfg.Workspace != "" {
+		where = cfg.Workspace
+	}
+	if cfg.FeatureBranch != "" {
+		return where + " (" + cfg.FeatureBranch + ")"
+	}
+	return where
+}
+
+// newJobsScheduleCmd creates the 'jobs schedule' command group
+func newJobsScheduleCmd(a *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage jobs the daemon queues on a schedule",
+		Long: `Manage schedules: jobs the daemon queues at the times given by a cron
+expression, such as a nightly run of the next feature.
+
+Expressions have five fields (minute hour day-of-month month day-of-week)
+in the daemon's local time, or are one of @hourly, @daily, @nightly
+(01:00), @weekly and @monthly.`,
+	}
+
+	cmd.AddCommand(newJobsScheduleAddCmd(a))
+	cmd.AddCommand(newJobsScheduleListCmd(a))
+	cmd.AddCommand(newJobsScheduleRemoveCmd(a))
+
+	return cmd
+}
+
+// newJobsScheduleAddCmd creates the 'jobs schedule add' command
+func newJobsScheduleAddCmd(a *App) *cobra.Command {
+	var (
+		sched    client.Schedule
+		repoPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <name> <cron>",
+		Short: "Queue a job at the times given by a cron expression",
+		Example: `  choo jobs schedule add nightly @nightly --workspace app --feature next
+  choo jobs schedule add weekdays "0 9 * * 1-5" --repo /srv/app --target main`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sched.Name, sched.Cron = args[0], args[1]
+			if (repoPath == "") == (sched.Job.Workspace == "") {
+				return fmt.Errorf("exactly one of --workspace and --repo is required")
+			}
+			if repoPath != "" {
+				if sched.Job.TargetBranch == "" {
+					return fmt.Errorf("--target is required with --repo")
+				}
+				if sched.Job.TasksDir == "" {
+					sched.Job.TasksDir = "specs/tasks"
+				}
+				sched.Job.RepoPath = repoPath
+			}
+
+			c, err := a.dialDaemon()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			added, err := c.AddSchedule(cmd.Context(), sched)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Added schedule %s, next run %s\n",
+				added.Name, added.NextRunAt.Local().Format("2006-01-02 15:04"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&sched.Job.Workspace, "workspace", "", "Workspace to run in (see choo workspace)")
+	cmd.Flags().StringVar(&repoPath, "repo", "", "Repository path on the daemon's host, instead of a workspace")
+	cmd.Flags().StringVar(&sched.Job.TasksDir, "tasks", "", "Tasks directory (default: the workspace's, or specs/tasks)")
+	cmd.Flags().StringVar(&sched.Job.TargetBranch, "target", "", "Branch PRs target (default: the workspace's)")
+	cmd.Flags().StringVar(&sched.Job.FeatureBranch, "feature", "", "PRD ID for feature mode")
+	cmd.Flags().IntVarP(&sched.Job.Parallelism, "parallelism", "p", 0, "Max concurrent units (0 = default)")
+	cmd.Flags().IntVar(&sched.Priority, "priority", 0, "Priority of the queued jobs (higher starts first)")
+
+	return cmd
+}
+
+// newJobsScheduleListCmd creates the 'jobs schedule list' command
+func newJobsScheduleListCmd(a *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List schedules",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.dialDaemon()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			schedules, err := c.ListSchedules(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if len(schedules) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No schedules")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tCRON\tJOB\tPRIORITY\tNEXT RUN\tLAST RUN")
+			for _, s := range schedules {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n",
+					s.Name, s.Cron, describeJobConfig(s.Job), s.Priority, formatScheduleTime(&s.NextRunAt), formatScheduleTime(s.LastRunAt))
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// newJobsScheduleRemoveCmd creates the 'jobs schedule remove' command
+func newJobsScheduleRemoveCmd(a *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a schedule",
+		Long:  "Remove a schedule. Jobs it already queued stay queued.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.dialDaemon()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			if err := c.RemoveSchedule(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed schedule %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+// formatScheduleTime formats a schedule's run time, or "never"
+func formatScheduleTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "never"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/client"
+)
+
+func TestJobsCmd_QueueSubcommands(t *testing.T) {
+	cmd := NewJobsCmd(New())
+
+	queue, _, err := cmd.Find([]string{"queue", "cancel"})
+	if err != nil || queue.Name() != "cancel" {
+		t.Fatalf("Expected jobs queue cancel, got %v (%v)", queue, err)
+	}
+
+	add, _, err := cmd.Find([]string{"schedule", "add"})
+	if err != nil || add.Name() != "add" {
+		t.Fatalf("Expected jobs schedule add, got %v (%v)", add, err)
+	}
+	for _, flag := range []string{"workspace", "repo", "tasks", "target", "feature", "parallelism", "priority"} {
+		if add.Flags().Lookup(flag) == nil {
+			t.Errorf("Expected schedule add flag --%s", flag)
+		}
+	}
+}
+
+func TestJobsScheduleAdd_RequiresOneTarget(t *testing.T) {
+	cmd := NewJobsCmd(New())
+	cmd.SetArgs([]string{"schedule", "add", "nightly", "@nightly"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "exactly one of --workspace and --repo") {
+		t.Errorf("Expected workspace/repo error, got %v", err)
+	}
+}
+
+func TestDisplayQueue(t *testing.T) {
+	var buf bytes.Buffer
+	if err := displayQueue(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Queue is empty") {
+		t.Errorf("Expected empty queue message, got %q", buf.String())
+	}
+
+	buf.Reset()
+	err := displayQueue(&buf, []*client.QueuedJob{
+		{ID: "q1", Status: "started", Priority: 2, JobID: "job-1", Job: client.JobConfig{Workspace: "app", FeatureBranch: "feature/api"}, QueuedAt: time.Now()},
+		{ID: "q2", Status: "queued", Schedule: "nightly", Job: client.JobConfig{RepoPath: "/srv/app"}, QueuedAt: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"app (feature/api)", "job job-1", "/srv/app", "schedule nightly"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunCmd_QueueRequiresDaemon(t *testing.T) {
+	cmd := NewRunCmd(New())
+	cmd.SetArgs([]string{"--queue", "--use-daemon=false"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--queue requires daemon mode") {
+		t.Errorf("Expected daemon mode error, got %v", err)
+	}
+}
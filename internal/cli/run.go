@@ -14,13 +14,15 @@ import (
 	"github.com/RevCBH/choo/internal/escalate"
 	"github.com/RevCBH/choo/internal/events"
 	"github.com/RevCBH/choo/internal/feature"
+	"github.com/RevCBH/choo/internal/forge"
 	"github.com/RevCBH/choo/internal/git"
-	"github.com/RevCBH/choo/internal/github"
 	"github.com/RevCBH/choo/internal/orchestrator"
 	"github.com/RevCBH/choo/internal/web"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // RunOptions holds flags for the run command
@@ -39,7 +41,11 @@ type RunOptions struct {
 	NoTUI        bool   // Disable TUI even when stdout is a TTY
 	Feature      string // PRD ID to work on in feature mode
 	UseDaemon    bool   // Use daemon mode
+	Workspace    string // Run in a workspace registered with the daemon
+	Queue        bool   // Queue the job in the daemon instead of starting it
+	Priority     int    // Priority of a queued job; higher starts first
 	Force        bool   // Force run even with uncommitted changes
+	Stacked      bool   // Open a stacked PR per unit instead of one feature PR
 
 	// Provider is the default provider for task execution
 	// Units without frontmatter override use this provider
@@ -74,42 +80,54 @@ func (opts RunOptions) Validate() error {
 	return nil
 }
 
-// runWithDaemon executes a job via the daemon and attaches to event stream.
-// If the daemon is not running, it will be started automatically.
-func runWithDaemon(ctx context.Context, tasksDir string, parallelism int, target, feature string) error {
+// connectForJob connects to the daemon for a job, starting a local daemon
+// if it is not running. Without a workspace, the job runs in the
+// repository at the working directory's path on the daemon's host.
+func (a *App) connectForJob(cfg *client.JobConfig) (*client.Client, error) {
 	// Auto-start daemon if not running
-	if !isDaemonRunning() {
+	if !a.isRemoteDaemon() && !isDaemonRunning() {
 		fmt.Println("Starting daemon...")
 		if err := startDaemonBackground(DaemonStartOptions{}); err != nil {
-			return fmt.Errorf("failed to start daemon: %w", err)
+			return nil, fmt.Errorf("failed to start daemon: %w", err)
 		}
 		// Give daemon a moment to initialize
 		time.Sleep(500 * time.Millisecond)
 	}
 
-	c, err := client.New(defaultSocketPath())
+	c, err := a.dialDaemon()
 	if err != nil {
-		return fmt.Errorf("failed to connect to daemon: %w", err)
+		return nil, fmt.Errorf("failed to connect to daemon: %w", err)
 	}
-	defer c.Close()
 
-	repoPath, err := os.Getwd()
+	if cfg.Workspace == "" {
+		repoPath, err := os.Getwd()
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+		cfg.RepoPath = repoPath
+	}
+	return c, nil
+}
+
+// runWithDaemon executes a job via the daemon and attaches to event stream.
+// If the local daemon is not running, it will be started automatically.
+func (a *App) runWithDaemon(ctx context.Context, cfg client.JobConfig) error {
+	c, err := a.connectForJob(&cfg)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
+	defer c.Close()
 
-	jobID, err := c.StartJob(ctx, client.JobConfig{
-		TasksDir:      tasksDir,
-		TargetBranch:  target,
-		FeatureBranch: feature,
-		Parallelism:   parallelism,
-		RepoPath:      repoPath,
-	})
+	jobID, err := c.StartJob(ctx, cfg)
 	if err != nil {
 		// Check if this is a connection error and provide helpful message
 		if strings.Contains(err.Error(), "connection error") || strings.Contains(err.Error(), "connect:") {
 			return fmt.Errorf("failed to connect to daemon: %w (is daemon running?)", err)
 		}
+		if status.Code(err) == codes.ResourceExhausted {
+			return fmt.Errorf("%w (use --queue to start it when a slot frees up)", err)
+		}
 		return err
 	}
 
@@ -119,6 +137,24 @@ func runWithDaemon(ctx context.Context, tasksDir string, parallelism int, target
 	return c.WatchJob(ctx, jobID, 0, displayEvent)
 }
 
+// queueWithDaemon adds a job to the daemon's queue and returns without
+// waiting for it to start.
+func (a *App) queueWithDaemon(ctx context.Context, cfg client.JobConfig, priority int) error {
+	c, err := a.connectForJob(&cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	queued, err := c.QueueJob(ctx, cfg, priority)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Queued job %s (priority %d); see choo jobs queue\n", queued.ID, queued.Priority)
+	return nil
+}
+
 // runInline executes jobs directly without daemon (existing behavior)
 func runInline(ctx context.Context, opts RunOptions, app *App) error {
 	// This will contain the existing inline execution logic
@@ -131,6 +167,7 @@ func registerRunFlags(cmd *cobra.Command, opts *RunOptions) {
 	cmd.Flags().StringVarP(&opts.TargetBranch, "target", "t", opts.TargetBranch, "Branch PRs target (default: current branch)")
 	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "n", opts.DryRun, "Show execution plan without running")
 	cmd.Flags().BoolVar(&opts.NoPR, "no-pr", opts.NoPR, "Skip PR creation")
+	cmd.Flags().BoolVar(&opts.Stacked, "stacked", opts.Stacked, "Open a PR per unit, stacked on its dependencies (overrides pull_requests.mode)")
 	cmd.Flags().StringVar(&opts.Unit, "unit", opts.Unit, "Run only specified unit (single-unit mode)")
 	cmd.Flags().BoolVar(&opts.SkipReview, "skip-review", opts.SkipReview, "Auto-merge without waiting for review")
 	cmd.Flags().StringVar(&opts.TasksDir, "tasks", opts.TasksDir, "Path to tasks directory")
@@ -143,6 +180,9 @@ func registerRunFlags(cmd *cobra.Command, opts *RunOptions) {
 	cmd.Flags().BoolVar(&opts.NoTUI, "no-tui", opts.NoTUI, "Disable interactive TUI (use summary-only output)")
 	cmd.Flags().StringVar(&opts.Feature, "feature", opts.Feature, "PRD ID for feature mode (targets feature branch)")
 	cmd.Flags().BoolVar(&opts.UseDaemon, "use-daemon", opts.UseDaemon, "Use daemon mode")
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", opts.Workspace, "Run in a workspace registered with the daemon (see choo workspace)")
+	cmd.Flags().BoolVar(&opts.Queue, "queue", opts.Queue, "Queue the job in the daemon; it starts when a slot is free")
+	cmd.Flags().IntVar(&opts.Priority, "priority", opts.Priority, "Priority of a queued job (higher starts first)")
 	cmd.Flags().StringVar(&opts.Provider, "provider", opts.Provider, "Default provider for task execution (claude, codex). Units without frontmatter override use this.")
 	cmd.Flags().StringVar(&opts.ForceTaskProvider, "force-task-provider", opts.ForceTaskProvider, "Force provider for ALL task execution, ignoring per-unit frontmatter (claude, codex)")
 }
@@ -177,9 +217,36 @@ Use --unit to run a single unit, or --dry-run to preview execution plan.`,
 				opts.TasksDir = args[0]
 			}
 
+			if opts.Queue && (!opts.UseDaemon || opts.DryRun) {
+				return fmt.Errorf("--queue requires daemon mode and cannot be combined with --dry-run")
+			}
+
 			// Create context
 			ctx := context.Background()
 
+			// A workspace job runs in the daemon's clone, so the local
+			// checkout is not checked. Unset flags take the workspace's
+			// defaults.
+			if opts.Workspace != "" {
+				if !opts.UseDaemon {
+					return fmt.Errorf("--workspace requires daemon mode")
+				}
+				jobCfg := client.JobConfig{Workspace: opts.Workspace, FeatureBranch: opts.Feature}
+				if len(args) > 0 || cmd.Flags().Changed("tasks") {
+					jobCfg.TasksDir = opts.TasksDir
+				}
+				if cmd.Flags().Changed("target") {
+					jobCfg.TargetBranch = opts.TargetBranch
+				}
+				if cmd.Flags().Changed("parallelism") {
+					jobCfg.Parallelism = opts.Parallelism
+				}
+				if opts.Queue {
+					return app.queueWithDaemon(ctx, jobCfg, opts.Priority)
+				}
+				return app.runWithDaemon(ctx, jobCfg)
+			}
+
 			// Get working directory
 			wd, err := os.Getwd()
 			if err != nil {
@@ -236,7 +303,16 @@ Use --unit to run a single unit, or --dry-run to preview execution plan.`,
 
 			// Dispatch based on mode
 			if opts.UseDaemon {
-				return runWithDaemon(ctx, opts.TasksDir, opts.Parallelism, opts.TargetBranch, opts.Feature)
+				jobCfg := client.JobConfig{
+					TasksDir:      opts.TasksDir,
+					TargetBranch:  opts.TargetBranch,
+					FeatureBranch: opts.Feature,
+					Parallelism:   opts.Parallelism,
+				}
+				if opts.Queue {
+					return app.queueWithDaemon(ctx, jobCfg, opts.Priority)
+				}
+				return app.runWithDaemon(ctx, jobCfg)
 			}
 			return runInline(ctx, opts, app)
 		},
@@ -328,8 +404,8 @@ func (a *App) RunOrchestrator(ctx context.Context, opts RunOptions) error {
 	// Create Git WorktreeManager
 	gitManager := git.NewWorktreeManager(wd, nil)
 
-	// Create GitHub PRClient (only if not dry-run, as it requires GitHub config)
-	var ghClient *github.PRClient
+	// Create the forge client (only if not dry-run, as it requires a token)
+	var forgeClient forge.Forge
 	if !opts.DryRun {
 		pollInterval, err := cfg.ReviewPollIntervalDuration()
 		if err != nil {
@@ -339,14 +415,18 @@ func (a *App) RunOrchestrator(ctx context.Context, opts RunOptions) error {
 		if err != nil {
 			return fmt.Errorf("invalid review timeout: %w", err)
 		}
-		ghClient, err = github.NewPRClient(github.PRClientConfig{
+		forgeClient, err = forge.New(forge.Config{
+			Type:          cfg.Forge.Type,
+			URL:           cfg.Forge.URL,
+			TokenEnv:      cfg.Forge.TokenEnv,
 			Owner:         cfg.GitHub.Owner,
 			Repo:          cfg.GitHub.Repo,
 			PollInterval:  pollInterval,
 			ReviewTimeout: reviewTimeout,
+			Review:        cfg.Review,
 		})
 		if err != nil {
-			return fmt.Errorf("failed to create GitHub client: %w", err)
+			return fmt.Errorf("failed to create %s client: %w", cfg.Forge.Type, err)
 		}
 	}
 
@@ -370,6 +450,14 @@ func (a *App) RunOrchestrator(ctx context.Context, opts RunOptions) error {
 		ForceTaskProvider: opts.ForceTaskProvider,
 		ProviderConfig:    cfg.Provider,
 		ClaudeCommand:     config.GetProviderCommand(cfg, config.ProviderClaude),
+		BaselineChecks:    cfg.QueueBaselineChecks(),
+		MergeQueue:        cfg.Merge.Queue,
+		Conflicts:         cfg.Conflicts,
+		PullRequests:      cfg.PullRequests,
+		CI:                cfg.CI,
+	}
+	if opts.Stacked {
+		orchCfg.PullRequests.Mode = config.PRModeStacked
 	}
 
 	// Configure feature mode if --feature flag provided
@@ -391,6 +479,11 @@ func (a *App) RunOrchestrator(ctx context.Context, opts RunOptions) error {
 				return fmt.Errorf("creating feature branch: %w", err)
 			}
 		}
+
+		// Report progress on the issue the PRD was imported from
+		if !opts.DryRun {
+			defer trackFeatureIssue(cfg, wd, opts.Feature, eventBus)()
+		}
 	}
 
 	// Create orchestrator
@@ -398,7 +491,7 @@ func (a *App) RunOrchestrator(ctx context.Context, opts RunOptions) error {
 		Bus:       eventBus,
 		Escalator: esc,
 		Git:       gitManager,
-		GitHub:    ghClient,
+		Forge:     forgeClient,
 	})
 	defer orch.Close()
 
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/RevCBH/choo/internal/client"
 )
 
 func TestRunCmd_DefaultFlags(t *testing.T) {
@@ -434,7 +436,7 @@ func TestRunWithDaemon_AutoStartsDaemon(t *testing.T) {
 	// runWithDaemon should attempt to auto-start the daemon
 	// In test environment, daemon start may fail (no proper environment)
 	// but we verify the attempt is made by checking the error message
-	err := runWithDaemon(ctx, "specs/tasks", 4, "main", "")
+	err := New().runWithDaemon(ctx, client.JobConfig{TasksDir: "specs/tasks", Parallelism: 4, TargetBranch: "main"})
 
 	if err == nil {
 		// If no error, daemon was successfully started - clean up
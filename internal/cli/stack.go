@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/forge"
+	"github.com/RevCBH/choo/internal/git"
+	"github.com/RevCBH/choo/internal/stack"
+	"github.com/spf13/cobra"
+)
+
+// StackOptions holds flags shared by the stack subcommands
+type StackOptions struct {
+	Base  string        // Root branch of the stack (default: the only stack, or current branch)
+	Watch time.Duration // Restack repeatedly at this interval (restack only)
+}
+
+// NewStackCmd creates the stack parent command
+func NewStackCmd(app *App) *cobra.Command {
+	opts := StackOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "stack",
+		Short: "Manage stacked per-unit pull requests",
+		Long: `Manage the per-unit pull requests opened in stacked mode
+(pull_requests.mode: stacked or run --stacked).
+
+Each unit's PR targets its dependency's branch. After a lower PR changes,
+restack rebases the PRs above it; merge lands the PRs bottom-up in
+dependency order.`,
+	}
+	cmd.PersistentFlags().StringVar(&opts.Base, "base", "", "Root branch of the stack (default: the only stack, or current branch)")
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the PRs in the stack",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.StackStatus(cmd.Context(), opts)
+		},
+	}
+
+	restackCmd := &cobra.Command{
+		Use:   "restack",
+		Short: "Rebase PRs whose base branch changed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.StackRestack(cmd.Context(), opts)
+		},
+	}
+	restackCmd.Flags().DurationVar(&opts.Watch, "watch", 0, "Keep restacking at this interval (e.g. 1m)")
+
+	mergeCmd := &cobra.Command{
+		Use:   "merge",
+		Short: "Merge PRs bottom-up in dependency order",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.StackMerge(cmd.Context(), opts)
+		},
+	}
+
+	cmd.AddCommand(statusCmd, restackCmd, mergeCmd)
+	return cmd
+}
+
+// StackStatus prints each unit's branch, base and PR
+func (a *App) StackStatus(ctx context.Context, opts StackOptions) error {
+	_, _, path, err := resolveStack(ctx, opts.Base)
+	if err != nil {
+		return err
+	}
+	state, err := stack.LoadState(path)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return fmt.Errorf("no stack found at %s", path)
+	}
+
+	fmt.Printf("Stack on %s\n\n", state.Root)
+	for _, e := range state.Entries {
+		status := "open"
+		if e.Merged {
+			status = "merged"
+		}
+		fmt.Printf("  %-20s #%-5d %-7s %s -> %s\n", e.Unit, e.PR, status, e.Branch, e.Base)
+	}
+	return nil
+}
+
+// StackRestack rebases stack branches onto their updated bases
+func (a *App) StackRestack(ctx context.Context, opts StackOptions) error {
+	s, err := openStack(ctx, opts.Base)
+	if err != nil {
+		return err
+	}
+
+	for {
+		restacked, err := s.Restack(ctx)
+		if err != nil {
+			return err
+		}
+		if len(restacked) > 0 {
+			fmt.Printf("Restacked: %s\n", strings.Join(restacked, ", "))
+		} else if opts.Watch == 0 {
+			fmt.Println("Stack is up to date")
+		}
+
+		if opts.Watch == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Watch):
+		}
+	}
+}
+
+// StackMerge merges the stack's PRs in topological order
+func (a *App) StackMerge(ctx context.Context, opts StackOptions) error {
+	s, err := openStack(ctx, opts.Base)
+	if err != nil {
+		return err
+	}
+
+	merged, err := s.Merge(ctx)
+	for _, unit := range merged {
+		fmt.Printf("Merged %s\n", unit)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, e := range s.State().Entries {
+		if !e.Merged {
+			fmt.Printf("%s (#%d) is waiting on its dependencies\n", e.Unit, e.PR)
+		}
+	}
+	return nil
+}
+
+// resolveStack finds the stack state file for base. With no base, the only
+// stored stack is used, falling back to the current branch.
+func resolveStack(ctx context.Context, base string) (cfg *config.Config, repoRoot, path string, err error) {
+	repoRoot, err = os.Getwd()
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	cfg, err = config.LoadConfig(repoRoot)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if base == "" {
+		paths, err := stack.ListStates(cfg.Worktree.BasePath)
+		if err != nil {
+			return nil, "", "", err
+		}
+		if len(paths) == 1 {
+			return cfg, repoRoot, paths[0], nil
+		}
+		if base, err = git.GetCurrentBranch(ctx, repoRoot); err != nil {
+			return nil, "", "", fmt.Errorf("failed to detect current branch (use --base): %w", err)
+		}
+	}
+	return cfg, repoRoot, stack.StatePath(cfg.Worktree.BasePath, base), nil
+}
+
+// openStack opens an existing stack with a forge client for PR updates
+func openStack(ctx context.Context, base string) (*stack.Stack, error) {
+	cfg, repoRoot, path, err := resolveStack(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	state, err := stack.LoadState(path)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("no stack found at %s", path)
+	}
+
+	prs, err := forge.New(forge.Config{
+		Type:     cfg.Forge.Type,
+		URL:      cfg.Forge.URL,
+		TokenEnv: cfg.Forge.TokenEnv,
+		Owner:    cfg.GitHub.Owner,
+		Repo:     cfg.GitHub.Repo,
+		Review:   cfg.Review,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s client: %w", cfg.Forge.Type, err)
+	}
+
+	return stack.Open(ctx, stack.Config{
+		RepoRoot:     repoRoot,
+		WorktreeBase: cfg.Worktree.BasePath,
+		Root:         state.Root,
+		BranchPrefix: cfg.PullRequests.BranchPrefix,
+	}, prs, nil)
+}
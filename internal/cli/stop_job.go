@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/RevCBH/choo/internal/client"
 	"github.com/spf13/cobra"
 )
 
@@ -24,7 +23,7 @@ Use --force to immediately terminate the job without waiting.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jobID := args[0]
-			return stopJob(cmd.Context(), jobID, force)
+			return a.stopJob(cmd.Context(), jobID, force)
 		},
 	}
 
@@ -34,8 +33,8 @@ Use --force to immediately terminate the job without waiting.`,
 }
 
 // stopJob connects to the daemon and stops the specified job
-func stopJob(ctx context.Context, jobID string, force bool) error {
-	c, err := client.New(defaultSocketPath())
+func (a *App) stopJob(ctx context.Context, jobID string, force bool) error {
+	c, err := a.dialDaemon()
 	if err != nil {
 		return err
 	}
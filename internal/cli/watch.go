@@ -3,7 +3,6 @@ package cli
 import (
 	"context"
 
-	"github.com/RevCBH/choo/internal/client"
 	"github.com/spf13/cobra"
 )
 
@@ -23,7 +22,7 @@ reconnection after network interruption without missing events.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jobID := args[0]
-			return watchJob(cmd.Context(), jobID, fromSequence)
+			return a.watchJob(cmd.Context(), jobID, fromSequence)
 		},
 	}
 
@@ -33,8 +32,8 @@ reconnection after network interruption without missing events.`,
 }
 
 // watchJob connects to the daemon and streams events for the specified job
-func watchJob(ctx context.Context, jobID string, fromSequence int) error {
-	c, err := client.New(defaultSocketPath())
+func (a *App) watchJob(ctx context.Context, jobID string, fromSequence int) error {
+	c, err := a.dialDaemon()
 	if err != nil {
 		return err
 	}
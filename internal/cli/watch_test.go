@@ -71,7 +71,7 @@ func TestWatchJob_ContextCancellation(t *testing.T) {
 	// 1. Return an error immediately (can't connect to daemon)
 	// 2. Return when context is cancelled
 	// Either behavior is acceptable for this test
-	err := watchJob(ctx, "test-job-id", 0)
+	err := New().watchJob(ctx, "test-job-id", 0)
 
 	// We expect an error (either connection error or context cancellation)
 	if err == nil {
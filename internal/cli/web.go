@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -13,10 +14,11 @@ import (
 )
 
 // NewWebCmd creates the web command.
-// Usage: choo web [--port PORT] [--socket PATH]
+// Usage: choo web [--port PORT] [--socket PATH] [--prd-dir DIR]
 func NewWebCmd(app *App) *cobra.Command {
 	var port string
 	var socketPath string
+	var prdDir string
 
 	cmd := &cobra.Command{
 		Use:   "web",
@@ -26,15 +28,21 @@ func NewWebCmd(app *App) *cobra.Command {
 The server receives events from 'choo run' via Unix socket and
 broadcasts them to connected browsers via Server-Sent Events.
 
-Open http://localhost:8080 in your browser to view the dashboard.
+Open http://localhost:8080 in your browser to view the dashboard. It also
+shows a roadmap of the PRDs in --prd-dir by status.
 
 Press Ctrl+C to stop the server.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			addr := ":" + port
 
+			if abs, err := filepath.Abs(prdDir); err == nil {
+				prdDir = abs
+			}
+
 			cfg := web.Config{
 				Addr:       addr,
 				SocketPath: socketPath,
+				PRDDir:     prdDir,
 			}
 
 			srv, err := web.New(cfg)
@@ -72,6 +80,7 @@ Press Ctrl+C to stop the server.`,
 
 	cmd.Flags().StringVar(&port, "port", "8080", "HTTP port to listen on")
 	cmd.Flags().StringVar(&socketPath, "socket", "", "Unix socket path (default ~/.choo/web.sock)")
+	cmd.Flags().StringVar(&prdDir, "prd-dir", "docs/prd", "PRDs directory shown on the roadmap")
 
 	return cmd
 }
@@ -7,8 +7,8 @@ import (
 	"github.com/RevCBH/choo/internal/config"
 	"github.com/RevCBH/choo/internal/discovery"
 	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/forge"
 	"github.com/RevCBH/choo/internal/git"
-	"github.com/RevCBH/choo/internal/github"
 	"github.com/RevCBH/choo/internal/scheduler"
 	"github.com/RevCBH/choo/internal/worker"
 	"gopkg.in/yaml.v3"
@@ -22,7 +22,7 @@ type Orchestrator struct {
 	Scheduler *scheduler.Scheduler
 	Workers   *worker.Pool
 	Git       *git.WorktreeManager
-	GitHub    *github.PRClient
+	Forge     forge.Forge
 }
 
 // WireOrchestrator assembles all components for orchestration
@@ -46,17 +46,21 @@ func WireOrchestrator(cfg *config.Config) (*Orchestrator, error) {
 	// Create Git WorktreeManager
 	gitManager := git.NewWorktreeManager(wd, nil)
 
-	// Create GitHub PRClient
+	// Create the forge client
 	pollInterval, _ := cfg.ReviewPollIntervalDuration()
 	reviewTimeout, _ := cfg.ReviewTimeoutDuration()
-	ghClient, err := github.NewPRClient(github.PRClientConfig{
+	forgeClient, err := forge.New(forge.Config{
+		Type:          cfg.Forge.Type,
+		URL:           cfg.Forge.URL,
+		TokenEnv:      cfg.Forge.TokenEnv,
 		Owner:         cfg.GitHub.Owner,
 		Repo:          cfg.GitHub.Repo,
 		PollInterval:  pollInterval,
 		ReviewTimeout: reviewTimeout,
+		Review:        cfg.Review,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+		return nil, fmt.Errorf("failed to create %s client: %w", cfg.Forge.Type, err)
 	}
 
 	// Create Scheduler (depends on event bus)
@@ -72,7 +76,7 @@ func WireOrchestrator(cfg *config.Config) (*Orchestrator, error) {
 		Scheduler: sched,
 		Workers:   workers,
 		Git:       gitManager,
-		GitHub:    ghClient,
+		Forge:     forgeClient,
 	}, nil
 }
 
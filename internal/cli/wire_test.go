@@ -46,7 +46,7 @@ func TestWireOrchestrator_AllComponents(t *testing.T) {
 	if orch.Git == nil {
 		t.Error("Git is nil")
 	}
-	if orch.GitHub == nil {
+	if orch.Forge == nil {
 		t.Error("GitHub is nil")
 	}
 }
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/RevCBH/choo/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspaceCmd creates the 'workspace' command group for managing the
+// repositories registered with the daemon
+func NewWorkspaceCmd(a *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Manage repositories registered with the daemon",
+		Long: `Manage workspaces: repositories the daemon clones and runs jobs in.
+
+The daemon keeps its own clone of each workspace under ~/.choo/workspaces
+on its host and fetches it before every job, so jobs can be started by
+name from any client with choo run --workspace <name>.`,
+	}
+
+	cmd.AddCommand(newWorkspaceAddCmd(a))
+	cmd.AddCommand(newWorkspaceListCmd(a))
+	cmd.AddCommand(newWorkspaceRemoveCmd(a))
+
+	return cmd
+}
+
+// newWorkspaceAddCmd creates the 'workspace add' command
+func newWorkspaceAddCmd(a *App) *cobra.Command {
+	var ws client.Workspace
+
+	cmd := &cobra.Command{
+		Use:   "add <name> <repo-url>",
+		Short: "Register a repository and clone it on the daemon's host",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ws.Name, ws.RepoURL = args[0], args[1]
+
+			c, err := a.dialDaemon()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			added, err := c.AddWorkspace(cmd.Context(), ws)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Added workspace %s (%s, tasks in %s), cloned to %s\n",
+				added.Name, added.TargetBranch, added.TasksDir, added.MirrorPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&ws.TargetBranch, "target", "", "Default branch PRs target (default: the repository's default branch)")
+	cmd.Flags().StringVar(&ws.TasksDir, "tasks", "", "Default tasks directory (default: specs/tasks)")
+	cmd.Flags().IntVarP(&ws.Parallelism, "parallelism", "p", 0, "Default max concurrent units per job (0 = daemon default)")
+	cmd.Flags().IntVar(&ws.MaxJobs, "max-jobs", 0, "Max concurrent jobs in this workspace (0 = daemon's --max-repo-jobs)")
+
+	return cmd
+}
+
+// newWorkspaceListCmd creates the 'workspace list' command
+func newWorkspaceListCmd(a *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List registered workspaces",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.dialDaemon()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			workspaces, err := c.ListWorkspaces(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if len(workspaces) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No workspaces")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tREPOSITORY\tTARGET\tTASKS\tMAX JOBS\tFETCHED")
+			for _, ws := range workspaces {
+				maxJobs := "-"
+				if ws.MaxJobs > 0 {
+					maxJobs = fmt.Sprint(ws.MaxJobs)
+				}
+				fetched := "never"
+				if ws.FetchedAt != nil {
+					fetched = ws.FetchedAt.Local().Format("2006-01-02 15:04")
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", ws.Name, ws.RepoURL, ws.TargetBranch, ws.TasksDir, maxJobs, fetched)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// newWorkspaceRemoveCmd creates the 'workspace remove' command
+func newWorkspaceRemoveCmd(a *App) *cobra.Command {
+	var deleteClone bool
+
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Unregister a workspace",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.dialDaemon()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			if err := c.RemoveWorkspace(cmd.Context(), args[0], deleteClone); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed workspace %s\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&deleteClone, "delete-clone", false, "Also delete the daemon's clone")
+
+	return cmd
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewWorkspaceCmd(t *testing.T) {
+	cmd := NewWorkspaceCmd(New())
+
+	uses := make(map[string]bool)
+	for _, sub := range cmd.Commands() {
+		uses[strings.Fields(sub.Use)[0]] = true
+	}
+	for _, name := range []string{"add", "list", "remove"} {
+		if !uses[name] {
+			t.Errorf("Expected subcommand %q", name)
+		}
+	}
+
+	add, _, err := cmd.Find([]string{"add"})
+	if err != nil {
+		t.Fatalf("Find add: %v", err)
+	}
+	if err := add.Args(add, []string{"app"}); err == nil {
+		t.Error("Expected add to require a name and a repository URL")
+	}
+	for _, flag := range []string{"target", "tasks", "parallelism", "max-jobs"} {
+		if add.Flags().Lookup(flag) == nil {
+			t.Errorf("Expected add flag --%s", flag)
+		}
+	}
+}
+
+func TestRunCmd_WorkspaceRequiresDaemon(t *testing.T) {
+	cmd := NewRunCmd(New())
+	cmd.SetArgs([]string{"--workspace", "app", "--use-daemon=false"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "requires daemon mode") {
+		t.Errorf("Expected daemon mode error, got %v", err)
+	}
+}
@@ -117,6 +117,172 @@ func (c *Client) Shutdown(ctx context.Context, waitForJobs bool, timeout int) er
 	return err
 }
 
+// RunGC asks the daemon to garbage collect worktrees of finished runs.
+// If dryRun is true, the returned report lists what would be removed
+// without deleting anything.
+func (c *Client) RunGC(ctx context.Context, dryRun bool) (*GCReport, error) {
+	resp, err := c.daemon.RunGC(ctx, &apiv1.RunGCRequest{DryRun: dryRun})
+	if err != nil {
+		return nil, err
+	}
+	return protoToGCReport(resp), nil
+}
+
+// AddWorkspace registers a workspace with the daemon, which clones its
+// repository. The returned workspace includes the defaults the daemon
+// filled in.
+func (c *Client) AddWorkspace(ctx context.Context, ws Workspace) (*Workspace, error) {
+	resp, err := c.daemon.AddWorkspace(ctx, &apiv1.AddWorkspaceRequest{Workspace: workspaceToProto(ws)})
+	if err != nil {
+		return nil, err
+	}
+	return protoToWorkspace(resp.GetWorkspace()), nil
+}
+
+// ListWorkspaces returns the workspaces registered with the daemon.
+func (c *Client) ListWorkspaces(ctx context.Context) ([]*Workspace, error) {
+	resp, err := c.daemon.ListWorkspaces(ctx, &apiv1.ListWorkspacesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	workspaces := make([]*Workspace, len(resp.GetWorkspaces()))
+	for i, ws := range resp.GetWorkspaces() {
+		workspaces[i] = protoToWorkspace(ws)
+	}
+	return workspaces, nil
+}
+
+// RemoveWorkspace unregisters a workspace. If deleteClone is true, the
+// daemon also deletes its clone.
+func (c *Client) RemoveWorkspace(ctx context.Context, name string, deleteClone bool) error {
+	_, err := c.daemon.RemoveWorkspace(ctx, &apiv1.RemoveWorkspaceRequest{Name: name, DeleteClone: deleteClone})
+	return err
+}
+
+// QueueJob adds a job to the daemon's queue. It starts as soon as a slot
+// is free; higher priorities start first.
+func (c *Client) QueueJob(ctx context.Context, cfg JobConfig, priority int) (*QueuedJob, error) {
+	resp, err := c.daemon.QueueJob(ctx, &apiv1.QueueJobRequest{
+		Job:      jobConfigToProto(cfg),
+		Priority: int32(priority),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return protoToQueuedJob(resp.GetQueued()), nil
+}
+
+// ListQueue returns the queued and started jobs in the daemon's queue.
+// If includeFinished is true, finished and cancelled entries are included.
+func (c *Client) ListQueue(ctx context.Context, includeFinished bool) ([]*QueuedJob, error) {
+	resp, err := c.daemon.ListQueue(ctx, &apiv1.ListQueueRequest{IncludeFinished: includeFinished})
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]*QueuedJob, len(resp.GetJobs()))
+	for i, q := range resp.GetJobs() {
+		jobs[i] = protoToQueuedJob(q)
+	}
+	return jobs, nil
+}
+
+// CancelQueuedJob removes a job from the daemon's queue before it starts.
+func (c *Client) CancelQueuedJob(ctx context.Context, id string) error {
+	_, err := c.daemon.CancelQueuedJob(ctx, &apiv1.CancelQueuedJobRequest{Id: id})
+	return err
+}
+
+// AddSchedule adds a schedule that queues its job whenever its cron
+// expression comes due. The returned schedule includes its next run.
+func (c *Client) AddSchedule(ctx context.Context, s Schedule) (*Schedule, error) {
+	resp, err := c.daemon.AddSchedule(ctx, &apiv1.AddScheduleRequest{Schedule: scheduleToProto(s)})
+	if err != nil {
+		return nil, err
+	}
+	return protoToSchedule(resp.GetSchedule()), nil
+}
+
+// ListSchedules returns the daemon's schedules.
+func (c *Client) ListSchedules(ctx context.Context) ([]*Schedule, error) {
+	resp, err := c.daemon.ListSchedules(ctx, &apiv1.ListSchedulesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]*Schedule, len(resp.GetSchedules()))
+	for i, s := range resp.GetSchedules() {
+		schedules[i] = protoToSchedule(s)
+	}
+	return schedules, nil
+}
+
+// RemoveSchedule deletes a schedule. Jobs it already queued stay queued.
+func (c *Client) RemoveSchedule(ctx context.Context, name string) error {
+	_, err := c.daemon.RemoveSchedule(ctx, &apiv1.RemoveScheduleRequest{Name: name})
+	return err
+}
+
+// ExportJob writes the archive of a job to w: a gzipped tar with its run,
+// units, event log, provider logs and diffs.
+func (c *Client) ExportJob(ctx context.Context, jobID string, w io.Writer) error {
+	stream, err := c.daemon.ExportJob(ctx, &apiv1.ExportJobRequest{JobId: jobID})
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return err
+		}
+	}
+}
+
+// ImportJob streams a job archive written by ExportJob to the daemon,
+// which records the job alongside its own.
+func (c *Client) ImportJob(ctx context.Context, r io.Reader) (*ImportedJob, error) {
+	stream, err := c.daemon.ImportJob(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&apiv1.JobArchiveChunk{Data: buf[:n]}); sendErr != nil {
+				// The daemon's error is reported by CloseAndRecv
+				if sendErr == io.EOF {
+					break
+				}
+				return nil, sendErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+	return &ImportedJob{
+		JobID:    resp.GetJobId(),
+		Units:    int(resp.GetUnits()),
+		Events:   int(resp.GetEvents()),
+		FilesDir: resp.GetFilesDir(),
+	}, nil
+}
+
 // WatchJob streams job events, calling handler for each event received.
 // The method blocks until the job completes (returns nil), the context
 // is cancelled (returns context error), or an error occurs.
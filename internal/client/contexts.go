@@ -0,0 +1,135 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocalContext names the daemon on this host, reached over its Unix socket
+const LocalContext = "local"
+
+// Context is a profile for reaching a remote daemon
+type Context struct {
+	// Address is the daemon's TCP listener, host:port
+	Address string `yaml:"address"`
+
+	// CACert, ClientCert and ClientKey are PEM files; see RemoteOptions
+	CACert     string `yaml:"ca_cert,omitempty"`
+	ClientCert string `yaml:"client_cert,omitempty"`
+	ClientKey  string `yaml:"client_key,omitempty"`
+
+	// TokenEnv names the environment variable holding the bearer token
+	TokenEnv string `yaml:"token_env,omitempty"`
+
+	// ServerName overrides the name the daemon's certificate is verified against
+	ServerName string `yaml:"server_name,omitempty"`
+}
+
+// Contexts is the contexts file (~/.choo/contexts.yaml): the known remote
+// daemons and which one commands target
+type Contexts struct {
+	Current  string              `yaml:"current,omitempty"`
+	Contexts map[string]*Context `yaml:"contexts,omitempty"`
+}
+
+// DefaultContextsPath returns the standard contexts file location
+func DefaultContextsPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".choo", "contexts.yaml")
+}
+
+// LoadContexts reads the contexts file at path. A missing file has no
+// contexts and targets the local daemon.
+func LoadContexts(path string) (*Contexts, error) {
+	c := &Contexts{Contexts: map[string]*Context{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contexts: %w", err)
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if c.Contexts == nil {
+		c.Contexts = map[string]*Context{}
+	}
+	return c, nil
+}
+
+// Save writes the contexts file to path
+func (c *Contexts) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal contexts: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write contexts: %w", err)
+	}
+	return nil
+}
+
+// Names returns the context names in sorted order, local first
+func (c *Contexts) Names() []string {
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{LocalContext}, names...)
+}
+
+// Resolve returns the context called name, or the current context if name
+// is empty. A nil context means the local daemon.
+func (c *Contexts) Resolve(name string) (string, *Context, error) {
+	if name == "" {
+		name = c.Current
+	}
+	if name == "" || name == LocalContext {
+		return LocalContext, nil, nil
+	}
+	ctx, ok := c.Contexts[name]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown context %q (see choo context list)", name)
+	}
+	return name, ctx, nil
+}
+
+// Dial connects to the context's daemon
+func (c *Context) Dial() (*Client, error) {
+	if c.Address == "" {
+		return nil, fmt.Errorf("context has no address")
+	}
+	opts := RemoteOptions{
+		CACert:     expandHome(c.CACert),
+		ClientCert: expandHome(c.ClientCert),
+		ClientKey:  expandHome(c.ClientKey),
+		ServerName: c.ServerName,
+	}
+	if c.TokenEnv != "" {
+		if opts.Token = os.Getenv(c.TokenEnv); opts.Token == "" {
+			return nil, fmt.Errorf("%s is not set", c.TokenEnv)
+		}
+	}
+	return NewRemote(c.Address, opts)
+}
+
+// expandHome expands a leading ~/ in path
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadContexts_MissingFile(t *testing.T) {
+	c, err := LoadContexts(filepath.Join(t.TempDir(), "contexts.yaml"))
+	if err != nil {
+		t.Fatalf("LoadContexts failed: %v", err)
+	}
+	name, ctx, err := c.Resolve("")
+	if err != nil || name != LocalContext || ctx != nil {
+		t.Errorf("expected local context, got %q %v %v", name, ctx, err)
+	}
+}
+
+func TestContexts_SaveAndResolve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contexts.yaml")
+	c := &Contexts{
+		Current: "build",
+		Contexts: map[string]*Context{
+			"build": {Address: "build.example.com:7443", TokenEnv: "CHOO_BUILD_TOKEN"},
+		},
+	}
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadContexts(path)
+	if err != nil {
+		t.Fatalf("LoadContexts failed: %v", err)
+	}
+	name, ctx, err := loaded.Resolve("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "build" || ctx.Address != "build.example.com:7443" {
+		t.Errorf("expected current context build, got %q %+v", name, ctx)
+	}
+
+	// An explicit name overrides the current context
+	if _, ctx, _ := loaded.Resolve(LocalContext); ctx != nil {
+		t.Error("expected local context to resolve to nil")
+	}
+	if _, _, err := loaded.Resolve("missing"); err == nil {
+		t.Error("expected error for unknown context")
+	}
+
+	if names := loaded.Names(); len(names) != 2 || names[0] != LocalContext || names[1] != "build" {
+		t.Errorf("unexpected names %v", names)
+	}
+}
+
+func TestContext_DialRequiresToken(t *testing.T) {
+	t.Setenv("CHOO_TEST_TOKEN", "")
+	c := &Context{Address: "localhost:7443", TokenEnv: "CHOO_TEST_TOKEN"}
+	if _, err := c.Dial(); err == nil {
+		t.Error("expected error when the token variable is unset")
+	}
+}
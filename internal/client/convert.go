@@ -6,6 +6,7 @@ import (
 
 	apiv1 "github.com/RevCBH/choo/pkg/api/v1"
 	"github.com/RevCBH/choo/internal/events"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // jobConfigToProto converts client JobConfig to protobuf StartJobRequest
@@ -16,6 +17,7 @@ func jobConfigToProto(cfg JobConfig) *apiv1.StartJobRequest {
 		FeatureBranch: cfg.FeatureBranch,
 		Parallelism:   int32(cfg.Parallelism),
 		RepoPath:      cfg.RepoPath,
+		Workspace:     cfg.Workspace,
 	}
 }
 
@@ -105,3 +107,121 @@ func protoToEvent(p *apiv1.JobEvent) events.Event {
 
 	return ev
 }
+
+// protoToGCReport converts RunGCResponse to client GCReport
+func protoToGCReport(resp *apiv1.RunGCResponse) *GCReport {
+	report := &GCReport{
+		DryRun:      resp.GetDryRun(),
+		UsageBefore: resp.GetUsageBeforeBytes(),
+		UsageAfter:  resp.GetUsageAfterBytes(),
+		Errors:      resp.GetErrors(),
+	}
+	for _, wt := range resp.GetRemoved() {
+		report.Removed = append(report.Removed, GCWorktree{
+			RepoPath: wt.GetRepoPath(),
+			Path:     wt.GetPath(),
+			Branch:   wt.GetBranch(),
+			Bytes:    wt.GetBytes(),
+			LastUsed: wt.GetLastUsed().AsTime(),
+			Reason:   wt.GetReason(),
+		})
+	}
+	for _, b := range resp.GetPrunedBranches() {
+		report.PrunedBranches = append(report.PrunedBranches, GCBranch{
+			RepoPath: b.GetRepoPath(),
+			Name:     b.GetName(),
+		})
+	}
+	return report
+}
+
+// workspaceToProto converts a client Workspace to protobuf Workspace
+func workspaceToProto(ws Workspace) *apiv1.Workspace {
+	return &apiv1.Workspace{
+		Name:         ws.Name,
+		RepoUrl:      ws.RepoURL,
+		TargetBranch: ws.TargetBranch,
+		TasksDir:     ws.TasksDir,
+		Parallelism:  int32(ws.Parallelism),
+		MaxJobs:      int32(ws.MaxJobs),
+	}
+}
+
+// protoToWorkspace converts a protobuf Workspace to client type
+func protoToWorkspace(p *apiv1.Workspace) *Workspace {
+	var fetchedAt *time.Time
+	if p.GetFetchedAt() != nil {
+		t := p.GetFetchedAt().AsTime()
+		fetchedAt = &t
+	}
+	return &Workspace{
+		Name:         p.GetName(),
+		RepoURL:      p.GetRepoUrl(),
+		MirrorPath:   p.GetMirrorPath(),
+		TargetBranch: p.GetTargetBranch(),
+		TasksDir:     p.GetTasksDir(),
+		Parallelism:  int(p.GetParallelism()),
+		MaxJobs:      int(p.GetMaxJobs()),
+		CreatedAt:    p.GetCreatedAt().AsTime(),
+		FetchedAt:    fetchedAt,
+	}
+}
+
+// protoToJobConfig converts a protobuf StartJobRequest to client JobConfig
+func protoToJobConfig(p *apiv1.StartJobRequest) JobConfig {
+	return JobConfig{
+		TasksDir:      p.GetTasksDir(),
+		TargetBranch:  p.GetTargetBranch(),
+		FeatureBranch: p.GetFeatureBranch(),
+		Parallelism:   int(p.GetParallelism()),
+		RepoPath:      p.GetRepoPath(),
+		Workspace:     p.GetWorkspace(),
+	}
+}
+
+// protoToOptionalTime converts a protobuf Timestamp that may be unset
+func protoToOptionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// protoToQueuedJob converts a protobuf QueuedJob to client type
+func protoToQueuedJob(p *apiv1.QueuedJob) *QueuedJob {
+	return &QueuedJob{
+		ID:         p.GetId(),
+		Job:        protoToJobConfig(p.GetJob()),
+		Priority:   int(p.GetPriority()),
+		Status:     p.GetStatus(),
+		JobID:      p.GetJobId(),
+		Schedule:   p.GetSchedule(),
+		Error:      p.GetError(),
+		QueuedAt:   p.GetQueuedAt().AsTime(),
+		StartedAt:  protoToOptionalTime(p.GetStartedAt()),
+		FinishedAt: protoToOptionalTime(p.GetFinishedAt()),
+	}
+}
+
+// scheduleToProto converts a client Schedule to protobuf Schedule
+func scheduleToProto(s Schedule) *apiv1.Schedule {
+	return &apiv1.Schedule{
+		Name:     s.Name,
+		Cron:     s.Cron,
+		Job:      jobConfigToProto(s.Job),
+		Priority: int32(s.Priority),
+	}
+}
+
+// protoToSchedule converts a protobuf Schedule to client type
+func protoToSchedule(p *apiv1.Schedule) *Schedule {
+	return &Schedule{
+		Name:      p.GetName(),
+		Cron:      p.GetCron(),
+		Job:       protoToJobConfig(p.GetJob()),
+		Priority:  int(p.GetPriority()),
+		NextRunAt: p.GetNextRunAt().AsTime(),
+		LastRunAt: protoToOptionalTime(p.GetLastRunAt()),
+	}
+}
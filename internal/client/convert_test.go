@@ -236,3 +236,102 @@ func TestProtoToHealthInfo(t *testing.T) {
 		t.Errorf("Version: got %s, want %s", result.Version, resp.Version)
 	}
 }
+
+func TestProtoToGCReport(t *testing.T) {
+	lastUsed := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	resp := &apiv1.RunGCResponse{
+		DryRun:           true,
+		UsageBeforeBytes: 2048,
+		UsageAfterBytes:  1024,
+		Removed: []*apiv1.GCWorktree{
+			{RepoPath: "/repo", Path: "/repo/.ralph/worktrees/unit-a", Branch: "ralph/unit-a-abc123", Bytes: 1024, LastUsed: timestamppb.New(lastUsed), Reason: "retention"},
+		},
+		PrunedBranches: []*apiv1.GCBranch{
+			{RepoPath: "/repo", Name: "ralph/unit-b-def456"},
+		},
+		Errors: []string{"failed to read worktree directory"},
+	}
+
+	result := protoToGCReport(resp)
+
+	if !result.DryRun {
+		t.Error("DryRun: got false, want true")
+	}
+	if result.UsageBefore != 2048 || result.UsageAfter != 1024 {
+		t.Errorf("Usage: got %d -> %d, want 2048 -> 1024", result.UsageBefore, result.UsageAfter)
+	}
+	if len(result.Removed) != 1 {
+		t.Fatalf("Removed: got %d entries, want 1", len(result.Removed))
+	}
+	if result.Removed[0].Reason != "retention" {
+		t.Errorf("Removed[0].Reason: got %s, want retention", result.Removed[0].Reason)
+	}
+	if !result.Removed[0].LastUsed.Equal(lastUsed) {
+		t.Errorf("Removed[0].LastUsed: got %v, want %v", result.Removed[0].LastUsed, lastUsed)
+	}
+	if len(result.PrunedBranches) != 1 || result.PrunedBranches[0].Name != "ralph/unit-b-def456" {
+		t.Errorf("PrunedBranches: got %v", result.PrunedBranches)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Errors: got %d entries, want 1", len(result.Errors))
+	}
+}
+
+func TestProtoToWorkspace(t *testing.T) {
+	created := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	result := protoToWorkspace(&apiv1.Workspace{
+		Name:         "app",
+		RepoUrl:      "https://github.com/o/app.git",
+		MirrorPath:   "/srv/choo/workspaces/app",
+		TargetBranch: "main",
+		TasksDir:     "specs/tasks",
+		Parallelism:  2,
+		MaxJobs:      3,
+		CreatedAt:    timestamppb.New(created),
+	})
+
+	if result.Name != "app" || result.RepoURL != "https://github.com/o/app.git" || result.MirrorPath != "/srv/choo/workspaces/app" {
+		t.Errorf("Identity: got %+v", result)
+	}
+	if result.Parallelism != 2 || result.MaxJobs != 3 {
+		t.Errorf("Limits: got parallelism %d, max jobs %d, want 2 and 3", result.Parallelism, result.MaxJobs)
+	}
+	if !result.CreatedAt.Equal(created) {
+		t.Errorf("CreatedAt: got %v, want %v", result.CreatedAt, created)
+	}
+	if result.FetchedAt != nil {
+		t.Errorf("FetchedAt: got %v, want nil", result.FetchedAt)
+	}
+
+	back := workspaceToProto(*result)
+	if back.GetName() != "app" || back.GetMaxJobs() != 3 || back.GetMirrorPath() != "" {
+		t.Errorf("workspaceToProto: got %v", back)
+	}
+}
+
+func TestProtoToQueuedJob(t *testing.T) {
+	queued := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	result := protoToQueuedJob(&apiv1.QueuedJob{
+		Id:       "01Q",
+		Job:      &apiv1.StartJobRequest{Workspace: "app", FeatureBranch: "feature/api"},
+		Priority: 2,
+		Status:   "queued",
+		Schedule: "nightly",
+		QueuedAt: timestamppb.New(queued),
+	})
+
+	if result.ID != "01Q" || result.Status != "queued" || result.Priority != 2 || result.Schedule != "nightly" {
+		t.Errorf("Entry: got %+v", result)
+	}
+	if result.Job.Workspace != "app" || result.Job.FeatureBranch != "feature/api" {
+		t.Errorf("Job: got %+v", result.Job)
+	}
+	if !result.QueuedAt.Equal(queued) {
+		t.Errorf("QueuedAt: got %v, want %v", result.QueuedAt, queued)
+	}
+	if result.StartedAt != nil || result.FinishedAt != nil {
+		t.Errorf("StartedAt/FinishedAt: got %v/%v, want nil", result.StartedAt, result.FinishedAt)
+	}
+}
@@ -9,6 +9,7 @@ type JobConfig struct {
 	FeatureBranch string // Branch name for work
 	Parallelism   int    // Max concurrent units
 	RepoPath      string // Repository root path
+	Workspace     string // Registered workspace to run in, instead of RepoPath
 }
 
 // JobSummary provides high-level job information for listings
@@ -46,3 +47,74 @@ type HealthInfo struct {
 	ActiveJobs int
 	Version    string
 }
+
+// GCReport summarizes a worktree garbage collection pass
+type GCReport struct {
+	DryRun         bool
+	UsageBefore    int64
+	UsageAfter     int64
+	Removed        []GCWorktree
+	PrunedBranches []GCBranch
+	Errors         []string
+}
+
+// GCWorktree describes a worktree removed by garbage collection
+type GCWorktree struct {
+	RepoPath string
+	Path     string
+	Branch   string
+	Bytes    int64
+	LastUsed time.Time
+	Reason   string
+}
+
+// GCBranch describes a unit branch pruned by garbage collection
+type GCBranch struct {
+	RepoPath string
+	Name     string
+}
+
+// Workspace is a repository registered with the daemon
+type Workspace struct {
+	Name         string
+	RepoURL      string
+	MirrorPath   string // The daemon's clone, on the daemon's host
+	TargetBranch string // Default base branch for jobs
+	TasksDir     string // Default tasks directory
+	Parallelism  int    // Default max concurrent units (0 = default)
+	MaxJobs      int    // Max concurrent jobs (0 = daemon default)
+	CreatedAt    time.Time
+	FetchedAt    *time.Time
+}
+
+// QueuedJob is a job in the daemon's queue
+type QueuedJob struct {
+	ID         string
+	Job        JobConfig
+	Priority   int    // Higher priorities start first
+	Status     string // "queued", "started", "finished" or "cancelled"
+	JobID      string // Job started from this entry
+	Schedule   string // Schedule that queued it, if any
+	Error      string // Why the job could not start
+	QueuedAt   time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// Schedule queues a job at the times given by a cron expression
+type Schedule struct {
+	Name      string
+	Cron      string
+	Job       JobConfig
+	Priority  int
+	NextRunAt time.Time
+	LastRunAt *time.Time
+}
+
+// ImportedJob describes a job archive loaded into the daemon
+type ImportedJob struct {
+	JobID    string
+	Units    int
+	Events   int
+	FilesDir string // Where the daemon extracted the archive's logs and diffs
+}
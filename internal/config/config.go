@@ -111,6 +111,9 @@ type Config struct {
 	// GitHub contains repository identification
 	GitHub GitHubConfig `yaml:"github"`
 
+	// Forge selects the code host (GitHub, GitLab or Gitea) and its API
+	Forge ForgeConfig `yaml:"forge"`
+
 	// Worktree contains worktree management settings
 	Worktree WorktreeConfig `yaml:"worktree"`
 
@@ -129,12 +132,24 @@ type Config struct {
 	// Review contains review polling settings
 	Review ReviewConfig `yaml:"review"`
 
+	// PullRequests controls how completed work is proposed for review
+	PullRequests PullRequestConfig `yaml:"pull_requests"`
+
+	// CI controls automatic fixes for failing checks on the feature PR
+	CI CIConfig `yaml:"ci"`
+
 	// Feature contains PRD-driven feature workflow settings
 	Feature FeatureConfig `yaml:"feature"`
 
+	// Intake configures importing PRDs from issue trackers
+	Intake IntakeConfig `yaml:"intake"`
+
 	// CodeReview configures the advisory code review system
 	CodeReview CodeReviewConfig `yaml:"code_review"`
 
+	// Conflicts configures conflict pre-detection between parallel units
+	Conflicts ConflictConfig `yaml:"conflicts"`
+
 	// LogLevel controls log verbosity (debug, info, warn, error)
 	LogLevel string `yaml:"log_level"`
 }
@@ -149,6 +164,38 @@ type GitHubConfig struct {
 	Repo string `yaml:"repo"`
 }
 
+// ForgeType identifies a code host API.
+type ForgeType string
+
+const (
+	// ForgeAuto detects the code host from the origin remote URL
+	ForgeAuto ForgeType = "auto"
+
+	// ForgeGitHub is github.com or GitHub Enterprise
+	ForgeGitHub ForgeType = "github"
+
+	// ForgeGitLab is gitlab.com or a self-hosted GitLab
+	ForgeGitLab ForgeType = "gitlab"
+
+	// ForgeGitea is a Gitea or Forgejo instance
+	ForgeGitea ForgeType = "gitea"
+)
+
+// ForgeConfig selects the code host that PRs (merge requests on GitLab)
+// are opened on. For GitLab, github.owner holds the full group path.
+type ForgeConfig struct {
+	// Type is "auto" (default), "github", "gitlab" or "gitea"
+	Type ForgeType `yaml:"type"`
+
+	// URL is the API base URL (e.g., "https://gitlab.example.com/api/v4").
+	// Derived from the remote host when empty.
+	URL string `yaml:"url"`
+
+	// TokenEnv names the environment variable holding the API token.
+	// Defaults to GITHUB_TOKEN, GITLAB_TOKEN or GITEA_TOKEN.
+	TokenEnv string `yaml:"token_env"`
+}
+
 // WorktreeConfig controls git worktree creation and lifecycle.
 type WorktreeConfig struct {
 	// BasePath is the directory where worktrees are created.
@@ -196,8 +243,63 @@ type BaselineCheck struct {
 type MergeConfig struct {
 	// MaxConflictRetries is how many times to attempt conflict resolution
 	MaxConflictRetries int `yaml:"max_conflict_retries"`
+
+	// Queue configures batched, speculatively tested merges
+	Queue MergeQueueConfig `yaml:"queue"`
 }
 
+// MergeQueueConfig controls the merge queue.
+// When enabled, completed units are batched and merged together in a scratch
+// worktree, baseline checks run once on the combined result, and failing
+// batches are bisected to find the offending unit before anything lands.
+type MergeQueueConfig struct {
+	// Enabled turns on the merge queue (default: false, units merge one at a time)
+	Enabled bool `yaml:"enabled"`
+
+	// BatchSize is the maximum number of units integrated together
+	BatchSize int `yaml:"batch_size"`
+
+	// BatchWindow is how long to wait for more units before starting a batch
+	BatchWindow string `yaml:"batch_window"`
+}
+
+// BatchWindowDuration parses the batch window as a Duration.
+func (c MergeQueueConfig) BatchWindowDuration() (time.Duration, error) {
+	return time.ParseDuration(c.BatchWindow)
+}
+
+// ConflictConfig controls conflict pre-detection between parallel units.
+type ConflictConfig struct {
+	// Detect analyzes task specs before dispatch and compares in-flight diffs
+	// to flag units likely to conflict. Default: true.
+	Detect bool `yaml:"detect"`
+
+	// Serialize prevents high-risk pairs from running at the same time.
+	// Default: false (risks are reported but not acted on).
+	Serialize bool `yaml:"serialize"`
+
+	// DiffInterval is how often running units' diffs are compared.
+	// "0" disables diff monitoring. Default: "2m".
+	DiffInterval string `yaml:"diff_interval"`
+}
+
+// DiffIntervalDuration parses the diff interval as a Duration.
+func (c ConflictConfig) DiffIntervalDuration() (time.Duration, error) {
+	return time.ParseDuration(c.DiffInterval)
+}
+
+// ReviewMode selects how PR approval is detected.
+type ReviewMode string
+
+const (
+	// ReviewModeReactions treats a 👍 reaction as approval and 👀 as in review
+	ReviewModeReactions ReviewMode = "reactions"
+
+	// ReviewModeReviews uses submitted pull request reviews together with
+	// the base branch's protection rules (GitHub only)
+	ReviewModeReviews ReviewMode = "reviews"
+)
+
 // ReviewConfig controls PR review polling.
 type ReviewConfig struct {
 	// Timeout is the maximum time to wait for review approval
@@ -205,6 +307,63 @@ type ReviewConfig struct {
 
 	// PollInterval is how often to check for review status
 	PollInterval string `yaml:"poll_interval"`
+
+	// Mode is "reactions" (default) or "reviews"
+	Mode ReviewMode `yaml:"mode"`
+
+	// RequiredApprovals is the minimum number of approving reviews in
+	// reviews mode; branch protection can raise it (default 1)
+	RequiredApprovals int `yaml:"required_approvals"`
+
+	// RequireCodeOwners requires a code owner's approval for every changed
+	// file listed in CODEOWNERS, even where branch protection does not
+	RequireCodeOwners bool `yaml:"require_code_owners"`
+}
+
+// PRMode selects how pull requests are opened for a run.
+type PRMode string
+
+const (
+	// PRModeSingle merges units locally and opens one PR for the whole feature
+	PRModeSingle PRMode = "single"
+
+	// PRModeStacked opens one PR per unit, stacked on its dependencies' branches
+	PRModeStacked PRMode = "stacked"
+)
+
+// PullRequestConfig controls pull request creation.
+type PullRequestConfig struct {
+	// Mode is "single" (default) or "stacked"
+	Mode PRMode `yaml:"mode"`
+
+	// BranchPrefix is the prefix for per-unit stack branches (stacked mode only)
+	BranchPrefix string `yaml:"branch_prefix"`
+}
+
+// CIConfig controls CI failure remediation on the feature PR.
+type CIConfig struct {
+	// Fix waits for checks after the feature PR opens and, when they fail,
+	// runs fix cycles on the feature branch (default: off)
+	Fix bool `yaml:"fix"`
+
+	// MaxAttempts is the number of fix cycles before handing over to a human
+	MaxAttempts int `yaml:"max_attempts"`
+
+	// Timeout bounds each wait for checks to finish
+	Timeout string `yaml:"timeout"`
+
+	// PollInterval is how often check status is polled
+	PollInterval string `yaml:"poll_interval"`
+}
+
+// TimeoutDuration parses the check wait timeout as a Duration.
+func (c CIConfig) TimeoutDuration() (time.Duration, error) {
+	return time.ParseDuration(c.Timeout)
+}
+
+// PollIntervalDuration parses the check poll interval as a Duration.
+func (c CIConfig) PollIntervalDuration() (time.Duration, error) {
+	return time.ParseDuration(c.PollInterval)
 }
 
 // FeatureConfig holds configuration for PRD-driven feature workflow.
@@ -219,6 +378,57 @@ type FeatureConfig struct {
 	BranchPrefix string `yaml:"branch_prefix"`
 }
 
+// IntakeConfig configures `choo feature import`, which turns tracker
+// issues into PRDs.
+type IntakeConfig struct {
+	// Label selects the GitHub issues and Linear issues to import
+	Label string `yaml:"label"`
+
+	// Jira configures the Jira Cloud or Server source
+	Jira JiraConfig `yaml:"jira"`
+
+	// Linear configures the Linear source
+	Linear LinearConfig `yaml:"linear"`
+}
+
+// JiraConfig identifies a Jira site and the issues to import.
+type JiraConfig struct {
+	// URL is the site base URL (e.g., https://example.atlassian.net)
+	URL string `yaml:"url"`
+
+	// JQL selects issues (default: open issues carrying the intake label)
+	JQL string `yaml:"jql"`
+
+	// EmailEnv names the env var holding the account email for basic auth;
+	// when unset the token is sent as a bearer token (Jira Server/DC)
+	EmailEnv string `yaml:"email_env"`
+
+	// TokenEnv names the env var holding the API token
+	TokenEnv string `yaml:"token_env"`
+}
+
+// LinearConfig identifies the Linear issues to import.
+type LinearConfig struct {
+	// URL is the GraphQL endpoint
+	URL string `yaml:"url"`
+
+	// Team restricts the import to one team key (e.g., ENG)
+	Team string `yaml:"team"`
+
+	// TokenEnv names the env var holding the API key
+	TokenEnv string `yaml:"token_env"`
+}
+
+// QueueBaselineChecks returns the baseline checks workers run, which they
+// do only with the merge queue enabled: after each unit and on each batch.
+// Without the queue it returns nil.
+func (c *Config) QueueBaselineChecks() []BaselineCheck {
+	if !c.Merge.Queue.Enabled {
+		return nil
+	}
+	return c.BaselineChecks
+}
+
 // ReviewTimeoutDuration parses the review timeout as a Duration.
 func (c *Config) ReviewTimeoutDuration() (time.Duration, error) {
 	return time.ParseDuration(c.Review.Timeout)
@@ -257,18 +467,9 @@ func LoadConfig(repoRoot string) (*Config, error) {
 		cfg.Worktree.BasePath = filepath.Join(repoRoot, cfg.Worktree.BasePath)
 	}
 
-	// Auto-detect GitHub owner/repo if set to "auto"
-	if cfg.GitHub.Owner == "auto" || cfg.GitHub.Repo == "auto" {
-		owner, repo, err := detectGitHubRepo(repoRoot)
-		if err != nil {
-			return nil, fmt.Errorf("auto-detect github: %w", err)
-		}
-		if cfg.GitHub.Owner == "auto" {
-			cfg.GitHub.Owner = owner
-		}
-		if cfg.GitHub.Repo == "auto" {
-			cfg.GitHub.Repo = repo
-		}
+	// Auto-detect the code host and owner/repo from the git remote
+	if err := detectForge(cfg, repoRoot); err != nil {
+		return nil, err
 	}
 
 	// Validate
@@ -385,6 +385,18 @@ baseline_checks:
 	}
 }
 
+func TestConfig_QueueBaselineChecks(t *testing.T) {
+	cfg := &Config{BaselineChecks: []BaselineCheck{{Name: "test", Command: "go test ./..."}}}
+	if checks := cfg.QueueBaselineChecks(); checks != nil {
+		t.Errorf("expected no checks without the merge queue, got %v", checks)
+	}
+
+	cfg.Merge.Queue.Enabled = true
+	if checks := cfg.QueueBaselineChecks(); len(checks) != 1 || checks[0].Name != "test" {
+		t.Errorf("expected the configured checks with the merge queue, got %v", checks)
+	}
+}
+
 func TestLoadConfig_ConditionalCommands(t *testing.T) {
 	dir := t.TempDir()
 	stubGitRemote(t, "https://github.com/testowner/testrepo.git", nil)
@@ -8,12 +8,24 @@ const (
 	DefaultClaudeMaxTurns     = 0 // unlimited
 	DefaultCodexCommand       = "codex"
 	DefaultMaxConflictRetries = 3
+	DefaultMergeQueueBatch    = 4
+	DefaultMergeQueueWindow   = "30s"
+	DefaultConflictDiffPeriod = "2m"
+	DefaultStackBranchPrefix  = "stack/"
 	DefaultReviewTimeout      = "2h"
 	DefaultReviewPollInterval = "30s"
+	DefaultCIFixAttempts      = 3
+	DefaultCITimeout          = "30m"
+	DefaultCIPollInterval     = "30s"
 	DefaultLogLevel           = "info"
 	DefaultPRDDir             = "docs/prd"
 	DefaultSpecsDir           = "specs"
 	DefaultBranchPrefix       = "feature/"
+	DefaultIntakeLabel        = "choo"
+	DefaultJiraEmailEnv       = "JIRA_EMAIL"
+	DefaultJiraTokenEnv       = "JIRA_API_TOKEN"
+	DefaultLinearURL          = "https://api.linear.app/graphql"
+	DefaultLinearTokenEnv     = "LINEAR_API_KEY"
 
 	DefaultCodeReviewEnabled          = true
 	DefaultCodeReviewProvider         = ReviewProviderCodex
@@ -63,6 +75,9 @@ func DefaultConfig() *Config {
 			Owner: "auto",
 			Repo:  "auto",
 		},
+		Forge: ForgeConfig{
+			Type: ForgeAuto,
+		},
 		Worktree: WorktreeConfig{
 			BasePath: DefaultWorktreeBasePath,
 		},
@@ -73,13 +88,42 @@ func DefaultConfig() *Config {
 		},
 		Merge: MergeConfig{
 			MaxConflictRetries: DefaultMaxConflictRetries,
+			Queue: MergeQueueConfig{
+				BatchSize:   DefaultMergeQueueBatch,
+				BatchWindow: DefaultMergeQueueWindow,
+			},
 		},
 		Review: ReviewConfig{
 			Timeout:      DefaultReviewTimeout,
 			PollInterval: DefaultReviewPollInterval,
+			Mode:         ReviewModeReactions,
+		},
+		PullRequests: PullRequestConfig{
+			Mode:         PRModeSingle,
+			BranchPrefix: DefaultStackBranchPrefix,
+		},
+		CI: CIConfig{
+			MaxAttempts:  DefaultCIFixAttempts,
+			Timeout:      DefaultCITimeout,
+			PollInterval: DefaultCIPollInterval,
+		},
+		Feature: DefaultFeatureConfig(),
+		Intake: IntakeConfig{
+			Label: DefaultIntakeLabel,
+			Jira: JiraConfig{
+				EmailEnv: DefaultJiraEmailEnv,
+				TokenEnv: DefaultJiraTokenEnv,
+			},
+			Linear: LinearConfig{
+				URL:      DefaultLinearURL,
+				TokenEnv: DefaultLinearTokenEnv,
+			},
 		},
-		Feature:    DefaultFeatureConfig(),
 		CodeReview: DefaultCodeReviewConfig(),
-		LogLevel:   DefaultLogLevel,
+		Conflicts: ConflictConfig{
+			Detect:       true,
+			DiffInterval: DefaultConflictDiffPeriod,
+		},
+		LogLevel: DefaultLogLevel,
 	}
 }
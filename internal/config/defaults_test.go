@@ -48,6 +48,15 @@ func TestDefaultConfig_Merge(t *testing.T) {
 	if cfg.Merge.MaxConflictRetries != 3 {
 		t.Errorf("expected Merge.MaxConflictRetries to be 3, got %d", cfg.Merge.MaxConflictRetries)
 	}
+	if cfg.Merge.Queue.Enabled {
+		t.Error("expected Merge.Queue.Enabled to be false")
+	}
+	if cfg.Merge.Queue.BatchSize != 4 {
+		t.Errorf("expected Merge.Queue.BatchSize to be 4, got %d", cfg.Merge.Queue.BatchSize)
+	}
+	if cfg.Merge.Queue.BatchWindow != "30s" {
+		t.Errorf("expected Merge.Queue.BatchWindow to be '30s', got %q", cfg.Merge.Queue.BatchWindow)
+	}
 }
 
 func TestDefaultConfig_Review(t *testing.T) {
@@ -66,3 +75,49 @@ func TestDefaultConfig_LogLevel(t *testing.T) {
 		t.Errorf("expected LogLevel to be 'info', got %q", cfg.LogLevel)
 	}
 }
+
+func TestDefaultConfig_Conflicts(t *testing.T) {
+	cfg := DefaultConfig()
+	if !cfg.Conflicts.Detect {
+		t.Error("expected Conflicts.Detect to be true")
+	}
+	if cfg.Conflicts.Serialize {
+		t.Error("expected Conflicts.Serialize to be false")
+	}
+	if cfg.Conflicts.DiffInterval != "2m" {
+		t.Errorf("expected Conflicts.DiffInterval to be '2m', got %q", cfg.Conflicts.DiffInterval)
+	}
+}
+
+func TestDefaultConfig_PullRequests(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.PullRequests.Mode != PRModeSingle {
+		t.Errorf("expected PullRequests.Mode to be 'single', got %q", cfg.PullRequests.Mode)
+	}
+	if cfg.PullRequests.BranchPrefix != "stack/" {
+		t.Errorf("expected PullRequests.BranchPrefix to be 'stack/', got %q", cfg.PullRequests.BranchPrefix)
+	}
+}
+
+func TestDefaultConfig_ReviewMode(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.Review.Mode != ReviewModeReactions {
+		t.Errorf("expected Review.Mode to be 'reactions', got %q", cfg.Review.Mode)
+	}
+	if cfg.Review.RequiredApprovals != 0 || cfg.Review.RequireCodeOwners {
+		t.Error("expected no extra approval requirements by default")
+	}
+}
+
+func TestDefaultConfig_Intake(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.Intake.Label != "choo" {
+		t.Errorf("expected Intake.Label to be 'choo', got %q", cfg.Intake.Label)
+	}
+	if cfg.Intake.Jira.TokenEnv != "JIRA_API_TOKEN" || cfg.Intake.Linear.TokenEnv != "LINEAR_API_KEY" {
+		t.Errorf("unexpected token env defaults: %q, %q", cfg.Intake.Jira.TokenEnv, cfg.Intake.Linear.TokenEnv)
+	}
+	if cfg.Intake.Linear.URL != "https://api.linear.app/graphql" {
+		t.Errorf("expected Linear URL default, got %q", cfg.Intake.Linear.URL)
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// remote is the parsed origin remote
+type remote struct {
+	Scheme string // scheme for web/API requests (https unless the remote is http)
+	Host   string // host, with port for http(s) remotes
+	Owner  string // owner or group path; GitLab groups may be nested
+	Repo   string
+}
+
+var scpRemoteRe = regexp.MustCompile(`^[\w.-]+@([^:/]+):(.+)$`)
+
+// parseRemoteURL splits a git remote URL into host and owner/repo.
+// Supports:
+//   - https://host[:port]/owner/repo(.git)
+//   - ssh://git@host[:port]/owner/repo(.git)
+//   - git@host:owner/repo(.git)
+//
+// The owner keeps every path segment but the last, so GitLab subgroups
+// (group/subgroup/repo) are preserved.
+func parseRemoteURL(rawURL string) (*remote, error) {
+	r := &remote{Scheme: "https"}
+	var path string
+
+	if m := scpRemoteRe.FindStringSubmatch(rawURL); m != nil {
+		r.Host, path = m[1], m[2]
+	} else {
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("unrecognized remote URL format: %s", rawURL)
+		}
+		switch u.Scheme {
+		case "http", "https":
+			r.Scheme, r.Host = u.Scheme, u.Host
+		default:
+			// The SSH port says nothing about where the web API listens
+			r.Host = u.Hostname()
+		}
+		path = u.Path
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	i := strings.LastIndex(path, "/")
+	if i <= 0 || i == len(path)-1 {
+		return nil, fmt.Errorf("unrecognized remote URL format: %s", rawURL)
+	}
+	r.Owner, r.Repo = path[:i], path[i+1:]
+	return r, nil
+}
+
+// forgeTypeForHost guesses the code host from its hostname. Hosts that
+// don't name their software are assumed to be GitHub (Enterprise); set
+// forge.type for a self-hosted GitLab or Gitea on a neutral hostname.
+func forgeTypeForHost(host string) ForgeType {
+	h := strings.ToLower(host)
+	switch {
+	case strings.Contains(h, "gitlab"):
+		return ForgeGitLab
+	case strings.Contains(h, "gitea"), strings.Contains(h, "forgejo"), h == "codeberg.org":
+		return ForgeGitea
+	default:
+		return ForgeGitHub
+	}
+}
+
+// defaultForgeURL returns the API base URL for a code host
+func defaultForgeURL(t ForgeType, scheme, host string) string {
+	switch t {
+	case ForgeGitLab:
+		return fmt.Sprintf("%s://%s/api/v4", scheme, host)
+	case ForgeGitea:
+		return fmt.Sprintf("%s://%s/api/v1", scheme, host)
+	default:
+		if host == "github.com" {
+			return "https://api.github.com"
+		}
+		return fmt.Sprintf("%s://%s/api/v3", scheme, host)
+	}
+}
+
+// detectForge fills in "auto" owner/repo, the forge type and the API URL
+// from the origin remote. A missing or unparseable remote is only an error
+// when owner/repo must be detected; otherwise GitHub is assumed.
+func detectForge(cfg *Config, repoRoot string) error {
+	needRepo := cfg.GitHub.Owner == "auto" || cfg.GitHub.Repo == "auto"
+	needType := cfg.Forge.Type == "" || cfg.Forge.Type == ForgeAuto
+	if !needRepo && !needType && cfg.Forge.URL != "" {
+		return nil
+	}
+
+	rawURL, err := gitRemoteGetter(repoRoot)
+	var r *remote
+	if err == nil {
+		r, err = parseRemoteURL(rawURL)
+	}
+	if err != nil {
+		if needRepo {
+			return fmt.Errorf("auto-detect github: %w", err)
+		}
+		if needType {
+			cfg.Forge.Type = ForgeGitHub
+		}
+		return nil
+	}
+
+	if cfg.GitHub.Owner == "auto" {
+		cfg.GitHub.Owner = r.Owner
+	}
+	if cfg.GitHub.Repo == "auto" {
+		cfg.GitHub.Repo = r.Repo
+	}
+	if needType {
+		cfg.Forge.Type = forgeTypeForHost(r.Host)
+	}
+	if cfg.Forge.URL == "" {
+		cfg.Forge.URL = defaultForgeURL(cfg.Forge.Type, r.Scheme, r.Host)
+	}
+	return nil
+}
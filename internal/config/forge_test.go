@@ -0,0 +1,104 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		url                       string
+		scheme, host, owner, repo string
+	}{
+		{"https://github.com/RevCBH/choo.git", "https", "github.com", "RevCBH", "choo"},
+		{"git@github.com:RevCBH/choo.git", "https", "github.com", "RevCBH", "choo"},
+		{"git@gitlab.example.com:platform/tools/choo.git", "https", "gitlab.example.com", "platform/tools", "choo"},
+		{"ssh://git@gitlab.example.com:2222/platform/choo.git", "https", "gitlab.example.com", "platform", "choo"},
+		{"http://localhost:3000/org/repo", "http", "localhost:3000", "org", "repo"},
+	}
+
+	for _, tt := range tests {
+		r, err := parseRemoteURL(tt.url)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.url, err)
+			continue
+		}
+		if r.Scheme != tt.scheme || r.Host != tt.host || r.Owner != tt.owner || r.Repo != tt.repo {
+			t.Errorf("%s: got %+v", tt.url, r)
+		}
+	}
+}
+
+func TestParseRemoteURL_Invalid(t *testing.T) {
+	for _, u := range []string{"not-a-url", "https://github.com/only-owner", "/local/path/repo.git"} {
+		if _, err := parseRemoteURL(u); err == nil {
+			t.Errorf("%s: expected error", u)
+		}
+	}
+}
+
+func TestLoadConfig_ForgeDetect(t *testing.T) {
+	tests := []struct {
+		remote string
+		typ    ForgeType
+		url    string
+	}{
+		{"git@github.com:org/repo.git", ForgeGitHub, "https://api.github.com"},
+		{"https://github.example.com/org/repo.git", ForgeGitHub, "https://github.example.com/api/v3"},
+		{"git@gitlab.example.com:group/sub/repo.git", ForgeGitLab, "https://gitlab.example.com/api/v4"},
+		{"https://codeberg.org/org/repo.git", ForgeGitea, "https://codeberg.org/api/v1"},
+	}
+
+	for _, tt := range tests {
+		stubGitRemote(t, tt.remote, nil)
+		cfg, err := LoadConfig(t.TempDir())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.remote, err)
+		}
+		if cfg.Forge.Type != tt.typ {
+			t.Errorf("%s: expected type %q, got %q", tt.remote, tt.typ, cfg.Forge.Type)
+		}
+		if cfg.Forge.URL != tt.url {
+			t.Errorf("%s: expected URL %q, got %q", tt.remote, tt.url, cfg.Forge.URL)
+		}
+	}
+}
+
+func TestLoadConfig_ForgeExplicit(t *testing.T) {
+	dir := t.TempDir()
+	stubGitRemote(t, "git@git.example.com:group/sub/repo.git", nil)
+
+	writeFile(t, filepath.Join(dir, ".choo.yaml"), `
+forge:
+  type: gitlab
+  token_env: CORP_GITLAB_TOKEN
+`)
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Forge.Type != ForgeGitLab {
+		t.Errorf("expected gitlab, got %q", cfg.Forge.Type)
+	}
+	if cfg.Forge.URL != "https://git.example.com/api/v4" {
+		t.Errorf("unexpected URL %q", cfg.Forge.URL)
+	}
+	if cfg.GitHub.Owner != "group/sub" || cfg.GitHub.Repo != "repo" {
+		t.Errorf("unexpected owner/repo %q/%q", cfg.GitHub.Owner, cfg.GitHub.Repo)
+	}
+	if cfg.Forge.TokenEnv != "CORP_GITLAB_TOKEN" {
+		t.Errorf("unexpected token env %q", cfg.Forge.TokenEnv)
+	}
+}
+
+func TestValidation_ForgeType(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.GitHub.Owner = "o"
+	cfg.GitHub.Repo = "r"
+	cfg.Forge.Type = "bitbucket"
+
+	if err := validateConfig(cfg); err == nil {
+		t.Error("expected error for unknown forge type")
+	}
+}
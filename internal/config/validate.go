@@ -49,6 +49,17 @@ func validateConfig(cfg *Config) error {
 		})
 	}
 
+	// Forge.Type must be a known code host (empty means auto)
+	switch cfg.Forge.Type {
+	case "", ForgeAuto, ForgeGitHub, ForgeGitLab, ForgeGitea:
+	default:
+		errs = append(errs, &ValidationError{
+			Field:   "forge.type",
+			Value:   cfg.Forge.Type,
+			Message: "must be one of: auto, github, gitlab, gitea",
+		})
+	}
+
 	// Claude.Command must not be empty
 	if cfg.Claude.Command == "" {
 		errs = append(errs, &ValidationError{
@@ -76,6 +87,47 @@ func validateConfig(cfg *Config) error {
 		})
 	}
 
+	// Merge.Queue settings only matter when the queue is enabled
+	if cfg.Merge.Queue.Enabled {
+		if cfg.Merge.Queue.BatchSize < 1 {
+			errs = append(errs, &ValidationError{
+				Field:   "merge.queue.batch_size",
+				Value:   cfg.Merge.Queue.BatchSize,
+				Message: "must be at least 1",
+			})
+		}
+		if d, err := cfg.Merge.Queue.BatchWindowDuration(); err != nil {
+			errs = append(errs, &ValidationError{
+				Field:   "merge.queue.batch_window",
+				Value:   cfg.Merge.Queue.BatchWindow,
+				Message: fmt.Sprintf("invalid duration: %v", err),
+			})
+		} else if d < 0 {
+			errs = append(errs, &ValidationError{
+				Field:   "merge.queue.batch_window",
+				Value:   cfg.Merge.Queue.BatchWindow,
+				Message: "must be non-negative",
+			})
+		}
+	}
+
+	// Conflicts.DiffInterval must be a non-negative duration when detection is on
+	if cfg.Conflicts.Detect {
+		if d, err := cfg.Conflicts.DiffIntervalDuration(); err != nil {
+			errs = append(errs, &ValidationError{
+				Field:   "conflicts.diff_interval",
+				Value:   cfg.Conflicts.DiffInterval,
+				Message: fmt.Sprintf("invalid duration: %v", err),
+			})
+		} else if d < 0 {
+			errs = append(errs, &ValidationError{
+				Field:   "conflicts.diff_interval",
+				Value:   cfg.Conflicts.DiffInterval,
+				Message: "must be non-negative",
+			})
+		}
+	}
+
 	// Review.Timeout must be valid Go duration string
 	if _, err := time.ParseDuration(cfg.Review.Timeout); err != nil {
 		errs = append(errs, &ValidationError{
@@ -94,6 +146,68 @@ func validateConfig(cfg *Config) error {
 		})
 	}
 
+	// Review.Mode must be a known mode (empty means reactions)
+	switch cfg.Review.Mode {
+	case "", ReviewModeReactions, ReviewModeReviews:
+	default:
+		errs = append(errs, &ValidationError{
+			Field:   "review.mode",
+			Value:   cfg.Review.Mode,
+			Message: "must be 'reactions' or 'reviews'",
+		})
+	}
+	if cfg.Review.RequiredApprovals < 0 {
+		errs = append(errs, &ValidationError{
+			Field:   "review.required_approvals",
+			Value:   cfg.Review.RequiredApprovals,
+			Message: "must be non-negative",
+		})
+	}
+
+	// PullRequests.Mode must be a known mode (empty means single)
+	switch cfg.PullRequests.Mode {
+	case "", PRModeSingle, PRModeStacked:
+	default:
+		errs = append(errs, &ValidationError{
+			Field:   "pull_requests.mode",
+			Value:   cfg.PullRequests.Mode,
+			Message: "must be 'single' or 'stacked'",
+		})
+	}
+	// Stack branches need a prefix so they never collide with unit or feature branches
+	if cfg.PullRequests.Mode == PRModeStacked && cfg.PullRequests.BranchPrefix == "" {
+		errs = append(errs, &ValidationError{
+			Field:   "pull_requests.branch_prefix",
+			Value:   cfg.PullRequests.BranchPrefix,
+			Message: "must not be empty in stacked mode",
+		})
+	}
+
+	// CI settings only matter when fixes are enabled
+	if cfg.CI.Fix {
+		if cfg.CI.MaxAttempts < 1 {
+			errs = append(errs, &ValidationError{
+				Field:   "ci.max_attempts",
+				Value:   cfg.CI.MaxAttempts,
+				Message: "must be at least 1",
+			})
+		}
+		if d, err := cfg.CI.TimeoutDuration(); err != nil || d <= 0 {
+			errs = append(errs, &ValidationError{
+				Field:   "ci.timeout",
+				Value:   cfg.CI.Timeout,
+				Message: "must be a positive duration",
+			})
+		}
+		if d, err := cfg.CI.PollIntervalDuration(); err != nil || d <= 0 {
+			errs = append(errs, &ValidationError{
+				Field:   "ci.poll_interval",
+				Value:   cfg.CI.PollInterval,
+				Message: "must be a positive duration",
+			})
+		}
+	}
+
 	// CodeReview validation
 	if err := cfg.CodeReview.Validate(); err != nil {
 		errs = append(errs, &ValidationError{
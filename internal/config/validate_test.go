@@ -264,6 +264,120 @@ func TestValidation_MaxConflictRetries_Zero(t *testing.T) {
 	}
 }
 
+func TestValidation_MergeQueue_Invalid(t *testing.T) {
+	cfg := &Config{
+		Parallelism: 4,
+		GitHub: GitHubConfig{
+			Owner: "test",
+			Repo:  "repo",
+		},
+		Claude: ClaudeConfig{
+			Command: "claude",
+		},
+		Merge: MergeConfig{
+			MaxConflictRetries: 3,
+			Queue: MergeQueueConfig{
+				Enabled:     true,
+				BatchSize:   0,
+				BatchWindow: "soon",
+			},
+		},
+		Review: ReviewConfig{
+			Timeout:      "2h",
+			PollInterval: "30s",
+		},
+		LogLevel: "info",
+	}
+
+	err := validateConfig(cfg)
+	if err == nil {
+		t.Fatal("expected error for invalid merge queue settings")
+	}
+	if !strings.Contains(err.Error(), "merge.queue.batch_size") {
+		t.Errorf("error should contain 'merge.queue.batch_size', got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "merge.queue.batch_window") {
+		t.Errorf("error should contain 'merge.queue.batch_window', got: %v", err)
+	}
+
+	// Disabled queue settings are not validated
+	cfg.Merge.Queue.Enabled = false
+	if err := validateConfig(cfg); err != nil {
+		t.Errorf("expected no error when merge queue disabled, got: %v", err)
+	}
+}
+
+func TestValidation_PullRequests(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.GitHub = GitHubConfig{Owner: "test", Repo: "repo"}
+
+	cfg.PullRequests.Mode = "octopus"
+	err := validateConfig(cfg)
+	if err == nil || !strings.Contains(err.Error(), "pull_requests.mode") {
+		t.Errorf("expected pull_requests.mode error, got: %v", err)
+	}
+
+	cfg.PullRequests = PullRequestConfig{Mode: PRModeStacked}
+	err = validateConfig(cfg)
+	if err == nil || !strings.Contains(err.Error(), "pull_requests.branch_prefix") {
+		t.Errorf("expected pull_requests.branch_prefix error, got: %v", err)
+	}
+
+	cfg.PullRequests.BranchPrefix = "stack/"
+	if err := validateConfig(cfg); err != nil {
+		t.Errorf("expected stacked mode to be valid, got: %v", err)
+	}
+}
+
+func TestValidation_CI(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.GitHub = GitHubConfig{Owner: "test", Repo: "repo"}
+
+	// Disabled CI fixes are not validated
+	cfg.CI = CIConfig{Timeout: "soon"}
+	if err := validateConfig(cfg); err != nil {
+		t.Errorf("expected no error when CI fixes disabled, got: %v", err)
+	}
+
+	cfg.CI = CIConfig{Fix: true, Timeout: "soon", PollInterval: "0s"}
+	err := validateConfig(cfg)
+	for _, field := range []string{"ci.max_attempts", "ci.timeout", "ci.poll_interval"} {
+		if err == nil || !strings.Contains(err.Error(), field) {
+			t.Errorf("expected %s error, got: %v", field, err)
+		}
+	}
+
+	cfg.CI = DefaultConfig().CI
+	cfg.CI.Fix = true
+	if err := validateConfig(cfg); err != nil {
+		t.Errorf("expected default CI settings to be valid, got: %v", err)
+	}
+}
+
+func TestValidation_ReviewMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.GitHub = GitHubConfig{Owner: "test", Repo: "repo"}
+
+	cfg.Review.Mode = "emoji"
+	err := validateConfig(cfg)
+	if err == nil || !strings.Contains(err.Error(), "review.mode") {
+		t.Errorf("expected review.mode error, got: %v", err)
+	}
+
+	cfg.Review.Mode = ReviewModeReviews
+	cfg.Review.RequiredApprovals = -1
+	err = validateConfig(cfg)
+	if err == nil || !strings.Contains(err.Error(), "review.required_approvals") {
+		t.Errorf("expected review.required_approvals error, got: %v", err)
+	}
+
+	cfg.Review.RequiredApprovals = 2
+	cfg.Review.RequireCodeOwners = true
+	if err := validateConfig(cfg); err != nil {
+		t.Errorf("expected reviews mode to be valid, got: %v", err)
+	}
+}
+
 func TestValidation_ReviewTimeout_Invalid(t *testing.T) {
 	cfg := &Config{
 		Parallelism: 4,
@@ -0,0 +1,218 @@
+package conflict
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/RevCBH/choo/internal/discovery"
+)
+
+// Level grades how likely two units are to conflict
+type Level string
+
+const (
+	// LevelHigh means both units touch the same files
+	LevelHigh Level = "high"
+
+	// LevelMedium means the units reference the same symbols but no shared files
+	LevelMedium Level = "medium"
+)
+
+// Source identifies how a risk was detected
+type Source string
+
+const (
+	// SourceSpec means the risk was predicted from task specs before dispatch
+	SourceSpec Source = "spec"
+
+	// SourceDiff means the risk was observed in the units' in-flight diffs
+	SourceDiff Source = "diff"
+)
+
+// Footprint is the set of files and symbols a unit is expected to touch
+type Footprint struct {
+	Files   map[string]bool
+	Symbols map[string]bool
+}
+
+// Risk describes an overlap between two units that may run in parallel
+type Risk struct {
+	UnitA   string
+	UnitB   string
+	Files   []string // Shared file paths, sorted
+	Symbols []string // Shared symbols, sorted
+	Source  Source
+}
+
+// Level returns the severity of the overlap
+func (r Risk) Level() Level {
+	if len(r.Files) > 0 {
+		return LevelHigh
+	}
+	return LevelMedium
+}
+
+// Payload returns the event payload for a unit.conflict_risk event
+func (r Risk) Payload() map[string]any {
+	return map[string]any{
+		"other_unit": r.UnitB,
+		"files":      r.Files,
+		"symbols":    r.Symbols,
+		"level":      string(r.Level()),
+		"source":     string(r.Source),
+	}
+}
+
+var (
+	// pathPattern matches relative file paths with at least one directory
+	// component and an extension, e.g. internal/worker/worker.go
+	pathPattern = regexp.MustCompile(`(?:[A-Za-z0-9_.-]+/)+[A-Za-z0-9_.-]+\.[A-Za-z0-9]+`)
+
+	// codeSpanPattern matches inline code spans
+	codeSpanPattern = regexp.MustCompile("`([^`\n]+)`")
+
+	// symbolPattern matches exported identifiers, optionally qualified,
+	// with an optional trailing call, e.g. Worker.Run() or NewPool
+	symbolPattern = regexp.MustCompile(`^(?:[a-z][A-Za-z0-9_]*\.)?([A-Z][A-Za-z0-9_]*(?:\.[A-Z][A-Za-z0-9_]*)?)(?:\(\))?$`)
+)
+
+// ExtractFootprint scans a unit's task specs for referenced file paths and
+// exported symbols. Paths inside URLs and spec files are ignored.
+func ExtractFootprint(unit *discovery.Unit) Footprint {
+	fp := Footprint{
+		Files:   make(map[string]bool),
+		Symbols: make(map[string]bool),
+	}
+
+	for _, task := range unit.Tasks {
+		for _, line := range strings.Split(task.Content, "\n") {
+			for _, loc := range pathPattern.FindAllStringIndex(line, -1) {
+				if isURL(line[:loc[0]]) {
+					continue
+				}
+				p := path.Clean(strings.TrimPrefix(line[loc[0]:loc[1]], "./"))
+				if strings.HasPrefix(p, "specs/") || strings.HasPrefix(p, "../") {
+					continue
+				}
+				fp.Files[p] = true
+			}
+
+			for _, m := range codeSpanPattern.FindAllStringSubmatch(line, -1) {
+				if sm := symbolPattern.FindStringSubmatch(strings.TrimSpace(m[1])); sm != nil && len(sm[1]) >= 3 {
+					fp.Symbols[sm[1]] = true
+				}
+			}
+		}
+	}
+
+	return fp
+}
+
+// isURL reports whether the text preceding a match ends inside a URL
+func isURL(prefix string) bool {
+	i := strings.LastIndexAny(prefix, " \t(<[`\"'")
+	return strings.Contains(prefix[i+1:], "://")
+}
+
+// Analyze predicts conflicts between units that may run in parallel.
+// Units related by a (transitive) dependency never run concurrently and
+// are not compared. Results are ordered by unit pair.
+func Analyze(units []*discovery.Unit) []Risk {
+	footprints := make(map[string]Footprint, len(units))
+	for _, u := range units {
+		footprints[u.ID] = ExtractFootprint(u)
+	}
+
+	ancestors := transitiveDeps(units)
+
+	var risks []Risk
+	for i, a := range units {
+		for _, b := range units[i+1:] {
+			if ancestors[a.ID][b.ID] || ancestors[b.ID][a.ID] {
+				continue
+			}
+			files := intersect(footprints[a.ID].Files, footprints[b.ID].Files)
+			symbols := intersect(footprints[a.ID].Symbols, footprints[b.ID].Symbols)
+			if len(files) == 0 && len(symbols) == 0 {
+				continue
+			}
+			risks = append(risks, newRisk(a.ID, b.ID, files, symbols, SourceSpec))
+		}
+	}
+	return risks
+}
+
+// CompareChanges flags units whose changed files overlap.
+// changes maps unit ID to the files that unit has modified so far.
+func CompareChanges(changes map[string][]string) []Risk {
+	ids := make([]string, 0, len(changes))
+	sets := make(map[string]map[string]bool, len(changes))
+	for id, files := range changes {
+		ids = append(ids, id)
+		set := make(map[string]bool, len(files))
+		for _, f := range files {
+			set[f] = true
+		}
+		sets[id] = set
+	}
+	sort.Strings(ids)
+
+	var risks []Risk
+	for i, a := range ids {
+		for _, b := range ids[i+1:] {
+			if files := intersect(sets[a], sets[b]); len(files) > 0 {
+				risks = append(risks, newRisk(a, b, files, nil, SourceDiff))
+			}
+		}
+	}
+	return risks
+}
+
+// newRisk builds a Risk with units in a stable order
+func newRisk(a, b string, files, symbols []string, source Source) Risk {
+	if b < a {
+		a, b = b, a
+	}
+	return Risk{UnitA: a, UnitB: b, Files: files, Symbols: symbols, Source: source}
+}
+
+// transitiveDeps returns, for each unit, the set of units it depends on
+// directly or indirectly
+func transitiveDeps(units []*discovery.Unit) map[string]map[string]bool {
+	direct := make(map[string][]string, len(units))
+	for _, u := range units {
+		direct[u.ID] = u.DependsOn
+	}
+
+	result := make(map[string]map[string]bool, len(units))
+	var visit func(id string, into map[string]bool)
+	visit = func(id string, into map[string]bool) {
+		for _, dep := range direct[id] {
+			if into[dep] {
+				continue
+			}
+			into[dep] = true
+			visit(dep, into)
+		}
+	}
+	for _, u := range units {
+		deps := make(map[string]bool)
+		visit(u.ID, deps)
+		result[u.ID] = deps
+	}
+	return result
+}
+
+// intersect returns the sorted keys present in both sets
+func intersect(a, b map[string]bool) []string {
+	var out []string
+	for k := range a {
+		if b[k] {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
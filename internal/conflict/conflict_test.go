@@ -0,0 +1,131 @@
+package conflict
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/discovery"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func unitWithTask(id string, content string, deps ...string) *discovery.Unit {
+	return &discovery.Unit{
+		ID:        id,
+		DependsOn: deps,
+		Tasks:     []*discovery.Task{{Number: 1, Content: content}},
+	}
+}
+
+func TestExtractFootprint(t *testing.T) {
+	unit := unitWithTask("web", strings.Join([]string{
+		"# Task 1: Add handler",
+		"Edit `internal/web/server.go` and ./internal/web/handlers.go.",
+		"Call `Store.HandleEvent()` and add `NewHub`; keep `ok` and `x` as is.",
+		"See https://example.com/docs/guide.html and specs/tasks/web/01-handler.md",
+		"Use `web.Broadcast` from pkg.",
+	}, "\n"))
+
+	fp := ExtractFootprint(unit)
+
+	assert.Equal(t, map[string]bool{
+		"internal/web/server.go":   true,
+		"internal/web/handlers.go": true,
+	}, fp.Files)
+	assert.Equal(t, map[string]bool{
+		"Store.HandleEvent": true,
+		"NewHub":            true,
+		"Broadcast":         true,
+	}, fp.Symbols)
+}
+
+func TestAnalyze(t *testing.T) {
+	units := []*discovery.Unit{
+		unitWithTask("a", "Modify internal/config/config.go to add `LoadConfig` option"),
+		unitWithTask("b", "Update internal/config/config.go defaults"),
+		unitWithTask("c", "Document `LoadConfig` in docs/usage.md"),
+		// d depends on a, so they never run concurrently
+		unitWithTask("d", "Refactor internal/config/config.go", "a"),
+		unitWithTask("e", "Nothing in common: cmd/tool/main.go"),
+	}
+
+	risks := Analyze(units)
+
+	byPair := make(map[string]Risk)
+	for _, r := range risks {
+		byPair[r.UnitA+"-"+r.UnitB] = r
+	}
+
+	require.Contains(t, byPair, "a-b")
+	assert.Equal(t, []string{"internal/config/config.go"}, byPair["a-b"].Files)
+	assert.Equal(t, LevelHigh, byPair["a-b"].Level())
+	assert.Equal(t, SourceSpec, byPair["a-b"].Source)
+
+	require.Contains(t, byPair, "a-c")
+	assert.Empty(t, byPair["a-c"].Files)
+	assert.Equal(t, []string{"LoadConfig"}, byPair["a-c"].Symbols)
+	assert.Equal(t, LevelMedium, byPair["a-c"].Level())
+
+	require.Contains(t, byPair, "b-d")
+	assert.NotContains(t, byPair, "a-d")
+	assert.NotContains(t, byPair, "a-e")
+}
+
+func TestCompareChanges(t *testing.T) {
+	risks := CompareChanges(map[string][]string{
+		"b": {"go.mod", "internal/x.go"},
+		"a": {"internal/x.go", "README.md"},
+		"c": {"other.go"},
+	})
+
+	require.Len(t, risks, 1)
+	assert.Equal(t, "a", risks[0].UnitA)
+	assert.Equal(t, "b", risks[0].UnitB)
+	assert.Equal(t, []string{"internal/x.go"}, risks[0].Files)
+	assert.Equal(t, SourceDiff, risks[0].Source)
+}
+
+func TestMonitor_Check(t *testing.T) {
+	testutil.UnsetGitEnv()
+
+	repo := t.TempDir()
+	testutil.Git(t, repo, "init", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "shared.go"), []byte("package x\n"), 0644))
+	testutil.Git(t, repo, "add", "-A")
+	testutil.Git(t, repo, "commit", "-m", "initial")
+
+	base := filepath.Join(repo, ".ralph", "worktrees")
+	for _, unit := range []string{"a", "b"} {
+		path := filepath.Join(base, unit)
+		testutil.Git(t, repo, "worktree", "add", "-b", "ralph/"+unit, path, "main")
+	}
+
+	// a commits a change, b has an uncommitted edit to the same file
+	require.NoError(t, os.WriteFile(filepath.Join(base, "a", "shared.go"), []byte("package x\n// a\n"), 0644))
+	testutil.Git(t, filepath.Join(base, "a"), "commit", "-am", "a")
+	require.NoError(t, os.WriteFile(filepath.Join(base, "b", "shared.go"), []byte("package x\n// b\n"), 0644))
+
+	bus := events.NewBus(10)
+	defer bus.Close()
+	collector := events.NewEventCollector(bus)
+
+	m := NewMonitor(bus, base, "main", func() []string { return []string{"a", "b", "missing"} })
+
+	risks := m.Check(context.Background())
+	require.Len(t, risks, 1)
+	assert.Equal(t, []string{"shared.go"}, risks[0].Files)
+
+	// Already reported overlaps are not repeated
+	assert.Empty(t, m.Check(context.Background()))
+
+	bus.Wait()
+	evts := collector.Get()
+	require.Len(t, evts, 1)
+	assert.Equal(t, events.UnitConflictRisk, evts[0].Type)
+	assert.Equal(t, "a", evts[0].Unit)
+}
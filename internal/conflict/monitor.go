@@ -0,0 +1,135 @@
+package conflict
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/git"
+)
+
+// Monitor periodically compares the diffs of running units against each
+// other and emits unit.conflict_risk events for newly overlapping edits.
+type Monitor struct {
+	bus          *events.Bus
+	runner       git.Runner
+	worktreeBase string
+	targetBranch string
+	active       func() []string // Returns IDs of units currently running
+
+	mu       sync.Mutex
+	reported map[string]map[string]bool // pair key -> files already reported
+}
+
+// NewMonitor creates a diff monitor. Each active unit's worktree is expected
+// at worktreeBase/<unit-id>, branched from targetBranch.
+func NewMonitor(bus *events.Bus, worktreeBase, targetBranch string, active func() []string) *Monitor {
+	return &Monitor{
+		bus:          bus,
+		runner:       git.DefaultRunner(),
+		worktreeBase: worktreeBase,
+		targetBranch: targetBranch,
+		active:       active,
+		reported:     make(map[string]map[string]bool),
+	}
+}
+
+// Run checks diffs every interval until ctx is cancelled
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Check(ctx)
+		}
+	}
+}
+
+// Check compares the current diffs of all active units once and returns
+// the risks that were newly reported. Units whose diff cannot be read
+// (e.g. worktree not created yet) are skipped.
+func (m *Monitor) Check(ctx context.Context) []Risk {
+	changes := make(map[string][]string)
+	for _, id := range m.active() {
+		files, err := m.changedFiles(ctx, filepath.Join(m.worktreeBase, id))
+		if err != nil || len(files) == 0 {
+			continue
+		}
+		changes[id] = files
+	}
+
+	var fresh []Risk
+	for _, risk := range CompareChanges(changes) {
+		if newFiles := m.markReported(risk); len(newFiles) > 0 {
+			risk.Files = newFiles
+			fresh = append(fresh, risk)
+			Emit(m.bus, risk)
+		}
+	}
+	return fresh
+}
+
+// markReported records the risk's files and returns those not yet reported
+// for the pair, so a growing overlap is reported incrementally
+func (m *Monitor) markReported(r Risk) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := r.UnitA + "\x00" + r.UnitB
+	seen := m.reported[key]
+	if seen == nil {
+		seen = make(map[string]bool)
+		m.reported[key] = seen
+	}
+
+	var fresh []string
+	for _, f := range r.Files {
+		if !seen[f] {
+			seen[f] = true
+			fresh = append(fresh, f)
+		}
+	}
+	return fresh
+}
+
+// changedFiles lists files changed in a worktree relative to the point it
+// branched from the target, including uncommitted edits
+func (m *Monitor) changedFiles(ctx context.Context, worktree string) ([]string, error) {
+	committed, err := m.runner.Exec(ctx, worktree, "diff", "--name-only", m.targetBranch+"...HEAD")
+	if err != nil {
+		return nil, err
+	}
+	uncommitted, err := m.runner.Exec(ctx, worktree, "diff", "--name-only", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]bool)
+	for _, line := range strings.Split(committed+"\n"+uncommitted, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			set[line] = true
+		}
+	}
+	files := make([]string, 0, len(set))
+	for f := range set {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Emit publishes a unit.conflict_risk event for the risk
+func Emit(bus *events.Bus, r Risk) {
+	if bus == nil {
+		return
+	}
+	bus.Emit(events.NewEvent(events.UnitConflictRisk, r.UnitA).WithPayload(r.Payload()))
+}
@@ -0,0 +1,555 @@
+package daemon
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+)
+
+// archiveFormat is the version of the job archive layout. Archives with a
+// newer format are refused.
+const archiveFormat = 1
+
+// Job archive layout: a gzipped tar holding these files, plus provider logs
+// under logs/ and diffs against the target branch under diffs/
+const (
+	archiveManifestFile = "manifest.json"
+	archiveRunFile      = "run.json"
+	archiveUnitsFile    = "units.json"
+	archiveEventsFile   = "events.jsonl"
+	archiveLogsDir      = "logs"
+	archiveDiffsDir     = "diffs"
+)
+
+// maxArchiveEntrySize bounds each file read from an imported archive, so a
+// malformed or hostile archive cannot exhaust the daemon's memory
+const maxArchiveEntrySize = 256 << 20
+
+// logWindowSlack widens a run's time window when matching provider logs by
+// modification time
+const logWindowSlack = time.Minute
+
+// ErrRunNotFound is returned when exporting a run the daemon has no record of.
+var ErrRunNotFound = errors.New("run not found")
+
+// archiveManifest describes a job archive
+type archiveManifest struct {
+	Format     int       `json:"format"`
+	RunID      string    `json:"run_id"`
+	ExportedAt time.Time `json:"exported_at"`
+	Files      []string  `json:"files"` // Logs and diffs in the archive
+}
+
+// archiveRun is a run row as stored in an archive
+type archiveRun struct {
+	ID            string          `json:"id"`
+	FeatureBranch string          `json:"feature_branch"`
+	RepoPath      string          `json:"repo_path"`
+	TargetBranch  string          `json:"target_branch"`
+	TasksDir      string          `json:"tasks_dir"`
+	Parallelism   int             `json:"parallelism"`
+	Status        string          `json:"status"`
+	DaemonVersion string          `json:"daemon_version"`
+	StartedAt     *time.Time      `json:"started_at,omitempty"`
+	CompletedAt   *time.Time      `json:"completed_at,omitempty"`
+	Error         *string         `json:"error,omitempty"`
+	Config        json.RawMessage `json:"config,omitempty"`
+}
+
+// archiveUnit is a unit row as stored in an archive
+type archiveUnit struct {
+	UnitID       string     `json:"unit_id"`
+	Status       string     `json:"status"`
+	Branch       *string    `json:"branch,omitempty"`
+	WorktreePath *string    `json:"worktree_path,omitempty"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	Error        *string    `json:"error,omitempty"`
+}
+
+// archiveEvent is one line of an archive's event log
+type archiveEvent struct {
+	Sequence int             `json:"sequence"`
+	Type     string          `json:"type"`
+	Unit     *string         `json:"unit,omitempty"`
+	Payload  json.RawMessage `json:"payload,omitempty"`
+	Time     time.Time       `json:"time"`
+}
+
+// ImportResult describes a job archive loaded into the daemon.
+type ImportResult struct {
+	RunID    string
+	Units    int
+	Events   int
+	FilesDir string // Where the archive's logs and diffs were extracted
+}
+
+// JobArchiver exports runs as self-contained archives and imports archives
+// exported by other daemons, for post-mortems.
+type JobArchiver struct {
+	db        *db.DB
+	importDir string
+	now       func() time.Time
+}
+
+// NewJobArchiver creates an archiver that extracts the logs and diffs of
+// imported archives under importDir.
+func NewJobArchiver(database *db.DB, importDir string) *JobArchiver {
+	return &JobArchiver{db: database, importDir: importDir, now: time.Now}
+}
+
+// Export writes the archive of a run to w: the run row, its units, its
+// event log as JSONL, the provider logs of its units and the diffs of its
+// branches against the target branch. Logs and diffs are included when the
+// repository is still on this host.
+func (a *JobArchiver) Export(ctx context.Context, runID string, w io.Writer) error {
+	run, err := a.db.GetRun(runID)
+	if err != nil {
+		return err
+	}
+	if run == nil {
+		return fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+	}
+	units, err := a.db.ListUnitsByRun(runID)
+	if err != nil {
+		return err
+	}
+	records, err := a.db.ListEvents(runID)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := a.now()
+	manifest := archiveManifest{Format: archiveFormat, RunID: runID, ExportedAt: now}
+
+	if err := writeArchiveJSON(tw, archiveRunFile, runToArchive(run), now); err != nil {
+		return err
+	}
+	archived := make([]archiveUnit, 0, len(units))
+	for _, u := range units {
+		archived = append(archived, archiveUnit{
+			UnitID:       u.UnitID,
+			Status:       u.Status,
+			Branch:       u.Branch,
+			WorktreePath: u.WorktreePath,
+			StartedAt:    u.StartedAt,
+			CompletedAt:  u.CompletedAt,
+			Error:        u.Error,
+		})
+	}
+	if err := writeArchiveJSON(tw, archiveUnitsFile, archived, now); err != nil {
+		return err
+	}
+
+	var lines strings.Builder
+	enc := json.NewEncoder(&lines)
+	for _, r := range records {
+		e := archiveEvent{Sequence: r.Sequence, Type: r.EventType, Unit: r.UnitID, Time: r.CreatedAt}
+		if r.PayloadJSON != nil {
+			e.Payload = json.RawMessage(*r.PayloadJSON)
+		}
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("failed to encode event %d: %w", r.Sequence, err)
+		}
+	}
+	if err := writeArchiveFile(tw, archiveEventsFile, []byte(lines.String()), now); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(run.RepoPath); err == nil {
+		for _, f := range runLogFiles(run, units, now) {
+			data, err := os.ReadFile(f.path)
+			if err != nil {
+				continue
+			}
+			if err := writeArchiveFile(tw, f.name, data, f.modTime); err != nil {
+				return err
+			}
+			manifest.Files = append(manifest.Files, f.name)
+		}
+		for name, diff := range runDiffs(ctx, run, units) {
+			if err := writeArchiveFile(tw, name, diff, now); err != nil {
+				return err
+			}
+			manifest.Files = append(manifest.Files, name)
+		}
+	}
+
+	if err := writeArchiveJSON(tw, archiveManifestFile, manifest, now); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// Import loads an archive written by Export. The run keeps its ID and
+// history; a run exported while still running is recorded as cancelled so
+// this daemon does not try to resume it. Logs and diffs are extracted under
+// the import directory, in a directory named after the run.
+func (a *JobArchiver) Import(r io.Reader) (*ImportResult, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a job archive: %w", err)
+	}
+	defer gz.Close()
+
+	var (
+		manifest *archiveManifest
+		run      *archiveRun
+		units    []archiveUnit
+		records  []*db.EventRecord
+		files    = make(map[string][]byte)
+	)
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if hdr.Size > maxArchiveEntrySize {
+			return nil, fmt.Errorf("%s is too large (%d bytes, limit %d)", hdr.Name, hdr.Size, maxArchiveEntrySize)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxArchiveEntrySize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", hdr.Name, err)
+		}
+		if len(data) > maxArchiveEntrySize {
+			return nil, fmt.Errorf("%s is larger than the %d byte limit", hdr.Name, maxArchiveEntrySize)
+		}
+
+		switch name := path.Clean(hdr.Name); name {
+		case archiveManifestFile:
+			manifest = &archiveManifest{}
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+		case archiveRunFile:
+			run = &archiveRun{}
+			if err := json.Unmarshal(data, run); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+		case archiveUnitsFile:
+			if err := json.Unmarshal(data, &units); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+		case archiveEventsFile:
+			records, err = decodeArchiveEvents(data)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			if isArchiveArtifact(name) {
+				files[name] = data
+			}
+		}
+	}
+
+	if manifest == nil || run == nil {
+		return nil, fmt.Errorf("not a job archive: missing %s or %s", archiveManifestFile, archiveRunFile)
+	}
+	if manifest.Format > archiveFormat {
+		return nil, fmt.Errorf("archive format %d is newer than this daemon supports (%d)", manifest.Format, archiveFormat)
+	}
+	if run.ID == "" || run.ID != manifest.RunID || strings.ContainsAny(run.ID, `/\`) || run.ID == "." || run.ID == ".." {
+		return nil, fmt.Errorf("archive has an invalid run ID %q", run.ID)
+	}
+
+	dbRun := runFromArchive(run)
+	switch dbRun.Status {
+	case db.RunStatusCompleted, db.RunStatusFailed, db.RunStatusCancelled:
+	default:
+		msg := fmt.Sprintf("exported while %s", dbRun.Status)
+		if dbRun.Error != nil {
+			msg = *dbRun.Error + "; " + msg
+		}
+		dbRun.Status = db.RunStatusCancelled
+		dbRun.Error = &msg
+	}
+
+	dbUnits := make([]*db.UnitRecord, 0, len(units))
+	for _, u := range units {
+		dbUnits = append(dbUnits, &db.UnitRecord{
+			UnitID:       u.UnitID,
+			Status:       u.Status,
+			Branch:       u.Branch,
+			WorktreePath: u.WorktreePath,
+			StartedAt:    u.StartedAt,
+			CompletedAt:  u.CompletedAt,
+			Error:        u.Error,
+		})
+	}
+	if err := a.db.ImportRun(dbRun, dbUnits, records); err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{RunID: run.ID, Units: len(dbUnits), Events: len(records)}
+	if len(files) > 0 {
+		dir := filepath.Join(a.importDir, run.ID)
+		for name, data := range files {
+			dest := filepath.Join(dir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return result, fmt.Errorf("failed to create %s: %w", filepath.Dir(dest), err)
+			}
+			if err := os.WriteFile(dest, data, 0644); err != nil {
+				return result, fmt.Errorf("failed to write %s: %w", dest, err)
+			}
+		}
+		result.FilesDir = dir
+	}
+	return result, nil
+}
+
+// runToArchive converts a run row for an archive
+func runToArchive(run *db.Run) archiveRun {
+	a := archiveRun{
+		ID:            run.ID,
+		FeatureBranch: run.FeatureBranch,
+		RepoPath:      run.RepoPath,
+		TargetBranch:  run.TargetBranch,
+		TasksDir:      run.TasksDir,
+		Parallelism:   run.Parallelism,
+		Status:        string(run.Status),
+		DaemonVersion: run.DaemonVersion,
+		StartedAt:     run.StartedAt,
+		CompletedAt:   run.CompletedAt,
+		Error:         run.Error,
+	}
+	if json.Valid([]byte(run.ConfigJSON)) {
+		a.Config = json.RawMessage(run.ConfigJSON)
+	}
+	return a
+}
+
+// runFromArchive converts an archived run back to a run row
+func runFromArchive(a *archiveRun) *db.Run {
+	return &db.Run{
+		ID:            a.ID,
+		FeatureBranch: a.FeatureBranch,
+		RepoPath:      a.RepoPath,
+		TargetBranch:  a.TargetBranch,
+		TasksDir:      a.TasksDir,
+		Parallelism:   a.Parallelism,
+		Status:        db.RunStatus(a.Status),
+		DaemonVersion: a.DaemonVersion,
+		StartedAt:     a.StartedAt,
+		CompletedAt:   a.CompletedAt,
+		Error:         a.Error,
+		ConfigJSON:    string(a.Config),
+	}
+}
+
+// decodeArchiveEvents parses an archive's JSONL event log
+func decodeArchiveEvents(data []byte) ([]*db.EventRecord, error) {
+	var records []*db.EventRecord
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	for {
+		var e archiveEvent
+		if err := dec.Decode(&e); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", archiveEventsFile, err)
+		}
+		r := &db.EventRecord{Sequence: e.Sequence, EventType: e.Type, UnitID: e.Unit, CreatedAt: e.Time}
+		if len(e.Payload) > 0 && string(e.Payload) != "null" {
+			payload := string(e.Payload)
+			r.PayloadJSON = &payload
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// isArchiveArtifact reports whether name is a log or diff, safe to extract
+func isArchiveArtifact(name string) bool {
+	if !strings.HasPrefix(name, archiveLogsDir+"/") && !strings.HasPrefix(name, archiveDiffsDir+"/") {
+		return false
+	}
+	return !strings.HasPrefix(name, "/") && !strings.Contains(name, "..")
+}
+
+// logFile is a provider log selected for an archive
+type logFile struct {
+	path    string
+	name    string // Name in the archive
+	modTime time.Time
+}
+
+// runLogFiles finds the provider logs of a run's units: the files under the
+// worktree logs directory named after one of its units and written while
+// the run was going
+func runLogFiles(run *db.Run, units []*db.UnitRecord, now time.Time) []logFile {
+	if len(units) == 0 {
+		return nil
+	}
+	from := now
+	if run.StartedAt != nil {
+		from = *run.StartedAt
+	}
+	to := now
+	if run.CompletedAt != nil {
+		to = *run.CompletedAt
+	}
+	from, to = from.Add(-logWindowSlack), to.Add(logWindowSlack)
+
+	base := filepath.Join(worktreeBaseFor(run.RepoPath), worktreeLogsDir)
+	var files []logFile
+	_ = filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().Before(from) || info.ModTime().After(to) {
+			return nil
+		}
+		name := d.Name()
+		for _, u := range units {
+			if strings.HasPrefix(name, u.UnitID+"-") || strings.Contains(name, "-"+u.UnitID+"-") {
+				rel, err := filepath.Rel(base, p)
+				if err != nil {
+					return nil
+				}
+				files = append(files, logFile{
+					path:    p,
+					name:    path.Join(archiveLogsDir, filepath.ToSlash(rel)),
+					modTime: info.ModTime(),
+				})
+				break
+			}
+		}
+		return nil
+	})
+	return files
+}
+
+// runDiffs returns the diffs of a run's feature branch and unit branches
+// against the target branch, keyed by archive name. Branches that no
+// longer exist are left out.
+func runDiffs(ctx context.Context, run *db.Run, units []*db.UnitRecord) map[string][]byte {
+	diffs := make(map[string][]byte)
+	add := func(name, branch string) {
+		cmd := exec.CommandContext(ctx, "git", "diff", run.TargetBranch+"..."+branch)
+		cmd.Dir = run.RepoPath
+		out, err := cmd.Output()
+		if err != nil || len(out) == 0 {
+			return
+		}
+		diffs[path.Join(archiveDiffsDir, name+".diff")] = out
+	}
+
+	if run.FeatureBranch != "" {
+		add("feature", run.FeatureBranch)
+	}
+	for _, u := range units {
+		if u.Branch != nil && *u.Branch != "" {
+			add("units/"+u.UnitID, *u.Branch)
+		}
+	}
+	return diffs
+}
+
+// writeArchiveJSON adds v to the archive as indented JSON
+func writeArchiveJSON(tw *tar.Writer, name string, v any, modTime time.Time) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return writeArchiveFile(tw, name, append(data, '\n'), modTime)
+}
+
+// writeArchiveFile adds a regular file to the archive
+func writeArchiveFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// archiveChunkSize is the size of the chunks archives are streamed in
+const archiveChunkSize = 64 * 1024
+
+// archiveChunkWriter buffers an archive into chunks passed to send
+type archiveChunkWriter struct {
+	send func(data []byte) error
+	buf  []byte
+}
+
+func (w *archiveChunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		take := min(archiveChunkSize-len(w.buf), len(p))
+		w.buf = append(w.buf, p[:take]...)
+		p = p[take:]
+		if len(w.buf) == archiveChunkSize {
+			if err := w.Flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Flush sends the buffered partial chunk, if any
+func (w *archiveChunkWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	chunk := w.buf
+	w.buf = make([]byte, 0, archiveChunkSize)
+	return w.send(chunk)
+}
+
+// archiveChunkReader reads an archive from chunks returned by recv, which
+// returns io.EOF after the last one
+type archiveChunkReader struct {
+	recv func() ([]byte, error)
+	buf  []byte
+}
+
+func (r *archiveChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		data, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,185 @@
+package daemon
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupArchivedRun creates a finished run of one unit whose branch holds a
+// commit, with a provider log and a few events
+func setupArchivedRun(t *testing.T, database *db.DB, status db.RunStatus) *db.Run {
+	t.Helper()
+	repo := setupGCRepo(t, "unit-a")
+	worktree := filepath.Join(repo, ".ralph", "worktrees", "unit-a")
+	require.NoError(t, os.WriteFile(filepath.Join(worktree, "feature.txt"), []byte("new feature\n"), 0644))
+	testutil.Git(t, worktree, "add", "feature.txt")
+	testutil.Git(t, worktree, "commit", "-m", "add feature")
+
+	run := createGCRun(t, database, repo, status, time.Now())
+
+	branch := "ralph/unit-a-abc123"
+	unit := &db.UnitRecord{
+		ID:           db.MakeUnitRecordID(run.ID, "unit-a"),
+		RunID:        run.ID,
+		UnitID:       "unit-a",
+		Status:       string(db.UnitStatusCompleted),
+		Branch:       &branch,
+		WorktreePath: &worktree,
+	}
+	require.NoError(t, database.CreateUnit(unit))
+
+	unitID := "unit-a"
+	for _, typ := range []events.EventType{events.UnitStarted, events.TaskCompleted, events.UnitCompleted} {
+		require.NoError(t, database.AppendEvent(run.ID, string(typ), &unitID, events.NewEvent(typ, unitID)))
+	}
+
+	logs := filepath.Join(worktreeBaseFor(repo), worktreeLogsDir)
+	require.NoError(t, os.MkdirAll(logs, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(logs, "claude-unit-a-1700000000.log"), []byte("provider output\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(logs, "claude-unit-b-1700000000.log"), []byte("other unit\n"), 0644))
+
+	return run
+}
+
+func TestJobArchiver_RoundTrip(t *testing.T) {
+	source := setupTestDB(t)
+	run := setupArchivedRun(t, source, db.RunStatusCompleted)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewJobArchiver(source, t.TempDir()).Export(context.Background(), run.ID, &buf))
+
+	target := setupTestDB(t)
+	importDir := t.TempDir()
+	result, err := NewJobArchiver(target, importDir).Import(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, run.ID, result.RunID)
+	assert.Equal(t, 1, result.Units)
+	assert.Equal(t, 3, result.Events)
+	assert.Equal(t, filepath.Join(importDir, run.ID), result.FilesDir)
+
+	imported, err := target.GetRun(run.ID)
+	require.NoError(t, err)
+	require.NotNil(t, imported)
+	assert.Equal(t, db.RunStatusCompleted, imported.Status)
+	assert.Equal(t, run.RepoPath, imported.RepoPath)
+
+	units, err := target.ListUnitsByRun(run.ID)
+	require.NoError(t, err)
+	require.Len(t, units, 1)
+	assert.Equal(t, "ralph/unit-a-abc123", *units[0].Branch)
+
+	original, err := source.ListEvents(run.ID)
+	require.NoError(t, err)
+	evts, err := target.ListEvents(run.ID)
+	require.NoError(t, err)
+	require.Len(t, evts, len(original))
+	for i := range evts {
+		assert.Equal(t, original[i].Sequence, evts[i].Sequence)
+		assert.Equal(t, original[i].EventType, evts[i].EventType)
+		assert.Equal(t, *original[i].PayloadJSON, *evts[i].PayloadJSON)
+	}
+
+	log, err := os.ReadFile(filepath.Join(result.FilesDir, "logs", "claude-unit-a-1700000000.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "provider output\n", string(log))
+	assert.NoFileExists(t, filepath.Join(result.FilesDir, "logs", "claude-unit-b-1700000000.log"))
+
+	diff, err := os.ReadFile(filepath.Join(result.FilesDir, "diffs", "units", "unit-a.diff"))
+	require.NoError(t, err)
+	assert.Contains(t, string(diff), "+new feature")
+
+	// The same run cannot be imported twice
+	_, err = NewJobArchiver(target, importDir).Import(bytes.NewReader(buf.Bytes()))
+	assert.ErrorContains(t, err, "already exists")
+}
+
+func TestJobArchiver_ImportCancelsUnfinishedRun(t *testing.T) {
+	source := setupTestDB(t)
+	run := setupArchivedRun(t, source, db.RunStatusRunning)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewJobArchiver(source, t.TempDir()).Export(context.Background(), run.ID, &buf))
+
+	target := setupTestDB(t)
+	_, err := NewJobArchiver(target, t.TempDir()).Import(&buf)
+	require.NoError(t, err)
+
+	imported, err := target.GetRun(run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, db.RunStatusCancelled, imported.Status)
+	require.NotNil(t, imported.Error)
+	assert.Contains(t, *imported.Error, "exported while running")
+}
+
+func TestJobArchiver_ExportUnknownRun(t *testing.T) {
+	database := setupTestDB(t)
+
+	err := NewJobArchiver(database, t.TempDir()).Export(context.Background(), "missing", &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrRunNotFound)
+}
+
+func TestJobArchiver_ImportRejectsNonArchive(t *testing.T) {
+	database := setupTestDB(t)
+
+	_, err := NewJobArchiver(database, t.TempDir()).Import(bytes.NewReader([]byte("not an archive")))
+	assert.ErrorContains(t, err, "not a job archive")
+}
+
+func TestJobArchiver_ImportRejectsOversizedEntries(t *testing.T) {
+	database := setupTestDB(t)
+
+	// Only the header is written: the size it declares is refused before
+	// the entry is read
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     archiveEventsFile,
+		Mode:     0644,
+		Size:     maxArchiveEntrySize + 1,
+		Typeflag: tar.TypeReg,
+	}))
+	require.NoError(t, gz.Close())
+
+	_, err := NewJobArchiver(database, t.TempDir()).Import(&buf)
+	assert.ErrorContains(t, err, "too large")
+}
+
+func TestArchiveChunks_RoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), archiveChunkSize/4)
+
+	var chunks [][]byte
+	w := &archiveChunkWriter{send: func(chunk []byte) error {
+		chunks = append(chunks, chunk)
+		return nil
+	}}
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	assert.Len(t, chunks, 3)
+
+	r := &archiveChunkReader{recv: func() ([]byte, error) {
+		if len(chunks) == 0 {
+			return nil, io.EOF
+		}
+		chunk := chunks[0]
+		chunks = chunks[1:]
+		return chunk, nil
+	}}
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+}
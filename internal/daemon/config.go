@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // Config holds daemon configuration with sensible defaults.
@@ -12,12 +13,38 @@ type Config struct {
 	PIDFile       string // Default: ~/.choo/daemon.pid
 	DBPath        string // Default: ~/.choo/choo.db
 	MaxJobs       int    // Default: 10
+	MaxRepoJobs   int    // Max jobs per repository; 0 = only MaxJobs applies
+	WorkspaceDir  string // Default: ~/.choo/workspaces; clones of registered workspaces
 	WebAddr       string // Default: :8080
 	WebSocketPath string // Default: ~/.choo/web.sock
+	JobsDir       string // Default: ~/.choo/jobs; journals and sockets of job runners; empty runs jobs in the daemon process
 
 	ContainerMode    bool   // Enable container isolation for job execution
 	ContainerImage   string // Container image to use, e.g., "choo:latest"
 	ContainerRuntime string // "auto", "docker", or "podman"
+
+	GCInterval        time.Duration // Default: 1h; 0 disables background worktree GC
+	WorktreeRetention time.Duration // Default: 72h; idle time before a finished run's worktrees are removed
+	WorktreeQuota     int64         // Max bytes of worktrees across repos; 0 = unlimited
+	GCPruneBranches   bool          // Default: true; delete stale ralph/* unit branches
+	GCDryRun          bool          // Report what GC would remove without deleting anything
+
+	FeatureWatchInterval time.Duration // Default: 5m; 0 disables closing out features whose PR merged
+
+	EventRetention    time.Duration // Default: 720h; time after which finished runs and their events are deleted; 0 keeps them
+	MaxRuns           int           // Finished runs to keep, newest first; 0 = unlimited
+	EventCompactAfter time.Duration // Default: 24h; time after which a finished run's task events are summarized; 0 disables
+	ImportDir         string        // Default: ~/.choo/imports; logs and diffs of imported job archives
+
+	OTLPEndpoint string // OTLP/HTTP collector for job traces, e.g. "http://localhost:4318"; empty disables tracing
+
+	Version string // Default: "dev"; build version reported to clients and traces
+
+	RemoteAddr  string // TCP address for remote clients, e.g. ":7443"; empty disables
+	TLSCert     string // Server certificate for the TCP listener and the web server
+	TLSKey      string // Server key for the TCP listener and the web server
+	TLSClientCA string // CA for client certificates (mutual TLS); empty = tokens only
+	AccessFile  string // Default: ~/.choo/remote-access.yaml; tokens and permissions of remote clients
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -35,8 +62,24 @@ func DefaultConfig() (*Config, error) {
 		PIDFile:       filepath.Join(chooDir, "daemon.pid"),
 		DBPath:        filepath.Join(chooDir, "choo.db"),
 		MaxJobs:       10,
+		WorkspaceDir:  filepath.Join(chooDir, "workspaces"),
 		WebAddr:       ":8080",
 		WebSocketPath: filepath.Join(chooDir, "web.sock"),
+		JobsDir:       filepath.Join(chooDir, "jobs"),
+
+		GCInterval:        time.Hour,
+		WorktreeRetention: 72 * time.Hour,
+		GCPruneBranches:   true,
+
+		FeatureWatchInterval: 5 * time.Minute,
+
+		EventRetention:    720 * time.Hour,
+		EventCompactAfter: 24 * time.Hour,
+		ImportDir:         filepath.Join(chooDir, "imports"),
+
+		AccessFile: filepath.Join(chooDir, "remote-access.yaml"),
+
+		Version: "dev",
 	}, nil
 }
 
@@ -46,6 +89,18 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("MaxJobs must be greater than 0, got %d", c.MaxJobs)
 	}
 
+	if c.MaxRepoJobs < 0 {
+		return fmt.Errorf("MaxRepoJobs must not be negative, got %d", c.MaxRepoJobs)
+	}
+
+	if c.WorkspaceDir != "" && !filepath.IsAbs(c.WorkspaceDir) {
+		return fmt.Errorf("WorkspaceDir must be absolute, got %s", c.WorkspaceDir)
+	}
+
+	if c.JobsDir != "" && !filepath.IsAbs(c.JobsDir) {
+		return fmt.Errorf("JobsDir must be absolute, got %s", c.JobsDir)
+	}
+
 	if !filepath.IsAbs(c.SocketPath) {
 		return fmt.Errorf("SocketPath must be absolute, got %s", c.SocketPath)
 	}
@@ -58,6 +113,53 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("DBPath must be absolute, got %s", c.DBPath)
 	}
 
+	if c.GCInterval < 0 {
+		return fmt.Errorf("GCInterval must not be negative, got %s", c.GCInterval)
+	}
+
+	if c.WorktreeRetention < 0 {
+		return fmt.Errorf("WorktreeRetention must not be negative, got %s", c.WorktreeRetention)
+	}
+
+	if c.FeatureWatchInterval < 0 {
+		return fmt.Errorf("FeatureWatchInterval must not be negative, got %s", c.FeatureWatchInterval)
+	}
+
+	if c.EventRetention < 0 {
+		return fmt.Errorf("EventRetention must not be negative, got %s", c.EventRetention)
+	}
+
+	// GC finds repositories through their runs, so runs must outlive
+	// their worktrees
+	if c.EventRetention > 0 && c.EventRetention < c.WorktreeRetention {
+		return fmt.Errorf("EventRetention (%s) must not be shorter than WorktreeRetention (%s)", c.EventRetention, c.WorktreeRetention)
+	}
+
+	if c.MaxRuns < 0 {
+		return fmt.Errorf("MaxRuns must not be negative, got %d", c.MaxRuns)
+	}
+
+	if c.EventCompactAfter < 0 {
+		return fmt.Errorf("EventCompactAfter must not be negative, got %s", c.EventCompactAfter)
+	}
+
+	if c.ImportDir != "" && !filepath.IsAbs(c.ImportDir) {
+		return fmt.Errorf("ImportDir must be absolute, got %s", c.ImportDir)
+	}
+
+	if c.WorktreeQuota < 0 {
+		return fmt.Errorf("WorktreeQuota must not be negative, got %d", c.WorktreeQuota)
+	}
+
+	if c.RemoteAddr != "" {
+		if c.TLSCert == "" || c.TLSKey == "" {
+			return fmt.Errorf("TLSCert and TLSKey are required when RemoteAddr is set")
+		}
+		if !filepath.IsAbs(c.AccessFile) {
+			return fmt.Errorf("AccessFile must be absolute, got %s", c.AccessFile)
+		}
+	}
+
 	if c.ContainerMode {
 		if c.ContainerImage == "" {
 			return fmt.Errorf("ContainerImage is required when ContainerMode is enabled")
@@ -79,6 +181,9 @@ func (c *Config) EnsureDirectories() error {
 	dirs[filepath.Dir(c.SocketPath)] = true
 	dirs[filepath.Dir(c.PIDFile)] = true
 	dirs[filepath.Dir(c.DBPath)] = true
+	if c.JobsDir != "" {
+		dirs[c.JobsDir] = true
+	}
 
 	// Create each directory with 0700 permissions
 	for dir := range dirs {
@@ -0,0 +1,64 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	// Wednesday
+	from := time.Date(2026, 3, 4, 10, 17, 30, 0, time.Local)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 4, 10, 18, 0, 0, time.Local)},
+		{"*/15 * * * *", time.Date(2026, 3, 4, 10, 30, 0, 0, time.Local)},
+		{"@nightly", time.Date(2026, 3, 5, 1, 0, 0, 0, time.Local)},
+		{"@hourly", time.Date(2026, 3, 4, 11, 0, 0, 0, time.Local)},
+		{"0 9 * * 1-5", time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local)},
+		{"30 8 * * 7", time.Date(2026, 3, 8, 8, 30, 0, 0, time.Local)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local)},
+		{"0 12 15 6 *", time.Date(2026, 6, 15, 12, 0, 0, 0, time.Local)},
+		// Both day fields restricted: either matches
+		{"0 0 10 * 5", time.Date(2026, 3, 6, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			c, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.Next(from))
+			assert.Equal(t, tt.expr, c.String())
+		})
+	}
+}
+
+func TestParseCron_Never(t *testing.T) {
+	c, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, c.Next(time.Now()).IsZero())
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@yearly",
+	} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, "expr %q", expr)
+	}
+}
@@ -11,7 +11,9 @@ import (
 
 	apiv1 "github.com/RevCBH/choo/pkg/api/v1"
 	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/telemetry"
 	"github.com/RevCBH/choo/internal/web"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
 )
 
@@ -22,8 +24,14 @@ type Daemon struct {
 	jobManager *jobManagerImpl
 	grpcServer *grpc.Server
 	listener   net.Listener
+	remote     *grpc.Server // TCP listener for remote clients, if enabled
 	pidFile    *PIDFile
 	webServer  *web.Server
+	gc         *WorktreeGC
+	watcher    *FeatureWatcher
+	retention  *EventRetention
+	metrics    *telemetry.Metrics
+	tracing    *sdktrace.TracerProvider // nil unless spans are exported
 
 	shutdownCh chan struct{}
 	wg         sync.WaitGroup
@@ -49,16 +57,57 @@ func New(cfg *Config) (*Daemon, error) {
 
 	// 4. Create JobManager
 	jobManager := NewJobManager(database, cfg.MaxJobs)
+	jobManager.maxRepoJobs = cfg.MaxRepoJobs
+	jobManager.jobsDir = cfg.JobsDir
+	if cfg.WorkspaceDir != "" {
+		jobManager.workspaces = NewWorkspaceManager(database, cfg.WorkspaceDir, jobManager.IsRepoActive)
+	}
 
 	// 5. Create PIDFile manager
 	pidFile := NewPIDFile(cfg.PIDFile)
 
-	// 6. Return initialized Daemon
+	// 6. Create worktree garbage collector
+	gc := NewWorktreeGC(database, GCPolicy{
+		Retention:     cfg.WorktreeRetention,
+		Quota:         cfg.WorktreeQuota,
+		PruneBranches: cfg.GCPruneBranches,
+		DryRun:        cfg.GCDryRun,
+	}, jobManager.IsActive)
+
+	// 7. Create feature PR watcher
+	watcher := NewFeatureWatcher(database, jobManager.RecordEvent, jobManager.IsActive)
+
+	// 8. Create event log retention
+	retention := NewEventRetention(database, RetentionPolicy{
+		MaxAge:       cfg.EventRetention,
+		MaxRuns:      cfg.MaxRuns,
+		CompactAfter: cfg.EventCompactAfter,
+	}, jobManager.IsActive)
+
+	// 9. Derive metrics, and traces if a collector is configured, from job events
+	metrics := telemetry.NewMetrics()
+	jobManager.AddObserver(metrics)
+	var tracing *sdktrace.TracerProvider
+	if cfg.OTLPEndpoint != "" {
+		tracing, err = telemetry.NewOTLPTracerProvider(context.Background(), cfg.OTLPEndpoint, cfg.Version)
+		if err != nil {
+			database.Close()
+			return nil, err
+		}
+		jobManager.AddObserver(telemetry.NewTracer(tracing))
+	}
+
+	// 10. Return initialized Daemon
 	return &Daemon{
 		cfg:        cfg,
 		db:         database,
 		jobManager: jobManager,
 		pidFile:    pidFile,
+		gc:         gc,
+		watcher:    watcher,
+		retention:  retention,
+		metrics:    metrics,
+		tracing:    tracing,
 		shutdownCh: make(chan struct{}),
 	}, nil
 }
@@ -95,11 +144,23 @@ func (d *Daemon) Start(ctx context.Context) error {
 	// 4. Create and register gRPC server
 	d.grpcServer = grpc.NewServer()
 	adapter := newJobManagerAdapter(d.jobManager, d.db)
-	grpcImpl := NewGRPCServer(d.db, adapter, "dev", d.Shutdown) // TODO: pass actual version
+	grpcImpl := NewGRPCServer(d.db, adapter, d.cfg.Version, d.Shutdown)
+	grpcImpl.SetWorktreeGC(d.gc)
+	grpcImpl.SetWorkspaces(d.jobManager.workspaces)
+	grpcImpl.SetJobArchiver(NewJobArchiver(d.db, d.cfg.ImportDir))
 	apiv1.RegisterDaemonServiceServer(d.grpcServer, grpcImpl)
 
-	// Wire up job completion callback to clean up gRPC tracking
-	d.jobManager.OnJobComplete = grpcImpl.UntrackJob
+	// 4b. Queued jobs start through StartJob, like any other client's
+	queue := NewJobQueue(d.db, grpcImpl.StartJob, d.jobManager.IsActive)
+	grpcImpl.SetJobQueue(queue)
+	d.metrics.SetQueueDepth(queue.Depth)
+
+	// Wire up job completion callback to clean up gRPC tracking and hand
+	// the freed slot to the queue
+	d.jobManager.OnJobComplete = func(jobID string) {
+		grpcImpl.UntrackJob(jobID)
+		queue.JobFinished(jobID)
+	}
 
 	// 5. Start gRPC server in goroutine
 	d.wg.Add(1)
@@ -110,13 +171,42 @@ func (d *Daemon) Start(ctx context.Context) error {
 		}
 	}()
 
-	// 6. Start web server (using job manager's Store for shared state)
+	// 5b. Serve remote clients over TLS, if enabled
+	if d.cfg.RemoteAddr != "" {
+		if err := d.startRemote(grpcImpl); err != nil {
+			d.grpcServer.Stop()
+			if releaseErr := d.pidFile.Release(); releaseErr != nil {
+				log.Printf("Error releasing PID file during cleanup: %v", releaseErr)
+			}
+			return fmt.Errorf("failed to start remote listener: %w", err)
+		}
+	}
+
+	// 6. Start web server (using job manager's Runs for shared state).
+	// Jobs are controlled from it with the tokens of remote clients with
+	// control permission, and past jobs are shown from the database. With
+	// the remote listener's certificate it is served over TLS; otherwise
+	// jobs can only be controlled from localhost.
+	control := newWebControl(grpcImpl, d.jobManager, d.db)
 	webCfg := web.Config{
 		Addr:       d.cfg.WebAddr,
 		SocketPath: d.cfg.WebSocketPath,
+		Metrics:    d.metrics.Handler(),
+		Control:    control,
+		History:    control,
+		Authorize:  newRemoteAuth(d.cfg.AccessFile).authorizeHTTP,
+	}
+	scheme := "http"
+	if d.cfg.TLSCert != "" && d.cfg.TLSKey != "" {
+		if tlsCfg, err := remoteTLSConfig(d.cfg); err != nil {
+			log.Printf("Warning: serving the web UI without TLS: %v", err)
+		} else {
+			webCfg.TLS = tlsCfg
+			scheme = "https"
+		}
 	}
-	// Use job manager's Store so state is shared regardless of startup order
-	webSrv, err := web.NewWithStore(webCfg, d.jobManager.Store())
+	// Use job manager's Runs so state is shared regardless of startup order
+	webSrv, err := web.NewWithRuns(webCfg, d.jobManager.Runs())
 	if err != nil {
 		log.Printf("Warning: failed to create web server: %v", err)
 	} else {
@@ -124,25 +214,57 @@ func (d *Daemon) Start(ctx context.Context) error {
 			log.Printf("Warning: failed to start web server: %v", err)
 		} else {
 			d.webServer = webSrv
-			log.Printf("Web server listening on http://localhost%s", d.cfg.WebAddr)
+			log.Printf("Web server listening on %s://localhost%s", scheme, d.cfg.WebAddr)
 
 			// Wire up SSE hub for broadcasting events to web clients
 			d.jobManager.SetWebHub(webSrv.Hub())
 		}
 	}
 
-	// 7. Log startup message
+	// 7. Start background worktree GC, feature PR watcher, job queue and
+	// event log retention
+	gcCtx, stopGC := context.WithCancel(ctx)
+	defer stopGC()
+	if d.cfg.GCInterval > 0 {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.gc.Run(gcCtx, d.cfg.GCInterval)
+		}()
+	}
+	if d.cfg.FeatureWatchInterval > 0 {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.watcher.Run(gcCtx, d.cfg.FeatureWatchInterval)
+		}()
+	}
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		queue.Run(gcCtx, queueCheckInterval)
+	}()
+	if d.retention.Enabled() {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.retention.Run(gcCtx, retentionInterval)
+		}()
+	}
+
+	// 8. Log startup message
 	log.Printf("Daemon started on %s (PID: %d)", d.cfg.SocketPath, os.Getpid())
 
-	// 8. Wait for shutdown signal
+	// 9. Wait for shutdown signal
 	select {
 	case <-ctx.Done():
 		log.Println("Received context cancellation")
 	case <-d.shutdownCh:
 		log.Println("Received shutdown signal")
 	}
+	stopGC()
 
-	// 8. Run graceful shutdown
+	// 10. Run graceful shutdown
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	return d.gracefulShutdown(shutdownCtx)
@@ -161,7 +283,7 @@ func (d *Daemon) Shutdown() {
 
 // gracefulShutdown performs ordered shutdown of daemon components.
 // The order is critical for prompt shutdown:
-// 1. Cancel all jobs FIRST (proactive interruption)
+// 1. Cancel all jobs FIRST (proactive interruption), detaching supervised ones
 // 2. Wait briefly for jobs to start cleanup
 // 3. Stop gRPC server (streams complete quickly since jobs are cancelled)
 // 4. Stop web server
@@ -170,7 +292,11 @@ func (d *Daemon) gracefulShutdown(ctx context.Context) error {
 	log.Println("Starting graceful shutdown...")
 
 	// 1. IMMEDIATELY cancel all running jobs (proactive interruption)
-	// This must happen BEFORE stopping gRPC so that WatchJob streams can complete
+	// This must happen BEFORE stopping gRPC so that WatchJob streams can complete.
+	// Jobs in job runners are detached instead and carry on without the daemon.
+	if detached := d.jobManager.DetachAll(); len(detached) > 0 {
+		log.Printf("Detached from %d job runner(s); they keep running", len(detached))
+	}
 	activeJobs := d.jobManager.ActiveCount()
 	if activeJobs > 0 {
 		log.Printf("Cancelling %d running job(s)...", activeJobs)
@@ -227,6 +353,11 @@ func (d *Daemon) gracefulShutdown(ctx context.Context) error {
 		}
 	}
 
+	// Remote streams ended with the jobs, so the TCP listener needs no grace period
+	if d.remote != nil {
+		d.remote.Stop()
+	}
+
 	// 4. Stop web server
 	if d.webServer != nil {
 		log.Println("Stopping web server...")
@@ -240,6 +371,15 @@ func (d *Daemon) gracefulShutdown(ctx context.Context) error {
 	// Wait for gRPC goroutine to finish
 	d.wg.Wait()
 
+	// 4b. Flush spans to the collector
+	if d.tracing != nil {
+		traceCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := d.tracing.Shutdown(traceCtx); err != nil {
+			log.Printf("Error flushing traces: %v", err)
+		}
+	}
+
 	// 5. Close database connection
 	if d.db != nil {
 		if err := d.db.Close(); err != nil {
@@ -286,3 +426,26 @@ func (d *Daemon) setupSocket() (net.Listener, error) {
 
 	return listener, nil
 }
+
+// startRemote starts the authenticated TCP listener for remote clients
+func (d *Daemon) startRemote(impl apiv1.DaemonServiceServer) error {
+	srv, err := newRemoteServer(d.cfg, impl)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", d.cfg.RemoteAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", d.cfg.RemoteAddr, err)
+	}
+	d.remote = srv
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		if err := srv.Serve(listener); err != nil {
+			log.Printf("Remote gRPC server error: %v", err)
+		}
+	}()
+	log.Printf("Accepting remote clients on %s (access file: %s)", listener.Addr(), d.cfg.AccessFile)
+	return nil
+}
@@ -3,20 +3,28 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	_ "modernc.org/sqlite"
 )
 
+// busyTimeout bounds how long a write waits for another to finish. Jobs
+// record events concurrently with status updates.
+const busyTimeout = 5 * time.Second
+
 // DB wraps the SQLite connection with daemon-specific operations
 type DB struct {
 	conn *sql.DB
 }
 
 // Open creates or opens a SQLite database at the given path.
-// It enables WAL mode, foreign keys, and runs migrations.
+// It enables WAL mode, foreign keys, and runs migrations. Writers wait up
+// to busyTimeout for each other rather than failing.
 func Open(path string) (*DB, error) {
-	// 1. Open connection with modernc.org/sqlite driver
-	conn, err := sql.Open("sqlite", path)
+	// 1. Open connection with modernc.org/sqlite driver, setting the busy
+	// timeout on every connection of the pool. Transactions take the write
+	// lock when they begin, since one upgraded later fails without waiting.
+	conn, err := sql.Open("sqlite", fmt.Sprintf("%s?_pragma=busy_timeout(%d)&_txlock=immediate", path, busyTimeout.Milliseconds()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -96,12 +104,91 @@ CREATE TABLE IF NOT EXISTS events (
     UNIQUE(run_id, sequence)
 );
 
+-- Feature PRs table: Feature pull requests opened by runs, watched until
+-- they merge or close. Not tied to the run row, which a later run of the
+-- same branch replaces.
+CREATE TABLE IF NOT EXISTS feature_prs (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    run_id          TEXT NOT NULL,
+    repo_path       TEXT NOT NULL,
+    feature_branch  TEXT NOT NULL,
+    target_branch   TEXT NOT NULL,
+    tasks_dir       TEXT NOT NULL,
+    pr_number       INTEGER NOT NULL,
+    pr_url          TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    created_at      DATETIME DEFAULT CURRENT_TIMESTAMP,
+    closed_at       DATETIME,
+    error           TEXT,
+    UNIQUE(repo_path, feature_branch)
+);
+
+-- Run imports table: When runs were imported from another daemon's job
+-- archive. Retention ages an imported run from its import, not from when
+-- it finished on the other daemon.
+CREATE TABLE IF NOT EXISTS run_imports (
+    run_id          TEXT PRIMARY KEY REFERENCES runs(id) ON DELETE CASCADE,
+    imported_at     DATETIME NOT NULL
+);
+
+-- Workspaces table: Repositories registered with the daemon, cloned and
+-- fetched by it, with defaults for the jobs started in them
+CREATE TABLE IF NOT EXISTS workspaces (
+    name            TEXT PRIMARY KEY,
+    repo_url        TEXT NOT NULL,
+    mirror_path     TEXT NOT NULL,
+    target_branch   TEXT NOT NULL,
+    tasks_dir       TEXT NOT NULL,
+    parallelism     INTEGER NOT NULL DEFAULT 0,
+    max_jobs        INTEGER NOT NULL DEFAULT 0,
+    created_at      DATETIME DEFAULT CURRENT_TIMESTAMP,
+    fetched_at      DATETIME
+);
+
+-- Job queue table: Jobs waiting for a free slot, started in priority order
+-- and kept after they leave the queue for listing
+CREATE TABLE IF NOT EXISTS job_queue (
+    id              TEXT PRIMARY KEY,
+    repo_path       TEXT NOT NULL,
+    workspace       TEXT NOT NULL,
+    tasks_dir       TEXT NOT NULL,
+    target_branch   TEXT NOT NULL,
+    feature_branch  TEXT NOT NULL,
+    parallelism     INTEGER NOT NULL DEFAULT 0,
+    priority        INTEGER NOT NULL DEFAULT 0,
+    status          TEXT NOT NULL,
+    job_id          TEXT,
+    schedule        TEXT,
+    error           TEXT,
+    queued_at       DATETIME DEFAULT CURRENT_TIMESTAMP,
+    started_at      DATETIME,
+    finished_at     DATETIME
+);
+
+-- Schedules table: Jobs queued at the times given by a cron expression
+CREATE TABLE IF NOT EXISTS schedules (
+    name            TEXT PRIMARY KEY,
+    cron            TEXT NOT NULL,
+    repo_path       TEXT NOT NULL,
+    workspace       TEXT NOT NULL,
+    tasks_dir       TEXT NOT NULL,
+    target_branch   TEXT NOT NULL,
+    feature_branch  TEXT NOT NULL,
+    parallelism     INTEGER NOT NULL DEFAULT 0,
+    priority        INTEGER NOT NULL DEFAULT 0,
+    next_run_at     DATETIME NOT NULL,
+    last_run_at     DATETIME,
+    created_at      DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Indexes for common queries
 CREATE INDEX IF NOT EXISTS idx_runs_status ON runs(status);
 CREATE INDEX IF NOT EXISTS idx_units_run_id ON units(run_id);
 CREATE INDEX IF NOT EXISTS idx_units_status ON units(status);
 CREATE INDEX IF NOT EXISTS idx_events_run_id ON events(run_id);
 CREATE INDEX IF NOT EXISTS idx_events_sequence ON events(run_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_feature_prs_status ON feature_prs(status);
+CREATE INDEX IF NOT EXISTS idx_job_queue_status ON job_queue(status, priority);
 `
 
 	_, err := db.conn.Exec(schema)
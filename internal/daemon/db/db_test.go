@@ -1,9 +1,12 @@
 package db
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
 // TestOpen verifies that opening an in-memory database works without error
@@ -501,6 +504,42 @@ func TestRunListIncomplete(t *testing.T) {
 	}
 }
 
+// TestRunListAll verifies that ListRuns returns runs in every status
+func TestRunListAll(t *testing.T) {
+	db, err := Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer db.Close()
+
+	statuses := []RunStatus{RunStatusRunning, RunStatusCompleted, RunStatusFailed}
+	for i, status := range statuses {
+		run := &Run{
+			ID:            NewRunID(),
+			FeatureBranch: fmt.Sprintf("feature/all%d", i),
+			RepoPath:      "/path/to/repo",
+			TargetBranch:  "main",
+			TasksDir:      "/path/to/tasks",
+			Parallelism:   4,
+			Status:        status,
+			DaemonVersion: "1.0.0",
+			ConfigJSON:    "{}",
+		}
+		if err := db.CreateRun(run); err != nil {
+			t.Fatalf("CreateRun failed: %v", err)
+		}
+	}
+
+	runs, err := db.ListRuns()
+	if err != nil {
+		t.Fatalf("ListRuns failed: %v", err)
+	}
+
+	if len(runs) != len(statuses) {
+		t.Errorf("Expected %d runs, got %d", len(statuses), len(runs))
+	}
+}
+
 // TestRunDelete verifies that DeleteRun removes run from database
 func TestRunDelete(t *testing.T) {
 	db, err := Open(":memory:")
@@ -2097,3 +2136,418 @@ func TestGetRunByBranchPartialMatch(t *testing.T) {
 		t.Errorf("Expected run ID %s, got %s", run.ID, retrieved.ID)
 	}
 }
+
+// TestFeaturePRLifecycle verifies feature PRs are recorded, replaced per
+// branch and leave the open list once closed out
+func TestFeaturePRLifecycle(t *testing.T) {
+	db := setupTestDB(t)
+
+	pr := &FeaturePR{
+		RunID:         NewRunID(),
+		RepoPath:      "/repo",
+		FeatureBranch: "feature/api",
+		TargetBranch:  "main",
+		TasksDir:      "specs/tasks",
+		PRNumber:      7,
+		PRURL:         "https://github.com/o/r/pull/7",
+	}
+	if err := db.RecordFeaturePR(pr); err != nil {
+		t.Fatalf("RecordFeaturePR failed: %v", err)
+	}
+
+	// A later run of the same branch replaces the earlier PR
+	pr.RunID = NewRunID()
+	pr.PRNumber = 8
+	pr.PRURL = "https://github.com/o/r/pull/8"
+	if err := db.RecordFeaturePR(pr); err != nil {
+		t.Fatalf("RecordFeaturePR failed: %v", err)
+	}
+
+	open, err := db.ListFeaturePRsByStatus(FeaturePRStatusOpen)
+	if err != nil {
+		t.Fatalf("ListFeaturePRsByStatus failed: %v", err)
+	}
+	if len(open) != 1 {
+		t.Fatalf("Expected 1 open feature PR, got %d", len(open))
+	}
+	if open[0].PRNumber != 8 || open[0].RunID != pr.RunID {
+		t.Errorf("Expected PR #8 from the latest run, got #%d from %s", open[0].PRNumber, open[0].RunID)
+	}
+	if open[0].ClosedAt != nil {
+		t.Error("Expected closed_at to be unset for an open PR")
+	}
+
+	msg := "push rejected"
+	if err := db.UpdateFeaturePRStatus(open[0].ID, FeaturePRStatusMerged, &msg); err != nil {
+		t.Fatalf("UpdateFeaturePRStatus failed: %v", err)
+	}
+
+	open, err = db.ListFeaturePRsByStatus(FeaturePRStatusOpen)
+	if err != nil {
+		t.Fatalf("ListFeaturePRsByStatus failed: %v", err)
+	}
+	if len(open) != 0 {
+		t.Errorf("Expected no open feature PRs, got %d", len(open))
+	}
+
+	merged, err := db.ListFeaturePRsByStatus(FeaturePRStatusMerged)
+	if err != nil {
+		t.Fatalf("ListFeaturePRsByStatus failed: %v", err)
+	}
+	if len(merged) != 1 {
+		t.Fatalf("Expected 1 merged feature PR, got %d", len(merged))
+	}
+	if merged[0].ClosedAt == nil {
+		t.Error("Expected closed_at to be set")
+	}
+	if merged[0].Error == nil || *merged[0].Error != msg {
+		t.Errorf("Expected error %q, got %v", msg, merged[0].Error)
+	}
+
+	if err := db.UpdateFeaturePRStatus(999, FeaturePRStatusClosed, nil); err == nil {
+		t.Error("Expected error updating unknown feature PR")
+	}
+}
+
+func TestWorkspaceLifecycle(t *testing.T) {
+	db := setupTestDB(t)
+
+	ws := &Workspace{
+		Name:         "app",
+		RepoURL:      "https://github.com/o/app.git",
+		MirrorPath:   "/home/choo/.choo/workspaces/app",
+		TargetBranch: "main",
+		TasksDir:     "specs/tasks",
+		MaxJobs:      2,
+	}
+	if err := db.CreateWorkspace(ws); err != nil {
+		t.Fatalf("CreateWorkspace failed: %v", err)
+	}
+	if err := db.CreateWorkspace(ws); err == nil {
+		t.Error("Expected error creating a duplicate workspace")
+	}
+	if err := db.CreateWorkspace(&Workspace{Name: "lib", RepoURL: "git@github.com:o/lib.git", MirrorPath: "/lib", TargetBranch: "trunk", TasksDir: "tasks"}); err != nil {
+		t.Fatalf("CreateWorkspace failed: %v", err)
+	}
+
+	got, err := db.GetWorkspace("app")
+	if err != nil {
+		t.Fatalf("GetWorkspace failed: %v", err)
+	}
+	if got == nil || got.RepoURL != ws.RepoURL || got.MaxJobs != 2 || got.TasksDir != "specs/tasks" {
+		t.Fatalf("Unexpected workspace: %+v", got)
+	}
+	if got.FetchedAt != nil {
+		t.Error("Expected fetched_at to be unset before the first fetch")
+	}
+
+	fetched := time.Now().UTC().Truncate(time.Second)
+	if err := db.MarkWorkspaceFetched("app", fetched); err != nil {
+		t.Fatalf("MarkWorkspaceFetched failed: %v", err)
+	}
+	got, _ = db.GetWorkspace("app")
+	if got.FetchedAt == nil || !got.FetchedAt.Equal(fetched) {
+		t.Errorf("Expected fetched_at %v, got %v", fetched, got.FetchedAt)
+	}
+
+	list, err := db.ListWorkspaces()
+	if err != nil {
+		t.Fatalf("ListWorkspaces failed: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "app" || list[1].Name != "lib" {
+		t.Fatalf("Expected workspaces app and lib, got %d", len(list))
+	}
+
+	if err := db.DeleteWorkspace("app"); err != nil {
+		t.Fatalf("DeleteWorkspace failed: %v", err)
+	}
+	if err := db.DeleteWorkspace("app"); err == nil {
+		t.Error("Expected error deleting a missing workspace")
+	}
+	if got, err := db.GetWorkspace("app"); err != nil || got != nil {
+		t.Errorf("Expected no workspace after delete, got %+v, %v", got, err)
+	}
+}
+
+func TestJobQueueLifecycle(t *testing.T) {
+	db := setupTestDB(t)
+
+	enqueue := func(branch string, priority int) *QueuedJob {
+		q := &QueuedJob{
+			Priority: priority,
+			JobSpec:  JobSpec{Workspace: "app", TasksDir: "specs/tasks", TargetBranch: "main", FeatureBranch: branch},
+		}
+		if err := db.EnqueueJob(q); err != nil {
+			t.Fatalf("EnqueueJob failed: %v", err)
+		}
+		return q
+	}
+	low := enqueue("feature/low", 0)
+	high := enqueue("feature/high", 5)
+	later := enqueue("feature/later", 0)
+
+	queued, err := db.ListQueuedJobsByStatus(QueueStatusQueued)
+	if err != nil {
+		t.Fatalf("ListQueuedJobsByStatus failed: %v", err)
+	}
+	var order []string
+	for _, q := range queued {
+		order = append(order, q.FeatureBranch)
+	}
+	if strings.Join(order, ",") != "feature/high,feature/low,feature/later" {
+		t.Errorf("Expected priority then queue order, got %v", order)
+	}
+
+	if err := db.MarkQueuedJobStarted(high.ID, "run-1"); err != nil {
+		t.Fatalf("MarkQueuedJobStarted failed: %v", err)
+	}
+	if err := db.CancelQueuedJob(later.ID); err != nil {
+		t.Fatalf("CancelQueuedJob failed: %v", err)
+	}
+	if err := db.CancelQueuedJob(high.ID); err == nil {
+		t.Error("Expected error cancelling a started job")
+	}
+
+	list, err := db.ListQueue(false)
+	if err != nil {
+		t.Fatalf("ListQueue failed: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != high.ID || list[1].ID != low.ID {
+		t.Fatalf("Expected started then queued entries, got %d entries", len(list))
+	}
+	if list[0].JobID == nil || *list[0].JobID != "run-1" || list[0].StartedAt == nil {
+		t.Errorf("Expected started entry to record its job, got %+v", list[0])
+	}
+
+	found, err := db.FinishQueuedJobForRun("run-1")
+	if err != nil || !found {
+		t.Fatalf("FinishQueuedJobForRun: found=%v err=%v", found, err)
+	}
+	if found, _ := db.FinishQueuedJobForRun("run-unknown"); found {
+		t.Error("Expected no entry for an unknown run")
+	}
+
+	msg := "workspace not found"
+	if err := db.MarkQueuedJobFinished(low.ID, &msg); err != nil {
+		t.Fatalf("MarkQueuedJobFinished failed: %v", err)
+	}
+	got, err := db.GetQueuedJob(low.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetQueuedJob: %v, %v", got, err)
+	}
+	if got.Status != QueueStatusFinished || got.Error == nil || *got.Error != msg {
+		t.Errorf("Expected finished entry with error, got %+v", got)
+	}
+
+	if list, _ := db.ListQueue(false); len(list) != 0 {
+		t.Errorf("Expected empty queue, got %d entries", len(list))
+	}
+	if list, _ := db.ListQueue(true); len(list) != 3 {
+		t.Errorf("Expected 3 entries including finished, got %d", len(list))
+	}
+}
+
+func TestScheduleLifecycle(t *testing.T) {
+	db := setupTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	nightly := &Schedule{
+		Name:      "nightly",
+		Cron:      "0 1 * * *",
+		Priority:  1,
+		NextRunAt: now.Add(-time.Minute),
+		JobSpec:   JobSpec{Workspace: "app", TasksDir: "specs/tasks", TargetBranch: "main"},
+	}
+	if err := db.CreateSchedule(nightly); err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+	if err := db.CreateSchedule(nightly); err == nil {
+		t.Error("Expected error creating a duplicate schedule")
+	}
+	weekly := &Schedule{Name: "weekly", Cron: "0 3 * * 0", NextRunAt: now.Add(time.Hour), JobSpec: JobSpec{RepoPath: "/repo", TasksDir: "specs/tasks", TargetBranch: "main"}}
+	if err := db.CreateSchedule(weekly); err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+
+	due, err := db.ListDueSchedules(now)
+	if err != nil {
+		t.Fatalf("ListDueSchedules failed: %v", err)
+	}
+	if len(due) != 1 || due[0].Name != "nightly" || due[0].Workspace != "app" {
+		t.Fatalf("Expected nightly to be due, got %d schedules", len(due))
+	}
+
+	next := now.Add(24 * time.Hour)
+	if err := db.MarkScheduleRun("nightly", now, next); err != nil {
+		t.Fatalf("MarkScheduleRun failed: %v", err)
+	}
+	if due, _ := db.ListDueSchedules(now); len(due) != 0 {
+		t.Errorf("Expected no due schedules after the run, got %d", len(due))
+	}
+
+	all, err := db.ListSchedules()
+	if err != nil {
+		t.Fatalf("ListSchedules failed: %v", err)
+	}
+	if len(all) != 2 || !all[0].NextRunAt.Equal(next) || all[0].LastRunAt == nil {
+		t.Errorf("Expected nightly rescheduled, got %+v", all[0])
+	}
+
+	if err := db.DeleteSchedule("weekly"); err != nil {
+		t.Fatalf("DeleteSchedule failed: %v", err)
+	}
+	if err := db.DeleteSchedule("weekly"); err == nil {
+		t.Error("Expected error deleting a missing schedule")
+	}
+}
+
+func TestCompactEvents(t *testing.T) {
+	db := setupTestDB(t)
+	runID := createTestRun(t, db)
+	unitA, unitB := "a", "b"
+
+	for _, e := range []struct {
+		eventType string
+		unit      *string
+	}{
+		{"unit.started", &unitA},
+		{"task.started", &unitA},
+		{"task.claude.invoke", &unitA},
+		{"task.started", &unitB},
+		{"task.committed", &unitA},
+		{"task.failed", &unitB},
+		{"unit.completed", &unitA},
+	} {
+		if err := db.AppendEvent(runID, e.eventType, e.unit, map[string]string{"k": "v"}); err != nil {
+			t.Fatalf("AppendEvent failed: %v", err)
+		}
+	}
+
+	removed, err := db.CompactEvents(runID, []string{"task.started", "task.claude.invoke", "task.committed"}, "events.compacted")
+	if err != nil {
+		t.Fatalf("CompactEvents failed: %v", err)
+	}
+	if removed != 4 {
+		t.Errorf("Expected 4 events removed, got %d", removed)
+	}
+
+	events, err := db.ListEvents(runID)
+	if err != nil {
+		t.Fatalf("ListEvents failed: %v", err)
+	}
+	var types []string
+	for _, e := range events {
+		types = append(types, e.EventType)
+	}
+	want := []string{"unit.started", "events.compacted", "events.compacted", "task.failed", "unit.completed"}
+	if strings.Join(types, ",") != strings.Join(want, ",") {
+		t.Fatalf("Expected events %v, got %v", want, types)
+	}
+
+	var summary CompactionSummary
+	if err := json.Unmarshal([]byte(*events[1].PayloadJSON), &summary); err != nil {
+		t.Fatalf("Failed to parse summary: %v", err)
+	}
+	if *events[1].UnitID != "a" || summary.Events != 3 || summary.Counts["task.started"] != 1 || summary.FirstSequence != 2 || summary.LastSequence != 5 {
+		t.Errorf("Unexpected summary for unit a: %+v", summary)
+	}
+
+	// A second pass finds nothing left to compact
+	removed, err = db.CompactEvents(runID, []string{"task.started"}, "events.compacted")
+	if err != nil || removed != 0 {
+		t.Errorf("Expected nothing to compact, got %d (%v)", removed, err)
+	}
+
+	// New events continue the sequence after the last one
+	if err := db.AppendEvent(runID, "orch.completed", nil, nil); err != nil {
+		t.Fatalf("AppendEvent failed: %v", err)
+	}
+	next, _ := db.GetNextSequence(runID)
+	if next != 9 {
+		t.Errorf("Expected next sequence 9, got %d", next)
+	}
+}
+
+func TestDeleteRuns(t *testing.T) {
+	db := setupTestDB(t)
+	keep := createTestRun(t, db)
+	drop := createTestRun(t, db)
+	for _, id := range []string{keep, drop} {
+		if err := db.CreateUnit(&UnitRecord{ID: MakeUnitRecordID(id, "u"), RunID: id, UnitID: "u", Status: "completed"}); err != nil {
+			t.Fatalf("CreateUnit failed: %v", err)
+		}
+		if err := db.AppendEvent(id, "unit.completed", nil, nil); err != nil {
+			t.Fatalf("AppendEvent failed: %v", err)
+		}
+	}
+
+	if err := db.DeleteRuns([]string{drop}); err != nil {
+		t.Fatalf("DeleteRuns failed: %v", err)
+	}
+
+	if run, _ := db.GetRun(drop); run != nil {
+		t.Error("Expected run to be deleted")
+	}
+	if units, _ := db.ListUnitsByRun(drop); len(units) != 0 {
+		t.Errorf("Expected units to be deleted, got %d", len(units))
+	}
+	if events, _ := db.ListEvents(drop); len(events) != 0 {
+		t.Errorf("Expected events to be deleted, got %d", len(events))
+	}
+	if events, _ := db.ListEvents(keep); len(events) != 1 {
+		t.Errorf("Expected other run's events to remain, got %d", len(events))
+	}
+}
+
+func TestImportRun(t *testing.T) {
+	db := setupTestDB(t)
+	started := time.Now().Add(-time.Hour).Truncate(time.Second)
+	completed := started.Add(30 * time.Minute)
+	branch := "ralph/api-1234"
+	payload := `{"type":"unit.completed"}`
+	unitID := "api"
+
+	run := &Run{
+		ID:            NewRunID(),
+		FeatureBranch: "feature/api",
+		RepoPath:      "/srv/app",
+		TargetBranch:  "main",
+		TasksDir:      "specs/tasks",
+		Parallelism:   2,
+		Status:        RunStatusFailed,
+		DaemonVersion: "1.2.0",
+		StartedAt:     &started,
+		CompletedAt:   &completed,
+		ConfigJSON:    "{}",
+	}
+	units := []*UnitRecord{{UnitID: "api", Status: "failed", Branch: &branch, StartedAt: &started}}
+	events := []*EventRecord{
+		{Sequence: 3, EventType: "events.compacted", UnitID: &unitID, CreatedAt: started},
+		{Sequence: 7, EventType: "unit.completed", UnitID: &unitID, PayloadJSON: &payload, CreatedAt: completed},
+	}
+
+	if err := db.ImportRun(run, units, events); err != nil {
+		t.Fatalf("ImportRun failed: %v", err)
+	}
+	if err := db.ImportRun(run, nil, nil); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected duplicate import to fail, got %v", err)
+	}
+
+	got, err := db.GetRun(run.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetRun failed: %v", err)
+	}
+	if got.Status != RunStatusFailed || got.CompletedAt == nil || !got.CompletedAt.Equal(completed) {
+		t.Errorf("Unexpected run: %+v", got)
+	}
+
+	gotUnits, _ := db.ListUnitsByRun(run.ID)
+	if len(gotUnits) != 1 || gotUnits[0].ID != MakeUnitRecordID(run.ID, "api") || *gotUnits[0].Branch != branch {
+		t.Errorf("Unexpected units: %+v", gotUnits)
+	}
+
+	gotEvents, _ := db.ListEvents(run.ID)
+	if len(gotEvents) != 2 || gotEvents[1].Sequence != 7 || *gotEvents[1].PayloadJSON != payload || !gotEvents[1].CreatedAt.Equal(completed) {
+		t.Errorf("Unexpected events: %+v", gotEvents)
+	}
+}
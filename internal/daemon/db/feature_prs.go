@@ -0,0 +1,120 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// RecordFeaturePR inserts a feature PR, replacing any earlier PR recorded
+// for the same branch and repo. The PR starts out open.
+func (db *DB) RecordFeaturePR(pr *FeaturePR) error {
+	query := `
+		INSERT INTO feature_prs (
+			run_id, repo_path, feature_branch, target_branch, tasks_dir,
+			pr_number, pr_url, status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(repo_path, feature_branch) DO UPDATE SET
+			run_id = excluded.run_id,
+			target_branch = excluded.target_branch,
+			tasks_dir = excluded.tasks_dir,
+			pr_number = excluded.pr_number,
+			pr_url = excluded.pr_url,
+			status = excluded.status,
+			created_at = CURRENT_TIMESTAMP,
+			closed_at = NULL,
+			error = NULL
+	`
+
+	pr.Status = FeaturePRStatusOpen
+	_, err := db.conn.Exec(
+		query,
+		pr.RunID,
+		pr.RepoPath,
+		pr.FeatureBranch,
+		pr.TargetBranch,
+		pr.TasksDir,
+		pr.PRNumber,
+		pr.PRURL,
+		pr.Status,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record feature PR: %w", err)
+	}
+
+	return nil
+}
+
+// ListFeaturePRsByStatus returns all feature PRs with the given status.
+func (db *DB) ListFeaturePRsByStatus(status FeaturePRStatus) ([]*FeaturePR, error) {
+	query := `
+		SELECT id, run_id, repo_path, feature_branch, target_branch, tasks_dir,
+		       pr_number, pr_url, status, created_at, closed_at, error
+		FROM feature_prs
+		WHERE status = ?
+		ORDER BY id
+	`
+
+	rows, err := db.conn.Query(query, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature PRs by status: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*FeaturePR
+	for rows.Next() {
+		pr := &FeaturePR{}
+		err := rows.Scan(
+			&pr.ID,
+			&pr.RunID,
+			&pr.RepoPath,
+			&pr.FeatureBranch,
+			&pr.TargetBranch,
+			&pr.TasksDir,
+			&pr.PRNumber,
+			&pr.PRURL,
+			&pr.Status,
+			&pr.CreatedAt,
+			&pr.ClosedAt,
+			&pr.Error,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feature PR: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating feature PRs: %w", err)
+	}
+
+	return prs, nil
+}
+
+// UpdateFeaturePRStatus updates a feature PR's status and error message.
+// Leaving the open state sets closed_at.
+func (db *DB) UpdateFeaturePRStatus(id int64, status FeaturePRStatus, err *string) error {
+	var closedAt *time.Time
+	if status != FeaturePRStatusOpen {
+		now := time.Now()
+		closedAt = &now
+	}
+
+	result, execErr := db.conn.Exec(
+		`UPDATE feature_prs SET status = ?, error = ?, closed_at = ? WHERE id = ?`,
+		status, err, closedAt, id,
+	)
+	if execErr != nil {
+		return fmt.Errorf("failed to update feature PR status: %w", execErr)
+	}
+
+	rowsAffected, raErr := result.RowsAffected()
+	if raErr != nil {
+		return fmt.Errorf("failed to check rows affected: %w", raErr)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("feature PR not found: %d", id)
+	}
+
+	return nil
+}
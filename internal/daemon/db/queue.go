@@ -0,0 +1,340 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// queueColumns lists the job queue columns in scan order
+const queueColumns = `id, priority, status, job_id, schedule, error, queued_at,
+		       started_at, finished_at, repo_path, workspace, tasks_dir,
+		       target_branch, feature_branch, parallelism`
+
+// EnqueueJob adds a job to the queue. An ID is generated if q has none.
+func (db *DB) EnqueueJob(q *QueuedJob) error {
+	if q.ID == "" {
+		q.ID = NewRunID()
+	}
+	q.Status = QueueStatusQueued
+	q.QueuedAt = time.Now()
+
+	query := `
+		INSERT INTO job_queue (
+			id, repo_path, workspace, tasks_dir, target_branch, feature_branch,
+			parallelism, priority, status, schedule, queued_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := db.conn.Exec(
+		query,
+		q.ID,
+		q.RepoPath,
+		q.Workspace,
+		q.TasksDir,
+		q.TargetBranch,
+		q.FeatureBranch,
+		q.Parallelism,
+		q.Priority,
+		q.Status,
+		q.Schedule,
+		q.QueuedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	return nil
+}
+
+// GetQueuedJob retrieves a queue entry by ID.
+// Returns nil, nil if the entry doesn't exist.
+func (db *DB) GetQueuedJob(id string) (*QueuedJob, error) {
+	query := `SELECT ` + queueColumns + ` FROM job_queue WHERE id = ?`
+
+	q, err := scanQueuedJob(db.conn.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queued job: %w", err)
+	}
+
+	return q, nil
+}
+
+// ListQueuedJobsByStatus returns the queue entries with the given status,
+// in the order they should start: highest priority first, then oldest.
+func (db *DB) ListQueuedJobsByStatus(status QueueStatus) ([]*QueuedJob, error) {
+	query := `SELECT ` + queueColumns + ` FROM job_queue
+		WHERE status = ?
+		ORDER BY priority DESC, rowid`
+
+	return db.queryQueuedJobs(query, status)
+}
+
+// ListQueue returns the started and queued entries, started first and the
+// rest in start order. With includeFinished, finished and cancelled
+// entries follow, most recent first.
+func (db *DB) ListQueue(includeFinished bool) ([]*QueuedJob, error) {
+	where := `WHERE status IN ('started', 'queued')`
+	if includeFinished {
+		where = ``
+	}
+	query := `SELECT ` + queueColumns + ` FROM job_queue ` + where + `
+		ORDER BY CASE status WHEN 'started' THEN 0 WHEN 'queued' THEN 1 ELSE 2 END,
+		         CASE WHEN status IN ('started', 'queued') THEN -priority ELSE 0 END,
+		         CASE WHEN status IN ('started', 'queued') THEN rowid END,
+		         rowid DESC`
+
+	return db.queryQueuedJobs(query)
+}
+
+// MarkQueuedJobStarted records that the entry left the queue as job jobID.
+func (db *DB) MarkQueuedJobStarted(id, jobID string) error {
+	return db.updateQueuedJob(
+		`UPDATE job_queue SET status = ?, job_id = ?, started_at = ? WHERE id = ?`,
+		QueueStatusStarted, jobID, time.Now(), id,
+	)
+}
+
+// MarkQueuedJobFinished records that the entry's job ended, or could not
+// start with the error errMsg.
+func (db *DB) MarkQueuedJobFinished(id string, errMsg *string) error {
+	return db.updateQueuedJob(
+		`UPDATE job_queue SET status = ?, error = ?, finished_at = ? WHERE id = ?`,
+		QueueStatusFinished, errMsg, time.Now(), id,
+	)
+}
+
+// FinishQueuedJobForRun marks the started entry whose job is jobID
+// finished, reporting whether there was one.
+func (db *DB) FinishQueuedJobForRun(jobID string) (bool, error) {
+	result, err := db.conn.Exec(
+		`UPDATE job_queue SET status = ?, finished_at = ? WHERE job_id = ? AND status = ?`,
+		QueueStatusFinished, time.Now(), jobID, QueueStatusStarted,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to finish queued job: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	return n > 0, nil
+}
+
+// CancelQueuedJob removes a job from the queue before it starts.
+// Returns an error if the entry doesn't exist or already left the queue.
+func (db *DB) CancelQueuedJob(id string) error {
+	result, err := db.conn.Exec(
+		`UPDATE job_queue SET status = ?, finished_at = ? WHERE id = ? AND status = ?`,
+		QueueStatusCancelled, time.Now(), id, QueueStatusQueued,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cancel queued job: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("no queued job %s", id)
+	}
+
+	return nil
+}
+
+func (db *DB) updateQueuedJob(query string, args ...any) error {
+	result, err := db.conn.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update queued job: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("queued job not found: %s", args[len(args)-1])
+	}
+
+	return nil
+}
+
+func (db *DB) queryQueuedJobs(query string, args ...any) ([]*QueuedJob, error) {
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queued jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*QueuedJob
+	for rows.Next() {
+		q, err := scanQueuedJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan queued job: %w", err)
+		}
+		jobs = append(jobs, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating queued jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func scanQueuedJob(row rowScanner) (*QueuedJob, error) {
+	q := &QueuedJob{}
+	err := row.Scan(
+		&q.ID,
+		&q.Priority,
+		&q.Status,
+		&q.JobID,
+		&q.Schedule,
+		&q.Error,
+		&q.QueuedAt,
+		&q.StartedAt,
+		&q.FinishedAt,
+		&q.RepoPath,
+		&q.Workspace,
+		&q.TasksDir,
+		&q.TargetBranch,
+		&q.FeatureBranch,
+		&q.Parallelism,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// scheduleColumns lists the schedule columns in scan order
+const scheduleColumns = `name, cron, priority, next_run_at, last_run_at, created_at,
+		       repo_path, workspace, tasks_dir, target_branch, feature_branch,
+		       parallelism`
+
+// CreateSchedule inserts a new schedule.
+// Fails if a schedule with the same name exists.
+func (db *DB) CreateSchedule(s *Schedule) error {
+	query := `
+		INSERT INTO schedules (
+			name, cron, repo_path, workspace, tasks_dir, target_branch,
+			feature_branch, parallelism, priority, next_run_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := db.conn.Exec(
+		query,
+		s.Name,
+		s.Cron,
+		s.RepoPath,
+		s.Workspace,
+		s.TasksDir,
+		s.TargetBranch,
+		s.FeatureBranch,
+		s.Parallelism,
+		s.Priority,
+		s.NextRunAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create schedule: %w", err)
+	}
+
+	return nil
+}
+
+// ListSchedules returns all schedules ordered by name.
+func (db *DB) ListSchedules() ([]*Schedule, error) {
+	return db.querySchedules(`SELECT ` + scheduleColumns + ` FROM schedules ORDER BY name`)
+}
+
+// ListDueSchedules returns the schedules whose next run is at or before now.
+func (db *DB) ListDueSchedules(now time.Time) ([]*Schedule, error) {
+	// Compared here rather than in SQL, where times are stored as text
+	schedules, err := db.ListSchedules()
+	if err != nil {
+		return nil, err
+	}
+	var due []*Schedule
+	for _, s := range schedules {
+		if !s.NextRunAt.After(now) {
+			due = append(due, s)
+		}
+	}
+	return due, nil
+}
+
+// MarkScheduleRun records that the schedule queued its job at ranAt and
+// next runs at next.
+func (db *DB) MarkScheduleRun(name string, ranAt, next time.Time) error {
+	_, err := db.conn.Exec(
+		`UPDATE schedules SET last_run_at = ?, next_run_at = ? WHERE name = ?`,
+		ranAt, next, name,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update schedule: %w", err)
+	}
+	return nil
+}
+
+// DeleteSchedule removes a schedule.
+// Returns an error if the schedule doesn't exist.
+func (db *DB) DeleteSchedule(name string) error {
+	result, err := db.conn.Exec(`DELETE FROM schedules WHERE name = ?`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("schedule not found: %s", name)
+	}
+
+	return nil
+}
+
+func (db *DB) querySchedules(query string, args ...any) ([]*Schedule, error) {
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []*Schedule
+	for rows.Next() {
+		s := &Schedule{}
+		err := rows.Scan(
+			&s.Name,
+			&s.Cron,
+			&s.Priority,
+			&s.NextRunAt,
+			&s.LastRunAt,
+			&s.CreatedAt,
+			&s.RepoPath,
+			&s.Workspace,
+			&s.TasksDir,
+			&s.TargetBranch,
+			&s.FeatureBranch,
+			&s.Parallelism,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan schedule: %w", err)
+		}
+		schedules = append(schedules, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating schedules: %w", err)
+	}
+
+	return schedules, nil
+}
@@ -0,0 +1,254 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DeleteRuns removes runs with their units and events in one transaction.
+// Units and events are deleted explicitly rather than by cascade, which
+// depends on a per-connection pragma.
+func (db *DB) DeleteRuns(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, id := range ids {
+		for _, query := range []string{
+			`DELETE FROM events WHERE run_id = ?`,
+			`DELETE FROM units WHERE run_id = ?`,
+			`DELETE FROM run_imports WHERE run_id = ?`,
+			`DELETE FROM runs WHERE id = ?`,
+		} {
+			if _, err := tx.Exec(query, id); err != nil {
+				return fmt.Errorf("failed to delete run %s: %w", id, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CompactionSummary is the payload of an event that replaced a unit's
+// compacted events.
+type CompactionSummary struct {
+	Events        int            `json:"events"`         // Number of events replaced
+	Counts        map[string]int `json:"counts"`         // Replaced events by type
+	FirstSequence int            `json:"first_sequence"` // Sequence of the first replaced event
+	LastSequence  int            `json:"last_sequence"`  // Sequence of the last replaced event
+	FirstAt       time.Time      `json:"first_at"`
+	LastAt        time.Time      `json:"last_at"`
+}
+
+// CompactEvents replaces a run's events of the given types with one event
+// of summaryType per unit, holding a CompactionSummary. The summary takes
+// the sequence of the first event it replaces, so replay order is kept.
+// Returns the number of events removed.
+func (db *DB) CompactEvents(runID string, types []string, summaryType string) (int, error) {
+	if len(types) == 0 {
+		return 0, nil
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(types)), ", ")
+	args := []any{runID}
+	for _, t := range types {
+		args = append(args, t)
+	}
+
+	rows, err := tx.Query(`
+		SELECT sequence, event_type, unit_id, created_at
+		FROM events
+		WHERE run_id = ? AND event_type IN (`+placeholders+`)
+		ORDER BY sequence`, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list events to compact: %w", err)
+	}
+
+	type unitSummary struct {
+		unitID  *string
+		summary CompactionSummary
+	}
+	var order []string
+	summaries := make(map[string]*unitSummary)
+	total := 0
+	for rows.Next() {
+		var (
+			sequence  int
+			eventType string
+			unitID    *string
+			createdAt time.Time
+		)
+		if err := rows.Scan(&sequence, &eventType, &unitID, &createdAt); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan event: %w", err)
+		}
+
+		key := ""
+		if unitID != nil {
+			key = *unitID
+		}
+		s, ok := summaries[key]
+		if !ok {
+			s = &unitSummary{unitID: unitID, summary: CompactionSummary{
+				Counts:        make(map[string]int),
+				FirstSequence: sequence,
+				FirstAt:       createdAt,
+			}}
+			summaries[key] = s
+			order = append(order, key)
+		}
+		s.summary.Events++
+		s.summary.Counts[eventType]++
+		s.summary.LastSequence = sequence
+		s.summary.LastAt = createdAt
+		total++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating events: %w", err)
+	}
+	if total == 0 {
+		return 0, nil
+	}
+
+	if _, err := tx.Exec(`DELETE FROM events WHERE run_id = ? AND event_type IN (`+placeholders+`)`, args...); err != nil {
+		return 0, fmt.Errorf("failed to delete compacted events: %w", err)
+	}
+
+	for _, key := range order {
+		s := summaries[key]
+		payload, err := json.Marshal(s.summary)
+		if err != nil {
+			return 0, fmt.Errorf("failed to serialize summary: %w", err)
+		}
+		_, err = tx.Exec(`
+			INSERT INTO events (run_id, sequence, event_type, unit_id, payload_json, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			runID, s.summary.FirstSequence, summaryType, s.unitID, string(payload), s.summary.FirstAt)
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert summary event: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return total, nil
+}
+
+// ImportRun inserts a run recorded by another daemon with its units and
+// events as they were, keeping event sequences and timestamps, and records
+// when it was imported. Fails if the run already exists.
+func (db *DB) ImportRun(run *Run, units []*UnitRecord, events []*EventRecord) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists int
+	err = tx.QueryRow(`SELECT 1 FROM runs WHERE id = ?`, run.ID).Scan(&exists)
+	if err == nil {
+		return fmt.Errorf("run %s already exists", run.ID)
+	}
+	if err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check for run: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO runs (
+			id, feature_branch, repo_path, target_branch, tasks_dir,
+			parallelism, status, daemon_version, started_at, completed_at,
+			error, config_json
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		run.ID, run.FeatureBranch, run.RepoPath, run.TargetBranch, run.TasksDir,
+		run.Parallelism, run.Status, run.DaemonVersion, run.StartedAt, run.CompletedAt,
+		run.Error, run.ConfigJSON,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed: runs.feature_branch, runs.repo_path") {
+			return fmt.Errorf("run already exists for branch %s in repo %s", run.FeatureBranch, run.RepoPath)
+		}
+		return fmt.Errorf("failed to insert run: %w", err)
+	}
+
+	for _, u := range units {
+		_, err := tx.Exec(`
+			INSERT INTO units (
+				id, run_id, unit_id, status, branch, worktree_path,
+				started_at, completed_at, error
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			MakeUnitRecordID(run.ID, u.UnitID), run.ID, u.UnitID, u.Status, u.Branch, u.WorktreePath,
+			u.StartedAt, u.CompletedAt, u.Error,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert unit %s: %w", u.UnitID, err)
+		}
+	}
+
+	for _, e := range events {
+		_, err := tx.Exec(`
+			INSERT INTO events (run_id, sequence, event_type, unit_id, payload_json, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			run.ID, e.Sequence, e.EventType, e.UnitID, e.PayloadJSON, e.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert event %d: %w", e.Sequence, err)
+		}
+	}
+
+	if _, err := tx.Exec(`INSERT INTO run_imports (run_id, imported_at) VALUES (?, ?)`, run.ID, time.Now()); err != nil {
+		return fmt.Errorf("failed to record import of run %s: %w", run.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListRunImports returns when each imported run was imported, by run ID.
+func (db *DB) ListRunImports() (map[string]time.Time, error) {
+	rows, err := db.conn.Query(`SELECT run_id, imported_at FROM run_imports`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list run imports: %w", err)
+	}
+	defer rows.Close()
+
+	imports := make(map[string]time.Time)
+	for rows.Next() {
+		var (
+			runID      string
+			importedAt time.Time
+		)
+		if err := rows.Scan(&runID, &importedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan run import: %w", err)
+		}
+		imports[runID] = importedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating run imports: %w", err)
+	}
+	return imports, nil
+}
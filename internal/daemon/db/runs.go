@@ -343,3 +343,49 @@ func (db *DB) DeleteNonActiveRunByBranch(featureBranch, repoPath string) (int, e
 
 	return int(deleted), nil
 }
+
+// ListRuns returns all runs regardless of status, ordered by ID.
+func (db *DB) ListRuns() ([]*Run, error) {
+	query := `
+		SELECT id, feature_branch, repo_path, target_branch, tasks_dir,
+		       parallelism, status, daemon_version, started_at, completed_at,
+		       error, config_json
+		FROM runs
+		ORDER BY id
+	`
+
+	rows, err := db.conn.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*Run
+	for rows.Next() {
+		run := &Run{}
+		err := rows.Scan(
+			&run.ID,
+			&run.FeatureBranch,
+			&run.RepoPath,
+			&run.TargetBranch,
+			&run.TasksDir,
+			&run.Parallelism,
+			&run.Status,
+			&run.DaemonVersion,
+			&run.StartedAt,
+			&run.CompletedAt,
+			&run.Error,
+			&run.ConfigJSON,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating runs: %w", err)
+	}
+
+	return runs, nil
+}
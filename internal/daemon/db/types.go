@@ -68,6 +68,89 @@ type EventRecord struct {
 	CreatedAt   time.Time `db:"created_at"`   // When event was recorded
 }
 
+// FeaturePRStatus represents the lifecycle state of a feature PR
+type FeaturePRStatus string
+
+const (
+	FeaturePRStatusOpen   FeaturePRStatus = "open"
+	FeaturePRStatusMerged FeaturePRStatus = "merged"
+	FeaturePRStatusClosed FeaturePRStatus = "closed"
+)
+
+// FeaturePR represents a feature pull request opened by a run
+type FeaturePR struct {
+	ID            int64           `db:"id"`             // Auto-increment primary key
+	RunID         string          `db:"run_id"`         // Run that opened the PR
+	RepoPath      string          `db:"repo_path"`      // Absolute path to repository
+	FeatureBranch string          `db:"feature_branch"` // PR head branch
+	TargetBranch  string          `db:"target_branch"`  // PR base branch
+	TasksDir      string          `db:"tasks_dir"`      // Directory containing the feature's units
+	PRNumber      int             `db:"pr_number"`      // PR number on the forge
+	PRURL         string          `db:"pr_url"`         // PR web URL
+	Status        FeaturePRStatus `db:"status"`         // Current PR status
+	CreatedAt     time.Time       `db:"created_at"`     // When the PR was recorded
+	ClosedAt      *time.Time      `db:"closed_at"`      // When the PR was seen merged or closed
+	Error         *string         `db:"error"`          // Problems closing out the feature
+}
+
+// Workspace represents a repository registered with the daemon
+type Workspace struct {
+	Name         string     `db:"name"`          // Unique workspace name
+	RepoURL      string     `db:"repo_url"`      // URL the repository is cloned from
+	MirrorPath   string     `db:"mirror_path"`   // Absolute path to the daemon's clone
+	TargetBranch string     `db:"target_branch"` // Default base branch for jobs
+	TasksDir     string     `db:"tasks_dir"`     // Default tasks directory, relative to the clone
+	Parallelism  int        `db:"parallelism"`   // Default max concurrent units (0 = default)
+	MaxJobs      int        `db:"max_jobs"`      // Max concurrent jobs (0 = daemon default)
+	CreatedAt    time.Time  `db:"created_at"`    // When the workspace was registered
+	FetchedAt    *time.Time `db:"fetched_at"`    // When the clone was last fetched
+}
+
+// QueueStatus represents the lifecycle state of a queued job
+type QueueStatus string
+
+const (
+	QueueStatusQueued    QueueStatus = "queued"
+	QueueStatusStarted   QueueStatus = "started"
+	QueueStatusFinished  QueueStatus = "finished"
+	QueueStatusCancelled QueueStatus = "cancelled"
+)
+
+// JobSpec is the configuration of a job that has not started yet
+type JobSpec struct {
+	RepoPath      string `db:"repo_path"`      // Absolute path to repository; empty for workspace jobs
+	Workspace     string `db:"workspace"`      // Registered workspace to run in
+	TasksDir      string `db:"tasks_dir"`      // Directory containing task definitions
+	TargetBranch  string `db:"target_branch"`  // Branch to merge into
+	FeatureBranch string `db:"feature_branch"` // Branch being worked on; empty in PR mode
+	Parallelism   int    `db:"parallelism"`    // Max concurrent units (0 = default)
+}
+
+// QueuedJob represents a job in the daemon's queue
+type QueuedJob struct {
+	ID         string      `db:"id"`          // ULID, so entries sort by queue time
+	Priority   int         `db:"priority"`    // Higher priorities start first
+	Status     QueueStatus `db:"status"`      // Current queue status
+	JobID      *string     `db:"job_id"`      // Run started from this entry
+	Schedule   *string     `db:"schedule"`    // Schedule that queued the job
+	Error      *string     `db:"error"`       // Why the job could not start
+	QueuedAt   time.Time   `db:"queued_at"`   // When the job was queued
+	StartedAt  *time.Time  `db:"started_at"`  // When the job left the queue
+	FinishedAt *time.Time  `db:"finished_at"` // When the started job ended
+	JobSpec
+}
+
+// Schedule represents a job queued at the times of a cron expression
+type Schedule struct {
+	Name      string     `db:"name"`        // Unique schedule name
+	Cron      string     `db:"cron"`        // Five-field cron expression
+	Priority  int        `db:"priority"`    // Priority of the queued jobs
+	NextRunAt time.Time  `db:"next_run_at"` // When the job is next queued
+	LastRunAt *time.Time `db:"last_run_at"` // When the job was last queued
+	CreatedAt time.Time  `db:"created_at"`  // When the schedule was added
+	JobSpec
+}
+
 // NewRunID generates a new ULID-based run ID
 func NewRunID() string {
 	return ulid.MustNew(ulid.Timestamp(time.Now()), rand.Reader).String()
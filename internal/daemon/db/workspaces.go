@@ -0,0 +1,133 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// workspaceColumns lists the workspace columns in scan order
+const workspaceColumns = `name, repo_url, mirror_path, target_branch, tasks_dir,
+		       parallelism, max_jobs, created_at, fetched_at`
+
+// CreateWorkspace inserts a new workspace.
+// Fails if a workspace with the same name exists.
+func (db *DB) CreateWorkspace(ws *Workspace) error {
+	query := `
+		INSERT INTO workspaces (
+			name, repo_url, mirror_path, target_branch, tasks_dir,
+			parallelism, max_jobs
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := db.conn.Exec(
+		query,
+		ws.Name,
+		ws.RepoURL,
+		ws.MirrorPath,
+		ws.TargetBranch,
+		ws.TasksDir,
+		ws.Parallelism,
+		ws.MaxJobs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	return nil
+}
+
+// GetWorkspace retrieves a workspace by name.
+// Returns nil, nil if the workspace doesn't exist.
+func (db *DB) GetWorkspace(name string) (*Workspace, error) {
+	query := `SELECT ` + workspaceColumns + ` FROM workspaces WHERE name = ?`
+
+	ws, err := scanWorkspace(db.conn.QueryRow(query, name))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	return ws, nil
+}
+
+// ListWorkspaces returns all workspaces ordered by name.
+func (db *DB) ListWorkspaces() ([]*Workspace, error) {
+	query := `SELECT ` + workspaceColumns + ` FROM workspaces ORDER BY name`
+
+	rows, err := db.conn.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	var workspaces []*Workspace
+	for rows.Next() {
+		ws, err := scanWorkspace(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan workspace: %w", err)
+		}
+		workspaces = append(workspaces, ws)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating workspaces: %w", err)
+	}
+
+	return workspaces, nil
+}
+
+// DeleteWorkspace removes a workspace.
+// Returns an error if the workspace doesn't exist.
+func (db *DB) DeleteWorkspace(name string) error {
+	result, err := db.conn.Exec(`DELETE FROM workspaces WHERE name = ?`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("workspace not found: %s", name)
+	}
+
+	return nil
+}
+
+// MarkWorkspaceFetched records that the workspace's clone was fetched at t.
+func (db *DB) MarkWorkspaceFetched(name string, t time.Time) error {
+	_, err := db.conn.Exec(`UPDATE workspaces SET fetched_at = ? WHERE name = ?`, t, name)
+	if err != nil {
+		return fmt.Errorf("failed to update workspace fetch time: %w", err)
+	}
+	return nil
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWorkspace(row rowScanner) (*Workspace, error) {
+	ws := &Workspace{}
+	err := row.Scan(
+		&ws.Name,
+		&ws.RepoURL,
+		&ws.MirrorPath,
+		&ws.TargetBranch,
+		&ws.TasksDir,
+		&ws.Parallelism,
+		&ws.MaxJobs,
+		&ws.CreatedAt,
+		&ws.FetchedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
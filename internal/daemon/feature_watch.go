@@ -0,0 +1,414 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/archive"
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/feature"
+	"github.com/RevCBH/choo/internal/forge"
+	"github.com/RevCBH/choo/internal/git"
+	"github.com/RevCBH/choo/internal/intake"
+)
+
+// closeoutBranchPrefix is the prefix of the branches that carry a
+// feature's close-out commit to the target branch through a PR.
+const closeoutBranchPrefix = "choo/close-out-"
+
+// FeatureCloseout describes the cleanup performed after a feature PR merged.
+type FeatureCloseout struct {
+	PR               *db.FeaturePR
+	PRDID            string
+	CloseoutPR       *forge.PRInfo // Proposes the close-out commit to the target branch
+	ArchivedSpecs    []string
+	ArchivedUnits    []string
+	RemovedWorktrees []string
+	DeletedBranches  []string // Local branches
+	DeletedRemote    []string // Branches deleted on origin
+	Errors           []string // Steps that failed; the others still ran
+
+	// prd is the merged PRD if it was imported from an issue tracker
+	prd *feature.PRD
+}
+
+// Summary returns a one-line description of the closeout.
+func (c *FeatureCloseout) Summary() string {
+	summary := fmt.Sprintf("Closed out %s after PR #%d merged: archived %d spec(s) and %d unit(s), removed %d worktree(s), deleted %d local and %d remote branch(es)",
+		c.PR.FeatureBranch, c.PR.PRNumber, len(c.ArchivedSpecs), len(c.ArchivedUnits),
+		len(c.RemovedWorktrees), len(c.DeletedBranches), len(c.DeletedRemote))
+	if c.CloseoutPR != nil {
+		summary += fmt.Sprintf(", opened close-out PR #%d", c.CloseoutPR.Number)
+	}
+	if len(c.Errors) > 0 {
+		summary += fmt.Sprintf(", %d error(s)", len(c.Errors))
+	}
+	return summary
+}
+
+func (c *FeatureCloseout) fail(format string, args ...any) {
+	c.Errors = append(c.Errors, fmt.Sprintf(format, args...))
+}
+
+// FeatureWatcher polls the feature PRs opened by daemon jobs and closes out
+// features whose PR has merged: a PR marking the PRD complete and archiving
+// any remaining specs is opened against the target branch, the run's unit
+// worktrees are removed, the feature and unit branches are deleted locally
+// and on origin, feature.completed is
+// recorded in the run's event log, and the issue the PRD was imported from,
+// if any, is closed.
+type FeatureWatcher struct {
+	db       *db.DB
+	record   func(runID string, e events.Event)
+	isActive func(runID string) bool
+	newForge func(repoPath string) (forge.Forge, error)
+
+	// runMu serializes watch passes.
+	runMu sync.Mutex
+}
+
+// NewFeatureWatcher creates a feature watcher. isActive reports whether a
+// run is currently executing in this daemon; its PR is left alone until the
+// run finishes. The runs' bus is closed by then, so events are passed to
+// record, which may be nil.
+func NewFeatureWatcher(database *db.DB, record func(runID string, e events.Event), isActive func(runID string) bool) *FeatureWatcher {
+	return &FeatureWatcher{
+		db:       database,
+		record:   record,
+		isActive: isActive,
+		newForge: newRepoForge,
+	}
+}
+
+// Run performs a watch pass every interval until ctx is cancelled.
+func (w *FeatureWatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			closeouts, err := w.Check(ctx)
+			if err != nil {
+				log.Printf("Feature watch failed: %v", err)
+				continue
+			}
+			for _, c := range closeouts {
+				log.Println(c.Summary())
+				for _, msg := range c.Errors {
+					log.Printf("Feature closeout: %s", msg)
+				}
+			}
+		}
+	}
+}
+
+// Check polls every open feature PR once and closes out the features whose
+// PR has merged. PRs closed without merging stop being watched.
+func (w *FeatureWatcher) Check(ctx context.Context) ([]*FeatureCloseout, error) {
+	w.runMu.Lock()
+	defer w.runMu.Unlock()
+
+	prs, err := w.db.ListFeaturePRsByStatus(db.FeaturePRStatusOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	var closeouts []*FeatureCloseout
+	for _, pr := range prs {
+		if ctx.Err() != nil {
+			return closeouts, ctx.Err()
+		}
+		// The run may still be waiting on CI or review for this PR
+		if w.isActive != nil && w.isActive(pr.RunID) {
+			continue
+		}
+
+		client, err := w.newForge(pr.RepoPath)
+		if err != nil {
+			log.Printf("Feature watch: cannot check %s PR #%d: %v", pr.FeatureBranch, pr.PRNumber, err)
+			continue
+		}
+		info, err := client.GetPR(ctx, pr.PRNumber)
+		if err != nil {
+			log.Printf("Feature watch: failed to get %s PR #%d: %v", pr.FeatureBranch, pr.PRNumber, err)
+			continue
+		}
+
+		switch {
+		case info.Merged:
+			c := w.closeOut(ctx, pr, client)
+			closeouts = append(closeouts, c)
+			var errMsg *string
+			if len(c.Errors) > 0 {
+				errMsg = ptrString(strings.Join(c.Errors, "; "))
+			}
+			if err := w.db.UpdateFeaturePRStatus(pr.ID, db.FeaturePRStatusMerged, errMsg); err != nil {
+				return closeouts, err
+			}
+		case info.State == "closed":
+			log.Printf("Feature watch: %s PR #%d was closed without merging", pr.FeatureBranch, pr.PRNumber)
+			if err := w.db.UpdateFeaturePRStatus(pr.ID, db.FeaturePRStatusClosed, nil); err != nil {
+				return closeouts, err
+			}
+		}
+	}
+
+	return closeouts, nil
+}
+
+// closeOut runs every cleanup step for a merged feature PR. A failing step
+// is recorded and the remaining steps still run.
+func (w *FeatureWatcher) closeOut(ctx context.Context, pr *db.FeaturePR, client forge.Forge) *FeatureCloseout {
+	c := &FeatureCloseout{PR: pr}
+
+	cfg, err := config.LoadConfig(pr.RepoPath)
+	if err != nil {
+		c.fail("failed to load config for %s: %v", pr.RepoPath, err)
+		return c
+	}
+	c.PRDID = strings.TrimPrefix(pr.FeatureBranch, cfg.Feature.BranchPrefix)
+
+	if err := closeOutSpecs(ctx, pr, cfg, client, c); err != nil {
+		c.fail("%v", err)
+	}
+
+	// Only what this run recorded is removed: other features' units may
+	// share unit IDs
+	units, err := w.db.ListUnitsByRun(pr.RunID)
+	if err != nil {
+		c.fail("failed to list units of run %s: %v", pr.RunID, err)
+	}
+	removeUnitWorktrees(ctx, pr.RepoPath, units, c)
+	deleteFeatureBranches(ctx, pr, units, c)
+
+	unitIDs := make([]string, 0, len(units))
+	for _, unit := range units {
+		unitIDs = append(unitIDs, unit.UnitID)
+	}
+	sort.Strings(unitIDs)
+	e := events.NewEvent(events.FeatureCompleted, c.PRDID).
+		WithPR(pr.PRNumber).
+		WithPayload(map[string]any{
+			"branch":   pr.FeatureBranch,
+			"target":   pr.TargetBranch,
+			"url":      pr.PRURL,
+			"units":    unitIDs,
+			"branches": append(append([]string(nil), c.DeletedBranches...), c.DeletedRemote...),
+		})
+	if len(c.Errors) > 0 {
+		e = e.WithError(fmt.Errorf("%s", strings.Join(c.Errors, "; ")))
+	}
+	if w.record != nil {
+		w.record(pr.RunID, e)
+	}
+	if tracker := issueTracker(cfg, c.prd); tracker != nil {
+		tracker.Handler()(e)
+		tracker.Close()
+	}
+
+	return c
+}
+
+// closeOutSpecs marks the PRD complete and archives completed specs in a
+// temporary worktree of the merged target branch, then proposes the result
+// in a PR so it goes through the target branch's review and protection.
+// The archived unit IDs are recorded even if the PR cannot be opened.
+func closeOutSpecs(ctx context.Context, pr *db.FeaturePR, cfg *config.Config, client forge.Forge, c *FeatureCloseout) error {
+	repo := pr.RepoPath
+	if err := git.Fetch(ctx, repo, pr.TargetBranch); err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", pr.TargetBranch, err)
+	}
+
+	tmp, err := os.MkdirTemp("", "choo-closeout-")
+	if err != nil {
+		return fmt.Errorf("failed to create closeout directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "worktree")
+	if _, err := gitOutput(ctx, repo, "worktree", "add", "--detach", path, "origin/"+pr.TargetBranch); err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = gitOutput(context.Background(), repo, "worktree", "remove", "--force", path)
+	}()
+
+	prdDir := filepath.Join(path, repoRelative(repo, cfg.Feature.PRDDir))
+	store := feature.NewPRDStore(prdDir)
+	if store.Exists(c.PRDID) {
+		if err := store.UpdateStatus(c.PRDID, feature.StatusComplete); err != nil {
+			return fmt.Errorf("failed to mark PRD %s complete: %w", c.PRDID, err)
+		}
+	}
+	c.prd = intake.ImportedPRD(prdDir, c.PRDID)
+
+	tasksDir := repoRelative(repo, pr.TasksDir)
+	if tasksDir == "" {
+		tasksDir = "specs/tasks"
+	}
+	specsDir := filepath.Join(path, strings.TrimSuffix(tasksDir, "/tasks"))
+	if _, err := os.Stat(specsDir); err == nil {
+		c.ArchivedSpecs, err = archive.Archive(archive.ArchiveOptions{SpecsDir: specsDir})
+		if err != nil {
+			return fmt.Errorf("failed to archive specs: %w", err)
+		}
+		c.ArchivedUnits, err = archive.ArchiveTasksDir(specsDir, false)
+		if err != nil {
+			return fmt.Errorf("failed to archive units: %w", err)
+		}
+	}
+
+	status, err := gitOutput(ctx, path, "status", "--porcelain")
+	if err != nil || status == "" {
+		return err
+	}
+	if _, err := gitOutput(ctx, path, "add", "-A"); err != nil {
+		return err
+	}
+	if _, err := gitOutput(ctx, path, "commit", "-m", "chore: close out "+c.PRDID); err != nil {
+		return err
+	}
+	// The branch is the daemon's own; a previous attempt's is replaced
+	branch := closeoutBranchPrefix + c.PRDID
+	if _, err := gitOutput(ctx, path, "push", "--force", "origin", "HEAD:refs/heads/"+branch); err != nil {
+		return fmt.Errorf("failed to push closeout branch %s: %w", branch, err)
+	}
+	body := fmt.Sprintf("Closes out `%s` after #%d merged: marks the PRD complete and archives its remaining specs.", c.PRDID, pr.PRNumber)
+	info, err := client.OpenPR(ctx, branch, pr.TargetBranch, "chore: close out "+c.PRDID, body)
+	if err != nil {
+		return fmt.Errorf("failed to open closeout PR for %s: %w", branch, err)
+	}
+	c.CloseoutPR = info
+	return nil
+}
+
+// removeUnitWorktrees removes the worktrees recorded for the run's units.
+func removeUnitWorktrees(ctx context.Context, repoPath string, units []*db.UnitRecord, c *FeatureCloseout) {
+	for _, unit := range units {
+		if unit.WorktreePath == nil || *unit.WorktreePath == "" {
+			continue
+		}
+		wt := GCWorktree{RepoPath: repoPath, Path: *unit.WorktreePath}
+		if !filepath.IsAbs(wt.Path) {
+			wt.Path = filepath.Join(repoPath, wt.Path)
+		}
+		if _, err := os.Stat(wt.Path); err != nil {
+			continue
+		}
+		if unit.Branch != nil {
+			wt.Branch = *unit.Branch
+		}
+		if err := removeWorktree(ctx, wt); err != nil {
+			c.fail("%v", err)
+			continue
+		}
+		c.RemovedWorktrees = append(c.RemovedWorktrees, wt.Path)
+	}
+}
+
+// deleteFeatureBranches deletes the feature branch and the worker branches
+// recorded for the run's units, locally and on origin. If the repository
+// has the feature branch checked out and is clean, it is switched to the
+// target branch first.
+func deleteFeatureBranches(ctx context.Context, pr *db.FeaturePR, units []*db.UnitRecord, c *FeatureCloseout) {
+	repo := pr.RepoPath
+	branches := map[string]bool{pr.FeatureBranch: true}
+	for _, unit := range units {
+		if unit.Branch != nil && *unit.Branch != "" {
+			branches[*unit.Branch] = true
+		}
+	}
+	isFeatureBranch := func(branch string) bool {
+		return branches[branch]
+	}
+
+	if current, err := git.GetCurrentBranch(ctx, repo); err == nil && current == pr.FeatureBranch {
+		if status, err := gitOutput(ctx, repo, "status", "--porcelain"); err != nil || status != "" {
+			c.fail("leaving %s checked out in %s: working tree is not clean", pr.FeatureBranch, repo)
+		} else if _, err := gitOutput(ctx, repo, "checkout", pr.TargetBranch); err != nil {
+			c.fail("%v", err)
+		}
+	}
+
+	local, err := git.ListLocalBranches(ctx, repo, "")
+	if err != nil {
+		c.fail("%v", err)
+	}
+	client := git.NewClient(repo)
+	for _, branch := range local {
+		if !isFeatureBranch(branch) {
+			continue
+		}
+		if err := client.DeleteBranch(ctx, branch); err != nil {
+			c.fail("failed to delete branch %s: %v", branch, err)
+			continue
+		}
+		c.DeletedBranches = append(c.DeletedBranches, branch)
+	}
+
+	remote, err := git.ListRemoteBranches(ctx, repo, "")
+	if err != nil {
+		c.fail("%v", err)
+	}
+	sort.Strings(remote)
+	for _, branch := range remote {
+		if !isFeatureBranch(branch) {
+			continue
+		}
+		if err := git.DeleteRemoteBranch(ctx, repo, branch); err != nil {
+			c.fail("%v", err)
+			continue
+		}
+		c.DeletedRemote = append(c.DeletedRemote, branch)
+	}
+}
+
+// newRepoForge creates the forge client configured for a repository.
+func newRepoForge(repoPath string) (forge.Forge, error) {
+	cfg, err := config.LoadConfig(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return forge.New(forge.Config{
+		Type:     cfg.Forge.Type,
+		URL:      cfg.Forge.URL,
+		TokenEnv: cfg.Forge.TokenEnv,
+		Owner:    cfg.GitHub.Owner,
+		Repo:     cfg.GitHub.Repo,
+	})
+}
+
+// repoRelative returns path relative to the repository root.
+func repoRelative(repoPath, path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+	if rel, err := filepath.Rel(repoPath, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+// gitOutput runs a git command in dir and returns its trimmed output.
+func gitOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,234 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/forge"
+	"github.com/RevCBH/choo/internal/testutil"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPRForge reports a fixed state for every PR and records the PRs opened
+type stubPRForge struct {
+	forge.Forge
+	info   *forge.PRInfo
+	opened []string // head -> base
+}
+
+func (f *stubPRForge) GetPR(ctx context.Context, prNumber int) (*forge.PRInfo, error) {
+	return f.info, nil
+}
+
+func (f *stubPRForge) OpenPR(ctx context.Context, head, base, title, body string) (*forge.PRInfo, error) {
+	f.opened = append(f.opened, head+" -> "+base)
+	return &forge.PRInfo{Number: 2, State: "open"}, nil
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// testPRD is the PRD of the test feature, imported from GitHub issue 42
+const testPRD = "---\nprd_id: api\ntitle: API\nstatus: approved\nfeature_status: pr_open\nsource:\n  type: github\n  key: \"42\"\n---\n\n# API\n"
+
+// setupFeatureRepo creates a repo whose feature/api branch, with one unit,
+// has been merged into main on origin. As on a completed run, the feature's
+// specs were archived before the PR was opened. The repo is left on
+// feature/api with a worktree for the unit.
+func setupFeatureRepo(t *testing.T) (repo, bare string) {
+	t.Helper()
+	testutil.UnsetGitEnv()
+
+	bare = t.TempDir()
+	testutil.Git(t, bare, "init", "--bare", "-b", "main")
+
+	repo = t.TempDir()
+	testutil.Git(t, repo, "init", "-b", "main")
+	testutil.Git(t, repo, "config", "user.email", "test@example.com")
+	testutil.Git(t, repo, "config", "user.name", "Test")
+	writeFile(t, filepath.Join(repo, ".choo.yaml"), "github:\n  owner: local\n  repo: app\nforge:\n  type: github\n")
+	writeFile(t, filepath.Join(repo, ".gitignore"), ".ralph/\n")
+	testutil.Git(t, repo, "add", "-A")
+	testutil.Git(t, repo, "commit", "-m", "initial")
+	testutil.Git(t, repo, "remote", "add", "origin", bare)
+	testutil.Git(t, repo, "push", "-u", "origin", "main")
+
+	testutil.Git(t, repo, "checkout", "-b", "feature/api")
+	writeFile(t, filepath.Join(repo, "docs", "prd", "api.md"),
+		testPRD)
+	writeFile(t, filepath.Join(repo, "specs", "completed", "API.md"), "---\nstatus: complete\n---\n\n# API\n")
+	writeFile(t, filepath.Join(repo, "specs", "completed", "tasks", "unit-a", "01-handlers.md"),
+		"---\ntask: 1\nstatus: complete\n---\n\n# Handlers\n")
+	testutil.Git(t, repo, "add", "-A")
+	testutil.Git(t, repo, "commit", "-m", "feat: api")
+	testutil.Git(t, repo, "push", "-u", "origin", "feature/api")
+
+	testutil.Git(t, repo, "worktree", "add", "-b", "ralph/unit-a-abc123", filepath.Join(repo, ".ralph", "worktrees", "unit-a"), "feature/api")
+	testutil.Git(t, repo, "push", "origin", "ralph/unit-a-abc123")
+	testutil.Git(t, repo, "branch", "ralph/unit-b-def456", "main")
+	// Another feature's unit with the same ID
+	testutil.Git(t, repo, "branch", "ralph/unit-a-fed321", "main")
+	testutil.Git(t, repo, "push", "origin", "ralph/unit-a-fed321")
+
+	// Merge the PR on origin
+	testutil.Git(t, repo, "checkout", "main")
+	testutil.Git(t, repo, "merge", "--no-ff", "-m", "Merge feature/api", "feature/api")
+	testutil.Git(t, repo, "push", "origin", "main")
+	testutil.Git(t, repo, "reset", "--hard", "HEAD~1")
+	testutil.Git(t, repo, "checkout", "feature/api")
+
+	return repo, bare
+}
+
+// recordTestFeaturePR records the feature PR of a run that worked on unit-a
+func recordTestFeaturePR(t *testing.T, database *db.DB, repo string) *db.FeaturePR {
+	t.Helper()
+	run := &db.Run{
+		ID:            ulid.Make().String(),
+		FeatureBranch: "feature/api",
+		RepoPath:      repo,
+		TargetBranch:  "main",
+		TasksDir:      "specs/tasks",
+		Parallelism:   1,
+		Status:        db.RunStatusCompleted,
+	}
+	require.NoError(t, database.CreateRun(run))
+	unitID := db.MakeUnitRecordID(run.ID, "unit-a")
+	require.NoError(t, database.CreateUnit(&db.UnitRecord{
+		ID:     unitID,
+		RunID:  run.ID,
+		UnitID: "unit-a",
+		Status: string(db.UnitStatusCompleted),
+	}))
+	require.NoError(t, database.UpdateUnitBranch(unitID, "ralph/unit-a-abc123", filepath.Join(repo, ".ralph", "worktrees", "unit-a")))
+
+	pr := &db.FeaturePR{
+		RunID:         run.ID,
+		RepoPath:      repo,
+		FeatureBranch: "feature/api",
+		TargetBranch:  "main",
+		TasksDir:      "specs/tasks",
+		PRNumber:      1,
+		PRURL:         "https://github.com/local/app/pull/1",
+	}
+	require.NoError(t, database.RecordFeaturePR(pr))
+	return pr
+}
+
+func newTestWatcher(database *db.DB, record func(string, events.Event), client *stubPRForge, active map[string]bool) *FeatureWatcher {
+	w := NewFeatureWatcher(database, record, func(runID string) bool { return active[runID] })
+	w.newForge = func(string) (forge.Forge, error) { return client, nil }
+	return w
+}
+
+func TestFeatureWatcher_ClosesOutMergedFeature(t *testing.T) {
+	database := setupTestDB(t)
+	repo, bare := setupFeatureRepo(t)
+	pr := recordTestFeaturePR(t, database, repo)
+	issues := useIssueSource(t)
+
+	jm := NewJobManager(database, 1)
+	client := &stubPRForge{info: &forge.PRInfo{Number: 1, State: "closed", Merged: true}}
+	w := newTestWatcher(database, jm.RecordEvent, client, nil)
+	closeouts, err := w.Check(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, closeouts, 1)
+	c := closeouts[0]
+	assert.Empty(t, c.Errors)
+	assert.Equal(t, "api", c.PRDID)
+	assert.Empty(t, c.ArchivedSpecs)
+	assert.Empty(t, c.ArchivedUnits)
+	assert.Contains(t, c.Summary(), "removed 1 worktree(s)")
+
+	// The PRD is marked complete in a PR to the target branch, which is
+	// left alone
+	assert.Equal(t, []string{"choo/close-out-api -> main"}, client.opened)
+	require.NotNil(t, c.CloseoutPR)
+	assert.Contains(t, c.Summary(), "opened close-out PR #2")
+	assert.Contains(t, testutil.Git(t, bare, "show", "choo/close-out-api:docs/prd/api.md"), "feature_status: complete")
+	assert.Contains(t, testutil.Git(t, bare, "log", "-1", "--format=%s", "choo/close-out-api"), "chore: close out api")
+	assert.Contains(t, testutil.Git(t, bare, "show", "main:docs/prd/api.md"), "feature_status: pr_open")
+	testutil.Git(t, bare, "cat-file", "-e", "main:specs/completed/API.md")
+	testutil.Git(t, bare, "cat-file", "-e", "main:specs/completed/tasks/unit-a/01-handlers.md")
+
+	// Worktree and branches gone, unrelated unit branch kept
+	assert.NoDirExists(t, filepath.Join(repo, ".ralph", "worktrees", "unit-a"))
+	assert.Equal(t, "main", testutil.Git(t, repo, "branch", "--show-current"))
+	branches := testutil.Git(t, repo, "branch", "--format=%(refname:short)")
+	assert.NotContains(t, branches, "feature/api")
+	assert.NotContains(t, branches, "ralph/unit-a-abc123")
+	assert.Contains(t, branches, "ralph/unit-b-def456")
+	assert.Contains(t, branches, "ralph/unit-a-fed321")
+	remote := testutil.Git(t, bare, "branch", "--format=%(refname:short)")
+	assert.NotContains(t, remote, "feature/api")
+	assert.NotContains(t, remote, "ralph/unit-a-abc123")
+	assert.Contains(t, remote, "ralph/unit-a-fed321")
+
+	// feature.completed is in the run's event log
+	records, err := database.ListEvents(pr.RunID)
+	require.NoError(t, err)
+	var completed []events.Event
+	for _, r := range records {
+		if e := eventFromRecord(r); e.Type == events.FeatureCompleted {
+			completed = append(completed, e)
+		}
+	}
+	require.Len(t, completed, 1)
+	assert.Equal(t, "api", completed[0].Unit)
+	require.NotNil(t, completed[0].PR)
+	assert.Equal(t, 1, *completed[0].PR)
+	assert.Equal(t, []any{"unit-a"}, completed[0].Payload.(map[string]any)["units"])
+
+	// The issue the PRD was imported from is closed
+	assert.Equal(t, []string{"choo: feature complete."}, issues.Comments("42"))
+	assert.Equal(t, []string{"42"}, issues.Closed())
+
+	open, err := database.ListFeaturePRsByStatus(db.FeaturePRStatusOpen)
+	require.NoError(t, err)
+	assert.Empty(t, open)
+	merged, err := database.ListFeaturePRsByStatus(db.FeaturePRStatusMerged)
+	require.NoError(t, err)
+	require.Len(t, merged, 1)
+	assert.Nil(t, merged[0].Error)
+}
+
+func TestFeatureWatcher_StopsWatchingClosedPR(t *testing.T) {
+	database := setupTestDB(t)
+	repo, _ := setupFeatureRepo(t)
+	recordTestFeaturePR(t, database, repo)
+
+	w := newTestWatcher(database, nil, &stubPRForge{info: &forge.PRInfo{Number: 1, State: "closed"}}, nil)
+	closeouts, err := w.Check(context.Background())
+	require.NoError(t, err)
+
+	assert.Empty(t, closeouts)
+	assert.Contains(t, testutil.Git(t, repo, "branch"), "feature/api")
+	closed, err := database.ListFeaturePRsByStatus(db.FeaturePRStatusClosed)
+	require.NoError(t, err)
+	assert.Len(t, closed, 1)
+}
+
+func TestFeatureWatcher_SkipsActiveRuns(t *testing.T) {
+	database := setupTestDB(t)
+	pr := recordTestFeaturePR(t, database, t.TempDir())
+
+	w := newTestWatcher(database, nil, &stubPRForge{info: &forge.PRInfo{Number: 1, State: "closed", Merged: true}},
+		map[string]bool{pr.RunID: true})
+	closeouts, err := w.Check(context.Background())
+	require.NoError(t, err)
+
+	assert.Empty(t, closeouts)
+	open, err := database.ListFeaturePRsByStatus(db.FeaturePRStatusOpen)
+	require.NoError(t, err)
+	assert.Len(t, open, 1)
+}
@@ -0,0 +1,508 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/git"
+	"github.com/dustin/go-humanize"
+)
+
+// unitBranchPrefix is the prefix of branches created by workers for units.
+const unitBranchPrefix = "ralph/"
+
+// worktreeLogsDir is the directory under the worktree base that holds
+// per-task provider logs rather than a worktree.
+const worktreeLogsDir = "logs"
+
+// worktreeStacksDir is the directory under the worktree base that holds
+// stacked PR state rather than a worktree.
+const worktreeStacksDir = "stacks"
+
+// GCPolicy controls which worktrees and branches the garbage collector removes.
+type GCPolicy struct {
+	// Retention is how long a finished run's worktrees are kept before removal.
+	Retention time.Duration
+
+	// Quota is the maximum number of bytes worktrees may use across all repos.
+	// When exceeded, the oldest idle worktrees are removed even if they are
+	// still within the retention window. Zero disables the quota.
+	Quota int64
+
+	// PruneBranches deletes local ralph/* unit branches in repos whose runs
+	// are past retention and that are no longer checked out in a worktree.
+	PruneBranches bool
+
+	// DryRun reports what would be removed without deleting anything.
+	DryRun bool
+}
+
+// GCReason explains why a worktree was selected for removal.
+type GCReason string
+
+const (
+	GCReasonRetention GCReason = "retention"
+	GCReasonQuota     GCReason = "quota"
+)
+
+// GCWorktree describes a worktree removed (or that would be removed) by GC.
+type GCWorktree struct {
+	RepoPath string
+	Path     string
+	Branch   string
+	Bytes    int64
+	LastUsed time.Time
+	Reason   GCReason
+}
+
+// GCBranch describes a unit branch pruned by GC.
+type GCBranch struct {
+	RepoPath string
+	Name     string
+}
+
+// GCReport summarizes a single garbage collection pass.
+type GCReport struct {
+	StartedAt      time.Time
+	DryRun         bool
+	UsageBefore    int64 // Bytes used by worktrees before the pass
+	UsageAfter     int64 // Bytes used after removal (projected in dry-run mode)
+	Removed        []GCWorktree
+	PrunedBranches []GCBranch
+	Errors         []string
+}
+
+// Summary returns a one-line description of the report for logging.
+func (r *GCReport) Summary() string {
+	verb := "removed"
+	if r.DryRun {
+		verb = "would remove"
+	}
+	return fmt.Sprintf("worktree GC %s %d worktree(s) and %d branch(es), usage %s -> %s",
+		verb, len(r.Removed), len(r.PrunedBranches),
+		humanize.IBytes(uint64(r.UsageBefore)), humanize.IBytes(uint64(r.UsageAfter)))
+}
+
+// WorktreeGC tracks disk usage of worktrees for every repo the daemon has run
+// jobs in and removes worktrees of completed or abandoned runs.
+type WorktreeGC struct {
+	db       *db.DB
+	policy   GCPolicy
+	isActive func(runID string) bool
+	now      func() time.Time
+
+	// runMu serializes collection passes (background ticker vs. RPC).
+	runMu sync.Mutex
+
+	mu         sync.RWMutex
+	lastReport *GCReport
+}
+
+// NewWorktreeGC creates a garbage collector. isActive reports whether a run
+// is currently executing in this daemon; runs marked running in the database
+// but not active are treated as abandoned.
+func NewWorktreeGC(database *db.DB, policy GCPolicy, isActive func(runID string) bool) *WorktreeGC {
+	return &WorktreeGC{
+		db:       database,
+		policy:   policy,
+		isActive: isActive,
+		now:      time.Now,
+	}
+}
+
+// LastReport returns the report from the most recent collection pass, or nil.
+func (gc *WorktreeGC) LastReport() *GCReport {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	return gc.lastReport
+}
+
+// Run performs a collection pass every interval until ctx is cancelled.
+func (gc *WorktreeGC) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := gc.Collect(ctx, gc.policy.DryRun)
+			if err != nil {
+				log.Printf("Worktree GC failed: %v", err)
+				continue
+			}
+			if len(report.Removed) > 0 || len(report.PrunedBranches) > 0 || len(report.Errors) > 0 {
+				log.Println(report.Summary())
+			}
+			for _, msg := range report.Errors {
+				log.Printf("Worktree GC: %s", msg)
+			}
+		}
+	}
+}
+
+// repoState aggregates the runs recorded for a single repository.
+type repoState struct {
+	path         string
+	active       bool
+	lastFinished time.Time
+	runs         []*db.Run
+}
+
+// Collect performs a single garbage collection pass. When dryRun is true the
+// report lists what would be removed but nothing is deleted.
+func (gc *WorktreeGC) Collect(ctx context.Context, dryRun bool) (*GCReport, error) {
+	gc.runMu.Lock()
+	defer gc.runMu.Unlock()
+
+	now := gc.now()
+	report := &GCReport{StartedAt: now, DryRun: dryRun}
+
+	repos, err := gc.loadRepos()
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Measure every worktree and split into protected and removable
+	var candidates []GCWorktree
+	for _, repo := range repos {
+		worktrees, err := scanWorktrees(ctx, repo.path, worktreeBaseFor(repo.path))
+		if err != nil {
+			report.Errors = append(report.Errors, err.Error())
+			continue
+		}
+		for _, wt := range worktrees {
+			report.UsageBefore += wt.Bytes
+			if repo.active {
+				continue
+			}
+			if repo.lastFinished.After(wt.LastUsed) {
+				wt.LastUsed = repo.lastFinished
+			}
+			candidates = append(candidates, wt)
+		}
+	}
+
+	// 2. Select by retention first, then by quota (oldest first)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].LastUsed.Before(candidates[j].LastUsed)
+	})
+
+	usage := report.UsageBefore
+	var selected []GCWorktree
+	var remaining []GCWorktree
+	for _, wt := range candidates {
+		if now.Sub(wt.LastUsed) >= gc.policy.Retention {
+			wt.Reason = GCReasonRetention
+			selected = append(selected, wt)
+			usage -= wt.Bytes
+		} else {
+			remaining = append(remaining, wt)
+		}
+	}
+	if gc.policy.Quota > 0 {
+		for _, wt := range remaining {
+			if usage <= gc.policy.Quota {
+				break
+			}
+			wt.Reason = GCReasonQuota
+			selected = append(selected, wt)
+			usage -= wt.Bytes
+		}
+	}
+
+	// 3. Remove selected worktrees, re-checking that no job started meanwhile
+	active, err := gc.activeRepos()
+	if err != nil {
+		return nil, err
+	}
+	report.UsageAfter = report.UsageBefore
+	for _, wt := range selected {
+		if active[wt.RepoPath] {
+			continue
+		}
+		if !dryRun {
+			if err := removeWorktree(ctx, wt); err != nil {
+				report.Errors = append(report.Errors, err.Error())
+				continue
+			}
+		}
+		report.Removed = append(report.Removed, wt)
+		report.UsageAfter -= wt.Bytes
+	}
+
+	// 4. Prune unit branches in repos that are past retention
+	if gc.policy.PruneBranches {
+		for _, repo := range repos {
+			if repo.active || active[repo.path] || now.Sub(repo.lastFinished) < gc.policy.Retention {
+				continue
+			}
+			pruned, err := gc.pruneUnitBranches(ctx, repo, dryRun)
+			if err != nil {
+				report.Errors = append(report.Errors, err.Error())
+			}
+			report.PrunedBranches = append(report.PrunedBranches, pruned...)
+		}
+	}
+
+	gc.mu.Lock()
+	gc.lastReport = report
+	gc.mu.Unlock()
+
+	return report, nil
+}
+
+// loadRepos groups all recorded runs by repository, skipping repos that no
+// longer exist on disk.
+func (gc *WorktreeGC) loadRepos() ([]*repoState, error) {
+	runs, err := gc.db.ListRuns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+
+	byPath := make(map[string]*repoState)
+	var repos []*repoState
+	for _, run := range runs {
+		state, ok := byPath[run.RepoPath]
+		if !ok {
+			if _, err := os.Stat(run.RepoPath); err != nil {
+				continue
+			}
+			state = &repoState{path: run.RepoPath}
+			byPath[run.RepoPath] = state
+			repos = append(repos, state)
+		}
+		state.runs = append(state.runs, run)
+
+		if gc.runIsActive(run) {
+			state.active = true
+		}
+		for _, t := range []*time.Time{run.StartedAt, run.CompletedAt} {
+			if t != nil && t.After(state.lastFinished) {
+				state.lastFinished = *t
+			}
+		}
+	}
+
+	return repos, nil
+}
+
+// activeRepos returns the set of repos with a pending or live run.
+func (gc *WorktreeGC) activeRepos() (map[string]bool, error) {
+	runs, err := gc.db.ListIncompleteRuns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incomplete runs: %w", err)
+	}
+
+	active := make(map[string]bool)
+	for _, run := range runs {
+		if gc.runIsActive(run) {
+			active[run.RepoPath] = true
+		}
+	}
+	return active, nil
+}
+
+// runIsActive reports whether a run still needs its worktrees. Pending runs
+// are about to start; running runs count only if this daemon is executing them.
+func (gc *WorktreeGC) runIsActive(run *db.Run) bool {
+	switch run.Status {
+	case db.RunStatusPending:
+		return true
+	case db.RunStatusRunning:
+		return gc.isActive == nil || gc.isActive(run.ID)
+	default:
+		return false
+	}
+}
+
+// worktreeBaseFor resolves the worktree base directory for a repository,
+// falling back to the default when the repo config cannot be loaded.
+func worktreeBaseFor(repoPath string) string {
+	if cfg, err := config.LoadConfig(repoPath); err == nil {
+		return cfg.Worktree.BasePath
+	}
+	return filepath.Join(repoPath, config.DefaultWorktreeBasePath)
+}
+
+// scanWorktrees measures every directory under the worktree base, whether or
+// not git still knows about it.
+func scanWorktrees(ctx context.Context, repoPath, base string) ([]GCWorktree, error) {
+	entries, err := os.ReadDir(base)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read worktree directory %s: %w", base, err)
+	}
+
+	manager := git.NewWorktreeManager(repoPath, nil)
+	manager.WorktreeBase = base
+	branches := make(map[string]string)
+	if registered, err := manager.ListWorktrees(ctx); err == nil {
+		for _, wt := range registered {
+			branches[wt.UnitID] = wt.Branch
+		}
+	}
+
+	var worktrees []GCWorktree
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == worktreeLogsDir || entry.Name() == worktreeStacksDir {
+			continue
+		}
+		path := filepath.Join(base, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		size, err := dirSize(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure %s: %w", path, err)
+		}
+		worktrees = append(worktrees, GCWorktree{
+			RepoPath: repoPath,
+			Path:     path,
+			Branch:   branches[entry.Name()],
+			Bytes:    size,
+			LastUsed: info.ModTime(),
+		})
+	}
+
+	return worktrees, nil
+}
+
+// removeWorktree deletes a worktree via git when registered, then removes
+// any remaining directory and prunes stale worktree metadata.
+func removeWorktree(ctx context.Context, wt GCWorktree) error {
+	manager := git.NewWorktreeManager(wt.RepoPath, nil)
+	manager.WorktreeBase = filepath.Dir(wt.Path)
+
+	if wt.Branch != "" {
+		if err := manager.RemoveWorktree(ctx, &git.Worktree{Path: wt.Path, Branch: wt.Branch}); err == nil {
+			return nil
+		}
+	}
+
+	if err := os.RemoveAll(wt.Path); err != nil {
+		return fmt.Errorf("failed to remove worktree %s: %w", wt.Path, err)
+	}
+	return manager.CleanupOrphans(ctx)
+}
+
+// pruneUnitBranches deletes ralph/* branches not checked out in any
+// worktree whose work is kept elsewhere: branches merged into a run's
+// target or feature branch, and branches of units of completed runs.
+// Other branches are only deleted if git considers them merged; the rest
+// are kept and logged, so a failed run's work is not lost.
+func (gc *WorktreeGC) pruneUnitBranches(ctx context.Context, repo *repoState, dryRun bool) ([]GCBranch, error) {
+	repoPath := repo.path
+	branches, err := git.ListLocalBranches(ctx, repoPath, unitBranchPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches in %s: %w", repoPath, err)
+	}
+
+	// ListWorktrees only reports worktrees under the base, so use the
+	// filesystem root as base to see every checked-out branch, including
+	// the main worktree.
+	manager := git.NewWorktreeManager(repoPath, nil)
+	manager.WorktreeBase = string(filepath.Separator)
+	checkedOut := make(map[string]bool)
+	if worktrees, err := manager.ListWorktrees(ctx); err == nil {
+		for _, wt := range worktrees {
+			checkedOut[wt.Branch] = true
+		}
+	}
+
+	completed := make(map[string]bool)
+	var bases []string
+	seenBase := make(map[string]bool)
+	for _, run := range repo.runs {
+		for _, base := range []string{run.TargetBranch, run.FeatureBranch} {
+			if base != "" && !seenBase[base] {
+				seenBase[base] = true
+				bases = append(bases, base)
+			}
+		}
+		if run.Status != db.RunStatusCompleted {
+			continue
+		}
+		units, err := gc.db.ListUnitsByRun(run.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list units of run %s: %w", run.ID, err)
+		}
+		for _, unit := range units {
+			if unit.Branch != nil {
+				completed[*unit.Branch] = true
+			}
+		}
+	}
+
+	client := git.NewClient(repoPath)
+	var pruned []GCBranch
+	for _, branch := range branches {
+		if checkedOut[branch] {
+			continue
+		}
+		if completed[branch] || mergedInto(ctx, repoPath, branch, bases) {
+			if !dryRun {
+				if err := client.DeleteBranch(ctx, branch); err != nil {
+					return pruned, fmt.Errorf("failed to delete branch %s in %s: %w", branch, repoPath, err)
+				}
+			}
+		} else if dryRun {
+			if !mergedInto(ctx, repoPath, branch, []string{"HEAD"}) {
+				log.Printf("Worktree GC: would keep unmerged branch %s in %s", branch, repoPath)
+				continue
+			}
+		} else if _, err := gitOutput(ctx, repoPath, "branch", "-d", branch); err != nil {
+			log.Printf("Worktree GC: keeping unmerged branch %s in %s", branch, repoPath)
+			continue
+		}
+		pruned = append(pruned, GCBranch{RepoPath: repoPath, Name: branch})
+	}
+
+	return pruned, nil
+}
+
+// mergedInto reports whether branch is merged into any of the existing
+// refs in bases
+func mergedInto(ctx context.Context, repoPath, branch string, bases []string) bool {
+	for _, base := range bases {
+		if _, err := gitOutput(ctx, repoPath, "merge-base", "--is-ancestor", branch, base); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// dirSize returns the total size of regular files under path.
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,232 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/testutil"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupGCRepo creates a git repo with a worktree for each unit under the
+// default worktree base.
+func setupGCRepo(t *testing.T, units ...string) string {
+	t.Helper()
+	testutil.UnsetGitEnv()
+
+	repo := t.TempDir()
+	testutil.Git(t, repo, "init", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("# test\n"), 0644))
+	testutil.Git(t, repo, "add", "README.md")
+	testutil.Git(t, repo, "commit", "-m", "initial")
+
+	for _, unit := range units {
+		path := filepath.Join(repo, ".ralph", "worktrees", unit)
+		testutil.Git(t, repo, "worktree", "add", "-b", "ralph/"+unit+"-abc123", path, "main")
+		require.NoError(t, os.WriteFile(filepath.Join(path, "payload.bin"), make([]byte, 4096), 0644))
+	}
+
+	return repo
+}
+
+func createGCRun(t *testing.T, database *db.DB, repo string, status db.RunStatus, finished time.Time) *db.Run {
+	t.Helper()
+	run := &db.Run{
+		ID:            ulid.Make().String(),
+		FeatureBranch: "feature/" + ulid.Make().String(),
+		RepoPath:      repo,
+		TargetBranch:  "main",
+		TasksDir:      "specs/tasks",
+		Parallelism:   1,
+		Status:        status,
+		StartedAt:     &finished,
+	}
+	if status != db.RunStatusRunning && status != db.RunStatusPending {
+		run.CompletedAt = &finished
+	}
+	require.NoError(t, database.CreateRun(run))
+	return run
+}
+
+func newTestGC(database *db.DB, policy GCPolicy, active map[string]bool, now time.Time) *WorktreeGC {
+	gc := NewWorktreeGC(database, policy, func(runID string) bool { return active[runID] })
+	gc.now = func() time.Time { return now }
+	return gc
+}
+
+func TestWorktreeGC_RemovesExpiredWorktrees(t *testing.T) {
+	database := setupTestDB(t)
+	repo := setupGCRepo(t, "unit-a")
+	createGCRun(t, database, repo, db.RunStatusCompleted, time.Now())
+
+	gc := newTestGC(database, GCPolicy{Retention: time.Hour, PruneBranches: true}, nil, time.Now().Add(2*time.Hour))
+	report, err := gc.Collect(context.Background(), false)
+	require.NoError(t, err)
+
+	require.Len(t, report.Removed, 1)
+	assert.Equal(t, GCReasonRetention, report.Removed[0].Reason)
+	assert.Equal(t, "ralph/unit-a-abc123", report.Removed[0].Branch)
+	assert.Greater(t, report.UsageBefore, report.UsageAfter)
+	assert.NoDirExists(t, filepath.Join(repo, ".ralph", "worktrees", "unit-a"))
+
+	require.Len(t, report.PrunedBranches, 1)
+	assert.Equal(t, "ralph/unit-a-abc123", report.PrunedBranches[0].Name)
+	assert.NotContains(t, testutil.Git(t, repo, "branch"), "ralph/unit-a-abc123")
+	assert.Same(t, report, gc.LastReport())
+}
+
+func TestWorktreeGC_KeepsWorktreesWithinRetention(t *testing.T) {
+	database := setupTestDB(t)
+	repo := setupGCRepo(t, "unit-a")
+	createGCRun(t, database, repo, db.RunStatusCompleted, time.Now())
+
+	gc := newTestGC(database, GCPolicy{Retention: 24 * time.Hour, PruneBranches: true}, nil, time.Now().Add(time.Hour))
+	report, err := gc.Collect(context.Background(), false)
+	require.NoError(t, err)
+
+	assert.Empty(t, report.Removed)
+	assert.Empty(t, report.PrunedBranches)
+	assert.DirExists(t, filepath.Join(repo, ".ralph", "worktrees", "unit-a"))
+}
+
+func TestWorktreeGC_ProtectsActiveRuns(t *testing.T) {
+	database := setupTestDB(t)
+	repo := setupGCRepo(t, "unit-a")
+	run := createGCRun(t, database, repo, db.RunStatusRunning, time.Now())
+
+	gc := newTestGC(database, GCPolicy{Retention: time.Hour, Quota: 1, PruneBranches: true},
+		map[string]bool{run.ID: true}, time.Now().Add(48*time.Hour))
+	report, err := gc.Collect(context.Background(), false)
+	require.NoError(t, err)
+
+	assert.Empty(t, report.Removed)
+	assert.Empty(t, report.PrunedBranches)
+	assert.Greater(t, report.UsageBefore, int64(0))
+	assert.DirExists(t, filepath.Join(repo, ".ralph", "worktrees", "unit-a"))
+}
+
+func TestWorktreeGC_RemovesAbandonedRuns(t *testing.T) {
+	database := setupTestDB(t)
+	repo := setupGCRepo(t, "unit-a")
+	// Marked running in the DB but not executing in this daemon
+	createGCRun(t, database, repo, db.RunStatusRunning, time.Now())
+
+	gc := newTestGC(database, GCPolicy{Retention: time.Hour}, nil, time.Now().Add(2*time.Hour))
+	report, err := gc.Collect(context.Background(), false)
+	require.NoError(t, err)
+
+	require.Len(t, report.Removed, 1)
+	assert.NoDirExists(t, filepath.Join(repo, ".ralph", "worktrees", "unit-a"))
+}
+
+func TestWorktreeGC_EnforcesQuotaOldestFirst(t *testing.T) {
+	database := setupTestDB(t)
+	oldRepo := setupGCRepo(t, "unit-old")
+	newRepo := setupGCRepo(t, "unit-new")
+	now := time.Now()
+	createGCRun(t, database, oldRepo, db.RunStatusFailed, now.Add(2*time.Hour))
+	createGCRun(t, database, newRepo, db.RunStatusCompleted, now.Add(3*time.Hour))
+
+	probe := newTestGC(database, GCPolicy{Retention: 24 * time.Hour}, nil, now.Add(4*time.Hour))
+	dry, err := probe.Collect(context.Background(), true)
+	require.NoError(t, err)
+
+	// Quota allows only one of the two worktrees
+	gc := newTestGC(database, GCPolicy{Retention: 24 * time.Hour, Quota: dry.UsageBefore - 1}, nil, now.Add(4*time.Hour))
+	report, err := gc.Collect(context.Background(), false)
+	require.NoError(t, err)
+
+	require.Len(t, report.Removed, 1)
+	assert.Equal(t, GCReasonQuota, report.Removed[0].Reason)
+	assert.Equal(t, oldRepo, report.Removed[0].RepoPath)
+	assert.LessOrEqual(t, report.UsageAfter, gc.policy.Quota)
+	assert.DirExists(t, filepath.Join(newRepo, ".ralph", "worktrees", "unit-new"))
+}
+
+func TestWorktreeGC_DryRunDeletesNothing(t *testing.T) {
+	database := setupTestDB(t)
+	repo := setupGCRepo(t, "unit-a", "unit-b")
+	createGCRun(t, database, repo, db.RunStatusCancelled, time.Now())
+	testutil.Git(t, repo, "branch", "ralph/orphan-def456", "main")
+
+	gc := newTestGC(database, GCPolicy{Retention: time.Hour, PruneBranches: true}, nil, time.Now().Add(2*time.Hour))
+	report, err := gc.Collect(context.Background(), true)
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Len(t, report.Removed, 2)
+	assert.Equal(t, int64(0), report.UsageAfter)
+	require.Len(t, report.PrunedBranches, 1)
+	assert.Equal(t, "ralph/orphan-def456", report.PrunedBranches[0].Name)
+	assert.Contains(t, report.Summary(), "would remove 2 worktree(s)")
+
+	assert.DirExists(t, filepath.Join(repo, ".ralph", "worktrees", "unit-a"))
+	assert.DirExists(t, filepath.Join(repo, ".ralph", "worktrees", "unit-b"))
+	assert.Contains(t, testutil.Git(t, repo, "branch"), "ralph/orphan-def456")
+}
+
+func TestWorktreeGC_KeepsUnmergedBranches(t *testing.T) {
+	database := setupTestDB(t)
+	repo := setupGCRepo(t)
+	unmergedBranch := func(branch string) {
+		testutil.Git(t, repo, "checkout", "-b", branch)
+		require.NoError(t, os.WriteFile(filepath.Join(repo, branch[len("ralph/"):]+".txt"), []byte("work\n"), 0644))
+		testutil.Git(t, repo, "add", "-A")
+		testutil.Git(t, repo, "commit", "-m", "work on "+branch)
+		testutil.Git(t, repo, "checkout", "main")
+	}
+	unmergedBranch("ralph/done-111111")
+	unmergedBranch("ralph/failed-222222")
+	testutil.Git(t, repo, "branch", "ralph/merged-333333", "main")
+
+	// The completed run's unit was squash-merged, so its branch is not an
+	// ancestor of main; the failed run's work exists only on its branch
+	recordUnit := func(run *db.Run, unit, branch string) {
+		id := db.MakeUnitRecordID(run.ID, unit)
+		require.NoError(t, database.CreateUnit(&db.UnitRecord{ID: id, RunID: run.ID, UnitID: unit, Status: string(db.UnitStatusCompleted)}))
+		require.NoError(t, database.UpdateUnitBranch(id, branch, ""))
+	}
+	recordUnit(createGCRun(t, database, repo, db.RunStatusCompleted, time.Now()), "done", "ralph/done-111111")
+	recordUnit(createGCRun(t, database, repo, db.RunStatusFailed, time.Now()), "failed", "ralph/failed-222222")
+
+	gc := newTestGC(database, GCPolicy{Retention: time.Hour, PruneBranches: true}, nil, time.Now().Add(2*time.Hour))
+	report, err := gc.Collect(context.Background(), false)
+	require.NoError(t, err)
+
+	var names []string
+	for _, b := range report.PrunedBranches {
+		names = append(names, b.Name)
+	}
+	assert.ElementsMatch(t, []string{"ralph/done-111111", "ralph/merged-333333"}, names)
+	branches := testutil.Git(t, repo, "branch")
+	assert.Contains(t, branches, "ralph/failed-222222")
+	assert.NotContains(t, branches, "ralph/done-111111")
+	assert.NotContains(t, branches, "ralph/merged-333333")
+}
+
+func TestWorktreeGC_RemovesUnregisteredDirectories(t *testing.T) {
+	database := setupTestDB(t)
+	repo := setupGCRepo(t)
+	createGCRun(t, database, repo, db.RunStatusCompleted, time.Now())
+
+	stale := filepath.Join(repo, ".ralph", "worktrees", "stale")
+	require.NoError(t, os.MkdirAll(stale, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(stale, "file.txt"), []byte("leftover"), 0644))
+	logs := filepath.Join(repo, ".ralph", "worktrees", "logs")
+	require.NoError(t, os.MkdirAll(logs, 0755))
+
+	gc := newTestGC(database, GCPolicy{Retention: time.Hour}, nil, time.Now().Add(2*time.Hour))
+	report, err := gc.Collect(context.Background(), false)
+	require.NoError(t, err)
+
+	require.Len(t, report.Removed, 1)
+	assert.Equal(t, stale, report.Removed[0].Path)
+	assert.NoDirExists(t, stale)
+	assert.DirExists(t, logs)
+}
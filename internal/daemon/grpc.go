@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -25,6 +26,11 @@ type GRPCServer struct {
 	shutdownCh     chan struct{}
 	activeJobs     map[string]context.CancelFunc
 	onShutdown     func() // Callback to signal daemon shutdown
+
+	gc         *WorktreeGC       // Optional worktree garbage collector for RunGC
+	workspaces *WorkspaceManager // Optional workspace registry for workspace RPCs
+	queue      *JobQueue         // Optional job queue for queue and schedule RPCs
+	archiver   *JobArchiver      // Optional archiver for export and import RPCs
 }
 
 // JobManager defines the interface for job lifecycle management
@@ -50,6 +56,10 @@ type JobManager interface {
 
 	// ActiveJobCount returns the number of currently running jobs
 	ActiveJobCount() int
+
+	// DetachAll detaches from the jobs that run in job runners, leaving
+	// them running through a daemon restart. Returns their IDs.
+	DetachAll() []string
 }
 
 // JobConfig contains configuration for starting a new job
@@ -60,6 +70,8 @@ type JobConfig struct {
 	FeatureBranch string // Optional: for feature mode
 	DryRun        bool   // If true, don't create PRs or merge
 	Concurrency   int    // Max parallel units (0 = default)
+	Workspace     string // Optional: registered workspace whose clone is RepoPath
+	Unit          string // Optional: run only this unit
 }
 
 // JobState represents the full state of a job
@@ -151,11 +163,24 @@ func (s *GRPCServer) UntrackJob(jobID string) {
 // returns that job's ID with status "attached" instead of creating a new one.
 // This enables CLI attach: `choo run` can join an in-progress workflow.
 func (s *GRPCServer) StartJob(ctx context.Context, req *apiv1.StartJobRequest) (*apiv1.StartJobResponse, error) {
+	return s.startJob(ctx, req, "")
+}
+
+// startJob starts a job as StartJob does. A non-empty unit limits the job
+// to that unit, for retrying a unit that failed.
+func (s *GRPCServer) startJob(ctx context.Context, req *apiv1.StartJobRequest, unit string) (*apiv1.StartJobResponse, error) {
 	// Check if server is shutting down
 	if s.isShuttingDown() {
 		return nil, status.Errorf(codes.Unavailable, "daemon is shutting down")
 	}
 
+	// Fill in a workspace job from the workspace's defaults
+	if req.Workspace != "" {
+		if err := s.resolveWorkspace(req); err != nil {
+			return nil, err
+		}
+	}
+
 	// Validate required fields
 	if req.TasksDir == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "tasks_dir is required")
@@ -211,9 +236,14 @@ func (s *GRPCServer) StartJob(ctx context.Context, req *apiv1.StartJobRequest) (
 		FeatureBranch: req.FeatureBranch,
 		DryRun:        false, // TODO: add to proto
 		Concurrency:   int(req.Parallelism),
+		Workspace:     req.Workspace,
+		Unit:          unit,
 	})
 	if err != nil {
 		cancel() // Clean up context
+		if errors.Is(err, ErrAtCapacity) {
+			return nil, status.Errorf(codes.ResourceExhausted, "failed to start job: %v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to start job: %v", err)
 	}
 
@@ -365,11 +395,21 @@ func (s *GRPCServer) Shutdown(ctx context.Context, req *apiv1.ShutdownRequest) (
 	// Mark as shutting down
 	s.shuttingDown = true
 	close(s.shutdownCh)
+	s.mu.Unlock()
+
+	// Jobs in job runners outlive the daemon; neither wait for nor stop them
+	detached := make(map[string]bool)
+	for _, jobID := range s.jobManager.DetachAll() {
+		detached[jobID] = true
+	}
 
 	// Copy active jobs map for iteration
+	s.mu.Lock()
 	activeJobs := make(map[string]context.CancelFunc)
 	for k, v := range s.activeJobs {
-		activeJobs[k] = v
+		if !detached[k] {
+			activeJobs[k] = v
+		}
 	}
 	s.mu.Unlock()
 
@@ -439,3 +479,342 @@ func (s *GRPCServer) Health(ctx context.Context, req *apiv1.HealthRequest) (*api
 		Version:    s.version,
 	}, nil
 }
+
+// SetWorktreeGC configures the garbage collector used by RunGC.
+func (s *GRPCServer) SetWorktreeGC(gc *WorktreeGC) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gc = gc
+}
+
+// RunGC performs a worktree garbage collection pass on demand.
+// With dry_run set, the report lists what would be removed without deleting anything.
+func (s *GRPCServer) RunGC(ctx context.Context, req *apiv1.RunGCRequest) (*apiv1.RunGCResponse, error) {
+	s.mu.RLock()
+	gc := s.gc
+	s.mu.RUnlock()
+
+	if gc == nil {
+		return nil, status.Errorf(codes.Unavailable, "worktree GC is not configured")
+	}
+
+	report, err := gc.Collect(ctx, req.DryRun)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "worktree GC failed: %v", err)
+	}
+
+	return gcReportToProto(report), nil
+}
+
+// SetWorkspaces configures the workspace registry used by the workspace RPCs.
+func (s *GRPCServer) SetWorkspaces(m *WorkspaceManager) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workspaces = m
+}
+
+// getWorkspaces returns the workspace registry, or an error if the daemon
+// runs without one
+func (s *GRPCServer) getWorkspaces() (*WorkspaceManager, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.workspaces == nil {
+		return nil, status.Errorf(codes.Unavailable, "workspaces are not configured")
+	}
+	return s.workspaces, nil
+}
+
+// resolveWorkspace points a workspace job at the workspace's clone and
+// fills in the defaults the request leaves empty
+func (s *GRPCServer) resolveWorkspace(req *apiv1.StartJobRequest) error {
+	workspaces, err := s.getWorkspaces()
+	if err != nil {
+		return err
+	}
+	if req.RepoPath != "" {
+		return status.Errorf(codes.InvalidArgument, "repo_path and workspace are mutually exclusive")
+	}
+	ws, err := workspaces.Get(req.Workspace)
+	if errors.Is(err, ErrWorkspaceNotFound) {
+		return status.Errorf(codes.NotFound, "workspace not found: %s", req.Workspace)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get workspace: %v", err)
+	}
+
+	req.RepoPath = ws.MirrorPath
+	if req.TasksDir == "" {
+		req.TasksDir = ws.TasksDir
+	}
+	if req.TargetBranch == "" {
+		req.TargetBranch = ws.TargetBranch
+	}
+	if req.Parallelism == 0 {
+		req.Parallelism = int32(ws.Parallelism)
+	}
+	return nil
+}
+
+// AddWorkspace registers a workspace and clones its repository.
+func (s *GRPCServer) AddWorkspace(ctx context.Context, req *apiv1.AddWorkspaceRequest) (*apiv1.AddWorkspaceResponse, error) {
+	workspaces, err := s.getWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+	if req.Workspace == nil || req.Workspace.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "workspace name is required")
+	}
+
+	ws := workspaceFromProto(req.Workspace)
+	if err := workspaces.Add(ctx, ws); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to add workspace: %v", err)
+	}
+
+	return &apiv1.AddWorkspaceResponse{Workspace: workspaceToProto(ws)}, nil
+}
+
+// ListWorkspaces returns all registered workspaces.
+func (s *GRPCServer) ListWorkspaces(ctx context.Context, req *apiv1.ListWorkspacesRequest) (*apiv1.ListWorkspacesResponse, error) {
+	workspaces, err := s.getWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := workspaces.List()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list workspaces: %v", err)
+	}
+
+	resp := &apiv1.ListWorkspacesResponse{}
+	for _, ws := range list {
+		resp.Workspaces = append(resp.Workspaces, workspaceToProto(ws))
+	}
+	return resp, nil
+}
+
+// RemoveWorkspace unregisters a workspace, optionally deleting its clone.
+func (s *GRPCServer) RemoveWorkspace(ctx context.Context, req *apiv1.RemoveWorkspaceRequest) (*apiv1.RemoveWorkspaceResponse, error) {
+	workspaces, err := s.getWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	err = workspaces.Remove(req.Name, req.DeleteClone)
+	if errors.Is(err, ErrWorkspaceNotFound) {
+		return nil, status.Errorf(codes.NotFound, "workspace not found: %s", req.Name)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to remove workspace: %v", err)
+	}
+
+	return &apiv1.RemoveWorkspaceResponse{}, nil
+}
+
+// SetJobQueue configures the job queue used by the queue and schedule RPCs.
+func (s *GRPCServer) SetJobQueue(q *JobQueue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queue = q
+}
+
+// getJobQueue returns the job queue, or an error if the daemon runs
+// without one
+func (s *GRPCServer) getJobQueue() (*JobQueue, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.queue == nil {
+		return nil, status.Errorf(codes.Unavailable, "job queue is not configured")
+	}
+	return s.queue, nil
+}
+
+// QueueJob adds a job to the queue. It starts as soon as a slot is free,
+// which may be right away.
+func (s *GRPCServer) QueueJob(ctx context.Context, req *apiv1.QueueJobRequest) (*apiv1.QueueJobResponse, error) {
+	queue, err := s.getJobQueue()
+	if err != nil {
+		return nil, err
+	}
+	if req.Job == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "job is required")
+	}
+
+	entry, err := queue.Enqueue(jobSpecFromProto(req.Job), int(req.Priority))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to queue job: %v", err)
+	}
+
+	return &apiv1.QueueJobResponse{Queued: queuedJobToProto(entry)}, nil
+}
+
+// ListQueue returns the queued and started entries of the job queue.
+func (s *GRPCServer) ListQueue(ctx context.Context, req *apiv1.ListQueueRequest) (*apiv1.ListQueueResponse, error) {
+	queue, err := s.getJobQueue()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := queue.List(req.IncludeFinished)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list queue: %v", err)
+	}
+
+	resp := &apiv1.ListQueueResponse{}
+	for _, entry := range entries {
+		resp.Jobs = append(resp.Jobs, queuedJobToProto(entry))
+	}
+	return resp, nil
+}
+
+// CancelQueuedJob removes a job from the queue before it starts.
+func (s *GRPCServer) CancelQueuedJob(ctx context.Context, req *apiv1.CancelQueuedJobRequest) (*apiv1.CancelQueuedJobResponse, error) {
+	queue, err := s.getJobQueue()
+	if err != nil {
+		return nil, err
+	}
+	if req.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "id is required")
+	}
+
+	if err := queue.Cancel(req.Id); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to cancel queued job: %v", err)
+	}
+
+	return &apiv1.CancelQueuedJobResponse{}, nil
+}
+
+// AddSchedule adds a schedule that queues a job whenever its cron
+// expression comes due.
+func (s *GRPCServer) AddSchedule(ctx context.Context, req *apiv1.AddScheduleRequest) (*apiv1.AddScheduleResponse, error) {
+	queue, err := s.getJobQueue()
+	if err != nil {
+		return nil, err
+	}
+	if req.Schedule == nil || req.Schedule.Job == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "schedule and its job are required")
+	}
+
+	sched := &db.Schedule{
+		Name:     req.Schedule.Name,
+		Cron:     req.Schedule.Cron,
+		Priority: int(req.Schedule.Priority),
+		JobSpec:  jobSpecFromProto(req.Schedule.Job),
+	}
+	if err := queue.AddSchedule(sched); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to add schedule: %v", err)
+	}
+
+	return &apiv1.AddScheduleResponse{Schedule: scheduleToProto(sched)}, nil
+}
+
+// ListSchedules returns all schedules.
+func (s *GRPCServer) ListSchedules(ctx context.Context, req *apiv1.ListSchedulesRequest) (*apiv1.ListSchedulesResponse, error) {
+	queue, err := s.getJobQueue()
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := queue.ListSchedules()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list schedules: %v", err)
+	}
+
+	resp := &apiv1.ListSchedulesResponse{}
+	for _, sched := range schedules {
+		resp.Schedules = append(resp.Schedules, scheduleToProto(sched))
+	}
+	return resp, nil
+}
+
+// RemoveSchedule deletes a schedule.
+func (s *GRPCServer) RemoveSchedule(ctx context.Context, req *apiv1.RemoveScheduleRequest) (*apiv1.RemoveScheduleResponse, error) {
+	queue, err := s.getJobQueue()
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	if err := queue.RemoveSchedule(req.Name); err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to remove schedule: %v", err)
+	}
+
+	return &apiv1.RemoveScheduleResponse{}, nil
+}
+
+// SetJobArchiver configures the archiver used by the export and import RPCs.
+func (s *GRPCServer) SetJobArchiver(a *JobArchiver) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.archiver = a
+}
+
+// getJobArchiver returns the job archiver, or an error if the daemon runs
+// without one
+func (s *GRPCServer) getJobArchiver() (*JobArchiver, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.archiver == nil {
+		return nil, status.Errorf(codes.Unavailable, "job archives are not configured")
+	}
+	return s.archiver, nil
+}
+
+// ExportJob streams the archive of a job: its run, units, event log,
+// provider logs and diffs.
+func (s *GRPCServer) ExportJob(req *apiv1.ExportJobRequest, stream apiv1.DaemonService_ExportJobServer) error {
+	archiver, err := s.getJobArchiver()
+	if err != nil {
+		return err
+	}
+	if req.JobId == "" {
+		return status.Errorf(codes.InvalidArgument, "job_id is required")
+	}
+
+	w := &archiveChunkWriter{send: func(data []byte) error {
+		return stream.Send(&apiv1.JobArchiveChunk{Data: data})
+	}}
+	err = archiver.Export(stream.Context(), req.JobId, w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if errors.Is(err, ErrRunNotFound) {
+		return status.Errorf(codes.NotFound, "job not found: %s", req.JobId)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to export job: %v", err)
+	}
+	return nil
+}
+
+// ImportJob loads a job archive streamed by the client, for post-mortems
+// of jobs run by another daemon.
+func (s *GRPCServer) ImportJob(stream apiv1.DaemonService_ImportJobServer) error {
+	archiver, err := s.getJobArchiver()
+	if err != nil {
+		return err
+	}
+
+	r := &archiveChunkReader{recv: func() ([]byte, error) {
+		chunk, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return chunk.Data, nil
+	}}
+	result, err := archiver.Import(r)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to import job: %v", err)
+	}
+
+	return stream.SendAndClose(&apiv1.ImportJobResponse{
+		JobId:    result.RunID,
+		Units:    int32(result.Units),
+		Events:   int32(result.Events),
+		FilesDir: result.FilesDir,
+	})
+}
@@ -3,6 +3,7 @@ package daemon
 import (
 	"time"
 
+	"github.com/RevCBH/choo/internal/daemon/db"
 	apiv1 "github.com/RevCBH/choo/pkg/api/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -65,3 +66,118 @@ func eventToProto(e Event) *apiv1.JobEvent {
 		Timestamp:   timestamppb.New(e.Timestamp),
 	}
 }
+
+// gcReportToProto converts a GCReport to protobuf RunGCResponse
+func gcReportToProto(r *GCReport) *apiv1.RunGCResponse {
+	resp := &apiv1.RunGCResponse{
+		DryRun:           r.DryRun,
+		UsageBeforeBytes: r.UsageBefore,
+		UsageAfterBytes:  r.UsageAfter,
+		Errors:           r.Errors,
+	}
+	for _, wt := range r.Removed {
+		resp.Removed = append(resp.Removed, &apiv1.GCWorktree{
+			RepoPath: wt.RepoPath,
+			Path:     wt.Path,
+			Branch:   wt.Branch,
+			Bytes:    wt.Bytes,
+			LastUsed: timeToProto(&wt.LastUsed),
+			Reason:   string(wt.Reason),
+		})
+	}
+	for _, b := range r.PrunedBranches {
+		resp.PrunedBranches = append(resp.PrunedBranches, &apiv1.GCBranch{
+			RepoPath: b.RepoPath,
+			Name:     b.Name,
+		})
+	}
+	return resp
+}
+
+// workspaceToProto converts a db Workspace to protobuf Workspace
+func workspaceToProto(ws *db.Workspace) *apiv1.Workspace {
+	return &apiv1.Workspace{
+		Name:         ws.Name,
+		RepoUrl:      ws.RepoURL,
+		MirrorPath:   ws.MirrorPath,
+		TargetBranch: ws.TargetBranch,
+		TasksDir:     ws.TasksDir,
+		Parallelism:  int32(ws.Parallelism),
+		MaxJobs:      int32(ws.MaxJobs),
+		CreatedAt:    timeToProto(&ws.CreatedAt),
+		FetchedAt:    timeToProto(ws.FetchedAt),
+	}
+}
+
+// workspaceFromProto converts the registration fields of a protobuf
+// Workspace; the clone path and timestamps are the daemon's to set
+func workspaceFromProto(p *apiv1.Workspace) *db.Workspace {
+	return &db.Workspace{
+		Name:         p.GetName(),
+		RepoURL:      p.GetRepoUrl(),
+		TargetBranch: p.GetTargetBranch(),
+		TasksDir:     p.GetTasksDir(),
+		Parallelism:  int(p.GetParallelism()),
+		MaxJobs:      int(p.GetMaxJobs()),
+	}
+}
+
+// jobSpecToProto converts a stored job spec to the StartJobRequest it is
+// started with
+func jobSpecToProto(spec db.JobSpec) *apiv1.StartJobRequest {
+	return &apiv1.StartJobRequest{
+		RepoPath:      spec.RepoPath,
+		Workspace:     spec.Workspace,
+		TasksDir:      spec.TasksDir,
+		TargetBranch:  spec.TargetBranch,
+		FeatureBranch: spec.FeatureBranch,
+		Parallelism:   int32(spec.Parallelism),
+	}
+}
+
+// jobSpecFromProto converts a StartJobRequest to a job spec for storage
+func jobSpecFromProto(req *apiv1.StartJobRequest) db.JobSpec {
+	return db.JobSpec{
+		RepoPath:      req.GetRepoPath(),
+		Workspace:     req.GetWorkspace(),
+		TasksDir:      req.GetTasksDir(),
+		TargetBranch:  req.GetTargetBranch(),
+		FeatureBranch: req.GetFeatureBranch(),
+		Parallelism:   int(req.GetParallelism()),
+	}
+}
+
+// queuedJobToProto converts a db QueuedJob to protobuf QueuedJob
+func queuedJobToProto(q *db.QueuedJob) *apiv1.QueuedJob {
+	p := &apiv1.QueuedJob{
+		Id:         q.ID,
+		Job:        jobSpecToProto(q.JobSpec),
+		Priority:   int32(q.Priority),
+		Status:     string(q.Status),
+		QueuedAt:   timeToProto(&q.QueuedAt),
+		StartedAt:  timeToProto(q.StartedAt),
+		FinishedAt: timeToProto(q.FinishedAt),
+	}
+	if q.JobID != nil {
+		p.JobId = *q.JobID
+	}
+	if q.Schedule != nil {
+		p.Schedule = *q.Schedule
+	}
+	if q.Error != nil {
+		p.Error = *q.Error
+	}
+	return p
+}
+
+// scheduleToProto converts a db Schedule to protobuf Schedule
+func scheduleToProto(s *db.Schedule) *apiv1.Schedule {
+	return &apiv1.Schedule{
+		Name:      s.Name,
+		Cron:      s.Cron,
+		Job:       jobSpecToProto(s.JobSpec),
+		Priority:  int32(s.Priority),
+		NextRunAt: timeToProto(&s.NextRunAt),
+		LastRunAt: timeToProto(s.LastRunAt),
+	}
+}
@@ -23,6 +23,7 @@ type mockJobManager struct {
 	stoppedJobs   map[string]bool
 	forceStopped  map[string]bool
 	subscribeFunc func(jobID string, fromSeq int) (<-chan Event, func())
+	started       []JobConfig
 }
 
 func newMockJobManager() *mockJobManager {
@@ -39,6 +40,7 @@ func (m *mockJobManager) Start(ctx context.Context, cancel context.CancelFunc, c
 	}
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.started = append(m.started, cfg)
 	jobID := "job-" + time.Now().Format("20060102150405")
 	m.jobs[jobID] = &JobState{
 		ID:        jobID,
@@ -108,6 +110,10 @@ func (m *mockJobManager) Subscribe(jobID string, fromSeq int) (<-chan Event, fun
 	return ch, func() { close(ch) }
 }
 
+func (m *mockJobManager) DetachAll() []string {
+	return nil
+}
+
 func (m *mockJobManager) ActiveJobCount() int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
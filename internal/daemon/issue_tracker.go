@@ -0,0 +1,51 @@
+package daemon
+
+import (
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/feature"
+	"github.com/RevCBH/choo/internal/intake"
+)
+
+// newIssueSource creates the issue tracker source of an imported PRD.
+// Replaced in tests.
+var newIssueSource = intake.SourceFromConfig
+
+// jobIssueTracker returns a tracker reporting a job's progress on the issue
+// its feature's PRD was imported from, or nil if the feature was not
+// imported from an issue tracker.
+func jobIssueTracker(cfg JobConfig) *intake.Tracker {
+	if cfg.FeatureBranch == "" {
+		return nil
+	}
+	repoCfg, err := config.LoadConfig(cfg.RepoPath)
+	if err != nil {
+		return nil
+	}
+	prdID, ok := strings.CutPrefix(cfg.FeatureBranch, repoCfg.Feature.BranchPrefix)
+	if !ok {
+		return nil
+	}
+	prdDir := repoCfg.Feature.PRDDir
+	if !filepath.IsAbs(prdDir) {
+		prdDir = filepath.Join(cfg.RepoPath, prdDir)
+	}
+	return issueTracker(repoCfg, intake.ImportedPRD(prdDir, prdID))
+}
+
+// issueTracker returns a tracker for prd's issue, or nil if prd is nil or
+// its tracker cannot be reached.
+func issueTracker(cfg *config.Config, prd *feature.PRD) *intake.Tracker {
+	if prd == nil {
+		return nil
+	}
+	src, err := newIssueSource(cfg, prd.Source.Type)
+	if err != nil {
+		log.Printf("Not updating %s issue %s: %v", prd.Source.Type, prd.Source.Key, err)
+		return nil
+	}
+	return intake.NewTracker(src, prd)
+}
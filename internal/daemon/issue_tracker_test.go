@@ -0,0 +1,111 @@
+package daemon
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/intake"
+	"github.com/RevCBH/choo/internal/orchestrator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIssueSource records the comments posted and issues closed
+type fakeIssueSource struct {
+	mu       sync.Mutex
+	comments map[string][]string
+	closed   []string
+}
+
+func (s *fakeIssueSource) Type() string { return intake.SourceGitHub }
+
+func (s *fakeIssueSource) List(ctx context.Context) ([]intake.Issue, error) { return nil, nil }
+
+func (s *fakeIssueSource) Comment(ctx context.Context, key, body string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.comments[key] = append(s.comments[key], body)
+	return nil
+}
+
+func (s *fakeIssueSource) Close(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = append(s.closed, key)
+	return nil
+}
+
+func (s *fakeIssueSource) Comments(key string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.comments[key]...)
+}
+
+func (s *fakeIssueSource) Closed() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.closed...)
+}
+
+// useIssueSource makes imported PRDs report to a fake tracker for the rest
+// of the test
+func useIssueSource(t *testing.T) *fakeIssueSource {
+	t.Helper()
+	src := &fakeIssueSource{comments: make(map[string][]string)}
+	prev := newIssueSource
+	newIssueSource = func(*config.Config, string) (intake.Source, error) { return src, nil }
+	t.Cleanup(func() { newIssueSource = prev })
+	return src
+}
+
+func TestJob_ReportsProgressOnImportedIssue(t *testing.T) {
+	database := setupTestDB(t)
+	jm := NewJobManager(database, 10)
+	repoPath := setupTestRepo(t)
+	writeFile(t, filepath.Join(repoPath, "docs", "prd", "api.md"), testPRD)
+	issues := useIssueSource(t)
+	useOrchestrator(t, func(cfg orchestrator.Config, deps orchestrator.Dependencies) orchestratorRunner {
+		return &scriptedOrchestrator{bus: deps.Bus, events: []events.Event{
+			events.NewEvent(events.OrchStarted, ""),
+			events.NewEvent(events.UnitStarted, "unit-a"),
+			events.NewEvent(events.PRCreated, "").WithPR(1).
+				WithPayload(map[string]any{"url": "https://github.com/local/app/pull/1"}),
+		}}
+	})
+
+	cfg := validJobConfigWithRepo(repoPath)
+	cfg.FeatureBranch = "feature/api"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	jobID, err := jm.Start(ctx, cancel, cfg)
+	require.NoError(t, err)
+
+	waitForRunStatus(t, database, jobID, db.RunStatusCompleted)
+	require.Eventually(t, func() bool { return len(issues.Comments("42")) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{
+		"choo: implementation started.",
+		"choo: pull request opened: https://github.com/local/app/pull/1",
+	}, issues.Comments("42"))
+	assert.Empty(t, issues.Closed())
+}
+
+func TestJob_SkipsIssueTrackingWithoutImportedPRD(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	useIssueSource(t)
+
+	cfg := validJobConfigWithRepo(repoPath)
+	assert.Nil(t, jobIssueTracker(cfg))
+
+	cfg.FeatureBranch = "feature/api"
+	assert.Nil(t, jobIssueTracker(cfg))
+
+	writeFile(t, filepath.Join(repoPath, "docs", "prd", "api.md"),
+		"---\nprd_id: api\ntitle: API\nstatus: approved\n---\n\n# API\n")
+	assert.Nil(t, jobIssueTracker(cfg))
+}
@@ -1,11 +1,14 @@
 package daemon
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"sync"
 
+	"github.com/RevCBH/choo/internal/daemon/db"
 	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/web"
 )
 
 // subscriber holds a channel for sending events to a subscriber
@@ -88,6 +91,24 @@ func (jm *jobManagerImpl) SubscribeFrom(jobID string, fromSequence int) (<-chan
 		return nil, nil, fmt.Errorf("failed to query historical events: %w", err)
 	}
 
+	// A finished job has only its history: replay all of it, then close
+	if !exists || job == nil {
+		done := make(chan struct{})
+		go func() {
+			defer close(ch)
+			for _, eventRecord := range historicalEvents {
+				select {
+				case ch <- eventFromRecord(eventRecord):
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		var once sync.Once
+		return ch, func() { once.Do(func() { close(done) }) }, nil
+	}
+
 	// Create subscriber
 	sub := &subscriber{
 		ch:     ch,
@@ -104,13 +125,7 @@ func (jm *jobManagerImpl) SubscribeFrom(jobID string, fromSequence int) (<-chan
 			}
 			sub.mu.Unlock()
 
-			// Convert EventRecord to events.Event
-			// For simplicity, we'll create an event with the stored type
-			evt := events.Event{
-				Type: events.EventType(eventRecord.EventType),
-				// Note: We're setting a simplified version. In production,
-				// you'd want to deserialize the full payload from PayloadJSON
-			}
+			evt := eventFromRecord(eventRecord)
 
 			select {
 			case sub.ch <- evt:
@@ -122,26 +137,24 @@ func (jm *jobManagerImpl) SubscribeFrom(jobID string, fromSequence int) (<-chan
 		}
 	}()
 
-	// 5. Register for live events if job still exists
-	if exists && job != nil {
-		job.Events.Subscribe(func(e events.Event) {
-			sub.mu.Lock()
-			defer sub.mu.Unlock()
+	// 5. Register for live events
+	job.Events.Subscribe(func(e events.Event) {
+		sub.mu.Lock()
+		defer sub.mu.Unlock()
 
-			if sub.closed {
-				return
-			}
+		if sub.closed {
+			return
+		}
 
-			// Non-blocking send
-			select {
-			case sub.ch <- e:
-				// Successfully sent
-			default:
-				// Channel full, log and drop event
-				log.Printf("WARN: subscriber channel full for job %s, dropping event %s", jobID, e.Type)
-			}
-		})
-	}
+		// Non-blocking send
+		select {
+		case sub.ch <- e:
+			// Successfully sent
+		default:
+			// Channel full, log and drop event
+			log.Printf("WARN: subscriber channel full for job %s, dropping event %s", jobID, e.Type)
+		}
+	})
 
 	// 6. Return channel and cleanup function
 	cleanup := func() {
@@ -189,3 +202,114 @@ func (jm *jobManagerImpl) closeJobSubscriptions(jobID string) {
 	// Close the job's event bus, which will stop dispatching to all subscribers
 	job.Events.Close()
 }
+
+// persistEvent appends a job event to the run's event log and keeps the
+// run's unit records current
+func (jm *jobManagerImpl) persistEvent(jobID string, e events.Event) {
+	var unitID *string
+	if e.Unit != "" {
+		unitID = &e.Unit
+	}
+	if err := jm.db.AppendEvent(jobID, string(e.Type), unitID, e); err != nil {
+		log.Printf("Failed to record event %s for job %s: %v", e.Type, jobID, err)
+	}
+
+	switch e.Type {
+	case events.UnitStarted, events.UnitCompleted, events.UnitFailed:
+		if err := jm.recordUnit(jobID, e); err != nil {
+			log.Printf("Failed to record unit %s for job %s: %v", e.Unit, jobID, err)
+		}
+	}
+}
+
+// RecordEvent records an event about a job that is no longer running, whose
+// bus is closed: the event is appended to the run's event log and reaches
+// web clients as if the job had emitted it
+func (jm *jobManagerImpl) RecordEvent(jobID string, e events.Event) {
+	jm.persistEvent(jobID, e)
+
+	webEvent := convertToWebEvent(jobID, e)
+	// A run without a store is seeded from the event log when it is viewed
+	if store, ok := jm.runs.Get(jobID); ok {
+		store.HandleEvent(webEvent)
+	}
+	jm.mu.RLock()
+	hub := jm.webHub
+	jm.mu.RUnlock()
+	if hub != nil {
+		hub.Broadcast(webEvent)
+	}
+}
+
+// seedStore replays a job's recorded events into its web store
+func (jm *jobManagerImpl) seedStore(store *web.Store, jobID string) {
+	records, err := jm.db.ListEvents(jobID)
+	if err != nil {
+		log.Printf("Failed to load events of job %s: %v", jobID, err)
+		return
+	}
+	for _, r := range records {
+		store.HandleEvent(convertToWebEvent(jobID, eventFromRecord(r)))
+	}
+}
+
+// recordUnit updates the unit record for a unit lifecycle event, creating
+// it on the unit's first event
+func (jm *jobManagerImpl) recordUnit(jobID string, e events.Event) error {
+	id := db.MakeUnitRecordID(jobID, e.Unit)
+	unit, err := jm.db.GetUnit(id)
+	if err != nil {
+		return err
+	}
+	if unit == nil {
+		unit = &db.UnitRecord{ID: id, RunID: jobID, UnitID: e.Unit, Status: string(db.UnitStatusPending)}
+		if err := jm.db.CreateUnit(unit); err != nil {
+			return err
+		}
+	}
+
+	switch e.Type {
+	case events.UnitStarted:
+		if payload, ok := e.Payload.(map[string]any); ok {
+			branch, _ := payload["branch"].(string)
+			worktree, _ := payload["worktree"].(string)
+			if branch != "" {
+				if err := jm.db.UpdateUnitBranch(id, branch, worktree); err != nil {
+					return err
+				}
+			}
+		}
+		return jm.db.UpdateUnitStatus(id, db.UnitStatusRunning, nil)
+	case events.UnitCompleted:
+		return jm.db.UpdateUnitStatus(id, db.UnitStatusCompleted, nil)
+	default:
+		var errMsg *string
+		if e.Error != "" {
+			errMsg = &e.Error
+		}
+		return jm.db.UpdateUnitStatus(id, db.UnitStatusFailed, errMsg)
+	}
+}
+
+// eventFromRecord restores an event from the event log. Events are stored
+// whole in the payload; records without one keep only their type and unit.
+func eventFromRecord(r *db.EventRecord) events.Event {
+	var evt events.Event
+	if r.PayloadJSON != nil {
+		if err := json.Unmarshal([]byte(*r.PayloadJSON), &evt); err == nil && evt.Type == events.EventType(r.EventType) {
+			return evt
+		}
+	}
+
+	evt = events.Event{Time: r.CreatedAt, Type: events.EventType(r.EventType)}
+	if r.UnitID != nil {
+		evt.Unit = *r.UnitID
+	}
+	if r.PayloadJSON != nil {
+		var payload any
+		if err := json.Unmarshal([]byte(*r.PayloadJSON), &payload); err == nil {
+			evt.Payload = payload
+		}
+	}
+	return evt
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/RevCBH/choo/internal/daemon/db"
 	"github.com/RevCBH/choo/internal/events"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -167,6 +168,36 @@ func TestSubscribeFrom_ReplaysHistory(t *testing.T) {
 	assert.Equal(t, events.EventType("event3"), receivedEvents[1].Type)
 }
 
+func TestSubscribeFrom_FinishedJobReplaysAllAndCloses(t *testing.T) {
+	database := setupTestDB(t)
+	jm := NewJobManager(database, 10)
+	run := createGCRun(t, database, "/repo", db.RunStatusCompleted, time.Now())
+
+	// More events than the channel buffers; none may be dropped
+	for i := 0; i < 150; i++ {
+		require.NoError(t, database.AppendEvent(run.ID, "task.completed", nil, nil))
+	}
+
+	ch, cleanup, err := jm.SubscribeFrom(run.ID, 1)
+	require.NoError(t, err)
+	defer cleanup()
+
+	received := 0
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				assert.Equal(t, 150, received)
+				return
+			}
+			received++
+		case <-timeout:
+			t.Fatalf("timeout after %d events", received)
+		}
+	}
+}
+
 func TestSubscribeFrom_ThenLive(t *testing.T) {
 	database := setupTestDB(t)
 	jm := NewJobManager(database, 10)
@@ -358,3 +389,29 @@ func TestCloseSubscriptions(t *testing.T) {
 	// This test verifies that closeJobSubscriptions doesn't panic
 	// and that the event bus is properly closed
 }
+
+func TestSeedStore_ReplaysRecordedEvents(t *testing.T) {
+	database := setupTestDB(t)
+	jm := NewJobManager(database, 10)
+	run := createGCRun(t, database, "/repo", db.RunStatusRunning, time.Now())
+
+	graph := map[string]any{"nodes": []map[string]any{{"id": "app", "level": 0, "tasks": 2}}}
+	require.NoError(t, database.AppendEvent(run.ID, string(events.OrchStarted), nil,
+		events.Event{Type: events.OrchStarted, Payload: map[string]any{"unit_count": 1, "parallelism": 2, "graph": graph}}))
+	unit := "app"
+	require.NoError(t, database.AppendEvent(run.ID, string(events.UnitCompleted), &unit,
+		events.Event{Type: events.UnitCompleted, Unit: unit}))
+
+	store := jm.Runs().Store(run.ID)
+	jm.seedStore(store, run.ID)
+
+	snapshot := store.Snapshot()
+	assert.Equal(t, "running", snapshot.Status)
+	assert.Equal(t, 2, snapshot.Parallelism)
+	require.Len(t, snapshot.Units, 1)
+	assert.Equal(t, "complete", snapshot.Units[0].Status)
+
+	evts, _ := store.Events()
+	require.Len(t, evts, 2)
+	assert.Equal(t, run.ID, evts[0].Job)
+}
@@ -3,6 +3,7 @@ package daemon
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"path/filepath"
@@ -14,9 +15,10 @@ import (
 	"github.com/RevCBH/choo/internal/daemon/db"
 	"github.com/RevCBH/choo/internal/escalate"
 	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/forge"
 	"github.com/RevCBH/choo/internal/git"
-	"github.com/RevCBH/choo/internal/github"
 	"github.com/RevCBH/choo/internal/orchestrator"
+	"github.com/RevCBH/choo/internal/telemetry"
 	"github.com/RevCBH/choo/internal/web"
 	"github.com/oklog/ulid/v2"
 )
@@ -27,6 +29,17 @@ type jobManagerImpl struct {
 	db      *db.DB
 	maxJobs int
 
+	// maxRepoJobs limits the jobs running in one repository; 0 leaves
+	// only maxJobs. A workspace's own limit takes precedence.
+	maxRepoJobs int
+
+	// workspaces prepares the clones of workspace jobs; nil disables them
+	workspaces *WorkspaceManager
+
+	// jobsDir holds the directories of jobs run by job runners, which
+	// survive daemon restarts; empty runs jobs in the daemon process
+	jobsDir string
+
 	mu   sync.RWMutex
 	jobs map[string]*ManagedJob
 
@@ -36,19 +49,27 @@ type jobManagerImpl struct {
 
 	eventBus *events.Bus // Global daemon event bus
 
-	// store maintains job state and is always updated regardless of web server status.
+	// runs maintain each job's state and are always updated regardless of web server status.
 	// This allows late-attaching clients to query current state.
-	store *web.Store
+	runs *web.Runs
 
 	// webHub is optional and set when the web server starts.
 	// When set, events are broadcast to SSE clients.
 	webHub *web.Hub
 
+	// observers receive every job's lifecycle and events, for metrics and
+	// tracing. Set before jobs start.
+	observers []telemetry.Observer
+
 	// OnJobComplete is called when a job finishes (success, failure, or cancellation).
 	// Used to notify external components (e.g., GRPCServer) for cleanup.
 	OnJobComplete func(jobID string)
 }
 
+// ErrAtCapacity is returned when starting a job would exceed the daemon's
+// or a repository's job limit. Such jobs can be queued instead.
+var ErrAtCapacity = errors.New("at capacity")
+
 var newOrchestrator = func(cfg orchestrator.Config, deps orchestrator.Dependencies) orchestratorRunner {
 	return orchestrator.New(cfg, deps)
 }
@@ -62,14 +83,14 @@ func NewJobManager(database *db.DB, maxJobs int) *jobManagerImpl {
 		containerJobs: make(map[string]*ManagedContainerJob),
 		cfg:           &Config{},
 		eventBus:      events.NewBus(1000), // Global event bus for daemon-level events
-		store:         web.NewStore(),      // Always have a store for state tracking
+		runs:          web.NewRuns(),       // Always have runs for state tracking
 	}
 }
 
-// Store returns the job state store.
-// This store is always kept in sync with job events, regardless of web server status.
-func (jm *jobManagerImpl) Store() *web.Store {
-	return jm.store
+// Runs returns the job state stores.
+// Each job's store is always kept in sync with its events, regardless of web server status.
+func (jm *jobManagerImpl) Runs() *web.Runs {
+	return jm.runs
 }
 
 // SetWebHub configures the SSE broadcast hub.
@@ -81,6 +102,14 @@ func (jm *jobManagerImpl) SetWebHub(hub *web.Hub) {
 	jm.webHub = hub
 }
 
+// AddObserver registers an observer of every job's lifecycle and events.
+// Jobs already running are not reported to it.
+func (jm *jobManagerImpl) AddObserver(o telemetry.Observer) {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+	jm.observers = append(jm.observers, o)
+}
+
 // Start creates and starts a new job, returning the job ID.
 // The caller provides both the context and its cancel func. This allows the caller
 // (typically the gRPC layer) to retain control over job cancellation while the
@@ -91,13 +120,43 @@ func (jm *jobManagerImpl) Start(ctx context.Context, cancel context.CancelFunc,
 		return "", fmt.Errorf("invalid job config: %w", err)
 	}
 
+	// 1b. A workspace may set its own per-repository limit
+	repoLimit := jm.maxRepoJobs
+	if cfg.Workspace != "" {
+		if jm.workspaces == nil {
+			return "", fmt.Errorf("workspaces are not enabled")
+		}
+		ws, err := jm.workspaces.Get(cfg.Workspace)
+		if err != nil {
+			return "", err
+		}
+		if ws.MaxJobs > 0 {
+			repoLimit = ws.MaxJobs
+		}
+	}
+
+	// 1c. Fetch the workspace's clone so the job starts from the latest
+	// commits. A job that cannot start yet, e.g. a queued one retried
+	// while the workspace is busy, leaves the shared clone alone.
+	if cfg.Workspace != "" {
+		jm.mu.RLock()
+		err := jm.checkCapacity(cfg.RepoPath, repoLimit)
+		jm.mu.RUnlock()
+		if err != nil {
+			return "", err
+		}
+		if _, err := jm.workspaces.Prepare(ctx, cfg.Workspace, cfg.TargetBranch); err != nil {
+			return "", err
+		}
+	}
+
 	// 2. Lock for write
 	jm.mu.Lock()
 	defer jm.mu.Unlock()
 
-	// 3. Enforce capacity limit
-	if len(jm.jobs) >= jm.maxJobs {
-		return "", fmt.Errorf("max jobs (%d) reached, cannot start new job", jm.maxJobs)
+	// 3. Enforce capacity limits, overall and per repository
+	if err := jm.checkCapacity(cfg.RepoPath, repoLimit); err != nil {
+		return "", err
 	}
 
 	// 4. Generate unique job ID using ULID
@@ -109,7 +168,11 @@ func (jm *jobManagerImpl) Start(ctx context.Context, cancel context.CancelFunc,
 		return "", fmt.Errorf("failed to clean up old runs: %w", err)
 	}
 
-	// 6. Create run record in SQLite
+	// 6. Create run record in SQLite, with the config a retry starts from
+	configJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize job config: %w", err)
+	}
 	run := &db.Run{
 		ID:            jobID,
 		FeatureBranch: cfg.FeatureBranch,
@@ -118,6 +181,7 @@ func (jm *jobManagerImpl) Start(ctx context.Context, cancel context.CancelFunc,
 		TasksDir:      cfg.TasksDir,
 		Parallelism:   cfg.Concurrency,
 		Status:        db.RunStatusRunning,
+		ConfigJSON:    string(configJSON),
 	}
 
 	if err := jm.db.CreateRun(run); err != nil {
@@ -127,40 +191,65 @@ func (jm *jobManagerImpl) Start(ctx context.Context, cancel context.CancelFunc,
 	// 6. Create isolated event bus for this job
 	jobEventBus := events.NewBus(1000)
 
-	// 7. Load repository config for dependency creation
-	repoCfg, err := config.LoadConfig(cfg.RepoPath)
+	// 7. Create the job's orchestrator, in a job runner process when jobs
+	// are supervised
+	var orch orchestratorRunner
+	if jm.jobsDir != "" {
+		orch, err = jm.superviseJob(jobID, cfg, jobEventBus)
+	} else {
+		orch, err = newJobOrchestrator(cfg, jobEventBus, nil)
+	}
 	if err != nil {
-		// Mark job as failed if config cannot be loaded
+		// Mark job as failed if it cannot be set up
 		if updateErr := jm.db.UpdateRunStatus(jobID, db.RunStatusFailed, ptrString(err.Error())); updateErr != nil {
 			log.Printf("failed to update run status: %v", updateErr)
 		}
-		return "", fmt.Errorf("failed to load config: %w", err)
+		return "", err
 	}
 
-	// 8. Create Git WorktreeManager
+	jm.track(ctx, cancel, jobID, cfg, jobEventBus, orch)
+
+	// 8. Return job ID
+	return jobID, nil
+}
+
+// newJobOrchestrator creates the orchestrator of a job from its repository's
+// config. recovery is what an interrupted attempt at the job finished.
+func newJobOrchestrator(cfg JobConfig, bus *events.Bus, recovery *orchestrator.Recovery) (orchestratorRunner, error) {
+	// 1. Load repository config for dependency creation
+	repoCfg, err := config.LoadConfig(cfg.RepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// 2. Create Git WorktreeManager
 	gitManager := git.NewWorktreeManager(cfg.RepoPath, nil)
 
-	// 9. Create GitHub PRClient (may fail if no token available)
-	var ghClient *github.PRClient
+	// 3. Create the forge client (may fail if no token available)
+	var forgeClient forge.Forge
 	pollInterval, _ := repoCfg.ReviewPollIntervalDuration()
 	reviewTimeout, _ := repoCfg.ReviewTimeoutDuration()
 
-	ghClient, err = github.NewPRClient(github.PRClientConfig{
+	forgeClient, err = forge.New(forge.Config{
+		Type:          repoCfg.Forge.Type,
+		URL:           repoCfg.Forge.URL,
+		TokenEnv:      repoCfg.Forge.TokenEnv,
 		Owner:         repoCfg.GitHub.Owner,
 		Repo:          repoCfg.GitHub.Repo,
 		PollInterval:  pollInterval,
 		ReviewTimeout: reviewTimeout,
+		Review:        repoCfg.Review,
 	})
 	if err != nil {
 		// Log warning but continue - jobs with NoPR: true will work fine
-		log.Printf("GitHub client not available (jobs requiring PR creation will fail): %v", err)
-		ghClient = nil
+		log.Printf("%s client not available (jobs requiring PR creation will fail): %v", repoCfg.Forge.Type, err)
+		forgeClient = nil
 	}
 
-	// 10. Create Escalator (Terminal for daemon mode)
+	// 4. Create Escalator (Terminal for daemon mode)
 	esc := escalate.NewTerminal()
 
-	// 11. Create orchestrator with job-specific config
+	// 5. Create orchestrator with job-specific config
 	// Make TasksDir absolute if it's relative (daemon runs from different cwd)
 	tasksDir := cfg.TasksDir
 	if !filepath.IsAbs(tasksDir) {
@@ -168,34 +257,71 @@ func (jm *jobManagerImpl) Start(ctx context.Context, cancel context.CancelFunc,
 	}
 
 	orchConfig := orchestrator.Config{
-		Parallelism:   cfg.Concurrency,
-		TargetBranch:  cfg.TargetBranch,
-		FeatureBranch: cfg.FeatureBranch,
-		FeatureMode:   cfg.FeatureBranch != "",
-		TasksDir:      tasksDir,
-		RepoRoot:      cfg.RepoPath,
-		DryRun:        cfg.DryRun,
-		WorktreeBase:  repoCfg.Worktree.BasePath,
-		ClaudeCommand: repoCfg.Claude.Command,
+		Parallelism:    cfg.Concurrency,
+		TargetBranch:   cfg.TargetBranch,
+		FeatureBranch:  cfg.FeatureBranch,
+		FeatureMode:    cfg.FeatureBranch != "",
+		TasksDir:       tasksDir,
+		RepoRoot:       cfg.RepoPath,
+		DryRun:         cfg.DryRun,
+		WorktreeBase:   repoCfg.Worktree.BasePath,
+		ClaudeCommand:  repoCfg.Claude.Command,
+		BaselineChecks: repoCfg.QueueBaselineChecks(),
+		MergeQueue:     repoCfg.Merge.Queue,
+		Conflicts:      repoCfg.Conflicts,
+		PullRequests:   repoCfg.PullRequests,
+		CI:             repoCfg.CI,
+		SingleUnit:     cfg.Unit,
+		Recovery:       recovery,
 	}
 
 	orchDeps := orchestrator.Dependencies{
-		Bus:       jobEventBus,
+		Bus:       bus,
 		Escalator: esc,
 		Git:       gitManager,
-		GitHub:    ghClient,
+		Forge:     forgeClient,
 	}
 
-	orch := newOrchestrator(orchConfig, orchDeps)
+	return newOrchestrator(orchConfig, orchDeps), nil
+}
 
-	// 12. Set up event forwarding to Store (always) and Hub (if available)
-	// Mark store as connected when job starts
-	jm.store.SetConnected(true)
+// track registers a job and runs its orchestrator in the background,
+// recording its events and its outcome
+func (jm *jobManagerImpl) track(ctx context.Context, cancel context.CancelFunc, jobID string, cfg JobConfig, jobEventBus *events.Bus, orch orchestratorRunner) {
+	// 1. Set up event forwarding to Store (always) and Hub (if available)
+	// Mark the job's store as connected when job starts. A job re-attached
+	// after a restart starts from the events recorded so far.
+	_, seen := jm.runs.Get(jobID)
+	store := jm.runs.Store(jobID)
+	if !seen {
+		jm.seedStore(store, jobID)
+	}
+	store.SetConnected(true)
+
+	// Subscribe to job events - always record and update Store, broadcast to Hub if set
+	observers := jm.observers
+	for _, o := range observers {
+		o.JobStarted(telemetry.Job{
+			ID:            jobID,
+			RepoPath:      cfg.RepoPath,
+			FeatureBranch: cfg.FeatureBranch,
+			TargetBranch:  cfg.TargetBranch,
+			Workspace:     cfg.Workspace,
+		})
+	}
 
-	// Subscribe to job events - always update Store, broadcast to Hub if set
 	jobEventBus.Subscribe(func(e events.Event) {
-		webEvent := convertToWebEvent(e)
-		jm.store.HandleEvent(webEvent)
+		jm.persistEvent(jobID, e)
+		for _, o := range observers {
+			o.JobEvent(jobID, e)
+		}
+
+		if e.Type == events.PRCreated {
+			jm.recordFeaturePR(jobID, cfg, e)
+		}
+
+		webEvent := convertToWebEvent(jobID, e)
+		store.HandleEvent(webEvent)
 
 		// Broadcast to SSE clients if Hub is configured
 		jm.mu.RLock()
@@ -206,7 +332,14 @@ func (jm *jobManagerImpl) Start(ctx context.Context, cancel context.CancelFunc,
 		}
 	})
 
-	// 13. Register ManagedJob in map (use the caller-provided cancel func)
+	// Report progress on the issue the feature was imported from
+	tracker := jobIssueTracker(cfg)
+	if tracker != nil {
+		jobEventBus.Subscribe(tracker.Handler())
+	}
+
+	// 2. Register ManagedJob in map (use the caller-provided cancel func).
+	// Callers hold jm.mu.
 	job := &ManagedJob{
 		ID:           jobID,
 		Orchestrator: orch,
@@ -217,13 +350,25 @@ func (jm *jobManagerImpl) Start(ctx context.Context, cancel context.CancelFunc,
 	}
 	jm.jobs[jobID] = job
 
-	// 14. Start orchestrator in goroutine with cleanup on completion
+	// 3. Start orchestrator in goroutine with cleanup on completion
 	go func() {
 		defer jm.cleanup(jobID)
 
 		// Run the orchestrator with the caller-provided context
 		_, err := orch.Run(ctx)
 
+		// A detached job runs on in its runner and keeps its status; record
+		// the events relayed so far for the daemon that re-attaches
+		if errors.Is(err, errDetached) {
+			store.SetConnected(false)
+			jobEventBus.Close()
+			waitForEvents(jobEventBus, eventDrainTimeout)
+			if tracker != nil {
+				tracker.Close()
+			}
+			return
+		}
+
 		// Update database status based on result
 		var status db.RunStatus
 		var errMsg *string
@@ -245,14 +390,21 @@ func (jm *jobManagerImpl) Start(ctx context.Context, cancel context.CancelFunc,
 		}
 
 		// Mark store as disconnected when job ends
-		jm.store.SetConnected(false)
+		store.SetConnected(false)
 
-		// Close the job's event bus
+		// Close the job's event bus, then report the outcome to observers
+		// once they have seen the job's last events
 		jobEventBus.Close()
+		if len(observers) > 0 || tracker != nil {
+			waitForEvents(jobEventBus, eventDrainTimeout)
+		}
+		for _, o := range observers {
+			o.JobFinished(jobID, string(status))
+		}
+		if tracker != nil {
+			tracker.Close()
+		}
 	}()
-
-	// 10. Return job ID
-	return jobID, nil
 }
 
 // Stop cancels a running job.
@@ -274,6 +426,36 @@ func (jm *jobManagerImpl) Stop(jobID string) error {
 	return jm.db.UpdateRunStatus(jobID, db.RunStatusCancelled, nil)
 }
 
+// Pause pauses or resumes a running job. A paused job starts no new units;
+// units already running carry on.
+func (jm *jobManagerImpl) Pause(jobID string, paused bool) error {
+	jm.mu.Lock()
+	job, exists := jm.jobs[jobID]
+	if !exists {
+		jm.mu.Unlock()
+		return fmt.Errorf("job not found: %s", jobID)
+	}
+	orch, ok := job.Orchestrator.(pausableRunner)
+	if !ok {
+		jm.mu.Unlock()
+		return fmt.Errorf("job %s cannot be paused", jobID)
+	}
+	job.Paused = paused
+	jm.mu.Unlock()
+
+	orch.SetPaused(paused)
+	return nil
+}
+
+// IsPaused reports whether the given job is running but paused.
+func (jm *jobManagerImpl) IsPaused(jobID string) bool {
+	jm.mu.RLock()
+	defer jm.mu.RUnlock()
+
+	job, ok := jm.jobs[jobID]
+	return ok && job.Paused
+}
+
 // StopAll cancels all running jobs.
 func (jm *jobManagerImpl) StopAll() {
 	jm.mu.RLock()
@@ -312,6 +494,51 @@ func (jm *jobManagerImpl) List() []string {
 	return ids
 }
 
+// IsActive reports whether the given job is currently executing in this
+// daemon, either in-process or in a container.
+func (jm *jobManagerImpl) IsActive(jobID string) bool {
+	jm.mu.RLock()
+	defer jm.mu.RUnlock()
+
+	if _, ok := jm.jobs[jobID]; ok {
+		return true
+	}
+	_, ok := jm.containerJobs[jobID]
+	return ok
+}
+
+// IsRepoActive reports whether a job is running in the repository at repoPath.
+func (jm *jobManagerImpl) IsRepoActive(repoPath string) bool {
+	jm.mu.RLock()
+	defer jm.mu.RUnlock()
+
+	return jm.countInRepo(repoPath) > 0
+}
+
+// checkCapacity returns ErrAtCapacity if another job may not start, in
+// the daemon or in repoPath. Callers hold jm.mu.
+func (jm *jobManagerImpl) checkCapacity(repoPath string, repoLimit int) error {
+	if len(jm.jobs) >= jm.maxJobs {
+		return fmt.Errorf("%w: max jobs (%d) reached, cannot start new job", ErrAtCapacity, jm.maxJobs)
+	}
+	if repoLimit > 0 && jm.countInRepo(repoPath) >= repoLimit {
+		return fmt.Errorf("%w: max jobs (%d) reached for %s, cannot start new job", ErrAtCapacity, repoLimit, repoPath)
+	}
+	return nil
+}
+
+// countInRepo returns the number of jobs running in the repository at
+// repoPath. Callers must hold jm.mu.
+func (jm *jobManagerImpl) countInRepo(repoPath string) int {
+	n := 0
+	for _, job := range jm.jobs {
+		if job.Config.RepoPath == repoPath {
+			n++
+		}
+	}
+	return n
+}
+
 // ActiveCount returns the number of currently running jobs.
 func (jm *jobManagerImpl) ActiveCount() int {
 	jm.mu.RLock()
@@ -320,6 +547,25 @@ func (jm *jobManagerImpl) ActiveCount() int {
 	return len(jm.jobs)
 }
 
+// eventDrainTimeout bounds how long a finished job's remaining events may
+// take to reach observers
+const eventDrainTimeout = 5 * time.Second
+
+// waitForEvents waits until bus has dispatched its pending events, or
+// timeout passes
+func waitForEvents(bus *events.Bus, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		bus.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.Printf("WARN: timed out waiting for job events to be dispatched")
+	}
+}
+
 // cleanup removes a completed job from tracking.
 // Called when orchestrator goroutine exits.
 func (jm *jobManagerImpl) cleanup(jobID string) {
@@ -334,13 +580,36 @@ func (jm *jobManagerImpl) cleanup(jobID string) {
 	}
 }
 
+// recordFeaturePR stores the feature PR a job opened so the feature watcher
+// can close the feature out once it merges.
+func (jm *jobManagerImpl) recordFeaturePR(jobID string, cfg JobConfig, e events.Event) {
+	if e.PR == nil {
+		log.Printf("Job %s opened a feature PR without a number; not watching it for merge", jobID)
+		return
+	}
+	payload, _ := e.Payload.(map[string]any)
+	prURL, _ := payload["url"].(string)
+	err := jm.db.RecordFeaturePR(&db.FeaturePR{
+		RunID:         jobID,
+		RepoPath:      cfg.RepoPath,
+		FeatureBranch: cfg.FeatureBranch,
+		TargetBranch:  cfg.TargetBranch,
+		TasksDir:      cfg.TasksDir,
+		PRNumber:      *e.PR,
+		PRURL:         prURL,
+	})
+	if err != nil {
+		log.Printf("Failed to record feature PR for job %s: %v", jobID, err)
+	}
+}
+
 // ptrString returns a pointer to the given string.
 func ptrString(s string) *string {
 	return &s
 }
 
-// convertToWebEvent converts an events.Event to a web.Event for the web UI.
-func convertToWebEvent(e events.Event) *web.Event {
+// convertToWebEvent converts a job's events.Event to a web.Event for the web UI.
+func convertToWebEvent(jobID string, e events.Event) *web.Event {
 	var payload json.RawMessage
 	if e.Payload != nil {
 		// Marshal the payload to JSON
@@ -351,6 +620,7 @@ func convertToWebEvent(e events.Event) *web.Event {
 	}
 
 	return &web.Event{
+		Job:     jobID,
 		Type:    string(e.Type),
 		Time:    e.Time,
 		Unit:    e.Unit,
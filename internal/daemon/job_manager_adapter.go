@@ -156,3 +156,8 @@ func (a *jobManagerAdapter) Subscribe(jobID string, fromSeq int) (<-chan Event,
 func (a *jobManagerAdapter) ActiveJobCount() int {
 	return a.impl.ActiveCount()
 }
+
+// DetachAll detaches from the jobs that run in job runners.
+func (a *jobManagerAdapter) DetachAll() []string {
+	return a.impl.DetachAll()
+}
@@ -138,6 +138,25 @@ func TestJobManager_Stop_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestJobManager_Pause(t *testing.T) {
+	database := setupTestDB(t)
+	jm := NewJobManager(database, 10)
+
+	err := jm.Pause("invalid-job-id", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	// The test orchestrator has no pause support
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	jobID, err := jm.Start(ctx, cancel, validJobConfigWithRepo(setupTestRepo(t)))
+	require.NoError(t, err)
+
+	err = jm.Pause(jobID, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be paused")
+}
+
 func TestJobManager_Get(t *testing.T) {
 	database := setupTestDB(t)
 	jm := NewJobManager(database, 10)
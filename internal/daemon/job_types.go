@@ -20,12 +20,18 @@ type ManagedJob struct {
 	Events       *events.Bus
 	StartedAt    time.Time
 	Config       JobConfig
+	Paused       bool // Holding back new units; guarded by the job manager's lock
 }
 
 type orchestratorRunner interface {
 	Run(ctx context.Context) (*orchestrator.Result, error)
 }
 
+// pausableRunner is an orchestratorRunner that can hold back new units
+type pausableRunner interface {
+	SetPaused(paused bool)
+}
+
 // Validate checks the JobConfig for required fields.
 func (c *JobConfig) Validate() error {
 	// RepoPath must be non-empty and absolute
@@ -0,0 +1,291 @@
+package daemon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/orchestrator"
+)
+
+// JournalRecord is an entry of a job's journal: one event, numbered from 1
+// in the order it was emitted.
+type JournalRecord struct {
+	Seq   int          `json:"seq"`
+	Event events.Event `json:"event"`
+}
+
+// decisionEvents mark orchestrator decisions and their outcomes. Their
+// records are synced to disk before the emitter continues, so a crash
+// never loses a unit's merge or a task's commit.
+var decisionEvents = map[events.EventType]bool{
+	events.UnitStarted:   true,
+	events.UnitMerged:    true,
+	events.UnitCompleted: true,
+	events.UnitFailed:    true,
+	events.UnitBlocked:   true,
+	events.TaskCommitted: true,
+	events.PRCreated:     true,
+	events.OrchCompleted: true,
+	events.OrchFailed:    true,
+}
+
+// sinceBatch bounds the records one Since call reads back from disk
+const sinceBatch = 1000
+
+// Journal is the write-ahead log of a job run by a job runner. Every event
+// of the job is appended to it, and runs resume from what it records
+// rather than from the status frontmatter of their specs. Only the
+// decision records stay in memory; others, such as task output, are read
+// back from the file when relayed.
+type Journal struct {
+	mu        sync.Mutex
+	path      string
+	file      *os.File
+	offsets   []int64         // file offset of each record, by seq
+	size      int64           // bytes of complete records
+	decisions []JournalRecord // records of decision events, for recovery
+	changed   chan struct{}   // closed and replaced on every append
+	closed    bool
+}
+
+// OpenJournal opens the journal at path, creating it if needed. Records of
+// earlier attempts are loaded; a record torn by a crash mid-write is
+// discarded.
+func OpenJournal(path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+
+	j := &Journal{path: path, file: file, changed: make(chan struct{})}
+	size, err := scanJournal(file, 0, func(rec JournalRecord, offset int64) bool {
+		j.add(rec, offset)
+		return true
+	})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	j.size = size
+
+	// Drop a torn tail so new records start on a line of their own
+	if err := file.Truncate(size); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to truncate journal: %w", err)
+	}
+	if _, err := file.Seek(size, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to seek journal: %w", err)
+	}
+
+	return j, nil
+}
+
+// ReadJournal returns the records of the journal at path without opening
+// it for writing.
+func ReadJournal(path string) ([]JournalRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+
+	var records []JournalRecord
+	_, err = scanJournal(file, 0, func(rec JournalRecord, offset int64) bool {
+		records = append(records, rec)
+		return true
+	})
+	return records, err
+}
+
+// scanJournal decodes complete records from r, which starts at offset in
+// the journal, passing each to fn with its offset until fn returns false.
+// It returns the offset after the last record scanned.
+func scanJournal(r io.Reader, offset int64, fn func(rec JournalRecord, offset int64) bool) (int64, error) {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// A line without its newline was torn mid-write
+			return offset, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read journal: %w", err)
+		}
+
+		var rec JournalRecord
+		if err := json.Unmarshal(bytes.TrimSpace(line), &rec); err != nil {
+			return 0, fmt.Errorf("corrupt journal record at offset %d: %w", offset, err)
+		}
+		if !fn(rec, offset) {
+			return offset, nil
+		}
+		offset += int64(len(line))
+	}
+}
+
+// add indexes a record written at offset. Callers hold j.mu.
+func (j *Journal) add(rec JournalRecord, offset int64) {
+	j.offsets = append(j.offsets, offset)
+	if decisionEvents[rec.Event.Type] {
+		j.decisions = append(j.decisions, rec)
+	}
+}
+
+// Append records an event. Decision events are synced to disk before
+// Append returns.
+func (j *Journal) Append(e events.Event) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.closed {
+		return fmt.Errorf("journal is closed")
+	}
+
+	rec := JournalRecord{Seq: len(j.offsets) + 1, Event: e}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal record: %w", err)
+	}
+	data = append(data, '\n')
+	if _, err := j.file.Write(data); err != nil {
+		return fmt.Errorf("failed to write journal record: %w", err)
+	}
+	if decisionEvents[e.Type] {
+		if err := j.file.Sync(); err != nil {
+			return fmt.Errorf("failed to sync journal: %w", err)
+		}
+	}
+
+	j.add(rec, j.size)
+	j.size += int64(len(data))
+	close(j.changed)
+	j.changed = make(chan struct{})
+	return nil
+}
+
+// Since returns up to sinceBatch records from seq on, read back from the
+// file, and a channel closed when more are appended or the journal is
+// closed. Once closed, the channel is nil.
+func (j *Journal) Since(seq int) ([]JournalRecord, <-chan struct{}, error) {
+	j.mu.Lock()
+	if seq < 1 {
+		seq = 1
+	}
+	var offset, end int64
+	if seq <= len(j.offsets) {
+		offset, end = j.offsets[seq-1], j.size
+	}
+	changed := j.changed
+	if j.closed {
+		changed = nil
+	}
+	j.mu.Unlock()
+
+	if offset == end {
+		return nil, changed, nil
+	}
+
+	// Records are only appended, so the span read stays as it was indexed
+	file, err := os.Open(j.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+
+	var records []JournalRecord
+	_, err = scanJournal(io.NewSectionReader(file, offset, end-offset), offset, func(rec JournalRecord, _ int64) bool {
+		records = append(records, rec)
+		return len(records) < sinceBatch
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return records, changed, nil
+}
+
+// Len returns the number of records in the journal.
+func (j *Journal) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.offsets)
+}
+
+// Recovery returns what the journal records the run as having finished.
+func (j *Journal) Recovery() *orchestrator.Recovery {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return recoveryFromJournal(j.decisions)
+}
+
+// Close syncs and closes the journal, waking readers waiting in Since.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.closed {
+		return nil
+	}
+	j.closed = true
+	close(j.changed)
+
+	if err := j.file.Sync(); err != nil {
+		j.file.Close()
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	return j.file.Close()
+}
+
+// recoveryFromJournal folds journal records into the units and tasks they
+// finished. Only decision records are looked at. Returns nil when nothing was finished.
+func recoveryFromJournal(records []JournalRecord) *orchestrator.Recovery {
+	r := &orchestrator.Recovery{CommittedTasks: make(map[string][]int)}
+	completed := make(map[string]bool)
+	committed := make(map[string]map[int]bool)
+	found := false
+
+	for _, rec := range records {
+		e := rec.Event
+		switch e.Type {
+		case events.UnitMerged, events.UnitCompleted:
+			if e.Unit != "" && !completed[e.Unit] {
+				completed[e.Unit] = true
+				r.CompletedUnits = append(r.CompletedUnits, e.Unit)
+				found = true
+			}
+		case events.TaskCommitted:
+			if e.Unit == "" || e.Task == nil {
+				continue
+			}
+			if committed[e.Unit] == nil {
+				committed[e.Unit] = make(map[int]bool)
+			}
+			if !committed[e.Unit][*e.Task] {
+				committed[e.Unit][*e.Task] = true
+				r.CommittedTasks[e.Unit] = append(r.CommittedTasks[e.Unit], *e.Task)
+				found = true
+			}
+		case events.PRCreated:
+			if payload, ok := e.Payload.(map[string]any); ok {
+				if url, _ := payload["url"].(string); url != "" {
+					r.FeaturePRURL = url
+					if e.PR != nil {
+						r.FeaturePR = *e.PR
+					}
+					found = true
+				}
+			}
+		}
+	}
+
+	if !found {
+		return nil
+	}
+	return r
+}
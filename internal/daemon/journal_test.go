@@ -0,0 +1,127 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal_AppendAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+
+	j, err := OpenJournal(path)
+	require.NoError(t, err)
+	require.NoError(t, j.Append(events.NewEvent(events.UnitStarted, "a")))
+	require.NoError(t, j.Append(events.NewEvent(events.TaskStarted, "a").WithTask(1)))
+	require.NoError(t, j.Close())
+
+	// A record torn by a crash mid-write is dropped on reopen
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"seq":3,"event":{"type":"task.comm`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	j, err = OpenJournal(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, j.Len())
+
+	// Numbering carries on from the last whole record
+	require.NoError(t, j.Append(events.NewEvent(events.TaskCommitted, "a").WithTask(1)))
+	require.NoError(t, j.Close())
+
+	records, err := ReadJournal(path)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	for i, rec := range records {
+		assert.Equal(t, i+1, rec.Seq)
+	}
+	assert.Equal(t, events.TaskCommitted, records[2].Event.Type)
+	require.NotNil(t, records[2].Event.Task)
+	assert.Equal(t, 1, *records[2].Event.Task)
+}
+
+func TestJournal_Since(t *testing.T) {
+	j, err := OpenJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+	require.NoError(t, err)
+	require.NoError(t, j.Append(events.NewEvent(events.UnitStarted, "a")))
+
+	records, changed, err := j.Since(2)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	require.NoError(t, j.Append(events.NewEvent(events.UnitCompleted, "a")))
+	select {
+	case <-changed:
+	default:
+		t.Fatal("expected the append to be signalled")
+	}
+	records, _, err = j.Since(2)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, events.UnitCompleted, records[0].Event.Type)
+
+	require.NoError(t, j.Close())
+	_, changed, err = j.Since(3)
+	require.NoError(t, err)
+	assert.Nil(t, changed, "a closed journal has nothing more to wait for")
+}
+
+func TestJournal_SinceReadsBackFromDisk(t *testing.T) {
+	j, err := OpenJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+	require.NoError(t, err)
+	defer j.Close()
+
+	require.NoError(t, j.Append(events.NewEvent(events.UnitStarted, "a")))
+	for i := 0; i < sinceBatch+5; i++ {
+		require.NoError(t, j.Append(events.NewEvent(events.TaskOutput, "a").
+			WithPayload(map[string]any{"line": i})))
+	}
+	assert.Len(t, j.decisions, 1, "only decisions stay in memory")
+
+	// Output is relayed in batches, in order
+	records, _, err := j.Since(2)
+	require.NoError(t, err)
+	require.Len(t, records, sinceBatch)
+	assert.Equal(t, 2, records[0].Seq)
+	assert.Equal(t, events.TaskOutput, records[0].Event.Type)
+
+	records, _, err = j.Since(records[len(records)-1].Seq + 1)
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+	assert.Equal(t, sinceBatch+6, records[4].Seq)
+}
+
+func TestJournal_Recovery(t *testing.T) {
+	j, err := OpenJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+	require.NoError(t, err)
+	defer j.Close()
+
+	assert.Nil(t, j.Recovery(), "nothing finished yet")
+
+	for _, e := range []events.Event{
+		events.NewEvent(events.UnitStarted, "a"),
+		events.NewEvent(events.TaskCommitted, "a").WithTask(1),
+		events.NewEvent(events.TaskCommitted, "a").WithTask(2),
+		events.NewEvent(events.UnitMerged, "a"),
+		// Reported by both the worker and the scheduler
+		events.NewEvent(events.UnitCompleted, "a"),
+		events.NewEvent(events.UnitCompleted, "a"),
+		events.NewEvent(events.UnitStarted, "b"),
+		events.NewEvent(events.TaskCompleted, "b").WithTask(1),
+		events.NewEvent(events.PRCreated, "").WithPR(12).WithPayload(map[string]any{"url": "https://github.com/o/r/pull/12"}),
+	} {
+		require.NoError(t, j.Append(e))
+	}
+
+	r := j.Recovery()
+	require.NotNil(t, r)
+	assert.Equal(t, []string{"a"}, r.CompletedUnits)
+	assert.Equal(t, map[string][]int{"a": {1, 2}}, r.CommittedTasks, "b's task completed but was never committed")
+	assert.Equal(t, 12, r.FeaturePR)
+	assert.Equal(t, "https://github.com/o/r/pull/12", r.FeaturePRURL)
+}
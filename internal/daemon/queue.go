@@ -0,0 +1,260 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	apiv1 "github.com/RevCBH/choo/pkg/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// queueCheckInterval is how often the queue looks for due schedules and
+// free slots it was not woken for
+const queueCheckInterval = 30 * time.Second
+
+// startFunc starts a job the way a StartJob call does
+type startFunc func(ctx context.Context, req *apiv1.StartJobRequest) (*apiv1.StartJobResponse, error)
+
+// JobQueue holds jobs that wait for a free slot, in SQLite so they survive
+// restarts, and starts them as slots free up: highest priority first, then
+// oldest. It also queues the jobs of schedules as they come due.
+type JobQueue struct {
+	db       *db.DB
+	start    startFunc
+	isActive func(jobID string) bool
+
+	wake chan struct{}
+	now  func() time.Time
+
+	mu sync.Mutex // serializes dispatch passes
+}
+
+// NewJobQueue creates a queue that starts jobs with start. isActive
+// reports whether a started job is still running.
+func NewJobQueue(database *db.DB, start startFunc, isActive func(jobID string) bool) *JobQueue {
+	return &JobQueue{
+		db:       database,
+		start:    start,
+		isActive: isActive,
+		wake:     make(chan struct{}, 1),
+		now:      time.Now,
+	}
+}
+
+// Enqueue adds a job to the queue and wakes the dispatcher.
+func (q *JobQueue) Enqueue(spec db.JobSpec, priority int) (*db.QueuedJob, error) {
+	if err := validateJobSpec(spec); err != nil {
+		return nil, err
+	}
+	entry := &db.QueuedJob{Priority: priority, JobSpec: spec}
+	if err := q.db.EnqueueJob(entry); err != nil {
+		return nil, err
+	}
+	q.Wake()
+	return entry, nil
+}
+
+// Cancel removes a job from the queue before it starts.
+func (q *JobQueue) Cancel(id string) error {
+	return q.db.CancelQueuedJob(id)
+}
+
+// List returns the queue, with finished and cancelled entries if
+// includeFinished is set.
+func (q *JobQueue) List(includeFinished bool) ([]*db.QueuedJob, error) {
+	return q.db.ListQueue(includeFinished)
+}
+
+// Depth returns how many jobs are waiting to start.
+func (q *JobQueue) Depth() int {
+	entries, err := q.db.ListQueuedJobsByStatus(db.QueueStatusQueued)
+	if err != nil {
+		log.Printf("Failed to count queued jobs: %v", err)
+		return 0
+	}
+	return len(entries)
+}
+
+// AddSchedule validates s and stores it with its first run time.
+func (q *JobQueue) AddSchedule(s *db.Schedule) error {
+	if s.Name == "" {
+		return fmt.Errorf("schedule name is required")
+	}
+	cron, err := ParseCron(s.Cron)
+	if err != nil {
+		return err
+	}
+	if err := validateJobSpec(s.JobSpec); err != nil {
+		return err
+	}
+	s.NextRunAt = cron.Next(q.now())
+	if s.NextRunAt.IsZero() {
+		return fmt.Errorf("cron expression %q never matches", s.Cron)
+	}
+	s.CreatedAt = q.now()
+	return q.db.CreateSchedule(s)
+}
+
+// ListSchedules returns all schedules.
+func (q *JobQueue) ListSchedules() ([]*db.Schedule, error) {
+	return q.db.ListSchedules()
+}
+
+// RemoveSchedule deletes a schedule. Jobs it already queued stay queued.
+func (q *JobQueue) RemoveSchedule(name string) error {
+	return q.db.DeleteSchedule(name)
+}
+
+// JobFinished marks the queue entry of a finished job finished and wakes
+// the dispatcher to use the freed slot.
+func (q *JobQueue) JobFinished(jobID string) {
+	if _, err := q.db.FinishQueuedJobForRun(jobID); err != nil {
+		log.Printf("Failed to update job queue for job %s: %v", jobID, err)
+	}
+	q.Wake()
+}
+
+// Wake asks the dispatcher for a pass without waiting for its next check.
+func (q *JobQueue) Wake() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run dispatches until ctx is cancelled: once at startup, so jobs queued
+// before a restart resume, then whenever woken and every interval.
+func (q *JobQueue) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := q.Dispatch(ctx); err != nil {
+			log.Printf("Job queue dispatch failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-q.wake:
+		}
+	}
+}
+
+// Dispatch queues the jobs of due schedules, then starts queued jobs in
+// order until none fit. A job blocked by its repository's limit does not
+// hold up jobs for other repositories. It returns the number started.
+func (q *JobQueue) Dispatch(ctx context.Context) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.finishStale()
+	if err := q.queueDueSchedules(); err != nil {
+		return 0, err
+	}
+
+	queued, err := q.db.ListQueuedJobsByStatus(db.QueueStatusQueued)
+	if err != nil {
+		return 0, err
+	}
+
+	started := 0
+	for _, entry := range queued {
+		if ctx.Err() != nil {
+			return started, ctx.Err()
+		}
+		resp, err := q.start(ctx, jobSpecToProto(entry.JobSpec))
+		switch status.Code(err) {
+		case codes.OK:
+			if err := q.db.MarkQueuedJobStarted(entry.ID, resp.JobId); err != nil {
+				return started, err
+			}
+			log.Printf("Started queued job %s as job %s", entry.ID, resp.JobId)
+			started++
+		case codes.ResourceExhausted:
+			// No slot for this one yet
+		case codes.Unavailable:
+			// Shutting down; the entry stays queued for the next start
+			return started, nil
+		default:
+			msg := status.Convert(err).Message()
+			log.Printf("Queued job %s could not start: %s", entry.ID, msg)
+			if err := q.db.MarkQueuedJobFinished(entry.ID, &msg); err != nil {
+				return started, err
+			}
+		}
+	}
+	return started, nil
+}
+
+// finishStale marks started entries whose job is no longer running
+// finished, such as jobs that ended while the daemon was down
+func (q *JobQueue) finishStale() {
+	started, err := q.db.ListQueuedJobsByStatus(db.QueueStatusStarted)
+	if err != nil {
+		log.Printf("Failed to list started queue entries: %v", err)
+		return
+	}
+	for _, entry := range started {
+		if entry.JobID != nil && !q.isActive(*entry.JobID) {
+			if _, err := q.db.FinishQueuedJobForRun(*entry.JobID); err != nil {
+				log.Printf("Failed to finish queue entry %s: %v", entry.ID, err)
+			}
+		}
+	}
+}
+
+// queueDueSchedules queues one job for each due schedule. Runs missed while
+// the daemon was down are not made up: the next run is computed from now.
+func (q *JobQueue) queueDueSchedules() error {
+	now := q.now()
+	due, err := q.db.ListDueSchedules(now)
+	if err != nil {
+		return err
+	}
+	for _, s := range due {
+		cron, err := ParseCron(s.Cron)
+		if err != nil {
+			log.Printf("Schedule %s has an invalid cron expression: %v", s.Name, err)
+			continue
+		}
+		name := s.Name
+		entry := &db.QueuedJob{Priority: s.Priority, Schedule: &name, JobSpec: s.JobSpec}
+		if err := q.db.EnqueueJob(entry); err != nil {
+			return err
+		}
+		if err := q.db.MarkScheduleRun(s.Name, now, cron.Next(now)); err != nil {
+			return err
+		}
+		log.Printf("Schedule %s queued job %s", s.Name, entry.ID)
+	}
+	return nil
+}
+
+// validateJobSpec checks that a job can be started later: it needs a
+// workspace, or an absolute repository path with a tasks directory and
+// target branch
+func validateJobSpec(spec db.JobSpec) error {
+	if spec.Workspace != "" {
+		if spec.RepoPath != "" {
+			return fmt.Errorf("repo path and workspace are mutually exclusive")
+		}
+		return nil
+	}
+	if spec.RepoPath == "" {
+		return fmt.Errorf("a repo path or workspace is required")
+	}
+	if !filepath.IsAbs(spec.RepoPath) {
+		return fmt.Errorf("repo path must be absolute, got %s", spec.RepoPath)
+	}
+	if spec.TasksDir == "" || spec.TargetBranch == "" {
+		return fmt.Errorf("tasks dir and target branch are required without a workspace")
+	}
+	return nil
+}
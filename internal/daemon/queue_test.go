@@ -0,0 +1,275 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	apiv1 "github.com/RevCBH/choo/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStarter starts jobs up to a number of slots, like StartJob at capacity
+type fakeStarter struct {
+	slots   int
+	active  map[string]bool
+	started []*apiv1.StartJobRequest
+	err     error
+}
+
+func newFakeStarter(slots int) *fakeStarter {
+	return &fakeStarter{slots: slots, active: make(map[string]bool)}
+}
+
+func (f *fakeStarter) start(ctx context.Context, req *apiv1.StartJobRequest) (*apiv1.StartJobResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if len(f.active) >= f.slots {
+		return nil, status.Errorf(codes.ResourceExhausted, "at capacity")
+	}
+	jobID := fmt.Sprintf("job-%d", len(f.started)+1)
+	f.active[jobID] = true
+	f.started = append(f.started, req)
+	return &apiv1.StartJobResponse{JobId: jobID, Status: "running"}, nil
+}
+
+func (f *fakeStarter) isActive(jobID string) bool {
+	return f.active[jobID]
+}
+
+func queueSpec(branch string) db.JobSpec {
+	return db.JobSpec{RepoPath: "/repo", TasksDir: "specs/tasks", TargetBranch: "main", FeatureBranch: branch}
+}
+
+func TestJobQueue_DispatchesByPriorityAsSlotsFree(t *testing.T) {
+	database := setupTestDB(t)
+	starter := newFakeStarter(1)
+	q := NewJobQueue(database, starter.start, starter.isActive)
+	ctx := context.Background()
+
+	low, err := q.Enqueue(queueSpec("feature/low"), 0)
+	require.NoError(t, err)
+	high, err := q.Enqueue(queueSpec("feature/high"), 5)
+	require.NoError(t, err)
+
+	n, err := q.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, starter.started, 1)
+	assert.Equal(t, "feature/high", starter.started[0].FeatureBranch)
+
+	entry, err := database.GetQueuedJob(high.ID)
+	require.NoError(t, err)
+	assert.Equal(t, db.QueueStatusStarted, entry.Status)
+	require.NotNil(t, entry.JobID)
+	assert.Equal(t, "job-1", *entry.JobID)
+
+	entry, err = database.GetQueuedJob(low.ID)
+	require.NoError(t, err)
+	assert.Equal(t, db.QueueStatusQueued, entry.Status, "no slot yet")
+
+	// The job finishing frees its slot for the next entry
+	delete(starter.active, "job-1")
+	q.JobFinished("job-1")
+	n, err = q.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	entry, err = database.GetQueuedJob(high.ID)
+	require.NoError(t, err)
+	assert.Equal(t, db.QueueStatusFinished, entry.Status)
+
+	entry, err = database.GetQueuedJob(low.ID)
+	require.NoError(t, err)
+	assert.Equal(t, db.QueueStatusStarted, entry.Status)
+}
+
+func TestJobQueue_SurvivesRestart(t *testing.T) {
+	database := setupTestDB(t)
+	blocked := newFakeStarter(0)
+	q := NewJobQueue(database, blocked.start, blocked.isActive)
+
+	entry, err := q.Enqueue(queueSpec("feature/a"), 0)
+	require.NoError(t, err)
+	_, err = q.Dispatch(context.Background())
+	require.NoError(t, err)
+
+	// A new queue over the same database picks the entry up
+	starter := newFakeStarter(1)
+	restarted := NewJobQueue(database, starter.start, starter.isActive)
+	n, err := restarted.Dispatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	got, err := database.GetQueuedJob(entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, db.QueueStatusStarted, got.Status)
+}
+
+func TestJobQueue_FinishesStaleAndFailedEntries(t *testing.T) {
+	database := setupTestDB(t)
+	starter := newFakeStarter(1)
+	q := NewJobQueue(database, starter.start, starter.isActive)
+	ctx := context.Background()
+
+	started, err := q.Enqueue(queueSpec("feature/a"), 0)
+	require.NoError(t, err)
+	_, err = q.Dispatch(ctx)
+	require.NoError(t, err)
+
+	// The job ended without a completion callback, as across a restart
+	starter.active = map[string]bool{}
+	starter.err = status.Errorf(codes.InvalidArgument, "tasks_dir is required")
+
+	failed, err := q.Enqueue(queueSpec("feature/b"), 0)
+	require.NoError(t, err)
+	_, err = q.Dispatch(ctx)
+	require.NoError(t, err)
+
+	got, err := database.GetQueuedJob(started.ID)
+	require.NoError(t, err)
+	assert.Equal(t, db.QueueStatusFinished, got.Status)
+
+	got, err = database.GetQueuedJob(failed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, db.QueueStatusFinished, got.Status)
+	require.NotNil(t, got.Error)
+	assert.Equal(t, "tasks_dir is required", *got.Error)
+
+	// Shutting down leaves entries queued
+	starter.err = status.Errorf(codes.Unavailable, "daemon is shutting down")
+	waiting, err := q.Enqueue(queueSpec("feature/c"), 0)
+	require.NoError(t, err)
+	_, err = q.Dispatch(ctx)
+	require.NoError(t, err)
+
+	got, err = database.GetQueuedJob(waiting.ID)
+	require.NoError(t, err)
+	assert.Equal(t, db.QueueStatusQueued, got.Status)
+}
+
+func TestJobQueue_Schedules(t *testing.T) {
+	database := setupTestDB(t)
+	starter := newFakeStarter(0)
+	q := NewJobQueue(database, starter.start, starter.isActive)
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	q.now = func() time.Time { return now }
+
+	sched := &db.Schedule{Name: "nightly", Cron: "@nightly", Priority: 2, JobSpec: db.JobSpec{Workspace: "app"}}
+	require.NoError(t, q.AddSchedule(sched))
+	assert.Equal(t, time.Date(2026, 3, 5, 1, 0, 0, 0, time.Local), sched.NextRunAt)
+
+	err := q.AddSchedule(&db.Schedule{Name: "bad", Cron: "99 * * * *", JobSpec: db.JobSpec{Workspace: "app"}})
+	assert.Error(t, err)
+
+	// Not due yet
+	_, err = q.Dispatch(context.Background())
+	require.NoError(t, err)
+	list, err := q.List(false)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	// A day later it queues one job, even though several runs were missed
+	now = now.Add(48 * time.Hour)
+	_, err = q.Dispatch(context.Background())
+	require.NoError(t, err)
+
+	list, err = q.List(false)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, 2, list[0].Priority)
+	assert.Equal(t, "app", list[0].Workspace)
+	require.NotNil(t, list[0].Schedule)
+	assert.Equal(t, "nightly", *list[0].Schedule)
+
+	schedules, err := q.ListSchedules()
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, time.Date(2026, 3, 7, 1, 0, 0, 0, time.Local), schedules[0].NextRunAt.Local())
+	require.NotNil(t, schedules[0].LastRunAt)
+
+	require.NoError(t, q.RemoveSchedule("nightly"))
+	assert.Error(t, q.RemoveSchedule("nightly"))
+}
+
+func TestJobQueue_Enqueue_Validates(t *testing.T) {
+	database := setupTestDB(t)
+	q := NewJobQueue(database, newFakeStarter(1).start, func(string) bool { return false })
+
+	_, err := q.Enqueue(db.JobSpec{}, 0)
+	assert.Error(t, err)
+	_, err = q.Enqueue(db.JobSpec{RepoPath: "relative", TasksDir: "specs/tasks", TargetBranch: "main"}, 0)
+	assert.Error(t, err)
+	_, err = q.Enqueue(db.JobSpec{RepoPath: "/repo"}, 0)
+	assert.Error(t, err)
+	_, err = q.Enqueue(db.JobSpec{RepoPath: "/repo", Workspace: "app"}, 0)
+	assert.Error(t, err)
+}
+
+func TestGRPC_QueueJob(t *testing.T) {
+	database := setupTestDB(t)
+	server := NewGRPCServer(database, newMockJobManager(), "v1.0.0", nil)
+	ctx := context.Background()
+
+	_, err := server.QueueJob(ctx, &apiv1.QueueJobRequest{Job: &apiv1.StartJobRequest{Workspace: "app"}})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	starter := newFakeStarter(0)
+	server.SetJobQueue(NewJobQueue(database, starter.start, starter.isActive))
+
+	resp, err := server.QueueJob(ctx, &apiv1.QueueJobRequest{
+		Job:      &apiv1.StartJobRequest{Workspace: "app", FeatureBranch: "feature/a"},
+		Priority: 3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "queued", resp.Queued.Status)
+	assert.Equal(t, int32(3), resp.Queued.Priority)
+
+	_, err = server.QueueJob(ctx, &apiv1.QueueJobRequest{Job: &apiv1.StartJobRequest{}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	list, err := server.ListQueue(ctx, &apiv1.ListQueueRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Jobs, 1)
+	assert.Equal(t, "feature/a", list.Jobs[0].Job.FeatureBranch)
+
+	_, err = server.CancelQueuedJob(ctx, &apiv1.CancelQueuedJobRequest{Id: resp.Queued.Id})
+	require.NoError(t, err)
+	_, err = server.CancelQueuedJob(ctx, &apiv1.CancelQueuedJobRequest{Id: resp.Queued.Id})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	added, err := server.AddSchedule(ctx, &apiv1.AddScheduleRequest{Schedule: &apiv1.Schedule{
+		Name: "nightly",
+		Cron: "0 1 * * *",
+		Job:  &apiv1.StartJobRequest{Workspace: "app"},
+	}})
+	require.NoError(t, err)
+	assert.NotNil(t, added.Schedule.NextRunAt)
+
+	schedules, err := server.ListSchedules(ctx, &apiv1.ListSchedulesRequest{})
+	require.NoError(t, err)
+	require.Len(t, schedules.Schedules, 1)
+
+	_, err = server.RemoveSchedule(ctx, &apiv1.RemoveScheduleRequest{Name: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPC_StartJob_AtCapacity(t *testing.T) {
+	database := setupTestDB(t)
+	jm := newMockJobManager()
+	jm.startErr = fmt.Errorf("%w: max jobs (1) reached, cannot start new job", ErrAtCapacity)
+	server := NewGRPCServer(database, jm, "v1.0.0", nil)
+
+	_, err := server.StartJob(context.Background(), &apiv1.StartJobRequest{
+		RepoPath:     "/repo",
+		TasksDir:     "specs/tasks",
+		TargetBranch: "main",
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
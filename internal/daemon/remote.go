@@ -0,0 +1,360 @@
+package daemon
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1 "github.com/RevCBH/choo/pkg/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// Permission is what a remote client may do
+type Permission string
+
+const (
+	// PermissionRead allows listing, inspecting and watching jobs
+	PermissionRead Permission = "read"
+
+	// PermissionControl additionally allows starting and stopping jobs,
+	// running GC and shutting the daemon down
+	PermissionControl Permission = "control"
+)
+
+// tokenPrefix marks choo access tokens so they are recognizable in logs
+// and secret scanners
+const tokenPrefix = "choo_"
+
+// readMethods are the RPCs a read-only client may call. Everything else
+// needs control, so RPCs added later are protected by default.
+var readMethods = map[string]bool{
+	apiv1.DaemonService_GetJobStatus_FullMethodName:   true,
+	apiv1.DaemonService_ListJobs_FullMethodName:       true,
+	apiv1.DaemonService_WatchJob_FullMethodName:       true,
+	apiv1.DaemonService_Health_FullMethodName:         true,
+	apiv1.DaemonService_ListWorkspaces_FullMethodName: true,
+	apiv1.DaemonService_ListQueue_FullMethodName:      true,
+	apiv1.DaemonService_ListSchedules_FullMethodName:  true,
+	apiv1.DaemonService_ExportJob_FullMethodName:      true,
+}
+
+// RemoteClient is a client allowed to use the daemon's TCP listener,
+// identified by a bearer token or by the common name of its certificate
+type RemoteClient struct {
+	Name        string     `yaml:"name"`
+	TokenSHA256 string     `yaml:"token_sha256,omitempty"`
+	CommonName  string     `yaml:"common_name,omitempty"`
+	Permission  Permission `yaml:"permission"`
+	CreatedAt   time.Time  `yaml:"created_at,omitempty"`
+}
+
+// AccessList is the access file: the clients allowed to connect remotely
+type AccessList struct {
+	Clients []RemoteClient `yaml:"clients"`
+}
+
+// LoadAccessList reads the access file at path. A missing file is an empty
+// list.
+func LoadAccessList(path string) (*AccessList, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &AccessList{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read access file: %w", err)
+	}
+	var list AccessList
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse access file %s: %w", path, err)
+	}
+	for _, c := range list.Clients {
+		if c.Permission != PermissionRead && c.Permission != PermissionControl {
+			return nil, fmt.Errorf("client %q: permission must be read or control, got %q", c.Name, c.Permission)
+		}
+	}
+	return &list, nil
+}
+
+// Save writes the access list to path, readable only by its owner
+func (l *AccessList) Save(path string) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to marshal access file: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write access file: %w", err)
+	}
+	return nil
+}
+
+// AddToken creates a token for a new client named name and returns it.
+// Only the token's hash is stored, so it cannot be shown again.
+func (l *AccessList) AddToken(name string, perm Permission) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("client name is required")
+	}
+	for _, c := range l.Clients {
+		if c.Name == name {
+			return "", fmt.Errorf("client %q already exists", name)
+		}
+	}
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := tokenPrefix + hex.EncodeToString(raw)
+	l.Clients = append(l.Clients, RemoteClient{
+		Name:        name,
+		TokenSHA256: hashToken(token),
+		Permission:  perm,
+		CreatedAt:   time.Now().UTC(),
+	})
+	return token, nil
+}
+
+// Remove deletes the client named name, reporting whether it existed
+func (l *AccessList) Remove(name string) bool {
+	for i, c := range l.Clients {
+		if c.Name == name {
+			l.Clients = append(l.Clients[:i], l.Clients[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// byToken returns the client holding token, or nil
+func (l *AccessList) byToken(token string) *RemoteClient {
+	hash := hashToken(token)
+	for i := range l.Clients {
+		c := &l.Clients[i]
+		if c.TokenSHA256 != "" && subtle.ConstantTimeCompare([]byte(c.TokenSHA256), []byte(hash)) == 1 {
+			return c
+		}
+	}
+	return nil
+}
+
+// byCommonName returns the client with the certificate common name cn, or nil
+func (l *AccessList) byCommonName(cn string) *RemoteClient {
+	for i := range l.Clients {
+		if c := &l.Clients[i]; c.CommonName != "" && c.CommonName == cn {
+			return c
+		}
+	}
+	return nil
+}
+
+// remoteAuth authenticates and authorizes calls on the TCP listener. The
+// access file is re-read when it changes, so tokens can be added or
+// revoked without restarting the daemon.
+type remoteAuth struct {
+	path string
+
+	mu      sync.Mutex
+	list    *AccessList
+	modTime time.Time
+}
+
+func newRemoteAuth(path string) *remoteAuth {
+	return &remoteAuth{path: path}
+}
+
+// accessList returns the current access list, reloading it if the file
+// changed
+func (a *remoteAuth) accessList() (*AccessList, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var modTime time.Time
+	if info, err := os.Stat(a.path); err == nil {
+		modTime = info.ModTime()
+	}
+	if a.list != nil && modTime.Equal(a.modTime) {
+		return a.list, nil
+	}
+	list, err := LoadAccessList(a.path)
+	if err != nil {
+		return nil, err
+	}
+	a.list, a.modTime = list, modTime
+	return list, nil
+}
+
+// authorize checks that the caller of method is a known client with
+// enough permission. A bearer token takes precedence over the client
+// certificate. Certificates verified against the client CA but not listed
+// in the access file get control: the CA is what admits them.
+func (a *remoteAuth) authorize(ctx context.Context, method string) error {
+	list, err := a.accessList()
+	if err != nil {
+		return status.Error(codes.Internal, "access file unreadable")
+	}
+
+	var client *RemoteClient
+	if token := bearerToken(ctx); token != "" {
+		if client = list.byToken(token); client == nil {
+			return status.Error(codes.Unauthenticated, "invalid token")
+		}
+	} else if cn, ok := verifiedCommonName(ctx); ok {
+		client = list.byCommonName(cn)
+		if client == nil {
+			client = &RemoteClient{Name: cn, CommonName: cn, Permission: PermissionControl}
+		}
+	} else {
+		return status.Error(codes.Unauthenticated, "a token or client certificate is required")
+	}
+
+	if client.Permission != PermissionControl && !readMethods[method] {
+		return status.Errorf(codes.PermissionDenied, "client %q is read-only", client.Name)
+	}
+	return nil
+}
+
+// authorizeHTTP checks that an HTTP request bears the token of a client
+// with control. The web UI has no client certificates, so only tokens
+// count. As with gRPC, tokens are not accepted in the clear: over plain
+// HTTP, only requests from loopback are admitted.
+func (a *remoteAuth) authorizeHTTP(r *http.Request) error {
+	if r.TLS == nil && !isLoopback(r.RemoteAddr) {
+		return errors.New("job control over plain HTTP is only accepted from localhost; serve the web UI over TLS")
+	}
+	list, err := a.accessList()
+	if err != nil {
+		return errors.New("access file unreadable")
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || strings.TrimSpace(token) == "" {
+		return errors.New("a control token is required")
+	}
+	client := list.byToken(strings.TrimSpace(token))
+	if client == nil {
+		return errors.New("invalid token")
+	}
+	if client.Permission != PermissionControl {
+		return fmt.Errorf("client %q is read-only", client.Name)
+	}
+	return nil
+}
+
+// isLoopback reports whether addr, a host:port, is a loopback address
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (a *remoteAuth) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := a.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *remoteAuth) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// bearerToken returns the token from the authorization metadata, if any
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, v := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(v, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// verifiedCommonName returns the common name of the caller's certificate
+// if it was verified against the client CA
+func verifiedCommonName(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	return info.State.VerifiedChains[0][0].Subject.CommonName, true
+}
+
+// remoteTLSConfig builds the TLS config for the TCP listener. With a
+// client CA, clients may present a certificate signed by it; clients
+// without one must send a token.
+func remoteTLSConfig(cfg *Config) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCert, cfg.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if cfg.TLSClientCA != "" {
+		pem, err := os.ReadFile(cfg.TLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client CA %s", cfg.TLSClientCA)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return tlsCfg, nil
+}
+
+// newRemoteServer creates the gRPC server for the TCP listener, serving
+// impl over TLS to authenticated clients
+func newRemoteServer(cfg *Config, impl apiv1.DaemonServiceServer) (*grpc.Server, error) {
+	tlsCfg, err := remoteTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	auth := newRemoteAuth(cfg.AccessFile)
+	if _, err := auth.accessList(); err != nil {
+		return nil, err
+	}
+	srv := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlsCfg)),
+		grpc.ChainUnaryInterceptor(auth.unaryInterceptor),
+		grpc.ChainStreamInterceptor(auth.streamInterceptor),
+	)
+	apiv1.RegisterDaemonServiceServer(srv, impl)
+	return srv, nil
+}
@@ -0,0 +1,258 @@
+package daemon
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testCA issues certificates for the remote listener tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	dir  string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "choo test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	ca := &testCA{cert: cert, key: key, dir: t.TempDir()}
+	writePEM(t, ca.path("ca.pem"), "CERTIFICATE", der)
+	return ca
+}
+
+func (ca *testCA) path(name string) string {
+	return filepath.Join(ca.dir, name)
+}
+
+// issue writes name.pem and name-key.pem for a certificate with the given
+// common name, usable as a server certificate for localhost
+func (ca *testCA) issue(t *testing.T, name, cn string, usage x509.ExtKeyUsage) (certPath, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath, keyPath = ca.path(name+".pem"), ca.path(name+"-key.pem")
+	writePEM(t, certPath, "CERTIFICATE", der)
+	writePEM(t, keyPath, "EC PRIVATE KEY", keyDER)
+	return certPath, keyPath
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+// startRemoteServer serves a GRPCServer on a TLS listener on localhost and
+// returns its address
+func startRemoteServer(t *testing.T, cfg *Config) string {
+	t.Helper()
+	impl := NewGRPCServer(setupTestDB(t), newMockJobManager(), "test", nil)
+	srv, err := newRemoteServer(cfg, impl)
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(listener)
+	t.Cleanup(srv.Stop)
+	return listener.Addr().String()
+}
+
+func dialRemote(t *testing.T, addr string, opts client.RemoteOptions) *client.Client {
+	t.Helper()
+	c, err := client.NewRemote(addr, opts)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestAccessList_Tokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "remote-access.yaml")
+	list, err := LoadAccessList(path)
+	require.NoError(t, err)
+	assert.Empty(t, list.Clients)
+
+	token, err := list.AddToken("alice", PermissionRead)
+	require.NoError(t, err)
+	assert.Contains(t, token, tokenPrefix)
+	_, err = list.AddToken("alice", PermissionControl)
+	assert.Error(t, err, "duplicate names are rejected")
+	require.NoError(t, list.Save(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), token, "only the hash is stored")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	loaded, err := LoadAccessList(path)
+	require.NoError(t, err)
+	require.NotNil(t, loaded.byToken(token))
+	assert.Equal(t, PermissionRead, loaded.byToken(token).Permission)
+	assert.Nil(t, loaded.byToken(token+"x"))
+
+	assert.True(t, loaded.Remove("alice"))
+	assert.False(t, loaded.Remove("alice"))
+}
+
+func TestLoadAccessList_InvalidPermission(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "remote-access.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("clients:\n  - name: x\n    permission: admin\n"), 0600))
+
+	_, err := LoadAccessList(path)
+	assert.Error(t, err)
+}
+
+func TestRemoteAuth_AuthorizeHTTP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "remote-access.yaml")
+	list := &AccessList{}
+	readToken, err := list.AddToken("viewer", PermissionRead)
+	require.NoError(t, err)
+	controlToken, err := list.AddToken("operator", PermissionControl)
+	require.NoError(t, err)
+	require.NoError(t, list.Save(path))
+
+	auth := newRemoteAuth(path)
+	request := func(header string) *http.Request {
+		r := httptest.NewRequest("POST", "/api/jobs/job-1/stop", nil)
+		r.RemoteAddr = "127.0.0.1:51234"
+		if header != "" {
+			r.Header.Set("Authorization", header)
+		}
+		return r
+	}
+
+	assert.NoError(t, auth.authorizeHTTP(request("Bearer "+controlToken)))
+	assert.Error(t, auth.authorizeHTTP(request("Bearer "+readToken)), "read-only tokens may not control jobs")
+	assert.Error(t, auth.authorizeHTTP(request("Bearer "+controlToken+"0")))
+	assert.Error(t, auth.authorizeHTTP(request(controlToken)), "the token must be a bearer token")
+	assert.Error(t, auth.authorizeHTTP(request("")))
+
+	// Remote clients must use TLS so the token is not sent in the clear
+	remote := request("Bearer " + controlToken)
+	remote.RemoteAddr = "203.0.113.7:51234"
+	assert.ErrorContains(t, auth.authorizeHTTP(remote), "only accepted from localhost")
+	remote.TLS = &tls.ConnectionState{}
+	assert.NoError(t, auth.authorizeHTTP(remote))
+	ipv6 := request("Bearer " + controlToken)
+	ipv6.RemoteAddr = "[::1]:51234"
+	assert.NoError(t, auth.authorizeHTTP(ipv6))
+}
+
+func TestRemoteServer_TokenPermissions(t *testing.T) {
+	ca := newTestCA(t)
+	serverCert, serverKey := ca.issue(t, "server", "localhost", x509.ExtKeyUsageServerAuth)
+	cfg := &Config{TLSCert: serverCert, TLSKey: serverKey, AccessFile: ca.path("remote-access.yaml")}
+
+	list := &AccessList{}
+	readToken, err := list.AddToken("viewer", PermissionRead)
+	require.NoError(t, err)
+	require.NoError(t, list.Save(cfg.AccessFile))
+
+	addr := startRemoteServer(t, cfg)
+	ctx := context.Background()
+
+	viewer := dialRemote(t, addr, client.RemoteOptions{CACert: ca.path("ca.pem"), Token: readToken})
+	_, err = viewer.ListJobs(ctx, nil)
+	assert.NoError(t, err, "read-only tokens may list jobs")
+	err = viewer.StopJob(ctx, "job-1", false)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "read-only tokens may not stop jobs")
+
+	anonymous := dialRemote(t, addr, client.RemoteOptions{CACert: ca.path("ca.pem")})
+	_, err = anonymous.ListJobs(ctx, nil)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	forged := dialRemote(t, addr, client.RemoteOptions{CACert: ca.path("ca.pem"), Token: readToken + "0"})
+	_, err = forged.ListJobs(ctx, nil)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Tokens added while the daemon runs are picked up
+	controlToken, err := list.AddToken("operator", PermissionControl)
+	require.NoError(t, err)
+	require.NoError(t, list.Save(cfg.AccessFile))
+	now := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(cfg.AccessFile, now, now))
+
+	operator := dialRemote(t, addr, client.RemoteOptions{CACert: ca.path("ca.pem"), Token: controlToken})
+	err = operator.StopJob(ctx, "job-1", false)
+	assert.Equal(t, codes.NotFound, status.Code(err), "control tokens get past authorization")
+}
+
+func TestRemoteServer_ClientCertificates(t *testing.T) {
+	ca := newTestCA(t)
+	serverCert, serverKey := ca.issue(t, "server", "localhost", x509.ExtKeyUsageServerAuth)
+	laptopCert, laptopKey := ca.issue(t, "laptop", "alice-laptop", x509.ExtKeyUsageClientAuth)
+	ciCert, ciKey := ca.issue(t, "ci", "ci-bot", x509.ExtKeyUsageClientAuth)
+	cfg := &Config{
+		TLSCert:     serverCert,
+		TLSKey:      serverKey,
+		TLSClientCA: ca.path("ca.pem"),
+		AccessFile:  ca.path("remote-access.yaml"),
+	}
+	list := &AccessList{Clients: []RemoteClient{{Name: "ci", CommonName: "ci-bot", Permission: PermissionRead}}}
+	require.NoError(t, list.Save(cfg.AccessFile))
+
+	addr := startRemoteServer(t, cfg)
+	ctx := context.Background()
+
+	laptop := dialRemote(t, addr, client.RemoteOptions{CACert: ca.path("ca.pem"), ClientCert: laptopCert, ClientKey: laptopKey})
+	err := laptop.StopJob(ctx, "job-1", false)
+	assert.Equal(t, codes.NotFound, status.Code(err), "unlisted certificates get control")
+
+	ci := dialRemote(t, addr, client.RemoteOptions{CACert: ca.path("ca.pem"), ClientCert: ciCert, ClientKey: ciKey})
+	_, err = ci.Health(ctx)
+	assert.NoError(t, err)
+	err = ci.StopJob(ctx, "job-1", false)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "listed common names get their permission")
+}
@@ -108,6 +108,15 @@ func (jm *jobManagerImpl) Resume(ctx context.Context, runID string, cfg JobConfi
 		return fmt.Errorf("repository no longer exists: %w", err)
 	}
 
+	// 2b. Jobs run by job runners carry on in their runner, or resume from
+	// their journal; the runner reconciles their worktrees
+	if jm.isSupervised(runID) {
+		if err := jm.reattach(runID); err != nil {
+			return fmt.Errorf("failed to re-attach: %w", err)
+		}
+		return nil
+	}
+
 	// 3. Validate worktrees for in-progress units
 	updatedUnits := validateWorktrees(units)
 
@@ -0,0 +1,211 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/events"
+)
+
+// retentionInterval is how often the event log retention policy is applied
+const retentionInterval = time.Hour
+
+// compactedEventType is the type of the event that replaces a unit's
+// compacted task events. Its payload is a db.CompactionSummary.
+const compactedEventType = "events.compacted"
+
+// compactableEventTypes are the verbose per-task events that compaction
+// folds into a summary. Task failures are kept for post-mortems. Transcript
+// chunks are the bulk of a run's log and only matter while it is watched.
+var compactableEventTypes = []string{
+	string(events.TaskStarted),
+	string(events.TaskClaudeInvoke),
+	string(events.TaskOutput),
+	string(events.TaskClaudeDone),
+	string(events.TaskBackpressure),
+	string(events.TaskValidationOK),
+	string(events.TaskValidationFail),
+	string(events.TaskCommitted),
+	string(events.TaskCompleted),
+	string(events.TaskRetry),
+}
+
+// RetentionPolicy controls how long finished runs and their event logs are
+// kept. Zero values disable the corresponding rule.
+type RetentionPolicy struct {
+	// MaxAge is how long after it finished a run is deleted with its units
+	// and events.
+	MaxAge time.Duration
+
+	// MaxRuns is how many finished runs are kept; older ones are deleted.
+	MaxRuns int
+
+	// CompactAfter is how long after it finished a run's task events are
+	// replaced by per-unit summaries.
+	CompactAfter time.Duration
+}
+
+// RetentionReport summarizes a single retention pass.
+type RetentionReport struct {
+	DeletedRuns     []string
+	CompactedRuns   int
+	CompactedEvents int
+}
+
+// EventRetention deletes and compacts the event logs of finished runs.
+type EventRetention struct {
+	db       *db.DB
+	policy   RetentionPolicy
+	isActive func(runID string) bool
+	now      func() time.Time
+
+	mu sync.Mutex // serializes passes
+}
+
+// NewEventRetention creates a retention pass runner. isActive reports
+// whether a run is executing in this daemon; active runs are never touched.
+func NewEventRetention(database *db.DB, policy RetentionPolicy, isActive func(runID string) bool) *EventRetention {
+	return &EventRetention{
+		db:       database,
+		policy:   policy,
+		isActive: isActive,
+		now:      time.Now,
+	}
+}
+
+// Enabled reports whether the policy has any rule to apply.
+func (r *EventRetention) Enabled() bool {
+	return r.policy.MaxAge > 0 || r.policy.MaxRuns > 0 || r.policy.CompactAfter > 0
+}
+
+// Run applies the policy every interval until ctx is cancelled.
+func (r *EventRetention) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := r.Apply()
+			if err != nil {
+				log.Printf("Event log retention failed: %v", err)
+				continue
+			}
+			if len(report.DeletedRuns) > 0 || report.CompactedEvents > 0 {
+				log.Printf("Event log retention deleted %d run(s) and compacted %d event(s) in %d run(s)",
+					len(report.DeletedRuns), report.CompactedEvents, report.CompactedRuns)
+			}
+		}
+	}
+}
+
+// Apply performs a single retention pass: it deletes finished runs past
+// MaxAge or beyond the newest MaxRuns, then compacts the task events of
+// the remaining runs finished longer than CompactAfter ago. Runs whose
+// feature PR is still watched are kept whole. Imported runs count as
+// finished when they were imported.
+func (r *EventRetention) Apply() (*RetentionReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	runs, err := r.db.ListRuns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+
+	// The feature watcher closes out an open PR's feature from its run
+	watched := make(map[string]bool)
+	prs, err := r.db.ListFeaturePRsByStatus(db.FeaturePRStatusOpen)
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range prs {
+		watched[pr.RunID] = true
+	}
+	imported, err := r.db.ListRunImports()
+	if err != nil {
+		return nil, err
+	}
+
+	now := r.now()
+	report := &RetentionReport{}
+
+	type finishedRun struct {
+		id         string
+		finishedAt time.Time
+	}
+	var finished []finishedRun
+	for _, run := range runs {
+		if watched[run.ID] {
+			continue
+		}
+		if at, ok := r.finishedAt(run); ok {
+			if importedAt, ok := imported[run.ID]; ok && importedAt.After(at) {
+				at = importedAt
+			}
+			finished = append(finished, finishedRun{run.ID, at})
+		}
+	}
+	// Newest first, so the runs past MaxRuns are at the end
+	sort.SliceStable(finished, func(i, j int) bool {
+		return finished[i].finishedAt.After(finished[j].finishedAt)
+	})
+
+	var kept []finishedRun
+	for i, run := range finished {
+		expired := r.policy.MaxAge > 0 && now.Sub(run.finishedAt) > r.policy.MaxAge
+		excess := r.policy.MaxRuns > 0 && i >= r.policy.MaxRuns
+		if expired || excess {
+			report.DeletedRuns = append(report.DeletedRuns, run.id)
+		} else {
+			kept = append(kept, run)
+		}
+	}
+	if err := r.db.DeleteRuns(report.DeletedRuns); err != nil {
+		return nil, err
+	}
+
+	if r.policy.CompactAfter > 0 {
+		for _, run := range kept {
+			if now.Sub(run.finishedAt) <= r.policy.CompactAfter {
+				continue
+			}
+			n, err := r.db.CompactEvents(run.id, compactableEventTypes, compactedEventType)
+			if err != nil {
+				return report, fmt.Errorf("failed to compact run %s: %w", run.id, err)
+			}
+			if n > 0 {
+				report.CompactedRuns++
+				report.CompactedEvents += n
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// finishedAt returns when a run finished, or false if it has not
+func (r *EventRetention) finishedAt(run *db.Run) (time.Time, bool) {
+	switch run.Status {
+	case db.RunStatusCompleted, db.RunStatusFailed, db.RunStatusCancelled:
+	default:
+		return time.Time{}, false
+	}
+	if r.isActive != nil && r.isActive(run.ID) {
+		return time.Time{}, false
+	}
+	if run.CompletedAt != nil {
+		return *run.CompletedAt, true
+	}
+	if run.StartedAt != nil {
+		return *run.StartedAt, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,163 @@
+package daemon
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRetention(database *db.DB, policy RetentionPolicy, active map[string]bool, now time.Time) *EventRetention {
+	r := NewEventRetention(database, policy, func(runID string) bool { return active[runID] })
+	r.now = func() time.Time { return now }
+	return r
+}
+
+func TestEventRetention_DeletesExpiredRuns(t *testing.T) {
+	database := setupTestDB(t)
+	now := time.Now()
+	old := createGCRun(t, database, "/repo", db.RunStatusCompleted, now.Add(-48*time.Hour))
+	recent := createGCRun(t, database, "/repo", db.RunStatusFailed, now.Add(-time.Hour))
+	running := createGCRun(t, database, "/repo", db.RunStatusRunning, now.Add(-72*time.Hour))
+	require.NoError(t, database.AppendEvent(old.ID, "orch.started", nil, nil))
+
+	r := newTestRetention(database, RetentionPolicy{MaxAge: 24 * time.Hour}, nil, now)
+	report, err := r.Apply()
+	require.NoError(t, err)
+	assert.Equal(t, []string{old.ID}, report.DeletedRuns)
+
+	for id, kept := range map[string]bool{old.ID: false, recent.ID: true, running.ID: true} {
+		run, err := database.GetRun(id)
+		require.NoError(t, err)
+		assert.Equal(t, kept, run != nil, "run %s", id)
+	}
+	evts, err := database.ListEvents(old.ID)
+	require.NoError(t, err)
+	assert.Empty(t, evts)
+}
+
+func TestEventRetention_KeepsNewestRuns(t *testing.T) {
+	database := setupTestDB(t)
+	now := time.Now()
+	oldest := createGCRun(t, database, "/repo", db.RunStatusCompleted, now.Add(-3*time.Hour))
+	middle := createGCRun(t, database, "/repo", db.RunStatusCancelled, now.Add(-2*time.Hour))
+	newest := createGCRun(t, database, "/repo", db.RunStatusCompleted, now.Add(-time.Hour))
+	// Active runs never count against the limit or get deleted
+	active := createGCRun(t, database, "/repo", db.RunStatusCompleted, now.Add(-4*time.Hour))
+
+	r := newTestRetention(database, RetentionPolicy{MaxRuns: 2}, map[string]bool{active.ID: true}, now)
+	report, err := r.Apply()
+	require.NoError(t, err)
+	assert.Equal(t, []string{oldest.ID}, report.DeletedRuns)
+
+	for _, id := range []string{middle.ID, newest.ID, active.ID} {
+		run, err := database.GetRun(id)
+		require.NoError(t, err)
+		assert.NotNil(t, run, "run %s", id)
+	}
+}
+
+func TestEventRetention_KeepsWatchedAndRecentlyImportedRuns(t *testing.T) {
+	database := setupTestDB(t)
+	now := time.Now()
+	watched := createGCRun(t, database, "/repo", db.RunStatusCompleted, now.Add(-48*time.Hour))
+	require.NoError(t, database.RecordFeaturePR(&db.FeaturePR{
+		RunID:         watched.ID,
+		RepoPath:      "/repo",
+		FeatureBranch: watched.FeatureBranch,
+		TargetBranch:  "main",
+		TasksDir:      "specs/tasks",
+		PRNumber:      1,
+		PRURL:         "https://github.com/local/app/pull/1",
+	}))
+
+	// Finished long ago on another daemon, imported just now
+	finished := now.Add(-48 * time.Hour)
+	imported := &db.Run{
+		ID:            ulid.Make().String(),
+		FeatureBranch: "feature/imported",
+		RepoPath:      "/repo",
+		TargetBranch:  "main",
+		TasksDir:      "specs/tasks",
+		Parallelism:   1,
+		Status:        db.RunStatusCompleted,
+		StartedAt:     &finished,
+		CompletedAt:   &finished,
+	}
+	require.NoError(t, database.ImportRun(imported, nil, nil))
+
+	r := newTestRetention(database, RetentionPolicy{MaxAge: 24 * time.Hour}, nil, now)
+	report, err := r.Apply()
+	require.NoError(t, err)
+	assert.Empty(t, report.DeletedRuns)
+
+	// The imported run ages from its import
+	r = newTestRetention(database, RetentionPolicy{MaxAge: 24 * time.Hour}, nil, now.Add(25*time.Hour))
+	report, err = r.Apply()
+	require.NoError(t, err)
+	assert.Equal(t, []string{imported.ID}, report.DeletedRuns)
+
+	run, err := database.GetRun(watched.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, run)
+}
+
+func TestEventRetention_CompactsTaskEvents(t *testing.T) {
+	database := setupTestDB(t)
+	now := time.Now()
+	run := createGCRun(t, database, "/repo", db.RunStatusCompleted, now.Add(-48*time.Hour))
+	fresh := createGCRun(t, database, "/repo", db.RunStatusCompleted, now.Add(-time.Hour))
+
+	unit := "unit-a"
+	for _, runID := range []string{run.ID, fresh.ID} {
+		for _, typ := range []events.EventType{
+			events.UnitStarted, events.TaskStarted, events.TaskClaudeInvoke, events.TaskOutput,
+			events.TaskOutput, events.TaskClaudeDone,
+			events.TaskFailed, events.TaskStarted, events.TaskCompleted, events.UnitCompleted,
+		} {
+			e := events.NewEvent(typ, unit)
+			require.NoError(t, database.AppendEvent(runID, string(typ), &unit, e))
+		}
+	}
+
+	r := newTestRetention(database, RetentionPolicy{CompactAfter: 24 * time.Hour}, nil, now)
+	report, err := r.Apply()
+	require.NoError(t, err)
+	assert.Empty(t, report.DeletedRuns)
+	assert.Equal(t, 1, report.CompactedRuns)
+	assert.Equal(t, 7, report.CompactedEvents)
+
+	evts, err := database.ListEvents(run.ID)
+	require.NoError(t, err)
+	var types []string
+	for _, e := range evts {
+		types = append(types, e.EventType)
+	}
+	assert.Equal(t, []string{"unit.started", compactedEventType, "task.failed", "unit.completed"}, types)
+
+	var summary db.CompactionSummary
+	require.NoError(t, json.Unmarshal([]byte(*evts[1].PayloadJSON), &summary))
+	assert.Equal(t, 7, summary.Events)
+	assert.Equal(t, 2, summary.Counts["task.output"])
+	assert.Equal(t, 2, summary.Counts["task.started"])
+
+	// Replaying a compacted log yields the summary as an event
+	replayed := eventFromRecord(evts[1])
+	assert.Equal(t, events.EventType(compactedEventType), replayed.Type)
+	assert.Equal(t, unit, replayed.Unit)
+
+	// Runs that finished recently are left alone
+	evts, err = database.ListEvents(fresh.ID)
+	require.NoError(t, err)
+	assert.Len(t, evts, 10)
+
+	// A second pass has nothing left to compact
+	report, err = r.Apply()
+	require.NoError(t, err)
+	assert.Zero(t, report.CompactedEvents)
+}
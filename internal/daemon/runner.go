@@ -0,0 +1,333 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/events"
+)
+
+// Files in a job runner's directory, <JobsDir>/<job-id>
+const (
+	runnerSpecFile    = "job.json"      // JobSpec, written by the daemon
+	runnerJournalFile = "journal.jsonl" // The job's journal
+	runnerSocketFile  = "runner.sock"   // Attach socket
+	runnerPIDFile     = "runner.pid"    // Keeps a second runner from starting
+	runnerResultFile  = "result.json"   // RunnerResult, once the job ends
+	runnerLogFile     = "runner.log"    // The runner's output
+)
+
+// runnerProtocol is the version of the attach protocol. Runners outlive
+// daemon upgrades, so a daemon must accept runners of older versions.
+const runnerProtocol = 1
+
+// runnerLingerTimeout bounds how long a finished runner waits for attached
+// daemons to read the rest of its journal
+const runnerLingerTimeout = 10 * time.Second
+
+// JobSpec is the job a job runner runs.
+type JobSpec struct {
+	ID     string    `json:"id"`
+	Config JobConfig `json:"config"`
+}
+
+// RunnerResult is how a job runner's job ended.
+type RunnerResult struct {
+	Status db.RunStatus `json:"status"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// runnerMessage is a line a job runner sends to an attached daemon: first
+// its protocol version, then journal records, then the job's result
+type runnerMessage struct {
+	Protocol int            `json:"protocol,omitempty"`
+	Record   *JournalRecord `json:"record,omitempty"`
+	Done     *RunnerResult  `json:"done,omitempty"`
+}
+
+// runnerRequest is a line an attached daemon sends to a job runner: first
+// the journal record to relay from, then cancellations and pauses
+type runnerRequest struct {
+	From   int   `json:"from,omitempty"`
+	Cancel bool  `json:"cancel,omitempty"`
+	Pause  *bool `json:"pause,omitempty"`
+}
+
+// RunJobRunner runs the job in dir until it ends, outside the daemon. The
+// daemon attaches to it over the directory's socket to relay its events,
+// and can detach and re-attach, e.g. across a restart, without
+// interrupting it. Every event is appended to the job's journal first; a
+// runner started on a journal of an interrupted attempt resumes from it.
+func RunJobRunner(ctx context.Context, dir string) error {
+	// 1. Read the job
+	data, err := os.ReadFile(filepath.Join(dir, runnerSpecFile))
+	if err != nil {
+		return fmt.Errorf("failed to read job spec: %w", err)
+	}
+	var spec JobSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return fmt.Errorf("failed to parse job spec: %w", err)
+	}
+
+	// 2. Only one runner per job
+	pidFile := NewPIDFile(filepath.Join(dir, runnerPIDFile))
+	if err := pidFile.Acquire(); err != nil {
+		return fmt.Errorf("job %s: %w", spec.ID, err)
+	}
+	defer pidFile.Release()
+
+	// 3. Open the journal, recovering what an earlier attempt finished
+	journal, err := OpenJournal(filepath.Join(dir, runnerJournalFile))
+	if err != nil {
+		return err
+	}
+	defer journal.Close()
+	recovery := journal.Recovery()
+	if n := journal.Len(); n > 0 {
+		log.Printf("Resuming job %s from %d journal records", spec.ID, n)
+	}
+
+	// 4. Accept daemons attaching
+	socketPath := filepath.Join(dir, runnerSocketFile)
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+	defer os.Remove(socketPath)
+	defer listener.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r := &jobRunner{journal: journal, cancel: cancel, done: make(chan struct{}), served: make(chan struct{})}
+	go r.serve(listener)
+
+	// 5. Run the job, journaling each event as it is emitted
+	bus := events.NewBus(1000)
+	bus.SetJournal(func(e events.Event) {
+		if err := journal.Append(e); err != nil {
+			log.Printf("Failed to journal %s: %v", e.Type, err)
+		}
+	})
+
+	var result RunnerResult
+	orch, err := newJobOrchestrator(spec.Config, bus, recovery)
+	if err == nil {
+		r.setOrchestrator(orch)
+		_, err = orch.Run(ctx)
+	}
+	switch {
+	case err == nil:
+		result.Status = db.RunStatusCompleted
+	case errors.Is(err, context.Canceled):
+		result.Status = db.RunStatusCancelled
+	default:
+		result.Status = db.RunStatusFailed
+		result.Error = err.Error()
+	}
+	bus.Close()
+
+	// 6. Record the result after the journal, so a daemon that finds it
+	// has the whole journal to relay
+	if err := journal.Close(); err != nil {
+		log.Printf("Failed to close journal: %v", err)
+	}
+	if err := writeRunnerResult(dir, result); err != nil {
+		return err
+	}
+	r.finish(result)
+
+	// 7. Give attached daemons time to read the end of the job
+	listener.Close()
+	<-r.served
+	drained := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(runnerLingerTimeout):
+		log.Printf("Timed out waiting for the daemon to read the end of job %s", spec.ID)
+	}
+	return nil
+}
+
+// jobRunner serves a running job's journal to attached daemons
+type jobRunner struct {
+	journal *Journal
+	cancel  context.CancelFunc
+
+	result RunnerResult
+	done   chan struct{} // closed once result is set
+	served chan struct{} // closed once serve stops accepting
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	orch   orchestratorRunner // nil until the job starts
+	paused bool
+}
+
+// serve accepts daemons until the listener closes
+func (r *jobRunner) serve(listener net.Listener) {
+	defer close(r.served)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			defer conn.Close()
+			if err := r.attach(conn); err != nil {
+				log.Printf("Daemon detached: %v", err)
+			}
+		}()
+	}
+}
+
+// setOrchestrator records the job's orchestrator, pausing it if a daemon
+// asked before it started
+func (r *jobRunner) setOrchestrator(orch orchestratorRunner) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.orch = orch
+	if p, ok := orch.(pausableRunner); ok && r.paused {
+		p.SetPaused(true)
+	}
+}
+
+// setPaused pauses or resumes the job
+func (r *jobRunner) setPaused(paused bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paused = paused
+	if p, ok := r.orch.(pausableRunner); ok {
+		p.SetPaused(paused)
+	}
+}
+
+// finish records the job's result and tells attached daemons
+func (r *jobRunner) finish(result RunnerResult) {
+	r.result = result
+	close(r.done)
+}
+
+// attach relays the journal to a daemon from the record it asks for, then
+// live records, then the job's result
+func (r *jobRunner) attach(conn net.Conn) error {
+	enc := json.NewEncoder(conn)
+	if err := enc.Encode(runnerMessage{Protocol: runnerProtocol}); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	var req runnerRequest
+	if err := dec.Decode(&req); err != nil {
+		return fmt.Errorf("failed to read attach request: %w", err)
+	}
+
+	// Later requests cancel or pause the job; a closed connection is a
+	// detach and leaves it running
+	go func() {
+		for {
+			var req runnerRequest
+			if err := dec.Decode(&req); err != nil {
+				return
+			}
+			if req.Pause != nil {
+				r.setPaused(*req.Pause)
+			}
+			if req.Cancel {
+				r.cancel()
+			}
+		}
+	}()
+
+	next := req.From
+	send := func(records []JournalRecord) error {
+		for i := range records {
+			if err := enc.Encode(runnerMessage{Record: &records[i]}); err != nil {
+				return err
+			}
+			next = records[i].Seq + 1
+		}
+		return nil
+	}
+
+	for {
+		records, changed, err := r.journal.Since(next)
+		if err != nil {
+			return err
+		}
+		if err := send(records); err != nil {
+			return err
+		}
+		if len(records) > 0 {
+			continue
+		}
+
+		select {
+		case <-changed:
+		case <-r.done:
+			// Relay the rest, which may take several batches
+			for {
+				records, _, err := r.journal.Since(next)
+				if err != nil {
+					return err
+				}
+				if len(records) == 0 {
+					break
+				}
+				if err := send(records); err != nil {
+					return err
+				}
+			}
+			return enc.Encode(runnerMessage{Done: &r.result})
+		}
+	}
+}
+
+// writeRunnerResult atomically records how a runner's job ended
+func writeRunnerResult(dir string, result RunnerResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+	tmp := filepath.Join(dir, runnerResultFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, runnerResultFile)); err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+	return nil
+}
+
+// readRunnerResult returns how a runner's job ended, or an error wrapping
+// os.ErrNotExist if it has not
+func readRunnerResult(dir string) (*RunnerResult, error) {
+	data, err := os.ReadFile(filepath.Join(dir, runnerResultFile))
+	if err != nil {
+		return nil, err
+	}
+	var result RunnerResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse result: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,390 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/orchestrator"
+)
+
+// errDetached is returned by a supervised job's Run when the daemon
+// detaches from it, leaving its runner running
+var errDetached = errors.New("detached from job runner")
+
+// errRunnerFinished is returned when dialing a job runner that ended its
+// job before the daemon attached
+var errRunnerFinished = errors.New("job runner already finished")
+
+// runnerStartTimeout bounds how long a new job runner may take to accept
+// the daemon
+const runnerStartTimeout = 10 * time.Second
+
+// relayBacklog bounds the relayed events waiting on a job's bus, well
+// within its buffer
+const relayBacklog = 100
+
+// maxRunnerRestarts bounds how often a job's runner is restarted after
+// exiting without a result
+const maxRunnerRestarts = 3
+
+// spawnRunner starts a job runner for the job in dir. The runner is the
+// daemon's executable in a session of its own, so it outlives the daemon.
+var spawnRunner = func(dir string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+	exe, err = filepath.EvalSymlinks(exe)
+	if err != nil {
+		return fmt.Errorf("failed to resolve executable path: %w", err)
+	}
+
+	logFile, err := os.OpenFile(filepath.Join(dir, runnerLogFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open runner log: %w", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(exe, "daemon", "run-job", dir)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start job runner: %w", err)
+	}
+
+	// Reap the runner if it exits while this daemon runs
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// superviseJob prepares a new job to run in a job runner
+func (jm *jobManagerImpl) superviseJob(jobID string, cfg JobConfig, bus *events.Bus) (orchestratorRunner, error) {
+	// Fail a misconfigured repository now rather than in the runner
+	if _, err := config.LoadConfig(cfg.RepoPath); err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dir := filepath.Join(jm.jobsDir, jobID)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create job directory: %w", err)
+	}
+	data, err := json.Marshal(JobSpec{ID: jobID, Config: cfg})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job spec: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, runnerSpecFile), data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write job spec: %w", err)
+	}
+
+	return newSupervisedJob(dir, bus, 1), nil
+}
+
+// reattach re-attaches to the runner of a job an earlier daemon started,
+// relaying the journal records it has not recorded yet. A runner that
+// exited without finishing the job is restarted on the journal.
+func (jm *jobManagerImpl) reattach(runID string) error {
+	dir := filepath.Join(jm.jobsDir, runID)
+	data, err := os.ReadFile(filepath.Join(dir, runnerSpecFile))
+	if err != nil {
+		return fmt.Errorf("failed to read job spec: %w", err)
+	}
+	var spec JobSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return fmt.Errorf("failed to parse job spec: %w", err)
+	}
+
+	// Events are recorded in journal order, one per record
+	from, err := jm.db.GetNextSequence(runID)
+	if err != nil {
+		return err
+	}
+
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+
+	if _, ok := jm.jobs[runID]; ok {
+		return fmt.Errorf("job %s is already attached", runID)
+	}
+
+	bus := events.NewBus(1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	jm.track(ctx, cancel, runID, spec.Config, bus, newSupervisedJob(dir, bus, from))
+	return nil
+}
+
+// isSupervised reports whether a job runner was started for runID
+func (jm *jobManagerImpl) isSupervised(runID string) bool {
+	if jm.jobsDir == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(jm.jobsDir, runID, runnerSpecFile))
+	return err == nil
+}
+
+// DetachAll detaches from every job running in a job runner, leaving the
+// runners running for the next daemon to re-attach. Returns their IDs.
+func (jm *jobManagerImpl) DetachAll() []string {
+	jm.mu.RLock()
+	defer jm.mu.RUnlock()
+
+	var ids []string
+	for id, job := range jm.jobs {
+		if sj, ok := job.Orchestrator.(*supervisedJob); ok {
+			sj.Detach()
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// supervisedJob runs a job in a job runner and relays the runner's
+// journal to the job's event bus. It is the orchestratorRunner of jobs
+// when the daemon supervises them.
+type supervisedJob struct {
+	dir  string
+	bus  *events.Bus
+	from int // First journal record not yet relayed
+
+	detach     chan struct{}
+	detachOnce sync.Once
+
+	mu     sync.Mutex
+	enc    *json.Encoder // Requests to the attached runner; nil when detached
+	paused bool
+}
+
+// newSupervisedJob creates the runner of the job in dir, relaying its
+// journal from record from
+func newSupervisedJob(dir string, bus *events.Bus, from int) *supervisedJob {
+	return &supervisedJob{dir: dir, bus: bus, from: from, detach: make(chan struct{})}
+}
+
+// Detach stops relaying the job and makes Run return errDetached. The
+// runner carries on.
+func (s *supervisedJob) Detach() {
+	s.detachOnce.Do(func() { close(s.detach) })
+}
+
+// SetPaused pauses or resumes the job in its runner. A runner attached
+// later, e.g. one restarted, is told too.
+func (s *supervisedJob) SetPaused(paused bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused = paused
+	if s.enc != nil {
+		// A failed request means the runner went away; the next is told on attach
+		_ = s.enc.Encode(runnerRequest{Pause: &paused})
+	}
+}
+
+// request sends req to the attached runner
+func (s *supervisedJob) request(req runnerRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.enc == nil {
+		return errors.New("not attached")
+	}
+	return s.enc.Encode(req)
+}
+
+// Run attaches to the job's runner, starting it if none is running, and
+// relays its journal until the job ends. Cancelling ctx cancels the job.
+func (s *supervisedJob) Run(ctx context.Context) (*orchestrator.Result, error) {
+	cancelled := false
+	for restarts := 0; ; restarts++ {
+		conn, err := s.dial()
+		if err != nil {
+			// The runner is gone: it either finished, with its result
+			// recorded, or must be (re)started on its journal
+			if result, err := readRunnerResult(s.dir); err == nil {
+				return nil, s.replay(result)
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			if cancelled {
+				return nil, context.Canceled
+			}
+			if restarts > maxRunnerRestarts {
+				return nil, fmt.Errorf("job runner exited %d times without finishing the job; see %s", restarts, filepath.Join(s.dir, runnerLogFile))
+			}
+			if restarts > 0 {
+				log.Printf("Restarting job runner in %s", s.dir)
+			}
+			if err := spawnRunner(s.dir); err != nil {
+				return nil, err
+			}
+			conn, err = s.dialWithin(runnerStartTimeout)
+			if errors.Is(err, errRunnerFinished) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("job runner did not start: %w", err)
+			}
+		}
+
+		result, err := s.relay(ctx, conn, &cancelled)
+		conn.Close()
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			return nil, result.err()
+		}
+		// The connection dropped before the job ended; go round to find out why
+	}
+}
+
+// relay relays journal records from conn until the job ends, returning its
+// result. A nil result and error means the connection dropped.
+func (s *supervisedJob) relay(ctx context.Context, conn net.Conn, cancelled *bool) (*RunnerResult, error) {
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	var hello runnerMessage
+	if err := dec.Decode(&hello); err != nil {
+		return nil, nil
+	}
+	if hello.Protocol > runnerProtocol {
+		return nil, fmt.Errorf("job runner speaks protocol %d; this daemon supports up to %d", hello.Protocol, runnerProtocol)
+	}
+
+	enc := json.NewEncoder(conn)
+	if err := enc.Encode(runnerRequest{From: s.from}); err != nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	s.enc = enc
+	if s.paused {
+		paused := true
+		_ = enc.Encode(runnerRequest{Pause: &paused})
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.enc = nil
+		s.mu.Unlock()
+	}()
+	if *cancelled {
+		if err := s.request(runnerRequest{Cancel: true}); err != nil {
+			return nil, nil
+		}
+	}
+
+	msgs := make(chan runnerMessage)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		defer close(msgs)
+		for {
+			var msg runnerMessage
+			if err := dec.Decode(&msg); err != nil {
+				return
+			}
+			select {
+			case msgs <- msg:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	ctxDone := ctx.Done()
+	for {
+		select {
+		case msg, ok := <-msgs:
+			if !ok {
+				return nil, nil
+			}
+			if msg.Record != nil {
+				s.emit(*msg.Record)
+			}
+			if msg.Done != nil {
+				return msg.Done, nil
+			}
+		case <-ctxDone:
+			// Keep relaying until the runner reports the cancellation
+			*cancelled = true
+			ctxDone = nil
+			if err := s.request(runnerRequest{Cancel: true}); err != nil {
+				return nil, nil
+			}
+		case <-s.detach:
+			return nil, errDetached
+		}
+	}
+}
+
+// replay relays the rest of a finished runner's journal from disk
+func (s *supervisedJob) replay(result *RunnerResult) error {
+	records, err := ReadJournal(filepath.Join(s.dir, runnerJournalFile))
+	if err != nil {
+		return err
+	}
+	for _, rec := range records {
+		s.emit(rec)
+	}
+	return result.err()
+}
+
+// emit relays a journal record not relayed yet. The bus drops events when
+// its buffer is full, which would put the job's recorded events out of step
+// with its journal, so a long journal is relayed at the pace of the bus.
+func (s *supervisedJob) emit(rec JournalRecord) {
+	if rec.Seq < s.from {
+		return
+	}
+	for s.bus.Len() >= relayBacklog {
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.bus.EmitRaw(rec.Event)
+	s.from = rec.Seq + 1
+}
+
+// dial connects to the job's runner, if it is listening
+func (s *supervisedJob) dial() (net.Conn, error) {
+	return net.Dial("unix", filepath.Join(s.dir, runnerSocketFile))
+}
+
+// dialWithin connects to the job's runner, waiting up to timeout for it to
+// listen. Returns errRunnerFinished if the runner ended the job first.
+func (s *supervisedJob) dialWithin(timeout time.Duration) (net.Conn, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		conn, err := s.dial()
+		if err == nil || time.Now().After(deadline) {
+			return conn, err
+		}
+		if _, err := os.Stat(filepath.Join(s.dir, runnerResultFile)); err == nil {
+			return nil, errRunnerFinished
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// err returns the error an orchestrator would have returned for the result
+func (r *RunnerResult) err() error {
+	switch r.Status {
+	case db.RunStatusCompleted:
+		return nil
+	case db.RunStatusCancelled:
+		return context.Canceled
+	default:
+		if r.Error == "" {
+			return errors.New("job runner failed")
+		}
+		return errors.New(r.Error)
+	}
+}
@@ -0,0 +1,321 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/orchestrator"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedOrchestrator emits its events on the job's bus, waiting on step
+// before each one after the first
+type scriptedOrchestrator struct {
+	bus    *events.Bus
+	events []events.Event
+	step   chan struct{}
+}
+
+func (o *scriptedOrchestrator) Run(ctx context.Context) (*orchestrator.Result, error) {
+	for i, e := range o.events {
+		if i > 0 && o.step != nil {
+			select {
+			case <-o.step:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		o.bus.Emit(e)
+	}
+	return &orchestrator.Result{}, nil
+}
+
+// pausableOrchestrator runs until cancelled, reporting each pause and resume
+type pausableOrchestrator struct {
+	pauses chan bool
+}
+
+func (o *pausableOrchestrator) Run(ctx context.Context) (*orchestrator.Result, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (o *pausableOrchestrator) SetPaused(paused bool) {
+	o.pauses <- paused
+}
+
+// useOrchestrator makes jobs run fn's orchestrator for the rest of the test
+func useOrchestrator(t *testing.T, fn func(cfg orchestrator.Config, deps orchestrator.Dependencies) orchestratorRunner) {
+	t.Helper()
+	prev := newOrchestrator
+	newOrchestrator = fn
+	t.Cleanup(func() { newOrchestrator = prev })
+}
+
+// runnersInProcess runs job runners in goroutines of the test, counting
+// how many are started
+func runnersInProcess(t *testing.T) *int {
+	t.Helper()
+	var mu sync.Mutex
+	spawned := 0
+	prev := spawnRunner
+	spawnRunner = func(dir string) error {
+		mu.Lock()
+		spawned++
+		mu.Unlock()
+		go func() {
+			if err := RunJobRunner(context.Background(), dir); err != nil {
+				log.Printf("job runner: %v", err)
+			}
+		}()
+		return nil
+	}
+	t.Cleanup(func() { spawnRunner = prev })
+	return &spawned
+}
+
+// setupSupervisedJobManager returns a job manager running jobs in job
+// runners, with a jobs directory short enough for their sockets
+func setupSupervisedJobManager(t *testing.T, database *db.DB) *jobManagerImpl {
+	t.Helper()
+	jobsDir, err := os.MkdirTemp("", "choo-jobs")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(jobsDir) })
+
+	jm := NewJobManager(database, 10)
+	jm.jobsDir = jobsDir
+	return jm
+}
+
+// setupSupervisedRepo returns a test repository that resumes as a git repository
+func setupSupervisedRepo(t *testing.T) string {
+	t.Helper()
+	repoPath := setupTestRepo(t)
+	require.NoError(t, os.Mkdir(filepath.Join(repoPath, ".git"), 0755))
+	return repoPath
+}
+
+// waitForRunStatus waits for a run to reach status
+func waitForRunStatus(t *testing.T, database *db.DB, runID string, status db.RunStatus) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		run, err := database.GetRun(runID)
+		return err == nil && run != nil && run.Status == status
+	}, 5*time.Second, 10*time.Millisecond, "run did not become %s", status)
+}
+
+// eventTypes returns the types of a run's recorded events, in order
+func eventTypes(t *testing.T, database *db.DB, runID string) []string {
+	t.Helper()
+	records, err := database.ListEvents(runID)
+	require.NoError(t, err)
+	types := make([]string, len(records))
+	for i, r := range records {
+		types[i] = r.EventType
+	}
+	return types
+}
+
+// waitForEventTypes waits for a run's recorded events to be of types.
+// Events are recorded asynchronously, so they may trail the run's status.
+func waitForEventTypes(t *testing.T, database *db.DB, runID string, types ...string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(types, eventTypes(t, database, runID))
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, types, eventTypes(t, database, runID))
+}
+
+func TestSupervisedJob_RunsInRunner(t *testing.T) {
+	database := setupTestDB(t)
+	jm := setupSupervisedJobManager(t, database)
+	spawned := runnersInProcess(t)
+	useOrchestrator(t, func(cfg orchestrator.Config, deps orchestrator.Dependencies) orchestratorRunner {
+		return &scriptedOrchestrator{bus: deps.Bus, events: []events.Event{
+			events.NewEvent(events.UnitStarted, "a"),
+			events.NewEvent(events.TaskCommitted, "a").WithTask(1),
+			events.NewEvent(events.UnitCompleted, "a"),
+		}}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	jobID, err := jm.Start(ctx, cancel, validJobConfigWithRepo(setupTestRepo(t)))
+	require.NoError(t, err)
+
+	waitForRunStatus(t, database, jobID, db.RunStatusCompleted)
+	assert.Equal(t, 1, *spawned)
+	waitForEventTypes(t, database, jobID, "unit.started", "task.committed", "unit.completed")
+
+	// The runner journaled every event and recorded the result
+	dir := filepath.Join(jm.jobsDir, jobID)
+	records, err := ReadJournal(filepath.Join(dir, runnerJournalFile))
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+	result, err := readRunnerResult(dir)
+	require.NoError(t, err)
+	assert.Equal(t, db.RunStatusCompleted, result.Status)
+}
+
+func TestSupervisedJob_Cancel(t *testing.T) {
+	database := setupTestDB(t)
+	jm := setupSupervisedJobManager(t, database)
+	runnersInProcess(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	jobID, err := jm.Start(ctx, cancel, validJobConfigWithRepo(setupTestRepo(t)))
+	require.NoError(t, err)
+
+	// The runner cancels the job when the daemon does
+	require.NoError(t, jm.Stop(jobID))
+	require.Eventually(t, func() bool {
+		result, err := readRunnerResult(filepath.Join(jm.jobsDir, jobID))
+		return err == nil && result.Status == db.RunStatusCancelled
+	}, 5*time.Second, 10*time.Millisecond)
+	waitForRunStatus(t, database, jobID, db.RunStatusCancelled)
+}
+
+func TestSupervisedJob_Pause(t *testing.T) {
+	database := setupTestDB(t)
+	jm := setupSupervisedJobManager(t, database)
+	runnersInProcess(t)
+	orch := &pausableOrchestrator{pauses: make(chan bool, 2)}
+	useOrchestrator(t, func(cfg orchestrator.Config, deps orchestrator.Dependencies) orchestratorRunner {
+		return orch
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	jobID, err := jm.Start(ctx, cancel, validJobConfigWithRepo(setupTestRepo(t)))
+	require.NoError(t, err)
+
+	// The runner pauses and resumes the job for the daemon, even when
+	// asked before it attached
+	for _, want := range []bool{true, false} {
+		require.NoError(t, jm.Pause(jobID, want))
+		assert.Equal(t, want, jm.IsPaused(jobID))
+		select {
+		case got := <-orch.pauses:
+			assert.Equal(t, want, got)
+		case <-time.After(5 * time.Second):
+			t.Fatal("runner did not pass on the pause")
+		}
+	}
+
+	require.NoError(t, jm.Stop(jobID))
+	waitForRunStatus(t, database, jobID, db.RunStatusCancelled)
+}
+
+func TestSupervisedJob_DetachAndReattach(t *testing.T) {
+	database := setupTestDB(t)
+	jm := setupSupervisedJobManager(t, database)
+	spawned := runnersInProcess(t)
+	step := make(chan struct{})
+	useOrchestrator(t, func(cfg orchestrator.Config, deps orchestrator.Dependencies) orchestratorRunner {
+		return &scriptedOrchestrator{bus: deps.Bus, step: step, events: []events.Event{
+			events.NewEvent(events.UnitStarted, "a"),
+			events.NewEvent(events.TaskCommitted, "a").WithTask(1),
+			events.NewEvent(events.UnitCompleted, "a"),
+		}}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	jobID, err := jm.Start(ctx, cancel, validJobConfigWithRepo(setupSupervisedRepo(t)))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(eventTypes(t, database, jobID)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The daemon goes away; the job carries on without it
+	assert.Equal(t, []string{jobID}, jm.DetachAll())
+	require.Eventually(t, func() bool { return !jm.IsActive(jobID) }, 5*time.Second, 10*time.Millisecond)
+	step <- struct{}{}
+
+	run, err := database.GetRun(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, db.RunStatusRunning, run.Status, "a detached job is still running")
+
+	// A new daemon re-attaches to the same runner and relays what it missed
+	jm2 := NewJobManager(database, 10)
+	jm2.jobsDir = jm.jobsDir
+	results := jm2.ResumeJobs(context.Background())
+	require.Len(t, results, 1)
+	require.True(t, results[0].Success, results[0].Reason)
+	assert.True(t, jm2.IsActive(jobID))
+
+	step <- struct{}{}
+	waitForRunStatus(t, database, jobID, db.RunStatusCompleted)
+	assert.Equal(t, 1, *spawned, "the runner survived the detach")
+	waitForEventTypes(t, database, jobID, "unit.started", "task.committed", "unit.completed")
+}
+
+func TestSupervisedJob_RestartsRunnerFromJournal(t *testing.T) {
+	database := setupTestDB(t)
+	jm := setupSupervisedJobManager(t, database)
+	spawned := runnersInProcess(t)
+	recovered := make(chan *orchestrator.Recovery, 1)
+	useOrchestrator(t, func(cfg orchestrator.Config, deps orchestrator.Dependencies) orchestratorRunner {
+		recovered <- cfg.Recovery
+		return &scriptedOrchestrator{bus: deps.Bus, events: []events.Event{
+			events.NewEvent(events.UnitStarted, "b"),
+			events.NewEvent(events.UnitCompleted, "b"),
+		}}
+	})
+
+	// A runner died after finishing unit a; the daemon had recorded only
+	// the first of its events
+	repoPath := setupSupervisedRepo(t)
+	run := &db.Run{
+		ID:            ulid.Make().String(),
+		FeatureBranch: "feature/x",
+		RepoPath:      repoPath,
+		TargetBranch:  "main",
+		TasksDir:      filepath.Join(repoPath, "specs", "tasks"),
+		Status:        db.RunStatusRunning,
+	}
+	require.NoError(t, database.CreateRun(run))
+	_, err := jm.superviseJob(run.ID, validJobConfigWithRepo(repoPath), events.NewBus(10))
+	require.NoError(t, err)
+
+	journal, err := OpenJournal(filepath.Join(jm.jobsDir, run.ID, runnerJournalFile))
+	require.NoError(t, err)
+	for _, e := range []events.Event{
+		events.NewEvent(events.UnitStarted, "a"),
+		events.NewEvent(events.TaskCommitted, "a").WithTask(1),
+		events.NewEvent(events.UnitCompleted, "a"),
+	} {
+		require.NoError(t, journal.Append(e))
+	}
+	require.NoError(t, journal.Close())
+	unit := "a"
+	require.NoError(t, database.AppendEvent(run.ID, "unit.started", &unit, nil))
+
+	results := jm.ResumeJobs(context.Background())
+	require.Len(t, results, 1)
+	require.True(t, results[0].Success, results[0].Reason)
+
+	// The new runner resumes from the journal, not from scratch
+	select {
+	case r := <-recovered:
+		require.NotNil(t, r)
+		assert.Equal(t, []string{"a"}, r.CompletedUnits)
+		assert.Equal(t, map[string][]int{"a": {1}}, r.CommittedTasks)
+	case <-time.After(5 * time.Second):
+		t.Fatal("runner was not restarted")
+	}
+
+	waitForRunStatus(t, database, run.ID, db.RunStatusCompleted)
+	assert.Equal(t, 1, *spawned)
+	waitForEventTypes(t, database, run.ID, "unit.started", "task.committed", "unit.completed", "unit.started", "unit.completed")
+}
@@ -0,0 +1,263 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/web"
+	apiv1 "github.com/RevCBH/choo/pkg/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultTasksDir is where a job started from the web UI finds its tasks
+// when none is given, as for choo run
+const defaultTasksDir = "specs/tasks"
+
+// webControl lets the web UI control jobs. Jobs start and stop through the
+// gRPC server, so they are tracked and validated like any client's.
+type webControl struct {
+	grpc *GRPCServer
+	jm   *jobManagerImpl
+	db   *db.DB
+}
+
+func newWebControl(grpcImpl *GRPCServer, jm *jobManagerImpl, database *db.DB) *webControl {
+	return &webControl{grpc: grpcImpl, jm: jm, db: database}
+}
+
+// ListJobs returns the jobs recorded in the database, most recent first
+func (c *webControl) ListJobs(ctx context.Context) ([]web.JobInfo, error) {
+	runs, err := c.db.ListRuns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	slices.Reverse(runs)
+
+	jobs := make([]web.JobInfo, len(runs))
+	for i, run := range runs {
+		jobs[i] = web.JobInfo{
+			ID:            run.ID,
+			Status:        string(run.Status),
+			Paused:        c.jm.IsPaused(run.ID),
+			RepoPath:      run.RepoPath,
+			TasksDir:      run.TasksDir,
+			FeatureBranch: run.FeatureBranch,
+			TargetBranch:  run.TargetBranch,
+			Parallelism:   run.Parallelism,
+			StartedAt:     run.StartedAt,
+			CompletedAt:   run.CompletedAt,
+		}
+		if run.Error != nil {
+			jobs[i].Error = *run.Error
+		}
+	}
+	return jobs, nil
+}
+
+// JobEvents returns a job's recorded events, oldest first
+func (c *webControl) JobEvents(ctx context.Context, jobID string) ([]*web.Event, error) {
+	run, err := c.db.GetRun(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if run == nil {
+		return nil, fmt.Errorf("%w: %s", web.ErrJobNotFound, jobID)
+	}
+	records, err := c.db.ListEvents(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	evts := make([]*web.Event, len(records))
+	for i, r := range records {
+		evts[i] = convertToWebEvent(jobID, eventFromRecord(r))
+	}
+	return evts, nil
+}
+
+// StartJob starts a job. A PRD runs on its feature branch, as with
+// choo run --feature.
+func (c *webControl) StartJob(ctx context.Context, req web.StartJobRequest) (string, error) {
+	startReq := &apiv1.StartJobRequest{
+		Workspace:     req.Workspace,
+		RepoPath:      req.RepoPath,
+		TasksDir:      req.TasksDir,
+		FeatureBranch: req.PRD,
+		TargetBranch:  req.TargetBranch,
+		Parallelism:   int32(req.Parallelism),
+	}
+	// A workspace fills in its own defaults
+	if req.Workspace == "" {
+		if startReq.TasksDir == "" {
+			startReq.TasksDir = defaultTasksDir
+		}
+		if startReq.TargetBranch == "" {
+			startReq.TargetBranch = "main"
+		}
+	}
+	return c.start(ctx, startReq)
+}
+
+// StopJob cancels a running job
+func (c *webControl) StopJob(ctx context.Context, jobID string, force bool) error {
+	_, err := c.grpc.StopJob(ctx, &apiv1.StopJobRequest{JobId: jobID, Force: force})
+	return controlError(err)
+}
+
+// PauseJob pauses or resumes a running job
+func (c *webControl) PauseJob(ctx context.Context, jobID string, paused bool) error {
+	if !c.jm.IsActive(jobID) {
+		return fmt.Errorf("%w: %s is not running", web.ErrJobConflict, jobID)
+	}
+	if err := c.jm.Pause(jobID, paused); err != nil {
+		return &kindError{kind: web.ErrJobConflict, msg: err.Error()}
+	}
+	return nil
+}
+
+// RetryJob runs a failed or cancelled job again with its configuration,
+// overridden by opts. It starts like any job, so a workspace job fetches
+// its clone and keeps to the workspace's limit. A retry that finds no free
+// slot is queued, if the daemon has a queue.
+func (c *webControl) RetryJob(ctx context.Context, jobID string, opts web.RunOptions) (web.JobResponse, error) {
+	cfg, err := c.retryConfig(jobID, opts)
+	if err != nil {
+		return web.JobResponse{}, err
+	}
+
+	spec := retrySpec(cfg)
+	resp, err := c.grpc.StartJob(ctx, jobSpecToProto(spec))
+	if status.Code(err) == codes.ResourceExhausted {
+		if queue, qerr := c.grpc.getJobQueue(); qerr == nil {
+			entry, err := queue.Enqueue(spec, 0)
+			if err != nil {
+				return web.JobResponse{}, fmt.Errorf("failed to queue retry: %w", err)
+			}
+			return web.JobResponse{QueueID: entry.ID}, nil
+		}
+	}
+	if err != nil {
+		return web.JobResponse{}, controlError(err)
+	}
+	return web.JobResponse{JobID: resp.JobId}, nil
+}
+
+// RetryUnit runs a failed unit of a failed or cancelled job again, alone,
+// with the job's configuration overridden by opts
+func (c *webControl) RetryUnit(ctx context.Context, jobID, unitID string, opts web.RunOptions) (string, error) {
+	cfg, err := c.retryConfig(jobID, opts)
+	if err != nil {
+		return "", err
+	}
+	unit, err := c.db.GetUnit(db.MakeUnitRecordID(jobID, unitID))
+	if err != nil {
+		return "", fmt.Errorf("failed to get unit: %w", err)
+	}
+	if unit == nil {
+		return "", fmt.Errorf("%w: unit %s of %s", web.ErrJobNotFound, unitID, jobID)
+	}
+	if unit.Status != string(db.UnitStatusFailed) {
+		return "", fmt.Errorf("%w: only failed units can be retried, %s is %s", web.ErrJobConflict, unitID, unit.Status)
+	}
+
+	resp, err := c.grpc.startJob(ctx, jobSpecToProto(retrySpec(cfg)), unitID)
+	if err != nil {
+		return "", controlError(err)
+	}
+	return resp.JobId, nil
+}
+
+// retryConfig returns the configuration to run a failed or cancelled job
+// again with, overridden by opts
+func (c *webControl) retryConfig(jobID string, opts web.RunOptions) (JobConfig, error) {
+	run, err := c.db.GetRun(jobID)
+	if err != nil {
+		return JobConfig{}, fmt.Errorf("failed to get job: %w", err)
+	}
+	if run == nil {
+		return JobConfig{}, fmt.Errorf("%w: %s", web.ErrJobNotFound, jobID)
+	}
+	if c.jm.IsActive(jobID) || (run.Status != db.RunStatusFailed && run.Status != db.RunStatusCancelled) {
+		return JobConfig{}, fmt.Errorf("%w: only failed or cancelled jobs can be retried, %s is %s", web.ErrJobConflict, jobID, run.Status)
+	}
+
+	// Runs recorded before their config was kept retry from their columns
+	var cfg JobConfig
+	if err := json.Unmarshal([]byte(run.ConfigJSON), &cfg); err != nil || cfg.RepoPath == "" {
+		cfg = JobConfig{
+			RepoPath:      run.RepoPath,
+			TasksDir:      run.TasksDir,
+			FeatureBranch: run.FeatureBranch,
+			TargetBranch:  run.TargetBranch,
+			Concurrency:   run.Parallelism,
+		}
+	}
+	if opts.TargetBranch != "" {
+		cfg.TargetBranch = opts.TargetBranch
+	}
+	if opts.Parallelism != 0 {
+		cfg.Concurrency = opts.Parallelism
+	}
+	return cfg, nil
+}
+
+// retrySpec returns the spec of a job running cfg again. A workspace job
+// runs in its workspace, whose clone it is started from.
+func retrySpec(cfg JobConfig) db.JobSpec {
+	spec := db.JobSpec{
+		Workspace:     cfg.Workspace,
+		TasksDir:      cfg.TasksDir,
+		TargetBranch:  cfg.TargetBranch,
+		FeatureBranch: cfg.FeatureBranch,
+		Parallelism:   cfg.Concurrency,
+	}
+	if cfg.Workspace == "" {
+		spec.RepoPath = cfg.RepoPath
+	}
+	return spec
+}
+
+// start starts a job through the gRPC server
+func (c *webControl) start(ctx context.Context, req *apiv1.StartJobRequest) (string, error) {
+	resp, err := c.grpc.StartJob(ctx, req)
+	if err != nil {
+		return "", controlError(err)
+	}
+	return resp.JobId, nil
+}
+
+// controlError maps a gRPC status error to the web package's error kinds,
+// keeping the status message
+func controlError(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch st.Code() {
+	case codes.InvalidArgument:
+		return &kindError{kind: web.ErrInvalidRequest, msg: st.Message()}
+	case codes.NotFound:
+		return &kindError{kind: web.ErrJobNotFound, msg: st.Message()}
+	case codes.FailedPrecondition, codes.ResourceExhausted, codes.Unavailable:
+		return &kindError{kind: web.ErrJobConflict, msg: st.Message()}
+	}
+	return errors.New(st.Message())
+}
+
+// kindError is an error of one of the web package's kinds with its own
+// message
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string { return e.msg }
+func (e *kindError) Unwrap() error { return e.kind }
@@ -0,0 +1,239 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/web"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupWebControl returns a web control starting jobs on a mock job manager
+func setupWebControl(t *testing.T) (*webControl, *mockJobManager, *db.DB) {
+	t.Helper()
+	database := setupTestDB(t)
+	mock := newMockJobManager()
+	grpcImpl := NewGRPCServer(database, mock, "test", nil)
+	return newWebControl(grpcImpl, NewJobManager(database, 10), database), mock, database
+}
+
+// createRun records a run with status
+func createRun(t *testing.T, database *db.DB, status db.RunStatus) *db.Run {
+	t.Helper()
+	return createRunWithConfig(t, database, status, JobConfig{})
+}
+
+// createRunWithConfig records a run with status, started with cfg if cfg
+// has a repository
+func createRunWithConfig(t *testing.T, database *db.DB, status db.RunStatus, cfg JobConfig) *db.Run {
+	t.Helper()
+	run := &db.Run{
+		ID:            ulid.Make().String(),
+		FeatureBranch: "feature/" + ulid.Make().String(),
+		RepoPath:      "/repo",
+		TargetBranch:  "main",
+		TasksDir:      "/repo/specs/tasks",
+		Parallelism:   4,
+		Status:        db.RunStatusRunning,
+	}
+	if cfg.RepoPath != "" {
+		configJSON, err := json.Marshal(cfg)
+		require.NoError(t, err)
+		run.RepoPath = cfg.RepoPath
+		run.FeatureBranch = cfg.FeatureBranch
+		run.ConfigJSON = string(configJSON)
+	}
+	require.NoError(t, database.CreateRun(run))
+	if status != db.RunStatusRunning {
+		require.NoError(t, database.UpdateRunStatus(run.ID, status, nil))
+	}
+	return run
+}
+
+func TestWebControl_ListJobs(t *testing.T) {
+	control, _, database := setupWebControl(t)
+	first := createRun(t, database, db.RunStatusCompleted)
+	second := createRun(t, database, db.RunStatusRunning)
+
+	jobs, err := control.ListJobs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, second.ID, jobs[0].ID, "most recent first")
+	assert.Equal(t, "running", jobs[0].Status)
+	assert.Equal(t, first.ID, jobs[1].ID)
+	assert.Equal(t, "completed", jobs[1].Status)
+	assert.Equal(t, first.FeatureBranch, jobs[1].FeatureBranch)
+	assert.Equal(t, 4, jobs[1].Parallelism)
+}
+
+func TestWebControl_JobEvents(t *testing.T) {
+	control, _, database := setupWebControl(t)
+	ctx := context.Background()
+
+	_, err := control.JobEvents(ctx, "missing")
+	assert.ErrorIs(t, err, web.ErrJobNotFound)
+
+	run := createRun(t, database, db.RunStatusCompleted)
+	unit := "app"
+	require.NoError(t, database.AppendEvent(run.ID, string(events.UnitStarted), &unit,
+		events.Event{Type: events.UnitStarted, Unit: unit, Payload: map[string]any{"total_tasks": 2}}))
+	require.NoError(t, database.AppendEvent(run.ID, string(events.UnitCompleted), &unit,
+		events.Event{Type: events.UnitCompleted, Unit: unit}))
+
+	evts, err := control.JobEvents(ctx, run.ID)
+	require.NoError(t, err)
+	require.Len(t, evts, 2)
+	assert.Equal(t, run.ID, evts[0].Job)
+	assert.Equal(t, "unit.started", evts[0].Type)
+	assert.Equal(t, unit, evts[0].Unit)
+	assert.JSONEq(t, `{"total_tasks":2}`, string(evts[0].Payload))
+	assert.Equal(t, "unit.completed", evts[1].Type)
+}
+
+func TestWebControl_StartJob(t *testing.T) {
+	control, mock, _ := setupWebControl(t)
+
+	jobID, err := control.StartJob(context.Background(), web.StartJobRequest{RepoPath: "/repo", PRD: "auth"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, jobID)
+	require.Len(t, mock.started, 1)
+	assert.Equal(t, JobConfig{
+		RepoPath:      "/repo",
+		TasksDir:      defaultTasksDir,
+		TargetBranch:  "main",
+		FeatureBranch: "auth",
+	}, mock.started[0])
+
+	// The gRPC server's errors keep their meaning
+	_, err = control.StartJob(context.Background(), web.StartJobRequest{Workspace: "api"})
+	assert.ErrorIs(t, err, web.ErrJobConflict)
+	assert.Equal(t, "workspaces are not configured", err.Error())
+}
+
+func TestWebControl_StopAndPauseJob(t *testing.T) {
+	control, mock, _ := setupWebControl(t)
+
+	assert.ErrorIs(t, control.StopJob(context.Background(), "missing", false), web.ErrJobNotFound)
+	mock.addJob("job-1", "completed")
+	assert.ErrorIs(t, control.StopJob(context.Background(), "job-1", false), web.ErrJobConflict)
+	mock.setJobStatus("job-1", "running")
+	require.NoError(t, control.StopJob(context.Background(), "job-1", true))
+	assert.True(t, mock.forceStopped["job-1"])
+
+	assert.ErrorIs(t, control.PauseJob(context.Background(), "job-1", true), web.ErrJobConflict,
+		"only jobs running in this daemon can be paused")
+}
+
+func TestWebControl_RetryJob(t *testing.T) {
+	control, mock, database := setupWebControl(t)
+	ctx := context.Background()
+
+	_, err := control.RetryJob(ctx, "missing", web.RunOptions{})
+	assert.ErrorIs(t, err, web.ErrJobNotFound)
+
+	completed := createRun(t, database, db.RunStatusCompleted)
+	_, err = control.RetryJob(ctx, completed.ID, web.RunOptions{})
+	assert.ErrorIs(t, err, web.ErrJobConflict)
+
+	// A failed job runs again with its configuration, overridden by the options
+	failed := createRun(t, database, db.RunStatusFailed)
+	resp, err := control.RetryJob(ctx, failed.ID, web.RunOptions{Parallelism: 1})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.JobID)
+	require.Len(t, mock.started, 1)
+	assert.Equal(t, JobConfig{
+		RepoPath:      failed.RepoPath,
+		TasksDir:      failed.TasksDir,
+		TargetBranch:  "main",
+		FeatureBranch: failed.FeatureBranch,
+		Concurrency:   1,
+	}, mock.started[0])
+}
+
+func TestWebControl_RetryJob_Workspace(t *testing.T) {
+	control, mock, database := setupWebControl(t)
+	require.NoError(t, database.CreateWorkspace(&db.Workspace{
+		Name:         "app",
+		RepoURL:      "https://example.com/app.git",
+		MirrorPath:   "/srv/choo/workspaces/app",
+		TargetBranch: "trunk",
+		TasksDir:     "specs/tasks",
+	}))
+	control.grpc.SetWorkspaces(NewWorkspaceManager(database, t.TempDir(), nil))
+
+	// The retry starts in the workspace, not from the clone's path
+	failed := createRunWithConfig(t, database, db.RunStatusFailed, JobConfig{
+		RepoPath:      "/srv/choo/workspaces/app",
+		TasksDir:      "specs/tasks",
+		TargetBranch:  "trunk",
+		FeatureBranch: "feature/api",
+		Workspace:     "app",
+	})
+	_, err := control.RetryJob(context.Background(), failed.ID, web.RunOptions{})
+	require.NoError(t, err)
+	require.Len(t, mock.started, 1)
+	assert.Equal(t, JobConfig{
+		RepoPath:      "/srv/choo/workspaces/app",
+		TasksDir:      "specs/tasks",
+		TargetBranch:  "trunk",
+		FeatureBranch: "feature/api",
+		Workspace:     "app",
+	}, mock.started[0])
+}
+
+func TestWebControl_RetryJob_QueuedAtCapacity(t *testing.T) {
+	control, mock, database := setupWebControl(t)
+	mock.startErr = fmt.Errorf("%w: 1 jobs running", ErrAtCapacity)
+	failed := createRun(t, database, db.RunStatusFailed)
+
+	// Without a queue the retry is refused
+	_, err := control.RetryJob(context.Background(), failed.ID, web.RunOptions{})
+	assert.ErrorIs(t, err, web.ErrJobConflict)
+
+	queue := NewJobQueue(database, control.grpc.StartJob, func(string) bool { return false })
+	control.grpc.SetJobQueue(queue)
+	failed = createRun(t, database, db.RunStatusFailed)
+	resp, err := control.RetryJob(context.Background(), failed.ID, web.RunOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.JobID)
+	require.NotEmpty(t, resp.QueueID)
+
+	entries, err := queue.List(false)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, resp.QueueID, entries[0].ID)
+	assert.Equal(t, failed.RepoPath, entries[0].RepoPath)
+	assert.Equal(t, failed.FeatureBranch, entries[0].FeatureBranch)
+}
+
+func TestWebControl_RetryUnit(t *testing.T) {
+	control, mock, database := setupWebControl(t)
+	ctx := context.Background()
+	failed := createRun(t, database, db.RunStatusFailed)
+	for unitID, status := range map[string]db.UnitStatus{"app": db.UnitStatusFailed, "lib": db.UnitStatusCompleted} {
+		require.NoError(t, database.CreateUnit(&db.UnitRecord{
+			ID:     db.MakeUnitRecordID(failed.ID, unitID),
+			RunID:  failed.ID,
+			UnitID: unitID,
+			Status: string(status),
+		}))
+	}
+
+	_, err := control.RetryUnit(ctx, failed.ID, "missing", web.RunOptions{})
+	assert.ErrorIs(t, err, web.ErrJobNotFound)
+	_, err = control.RetryUnit(ctx, failed.ID, "lib", web.RunOptions{})
+	assert.ErrorIs(t, err, web.ErrJobConflict)
+
+	jobID, err := control.RetryUnit(ctx, failed.ID, "app", web.RunOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, jobID)
+	require.Len(t, mock.started, 1)
+	assert.Equal(t, "app", mock.started[0].Unit)
+	assert.Equal(t, failed.FeatureBranch, mock.started[0].FeatureBranch)
+}
@@ -0,0 +1,199 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+)
+
+// Workspace defaults applied when registration leaves them empty
+const (
+	defaultWorkspaceTasksDir = "specs/tasks"
+	defaultWorkspaceBranch   = "main"
+)
+
+// ErrWorkspaceNotFound is returned for workspaces that are not registered.
+var ErrWorkspaceNotFound = errors.New("workspace not found")
+
+// workspaceNamePattern keeps names usable as directory names
+var workspaceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,62}$`)
+
+// WorkspaceManager registers repositories with the daemon and keeps a
+// clone of each under its directory. Clones are fetched before every job,
+// so jobs can be started by workspace name from any host.
+type WorkspaceManager struct {
+	db  *db.DB
+	dir string
+
+	// repoActive reports whether a job is running in the repository at a
+	// path; the checkout of a busy clone is left alone
+	repoActive func(repoPath string) bool
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex // serializes git operations per workspace
+}
+
+// NewWorkspaceManager creates a workspace manager keeping clones in dir.
+func NewWorkspaceManager(database *db.DB, dir string, repoActive func(repoPath string) bool) *WorkspaceManager {
+	return &WorkspaceManager{
+		db:         database,
+		dir:        dir,
+		repoActive: repoActive,
+		locks:      make(map[string]*sync.Mutex),
+	}
+}
+
+// Add validates ws, clones its repository and registers it. Empty
+// defaults are filled in: the tasks directory with specs/tasks and the
+// target branch with the repository's default branch.
+func (m *WorkspaceManager) Add(ctx context.Context, ws *db.Workspace) error {
+	if !workspaceNamePattern.MatchString(ws.Name) {
+		return fmt.Errorf("invalid workspace name %q: use lowercase letters, digits, '.', '_' and '-'", ws.Name)
+	}
+	if ws.RepoURL == "" {
+		return fmt.Errorf("repository URL is required")
+	}
+	if ws.Parallelism < 0 || ws.MaxJobs < 0 {
+		return fmt.Errorf("parallelism and max jobs must not be negative")
+	}
+	if ws.TasksDir == "" {
+		ws.TasksDir = defaultWorkspaceTasksDir
+	}
+
+	lock := m.lock(ws.Name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	existing, err := m.db.GetWorkspace(ws.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("workspace %q already exists", ws.Name)
+	}
+
+	ws.MirrorPath = filepath.Join(m.dir, ws.Name)
+	if _, err := os.Stat(ws.MirrorPath); err == nil {
+		return fmt.Errorf("%s already exists; remove it or pick another name", ws.MirrorPath)
+	}
+	if err := os.MkdirAll(m.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create workspace directory: %w", err)
+	}
+	if _, err := gitOutput(ctx, m.dir, "clone", "--", ws.RepoURL, ws.MirrorPath); err != nil {
+		os.RemoveAll(ws.MirrorPath)
+		return fmt.Errorf("failed to clone %s: %w", ws.RepoURL, err)
+	}
+
+	if ws.TargetBranch == "" {
+		ws.TargetBranch = defaultWorkspaceBranch
+		if head, err := gitOutput(ctx, ws.MirrorPath, "symbolic-ref", "--short", "refs/remotes/origin/HEAD"); err == nil {
+			ws.TargetBranch = strings.TrimPrefix(head, "origin/")
+		}
+	}
+
+	if err := m.db.CreateWorkspace(ws); err != nil {
+		os.RemoveAll(ws.MirrorPath)
+		return err
+	}
+	now := time.Now()
+	if err := m.db.MarkWorkspaceFetched(ws.Name, now); err != nil {
+		return err
+	}
+	ws.CreatedAt, ws.FetchedAt = now, &now
+	return nil
+}
+
+// Get returns the workspace named name, or ErrWorkspaceNotFound.
+func (m *WorkspaceManager) Get(name string) (*db.Workspace, error) {
+	ws, err := m.db.GetWorkspace(name)
+	if err != nil {
+		return nil, err
+	}
+	if ws == nil {
+		return nil, fmt.Errorf("%w: %s", ErrWorkspaceNotFound, name)
+	}
+	return ws, nil
+}
+
+// List returns all registered workspaces.
+func (m *WorkspaceManager) List() ([]*db.Workspace, error) {
+	return m.db.ListWorkspaces()
+}
+
+// Remove unregisters the workspace named name, deleting its clone if
+// deleteClone is set. Workspaces with running jobs cannot be removed.
+func (m *WorkspaceManager) Remove(name string, deleteClone bool) error {
+	lock := m.lock(name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	ws, err := m.Get(name)
+	if err != nil {
+		return err
+	}
+	if m.repoActive != nil && m.repoActive(ws.MirrorPath) {
+		return fmt.Errorf("workspace %q has running jobs", name)
+	}
+	if err := m.db.DeleteWorkspace(name); err != nil {
+		return err
+	}
+	if deleteClone {
+		if err := os.RemoveAll(ws.MirrorPath); err != nil {
+			return fmt.Errorf("failed to delete clone: %w", err)
+		}
+	}
+	return nil
+}
+
+// Prepare fetches the workspace's clone before a job on targetBranch. When
+// no other job is running in it, the checkout is also reset to the fetched
+// target branch, so the job reads the latest tasks and config.
+func (m *WorkspaceManager) Prepare(ctx context.Context, name, targetBranch string) (*db.Workspace, error) {
+	lock := m.lock(name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	ws, err := m.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if targetBranch == "" {
+		targetBranch = ws.TargetBranch
+	}
+
+	if _, err := gitOutput(ctx, ws.MirrorPath, "fetch", "--prune", "origin"); err != nil {
+		return nil, fmt.Errorf("failed to fetch workspace %s: %w", name, err)
+	}
+	if m.repoActive == nil || !m.repoActive(ws.MirrorPath) {
+		if _, err := gitOutput(ctx, ws.MirrorPath, "checkout", "--force", "-B", targetBranch, "origin/"+targetBranch); err != nil {
+			return nil, fmt.Errorf("failed to check out %s in workspace %s: %w", targetBranch, name, err)
+		}
+	}
+
+	now := time.Now()
+	if err := m.db.MarkWorkspaceFetched(name, now); err != nil {
+		return nil, err
+	}
+	ws.FetchedAt = &now
+	return ws, nil
+}
+
+// lock returns the mutex serializing git operations on the named workspace
+func (m *WorkspaceManager) lock(name string) *sync.Mutex {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, ok := m.locks[name]
+	if !ok {
+		l = &sync.Mutex{}
+		m.locks[name] = l
+	}
+	return l
+}
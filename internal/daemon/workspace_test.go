@@ -0,0 +1,202 @@
+package daemon
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/daemon/db"
+	"github.com/RevCBH/choo/internal/testutil"
+	apiv1 "github.com/RevCBH/choo/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// setupOriginRepo creates a bare repository with a choo project on main and
+// returns it with a checkout for pushing further commits
+func setupOriginRepo(t *testing.T) (bare, work string) {
+	t.Helper()
+	testutil.UnsetGitEnv()
+
+	bare = t.TempDir()
+	testutil.Git(t, bare, "init", "--bare", "-b", "main")
+
+	work = t.TempDir()
+	testutil.Git(t, work, "init", "-b", "main")
+	writeFile(t, filepath.Join(work, ".choo.yaml"), "github:\n  owner: local\n  repo: app\n")
+	writeFile(t, filepath.Join(work, "specs", "tasks", "README.md"), "# Tasks\n")
+	testutil.Git(t, work, "add", "-A")
+	testutil.Git(t, work, "commit", "-m", "initial")
+	testutil.Git(t, work, "remote", "add", "origin", bare)
+	testutil.Git(t, work, "push", "-u", "origin", "main")
+	return bare, work
+}
+
+func TestWorkspaceManager_Lifecycle(t *testing.T) {
+	database := setupTestDB(t)
+	bare, work := setupOriginRepo(t)
+	m := NewWorkspaceManager(database, filepath.Join(t.TempDir(), "workspaces"), nil)
+	ctx := context.Background()
+
+	ws := &db.Workspace{Name: "app", RepoURL: bare, MaxJobs: 1}
+	require.NoError(t, m.Add(ctx, ws))
+	assert.Equal(t, "main", ws.TargetBranch, "target branch defaults to origin's HEAD")
+	assert.Equal(t, "specs/tasks", ws.TasksDir)
+	assert.FileExists(t, filepath.Join(ws.MirrorPath, ".choo.yaml"))
+	require.NotNil(t, ws.FetchedAt)
+
+	err := m.Add(ctx, &db.Workspace{Name: "app", RepoURL: bare})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+
+	// Prepare picks up commits pushed since the clone
+	writeFile(t, filepath.Join(work, "specs", "tasks", "api", "01-handlers.md"), "# Handlers\n")
+	testutil.Git(t, work, "add", "-A")
+	testutil.Git(t, work, "commit", "-m", "add api unit")
+	testutil.Git(t, work, "push", "origin", "main")
+
+	prepared, err := m.Prepare(ctx, "app", "")
+	require.NoError(t, err)
+	assert.Equal(t, ws.MirrorPath, prepared.MirrorPath)
+	assert.FileExists(t, filepath.Join(ws.MirrorPath, "specs", "tasks", "api", "01-handlers.md"))
+
+	list, err := m.List()
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, 1, list[0].MaxJobs)
+
+	require.NoError(t, m.Remove("app", true))
+	assert.NoDirExists(t, ws.MirrorPath)
+	_, err = m.Get("app")
+	assert.ErrorIs(t, err, ErrWorkspaceNotFound)
+}
+
+func TestWorkspaceManager_Add_Validates(t *testing.T) {
+	database := setupTestDB(t)
+	m := NewWorkspaceManager(database, t.TempDir(), nil)
+	ctx := context.Background()
+
+	err := m.Add(ctx, &db.Workspace{Name: "../escape", RepoURL: "https://example.com/app.git"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid workspace name")
+
+	err = m.Add(ctx, &db.Workspace{Name: "app"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "repository URL is required")
+
+	err = m.Add(ctx, &db.Workspace{Name: "app", RepoURL: filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to clone")
+	assert.NoDirExists(t, filepath.Join(m.dir, "app"))
+
+	list, err := m.List()
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestWorkspaceManager_Remove_RefusesActiveWorkspace(t *testing.T) {
+	database := setupTestDB(t)
+	bare, _ := setupOriginRepo(t)
+	m := NewWorkspaceManager(database, t.TempDir(), func(string) bool { return true })
+
+	require.NoError(t, m.Add(context.Background(), &db.Workspace{Name: "app", RepoURL: bare}))
+	err := m.Remove("app", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "running jobs")
+}
+
+func TestJobManager_Start_RepoLimits(t *testing.T) {
+	database := setupTestDB(t)
+	bare, _ := setupOriginRepo(t)
+	jm := NewJobManager(database, 10)
+	jm.workspaces = NewWorkspaceManager(database, t.TempDir(), jm.IsRepoActive)
+
+	ws := &db.Workspace{Name: "app", RepoURL: bare, MaxJobs: 1}
+	require.NoError(t, jm.workspaces.Add(context.Background(), ws))
+
+	start := func(cfg JobConfig) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		_, err := jm.Start(ctx, cancel, cfg)
+		return err
+	}
+	workspaceJob := func(branch string) JobConfig {
+		return JobConfig{
+			RepoPath:      ws.MirrorPath,
+			TasksDir:      ws.TasksDir,
+			TargetBranch:  ws.TargetBranch,
+			FeatureBranch: branch,
+			Workspace:     ws.Name,
+		}
+	}
+
+	require.NoError(t, start(workspaceJob("feature/a")))
+	assert.True(t, jm.IsRepoActive(ws.MirrorPath))
+	prepared, err := jm.workspaces.Get(ws.Name)
+	require.NoError(t, err)
+
+	err = start(workspaceJob("feature/b"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max jobs (1) reached for")
+
+	// The blocked job did not fetch the clone the running job uses
+	blocked, err := jm.workspaces.Get(ws.Name)
+	require.NoError(t, err)
+	assert.Equal(t, prepared.FetchedAt, blocked.FetchedAt)
+
+	// Other repositories have their own limit
+	repo := setupTestRepo(t)
+	require.NoError(t, start(JobConfig{RepoPath: repo, TasksDir: "specs/tasks", TargetBranch: "main"}))
+
+	jm.maxRepoJobs = 1
+	err = start(JobConfig{RepoPath: repo, TasksDir: "specs/tasks", TargetBranch: "main", FeatureBranch: "feature/c"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max jobs (1) reached for")
+}
+
+func TestGRPC_StartJob_Workspace(t *testing.T) {
+	database := setupTestDB(t)
+	require.NoError(t, database.CreateWorkspace(&db.Workspace{
+		Name:         "app",
+		RepoURL:      "https://example.com/app.git",
+		MirrorPath:   "/srv/choo/workspaces/app",
+		TargetBranch: "trunk",
+		TasksDir:     "specs/tasks",
+		Parallelism:  3,
+	}))
+
+	jm := newMockJobManager()
+	server := NewGRPCServer(database, jm, "v1.0.0", nil)
+
+	_, err := server.StartJob(context.Background(), &apiv1.StartJobRequest{Workspace: "app"})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err), "workspaces need a registry")
+
+	server.SetWorkspaces(NewWorkspaceManager(database, t.TempDir(), nil))
+
+	_, err = server.StartJob(context.Background(), &apiv1.StartJobRequest{Workspace: "app", FeatureBranch: "feature/api"})
+	require.NoError(t, err)
+	require.Len(t, jm.started, 1)
+	cfg := jm.started[0]
+	assert.Equal(t, "/srv/choo/workspaces/app", cfg.RepoPath)
+	assert.Equal(t, "trunk", cfg.TargetBranch)
+	assert.Equal(t, "specs/tasks", cfg.TasksDir)
+	assert.Equal(t, 3, cfg.Concurrency)
+	assert.Equal(t, "app", cfg.Workspace)
+
+	_, err = server.StartJob(context.Background(), &apiv1.StartJobRequest{Workspace: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = server.StartJob(context.Background(), &apiv1.StartJobRequest{Workspace: "app", RepoPath: "/repo"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	list, err := server.ListWorkspaces(context.Background(), &apiv1.ListWorkspacesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Workspaces, 1)
+	assert.Equal(t, "https://example.com/app.git", list.Workspaces[0].RepoUrl)
+
+	_, err = server.RemoveWorkspace(context.Background(), &apiv1.RemoveWorkspaceRequest{Name: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
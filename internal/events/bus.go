@@ -14,6 +14,9 @@ type Bus struct {
 	// handlers is the list of registered event handlers
 	handlers []Handler
 
+	// journal records events as they are emitted, before dispatch
+	journal Handler
+
 	// ch is the buffered channel for event delivery
 	ch chan Event
 
@@ -56,12 +59,29 @@ func (b *Bus) Subscribe(h Handler) {
 	b.handlers = append(b.handlers, h)
 }
 
+// SetJournal registers a handler called synchronously by Emit, before the
+// event is queued for dispatch. Emitters block until it returns, so events
+// it records are durable before the emitter acts on them.
+func (b *Bus) SetJournal(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.journal = h
+}
+
 // Emit publishes an event to all handlers
 // Sets event.Time to current time
 // Non-blocking: drops event if buffer is full (logs warning)
 // Safe to call from multiple goroutines
 func (b *Bus) Emit(e Event) {
 	e.Time = time.Now()
+
+	b.mu.RLock()
+	journal := b.journal
+	b.mu.RUnlock()
+	if journal != nil {
+		journal(e)
+	}
+
 	b.wg.Add(1)
 	select {
 	case b.ch <- e:
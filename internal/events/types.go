@@ -39,6 +39,10 @@ const (
 	OrchCompleted EventType = "orch.completed"
 	OrchFailed    EventType = "orch.failed"
 
+	// Dispatching new units paused and resumed; running units carry on
+	OrchPaused  EventType = "orch.paused"
+	OrchResumed EventType = "orch.resumed"
+
 	// Dry-run events (no actual execution)
 	OrchDryRunStarted   EventType = "orch.dryrun.started"
 	OrchDryRunCompleted EventType = "orch.dryrun.completed"
@@ -49,9 +53,15 @@ const (
 	UnitQueued    EventType = "unit.queued"
 	UnitStarted   EventType = "unit.started"
 	UnitCompleted EventType = "unit.completed" // Terminal: tasks done and merged to feature branch
+	UnitMerging   EventType = "unit.merging"   // Emitted when the unit starts landing on its target, after code review
 	UnitMerged    EventType = "unit.merged"    // Emitted when unit is merged to feature branch (same as completed)
 	UnitFailed    EventType = "unit.failed"
 	UnitBlocked   EventType = "unit.blocked"
+
+	// UnitConflictRisk flags two units likely to produce conflicting edits
+	// Payload: other_unit (string), files ([]string), symbols ([]string),
+	// level ("high" or "medium"), source ("spec" or "diff")
+	UnitConflictRisk EventType = "unit.conflict_risk"
 )
 
 // Task lifecycle events
@@ -66,6 +76,92 @@ const (
 	TaskCompleted      EventType = "task.completed"
 	TaskRetry          EventType = "task.retry"
 	TaskFailed         EventType = "task.failed"
+
+	// TaskOutput carries the provider's transcript since the last one,
+	// batched to at most a few events a second
+	// Payload: entries ([]provider.TranscriptEntry), dropped (int)
+	TaskOutput EventType = "task.output"
+)
+
+// Baseline check events (baseline_checks, run after a unit's tasks complete)
+const (
+	// BaselinePassed is emitted when a unit's baseline checks pass
+	// Payload: checks ([]string), fix_attempts (int)
+	BaselinePassed EventType = "baseline.passed"
+
+	// BaselineFailed is emitted when baseline checks still fail after the
+	// last fix attempt
+	// Payload: checks ([]string), fix_attempts (int), output (string)
+	BaselineFailed EventType = "baseline.failed"
+)
+
+// Merge queue events
+const (
+	// MergeQueueBatchStarted is emitted when a batch of units begins speculative integration
+	// Payload: units ([]string), base (string)
+	MergeQueueBatchStarted EventType = "mergequeue.batch.started"
+
+	// MergeQueueBisect is emitted when a failing batch is split to isolate the culprit
+	// Payload: units ([]string), output (string)
+	MergeQueueBisect EventType = "mergequeue.bisect"
+
+	// MergeQueueUnitRejected is emitted when a unit is dropped from a batch
+	// Payload: reason ("conflict" or "baseline"), output (string)
+	MergeQueueUnitRejected EventType = "mergequeue.unit.rejected"
+
+	// MergeQueueBatchLanded is emitted when verified units land on the target branch
+	// Payload: units ([]string), commit (string), checks_run (int)
+	MergeQueueBatchLanded EventType = "mergequeue.batch.landed"
+)
+
+// Conflict resolution events
+const (
+	// ConflictResolved is emitted when an agent resolution passes verification
+	// Payload: stage (string), files ([]string), dropped_hunks ([]string), artifact (string)
+	ConflictResolved EventType = "conflict.resolved"
+
+	// ConflictVerificationFailed is emitted when a resolution breaks
+	// backpressure or baseline checks; the unit is escalated, not merged
+	// Payload: same as ConflictResolved
+	ConflictVerificationFailed EventType = "conflict.verification.failed"
+)
+
+// Stacked PR events (pull_requests.mode: stacked)
+const (
+	// StackPROpened is emitted when a unit's stacked PR is opened
+	// Payload: branch (string), base (string), number (int), url (string)
+	StackPROpened EventType = "stack.pr.opened"
+
+	// StackRestacked is emitted when a unit's branch is rebased onto a changed parent
+	// Payload: branch (string), base (string)
+	StackRestacked EventType = "stack.restacked"
+
+	// StackPRMerged is emitted when a unit's stacked PR is merged
+	// Payload: number (int), sha (string)
+	StackPRMerged EventType = "stack.pr.merged"
+
+	// StackFailed is emitted when a unit cannot be added to or updated in the stack
+	// Payload: branch (string)
+	StackFailed EventType = "stack.failed"
+)
+
+// Feature PR CI events (ci.fix: true)
+const (
+	// CIChecksFailed is emitted when checks fail on the feature branch
+	// Payload: branch (string), attempt (int), checks ([]string)
+	CIChecksFailed EventType = "ci.checks.failed"
+
+	// CIFixPushed is emitted when a fix cycle pushes a commit
+	// Payload: branch (string), attempt (int), sha (string)
+	CIFixPushed EventType = "ci.fix.pushed"
+
+	// CIChecksPassed is emitted when checks pass on the feature branch
+	// Payload: branch (string), attempts (int)
+	CIChecksPassed EventType = "ci.checks.passed"
+
+	// CIFixExhausted is emitted when checks still fail after the last fix cycle
+	// Payload: branch (string), attempts (int)
+	CIFixExhausted EventType = "ci.fix.exhausted"
 )
 
 // PR lifecycle events (deprecated: local merge workflow replaces PRs for unit branches)
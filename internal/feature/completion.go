@@ -8,15 +8,15 @@ import (
 	"strings"
 
 	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/forge"
 	"github.com/RevCBH/choo/internal/git"
-	"github.com/RevCBH/choo/internal/github"
 )
 
 // CompletionChecker monitors unit completion and triggers feature PR
 type CompletionChecker struct {
 	prd    *PRD
 	git    *git.Client
-	github *github.PRClient
+	forge  forge.Forge
 	events *events.Bus
 }
 
@@ -24,16 +24,16 @@ type CompletionChecker struct {
 type CompletionStatus struct {
 	AllUnitsMerged bool
 	BranchClean    bool
-	ExistingPR     *github.PRInfo
+	ExistingPR     *forge.PRInfo
 	ReadyForPR     bool
 }
 
 // NewCompletionChecker creates a completion checker for the feature
-func NewCompletionChecker(prd *PRD, gitClient *git.Client, ghClient *github.PRClient, bus *events.Bus) *CompletionChecker {
+func NewCompletionChecker(prd *PRD, gitClient *git.Client, forgeClient forge.Forge, bus *events.Bus) *CompletionChecker {
 	return &CompletionChecker{
 		prd:    prd,
 		git:    gitClient,
-		github: ghClient,
+		forge:  forgeClient,
 		events: bus,
 	}
 }
@@ -146,7 +146,7 @@ func (c *CompletionChecker) branchIsClean() bool {
 }
 
 // findExistingPR checks if a feature PR already exists
-func (c *CompletionChecker) findExistingPR(ctx context.Context) (*github.PRInfo, error) {
+func (c *CompletionChecker) findExistingPR(ctx context.Context) (*forge.PRInfo, error) {
 	// For now, we return nil since there's no direct API to search for PRs by branch
 	// The actual implementation would need to search for open PRs from the feature branch
 	// This is a placeholder that returns nil (no existing PR found)
@@ -161,9 +161,9 @@ func (c *CompletionChecker) openFeaturePR(ctx context.Context) error {
 		featureBranch = fmt.Sprintf("feature/%s", c.prd.ID)
 	}
 
-	// According to the PRClient implementation, CreatePR is delegated to Claude via gh CLI
-	// This returns an error indicating the operation should be performed externally
-	_, err := c.github.CreatePR(ctx,
+	// On GitHub, CreatePR is delegated to Claude via gh CLI and returns an
+	// error indicating the operation should be performed externally
+	_, err := c.forge.CreatePR(ctx,
 		fmt.Sprintf("feat: %s", c.prd.Title),
 		fmt.Sprintf("Completes feature: %s (%s)", c.prd.Title, c.prd.ID),
 		featureBranch,
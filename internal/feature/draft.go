@@ -0,0 +1,326 @@
+package feature
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDraftRounds is the number of agent turns before a draft gives up
+const DefaultDraftRounds = 8
+
+// Roles of the participants in a drafting conversation
+const (
+	DraftRoleAssistant = "assistant"
+	DraftRoleAuthor    = "author"
+	DraftRoleChoo      = "choo" // corrections from choo itself, e.g. invalid proposals
+)
+
+// Interviewer carries the drafting conversation with the PRD's author,
+// whether in a terminal or elsewhere
+type Interviewer interface {
+	// Ask shows a question and returns the author's answer
+	Ask(ctx context.Context, question string) (string, error)
+
+	// Show displays a message that needs no answer
+	Show(message string)
+}
+
+// DraftOptions configures a drafting session
+type DraftOptions struct {
+	// Idea is the author's initial description of the feature
+	Idea string
+
+	// PRDDir is where the PRD and transcript are written
+	PRDDir string
+
+	// MaxRounds bounds the agent turns (default: DefaultDraftRounds)
+	MaxRounds int
+}
+
+// DraftTurn is one message in a drafting conversation
+type DraftTurn struct {
+	Role    string `yaml:"role"`
+	Content string `yaml:"content"`
+}
+
+// DraftTranscript records a drafting conversation. It is saved next to the
+// PRD as <prd-id>.draft.yaml.
+type DraftTranscript struct {
+	PRDID      string      `yaml:"prd_id"`
+	Idea       string      `yaml:"idea"`
+	StartedAt  time.Time   `yaml:"started_at"`
+	FinishedAt time.Time   `yaml:"finished_at"`
+	Turns      []DraftTurn `yaml:"turns"`
+}
+
+// DraftResult is the outcome of a completed drafting session
+type DraftResult struct {
+	PRD            *PRD
+	Transcript     *DraftTranscript
+	TranscriptPath string
+}
+
+// draftReply is the JSON the agent answers each turn with: either
+// clarifying questions or a proposal
+type draftReply struct {
+	Questions []string       `json:"questions"`
+	Proposal  *draftProposal `json:"proposal"`
+}
+
+type draftProposal struct {
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	Scope          []string `json:"scope"`
+	OutOfScope     []string `json:"out_of_scope"`
+	DependsOn      []string `json:"depends_on"`
+	EstimatedUnits int      `json:"estimated_units"`
+	EstimatedTasks int      `json:"estimated_tasks"`
+	Body           string   `json:"body"`
+}
+
+// Draft interviews the author through in, with agent asking clarifying
+// questions and proposing scope and estimates, until the author accepts a
+// proposal. The accepted PRD is written to opts.PRDDir as a draft together
+// with the conversation's transcript.
+func Draft(ctx context.Context, agent AgentInvoker, in Interviewer, opts DraftOptions) (*DraftResult, error) {
+	idea := strings.TrimSpace(opts.Idea)
+	if idea == "" {
+		return nil, fmt.Errorf("a feature idea is required")
+	}
+	maxRounds := opts.MaxRounds
+	if maxRounds <= 0 {
+		maxRounds = DefaultDraftRounds
+	}
+
+	var existing []*PRD
+	if _, err := os.Stat(opts.PRDDir); err == nil {
+		var err error
+		if existing, err = DiscoverPRDs(opts.PRDDir); err != nil {
+			return nil, err
+		}
+	}
+
+	t := &DraftTranscript{Idea: idea, StartedAt: time.Now()}
+	for round := 1; round <= maxRounds; round++ {
+		prompt := buildDraftPrompt(t, existing, round == maxRounds)
+		response, err := agent.Invoke(ctx, prompt)
+		if err != nil {
+			return nil, fmt.Errorf("agent invocation failed: %w", err)
+		}
+		reply, err := parseDraftReply(response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse agent response: %w", err)
+		}
+
+		if reply.Proposal == nil {
+			for _, q := range reply.Questions {
+				t.add(DraftRoleAssistant, q)
+				answer, err := in.Ask(ctx, q)
+				if err != nil {
+					return nil, err
+				}
+				t.add(DraftRoleAuthor, answer)
+			}
+			continue
+		}
+
+		prd := reply.Proposal.prd(opts.PRDDir)
+		if err := checkProposal(prd, existing); err != nil {
+			t.add(DraftRoleChoo, fmt.Sprintf("The proposal was rejected: %v. Propose again with this fixed.", err))
+			continue
+		}
+
+		summary := reply.Proposal.summary()
+		t.add(DraftRoleAssistant, summary)
+		in.Show(summary)
+		answer, err := in.Ask(ctx, "Write this PRD? Answer yes, or describe what to change.")
+		if err != nil {
+			return nil, err
+		}
+		t.add(DraftRoleAuthor, answer)
+		if !isYes(answer) {
+			continue
+		}
+
+		if err := WritePRD(prd); err != nil {
+			return nil, err
+		}
+		t.PRDID = prd.ID
+		t.FinishedAt = time.Now()
+		path := filepath.Join(opts.PRDDir, prd.ID+".draft.yaml")
+		if err := writeTranscript(path, t); err != nil {
+			return nil, err
+		}
+		return &DraftResult{PRD: prd, Transcript: t, TranscriptPath: path}, nil
+	}
+
+	return nil, fmt.Errorf("no PRD was accepted after %d rounds", maxRounds)
+}
+
+func (t *DraftTranscript) add(role, content string) {
+	t.Turns = append(t.Turns, DraftTurn{Role: role, Content: strings.TrimSpace(content)})
+}
+
+// buildDraftPrompt asks for the next turn of the conversation so far. The
+// final round requires a proposal.
+func buildDraftPrompt(t *DraftTranscript, existing []*PRD, final bool) string {
+	var sb strings.Builder
+
+	sb.WriteString("You are a product engineer helping an author turn a feature idea into a PRD (product requirements document) for this repository.\n")
+	sb.WriteString("You are running in the repository root. Read the code to ground your questions and estimates, but do not modify any files.\n\n")
+
+	sb.WriteString("## Feature Idea\n\n")
+	sb.WriteString(t.Idea)
+	sb.WriteString("\n\n")
+
+	if len(existing) > 0 {
+		sb.WriteString("## Existing PRDs\n\n")
+		sb.WriteString("Do not reuse these IDs. List any the feature builds on in depends_on.\n\n")
+		for _, prd := range existing {
+			fmt.Fprintf(&sb, "- %s: %s (%s)\n", prd.ID, prd.Title, prd.Status)
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(t.Turns) > 0 {
+		sb.WriteString("## Conversation So Far\n\n")
+		for _, turn := range t.Turns {
+			fmt.Fprintf(&sb, "**%s:** %s\n\n", turn.Role, turn.Content)
+		}
+	}
+
+	sb.WriteString("## Your Turn\n\n")
+	if final {
+		sb.WriteString("This is the last turn: make a proposal with what you know, noting open questions in the body.\n\n")
+	} else {
+		sb.WriteString("Ask clarifying questions while the goal, users, scope or acceptance criteria are unclear (at most 3 at a time). ")
+		sb.WriteString("Once they are clear, or the author asked for changes to a proposal, make a proposal.\n\n")
+	}
+	sb.WriteString("Respond with JSON only, in one of these forms:\n\n")
+	sb.WriteString("```json\n{\"questions\": [\"...\"]}\n```\n\n")
+	sb.WriteString("```json\n")
+	sb.WriteString(`{"proposal": {
+  "id": "lowercase-hyphenated-id",
+  "title": "Short title",
+  "scope": ["what the feature includes"],
+  "out_of_scope": ["what it deliberately leaves out"],
+  "depends_on": ["existing-prd-id"],
+  "estimated_units": 3,
+  "estimated_tasks": 12,
+  "body": "Markdown PRD body: overview, goals, user stories, requirements, acceptance criteria, out of scope, open questions"
+}}`)
+	sb.WriteString("\n```\n\n")
+	sb.WriteString("A unit is a group of related tasks that can be implemented in parallel with other units; a task is one atomic, testable change.\n")
+
+	return sb.String()
+}
+
+// parseDraftReply extracts the agent's JSON reply, which may be wrapped in
+// prose or a code fence
+func parseDraftReply(response string) (*draftReply, error) {
+	jsonStr, err := extractJSON(response)
+	if err != nil {
+		return nil, err
+	}
+	var reply draftReply
+	if err := json.Unmarshal([]byte(jsonStr), &reply); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if reply.Proposal == nil && len(reply.Questions) == 0 {
+		return nil, errors.New("reply has neither questions nor a proposal")
+	}
+	return &reply, nil
+}
+
+// prd builds the draft PRD the proposal describes
+func (p *draftProposal) prd(prdDir string) *PRD {
+	body := strings.TrimSpace(p.Body)
+	if !strings.HasPrefix(body, "# ") {
+		body = "# " + p.Title + "\n\n" + body
+	}
+	return &PRD{
+		ID:             p.ID,
+		Title:          p.Title,
+		Status:         PRDStatusDraft,
+		DependsOn:      p.DependsOn,
+		EstimatedUnits: p.EstimatedUnits,
+		EstimatedTasks: p.EstimatedTasks,
+		FilePath:       filepath.Join(prdDir, p.ID+".md"),
+		Body:           "\n" + body + "\n",
+	}
+}
+
+// summary describes the proposal for the author to accept or amend
+func (p *draftProposal) summary() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Proposed PRD: %s (%s)\n", p.Title, p.ID)
+	writeList := func(heading string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n%s:\n", heading)
+		for _, item := range items {
+			fmt.Fprintf(&sb, "  - %s\n", item)
+		}
+	}
+	writeList("Scope", p.Scope)
+	writeList("Out of scope", p.OutOfScope)
+	if len(p.DependsOn) > 0 {
+		fmt.Fprintf(&sb, "\nDepends on: %s\n", strings.Join(p.DependsOn, ", "))
+	}
+	fmt.Fprintf(&sb, "\nEstimate: %d unit(s), %d task(s)\n", p.EstimatedUnits, p.EstimatedTasks)
+	fmt.Fprintf(&sb, "\n%s\n", strings.TrimSpace(p.Body))
+	return sb.String()
+}
+
+// checkProposal rejects PRDs that are invalid, clash with an existing PRD,
+// or depend on PRDs that do not exist
+func checkProposal(prd *PRD, existing []*PRD) error {
+	if err := ValidatePRD(prd); err != nil {
+		return err
+	}
+	ids := make(map[string]bool)
+	for _, e := range existing {
+		ids[e.ID] = true
+	}
+	if ids[prd.ID] {
+		return fmt.Errorf("a PRD with id %q already exists", prd.ID)
+	}
+	if _, err := os.Stat(prd.FilePath); err == nil {
+		return fmt.Errorf("%s already exists", filepath.Base(prd.FilePath))
+	}
+	for _, dep := range prd.DependsOn {
+		if !ids[dep] {
+			return fmt.Errorf("depends_on names unknown PRD %q", dep)
+		}
+	}
+	return nil
+}
+
+// isYes reports whether the author accepted a proposal
+func isYes(answer string) bool {
+	switch strings.ToLower(strings.TrimRight(strings.TrimSpace(answer), ".!")) {
+	case "y", "yes", "ok", "lgtm", "accept", "approve", "write it":
+		return true
+	}
+	return false
+}
+
+func writeTranscript(path string, t *DraftTranscript) error {
+	data, err := yaml.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("marshal transcript: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write transcript: %w", err)
+	}
+	return nil
+}
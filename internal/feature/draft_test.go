@@ -0,0 +1,169 @@
+package feature
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// scriptedInvoker returns its responses in order and records the prompts
+type scriptedInvoker struct {
+	responses []string
+	prompts   []string
+}
+
+func (s *scriptedInvoker) Invoke(ctx context.Context, prompt string) (string, error) {
+	s.prompts = append(s.prompts, prompt)
+	if len(s.prompts) > len(s.responses) {
+		return "", os.ErrDeadlineExceeded
+	}
+	return s.responses[len(s.prompts)-1], nil
+}
+
+// scriptedInterviewer answers questions in order and records what it was shown
+type scriptedInterviewer struct {
+	answers   []string
+	questions []string
+	shown     []string
+}
+
+func (s *scriptedInterviewer) Ask(ctx context.Context, question string) (string, error) {
+	s.questions = append(s.questions, question)
+	answer := s.answers[0]
+	s.answers = s.answers[1:]
+	return answer, nil
+}
+
+func (s *scriptedInterviewer) Show(message string) {
+	s.shown = append(s.shown, message)
+}
+
+const draftProposalJSON = "```json\n" + `{"proposal": {
+  "id": "csv-export",
+  "title": "CSV Export",
+  "scope": ["Export reports as CSV"],
+  "out_of_scope": ["Excel"],
+  "depends_on": ["user-auth"],
+  "estimated_units": 2,
+  "estimated_tasks": 7,
+  "body": "## Overview\n\nLet users download reports."
+}}` + "\n```"
+
+func TestDraft_InterviewsAndWritesPRD(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPRD(t, dir, "user-auth", "User Authentication")
+
+	agent := &scriptedInvoker{responses: []string{
+		`{"questions": ["Who uses the export?", "Which reports?"]}`,
+		draftProposalJSON,
+		draftProposalJSON,
+	}}
+	in := &scriptedInterviewer{answers: []string{"Admins", "All of them", "Mention admins", "yes"}}
+
+	result, err := Draft(context.Background(), agent, in, DraftOptions{Idea: "Export reports", PRDDir: dir})
+	if err != nil {
+		t.Fatalf("Draft failed: %v", err)
+	}
+
+	if len(agent.prompts) != 3 {
+		t.Fatalf("expected 3 agent turns, got %d", len(agent.prompts))
+	}
+	if !strings.Contains(agent.prompts[0], "user-auth: User Authentication") {
+		t.Error("prompt should list existing PRDs")
+	}
+	if !strings.Contains(agent.prompts[1], "**author:** Admins") {
+		t.Error("prompt should include the author's answers")
+	}
+	if !strings.Contains(agent.prompts[2], "**author:** Mention admins") {
+		t.Error("prompt should include requested changes")
+	}
+	if len(in.shown) != 2 || !strings.Contains(in.shown[0], "Estimate: 2 unit(s), 7 task(s)") {
+		t.Errorf("expected the proposal summary to be shown twice, got %v", in.shown)
+	}
+
+	prd, err := ParsePRD(filepath.Join(dir, "csv-export.md"))
+	if err != nil {
+		t.Fatalf("written PRD does not parse: %v", err)
+	}
+	if err := ValidatePRD(prd); err != nil {
+		t.Errorf("written PRD is invalid: %v", err)
+	}
+	if prd.Status != PRDStatusDraft || prd.EstimatedUnits != 2 || prd.EstimatedTasks != 7 {
+		t.Errorf("unexpected frontmatter: %+v", prd)
+	}
+	if !strings.Contains(prd.Body, "# CSV Export") {
+		t.Errorf("body should start with the title, got %q", prd.Body)
+	}
+
+	data, err := os.ReadFile(result.TranscriptPath)
+	if err != nil {
+		t.Fatalf("transcript not written: %v", err)
+	}
+	var transcript DraftTranscript
+	if err := yaml.Unmarshal(data, &transcript); err != nil {
+		t.Fatalf("transcript does not parse: %v", err)
+	}
+	if transcript.PRDID != "csv-export" || transcript.Idea != "Export reports" {
+		t.Errorf("unexpected transcript header: %+v", transcript)
+	}
+	if len(transcript.Turns) != 8 {
+		t.Errorf("expected 8 turns, got %d", len(transcript.Turns))
+	}
+
+	// The transcript is not mistaken for a PRD
+	prds, err := DiscoverPRDs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prds) != 2 {
+		t.Errorf("expected 2 PRDs, got %d", len(prds))
+	}
+}
+
+func TestDraft_RejectsInvalidProposal(t *testing.T) {
+	dir := t.TempDir()
+
+	invalid := `{"proposal": {"id": "Bad ID", "title": "X", "body": "text"}}`
+	valid := `{"proposal": {"id": "good-id", "title": "Good", "body": "text"}}`
+	agent := &scriptedInvoker{responses: []string{invalid, valid}}
+	in := &scriptedInterviewer{answers: []string{"y"}}
+
+	result, err := Draft(context.Background(), agent, in, DraftOptions{Idea: "Something", PRDDir: dir})
+	if err != nil {
+		t.Fatalf("Draft failed: %v", err)
+	}
+	if result.PRD.ID != "good-id" {
+		t.Errorf("expected good-id, got %s", result.PRD.ID)
+	}
+	if len(in.shown) != 1 {
+		t.Errorf("invalid proposal should not be shown, got %d", len(in.shown))
+	}
+	if !strings.Contains(agent.prompts[1], "**choo:** The proposal was rejected") {
+		t.Error("rejection should be fed back to the agent")
+	}
+}
+
+func TestDraft_GivesUpAfterMaxRounds(t *testing.T) {
+	agent := &scriptedInvoker{responses: []string{`{"questions": ["Why?"]}`, `{"questions": ["Why?"]}`}}
+	in := &scriptedInterviewer{answers: []string{"because", "because"}}
+
+	_, err := Draft(context.Background(), agent, in, DraftOptions{Idea: "Something", PRDDir: t.TempDir(), MaxRounds: 2})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(agent.prompts[1], "This is the last turn") {
+		t.Error("final prompt should require a proposal")
+	}
+}
+
+func writeTestPRD(t *testing.T, dir, id, title string) {
+	t.Helper()
+	content := "---\nprd_id: " + id + "\ntitle: " + title + "\nstatus: approved\n---\n\n# " + title + "\n"
+	if err := os.WriteFile(filepath.Join(dir, id+".md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,295 @@
+package feature
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Graph formats accepted by Graph.Render
+const (
+	GraphFormatText    = "text"
+	GraphFormatDOT     = "dot"
+	GraphFormatMermaid = "mermaid"
+)
+
+// Graph is the dependency DAG of PRDs, built from their depends_on fields
+type Graph struct {
+	prds map[string]*PRD
+
+	// edges map from PRD ID to its dependencies
+	edges map[string][]string
+
+	// dependents is reverse edges for dependent lookup
+	dependents map[string][]string
+}
+
+// PRDCycleError indicates PRDs that depend on each other
+type PRDCycleError struct {
+	Cycle []string
+}
+
+func (e *PRDCycleError) Error() string {
+	return fmt.Sprintf("circular PRD dependency detected: %s", strings.Join(e.Cycle, " -> "))
+}
+
+// MissingPRDError indicates a PRD depends on one that does not exist
+type MissingPRDError struct {
+	PRD        string
+	Dependency string
+}
+
+func (e *MissingPRDError) Error() string {
+	return fmt.Sprintf("PRD %q depends on non-existent PRD %q", e.PRD, e.Dependency)
+}
+
+// NewGraph builds the dependency graph of prds.
+// Returns error if cycles or missing dependencies are detected
+func NewGraph(prds []*PRD) (*Graph, error) {
+	g := &Graph{
+		prds:       make(map[string]*PRD),
+		edges:      make(map[string][]string),
+		dependents: make(map[string][]string),
+	}
+	for _, prd := range prds {
+		g.prds[prd.ID] = prd
+	}
+	for _, prd := range prds {
+		deps := append([]string(nil), prd.DependsOn...)
+		sort.Strings(deps)
+		g.edges[prd.ID] = deps
+		for _, dep := range deps {
+			if g.prds[dep] == nil {
+				return nil, &MissingPRDError{PRD: prd.ID, Dependency: dep}
+			}
+			g.dependents[dep] = append(g.dependents[dep], prd.ID)
+		}
+	}
+	for id := range g.dependents {
+		sort.Strings(g.dependents[id])
+	}
+
+	if cycle := g.findCycle(); cycle != nil {
+		return nil, &PRDCycleError{Cycle: cycle}
+	}
+	return g, nil
+}
+
+// PRD returns the PRD with the given ID, or nil
+func (g *Graph) PRD(id string) *PRD {
+	return g.prds[id]
+}
+
+// IDs returns all PRD IDs in sorted order
+func (g *Graph) IDs() []string {
+	ids := make([]string, 0, len(g.prds))
+	for id := range g.prds {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Dependencies returns the PRDs that id directly depends on
+func (g *Graph) Dependencies(id string) []string {
+	return append([]string{}, g.edges[id]...)
+}
+
+// Dependents returns the PRDs that directly depend on id
+func (g *Graph) Dependents(id string) []string {
+	return append([]string{}, g.dependents[id]...)
+}
+
+// UnmetDependencies returns the PRDs that id depends on, directly or
+// transitively, whose features are not complete
+func (g *Graph) UnmetDependencies(id string) []string {
+	seen := make(map[string]bool)
+	var unmet []string
+	var visit func(string)
+	visit = func(id string) {
+		for _, dep := range g.edges[id] {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			if !g.prds[dep].IsComplete() {
+				unmet = append(unmet, dep)
+			}
+			visit(dep)
+		}
+	}
+	visit(id)
+	sort.Strings(unmet)
+	return unmet
+}
+
+// Levels returns PRD IDs grouped by dependency depth.
+// Level 0 contains PRDs with no dependencies
+func (g *Graph) Levels() [][]string {
+	level := make(map[string]int)
+	var depth func(string) int
+	depth = func(id string) int {
+		if l, ok := level[id]; ok {
+			return l
+		}
+		l := 0
+		for _, dep := range g.edges[id] {
+			if d := depth(dep) + 1; d > l {
+				l = d
+			}
+		}
+		level[id] = l
+		return l
+	}
+
+	var levels [][]string
+	for _, id := range g.IDs() {
+		l := depth(id)
+		for len(levels) <= l {
+			levels = append(levels, nil)
+		}
+		levels[l] = append(levels[l], id)
+	}
+	return levels
+}
+
+// findCycle returns a dependency cycle, or nil if the graph is acyclic
+func (g *Graph) findCycle() []string {
+	const (
+		white = 0 // unvisited
+		gray  = 1 // visiting
+		black = 2 // visited
+	)
+	color := make(map[string]int)
+	var stack []string
+
+	var dfs func(string) []string
+	dfs = func(id string) []string {
+		color[id] = gray
+		stack = append(stack, id)
+		for _, dep := range g.edges[id] {
+			switch color[dep] {
+			case gray:
+				// Found cycle: the stack from dep back to here
+				for i, s := range stack {
+					if s == dep {
+						return append(append([]string{}, stack[i:]...), dep)
+					}
+				}
+			case white:
+				if cycle := dfs(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		color[id] = black
+		return nil
+	}
+
+	for _, id := range g.IDs() {
+		if color[id] == white {
+			if cycle := dfs(id); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// Render returns the graph in the given format: text, dot or mermaid
+func (g *Graph) Render(format string) (string, error) {
+	switch format {
+	case GraphFormatText, "":
+		return g.renderText(), nil
+	case GraphFormatDOT:
+		return g.renderDOT(), nil
+	case GraphFormatMermaid:
+		return g.renderMermaid(), nil
+	}
+	return "", fmt.Errorf("unknown graph format %q (want text, dot or mermaid)", format)
+}
+
+// renderText lists PRDs level by level, so each appears after everything
+// it depends on
+func (g *Graph) renderText() string {
+	var sb strings.Builder
+	for i, level := range g.Levels() {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "Level %d\n", i)
+		for _, id := range level {
+			prd := g.prds[id]
+			fmt.Fprintf(&sb, "  [%s] %s: %s", prd.RoadmapStatus(), id, prd.Title)
+			if deps := g.edges[id]; len(deps) > 0 {
+				fmt.Fprintf(&sb, " (depends on %s)", strings.Join(deps, ", "))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// graphColors fills nodes by roadmap status in DOT and Mermaid output
+var graphColors = map[string]string{
+	PRDStatusDraft:      "#e5e7eb",
+	PRDStatusApproved:   "#bfdbfe",
+	PRDStatusInProgress: "#fde68a",
+	PRDStatusComplete:   "#bbf7d0",
+	PRDStatusArchived:   "#d1d5db",
+}
+
+// renderDOT renders the graph for Graphviz, with edges pointing from each
+// PRD to the PRDs that depend on it
+func (g *Graph) renderDOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph prds {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, style=\"rounded,filled\"];\n")
+	for _, id := range g.IDs() {
+		prd := g.prds[id]
+		status := prd.RoadmapStatus()
+		fmt.Fprintf(&sb, "  %q [label=%q, fillcolor=%q];\n",
+			id, fmt.Sprintf("%s\n%s\n(%s)", id, prd.Title, status), graphColors[status])
+	}
+	for _, id := range g.IDs() {
+		for _, dep := range g.edges[id] {
+			fmt.Fprintf(&sb, "  %q -> %q;\n", dep, id)
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// renderMermaid renders the graph as a Mermaid flowchart, with edges
+// pointing from each PRD to the PRDs that depend on it
+func (g *Graph) renderMermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for _, id := range g.IDs() {
+		prd := g.prds[id]
+		fmt.Fprintf(&sb, "  %s[\"%s<br/>%s\"]:::%s\n",
+			mermaidID(id), id, strings.ReplaceAll(prd.Title, `"`, "#quot;"), prd.RoadmapStatus())
+	}
+	for _, id := range g.IDs() {
+		for _, dep := range g.edges[id] {
+			fmt.Fprintf(&sb, "  %s --> %s\n", mermaidID(dep), mermaidID(id))
+		}
+	}
+	statuses := make([]string, 0, len(graphColors))
+	for status := range graphColors {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(&sb, "  classDef %s fill:%s\n", status, graphColors[status])
+	}
+	return sb.String()
+}
+
+// mermaidID makes a PRD ID safe as a Mermaid node ID: hyphens can be read
+// as part of an arrow, and a few words such as "end" are reserved
+func mermaidID(id string) string {
+	return "prd_" + strings.ReplaceAll(id, "-", "_")
+}
@@ -0,0 +1,101 @@
+package feature
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func graphTestPRDs() []*PRD {
+	return []*PRD{
+		{ID: "auth", Title: "Auth", Status: PRDStatusComplete},
+		{ID: "billing", Title: "Billing", Status: PRDStatusApproved, DependsOn: []string{"auth"}},
+		{ID: "reports", Title: "Reports", Status: PRDStatusApproved, FeatureStatus: FeatureStatusInProgress, DependsOn: []string{"auth"}},
+		{ID: "invoices", Title: "Invoices", Status: PRDStatusDraft, DependsOn: []string{"billing", "reports"}},
+	}
+}
+
+func TestNewGraph_Levels(t *testing.T) {
+	g, err := NewGraph(graphTestPRDs())
+	if err != nil {
+		t.Fatalf("NewGraph failed: %v", err)
+	}
+
+	want := [][]string{{"auth"}, {"billing", "reports"}, {"invoices"}}
+	if got := g.Levels(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Levels() = %v, want %v", got, want)
+	}
+	if got := g.Dependents("auth"); !reflect.DeepEqual(got, []string{"billing", "reports"}) {
+		t.Errorf("Dependents(auth) = %v", got)
+	}
+}
+
+func TestNewGraph_Cycle(t *testing.T) {
+	prds := []*PRD{
+		{ID: "a", DependsOn: []string{"b"}},
+		{ID: "b", DependsOn: []string{"c"}},
+		{ID: "c", DependsOn: []string{"a"}},
+	}
+	_, err := NewGraph(prds)
+
+	var cycleErr *PRDCycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected PRDCycleError, got %v", err)
+	}
+	if want := []string{"a", "b", "c", "a"}; !reflect.DeepEqual(cycleErr.Cycle, want) {
+		t.Errorf("Cycle = %v, want %v", cycleErr.Cycle, want)
+	}
+}
+
+func TestNewGraph_MissingDependency(t *testing.T) {
+	_, err := NewGraph([]*PRD{{ID: "a", DependsOn: []string{"ghost"}}})
+
+	var missingErr *MissingPRDError
+	if !errors.As(err, &missingErr) || missingErr.Dependency != "ghost" {
+		t.Fatalf("expected MissingPRDError for ghost, got %v", err)
+	}
+}
+
+func TestGraph_UnmetDependencies(t *testing.T) {
+	g, err := NewGraph(graphTestPRDs())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := g.UnmetDependencies("billing"); len(got) != 0 {
+		t.Errorf("billing should be ready, got unmet %v", got)
+	}
+	if got := g.UnmetDependencies("invoices"); !reflect.DeepEqual(got, []string{"billing", "reports"}) {
+		t.Errorf("UnmetDependencies(invoices) = %v", got)
+	}
+}
+
+func TestGraph_Render(t *testing.T) {
+	g, err := NewGraph(graphTestPRDs())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := g.Render(GraphFormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, "[in_progress] reports: Reports (depends on auth)") {
+		t.Errorf("text output missing reports line:\n%s", text)
+	}
+
+	dot, _ := g.Render(GraphFormatDOT)
+	if !strings.Contains(dot, `"auth" -> "billing";`) || !strings.HasPrefix(dot, "digraph prds {") {
+		t.Errorf("unexpected DOT output:\n%s", dot)
+	}
+
+	mermaid, _ := g.Render(GraphFormatMermaid)
+	if !strings.Contains(mermaid, "prd_billing --> prd_invoices") || !strings.Contains(mermaid, ":::complete") {
+		t.Errorf("unexpected Mermaid output:\n%s", mermaid)
+	}
+
+	if _, err := g.Render("svg"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
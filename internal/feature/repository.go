@@ -3,6 +3,7 @@ package feature
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/RevCBH/choo/internal/events"
@@ -124,6 +125,31 @@ func (r *PRDRepository) CheckDrift(id string) (bool, error) {
 	return cached.BodyHash != current.BodyHash, nil
 }
 
+// WritePRD writes prd to prd.FilePath as frontmatter followed by its body.
+// An existing file is never overwritten.
+func WritePRD(prd *PRD) error {
+	if err := ValidatePRD(prd); err != nil {
+		return err
+	}
+	frontmatter, err := yaml.Marshal(prd)
+	if err != nil {
+		return fmt.Errorf("marshal frontmatter: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(prd.FilePath), 0755); err != nil {
+		return fmt.Errorf("create PRD directory: %w", err)
+	}
+
+	f, err := os.OpenFile(prd.FilePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return fmt.Errorf("create PRD file: %w", err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintf(f, "---\n%s---\n%s", frontmatter, prd.Body); err != nil {
+		return fmt.Errorf("write PRD file: %w", err)
+	}
+	return nil
+}
+
 // WritePRDFrontmatter updates the frontmatter in a PRD file
 // Preserves the body content unchanged
 func WritePRDFrontmatter(prd *PRD) error {
@@ -19,6 +19,9 @@ type PRD struct {
 	EstimatedUnits int `yaml:"estimated_units,omitempty"`
 	EstimatedTasks int `yaml:"estimated_tasks,omitempty"`
 
+	// Source links a PRD imported from an issue tracker back to its issue
+	Source *PRDSource `yaml:"source,omitempty"`
+
 	// Orchestrator-managed fields (updated at runtime)
 	FeatureBranch        string     `yaml:"feature_branch,omitempty"`
 	FeatureStatus        string     `yaml:"feature_status,omitempty"`
@@ -36,6 +39,13 @@ type PRD struct {
 	Units []Unit `yaml:"-"` // Units associated with this PRD
 }
 
+// PRDSource identifies the tracker issue a PRD was imported from
+type PRDSource struct {
+	Type string `yaml:"type"` // github | jira | linear
+	Key  string `yaml:"key"`  // issue number or key, e.g. "42" or "ENG-123"
+	URL  string `yaml:"url,omitempty"`
+}
+
 // Unit represents a work unit associated with a PRD
 type Unit struct {
 	Name   string
@@ -125,3 +135,24 @@ func IsValidFeatureStatus(s string) bool {
 	}
 	return false
 }
+
+// IsComplete reports whether the PRD's feature has shipped, either marked
+// complete by hand or closed out after its PR merged
+func (p *PRD) IsComplete() bool {
+	return p.Status == PRDStatusComplete || p.Status == PRDStatusArchived ||
+		p.FeatureStatus == FeatureStatusComplete
+}
+
+// RoadmapStatus returns the PRD's status as shown on the roadmap: its
+// status field, advanced by the progress of a feature started from it
+func (p *PRD) RoadmapStatus() string {
+	switch {
+	case p.Status == PRDStatusArchived:
+		return PRDStatusArchived
+	case p.IsComplete():
+		return PRDStatusComplete
+	case p.FeatureStatus != "" && p.FeatureStatus != FeatureStatusPending:
+		return PRDStatusInProgress
+	}
+	return p.Status
+}
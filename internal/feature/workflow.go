@@ -7,8 +7,8 @@ import (
 
 	"github.com/RevCBH/choo/internal/discovery"
 	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/forge"
 	"github.com/RevCBH/choo/internal/git"
-	"github.com/RevCBH/choo/internal/github"
 )
 
 // Workflow manages the feature development lifecycle
@@ -19,7 +19,7 @@ type Workflow struct {
 	discovery           *discovery.Discovery
 	events              *events.Bus
 	git                 *git.Client
-	github              *github.PRClient
+	forge               forge.Forge
 	drift               *DriftDetector
 	completion          *CompletionChecker
 	reviewCycle         *ReviewCycle
@@ -36,13 +36,13 @@ type WorkflowConfig struct {
 
 // WorkflowDeps holds external dependencies
 type WorkflowDeps struct {
-	BranchMgr  *BranchManager
-	Reviewer   Reviewer
-	Discovery  *discovery.Discovery
-	Events     *events.Bus
-	Git        *git.Client
-	GitHub     *github.PRClient
-	Claude     ClaudeClient
+	BranchMgr *BranchManager
+	Reviewer  Reviewer
+	Discovery *discovery.Discovery
+	Events    *events.Bus
+	Git       *git.Client
+	Forge     forge.Forge
+	Claude    ClaudeClient
 }
 
 // NewWorkflow creates a new feature workflow manager
@@ -58,7 +58,7 @@ func NewWorkflow(prd *PRD, cfg WorkflowConfig, deps WorkflowDeps) *Workflow {
 		discovery:           deps.Discovery,
 		events:              deps.Events,
 		git:                 deps.Git,
-		github:              deps.GitHub,
+		forge:               deps.Forge,
 		maxReviewIterations: cfg.MaxReviewIterations,
 		status:              StatusPending,
 	}
@@ -67,8 +67,8 @@ func NewWorkflow(prd *PRD, cfg WorkflowConfig, deps WorkflowDeps) *Workflow {
 	w.drift = NewDriftDetectorFromPRD(prd, deps.Claude)
 
 	// Initialize completion checker
-	if deps.GitHub != nil {
-		w.completion = NewCompletionChecker(prd, deps.Git, deps.GitHub, deps.Events)
+	if deps.Forge != nil {
+		w.completion = NewCompletionChecker(prd, deps.Git, deps.Forge, deps.Events)
 	}
 
 	// Initialize review cycle
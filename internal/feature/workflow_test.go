@@ -34,7 +34,7 @@ func newTestWorkflow() *Workflow {
 		Discovery: &discovery.Discovery{},
 		Events:    bus,
 		Git:       &git.Client{WorktreePath: "/tmp/test"},
-		GitHub:    &github.PRClient{},
+		Forge:     &github.PRClient{},
 		Claude:    &mockClaudeClient{},
 	}
 
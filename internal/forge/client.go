@@ -0,0 +1,106 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// apiClient issues JSON requests against a forge REST API with the same
+// rate limit and 5xx retry policy as the GitHub client
+type apiClient struct {
+	httpClient  *http.Client
+	baseURL     string
+	authHeader  string
+	authValue   string
+	backoff     time.Duration // initial retry backoff
+	maxAttempts int
+}
+
+func newAPIClient(baseURL, authHeader, authValue string) *apiClient {
+	return &apiClient{
+		httpClient:  &http.Client{},
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		authHeader:  authHeader,
+		authValue:   authValue,
+		backoff:     time.Second,
+		maxAttempts: 6,
+	}
+}
+
+// do sends a request to path (relative to the base URL) and decodes the
+// JSON response into out when out is non-nil
+func (c *apiClient) do(ctx context.Context, method, path string, body, out any) error {
+	var jsonData []byte
+	if body != nil {
+		var err error
+		if jsonData, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+
+	backoff := c.backoff
+	for attempt := 1; ; attempt++ {
+		var reqBody io.Reader
+		if jsonData != nil {
+			reqBody = bytes.NewReader(jsonData)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set(c.authHeader, c.authValue)
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to execute request: %w", err)
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			defer resp.Body.Close()
+			if out == nil {
+				return nil
+			}
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+			return nil
+		}
+
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		if !retryable {
+			defer resp.Body.Close()
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		}
+		resp.Body.Close()
+
+		if attempt == c.maxAttempts {
+			return fmt.Errorf("request failed after %d attempts: status %d", attempt, resp.StatusCode)
+		}
+
+		wait := backoff
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil {
+				wait = time.Duration(seconds) * time.Second
+			}
+		}
+		select {
+		case <-time.After(wait):
+			backoff *= 2
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// committer identity for merge commits made by the server
+var committer = []string{"-c", "user.name=choo fake forge", "-c", "user.email=forge@localhost"}
+
+// resolve returns the commit SHA for a branch name or SHA
+func (s *Server) resolve(ref string) (string, error) {
+	out, err := s.git.Exec(context.Background(), s.cfg.BarePath, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown ref %q", ref)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// defaultBranch is the branch HEAD points to in the bare repository
+func (s *Server) defaultBranch() (string, error) {
+	out, err := s.git.Exec(context.Background(), s.cfg.BarePath, "symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// merge lands head onto base and returns the new commit. Squash (the
+// default) makes a single-parent commit of the merged tree; merge keeps
+// head as a second parent. The tree is computed with merge-tree, so no
+// worktree is needed and conflicts leave the repository untouched.
+func (s *Server) merge(head, base, method, title, body string) (string, error) {
+	ctx := context.Background()
+
+	baseSHA, err := s.resolve("refs/heads/" + base)
+	if err != nil {
+		return "", err
+	}
+	headSHA, err := s.resolve("refs/heads/" + head)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := s.git.Exec(ctx, s.cfg.BarePath, "merge-tree", "--write-tree", baseSHA, headSHA)
+	if err != nil {
+		return "", fmt.Errorf("Pull Request is not mergeable: %s conflicts with %s", head, base)
+	}
+	tree := strings.TrimSpace(strings.SplitN(out, "\n", 2)[0])
+
+	args := append(append([]string{}, committer...), "commit-tree", tree, "-p", baseSHA)
+	switch method {
+	case "", "squash":
+	case "merge":
+		args = append(args, "-p", headSHA)
+	default:
+		return "", fmt.Errorf("merge method %q is not supported", method)
+	}
+	args = append(args, "-m", title)
+	if body != "" {
+		args = append(args, "-m", body)
+	}
+
+	out, err = s.git.Exec(ctx, s.cfg.BarePath, args...)
+	if err != nil {
+		return "", err
+	}
+	sha := strings.TrimSpace(out)
+
+	// Compare-and-swap so a concurrent push to base is not lost
+	if _, err := s.git.Exec(ctx, s.cfg.BarePath, "update-ref", "refs/heads/"+base, sha, baseSHA); err != nil {
+		return "", fmt.Errorf("Base branch was modified. Review and try the merge again.")
+	}
+	return sha, nil
+}
@@ -0,0 +1,835 @@
+// Package fake is an in-process stand-in for GitHub. It speaks the subset
+// of the REST API used by internal/github (pulls, reviews, review comments
+// and their replies, reactions, check runs and merges), plus the GraphQL
+// review thread queries, and merges into a local bare repository, so the PR
+// lifecycle can run in CI and offline.
+//
+// The token in the Authorization header is taken as the caller's login, so
+// a reviewer can be simulated by sending requests with their name as token.
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/git"
+)
+
+// Config identifies the repository the server hosts
+type Config struct {
+	Owner    string
+	Repo     string
+	BarePath string // bare repository that branches are pushed to
+}
+
+// Server is a fake GitHub REST API for a single repository
+type Server struct {
+	cfg Config
+	git git.Runner
+	mux *http.ServeMux
+
+	mu        sync.Mutex
+	nextID    int64
+	pulls     map[int]*Pull
+	comments  map[int][]*Comment
+	reactions map[int][]*Reaction
+	reviews   map[int][]*Review
+	checkRuns []*CheckRun
+}
+
+// Pull is a pull request
+type Pull struct {
+	Number         int
+	Title          string
+	Body           string
+	Head           string
+	Base           string
+	User           string
+	State          string // open or closed
+	Merged         bool
+	MergeCommitSHA string
+	CreatedAt      time.Time
+	MergedAt       time.Time
+}
+
+// Comment is a review comment on a pull request
+type Comment struct {
+	ID        int64
+	Path      string
+	Line      int
+	Body      string
+	User      string
+	CreatedAt time.Time
+	InReplyTo int64 // comment that opened the thread, for replies
+	Resolved  bool  // thread resolution, tracked on the opening comment
+}
+
+// Reaction is an emoji reaction on a pull request
+type Reaction struct {
+	ID        int64
+	Content   string
+	User      string
+	CreatedAt time.Time
+}
+
+// Review is a submitted pull request review
+type Review struct {
+	ID          int64
+	State       string // APPROVED, CHANGES_REQUESTED or COMMENTED
+	Body        string
+	User        string
+	CommitID    string
+	SubmittedAt time.Time
+}
+
+// CheckRun is a CI check on a commit
+type CheckRun struct {
+	ID         int64
+	Name       string
+	HeadSHA    string
+	Status     string // queued, in_progress or completed
+	Conclusion string // set once completed
+	Title      string // output title
+	Summary    string // output summary
+}
+
+// New creates a server for the bare repository in cfg
+func New(cfg Config) (*Server, error) {
+	if cfg.Owner == "" || cfg.Repo == "" {
+		return nil, fmt.Errorf("owner and repo are required")
+	}
+	if cfg.BarePath == "" {
+		return nil, fmt.Errorf("bare repository path is required")
+	}
+
+	s := &Server{
+		cfg:       cfg,
+		git:       git.DefaultRunner(),
+		mux:       http.NewServeMux(),
+		pulls:     make(map[int]*Pull),
+		comments:  make(map[int][]*Comment),
+		reactions: make(map[int][]*Reaction),
+		reviews:   make(map[int][]*Review),
+	}
+	if _, err := s.git.Exec(context.Background(), cfg.BarePath, "rev-parse", "--git-dir"); err != nil {
+		return nil, fmt.Errorf("not a git repository: %s: %w", cfg.BarePath, err)
+	}
+
+	prefix := "/repos/{owner}/{repo}"
+	s.mux.HandleFunc("GET "+prefix, s.handleGetRepo)
+	s.mux.HandleFunc("GET "+prefix+"/pulls", s.handleListPulls)
+	s.mux.HandleFunc("POST "+prefix+"/pulls", s.handleCreatePull)
+	s.mux.HandleFunc("GET "+prefix+"/pulls/{number}", s.handleGetPull)
+	s.mux.HandleFunc("PATCH "+prefix+"/pulls/{number}", s.handleUpdatePull)
+	s.mux.HandleFunc("PUT "+prefix+"/pulls/{number}/merge", s.handleMerge)
+	s.mux.HandleFunc("GET "+prefix+"/pulls/{number}/comments", s.handleListComments)
+	s.mux.HandleFunc("POST "+prefix+"/pulls/{number}/comments", s.handleCreateComment)
+	s.mux.HandleFunc("POST "+prefix+"/pulls/{number}/comments/{id}/replies", s.handleCreateReply)
+	s.mux.HandleFunc("GET "+prefix+"/pulls/{number}/reviews", s.handleListReviews)
+	s.mux.HandleFunc("POST "+prefix+"/pulls/{number}/reviews", s.handleCreateReview)
+	s.mux.HandleFunc("GET "+prefix+"/issues/{number}/reactions", s.handleListReactions)
+	s.mux.HandleFunc("POST "+prefix+"/issues/{number}/reactions", s.handleCreateReaction)
+	// Branch refs may contain slashes, so the ref is split off by hand
+	s.mux.HandleFunc("GET "+prefix+"/commits/{path...}", s.handleListCheckRuns)
+	s.mux.HandleFunc("POST "+prefix+"/check-runs", s.handleCreateCheckRun)
+	s.mux.HandleFunc("GET "+prefix+"/check-runs/{id}/annotations", s.handleListAnnotations)
+	s.mux.HandleFunc("POST /graphql", s.handleGraphQL)
+
+	return s, nil
+}
+
+// ServeHTTP authenticates the caller and routes the request
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if login(r) == "" {
+		writeError(w, http.StatusUnauthorized, "Requires authentication")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// login extracts the caller from "Bearer <token>" or "token <token>"
+func login(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	for _, scheme := range []string{"Bearer ", "token "} {
+		if strings.HasPrefix(auth, scheme) {
+			return strings.TrimSpace(strings.TrimPrefix(auth, scheme))
+		}
+	}
+	return ""
+}
+
+// repoMatches reports whether the request targets the hosted repository,
+// writing a 404 if not
+func (s *Server) repoMatches(w http.ResponseWriter, r *http.Request) bool {
+	if r.PathValue("owner") != s.cfg.Owner || r.PathValue("repo") != s.cfg.Repo {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return false
+	}
+	return true
+}
+
+// pull looks up the pull request in the path, writing a 404 if missing.
+// Callers must hold s.mu.
+func (s *Server) pull(w http.ResponseWriter, r *http.Request) *Pull {
+	if !s.repoMatches(w, r) {
+		return nil
+	}
+	n, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil || s.pulls[n] == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil
+	}
+	return s.pulls[n]
+}
+
+func (s *Server) id() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func baseURL(r *http.Request) string {
+	return "http://" + r.Host
+}
+
+func (s *Server) handleGetRepo(w http.ResponseWriter, r *http.Request) {
+	if !s.repoMatches(w, r) {
+		return
+	}
+	branch, err := s.defaultBranch()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"name":           s.cfg.Repo,
+		"full_name":      s.cfg.Owner + "/" + s.cfg.Repo,
+		"default_branch": branch,
+	})
+}
+
+func (s *Server) handleListPulls(w http.ResponseWriter, r *http.Request) {
+	if !s.repoMatches(w, r) {
+		return
+	}
+	state := r.URL.Query().Get("state")
+	if state == "" {
+		state = "open"
+	}
+	// head is "owner:branch"
+	head := r.URL.Query().Get("head")
+	if i := strings.Index(head, ":"); i >= 0 {
+		head = head[i+1:]
+	}
+	base := r.URL.Query().Get("base")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []map[string]any{}
+	for _, n := range s.pullNumbers() {
+		p := s.pulls[n]
+		if (state != "all" && p.State != state) || (head != "" && p.Head != head) || (base != "" && p.Base != base) {
+			continue
+		}
+		out = append(out, s.pullJSON(r, p))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleCreatePull(w http.ResponseWriter, r *http.Request) {
+	if !s.repoMatches(w, r) {
+		return
+	}
+	var req struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+		Head  string `json:"head"`
+		Base  string `json:"base"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if i := strings.Index(req.Head, ":"); i >= 0 {
+		req.Head = req.Head[i+1:]
+	}
+	if req.Title == "" || req.Head == "" || req.Base == "" {
+		writeError(w, http.StatusUnprocessableEntity, "title, head and base are required")
+		return
+	}
+	for _, branch := range []string{req.Head, req.Base} {
+		if _, err := s.resolve(branch); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("branch %q does not exist", branch))
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.pulls {
+		if p.State == "open" && p.Head == req.Head && p.Base == req.Base {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("A pull request already exists for %s:%s.", s.cfg.Owner, req.Head))
+			return
+		}
+	}
+
+	p := &Pull{
+		Number:    len(s.pulls) + 1,
+		Title:     req.Title,
+		Body:      req.Body,
+		Head:      req.Head,
+		Base:      req.Base,
+		User:      login(r),
+		State:     "open",
+		CreatedAt: time.Now().UTC(),
+	}
+	s.pulls[p.Number] = p
+	writeJSON(w, http.StatusCreated, s.pullJSON(r, p))
+}
+
+func (s *Server) handleGetPull(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p := s.pull(w, r); p != nil {
+		writeJSON(w, http.StatusOK, s.pullJSON(r, p))
+	}
+}
+
+func (s *Server) handleUpdatePull(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Title *string `json:"title"`
+		Body  *string `json:"body"`
+		Base  *string `json:"base"`
+		State *string `json:"state"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Base != nil {
+		if _, err := s.resolve(*req.Base); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("branch %q does not exist", *req.Base))
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pull(w, r)
+	if p == nil {
+		return
+	}
+	if req.Title != nil {
+		p.Title = *req.Title
+	}
+	if req.Body != nil {
+		p.Body = *req.Body
+	}
+	if req.Base != nil {
+		p.Base = *req.Base
+	}
+	if req.State != nil && !p.Merged {
+		p.State = *req.State
+	}
+	writeJSON(w, http.StatusOK, s.pullJSON(r, p))
+}
+
+func (s *Server) handleMerge(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		MergeMethod string `json:"merge_method"`
+		CommitTitle string `json:"commit_title"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pull(w, r)
+	if p == nil {
+		return
+	}
+	if p.State != "open" {
+		writeError(w, http.StatusMethodNotAllowed, "Pull Request is not mergeable")
+		return
+	}
+
+	title := req.CommitTitle
+	if title == "" {
+		title = fmt.Sprintf("%s (#%d)", p.Title, p.Number)
+	}
+	sha, err := s.merge(p.Head, p.Base, req.MergeMethod, title, p.Body)
+	if err != nil {
+		writeError(w, http.StatusMethodNotAllowed, err.Error())
+		return
+	}
+
+	p.State = "closed"
+	p.Merged = true
+	p.MergeCommitSHA = sha
+	p.MergedAt = time.Now().UTC()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"sha":     sha,
+		"merged":  true,
+		"message": "Pull Request successfully merged",
+	})
+}
+
+func (s *Server) handleListComments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pull(w, r)
+	if p == nil {
+		return
+	}
+	out := []map[string]any{}
+	for _, c := range s.comments[p.Number] {
+		out = append(out, commentJSON(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleCreateComment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Body string `json:"body"`
+		Path string `json:"path"`
+		Line int    `json:"line"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pull(w, r)
+	if p == nil {
+		return
+	}
+	c := &Comment{ID: s.id(), Path: req.Path, Line: req.Line, Body: req.Body, User: login(r), CreatedAt: time.Now().UTC()}
+	s.comments[p.Number] = append(s.comments[p.Number], c)
+	writeJSON(w, http.StatusCreated, commentJSON(c))
+}
+
+func (s *Server) handleCreateReply(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Body string `json:"body"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pull(w, r)
+	if p == nil {
+		return
+	}
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	parent := s.comment(p.Number, id)
+	if parent == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	root := parent.ID
+	if parent.InReplyTo != 0 {
+		root = parent.InReplyTo
+	}
+	c := &Comment{ID: s.id(), Path: parent.Path, Line: parent.Line, Body: req.Body, User: login(r), CreatedAt: time.Now().UTC(), InReplyTo: root}
+	s.comments[p.Number] = append(s.comments[p.Number], c)
+	writeJSON(w, http.StatusCreated, commentJSON(c))
+}
+
+// comment finds a review comment on a pull. Callers must hold s.mu.
+func (s *Server) comment(number int, id int64) *Comment {
+	for _, c := range s.comments[number] {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// handleGraphQL answers the two review thread operations internal/github
+// sends: listing a pull's threads and resolving one. Thread IDs are
+// "PRRT_" followed by the ID of the comment that opened the thread.
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query     string `json:"query"`
+		Variables struct {
+			Owner  string `json:"owner"`
+			Repo   string `json:"repo"`
+			Number int    `json:"number"`
+			ID     string `json:"id"`
+		} `json:"variables"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.Contains(req.Query, "resolveReviewThread"):
+		id, err := strconv.ParseInt(strings.TrimPrefix(req.Variables.ID, "PRRT_"), 10, 64)
+		var root *Comment
+		for _, n := range s.pullNumbers() {
+			if c := s.comment(n, id); c != nil && c.InReplyTo == 0 {
+				root = c
+			}
+		}
+		if err != nil || root == nil {
+			writeGraphQLError(w, "Could not resolve to a node with the global id of '"+req.Variables.ID+"'")
+			return
+		}
+		root.Resolved = true
+		writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{
+			"resolveReviewThread": map[string]any{"thread": map[string]any{"isResolved": true}},
+		}})
+
+	case strings.Contains(req.Query, "reviewThreads"):
+		p := s.pulls[req.Variables.Number]
+		if req.Variables.Owner != s.cfg.Owner || req.Variables.Repo != s.cfg.Repo || p == nil {
+			writeGraphQLError(w, "Could not resolve to a PullRequest")
+			return
+		}
+		threads := []map[string]any{}
+		for _, root := range s.comments[p.Number] {
+			if root.InReplyTo != 0 {
+				continue
+			}
+			ids := []map[string]any{{"databaseId": root.ID}}
+			for _, c := range s.comments[p.Number] {
+				if c.InReplyTo == root.ID {
+					ids = append(ids, map[string]any{"databaseId": c.ID})
+				}
+			}
+			threads = append(threads, map[string]any{
+				"id":         fmt.Sprintf("PRRT_%d", root.ID),
+				"isResolved": root.Resolved,
+				"comments":   map[string]any{"nodes": ids},
+			})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{
+			"repository": map[string]any{"pullRequest": map[string]any{"reviewThreads": map[string]any{"nodes": threads}}},
+		}})
+
+	default:
+		writeGraphQLError(w, "unsupported query")
+	}
+}
+
+func (s *Server) handleListReviews(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pull(w, r)
+	if p == nil {
+		return
+	}
+	out := []map[string]any{}
+	for _, rv := range s.reviews[p.Number] {
+		out = append(out, reviewJSON(rv))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleCreateReview(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Body     string `json:"body"`
+		Event    string `json:"event"` // APPROVE, REQUEST_CHANGES or COMMENT
+		Comments []struct {
+			Path string `json:"path"`
+			Line int    `json:"line"`
+			Body string `json:"body"`
+		} `json:"comments"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	states := map[string]string{"APPROVE": "APPROVED", "REQUEST_CHANGES": "CHANGES_REQUESTED", "COMMENT": "COMMENTED", "": "COMMENTED"}
+	state, ok := states[req.Event]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("unknown review event %q", req.Event))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pull(w, r)
+	if p == nil {
+		return
+	}
+	headSHA, _ := s.resolve(p.Head)
+	now := time.Now().UTC()
+	rv := &Review{ID: s.id(), State: state, Body: req.Body, User: login(r), CommitID: headSHA, SubmittedAt: now}
+	s.reviews[p.Number] = append(s.reviews[p.Number], rv)
+	for _, c := range req.Comments {
+		s.comments[p.Number] = append(s.comments[p.Number], &Comment{
+			ID: s.id(), Path: c.Path, Line: c.Line, Body: c.Body, User: rv.User, CreatedAt: now,
+		})
+	}
+	writeJSON(w, http.StatusOK, reviewJSON(rv))
+}
+
+func (s *Server) handleListReactions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pull(w, r)
+	if p == nil {
+		return
+	}
+	out := []map[string]any{}
+	for _, rc := range s.reactions[p.Number] {
+		out = append(out, map[string]any{
+			"id":         rc.ID,
+			"content":    rc.Content,
+			"user":       map[string]any{"login": rc.User},
+			"created_at": rc.CreatedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleCreateReaction(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Content string `json:"content"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pull(w, r)
+	if p == nil {
+		return
+	}
+	rc := &Reaction{ID: s.id(), Content: req.Content, User: login(r), CreatedAt: time.Now().UTC()}
+	s.reactions[p.Number] = append(s.reactions[p.Number], rc)
+	writeJSON(w, http.StatusCreated, map[string]any{"id": rc.ID, "content": rc.Content})
+}
+
+func (s *Server) handleListCheckRuns(w http.ResponseWriter, r *http.Request) {
+	if !s.repoMatches(w, r) {
+		return
+	}
+	ref, ok := strings.CutSuffix(r.PathValue("path"), "/check-runs")
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	sha, err := s.resolve(ref)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("No commit found for SHA: %s", ref))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runs := []map[string]any{}
+	for _, run := range s.checkRuns {
+		if run.HeadSHA == sha {
+			runs = append(runs, checkRunJSON(run))
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"total_count": len(runs), "check_runs": runs})
+}
+
+// handleCreateCheckRun records a check run. Posting an existing name for
+// the same commit updates it, so a CI script can move a check from
+// in_progress to completed.
+func (s *Server) handleCreateCheckRun(w http.ResponseWriter, r *http.Request) {
+	if !s.repoMatches(w, r) {
+		return
+	}
+	var req struct {
+		Name       string `json:"name"`
+		HeadSHA    string `json:"head_sha"`
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+		Output     struct {
+			Title   string `json:"title"`
+			Summary string `json:"summary"`
+		} `json:"output"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	sha, err := s.resolve(req.HeadSHA)
+	if err != nil || req.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name and a valid head_sha are required")
+		return
+	}
+	if req.Status == "" {
+		req.Status = "queued"
+	}
+	if req.Conclusion != "" {
+		req.Status = "completed"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, run := range s.checkRuns {
+		if run.HeadSHA == sha && run.Name == req.Name {
+			run.Status, run.Conclusion = req.Status, req.Conclusion
+			run.Title, run.Summary = req.Output.Title, req.Output.Summary
+			writeJSON(w, http.StatusOK, checkRunJSON(run))
+			return
+		}
+	}
+	run := &CheckRun{ID: s.id(), Name: req.Name, HeadSHA: sha, Status: req.Status, Conclusion: req.Conclusion,
+		Title: req.Output.Title, Summary: req.Output.Summary}
+	s.checkRuns = append(s.checkRuns, run)
+	writeJSON(w, http.StatusCreated, checkRunJSON(run))
+}
+
+// handleListAnnotations returns no annotations; check runs posted to the
+// fake carry their detail in the output summary
+func (s *Server) handleListAnnotations(w http.ResponseWriter, r *http.Request) {
+	if !s.repoMatches(w, r) {
+		return
+	}
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, run := range s.checkRuns {
+		if run.ID == id {
+			writeJSON(w, http.StatusOK, []any{})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+// Pulls returns a snapshot of all pull requests, ordered by number
+func (s *Server) Pulls() []Pull {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Pull
+	for _, n := range s.pullNumbers() {
+		out = append(out, *s.pulls[n])
+	}
+	return out
+}
+
+// Comments returns a snapshot of a pull request's review comments in the
+// order they were posted
+func (s *Server) Comments(number int) []Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Comment
+	for _, c := range s.comments[number] {
+		out = append(out, *c)
+	}
+	return out
+}
+
+func (s *Server) pullNumbers() []int {
+	nums := make([]int, 0, len(s.pulls))
+	for n := range s.pulls {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+func (s *Server) pullJSON(r *http.Request, p *Pull) map[string]any {
+	headSHA, _ := s.resolve(p.Head)
+	baseSHA, _ := s.resolve(p.Base)
+	out := map[string]any{
+		"number":           p.Number,
+		"html_url":         fmt.Sprintf("%s/%s/%s/pull/%d", baseURL(r), s.cfg.Owner, s.cfg.Repo, p.Number),
+		"title":            p.Title,
+		"body":             p.Body,
+		"state":            p.State,
+		"merged":           p.Merged,
+		"merge_commit_sha": p.MergeCommitSHA,
+		"user":             map[string]any{"login": p.User},
+		"head":             map[string]any{"ref": p.Head, "sha": headSHA},
+		"base":             map[string]any{"ref": p.Base, "sha": baseSHA},
+		"created_at":       p.CreatedAt,
+	}
+	if p.Merged {
+		out["merged_at"] = p.MergedAt
+	}
+	return out
+}
+
+func commentJSON(c *Comment) map[string]any {
+	out := map[string]any{
+		"id":         c.ID,
+		"path":       c.Path,
+		"body":       c.Body,
+		"user":       map[string]any{"login": c.User},
+		"created_at": c.CreatedAt,
+	}
+	if c.Line > 0 {
+		out["line"] = c.Line
+	}
+	if c.InReplyTo != 0 {
+		out["in_reply_to_id"] = c.InReplyTo
+	}
+	return out
+}
+
+func reviewJSON(rv *Review) map[string]any {
+	return map[string]any{
+		"id":           rv.ID,
+		"state":        rv.State,
+		"body":         rv.Body,
+		"user":         map[string]any{"login": rv.User},
+		"commit_id":    rv.CommitID,
+		"submitted_at": rv.SubmittedAt,
+	}
+}
+
+func checkRunJSON(run *CheckRun) map[string]any {
+	out := map[string]any{
+		"id":       run.ID,
+		"name":     run.Name,
+		"head_sha": run.HeadSHA,
+		"status":   run.Status,
+		"output":   map[string]any{"title": run.Title, "summary": run.Summary},
+	}
+	if run.Conclusion != "" {
+		out["conclusion"] = run.Conclusion
+	}
+	return out
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if r.ContentLength == 0 {
+		return true
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{"message": message})
+}
+
+// writeGraphQLError reports a GraphQL error, which GitHub sends with 200 OK
+func writeGraphQLError(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusOK, map[string]any{"data": nil, "errors": []map[string]any{{"message": message}}})
+}
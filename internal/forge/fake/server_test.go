@@ -0,0 +1,222 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/github"
+	"github.com/RevCBH/choo/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setup creates a bare repo with main and a clone, and serves it
+func setup(t *testing.T) (srv *httptest.Server, bare, clone string) {
+	t.Helper()
+	testutil.UnsetGitEnv()
+
+	tmp := t.TempDir()
+	bare = filepath.Join(tmp, "repo.git")
+	clone = filepath.Join(tmp, "clone")
+	testutil.Git(t, tmp, "init", "--bare", "-b", "main", bare)
+	testutil.Git(t, tmp, "clone", bare, clone)
+	testutil.Git(t, clone, "checkout", "-b", "main")
+	testutil.CommitFile(t, clone, "README.md", "hello\n")
+	testutil.Git(t, clone, "push", "origin", "main")
+
+	s, err := New(Config{Owner: "local", Repo: "choo", BarePath: bare})
+	require.NoError(t, err)
+	srv = httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return srv, bare, clone
+}
+
+func newClient(t *testing.T, srv *httptest.Server) *github.PRClient {
+	t.Helper()
+	client, err := github.NewPRClient(github.PRClientConfig{
+		Owner:   "local",
+		Repo:    "choo",
+		BaseURL: srv.URL,
+		Token:   "choo",
+	})
+	require.NoError(t, err)
+	return client
+}
+
+// post sends a request as user, as a reviewer or CI system would
+func post(t *testing.T, srv *httptest.Server, user, path string, body any) int {
+	t.Helper()
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", srv.URL+path, bytes.NewReader(data))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+user)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestServer_PRLifecycle(t *testing.T) {
+	srv, bare, clone := setup(t)
+	ctx := context.Background()
+	client := newClient(t, srv)
+
+	testutil.Git(t, clone, "checkout", "-b", "feature/x")
+	headSHA := testutil.CommitFile(t, clone, "x.go", "package x\n")
+	testutil.Git(t, clone, "push", "origin", "feature/x")
+
+	pr, err := client.OpenPR(ctx, "feature/x", "main", "Add x", "Adds x")
+	require.NoError(t, err)
+	assert.Equal(t, 1, pr.Number)
+	assert.Equal(t, srv.URL+"/local/choo/pull/1", pr.URL)
+
+	// A reviewer looks, comments, then approves
+	assert.Equal(t, http.StatusCreated, post(t, srv, "alice", "/repos/local/choo/issues/1/reactions", map[string]string{"content": "eyes"}))
+	state, err := client.GetReviewStatus(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, github.ReviewInProgress, state.Status)
+
+	assert.Equal(t, http.StatusCreated, post(t, srv, "alice", "/repos/local/choo/pulls/1/comments", map[string]any{"body": "Add a doc comment", "path": "x.go", "line": 1}))
+	comments, err := client.GetPRComments(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, github.PRComment{ID: comments[0].ID, Path: "x.go", Line: 1, Body: "Add a doc comment", Author: "alice", CreatedAt: comments[0].CreatedAt}, comments[0])
+
+	assert.Equal(t, http.StatusCreated, post(t, srv, "alice", "/repos/local/choo/issues/1/reactions", map[string]string{"content": "+1"}))
+	state, err = client.GetReviewStatus(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, github.ReviewApproved, state.Status)
+
+	// CI reports on the head commit
+	status, err := client.GetCheckStatus(ctx, "feature/x")
+	require.NoError(t, err)
+	assert.Equal(t, github.CheckPending, status)
+
+	assert.Equal(t, http.StatusCreated, post(t, srv, "ci", "/repos/local/choo/check-runs", map[string]string{"name": "test", "head_sha": headSHA, "status": "in_progress"}))
+	assert.Equal(t, http.StatusOK, post(t, srv, "ci", "/repos/local/choo/check-runs", map[string]string{"name": "test", "head_sha": headSHA, "conclusion": "success"}))
+	status, err = client.GetCheckStatus(ctx, headSHA)
+	require.NoError(t, err)
+	assert.Equal(t, github.CheckSuccess, status)
+
+	require.NoError(t, client.UpdatePR(ctx, 1, "Add x package", "Adds the x package"))
+
+	result, err := client.Merge(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, result.Merged)
+
+	// The squash commit landed on main in the bare repo
+	assert.Equal(t, result.SHA, testutil.Git(t, bare, "rev-parse", "main"))
+	assert.Equal(t, "Add x package (#1)", testutil.Git(t, bare, "log", "-1", "--format=%s", "main"))
+	assert.Equal(t, "package x", testutil.Git(t, bare, "show", "main:x.go"))
+	assert.Equal(t, 1, strings.Count(testutil.Git(t, bare, "log", "--format=%P", "-1", "main"), " ")+1)
+
+	_, err = client.Merge(ctx, 1)
+	assert.Error(t, err, "merged PRs cannot be merged again")
+}
+
+func TestServer_MergeConflict(t *testing.T) {
+	srv, bare, clone := setup(t)
+	ctx := context.Background()
+	client := newClient(t, srv)
+
+	testutil.Git(t, clone, "checkout", "-b", "feature/y")
+	testutil.CommitFile(t, clone, "README.md", "from feature\n")
+	testutil.Git(t, clone, "push", "origin", "feature/y")
+
+	testutil.Git(t, clone, "checkout", "main")
+	mainSHA := testutil.CommitFile(t, clone, "README.md", "from main\n")
+	testutil.Git(t, clone, "push", "origin", "main")
+
+	_, err := client.OpenPR(ctx, "feature/y", "main", "Edit readme", "")
+	require.NoError(t, err)
+
+	_, err = client.Merge(ctx, 1)
+	assert.ErrorContains(t, err, "405")
+	assert.Equal(t, mainSHA, testutil.Git(t, bare, "rev-parse", "main"))
+}
+
+func TestServer_RetargetAndClose(t *testing.T) {
+	srv, _, clone := setup(t)
+	ctx := context.Background()
+	client := newClient(t, srv)
+
+	testutil.Git(t, clone, "checkout", "-b", "stack/a")
+	testutil.CommitFile(t, clone, "a.go", "package a\n")
+	testutil.Git(t, clone, "push", "origin", "stack/a")
+	testutil.Git(t, clone, "checkout", "-b", "stack/b")
+	testutil.CommitFile(t, clone, "b.go", "package b\n")
+	testutil.Git(t, clone, "push", "origin", "stack/b")
+
+	_, err := client.OpenPR(ctx, "stack/b", "stack/a", "b", "")
+	require.NoError(t, err)
+	_, err = client.OpenPR(ctx, "stack/b", "stack/a", "b again", "")
+	assert.ErrorContains(t, err, "422", "duplicate PRs are rejected")
+	_, err = client.OpenPR(ctx, "missing", "main", "nope", "")
+	assert.ErrorContains(t, err, "422")
+
+	require.NoError(t, client.UpdatePRBase(ctx, 1, "main"))
+	pr, err := client.GetPR(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "main", pr.TargetBranch)
+
+	require.NoError(t, client.ClosePR(ctx, 1))
+	_, err = client.Merge(ctx, 1)
+	assert.Error(t, err)
+}
+
+func TestServer_RejectsUnauthenticatedAndOtherRepos(t *testing.T) {
+	srv, _, _ := setup(t)
+
+	resp, err := http.Get(srv.URL + "/repos/local/choo/pulls")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	assert.Equal(t, http.StatusNotFound, post(t, srv, "alice", "/repos/other/repo/pulls", map[string]string{"title": "t", "head": "main", "base": "main"}))
+}
+
+func TestServer_ReviewThreads(t *testing.T) {
+	srv, _, clone := setup(t)
+	ctx := context.Background()
+	client := newClient(t, srv)
+
+	testutil.Git(t, clone, "checkout", "-b", "feature/z")
+	testutil.CommitFile(t, clone, "z.go", "package z\n")
+	testutil.Git(t, clone, "push", "origin", "feature/z")
+	_, err := client.OpenPR(ctx, "feature/z", "main", "Add z", "")
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusCreated, post(t, srv, "alice", "/repos/local/choo/pulls/1/comments", map[string]any{"body": "Rename z", "path": "z.go", "line": 1}))
+	require.Equal(t, http.StatusCreated, post(t, srv, "bob", "/repos/local/choo/pulls/1/comments", map[string]any{"body": "Why z?", "path": "z.go", "line": 1}))
+	comments, err := client.GetPRComments(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	first, second := comments[0].ID, comments[1].ID
+
+	require.NoError(t, client.ReplyToComment(ctx, 1, first, "Renamed in abc123"))
+	require.NoError(t, client.ResolveThread(ctx, 1, first))
+	require.NoError(t, client.ReplyToComment(ctx, 1, second, "Kept as is"))
+	assert.Error(t, client.ReplyToComment(ctx, 1, 999, "nope"))
+	assert.ErrorContains(t, client.ResolveThread(ctx, 1, 999), "no review thread")
+
+	comments, err = client.GetPRComments(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, comments, 4)
+	assert.Equal(t, first, comments[2].InReplyTo)
+	assert.Equal(t, "Renamed in abc123", comments[2].Body)
+	assert.Equal(t, "choo", comments[2].Author)
+	assert.Equal(t, second, comments[3].InReplyTo)
+
+	// Replies to replies stay in the thread they belong to
+	require.NoError(t, client.ReplyToComment(ctx, 1, comments[3].ID, "Still kept"))
+	comments, err = client.GetPRComments(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, second, comments[4].InReplyTo)
+}
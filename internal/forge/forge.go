@@ -0,0 +1,217 @@
+// Package forge abstracts the code host that pull requests are opened on.
+// GitHub is served by github.PRClient; GitLab (merge requests and
+// pipelines) and Gitea are implemented here against their REST APIs.
+package forge
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/github"
+)
+
+// The forge types are shared with the GitHub client so callers can switch
+// backends without converting values.
+type (
+	PRInfo       = github.PRInfo
+	MergeResult  = github.MergeResult
+	PRComment    = github.PRComment
+	ReviewState  = github.ReviewState
+	ReviewStatus = github.ReviewStatus
+	CheckStatus  = github.CheckStatus
+
+	FailedCheck     = github.FailedCheck
+	CheckAnnotation = github.CheckAnnotation
+)
+
+const (
+	CheckPending = github.CheckPending
+	CheckSuccess = github.CheckSuccess
+	CheckFailure = github.CheckFailure
+)
+
+// Forge is a code host. GitLab merge requests are addressed by their
+// project-scoped IID wherever a PR number is expected.
+type Forge interface {
+	// CreatePR opens a PR from branch into the repository's default branch
+	CreatePR(ctx context.Context, title, body, branch string) (*PRInfo, error)
+
+	// OpenPR opens a PR from head into base
+	OpenPR(ctx context.Context, head, base, title, body string) (*PRInfo, error)
+
+	// GetPR fetches a PR by number
+	GetPR(ctx context.Context, prNumber int) (*PRInfo, error)
+
+	// UpdatePR replaces a PR's title and body
+	UpdatePR(ctx context.Context, prNumber int, title, body string) error
+
+	// UpdatePRBase retargets a PR onto another base branch
+	UpdatePRBase(ctx context.Context, prNumber int, base string) error
+
+	// Merge squash-merges a PR
+	Merge(ctx context.Context, prNumber int) (*MergeResult, error)
+
+	// ClosePR closes a PR without merging
+	ClosePR(ctx context.Context, prNumber int) error
+
+	// GetPRComments fetches review comments on a PR
+	GetPRComments(ctx context.Context, prNumber int) ([]PRComment, error)
+
+	// GetReviewStatus derives review state from reactions and comments
+	GetReviewStatus(ctx context.Context, prNumber int) (*ReviewState, error)
+
+	// GetCheckStatus aggregates CI status for a branch or commit
+	GetCheckStatus(ctx context.Context, ref string) (CheckStatus, error)
+
+	// WaitForChecks polls until CI for ref finishes or ctx is cancelled
+	WaitForChecks(ctx context.Context, ref string, pollInterval time.Duration) (CheckStatus, error)
+}
+
+var _ Forge = (*github.PRClient)(nil)
+
+// ThreadResponder is implemented by forges that can answer review threads.
+// A thread is addressed by the ID of any comment in it.
+type ThreadResponder interface {
+	// ReplyToComment posts a reply in the thread containing commentID
+	ReplyToComment(ctx context.Context, prNumber int, commentID int64, body string) error
+
+	// ResolveThread marks the thread containing commentID resolved
+	ResolveThread(ctx context.Context, prNumber int, commentID int64) error
+}
+
+var (
+	_ ThreadResponder = (*github.PRClient)(nil)
+	_ ThreadResponder = (*GitLab)(nil)
+)
+
+// CheckReporter is implemented by forges that can explain CI failures
+type CheckReporter interface {
+	// GetFailedChecks returns the failed checks for ref with their output
+	GetFailedChecks(ctx context.Context, ref string) ([]FailedCheck, error)
+}
+
+var _ CheckReporter = (*github.PRClient)(nil)
+
+// UsesGHCLI reports whether the gh CLI can stand in for f's API. That is
+// only the case for github.com: gh is not set up for Enterprise hosts or
+// a local fake server, which are driven through the API instead.
+func UsesGHCLI(f Forge) bool {
+	c, ok := f.(*github.PRClient)
+	return ok && c.BaseURL() == github.DefaultBaseURL
+}
+
+// Config selects and configures a forge backend
+type Config struct {
+	Type          config.ForgeType // github (default), gitlab or gitea
+	URL           string           // API base URL; required for gitlab and gitea
+	TokenEnv      string           // env var holding the token (default per forge)
+	Owner         string           // owner, or group path on GitLab
+	Repo          string
+	PollInterval  time.Duration
+	ReviewTimeout time.Duration
+	Review        config.ReviewConfig // approval mode; reviews mode is GitHub only
+}
+
+// New creates the forge backend for cfg.Type
+func New(cfg Config) (Forge, error) {
+	switch cfg.Type {
+	case "", config.ForgeAuto, config.ForgeGitHub:
+		ghCfg := github.PRClientConfig{
+			Owner:         cfg.Owner,
+			Repo:          cfg.Repo,
+			PollInterval:  cfg.PollInterval,
+			ReviewTimeout: cfg.ReviewTimeout,
+			BaseURL:       cfg.URL,
+			Reviews: github.ReviewPolicy{
+				Formal:            cfg.Review.Mode == config.ReviewModeReviews,
+				RequiredApprovals: cfg.Review.RequiredApprovals,
+				RequireCodeOwners: cfg.Review.RequireCodeOwners,
+			},
+		}
+		if cfg.TokenEnv != "" {
+			token, err := envToken(cfg.TokenEnv, "GitHub")
+			if err != nil {
+				return nil, err
+			}
+			ghCfg.Token = token
+		}
+		client, err := github.NewPRClient(ghCfg)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+
+	case config.ForgeGitLab:
+		if cfg.Review.Mode == config.ReviewModeReviews {
+			return nil, fmt.Errorf("review.mode reviews is not supported on GitLab")
+		}
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("forge.url is required for GitLab")
+		}
+		token, err := envToken(tokenEnvOr(cfg.TokenEnv, "GITLAB_TOKEN"), "GitLab")
+		if err != nil {
+			return nil, err
+		}
+		return NewGitLab(cfg.URL, token, cfg.Owner, cfg.Repo), nil
+
+	case config.ForgeGitea:
+		if cfg.Review.Mode == config.ReviewModeReviews {
+			return nil, fmt.Errorf("review.mode reviews is not supported on Gitea")
+		}
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("forge.url is required for Gitea")
+		}
+		token, err := envToken(tokenEnvOr(cfg.TokenEnv, "GITEA_TOKEN"), "Gitea")
+		if err != nil {
+			return nil, err
+		}
+		return NewGitea(cfg.URL, token, cfg.Owner, cfg.Repo), nil
+
+	default:
+		return nil, fmt.Errorf("unknown forge type %q", cfg.Type)
+	}
+}
+
+func tokenEnvOr(name, def string) string {
+	if name != "" {
+		return name
+	}
+	return def
+}
+
+// envToken reads a token from the named environment variable
+func envToken(name, host string) (string, error) {
+	if token := os.Getenv(name); token != "" {
+		return token, nil
+	}
+	return "", fmt.Errorf("no %s token found: set %s", host, name)
+}
+
+// checkStatusGetter is the part of a forge WaitForChecks polls
+type checkStatusGetter interface {
+	GetCheckStatus(ctx context.Context, ref string) (CheckStatus, error)
+}
+
+// waitForChecks polls f until checks for ref leave the pending state
+func waitForChecks(ctx context.Context, f checkStatusGetter, ref string, pollInterval time.Duration) (CheckStatus, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-ticker.C:
+			status, err := f.GetCheckStatus(ctx, ref)
+			if err != nil {
+				return "", err
+			}
+			if status != CheckPending {
+				return status, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_SelectsBackend(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "gl")
+	t.Setenv("CORP_TOKEN", "corp")
+
+	f, err := New(Config{Type: config.ForgeGitLab, URL: "https://gitlab.example.com/api/v4", Owner: "g/sub", Repo: "r"})
+	require.NoError(t, err)
+	gl, ok := f.(*GitLab)
+	require.True(t, ok)
+	assert.Equal(t, "gl", gl.api.authValue)
+	assert.Equal(t, "g%2Fsub%2Fr", gl.project)
+
+	f, err = New(Config{Type: config.ForgeGitea, URL: "https://gitea.example.com/api/v1", TokenEnv: "CORP_TOKEN", Owner: "o", Repo: "r"})
+	require.NoError(t, err)
+	gt, ok := f.(*Gitea)
+	require.True(t, ok)
+	assert.Equal(t, "token corp", gt.api.authValue)
+
+	f, err = New(Config{Type: config.ForgeGitHub, TokenEnv: "CORP_TOKEN", Owner: "o", Repo: "r"})
+	require.NoError(t, err)
+	_, ok = f.(*github.PRClient)
+	assert.True(t, ok)
+}
+
+func TestNew_Errors(t *testing.T) {
+	t.Setenv("GITEA_TOKEN", "")
+
+	_, err := New(Config{Type: config.ForgeGitLab, Owner: "o", Repo: "r"})
+	assert.ErrorContains(t, err, "forge.url")
+
+	_, err = New(Config{Type: config.ForgeGitea, URL: "https://gitea.example.com/api/v1", Owner: "o", Repo: "r"})
+	assert.ErrorContains(t, err, "GITEA_TOKEN")
+
+	_, err = New(Config{Type: config.ForgeGitLab, URL: "https://gitlab.example.com/api/v4", Review: config.ReviewConfig{Mode: config.ReviewModeReviews}})
+	assert.ErrorContains(t, err, "review.mode")
+
+	f, err := New(Config{Type: "bitbucket"})
+	assert.Error(t, err)
+	// A failed construction must leave a nil interface so callers' nil
+	// checks keep working
+	assert.True(t, f == nil)
+}
+
+func TestAPIClient_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	c := newAPIClient(server.URL, "PRIVATE-TOKEN", "t")
+	c.backoff = time.Millisecond
+
+	var out struct{ OK bool }
+	require.NoError(t, c.do(context.Background(), "GET", "/x", nil, &out))
+	assert.True(t, out.OK)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestAPIClient_ClientErrorNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"message": "405 Method Not Allowed"}`))
+	}))
+	defer server.Close()
+
+	err := newAPIClient(server.URL, "PRIVATE-TOKEN", "t").do(context.Background(), "PUT", "/merge", nil, nil)
+	assert.ErrorContains(t, err, "status 405")
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,239 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/RevCBH/choo/internal/github"
+)
+
+// Gitea implements Forge through the Gitea REST API (v1), which Forgejo
+// and Codeberg also serve
+type Gitea struct {
+	api  *apiClient
+	repo string // "/repos/{owner}/{repo}"
+}
+
+// NewGitea creates a Gitea backend. baseURL is the API root, e.g.
+// https://gitea.example.com/api/v1.
+func NewGitea(baseURL, token, owner, repo string) *Gitea {
+	return &Gitea{
+		api:  newAPIClient(baseURL, "Authorization", "token "+token),
+		repo: fmt.Sprintf("/repos/%s/%s", url.PathEscape(owner), url.PathEscape(repo)),
+	}
+}
+
+var _ Forge = (*Gitea)(nil)
+
+type gtPullRequest struct {
+	Number         int       `json:"number"`
+	HTMLURL        string    `json:"html_url"`
+	Title          string    `json:"title"`
+	State          string    `json:"state"`
+	Merged         bool      `json:"merged"`
+	MergeCommitSHA string    `json:"merge_commit_sha"`
+	CreatedAt      time.Time `json:"created_at"`
+	Head           struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (pr *gtPullRequest) info() *PRInfo {
+	return &PRInfo{
+		Number:       pr.Number,
+		URL:          pr.HTMLURL,
+		Branch:       pr.Head.Ref,
+		TargetBranch: pr.Base.Ref,
+		Title:        pr.Title,
+		CreatedAt:    pr.CreatedAt,
+		State:        pr.State,
+		Merged:       pr.Merged,
+	}
+}
+
+type gtUser struct {
+	Login string `json:"login"`
+}
+
+type gtReview struct {
+	ID int64 `json:"id"`
+}
+
+type gtReviewComment struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	Path      string    `json:"path"`
+	Position  int       `json:"position"`
+	User      gtUser    `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type gtReaction struct {
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (g *Gitea) pullPath(prNumber int) string {
+	return fmt.Sprintf("%s/pulls/%d", g.repo, prNumber)
+}
+
+// CreatePR opens a PR from branch into the repository's default branch
+func (g *Gitea) CreatePR(ctx context.Context, title, body, branch string) (*PRInfo, error) {
+	var repo struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.api.do(ctx, "GET", g.repo, nil, &repo); err != nil {
+		return nil, fmt.Errorf("failed to get default branch: %w", err)
+	}
+	return g.OpenPR(ctx, branch, repo.DefaultBranch, title, body)
+}
+
+// OpenPR opens a PR from head into base
+func (g *Gitea) OpenPR(ctx context.Context, head, base, title, body string) (*PRInfo, error) {
+	var pr gtPullRequest
+	err := g.api.do(ctx, "POST", g.repo+"/pulls", map[string]string{
+		"head":  head,
+		"base":  base,
+		"title": title,
+		"body":  body,
+	}, &pr)
+	if err != nil {
+		return nil, err
+	}
+	return pr.info(), nil
+}
+
+// GetPR fetches a PR by number
+func (g *Gitea) GetPR(ctx context.Context, prNumber int) (*PRInfo, error) {
+	var pr gtPullRequest
+	if err := g.api.do(ctx, "GET", g.pullPath(prNumber), nil, &pr); err != nil {
+		return nil, err
+	}
+	return pr.info(), nil
+}
+
+// UpdatePR replaces a PR's title and body
+func (g *Gitea) UpdatePR(ctx context.Context, prNumber int, title, body string) error {
+	return g.api.do(ctx, "PATCH", g.pullPath(prNumber), map[string]string{
+		"title": title,
+		"body":  body,
+	}, nil)
+}
+
+// UpdatePRBase retargets a PR
+func (g *Gitea) UpdatePRBase(ctx context.Context, prNumber int, base string) error {
+	return g.api.do(ctx, "PATCH", g.pullPath(prNumber), map[string]string{
+		"base": base,
+	}, nil)
+}
+
+// Merge squash-merges a PR. Gitea's merge endpoint returns no body, so the
+// PR is re-read for the resulting commit.
+func (g *Gitea) Merge(ctx context.Context, prNumber int) (*MergeResult, error) {
+	if err := g.api.do(ctx, "POST", g.pullPath(prNumber)+"/merge", map[string]string{
+		"Do": "squash",
+	}, nil); err != nil {
+		return nil, err
+	}
+
+	var pr gtPullRequest
+	if err := g.api.do(ctx, "GET", g.pullPath(prNumber), nil, &pr); err != nil {
+		return nil, err
+	}
+	result := &MergeResult{Merged: pr.Merged, SHA: pr.MergeCommitSHA}
+	if !pr.Merged {
+		result.Message = "pull request was not merged"
+	}
+	return result, nil
+}
+
+// ClosePR closes a PR without merging
+func (g *Gitea) ClosePR(ctx context.Context, prNumber int) error {
+	return g.api.do(ctx, "PATCH", g.pullPath(prNumber), map[string]string{
+		"state": "closed",
+	}, nil)
+}
+
+// GetPRComments fetches the line comments of every review on a PR
+func (g *Gitea) GetPRComments(ctx context.Context, prNumber int) ([]PRComment, error) {
+	var reviews []gtReview
+	if err := g.api.do(ctx, "GET", g.pullPath(prNumber)+"/reviews", nil, &reviews); err != nil {
+		return nil, err
+	}
+
+	var comments []PRComment
+	for _, review := range reviews {
+		var rc []gtReviewComment
+		path := fmt.Sprintf("%s/reviews/%d/comments", g.pullPath(prNumber), review.ID)
+		if err := g.api.do(ctx, "GET", path, nil, &rc); err != nil {
+			return nil, err
+		}
+		for _, c := range rc {
+			comments = append(comments, PRComment{
+				ID:        c.ID,
+				Path:      c.Path,
+				Line:      c.Position,
+				Body:      c.Body,
+				Author:    c.User.Login,
+				CreatedAt: c.CreatedAt,
+			})
+		}
+	}
+	return comments, nil
+}
+
+// GetReviewStatus derives review state from +1/eyes reactions and review
+// comments, as on GitHub
+func (g *Gitea) GetReviewStatus(ctx context.Context, prNumber int) (*ReviewState, error) {
+	var gtReactions []gtReaction
+	path := fmt.Sprintf("%s/issues/%d/reactions", g.repo, prNumber)
+	if err := g.api.do(ctx, "GET", path, nil, &gtReactions); err != nil {
+		return nil, err
+	}
+
+	comments, err := g.GetPRComments(ctx, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	reactions := make([]github.Reaction, 0, len(gtReactions))
+	for _, r := range gtReactions {
+		reactions = append(reactions, github.Reaction{Content: r.Content, CreatedAt: r.CreatedAt})
+	}
+	return github.NewReviewState(reactions, comments), nil
+}
+
+// GetCheckStatus maps the combined commit status for a branch or SHA.
+// Gitea Actions report through commit statuses too.
+func (g *Gitea) GetCheckStatus(ctx context.Context, ref string) (CheckStatus, error) {
+	var combined struct {
+		State      string `json:"state"`
+		TotalCount int    `json:"total_count"`
+	}
+	path := fmt.Sprintf("%s/commits/%s/status", g.repo, url.PathEscape(ref))
+	if err := g.api.do(ctx, "GET", path, nil, &combined); err != nil {
+		return "", fmt.Errorf("get commit status: %w", err)
+	}
+	if combined.TotalCount == 0 {
+		return CheckPending, nil
+	}
+
+	switch combined.State {
+	case "success", "warning":
+		return CheckSuccess, nil
+	case "failure", "error":
+		return CheckFailure, nil
+	default:
+		return CheckPending, nil
+	}
+}
+
+// WaitForChecks polls the commit status until it completes
+func (g *Gitea) WaitForChecks(ctx context.Context, ref string, pollInterval time.Duration) (CheckStatus, error) {
+	return waitForChecks(ctx, g, ref, pollInterval)
+}
@@ -0,0 +1,109 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGiteaServer(t *testing.T, routes map[string]string) (*Gitea, map[string]map[string]any) {
+	t.Helper()
+	bodies := make(map[string]map[string]any)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+
+		key := r.Method + " " + r.URL.EscapedPath()
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(data, &body))
+			bodies[key] = body
+		}
+
+		resp, ok := routes[key]
+		if !ok {
+			t.Errorf("unexpected request %s", key)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(resp))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewGitea(server.URL+"/api/v1", "secret", "org", "choo"), bodies
+}
+
+func TestGitea_CreatePR(t *testing.T) {
+	g, bodies := newGiteaServer(t, map[string]string{
+		"GET /api/v1/repos/org/choo": `{"default_branch": "trunk"}`,
+		"POST /api/v1/repos/org/choo/pulls": `{"number": 3, "html_url": "https://gitea.example.com/org/choo/pulls/3",
+			"title": "Add x", "head": {"ref": "feature/x"}, "base": {"ref": "trunk"}}`,
+	})
+
+	pr, err := g.CreatePR(context.Background(), "Add x", "Body", "feature/x")
+	require.NoError(t, err)
+	assert.Equal(t, 3, pr.Number)
+	assert.Equal(t, "trunk", pr.TargetBranch)
+	assert.Equal(t, "trunk", bodies["POST /api/v1/repos/org/choo/pulls"]["base"])
+}
+
+func TestGitea_Merge(t *testing.T) {
+	g, bodies := newGiteaServer(t, map[string]string{
+		"POST /api/v1/repos/org/choo/pulls/3/merge": ``,
+		"GET /api/v1/repos/org/choo/pulls/3":        `{"number": 3, "merged": true, "merge_commit_sha": "def456"}`,
+	})
+
+	result, err := g.Merge(context.Background(), 3)
+	require.NoError(t, err)
+	assert.True(t, result.Merged)
+	assert.Equal(t, "def456", result.SHA)
+	assert.Equal(t, map[string]any{"Do": "squash"}, bodies["POST /api/v1/repos/org/choo/pulls/3/merge"])
+}
+
+func TestGitea_ReviewStatus(t *testing.T) {
+	g, _ := newGiteaServer(t, map[string]string{
+		"GET /api/v1/repos/org/choo/issues/3/reactions":         `[{"content": "eyes", "created_at": "2026-01-02T03:00:00Z"}]`,
+		"GET /api/v1/repos/org/choo/pulls/3/reviews":            `[{"id": 1}, {"id": 2}]`,
+		"GET /api/v1/repos/org/choo/pulls/3/reviews/1/comments": `[{"id": 5, "body": "Typo", "path": "a.go", "position": 4, "user": {"login": "bob"}}]`,
+		"GET /api/v1/repos/org/choo/pulls/3/reviews/2/comments": `[]`,
+	})
+
+	state, err := g.GetReviewStatus(context.Background(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, ReviewStatus("in_progress"), state.Status)
+	assert.Equal(t, 1, state.CommentCount)
+
+	comments, err := g.GetPRComments(context.Background(), 3)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "a.go", comments[0].Path)
+	assert.Equal(t, 4, comments[0].Line)
+	assert.Equal(t, "bob", comments[0].Author)
+}
+
+func TestGitea_CheckStatus(t *testing.T) {
+	tests := []struct {
+		response string
+		want     CheckStatus
+	}{
+		{`{"state": "", "total_count": 0}`, CheckPending},
+		{`{"state": "pending", "total_count": 2}`, CheckPending},
+		{`{"state": "success", "total_count": 2}`, CheckSuccess},
+		{`{"state": "error", "total_count": 1}`, CheckFailure},
+	}
+
+	for _, tt := range tests {
+		g, _ := newGiteaServer(t, map[string]string{
+			"GET /api/v1/repos/org/choo/commits/main/status": tt.response,
+		})
+		status, err := g.GetCheckStatus(context.Background(), "main")
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, status, tt.response)
+	}
+}
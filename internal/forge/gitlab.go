@@ -0,0 +1,311 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/RevCBH/choo/internal/github"
+)
+
+// GitLab implements Forge with merge requests and pipelines through the
+// GitLab REST API (v4)
+type GitLab struct {
+	api     *apiClient
+	project string // URL-escaped "group/subgroup/repo" path
+}
+
+// NewGitLab creates a GitLab backend. baseURL is the API root, e.g.
+// https://gitlab.example.com/api/v4, and owner is the (possibly nested)
+// group path.
+func NewGitLab(baseURL, token, owner, repo string) *GitLab {
+	return &GitLab{
+		api:     newAPIClient(baseURL, "PRIVATE-TOKEN", token),
+		project: url.PathEscape(owner + "/" + repo),
+	}
+}
+
+var _ Forge = (*GitLab)(nil)
+
+type glMergeRequest struct {
+	IID             int       `json:"iid"`
+	WebURL          string    `json:"web_url"`
+	SourceBranch    string    `json:"source_branch"`
+	TargetBranch    string    `json:"target_branch"`
+	Title           string    `json:"title"`
+	State           string    `json:"state"`
+	CreatedAt       time.Time `json:"created_at"`
+	MergeCommitSHA  string    `json:"merge_commit_sha"`
+	SquashCommitSHA string    `json:"squash_commit_sha"`
+}
+
+func (mr *glMergeRequest) info() *PRInfo {
+	return &PRInfo{
+		Number:       mr.IID,
+		URL:          mr.WebURL,
+		Branch:       mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		Title:        mr.Title,
+		CreatedAt:    mr.CreatedAt,
+		State:        mr.State,
+		Merged:       mr.State == "merged",
+	}
+}
+
+type glNote struct {
+	ID         int64     `json:"id"`
+	Body       string    `json:"body"`
+	System     bool      `json:"system"`
+	Resolvable bool      `json:"resolvable"`
+	CreatedAt  time.Time `json:"created_at"`
+	Author     struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	Position *struct {
+		NewPath string `json:"new_path"`
+		NewLine int    `json:"new_line"`
+	} `json:"position"`
+}
+
+// glDiscussion is a thread of notes; the first note opened it
+type glDiscussion struct {
+	ID    string   `json:"id"`
+	Notes []glNote `json:"notes"`
+}
+
+type glAwardEmoji struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type glPipeline struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+}
+
+func (g *GitLab) mrPath(iid int) string {
+	return fmt.Sprintf("/projects/%s/merge_requests/%d", g.project, iid)
+}
+
+// CreatePR opens a merge request from branch into the project's default branch
+func (g *GitLab) CreatePR(ctx context.Context, title, body, branch string) (*PRInfo, error) {
+	var project struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.api.do(ctx, "GET", "/projects/"+g.project, nil, &project); err != nil {
+		return nil, fmt.Errorf("failed to get default branch: %w", err)
+	}
+	return g.OpenPR(ctx, branch, project.DefaultBranch, title, body)
+}
+
+// OpenPR opens a merge request from head into base
+func (g *GitLab) OpenPR(ctx context.Context, head, base, title, body string) (*PRInfo, error) {
+	var mr glMergeRequest
+	err := g.api.do(ctx, "POST", fmt.Sprintf("/projects/%s/merge_requests", g.project), map[string]string{
+		"source_branch": head,
+		"target_branch": base,
+		"title":         title,
+		"description":   body,
+	}, &mr)
+	if err != nil {
+		return nil, err
+	}
+	return mr.info(), nil
+}
+
+// GetPR fetches a merge request by IID
+func (g *GitLab) GetPR(ctx context.Context, prNumber int) (*PRInfo, error) {
+	var mr glMergeRequest
+	if err := g.api.do(ctx, "GET", g.mrPath(prNumber), nil, &mr); err != nil {
+		return nil, err
+	}
+	return mr.info(), nil
+}
+
+// UpdatePR replaces a merge request's title and description
+func (g *GitLab) UpdatePR(ctx context.Context, prNumber int, title, body string) error {
+	return g.api.do(ctx, "PUT", g.mrPath(prNumber), map[string]string{
+		"title":       title,
+		"description": body,
+	}, nil)
+}
+
+// UpdatePRBase retargets a merge request
+func (g *GitLab) UpdatePRBase(ctx context.Context, prNumber int, base string) error {
+	return g.api.do(ctx, "PUT", g.mrPath(prNumber), map[string]string{
+		"target_branch": base,
+	}, nil)
+}
+
+// Merge squash-merges a merge request
+func (g *GitLab) Merge(ctx context.Context, prNumber int) (*MergeResult, error) {
+	var mr glMergeRequest
+	if err := g.api.do(ctx, "PUT", g.mrPath(prNumber)+"/merge", map[string]bool{
+		"squash": true,
+	}, &mr); err != nil {
+		return nil, err
+	}
+
+	sha := mr.SquashCommitSHA
+	if sha == "" {
+		sha = mr.MergeCommitSHA
+	}
+	result := &MergeResult{Merged: mr.State == "merged", SHA: sha}
+	if !result.Merged {
+		result.Message = fmt.Sprintf("merge request is %s", mr.State)
+	}
+	return result, nil
+}
+
+// ClosePR closes a merge request without merging
+func (g *GitLab) ClosePR(ctx context.Context, prNumber int) error {
+	return g.api.do(ctx, "PUT", g.mrPath(prNumber), map[string]string{
+		"state_event": "close",
+	}, nil)
+}
+
+// GetPRComments fetches the non-system notes on a merge request, thread by
+// thread. Diff notes carry the file and line they were left on, and replies
+// point at the note that opened their discussion.
+func (g *GitLab) GetPRComments(ctx context.Context, prNumber int) ([]PRComment, error) {
+	discussions, err := g.discussions(ctx, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []PRComment
+	for _, d := range discussions {
+		var root int64
+		for _, n := range d.Notes {
+			if n.System {
+				continue
+			}
+			comment := PRComment{
+				ID:        n.ID,
+				Body:      n.Body,
+				Author:    n.Author.Username,
+				CreatedAt: n.CreatedAt,
+			}
+			if n.Position != nil {
+				comment.Path = n.Position.NewPath
+				comment.Line = n.Position.NewLine
+			}
+			if root == 0 {
+				root = n.ID
+			} else {
+				comment.InReplyTo = root
+			}
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+// ReplyToComment adds a note to the discussion containing commentID
+func (g *GitLab) ReplyToComment(ctx context.Context, prNumber int, commentID int64, body string) error {
+	d, err := g.discussionFor(ctx, prNumber, commentID)
+	if err != nil {
+		return err
+	}
+	return g.api.do(ctx, "POST", g.mrPath(prNumber)+"/discussions/"+d.ID+"/notes", map[string]string{"body": body}, nil)
+}
+
+// ResolveThread resolves the discussion containing commentID. Plain
+// comments outside a diff are not resolvable and are left as they are.
+func (g *GitLab) ResolveThread(ctx context.Context, prNumber int, commentID int64) error {
+	d, err := g.discussionFor(ctx, prNumber, commentID)
+	if err != nil {
+		return err
+	}
+	if len(d.Notes) == 0 || !d.Notes[0].Resolvable {
+		return nil
+	}
+	return g.api.do(ctx, "PUT", g.mrPath(prNumber)+"/discussions/"+d.ID+"?resolved=true", nil, nil)
+}
+
+func (g *GitLab) discussions(ctx context.Context, prNumber int) ([]glDiscussion, error) {
+	var discussions []glDiscussion
+	if err := g.api.do(ctx, "GET", g.mrPath(prNumber)+"/discussions?per_page=100", nil, &discussions); err != nil {
+		return nil, err
+	}
+	return discussions, nil
+}
+
+// discussionFor finds the discussion a note belongs to
+func (g *GitLab) discussionFor(ctx context.Context, prNumber int, noteID int64) (*glDiscussion, error) {
+	discussions, err := g.discussions(ctx, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	for i := range discussions {
+		for _, n := range discussions[i].Notes {
+			if n.ID == noteID {
+				return &discussions[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no discussion contains note %d", noteID)
+}
+
+// GetReviewStatus derives review state from award emoji and notes.
+// :thumbsup: approves and :eyes: marks the review in progress, matching
+// the +1/eyes reactions used on GitHub.
+func (g *GitLab) GetReviewStatus(ctx context.Context, prNumber int) (*ReviewState, error) {
+	var awards []glAwardEmoji
+	if err := g.api.do(ctx, "GET", g.mrPath(prNumber)+"/award_emoji", nil, &awards); err != nil {
+		return nil, err
+	}
+
+	comments, err := g.GetPRComments(ctx, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	reactions := make([]github.Reaction, 0, len(awards))
+	for _, a := range awards {
+		content := a.Name
+		if content == "thumbsup" {
+			content = "+1"
+		}
+		reactions = append(reactions, github.Reaction{ID: a.ID, Content: content, CreatedAt: a.CreatedAt})
+	}
+	return github.NewReviewState(reactions, comments), nil
+}
+
+var shaRe = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// GetCheckStatus maps the latest pipeline for a branch or commit SHA.
+// No pipeline yet counts as pending.
+func (g *GitLab) GetCheckStatus(ctx context.Context, ref string) (CheckStatus, error) {
+	filter := "ref"
+	if shaRe.MatchString(ref) {
+		filter = "sha"
+	}
+
+	var pipelines []glPipeline
+	path := fmt.Sprintf("/projects/%s/pipelines?%s=%s&order_by=id&sort=desc&per_page=1", g.project, filter, url.QueryEscape(ref))
+	if err := g.api.do(ctx, "GET", path, nil, &pipelines); err != nil {
+		return "", fmt.Errorf("get pipelines: %w", err)
+	}
+	if len(pipelines) == 0 {
+		return CheckPending, nil
+	}
+
+	switch pipelines[0].Status {
+	case "success", "skipped":
+		return CheckSuccess, nil
+	case "failed", "canceled":
+		return CheckFailure, nil
+	default:
+		// created, waiting_for_resource, preparing, pending, running, manual, scheduled
+		return CheckPending, nil
+	}
+}
+
+// WaitForChecks polls the pipeline status until it completes
+func (g *GitLab) WaitForChecks(ctx context.Context, ref string, pollInterval time.Duration) (CheckStatus, error) {
+	return waitForChecks(ctx, g, ref, pollInterval)
+}
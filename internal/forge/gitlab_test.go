@@ -0,0 +1,185 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitLabServer serves canned responses keyed by "METHOD escaped-path"
+// and records request bodies
+func newGitLabServer(t *testing.T, routes map[string]string) (*GitLab, map[string]map[string]any) {
+	t.Helper()
+	bodies := make(map[string]map[string]any)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+
+		key := r.Method + " " + r.URL.EscapedPath()
+		if r.URL.RawQuery != "" {
+			key += "?" + r.URL.RawQuery
+		}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(data, &body))
+			bodies[key] = body
+		}
+
+		resp, ok := routes[key]
+		if !ok {
+			t.Errorf("unexpected request %s", key)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(resp))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewGitLab(server.URL+"/api/v4", "secret", "platform/tools", "choo"), bodies
+}
+
+const glMR = `{"iid": 7, "web_url": "https://gitlab.example.com/platform/tools/choo/-/merge_requests/7",
+	"source_branch": "feature/x", "target_branch": "main", "title": "Add x", "state": "opened",
+	"created_at": "2026-01-02T03:04:05Z"}`
+
+func TestGitLab_OpenAndGetPR(t *testing.T) {
+	g, bodies := newGitLabServer(t, map[string]string{
+		"POST /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests":  glMR,
+		"GET /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7": glMR,
+	})
+	ctx := context.Background()
+
+	pr, err := g.OpenPR(ctx, "feature/x", "main", "Add x", "Body")
+	require.NoError(t, err)
+	assert.Equal(t, 7, pr.Number)
+	assert.Equal(t, "feature/x", pr.Branch)
+	assert.Equal(t, "main", pr.TargetBranch)
+	assert.Equal(t, map[string]any{
+		"source_branch": "feature/x",
+		"target_branch": "main",
+		"title":         "Add x",
+		"description":   "Body",
+	}, bodies["POST /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests"])
+
+	pr, err = g.GetPR(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "Add x", pr.Title)
+}
+
+func TestGitLab_UpdateAndClose(t *testing.T) {
+	g, bodies := newGitLabServer(t, map[string]string{
+		"PUT /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7": glMR,
+	})
+	ctx := context.Background()
+	key := "PUT /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7"
+
+	require.NoError(t, g.UpdatePRBase(ctx, 7, "develop"))
+	assert.Equal(t, map[string]any{"target_branch": "develop"}, bodies[key])
+
+	require.NoError(t, g.ClosePR(ctx, 7))
+	assert.Equal(t, map[string]any{"state_event": "close"}, bodies[key])
+}
+
+func TestGitLab_Merge(t *testing.T) {
+	g, bodies := newGitLabServer(t, map[string]string{
+		"PUT /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7/merge": `{"iid": 7, "state": "merged", "squash_commit_sha": "abc123"}`,
+	})
+
+	result, err := g.Merge(context.Background(), 7)
+	require.NoError(t, err)
+	assert.True(t, result.Merged)
+	assert.Equal(t, "abc123", result.SHA)
+	assert.Equal(t, map[string]any{"squash": true}, bodies["PUT /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7/merge"])
+}
+
+func TestGitLab_ReviewStatus(t *testing.T) {
+	g, _ := newGitLabServer(t, map[string]string{
+		"GET /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7/award_emoji": `[
+			{"id": 1, "name": "eyes", "created_at": "2026-01-02T03:00:00Z"},
+			{"id": 2, "name": "thumbsup", "created_at": "2026-01-02T04:00:00Z"}]`,
+		"GET /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7/discussions?per_page=100": `[
+			{"id": "d1", "notes": [{"id": 10, "body": "added 1 commit", "system": true, "created_at": "2026-01-02T02:00:00Z", "author": {"username": "bot"}}]},
+			{"id": "d2", "notes": [{"id": 11, "body": "Rename this", "created_at": "2026-01-02T05:00:00Z", "author": {"username": "alice"},
+			 "position": {"new_path": "main.go", "new_line": 12}}]}]`,
+	})
+
+	state, err := g.GetReviewStatus(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, ReviewStatus("approved"), state.Status)
+	assert.True(t, state.HasEyes)
+	assert.Equal(t, 1, state.CommentCount)
+	assert.Equal(t, time.Date(2026, 1, 2, 5, 0, 0, 0, time.UTC), state.LastActivity.UTC())
+
+	comments, err := g.GetPRComments(context.Background(), 7)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, PRComment{ID: 11, Path: "main.go", Line: 12, Body: "Rename this", Author: "alice", CreatedAt: comments[0].CreatedAt}, comments[0])
+}
+
+func TestGitLab_CheckStatus(t *testing.T) {
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	g, _ := newGitLabServer(t, map[string]string{
+		"GET /api/v4/projects/platform%2Ftools%2Fchoo/pipelines?ref=feature%2Fx&order_by=id&sort=desc&per_page=1": `[{"id": 3, "status": "running"}]`,
+		"GET /api/v4/projects/platform%2Ftools%2Fchoo/pipelines?sha=" + sha + "&order_by=id&sort=desc&per_page=1": `[{"id": 4, "status": "failed"}]`,
+		"GET /api/v4/projects/platform%2Ftools%2Fchoo/pipelines?ref=main&order_by=id&sort=desc&per_page=1":        `[]`,
+	})
+	ctx := context.Background()
+
+	status, err := g.GetCheckStatus(ctx, "feature/x")
+	require.NoError(t, err)
+	assert.Equal(t, CheckPending, status)
+
+	status, err = g.GetCheckStatus(ctx, sha)
+	require.NoError(t, err)
+	assert.Equal(t, CheckFailure, status)
+
+	status, err = g.GetCheckStatus(ctx, "main")
+	require.NoError(t, err)
+	assert.Equal(t, CheckPending, status)
+}
+
+const glThreads = `[
+	{"id": "d2", "notes": [
+		{"id": 11, "body": "Rename this", "resolvable": true, "created_at": "2026-01-02T05:00:00Z", "author": {"username": "alice"}},
+		{"id": 12, "body": "Done", "resolvable": true, "created_at": "2026-01-02T06:00:00Z", "author": {"username": "choo"}}]},
+	{"id": "d3", "notes": [
+		{"id": 13, "body": "Looks good overall", "created_at": "2026-01-02T07:00:00Z", "author": {"username": "bob"}}]}]`
+
+func TestGitLab_ThreadReplies(t *testing.T) {
+	g, _ := newGitLabServer(t, map[string]string{
+		"GET /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7/discussions?per_page=100": glThreads,
+	})
+
+	comments, err := g.GetPRComments(context.Background(), 7)
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+	assert.Equal(t, int64(0), comments[0].InReplyTo)
+	assert.Equal(t, int64(11), comments[1].InReplyTo)
+	assert.Equal(t, int64(0), comments[2].InReplyTo)
+}
+
+func TestGitLab_ReplyAndResolve(t *testing.T) {
+	g, bodies := newGitLabServer(t, map[string]string{
+		"GET /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7/discussions?per_page=100":     glThreads,
+		"POST /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7/discussions/d2/notes":        `{"id": 14}`,
+		"PUT /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7/discussions/d2?resolved=true": `{"id": "d2"}`,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, g.ReplyToComment(ctx, 7, 12, "Fixed in abc123"))
+	assert.Equal(t, map[string]any{"body": "Fixed in abc123"}, bodies["POST /api/v4/projects/platform%2Ftools%2Fchoo/merge_requests/7/discussions/d2/notes"])
+
+	require.NoError(t, g.ResolveThread(ctx, 7, 11))
+
+	// Plain comments are not resolvable, so nothing is sent for them
+	require.NoError(t, g.ResolveThread(ctx, 7, 13))
+
+	assert.Error(t, g.ReplyToComment(ctx, 7, 99, "nope"))
+}
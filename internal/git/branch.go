@@ -86,6 +86,25 @@ func GetCurrentBranch(ctx context.Context, repoDir string) (string, error) {
 	return branch, nil
 }
 
+// ListLocalBranches returns the names of local branches that start with prefix.
+// An empty prefix lists every local branch.
+func ListLocalBranches(ctx context.Context, repoDir, prefix string) ([]string, error) {
+	output, err := gitExec(ctx, repoDir, "for-each-ref", "--format=%(refname:short)", "refs/heads/"+prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && strings.HasPrefix(line, prefix) {
+			branches = append(branches, line)
+		}
+	}
+
+	return branches, nil
+}
+
 // BranchExistsOnRemote checks if a branch exists on the remote (origin).
 func BranchExistsOnRemote(ctx context.Context, repoDir, branch string) (bool, error) {
 	output, err := gitExec(ctx, repoDir, "ls-remote", "--heads", "origin", branch)
@@ -97,6 +116,37 @@ func BranchExistsOnRemote(ctx context.Context, repoDir, branch string) (bool, er
 	return strings.TrimSpace(output) != "", nil
 }
 
+// ListRemoteBranches returns the names of branches on the remote (origin)
+// that start with prefix.
+func ListRemoteBranches(ctx context.Context, repoDir, prefix string) ([]string, error) {
+	output, err := gitExec(ctx, repoDir, "ls-remote", "--heads", "origin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote branches: %w", err)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		name := strings.TrimPrefix(fields[1], "refs/heads/")
+		if strings.HasPrefix(name, prefix) {
+			branches = append(branches, name)
+		}
+	}
+
+	return branches, nil
+}
+
+// DeleteRemoteBranch deletes a branch on the remote (origin).
+func DeleteRemoteBranch(ctx context.Context, repoDir, branch string) error {
+	if err := deleteBranch(ctx, repoDir, branch, true); err != nil {
+		return fmt.Errorf("failed to delete remote branch %s: %w", branch, err)
+	}
+	return nil
+}
+
 // PushBranch pushes a local branch to the remote with upstream tracking.
 func PushBranch(ctx context.Context, repoDir, branch string) error {
 	_, err := gitExec(ctx, repoDir, "push", "-u", "origin", branch)
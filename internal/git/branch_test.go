@@ -374,3 +374,39 @@ func TestValidateBranchName_Comprehensive(t *testing.T) {
 		})
 	}
 }
+
+func TestListLocalBranches_FiltersByPrefix(t *testing.T) {
+	fake := newFakeRunner()
+	fake.stub("for-each-ref --format=%(refname:short) refs/heads/ralph/", "ralph/unit-a-abc123\nralph/unit-b-def456\n", nil)
+	SetDefaultRunner(fake)
+	defer SetDefaultRunner(nil)
+
+	branches, err := ListLocalBranches(context.Background(), "/test/repo", "ralph/")
+	if err != nil {
+		t.Fatalf("ListLocalBranches() returned error: %v", err)
+	}
+
+	if len(branches) != 2 {
+		t.Fatalf("ListLocalBranches() returned %d branches, want 2", len(branches))
+	}
+	if branches[0] != "ralph/unit-a-abc123" {
+		t.Errorf("ListLocalBranches()[0] = %q, want %q", branches[0], "ralph/unit-a-abc123")
+	}
+}
+
+func TestListRemoteBranches_FiltersByPrefix(t *testing.T) {
+	fake := newFakeRunner()
+	fake.stub("ls-remote --heads origin",
+		"1111111\trefs/heads/main\n2222222\trefs/heads/ralph/unit-a-abc123\n3333333\trefs/heads/feature/api\n", nil)
+	SetDefaultRunner(fake)
+	defer SetDefaultRunner(nil)
+
+	branches, err := ListRemoteBranches(context.Background(), "/test/repo", "ralph/")
+	if err != nil {
+		t.Fatalf("ListRemoteBranches() returned error: %v", err)
+	}
+
+	if len(branches) != 1 || branches[0] != "ralph/unit-a-abc123" {
+		t.Errorf("ListRemoteBranches() = %v, want [ralph/unit-a-abc123]", branches)
+	}
+}
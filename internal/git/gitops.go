@@ -298,6 +298,9 @@ func (g *gitOps) Log(ctx context.Context, opts LogOpts) ([]CommitRecord, error)
 	if !opts.Until.IsZero() {
 		args = append(args, "--until="+opts.Until.Format(time.RFC3339))
 	}
+	if opts.Range != "" {
+		args = append(args, opts.Range)
+	}
 	if opts.Path != "" {
 		args = append(args, "--", opts.Path)
 	}
@@ -56,4 +56,5 @@ type LogOpts struct {
 	Since    time.Time // Filters commits after this time
 	Until    time.Time // Filters commits before this time
 	Path     string    // Filters commits affecting this path
+	Range    string    // Revision range to list, e.g. "base..HEAD" (default: HEAD)
 }
@@ -109,3 +109,18 @@ func TestBranchExists_Missing(t *testing.T) {
 		t.Fatal("expected branch to be missing")
 	}
 }
+
+func TestLog_Range(t *testing.T) {
+	runner := testutil.NewStubRunner()
+	ops := newTestGitOps(t, runner, GitOpsOpts{AllowRepoRoot: true})
+
+	runner.Stub("log --format=%H|%an|%aI|%s|%b%x00 base..HEAD",
+		"abc|Dev|2024-01-02T03:04:05Z|second|\x00def|Dev|2024-01-01T03:04:05Z|first|\x00", nil)
+	commits, err := ops.Log(context.Background(), LogOpts{Range: "base..HEAD"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(commits) != 2 || commits[0].Hash != "abc" || commits[1].Subject != "first" {
+		t.Fatalf("unexpected commits %+v", commits)
+	}
+}
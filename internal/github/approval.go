@@ -0,0 +1,287 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReviewPolicy selects how approval is decided. The zero value uses 👀 and
+// 👍 reactions; Formal uses submitted pull request reviews, and the
+// requirements below are combined with the base branch's protection rules,
+// whichever is stricter.
+type ReviewPolicy struct {
+	Formal            bool // Use APPROVED / CHANGES_REQUESTED reviews instead of reactions
+	RequiredApprovals int  // Minimum distinct approvals (default 1)
+	RequireCodeOwners bool // Every changed file with code owners needs an owner's approval
+}
+
+// ghReview is the GitHub API response for a pull request review
+type ghReview struct {
+	ID          int64     `json:"id"`
+	State       string    `json:"state"`
+	User        ghUser    `json:"user"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// reviewRequirements are the approval rules that apply to a base branch
+type reviewRequirements struct {
+	approvals  int
+	codeOwners bool
+}
+
+// getFormalReviewStatus derives review state from submitted reviews, the
+// base branch's protection rules and GitHub's own view of merge-ability
+func (c *PRClient) getFormalReviewStatus(ctx context.Context, prNumber int) (*ReviewState, error) {
+	var pr struct {
+		MergeableState string `json:"mergeable_state"`
+		Base           struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("pulls/%d", prNumber), &pr); err != nil {
+		return nil, err
+	}
+
+	var reviews []ghReview
+	if err := c.getJSON(ctx, fmt.Sprintf("pulls/%d/reviews?per_page=100", prNumber), &reviews); err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+
+	comments, err := c.GetPRComments(ctx, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.reviewRequirements(ctx, pr.Base.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &ReviewState{
+		CommentCount:      len(comments),
+		RequiredApprovals: req.approvals,
+		Blocked:           pr.MergeableState == "blocked",
+	}
+	for _, comment := range comments {
+		if comment.CreatedAt.After(state.LastActivity) {
+			state.LastActivity = comment.CreatedAt
+		}
+	}
+
+	// Each reviewer's latest approving, rejecting or dismissed review is
+	// their verdict; plain comments leave it unchanged
+	verdicts := make(map[string]string)
+	for _, review := range reviews {
+		if review.SubmittedAt.After(state.LastActivity) {
+			state.LastActivity = review.SubmittedAt
+		}
+		switch review.State {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			verdicts[review.User.Login] = review.State
+		}
+	}
+	var approvers []string
+	changesRequested := false
+	for login, verdict := range verdicts {
+		switch verdict {
+		case "APPROVED":
+			approvers = append(approvers, login)
+		case "CHANGES_REQUESTED":
+			changesRequested = true
+		}
+	}
+	state.Approvals = len(approvers)
+
+	if req.codeOwners && len(approvers) > 0 {
+		pending, err := c.filesAwaitingOwners(ctx, prNumber, pr.Base.Ref, approvers)
+		if err != nil {
+			return nil, err
+		}
+		state.OwnersPending = pending
+	}
+
+	if state.LastActivity.IsZero() {
+		state.LastActivity = time.Now()
+	}
+
+	switch {
+	case changesRequested:
+		state.Status = ReviewChangesRequested
+	case state.Approvals >= req.approvals && len(state.OwnersPending) == 0 && !state.Blocked:
+		state.Status = ReviewApproved
+	case len(reviews) > 0 || len(comments) > 0:
+		state.Status = ReviewInProgress
+	default:
+		state.Status = ReviewPending
+	}
+	return state, nil
+}
+
+// reviewRequirements merges the client's policy with the approval rules of
+// the base branch's classic protection and rulesets. Reading classic
+// protection needs admin access; without it only rulesets are consulted.
+func (c *PRClient) reviewRequirements(ctx context.Context, branch string) (reviewRequirements, error) {
+	req := reviewRequirements{approvals: c.reviews.RequiredApprovals, codeOwners: c.reviews.RequireCodeOwners}
+
+	var protection struct {
+		RequiredPullRequestReviews *struct {
+			RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+			RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+		} `json:"required_pull_request_reviews"`
+	}
+	err := c.getJSON(ctx, "branches/"+url.PathEscape(branch)+"/protection", &protection)
+	switch {
+	case isStatus(err, http.StatusNotFound), isStatus(err, http.StatusForbidden):
+	case err != nil:
+		return req, fmt.Errorf("failed to get branch protection: %w", err)
+	case protection.RequiredPullRequestReviews != nil:
+		req.approvals = max(req.approvals, protection.RequiredPullRequestReviews.RequiredApprovingReviewCount)
+		req.codeOwners = req.codeOwners || protection.RequiredPullRequestReviews.RequireCodeOwnerReviews
+	}
+
+	var rules []struct {
+		Type       string `json:"type"`
+		Parameters struct {
+			RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+			RequireCodeOwnerReview       bool `json:"require_code_owner_review"`
+		} `json:"parameters"`
+	}
+	err = c.getJSON(ctx, "rules/branches/"+url.PathEscape(branch), &rules)
+	switch {
+	case isStatus(err, http.StatusNotFound), isStatus(err, http.StatusForbidden):
+	case err != nil:
+		return req, fmt.Errorf("failed to get branch rules: %w", err)
+	}
+	for _, rule := range rules {
+		if rule.Type == "pull_request" {
+			req.approvals = max(req.approvals, rule.Parameters.RequiredApprovingReviewCount)
+			req.codeOwners = req.codeOwners || rule.Parameters.RequireCodeOwnerReview
+		}
+	}
+
+	req.approvals = max(req.approvals, 1)
+	return req, nil
+}
+
+// filesAwaitingOwners lists the PR's changed files whose code owners have
+// not approved. Files without owners need no owner approval.
+func (c *PRClient) filesAwaitingOwners(ctx context.Context, prNumber int, base string, approvers []string) ([]string, error) {
+	owners, err := c.getCodeOwners(ctx, base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CODEOWNERS: %w", err)
+	}
+	if owners == nil {
+		return nil, nil
+	}
+
+	files, err := c.getPRFiles(ctx, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	approved := make(map[string]bool) // owner -> approved, cached across files
+	var pending []string
+	for _, file := range files {
+		fileOwners := owners.Owners(file)
+		if len(fileOwners) == 0 {
+			continue
+		}
+		ok := false
+		for _, owner := range fileOwners {
+			if _, seen := approved[owner]; !seen {
+				if approved[owner], err = c.ownerApproved(ctx, owner, approvers); err != nil {
+					return nil, err
+				}
+			}
+			if approved[owner] {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			pending = append(pending, file)
+		}
+	}
+	sort.Strings(pending)
+	return pending, nil
+}
+
+// ownerApproved reports whether a CODEOWNERS owner is among the approvers:
+// directly for @user, by active membership for @org/team. Email owners
+// cannot be matched to logins and never count.
+func (c *PRClient) ownerApproved(ctx context.Context, owner string, approvers []string) (bool, error) {
+	name, ok := strings.CutPrefix(owner, "@")
+	if !ok {
+		return false, nil
+	}
+
+	org, team, isTeam := strings.Cut(name, "/")
+	for _, login := range approvers {
+		if !isTeam {
+			if strings.EqualFold(login, name) {
+				return true, nil
+			}
+			continue
+		}
+
+		var membership struct {
+			State string `json:"state"`
+		}
+		u := fmt.Sprintf("%s/orgs/%s/teams/%s/memberships/%s", c.baseURL, org, team, login)
+		resp, err := c.doRequest(ctx, "GET", u, nil)
+		if isStatus(err, http.StatusNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to check %s membership: %w", owner, err)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&membership)
+		resp.Body.Close()
+		if err != nil {
+			return false, fmt.Errorf("failed to decode %s membership: %w", owner, err)
+		}
+		if membership.State == "active" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getPRFiles lists the paths a PR changes
+func (c *PRClient) getPRFiles(ctx context.Context, prNumber int) ([]string, error) {
+	var paths []string
+	for page := 1; ; page++ {
+		var files []struct {
+			Filename string `json:"filename"`
+		}
+		if err := c.getJSON(ctx, fmt.Sprintf("pulls/%d/files?per_page=100&page=%d", prNumber, page), &files); err != nil {
+			return nil, fmt.Errorf("failed to get PR files: %w", err)
+		}
+		for _, f := range files {
+			paths = append(paths, f.Filename)
+		}
+		if len(files) < 100 {
+			return paths, nil
+		}
+	}
+}
+
+// getJSON fetches a path under the repository and decodes the response
+func (c *PRClient) getJSON(ctx context.Context, path string, out any) error {
+	u := fmt.Sprintf("%s/repos/%s/%s/%s", c.baseURL, c.owner, c.repo, path)
+	resp, err := c.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// newMockReviewsClient serves canned JSON keyed by request URI, or an error
+// status for int values; unknown paths return 404
+func newMockReviewsClient(t *testing.T, policy ReviewPolicy, routes map[string]any) *PRClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.RequestURI()]
+		if !ok {
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+			return
+		}
+		if status, ok := body.(int); ok {
+			http.Error(w, `{"message": "denied"}`, status)
+			return
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return &PRClient{httpClient: server.Client(), owner: "owner", repo: "repo", token: "test-token", baseURL: server.URL, reviews: policy}
+}
+
+func review(login, state string) map[string]any {
+	return map[string]any{"user": map[string]any{"login": login}, "state": state, "submitted_at": "2026-01-02T03:04:05Z"}
+}
+
+func basePR(mergeableState string) map[string]any {
+	return map[string]any{"mergeable_state": mergeableState, "base": map[string]any{"ref": "main"}}
+}
+
+func TestFormalReviewStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		routes    map[string]any
+		policy    ReviewPolicy
+		want      ReviewStatus
+		approvals int
+		needed    int
+	}{
+		{
+			name:   "no reviews",
+			routes: map[string]any{"/repos/owner/repo/pulls/1/reviews?per_page=100": []any{}},
+			want:   ReviewPending,
+			needed: 1,
+		},
+		{
+			name: "approved",
+			routes: map[string]any{"/repos/owner/repo/pulls/1/reviews?per_page=100": []any{
+				review("alice", "COMMENTED"), review("alice", "APPROVED"), review("alice", "COMMENTED"),
+			}},
+			want:      ReviewApproved,
+			approvals: 1,
+			needed:    1,
+		},
+		{
+			name: "changes requested by one reviewer",
+			routes: map[string]any{"/repos/owner/repo/pulls/1/reviews?per_page=100": []any{
+				review("alice", "APPROVED"), review("bob", "CHANGES_REQUESTED"),
+			}},
+			want:      ReviewChangesRequested,
+			approvals: 1,
+			needed:    1,
+		},
+		{
+			name: "dismissed approval no longer counts",
+			routes: map[string]any{"/repos/owner/repo/pulls/1/reviews?per_page=100": []any{
+				review("alice", "APPROVED"), review("alice", "DISMISSED"),
+			}},
+			want:   ReviewInProgress,
+			needed: 1,
+		},
+		{
+			name: "branch protection raises the approval count",
+			routes: map[string]any{
+				"/repos/owner/repo/pulls/1/reviews?per_page=100": []any{review("alice", "APPROVED")},
+				"/repos/owner/repo/branches/main/protection": map[string]any{
+					"required_pull_request_reviews": map[string]any{"required_approving_review_count": 2},
+				},
+			},
+			want:      ReviewInProgress,
+			approvals: 1,
+			needed:    2,
+		},
+		{
+			name: "rulesets apply without admin access",
+			routes: map[string]any{
+				"/repos/owner/repo/pulls/1/reviews?per_page=100": []any{review("alice", "APPROVED"), review("bob", "APPROVED")},
+				"/repos/owner/repo/branches/main/protection":     http.StatusForbidden,
+				"/repos/owner/repo/rules/branches/main": []any{
+					map[string]any{"type": "pull_request", "parameters": map[string]any{"required_approving_review_count": 3}},
+				},
+			},
+			want:      ReviewInProgress,
+			approvals: 2,
+			needed:    3,
+		},
+		{
+			name: "policy count",
+			routes: map[string]any{"/repos/owner/repo/pulls/1/reviews?per_page=100": []any{
+				review("alice", "APPROVED"), review("bob", "APPROVED"),
+			}},
+			policy:    ReviewPolicy{RequiredApprovals: 2},
+			want:      ReviewApproved,
+			approvals: 2,
+			needed:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.routes["/repos/owner/repo/pulls/1"] = basePR("clean")
+			tt.routes["/repos/owner/repo/pulls/1/comments"] = []any{}
+			tt.policy.Formal = true
+			client := newMockReviewsClient(t, tt.policy, tt.routes)
+
+			state, err := client.GetReviewStatus(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if state.Status != tt.want {
+				t.Errorf("expected status %s, got %s", tt.want, state.Status)
+			}
+			if state.Approvals != tt.approvals || state.RequiredApprovals != tt.needed {
+				t.Errorf("expected %d/%d approvals, got %d/%d", tt.approvals, tt.needed, state.Approvals, state.RequiredApprovals)
+			}
+		})
+	}
+}
+
+func TestFormalReviewStatus_BlockedIsNotApproved(t *testing.T) {
+	client := newMockReviewsClient(t, ReviewPolicy{Formal: true}, map[string]any{
+		"/repos/owner/repo/pulls/1":                      basePR("blocked"),
+		"/repos/owner/repo/pulls/1/comments":             []any{},
+		"/repos/owner/repo/pulls/1/reviews?per_page=100": []any{review("alice", "APPROVED")},
+	})
+
+	state, err := client.GetReviewStatus(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !state.Blocked || state.Status != ReviewInProgress {
+		t.Errorf("expected blocked and in progress, got blocked=%v status=%s", state.Blocked, state.Status)
+	}
+}
+
+func TestFormalReviewStatus_CodeOwners(t *testing.T) {
+	codeowners := base64.StdEncoding.EncodeToString([]byte("*.go @gopher\n/docs/ @org/writers\n"))
+	routes := map[string]any{
+		"/repos/owner/repo/pulls/1":                              basePR("clean"),
+		"/repos/owner/repo/pulls/1/comments":                     []any{},
+		"/repos/owner/repo/contents/.github/CODEOWNERS?ref=main": map[string]any{"content": codeowners, "encoding": "base64"},
+		"/repos/owner/repo/pulls/1/files?per_page=100&page=1":    []any{map[string]any{"filename": "main.go"}, map[string]any{"filename": "docs/intro.md"}, map[string]any{"filename": "LICENSE"}},
+		"/repos/owner/repo/pulls/1/reviews?per_page=100":         []any{review("alice", "APPROVED")},
+		"/orgs/org/teams/writers/memberships/alice":              map[string]any{"state": "active"},
+	}
+	client := newMockReviewsClient(t, ReviewPolicy{Formal: true, RequireCodeOwners: true}, routes)
+	ctx := context.Background()
+
+	// alice is a writer but not a Go owner
+	state, err := client.GetReviewStatus(ctx, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(state.OwnersPending, []string{"main.go"}) {
+		t.Errorf("expected main.go pending, got %v", state.OwnersPending)
+	}
+	if state.Status != ReviewInProgress {
+		t.Errorf("expected in progress, got %s", state.Status)
+	}
+
+	routes["/repos/owner/repo/pulls/1/reviews?per_page=100"] = []any{review("alice", "APPROVED"), review("Gopher", "APPROVED")}
+	state, err = client.GetReviewStatus(ctx, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(state.OwnersPending) != 0 || state.Status != ReviewApproved {
+		t.Errorf("expected approved with no pending owners, got %s %v", state.Status, state.OwnersPending)
+	}
+}
+
+func TestDoRequest_PermissionErrorsAreNotRetried(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"message": "Must have admin rights"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := &PRClient{httpClient: server.Client(), token: "test-token", baseURL: server.URL}
+	_, err := client.doRequest(context.Background(), "GET", server.URL+"/x", nil)
+	if !isStatus(err, http.StatusForbidden) {
+		t.Errorf("expected 403 API error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
@@ -2,6 +2,7 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -27,6 +28,15 @@ type CheckRun struct {
 	Name       string `json:"name"`
 	Status     string `json:"status"`     // queued, in_progress, completed
 	Conclusion string `json:"conclusion"` // success, failure, cancelled, skipped
+	HTMLURL    string `json:"html_url"`
+	Output     struct {
+		Title   string `json:"title"`
+		Summary string `json:"summary"`
+		Text    string `json:"text"`
+	} `json:"output"`
+	App struct {
+		Slug string `json:"slug"`
+	} `json:"app"`
 }
 
 // CheckRunsResponse represents the GitHub API response for check runs
@@ -137,3 +147,97 @@ func (c *PRClient) WaitForChecks(ctx context.Context, ref string, pollInterval t
 		}
 	}
 }
+
+// maxCheckLogBytes bounds the job log kept per failed check
+const maxCheckLogBytes = 16 * 1024
+
+// FailedCheck describes a failed check run with the detail a fix needs
+type FailedCheck struct {
+	Name        string
+	URL         string
+	Title       string
+	Summary     string
+	Text        string
+	Annotations []CheckAnnotation
+	Log         string // Tail of the job log (GitHub Actions only)
+}
+
+// CheckAnnotation is a file-level finding reported by a check
+type CheckAnnotation struct {
+	Path    string `json:"path"`
+	Line    int    `json:"start_line"`
+	Level   string `json:"annotation_level"` // notice, warning or failure
+	Message string `json:"message"`
+}
+
+// GetFailedChecks returns the failed check runs for ref with their output,
+// annotations and, for GitHub Actions jobs, the tail of the job log
+func (c *PRClient) GetFailedChecks(ctx context.Context, ref string) ([]FailedCheck, error) {
+	runs, err := c.getCheckRuns(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("get check runs: %w", err)
+	}
+
+	var failures []FailedCheck
+	for _, run := range runs {
+		if run.Status != "completed" {
+			continue
+		}
+		switch run.Conclusion {
+		case "success", "skipped", "neutral":
+			continue
+		}
+
+		failure := FailedCheck{
+			Name:    run.Name,
+			URL:     run.HTMLURL,
+			Title:   run.Output.Title,
+			Summary: run.Output.Summary,
+			Text:    run.Output.Text,
+		}
+
+		url := fmt.Sprintf("%s/repos/%s/%s/check-runs/%d/annotations", c.baseURL, c.owner, c.repo, run.ID)
+		resp, err := c.doRequest(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("get annotations for %s: %w", run.Name, err)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&failure.Annotations)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode annotations for %s: %w", run.Name, err)
+		}
+
+		// Actions check run IDs double as job IDs. Logs may have expired or
+		// be unreadable with the token's scopes, so they are best effort.
+		if run.App.Slug == "github-actions" {
+			failure.Log = c.jobLogTail(ctx, run.ID)
+		}
+
+		failures = append(failures, failure)
+	}
+	return failures, nil
+}
+
+// jobLogTail fetches the end of a GitHub Actions job log, or "" if it is
+// unavailable
+func (c *PRClient) jobLogTail(ctx context.Context, jobID int64) string {
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/jobs/%d/logs", c.baseURL, c.owner, c.repo, jobID)
+	resp, err := c.doRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ""
+	}
+	if len(data) > maxCheckLogBytes {
+		data = data[len(data)-maxCheckLogBytes:]
+		// Start at a line boundary
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+	return string(data)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -288,3 +289,49 @@ func TestGetCheckRuns_Pagination(t *testing.T) {
 		t.Errorf("expected 2 API calls for pagination, got %d", callCount)
 	}
 }
+
+func TestGetFailedChecks(t *testing.T) {
+	longLog := strings.Repeat("noise line\n", 3000) + "--- FAIL: TestParse\n"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/test-owner/test-repo/commits/feature/x/check-runs":
+			w.Write([]byte(`{"total_count": 3, "check_runs": [
+				{"id": 1, "name": "lint", "status": "completed", "conclusion": "success"},
+				{"id": 2, "name": "test", "status": "completed", "conclusion": "failure", "html_url": "https://ci/2",
+				 "output": {"title": "1 test failed", "summary": "TestParse failed"}, "app": {"slug": "github-actions"}},
+				{"id": 3, "name": "vet", "status": "completed", "conclusion": "timed_out", "app": {"slug": "other-ci"}}]}`))
+		case "/repos/test-owner/test-repo/check-runs/2/annotations":
+			w.Write([]byte(`[{"path": "parse.go", "start_line": 12, "annotation_level": "failure", "message": "nil map write"}]`))
+		case "/repos/test-owner/test-repo/check-runs/3/annotations":
+			w.Write([]byte(`[]`))
+		case "/repos/test-owner/test-repo/actions/jobs/2/logs":
+			w.Write([]byte(longLog))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+
+	failures, err := client.GetFailedChecks(context.Background(), "feature/x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("expected 2 failures, got %d", len(failures))
+	}
+
+	test := failures[0]
+	if test.Name != "test" || test.Title != "1 test failed" || test.URL != "https://ci/2" {
+		t.Errorf("unexpected failure %+v", test)
+	}
+	if len(test.Annotations) != 1 || test.Annotations[0] != (CheckAnnotation{Path: "parse.go", Line: 12, Level: "failure", Message: "nil map write"}) {
+		t.Errorf("unexpected annotations %+v", test.Annotations)
+	}
+	if len(test.Log) > maxCheckLogBytes || !strings.HasSuffix(test.Log, "--- FAIL: TestParse\n") || !strings.HasPrefix(test.Log, "noise line\n") {
+		t.Errorf("expected the log tail trimmed to whole lines, got %d bytes", len(test.Log))
+	}
+
+	if failures[1].Name != "vet" || failures[1].Log != "" {
+		t.Errorf("expected vet failure without a log, got %+v", failures[1])
+	}
+}
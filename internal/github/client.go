@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,6 +16,9 @@ import (
 	"time"
 )
 
+// DefaultBaseURL is the github.com REST API
+const DefaultBaseURL = "https://api.github.com"
+
 // PRClient manages GitHub PR operations
 type PRClient struct {
 	httpClient    *http.Client
@@ -24,6 +28,7 @@ type PRClient struct {
 	reviewTimeout time.Duration
 	token         string
 	baseURL       string // Base URL for GitHub API, defaults to https://api.github.com
+	reviews       ReviewPolicy
 	// events *events.Bus - added later when events package exists
 }
 
@@ -33,13 +38,19 @@ type PRClientConfig struct {
 	Repo          string
 	PollInterval  time.Duration
 	ReviewTimeout time.Duration
+	BaseURL       string // API base URL for GitHub Enterprise (default: https://api.github.com)
+	Token         string // API token (default: GITHUB_TOKEN or 'gh auth token')
+	Reviews       ReviewPolicy
 }
 
 // NewPRClient creates a new GitHub PR client
 func NewPRClient(cfg PRClientConfig) (*PRClient, error) {
-	token, err := getToken()
-	if err != nil {
-		return nil, err
+	token := cfg.Token
+	if token == "" {
+		var err error
+		if token, err = getToken(); err != nil {
+			return nil, err
+		}
 	}
 
 	owner := cfg.Owner
@@ -67,6 +78,11 @@ func NewPRClient(cfg PRClientConfig) (*PRClient, error) {
 		reviewTimeout = 2 * time.Hour
 	}
 
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
 	return &PRClient{
 		httpClient:    &http.Client{},
 		owner:         owner,
@@ -74,10 +90,16 @@ func NewPRClient(cfg PRClientConfig) (*PRClient, error) {
 		pollInterval:  pollInterval,
 		reviewTimeout: reviewTimeout,
 		token:         token,
-		baseURL:       "https://api.github.com",
+		baseURL:       baseURL,
+		reviews:       cfg.Reviews,
 	}, nil
 }
 
+// BaseURL returns the API base URL the client talks to
+func (c *PRClient) BaseURL() string {
+	return c.baseURL
+}
+
 // getToken retrieves the GitHub token from env or gh CLI
 func getToken() (string, error) {
 	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
@@ -162,8 +184,9 @@ func (c *PRClient) doRequest(ctx context.Context, method, url string, body any)
 			return resp, nil
 		}
 
-		// Handle rate limits (403 or 429)
-		if resp.StatusCode == 403 || resp.StatusCode == 429 {
+		// Handle rate limits (429, or 403 with the rate limit exhausted).
+		// Other 403s are permission errors and fail immediately.
+		if resp.StatusCode == 429 || (resp.StatusCode == 403 && isRateLimited(resp)) {
 			resp.Body.Close()
 
 			if attempt == maxRetries {
@@ -207,8 +230,30 @@ func (c *PRClient) doRequest(ctx context.Context, method, url string, body any)
 		// Fail on other 4xx errors
 		defer resp.Body.Close()
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	return nil, fmt.Errorf("request failed after %d retries", maxRetries)
 }
+
+// APIError is a non-retryable error response from the API
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// isStatus reports whether err is an API error with the given status
+func isStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// isRateLimited reports whether a 403 response is GitHub's primary or
+// secondary rate limit rather than a permission error
+func isRateLimited(resp *http.Response) bool {
+	return resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""
+}
@@ -0,0 +1,136 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// codeownersPaths are the locations GitHub reads CODEOWNERS from, in order
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeOwners maps paths to their owners using CODEOWNERS rules
+type CodeOwners struct {
+	rules []codeownersRule
+}
+
+type codeownersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// ParseCodeOwners parses a CODEOWNERS file. Invalid patterns are skipped,
+// as GitHub does.
+func ParseCodeOwners(content string) *CodeOwners {
+	co := &CodeOwners{}
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		pattern, err := codeownersPattern(fields[0])
+		if err != nil {
+			continue
+		}
+		rule := codeownersRule{pattern: pattern}
+		if len(fields) > 1 {
+			rule.owners = fields[1:]
+		}
+		co.rules = append(co.rules, rule)
+	}
+	return co
+}
+
+// Owners returns the owners of path. The last matching rule wins, and a
+// rule without owners leaves the path unowned.
+func (co *CodeOwners) Owners(path string) []string {
+	for i := len(co.rules) - 1; i >= 0; i-- {
+		if co.rules[i].pattern.MatchString(path) {
+			return co.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// codeownersPattern compiles a gitignore-style pattern. Patterns with a
+// leading or inner slash are anchored at the repository root; others match
+// at any depth. A match on a directory covers everything beneath it, except
+// that "dir/*" only covers dir's direct children.
+func codeownersPattern(p string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(strings.TrimSuffix(p, "/"), "/")
+	p = strings.TrimSuffix(strings.TrimPrefix(p, "/"), "/")
+	shallow := p == "*" || strings.HasSuffix(p, "/*")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	var re strings.Builder
+	if anchored {
+		re.WriteString("^")
+	} else {
+		re.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			re.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case p[i] == '*':
+			re.WriteString("[^/]*")
+		case p[i] == '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+	if !shallow {
+		re.WriteString("(?:/.*)?")
+	}
+	re.WriteString("$")
+	return regexp.Compile(re.String())
+}
+
+// getCodeOwners fetches CODEOWNERS from ref, returning nil if the
+// repository has none
+func (c *PRClient) getCodeOwners(ctx context.Context, ref string) (*CodeOwners, error) {
+	for _, path := range codeownersPaths {
+		u := fmt.Sprintf("%s/repos/%s/%s/contents/%s?ref=%s", c.baseURL, c.owner, c.repo, path, url.QueryEscape(ref))
+		resp, err := c.doRequest(ctx, "GET", u, nil)
+		if isStatus(err, http.StatusNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		var file struct {
+			Content  string `json:"content"`
+			Encoding string `json:"encoding"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		content := file.Content
+		if file.Encoding == "base64" {
+			data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content, "\n", ""))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+			}
+			content = string(data)
+		}
+		return ParseCodeOwners(content), nil
+	}
+	return nil, nil
+}
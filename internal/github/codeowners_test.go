@@ -0,0 +1,46 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCodeOwners_Owners(t *testing.T) {
+	co := ParseCodeOwners(`# Default owners
+*                 @org/core
+*.go              @gopher   # Go files
+/docs/            @writer
+internal/forge/   @alice @org/forge
+build/*           @builder
+**/testdata       @tester
+/vendor/
+`)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"@org/core"}},
+		{"main.go", []string{"@gopher"}},
+		{"internal/cli/run.go", []string{"@gopher"}},
+		{"docs/guide/intro.md", []string{"@writer"}},
+		{"internal/docs/x.md", []string{"@org/core"}},
+		{"internal/forge/gitlab.go", []string{"@alice", "@org/forge"}},
+		{"build/Makefile", []string{"@builder"}},
+		{"build/scripts/release.sh", []string{"@org/core"}},
+		{"internal/worker/testdata/a.txt", []string{"@tester"}},
+		{"vendor/lib/lib.go", nil},
+	}
+	for _, tt := range tests {
+		if got := co.Owners(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCodeOwners_Empty(t *testing.T) {
+	co := ParseCodeOwners("# nothing owned\n\n")
+	if got := co.Owners("main.go"); got != nil {
+		t.Errorf("expected no owners, got %v", got)
+	}
+}
@@ -15,6 +15,7 @@ type PRComment struct {
 	Body      string
 	Author    string
 	CreatedAt time.Time
+	InReplyTo int64 // ID of the comment that opened the thread, if a reply
 }
 
 // ghReviewComment is the GitHub API response for a PR review comment
@@ -25,6 +26,7 @@ type ghReviewComment struct {
 	Body      string    `json:"body"`
 	User      ghUser    `json:"user"`
 	CreatedAt time.Time `json:"created_at"`
+	InReplyTo int64     `json:"in_reply_to_id"`
 }
 
 type ghUser struct {
@@ -59,6 +61,7 @@ func (c *PRClient) GetPRComments(ctx context.Context, prNumber int) ([]PRComment
 			Body:      ghComment.Body,
 			Author:    ghComment.User.Login,
 			CreatedAt: ghComment.CreatedAt,
+			InReplyTo: ghComment.InReplyTo,
 		})
 	}
 
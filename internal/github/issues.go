@@ -0,0 +1,77 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Issue is a GitHub issue
+type Issue struct {
+	Number int
+	Title  string
+	Body   string
+	URL    string
+	Labels []string
+}
+
+type ghIssue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	Labels      []ghLabel `json:"labels"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+type ghLabel struct {
+	Name string `json:"name"`
+}
+
+// ListIssues returns the open issues carrying label. Pull requests, which
+// the issues API also returns, are skipped.
+func (c *PRClient) ListIssues(ctx context.Context, label string) ([]Issue, error) {
+	var issues []Issue
+	for page := 1; ; page++ {
+		var batch []ghIssue
+		path := fmt.Sprintf("issues?state=open&labels=%s&per_page=100&page=%d", url.QueryEscape(label), page)
+		if err := c.getJSON(ctx, path, &batch); err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+		for _, gi := range batch {
+			if gi.PullRequest != nil {
+				continue
+			}
+			issue := Issue{Number: gi.Number, Title: gi.Title, Body: gi.Body, URL: gi.HTMLURL}
+			for _, l := range gi.Labels {
+				issue.Labels = append(issue.Labels, l.Name)
+			}
+			issues = append(issues, issue)
+		}
+		if len(batch) < 100 {
+			return issues, nil
+		}
+	}
+}
+
+// CommentOnIssue posts a comment on an issue or PR conversation
+func (c *PRClient) CommentOnIssue(ctx context.Context, number int, body string) error {
+	u := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", c.baseURL, c.owner, c.repo, number)
+	resp, err := c.doRequest(ctx, "POST", u, map[string]string{"body": body})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// CloseIssue closes an issue as completed
+func (c *PRClient) CloseIssue(ctx context.Context, number int) error {
+	u := fmt.Sprintf("%s/repos/%s/%s/issues/%d", c.baseURL, c.owner, c.repo, number)
+	resp, err := c.doRequest(ctx, "PATCH", u, map[string]string{"state": "closed", "state_reason": "completed"})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListIssues_SkipsPullRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/issues" || r.URL.Query().Get("labels") != "choo" || r.URL.Query().Get("state") != "open" {
+			t.Errorf("unexpected request %s", r.URL.RequestURI())
+		}
+		w.Write([]byte(`[
+			{"number": 1, "title": "Dark mode", "body": "Please", "html_url": "https://github.com/owner/repo/issues/1", "labels": [{"name": "choo"}]},
+			{"number": 2, "title": "Add dark mode", "pull_request": {"url": "x"}}
+		]`))
+	}))
+	defer server.Close()
+
+	client := &PRClient{httpClient: server.Client(), owner: "owner", repo: "repo", token: "test-token", baseURL: server.URL}
+	issues, err := client.ListIssues(context.Background(), "choo")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].Number != 1 || issues[0].URL != "https://github.com/owner/repo/issues/1" || issues[0].Labels[0] != "choo" {
+		t.Errorf("unexpected issue %+v", issues[0])
+	}
+}
+
+func TestCloseIssue(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/repos/owner/repo/issues/7" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &PRClient{httpClient: server.Client(), owner: "owner", repo: "repo", token: "test-token", baseURL: server.URL}
+	if err := client.CloseIssue(context.Background(), 7); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got["state"] != "closed" || got["state_reason"] != "completed" {
+		t.Errorf("unexpected body %v", got)
+	}
+}
@@ -16,6 +16,8 @@ type PRInfo struct {
 	TargetBranch string
 	Title        string
 	CreatedAt    time.Time
+	State        string // as reported by the code host, e.g. "open" or "closed"
+	Merged       bool
 }
 
 // MergeResult holds the result of a merge operation
@@ -33,6 +35,8 @@ type ghPullRequest struct {
 	Head      ghRef     `json:"head"`
 	Base      ghRef     `json:"base"`
 	Title     string    `json:"title"`
+	State     string    `json:"state"`
+	Merged    bool      `json:"merged"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -53,9 +57,65 @@ func (c *PRClient) CreatePR(ctx context.Context, title, body, branch string) (*P
 	return nil, fmt.Errorf("PR creation is delegated to Claude via 'gh pr create'")
 }
 
+// OpenPR opens a pull request from head into base through the REST API.
+// Unlike CreatePR, which leaves the description to Claude, the caller
+// supplies the title and body.
+func (c *PRClient) OpenPR(ctx context.Context, head, base, title, body string) (*PRInfo, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls", c.baseURL, c.owner, c.repo)
+	reqBody := map[string]string{
+		"head":  head,
+		"base":  base,
+		"title": title,
+		"body":  body,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var ghPR ghPullRequest
+	if err := json.Unmarshal(bodyBytes, &ghPR); err != nil {
+		return nil, fmt.Errorf("failed to parse PR response: %w", err)
+	}
+
+	return &PRInfo{
+		Number:       ghPR.Number,
+		URL:          ghPR.HTMLURL,
+		Branch:       ghPR.Head.Ref,
+		TargetBranch: ghPR.Base.Ref,
+		Title:        ghPR.Title,
+		CreatedAt:    ghPR.CreatedAt,
+		State:        ghPR.State,
+		Merged:       ghPR.Merged,
+	}, nil
+}
+
+// UpdatePRBase retargets an open PR onto a different base branch
+func (c *PRClient) UpdatePRBase(ctx context.Context, prNumber int, base string) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", c.baseURL, c.owner, c.repo, prNumber)
+	reqBody := map[string]string{
+		"base": base,
+	}
+
+	resp, err := c.doRequest(ctx, "PATCH", url, reqBody)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
 // GetPR fetches current PR information by number
 func (c *PRClient) GetPR(ctx context.Context, prNumber int) (*PRInfo, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d", c.owner, c.repo, prNumber)
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", c.baseURL, c.owner, c.repo, prNumber)
 	resp, err := c.doRequest(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
@@ -79,12 +139,14 @@ func (c *PRClient) GetPR(ctx context.Context, prNumber int) (*PRInfo, error) {
 		TargetBranch: ghPR.Base.Ref,
 		Title:        ghPR.Title,
 		CreatedAt:    ghPR.CreatedAt,
+		State:        ghPR.State,
+		Merged:       ghPR.Merged,
 	}, nil
 }
 
 // UpdatePR updates an existing PR's title or body
 func (c *PRClient) UpdatePR(ctx context.Context, prNumber int, title, body string) error {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d", c.owner, c.repo, prNumber)
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", c.baseURL, c.owner, c.repo, prNumber)
 	reqBody := map[string]string{
 		"title": title,
 		"body":  body,
@@ -101,7 +163,7 @@ func (c *PRClient) UpdatePR(ctx context.Context, prNumber int, title, body strin
 
 // Merge executes a squash merge on the PR
 func (c *PRClient) Merge(ctx context.Context, prNumber int) (*MergeResult, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d/merge", c.owner, c.repo, prNumber)
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/merge", c.baseURL, c.owner, c.repo, prNumber)
 	reqBody := map[string]string{
 		"merge_method": "squash",
 	}
@@ -131,7 +193,7 @@ func (c *PRClient) Merge(ctx context.Context, prNumber int) (*MergeResult, error
 
 // ClosePR closes a PR without merging
 func (c *PRClient) ClosePR(ctx context.Context, prNumber int) error {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d", c.owner, c.repo, prNumber)
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", c.baseURL, c.owner, c.repo, prNumber)
 	reqBody := map[string]string{
 		"state": "closed",
 	}
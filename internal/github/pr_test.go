@@ -160,6 +160,84 @@ func TestUpdatePR_Success(t *testing.T) {
 	}
 }
 
+func TestOpenPR_Success(t *testing.T) {
+	var receivedBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/repos/testowner/testrepo/pulls" {
+			t.Errorf("expected path /repos/testowner/testrepo/pulls, got %s", r.URL.Path)
+		}
+
+		json.NewDecoder(r.Body).Decode(&receivedBody)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(ghPullRequest{
+			Number:  7,
+			HTMLURL: "https://github.com/testowner/testrepo/pull/7",
+			Head:    ghRef{Ref: receivedBody["head"]},
+			Base:    ghRef{Ref: receivedBody["base"]},
+			Title:   receivedBody["title"],
+		})
+	}))
+	defer server.Close()
+
+	client := &PRClient{
+		httpClient: server.Client(),
+		owner:      "testowner",
+		repo:       "testrepo",
+		token:      "test-token",
+		baseURL:    server.URL,
+	}
+
+	pr, err := client.OpenPR(context.Background(), "stack/db", "stack/config", "db: add schema", "body")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if receivedBody["head"] != "stack/db" || receivedBody["base"] != "stack/config" {
+		t.Errorf("unexpected head/base: %v", receivedBody)
+	}
+	if pr.Number != 7 {
+		t.Errorf("expected PR number 7, got %d", pr.Number)
+	}
+	if pr.Branch != "stack/db" || pr.TargetBranch != "stack/config" {
+		t.Errorf("unexpected branches: %s -> %s", pr.Branch, pr.TargetBranch)
+	}
+}
+
+func TestUpdatePRBase_Success(t *testing.T) {
+	var receivedBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("expected PATCH request, got %s", r.Method)
+		}
+		if r.URL.Path != "/repos/testowner/testrepo/pulls/7" {
+			t.Errorf("expected path /repos/testowner/testrepo/pulls/7, got %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&receivedBody)
+		json.NewEncoder(w).Encode(map[string]any{"number": 7})
+	}))
+	defer server.Close()
+
+	client := &PRClient{
+		httpClient: server.Client(),
+		owner:      "testowner",
+		repo:       "testrepo",
+		token:      "test-token",
+		baseURL:    server.URL,
+	}
+
+	if err := client.UpdatePRBase(context.Background(), 7, "main"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if receivedBody["base"] != "main" {
+		t.Errorf("expected base 'main', got %q", receivedBody["base"])
+	}
+}
+
 func TestMerge_SquashMethod(t *testing.T) {
 	var receivedBody map[string]string
 
@@ -18,13 +18,20 @@ const (
 	ReviewTimeout          ReviewStatus = "timeout"
 )
 
-// ReviewState holds the parsed review state from PR reactions
+// ReviewState holds the parsed review state from PR reactions, or from
+// formal reviews under a Formal review policy
 type ReviewState struct {
 	Status       ReviewStatus
 	HasEyes      bool
 	HasThumbsUp  bool
 	CommentCount int
 	LastActivity time.Time
+
+	// Formal review policy only
+	Approvals         int      // Distinct reviewers whose latest verdict is APPROVED
+	RequiredApprovals int      // Approvals needed under the policy and branch rules
+	OwnersPending     []string // Changed files still awaiting a code owner's approval
+	Blocked           bool     // GitHub reports the PR as blocked from merging
 }
 
 // PollResult represents the result of a single poll iteration
@@ -76,8 +83,13 @@ func (c *PRClient) getReactions(ctx context.Context, prNumber int) ([]Reaction,
 	return reactions, nil
 }
 
-// GetReviewStatus fetches the current review status from reactions and comments
+// GetReviewStatus fetches the current review status from reactions and
+// comments, or from submitted reviews and branch rules under a Formal policy
 func (c *PRClient) GetReviewStatus(ctx context.Context, prNumber int) (*ReviewState, error) {
+	if c.reviews.Formal {
+		return c.getFormalReviewStatus(ctx, prNumber)
+	}
+
 	reactions, err := c.getReactions(ctx, prNumber)
 	if err != nil {
 		return nil, err
@@ -88,6 +100,13 @@ func (c *PRClient) GetReviewStatus(ctx context.Context, prNumber int) (*ReviewSt
 		return nil, err
 	}
 
+	return NewReviewState(reactions, comments), nil
+}
+
+// NewReviewState derives the review state from reactions and review
+// comments. Other code hosts reuse it so reaction-based review behaves the
+// same everywhere.
+func NewReviewState(reactions []Reaction, comments []PRComment) *ReviewState {
 	state := &ReviewState{
 		CommentCount: len(comments),
 	}
@@ -120,7 +139,7 @@ func (c *PRClient) GetReviewStatus(ctx context.Context, prNumber int) (*ReviewSt
 	// Determine status with precedence: approved > in_progress > changes_requested > pending
 	state.Status = determineStatus(state.HasThumbsUp, state.HasEyes, state.CommentCount)
 
-	return state, nil
+	return state
 }
 
 // determineStatus applies status precedence rules
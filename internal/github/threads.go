@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ReplyToComment posts a reply in the review thread that commentID belongs to
+func (c *PRClient) ReplyToComment(ctx context.Context, prNumber int, commentID int64, body string) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/comments/%d/replies", c.baseURL, c.owner, c.repo, prNumber, commentID)
+	resp, err := c.doRequest(ctx, "POST", url, map[string]string{"body": body})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// ResolveThread marks the review thread containing commentID as resolved.
+// Threads are only addressable through the GraphQL API, so the thread is
+// found by listing the PR's threads and matching the comment's database ID.
+func (c *PRClient) ResolveThread(ctx context.Context, prNumber int, commentID int64) error {
+	const query = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100) {
+        nodes { id isResolved comments(first: 100) { nodes { databaseId } } }
+      }
+    }
+  }
+}`
+	var threads struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					Nodes []struct {
+						ID         string `json:"id"`
+						IsResolved bool   `json:"isResolved"`
+						Comments   struct {
+							Nodes []struct {
+								DatabaseID int64 `json:"databaseId"`
+							} `json:"nodes"`
+						} `json:"comments"`
+					} `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	vars := map[string]any{"owner": c.owner, "repo": c.repo, "number": prNumber}
+	if err := c.graphql(ctx, query, vars, &threads); err != nil {
+		return fmt.Errorf("failed to list review threads: %w", err)
+	}
+
+	for _, thread := range threads.Repository.PullRequest.ReviewThreads.Nodes {
+		for _, comment := range thread.Comments.Nodes {
+			if comment.DatabaseID != commentID {
+				continue
+			}
+			if thread.IsResolved {
+				return nil
+			}
+			const mutation = `mutation($id: ID!) {
+  resolveReviewThread(input: {threadId: $id}) { thread { isResolved } }
+}`
+			if err := c.graphql(ctx, mutation, map[string]any{"id": thread.ID}, nil); err != nil {
+				return fmt.Errorf("failed to resolve review thread: %w", err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("no review thread contains comment %d", commentID)
+}
+
+// graphqlURL derives the GraphQL endpoint from the REST base URL:
+// api.github.com/graphql on github.com, /api/graphql on Enterprise
+func (c *PRClient) graphqlURL() string {
+	if base, ok := strings.CutSuffix(c.baseURL, "/api/v3"); ok {
+		return base + "/api/graphql"
+	}
+	return c.baseURL + "/graphql"
+}
+
+// graphql runs a GraphQL query and decodes its data into out
+func (c *PRClient) graphql(ctx context.Context, query string, vars map[string]any, out any) error {
+	resp, err := c.doRequest(ctx, "POST", c.graphqlURL(), map[string]any{"query": query, "variables": vars})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode GraphQL response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("GraphQL error: %s", result.Errors[0].Message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("failed to decode GraphQL data: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReplyToComment_PostsToRepliesEndpoint(t *testing.T) {
+	var gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.Path
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		gotBody = body["body"]
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": 2}`))
+	}))
+	defer server.Close()
+
+	client := &PRClient{httpClient: server.Client(), owner: "owner", repo: "repo", token: "test-token", baseURL: server.URL}
+	if err := client.ReplyToComment(context.Background(), 5, 101, "Fixed in abc123"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if gotPath != "POST /repos/owner/repo/pulls/5/comments/101/replies" {
+		t.Errorf("unexpected request %s", gotPath)
+	}
+	if gotBody != "Fixed in abc123" {
+		t.Errorf("expected reply body, got %q", gotBody)
+	}
+}
+
+func TestResolveThread_FindsThreadByComment(t *testing.T) {
+	var resolved []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if strings.Contains(req.Query, "resolveReviewThread") {
+			resolved = append(resolved, req.Variables["id"].(string))
+			w.Write([]byte(`{"data": {"resolveReviewThread": {"thread": {"isResolved": true}}}}`))
+			return
+		}
+		if req.Variables["number"] != float64(5) {
+			t.Errorf("expected PR 5, got %v", req.Variables["number"])
+		}
+		w.Write([]byte(`{"data": {"repository": {"pullRequest": {"reviewThreads": {"nodes": [
+			{"id": "T1", "isResolved": false, "comments": {"nodes": [{"databaseId": 100}]}},
+			{"id": "T2", "isResolved": false, "comments": {"nodes": [{"databaseId": 101}, {"databaseId": 102}]}},
+			{"id": "T3", "isResolved": true, "comments": {"nodes": [{"databaseId": 103}]}}
+		]}}}}}`))
+	}))
+	defer server.Close()
+
+	client := &PRClient{httpClient: server.Client(), owner: "owner", repo: "repo", token: "test-token", baseURL: server.URL}
+	ctx := context.Background()
+
+	if err := client.ResolveThread(ctx, 5, 102); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := client.ResolveThread(ctx, 5, 103); err != nil {
+		t.Fatalf("expected already-resolved thread to succeed, got %v", err)
+	}
+	if err := client.ResolveThread(ctx, 5, 999); err == nil {
+		t.Error("expected error for unknown comment")
+	}
+
+	if len(resolved) != 1 || resolved[0] != "T2" {
+		t.Errorf("expected only T2 to be resolved, got %v", resolved)
+	}
+}
+
+func TestResolveThread_ReturnsGraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": null, "errors": [{"message": "Resource not accessible by integration"}]}`))
+	}))
+	defer server.Close()
+
+	client := &PRClient{httpClient: server.Client(), owner: "owner", repo: "repo", token: "test-token", baseURL: server.URL}
+	err := client.ResolveThread(context.Background(), 5, 100)
+	if err == nil || !strings.Contains(err.Error(), "Resource not accessible") {
+		t.Errorf("expected GraphQL error, got %v", err)
+	}
+}
+
+func TestGraphqlURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{DefaultBaseURL, "https://api.github.com/graphql"},
+		{"https://ghe.example.com/api/v3", "https://ghe.example.com/api/graphql"},
+		{"http://127.0.0.1:8787", "http://127.0.0.1:8787/graphql"},
+	}
+	for _, tt := range tests {
+		c := &PRClient{baseURL: tt.baseURL}
+		if got := c.graphqlURL(); got != tt.want {
+			t.Errorf("graphqlURL() for %s = %s, want %s", tt.baseURL, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package intake
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/RevCBH/choo/internal/github"
+)
+
+// GitHubIssues is the part of the GitHub client the GitHub source uses
+type GitHubIssues interface {
+	ListIssues(ctx context.Context, label string) ([]github.Issue, error)
+	CommentOnIssue(ctx context.Context, number int, body string) error
+	CloseIssue(ctx context.Context, number int) error
+}
+
+var _ GitHubIssues = (*github.PRClient)(nil)
+
+// GitHubSource imports open issues carrying a label
+type GitHubSource struct {
+	client GitHubIssues
+	label  string
+}
+
+// NewGitHubSource creates a source for the issues labeled label
+func NewGitHubSource(client GitHubIssues, label string) *GitHubSource {
+	return &GitHubSource{client: client, label: label}
+}
+
+// Type implements Source
+func (s *GitHubSource) Type() string { return SourceGitHub }
+
+// List implements Source
+func (s *GitHubSource) List(ctx context.Context) ([]Issue, error) {
+	ghIssues, err := s.client.ListIssues(ctx, s.label)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, 0, len(ghIssues))
+	for _, gi := range ghIssues {
+		issues = append(issues, Issue{
+			Key:    strconv.Itoa(gi.Number),
+			Title:  gi.Title,
+			Body:   gi.Body,
+			URL:    gi.URL,
+			Labels: gi.Labels,
+		})
+	}
+	return issues, nil
+}
+
+// Comment implements Source
+func (s *GitHubSource) Comment(ctx context.Context, key, body string) error {
+	number, err := issueNumber(key)
+	if err != nil {
+		return err
+	}
+	return s.client.CommentOnIssue(ctx, number, body)
+}
+
+// Close implements Closer
+func (s *GitHubSource) Close(ctx context.Context, key string) error {
+	number, err := issueNumber(key)
+	if err != nil {
+		return err
+	}
+	return s.client.CloseIssue(ctx, number)
+}
+
+func issueNumber(key string) (int, error) {
+	number, err := strconv.Atoi(key)
+	if err != nil {
+		return 0, fmt.Errorf("invalid GitHub issue number %q", key)
+	}
+	return number, nil
+}
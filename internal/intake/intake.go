@@ -0,0 +1,243 @@
+// Package intake turns issues from trackers (GitHub issues, Jira, Linear)
+// into PRD files and reports feature progress back on the issue.
+package intake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/feature"
+	"github.com/RevCBH/choo/internal/forge"
+)
+
+// Source types, recorded in a PRD's source.type
+const (
+	SourceGitHub = "github"
+	SourceJira   = "jira"
+	SourceLinear = "linear"
+)
+
+// Issue is a tracker issue selected for import
+type Issue struct {
+	Key    string // "42" on GitHub, "ENG-123" on Jira and Linear
+	Title  string
+	Body   string // Markdown on GitHub and Linear, wiki markup on Jira
+	URL    string
+	Labels []string
+}
+
+// Source is an issue tracker that PRDs can be imported from
+type Source interface {
+	// Type returns the source type recorded in imported PRDs
+	Type() string
+
+	// List returns the issues selected for import
+	List(ctx context.Context) ([]Issue, error)
+
+	// Comment posts a comment on the issue identified by key
+	Comment(ctx context.Context, key, body string) error
+}
+
+// Closer is implemented by sources that close issues when their feature
+// completes
+type Closer interface {
+	Close(ctx context.Context, key string) error
+}
+
+// ImportResult reports what an import did
+type ImportResult struct {
+	Imported []*feature.PRD
+	Skipped  []Issue // already imported
+}
+
+// maxSlugLen leaves room for a key suffix within the 50 character PRD ID limit
+const maxSlugLen = 40
+
+// Import writes a PRD into prdDir for each of src's issues that has not
+// been imported before, and comments on the issue with the PRD's path. In
+// dry-run mode nothing is written or posted.
+func Import(ctx context.Context, src Source, prdDir string, dryRun bool) (*ImportResult, error) {
+	issues, err := src.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list %s issues: %w", src.Type(), err)
+	}
+
+	existing, err := discoverExisting(prdDir)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool)
+	imported := make(map[string]bool)
+	for _, prd := range existing {
+		ids[prd.ID] = true
+		if prd.Source != nil && prd.Source.Type == src.Type() {
+			imported[prd.Source.Key] = true
+		}
+	}
+
+	result := &ImportResult{}
+	for _, issue := range issues {
+		if imported[issue.Key] {
+			result.Skipped = append(result.Skipped, issue)
+			continue
+		}
+
+		prd := PRDFromIssue(src.Type(), issue, ids)
+		prd.FilePath = filepath.Join(prdDir, prd.ID+".md")
+		ids[prd.ID] = true
+		result.Imported = append(result.Imported, prd)
+		if dryRun {
+			continue
+		}
+
+		if err := feature.WritePRD(prd); err != nil {
+			return result, err
+		}
+		note := fmt.Sprintf("choo: imported as PRD `%s` (`%s`).", prd.ID, filepath.ToSlash(prd.FilePath))
+		if err := src.Comment(ctx, issue.Key, note); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to comment on %s issue %s: %v\n", src.Type(), issue.Key, err)
+		}
+	}
+	return result, nil
+}
+
+// discoverExisting returns the PRDs already in prdDir, if it exists
+func discoverExisting(prdDir string) ([]*feature.PRD, error) {
+	if _, err := os.Stat(prdDir); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return feature.DiscoverPRDs(prdDir)
+}
+
+// PRDFromIssue builds a draft PRD for issue. The ID is derived from the
+// title, suffixed with the issue key if it would clash with one in taken.
+func PRDFromIssue(sourceType string, issue Issue, taken map[string]bool) *feature.PRD {
+	id := slugify(issue.Title, maxSlugLen)
+	if len(id) < 2 {
+		id = "issue"
+	}
+	if taken[id] {
+		id = strings.Trim(id+"-"+slugify(issue.Key, 50-len(id)-1), "-")
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "\n# %s\n\n", issue.Title)
+	if issue.URL != "" {
+		fmt.Fprintf(&body, "Imported from [%s](%s).\n\n", issue.Key, issue.URL)
+	} else {
+		fmt.Fprintf(&body, "Imported from %s issue %s.\n\n", sourceType, issue.Key)
+	}
+	if text := strings.TrimSpace(issue.Body); text != "" {
+		body.WriteString(text)
+		body.WriteString("\n")
+	}
+
+	return &feature.PRD{
+		ID:     id,
+		Title:  issue.Title,
+		Status: feature.PRDStatusDraft,
+		Source: &feature.PRDSource{Type: sourceType, Key: issue.Key, URL: issue.URL},
+		Body:   body.String(),
+	}
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify lowercases s and joins its words with hyphens, cutting at a word
+// boundary to fit max
+func slugify(s string, max int) string {
+	slug := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(slug) > max {
+		cut := slug[:max]
+		if slug[max] != '-' {
+			if i := strings.LastIndex(cut, "-"); i > 0 {
+				cut = cut[:i]
+			}
+		}
+		slug = strings.Trim(cut, "-")
+	}
+	return slug
+}
+
+// NewSource creates the source named by sourceType from .choo.yaml intake
+// settings. Tokens are read from the configured environment variables; gh
+// is only used for GitHub and may be nil otherwise.
+func NewSource(sourceType string, cfg config.IntakeConfig, gh GitHubIssues) (Source, error) {
+	switch sourceType {
+	case SourceGitHub:
+		if gh == nil {
+			return nil, fmt.Errorf("github issues need the github forge")
+		}
+		return NewGitHubSource(gh, cfg.Label), nil
+	case SourceJira:
+		if os.Getenv(cfg.Jira.TokenEnv) == "" {
+			return nil, fmt.Errorf("%s is not set", cfg.Jira.TokenEnv)
+		}
+		jql := cfg.Jira.JQL
+		if jql == "" {
+			jql = DefaultJQL(cfg.Label)
+		}
+		return NewJiraSource(JiraConfig{
+			URL:   cfg.Jira.URL,
+			JQL:   jql,
+			Email: os.Getenv(cfg.Jira.EmailEnv),
+			Token: os.Getenv(cfg.Jira.TokenEnv),
+		})
+	case SourceLinear:
+		if os.Getenv(cfg.Linear.TokenEnv) == "" {
+			return nil, fmt.Errorf("%s is not set", cfg.Linear.TokenEnv)
+		}
+		return NewLinearSource(LinearConfig{
+			URL:   cfg.Linear.URL,
+			Label: cfg.Label,
+			Team:  cfg.Linear.Team,
+			Token: os.Getenv(cfg.Linear.TokenEnv),
+		})
+	}
+	return nil, fmt.Errorf("unknown intake source %q (want github, jira or linear)", sourceType)
+}
+
+// SourceFromConfig creates the source for sourceType from a repository's
+// configuration. GitHub issues are read through the configured forge,
+// which must be GitHub.
+func SourceFromConfig(cfg *config.Config, sourceType string) (Source, error) {
+	var gh GitHubIssues
+	if sourceType == SourceGitHub {
+		client, err := forge.New(forge.Config{
+			Type:     cfg.Forge.Type,
+			URL:      cfg.Forge.URL,
+			TokenEnv: cfg.Forge.TokenEnv,
+			Owner:    cfg.GitHub.Owner,
+			Repo:     cfg.GitHub.Repo,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s client: %w", cfg.Forge.Type, err)
+		}
+		var ok bool
+		if gh, ok = client.(GitHubIssues); !ok {
+			return nil, fmt.Errorf("importing GitHub issues needs forge.type github, not %s", cfg.Forge.Type)
+		}
+	}
+	return NewSource(sourceType, cfg.Intake, gh)
+}
+
+// ImportedPRD returns the PRD prdID in prdDir if it was imported from an
+// issue tracker, or nil
+func ImportedPRD(prdDir, prdID string) *feature.PRD {
+	prds, err := feature.DiscoverPRDs(prdDir)
+	if err != nil {
+		return nil
+	}
+	for _, prd := range prds {
+		if prd.ID == prdID && prd.Source != nil {
+			return prd
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package intake
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/feature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource is an in-memory tracker
+type fakeSource struct {
+	issues   []Issue
+	comments map[string][]string
+	closed   []string
+}
+
+func newFakeSource(issues ...Issue) *fakeSource {
+	return &fakeSource{issues: issues, comments: make(map[string][]string)}
+}
+
+func (s *fakeSource) Type() string { return SourceGitHub }
+
+func (s *fakeSource) List(ctx context.Context) ([]Issue, error) { return s.issues, nil }
+
+func (s *fakeSource) Comment(ctx context.Context, key, body string) error {
+	s.comments[key] = append(s.comments[key], body)
+	return nil
+}
+
+func (s *fakeSource) Close(ctx context.Context, key string) error {
+	s.closed = append(s.closed, key)
+	return nil
+}
+
+func TestImport_WritesLinkedPRDs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "prd")
+	src := newFakeSource(Issue{Key: "42", Title: "Add dark mode!", Body: "Users want it.", URL: "https://github.com/o/r/issues/42"})
+
+	result, err := Import(context.Background(), src, dir, false)
+	require.NoError(t, err)
+	require.Len(t, result.Imported, 1)
+
+	prd, err := feature.ParsePRD(filepath.Join(dir, "add-dark-mode.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "add-dark-mode", prd.ID)
+	assert.Equal(t, "Add dark mode!", prd.Title)
+	assert.Equal(t, feature.PRDStatusDraft, prd.Status)
+	assert.Equal(t, &feature.PRDSource{Type: "github", Key: "42", URL: "https://github.com/o/r/issues/42"}, prd.Source)
+	assert.Contains(t, prd.Body, "# Add dark mode!")
+	assert.Contains(t, prd.Body, "Imported from [42](https://github.com/o/r/issues/42).")
+	assert.Contains(t, prd.Body, "Users want it.")
+	require.NoError(t, feature.ValidatePRD(prd))
+
+	require.Len(t, src.comments["42"], 1)
+	assert.Contains(t, src.comments["42"][0], "imported as PRD `add-dark-mode`")
+}
+
+func TestImport_SkipsImportedAndAvoidsIDClashes(t *testing.T) {
+	dir := t.TempDir()
+	src := newFakeSource(Issue{Key: "1", Title: "Search"})
+	_, err := Import(context.Background(), src, dir, false)
+	require.NoError(t, err)
+
+	src.issues = append(src.issues, Issue{Key: "2", Title: "Search"})
+	result, err := Import(context.Background(), src, dir, false)
+	require.NoError(t, err)
+
+	require.Len(t, result.Skipped, 1)
+	assert.Equal(t, "1", result.Skipped[0].Key)
+	require.Len(t, result.Imported, 1)
+	assert.Equal(t, "search-2", result.Imported[0].ID)
+	assert.FileExists(t, filepath.Join(dir, "search-2.md"))
+}
+
+func TestImport_DryRunWritesNothing(t *testing.T) {
+	dir := t.TempDir()
+	src := newFakeSource(Issue{Key: "1", Title: "Search"})
+
+	result, err := Import(context.Background(), src, dir, true)
+	require.NoError(t, err)
+
+	require.Len(t, result.Imported, 1)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Empty(t, src.comments)
+}
+
+func TestPRDFromIssue_IDs(t *testing.T) {
+	tests := []struct {
+		title string
+		key   string
+		taken map[string]bool
+		want  string
+	}{
+		{"Export reports as CSV", "ENG-101", nil, "export-reports-as-csv"},
+		{"Export reports as CSV", "ENG-101", map[string]bool{"export-reports-as-csv": true}, "export-reports-as-csv-eng-101"},
+		{"A very long issue title that keeps going well past the limit", "9", nil, "a-very-long-issue-title-that-keeps-going"},
+		{"🚀", "PLAT-7", nil, "issue"},
+	}
+	for _, tt := range tests {
+		prd := PRDFromIssue(SourceJira, Issue{Key: tt.key, Title: tt.title}, tt.taken)
+		assert.Equal(t, tt.want, prd.ID, tt.title)
+	}
+}
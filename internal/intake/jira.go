@@ -0,0 +1,145 @@
+package intake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// JiraSource imports issues from Jira through the v2 REST API, which
+// returns descriptions as wiki markup on both Cloud and Server
+type JiraSource struct {
+	httpClient *http.Client
+	baseURL    string
+	jql        string
+	email      string // basic auth user; empty sends the token as a bearer token
+	token      string
+}
+
+// JiraConfig configures a JiraSource
+type JiraConfig struct {
+	URL   string
+	JQL   string
+	Email string
+	Token string
+}
+
+// NewJiraSource creates a source for the issues matched by cfg.JQL
+func NewJiraSource(cfg JiraConfig) (*JiraSource, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("jira url is required")
+	}
+	if cfg.JQL == "" {
+		return nil, fmt.Errorf("jira jql is required")
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("jira token is required")
+	}
+	return &JiraSource{
+		httpClient: &http.Client{},
+		baseURL:    strings.TrimSuffix(cfg.URL, "/"),
+		jql:        cfg.JQL,
+		email:      cfg.Email,
+		token:      cfg.Token,
+	}, nil
+}
+
+// DefaultJQL selects unresolved issues carrying label
+func DefaultJQL(label string) string {
+	return fmt.Sprintf(`labels = "%s" AND statusCategory != Done ORDER BY created ASC`, label)
+}
+
+// Type implements Source
+func (s *JiraSource) Type() string { return SourceJira }
+
+// List implements Source
+func (s *JiraSource) List(ctx context.Context) ([]Issue, error) {
+	var issues []Issue
+	for start := 0; ; {
+		q := url.Values{}
+		q.Set("jql", s.jql)
+		q.Set("fields", "summary,description,labels")
+		q.Set("startAt", fmt.Sprint(start))
+		q.Set("maxResults", "50")
+
+		var page struct {
+			Total  int `json:"total"`
+			Issues []struct {
+				Key    string `json:"key"`
+				Fields struct {
+					Summary     string   `json:"summary"`
+					Description string   `json:"description"`
+					Labels      []string `json:"labels"`
+				} `json:"fields"`
+			} `json:"issues"`
+		}
+		if err := s.do(ctx, "GET", "/rest/api/2/search?"+q.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, ji := range page.Issues {
+			issues = append(issues, Issue{
+				Key:    ji.Key,
+				Title:  ji.Fields.Summary,
+				Body:   ji.Fields.Description,
+				URL:    s.baseURL + "/browse/" + ji.Key,
+				Labels: ji.Fields.Labels,
+			})
+		}
+		start += len(page.Issues)
+		if len(page.Issues) == 0 || start >= page.Total {
+			return issues, nil
+		}
+	}
+}
+
+// Comment implements Source
+func (s *JiraSource) Comment(ctx context.Context, key, body string) error {
+	return s.do(ctx, "POST", "/rest/api/2/issue/"+url.PathEscape(key)+"/comment", map[string]string{"body": body}, nil)
+}
+
+// do sends an authenticated request and decodes the response into out
+func (s *JiraSource) do(ctx context.Context, method, path string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	if s.email != "" {
+		req.SetBasicAuth(s.email, s.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("jira request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("jira %s %s: status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode jira response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package intake
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraSource_ListAndComment(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/jira_search.json")
+	require.NoError(t, err)
+
+	var comment map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok && user == "me@example.com" && pass == "secret", "expected basic auth")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/rest/api/2/search":
+			assert.Equal(t, `labels = "choo" AND statusCategory != Done ORDER BY created ASC`, r.URL.Query().Get("jql"))
+			w.Write(fixture)
+		case r.Method == "POST" && r.URL.Path == "/rest/api/2/issue/ENG-101/comment":
+			json.NewDecoder(r.Body).Decode(&comment)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "1"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	src, err := NewJiraSource(JiraConfig{URL: server.URL + "/", JQL: DefaultJQL("choo"), Email: "me@example.com", Token: "secret"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	issues, err := src.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, Issue{
+		Key:    "ENG-101",
+		Title:  "Export reports as CSV",
+		Body:   "h2. Goal\nLet users download any report as CSV.",
+		URL:    server.URL + "/browse/ENG-101",
+		Labels: []string{"choo", "reports"},
+	}, issues[0])
+	assert.Empty(t, issues[1].Body)
+
+	require.NoError(t, src.Comment(ctx, "ENG-101", "choo: implementation started."))
+	assert.Equal(t, "choo: implementation started.", comment["body"])
+}
+
+func TestJiraSource_BearerTokenWithoutEmail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer pat", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"total": 0, "issues": []}`))
+	}))
+	defer server.Close()
+
+	src, err := NewJiraSource(JiraConfig{URL: server.URL, JQL: "project = ENG", Token: "pat"})
+	require.NoError(t, err)
+	issues, err := src.List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
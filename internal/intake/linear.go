@@ -0,0 +1,170 @@
+package intake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// LinearSource imports open Linear issues carrying a label through the
+// GraphQL API
+type LinearSource struct {
+	httpClient *http.Client
+	url        string
+	label      string
+	team       string
+	token      string
+}
+
+// LinearConfig configures a LinearSource
+type LinearConfig struct {
+	URL   string
+	Label string
+	Team  string // optional team key
+	Token string
+}
+
+// NewLinearSource creates a source for the issues labeled cfg.Label
+func NewLinearSource(cfg LinearConfig) (*LinearSource, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("linear url is required")
+	}
+	if cfg.Label == "" {
+		return nil, fmt.Errorf("linear label is required")
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("linear token is required")
+	}
+	return &LinearSource{
+		httpClient: &http.Client{},
+		url:        cfg.URL,
+		label:      cfg.Label,
+		team:       cfg.Team,
+		token:      cfg.Token,
+	}, nil
+}
+
+// Type implements Source
+func (s *LinearSource) Type() string { return SourceLinear }
+
+// List implements Source
+func (s *LinearSource) List(ctx context.Context) ([]Issue, error) {
+	const query = `query($filter: IssueFilter, $after: String) {
+  issues(filter: $filter, first: 50, after: $after) {
+    nodes { identifier title description url labels { nodes { name } } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+	filter := map[string]any{
+		"labels": map[string]any{"name": map[string]any{"eq": s.label}},
+		"state":  map[string]any{"type": map[string]any{"nin": []string{"completed", "canceled"}}},
+	}
+	if s.team != "" {
+		filter["team"] = map[string]any{"key": map[string]any{"eq": s.team}}
+	}
+
+	var issues []Issue
+	var after *string
+	for {
+		var data struct {
+			Issues struct {
+				Nodes []struct {
+					Identifier  string `json:"identifier"`
+					Title       string `json:"title"`
+					Description string `json:"description"`
+					URL         string `json:"url"`
+					Labels      struct {
+						Nodes []struct {
+							Name string `json:"name"`
+						} `json:"nodes"`
+					} `json:"labels"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"issues"`
+		}
+		if err := s.graphql(ctx, query, map[string]any{"filter": filter, "after": after}, &data); err != nil {
+			return nil, err
+		}
+		for _, n := range data.Issues.Nodes {
+			issue := Issue{Key: n.Identifier, Title: n.Title, Body: n.Description, URL: n.URL}
+			for _, l := range n.Labels.Nodes {
+				issue.Labels = append(issue.Labels, l.Name)
+			}
+			issues = append(issues, issue)
+		}
+		if !data.Issues.PageInfo.HasNextPage {
+			return issues, nil
+		}
+		cursor := data.Issues.PageInfo.EndCursor
+		after = &cursor
+	}
+}
+
+// Comment implements Source. Linear accepts an issue identifier such as
+// ENG-123 wherever an issue ID is expected.
+func (s *LinearSource) Comment(ctx context.Context, key, body string) error {
+	const mutation = `mutation($issueId: String!, $body: String!) {
+  commentCreate(input: {issueId: $issueId, body: $body}) { success }
+}`
+	var data struct {
+		CommentCreate struct {
+			Success bool `json:"success"`
+		} `json:"commentCreate"`
+	}
+	if err := s.graphql(ctx, mutation, map[string]any{"issueId": key, "body": body}, &data); err != nil {
+		return err
+	}
+	if !data.CommentCreate.Success {
+		return fmt.Errorf("linear did not create the comment on %s", key)
+	}
+	return nil
+}
+
+// graphql runs a query and decodes its data into out
+func (s *LinearSource) graphql(ctx context.Context, query string, vars map[string]any, out any) error {
+	data, err := json.Marshal(map[string]any{"query": query, "variables": vars})
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	// Personal API keys are sent as-is; OAuth tokens carry their own prefix
+	req.Header.Set("Authorization", s.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("linear request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("linear: status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode linear response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("linear: %s", result.Errors[0].Message)
+	}
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("decode linear data: %w", err)
+	}
+	return nil
+}
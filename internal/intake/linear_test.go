@@ -0,0 +1,68 @@
+package intake
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinearSource_ListAndComment(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/linear_issues.json")
+	require.NoError(t, err)
+
+	var commented map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "lin_api_key", r.Header.Get("Authorization"))
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if strings.Contains(req.Query, "commentCreate") {
+			commented = req.Variables
+			w.Write([]byte(`{"data": {"commentCreate": {"success": true}}}`))
+			return
+		}
+		filter := req.Variables["filter"].(map[string]any)
+		assert.Equal(t, map[string]any{"name": map[string]any{"eq": "choo"}}, filter["labels"])
+		assert.Equal(t, map[string]any{"key": map[string]any{"eq": "PLAT"}}, filter["team"])
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	src, err := NewLinearSource(LinearConfig{URL: server.URL, Label: "choo", Team: "PLAT", Token: "lin_api_key"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	issues, err := src.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "PLAT-7", issues[0].Key)
+	assert.Equal(t, "Rate limit the public API", issues[0].Title)
+	assert.Contains(t, issues[0].Body, "100 req/min")
+	assert.Equal(t, []string{"choo"}, issues[0].Labels)
+
+	require.NoError(t, src.Comment(ctx, "PLAT-7", "choo: feature complete."))
+	assert.Equal(t, "PLAT-7", commented["issueId"])
+	assert.Equal(t, "choo: feature complete.", commented["body"])
+}
+
+func TestLinearSource_GraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": null, "errors": [{"message": "Authentication required"}]}`))
+	}))
+	defer server.Close()
+
+	src, err := NewLinearSource(LinearConfig{URL: server.URL, Label: "choo", Token: "bad"})
+	require.NoError(t, err)
+	_, err = src.List(context.Background())
+	assert.ErrorContains(t, err, "Authentication required")
+}
@@ -0,0 +1,167 @@
+package intake
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/feature"
+)
+
+// trackerTimeout bounds each update posted to the tracker
+const trackerTimeout = 30 * time.Second
+
+// Tracker reports a feature's progress on the issue its PRD was imported
+// from. Updates are posted in order from a goroutine so the event bus is
+// never blocked on the tracker's API.
+type Tracker struct {
+	src   Source
+	key   string
+	prdID string
+
+	updates chan update
+	done    chan struct{}
+
+	mu     sync.Mutex
+	last   feature.FeatureStatus
+	closed bool
+}
+
+type update struct {
+	status feature.FeatureStatus
+	note   string
+}
+
+// NewTracker creates a tracker for prd, which must have a Source
+func NewTracker(src Source, prd *feature.PRD) *Tracker {
+	t := &Tracker{
+		src:     src,
+		key:     prd.Source.Key,
+		prdID:   prd.ID,
+		updates: make(chan update, 16),
+		done:    make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// Handler returns an event handler to subscribe to the run's bus
+func (t *Tracker) Handler() events.Handler {
+	return func(e events.Event) {
+		status, note, ok := statusFor(e, t.prdID)
+		if !ok {
+			return
+		}
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.closed || status == t.last {
+			return
+		}
+		t.last = status
+		select {
+		case t.updates <- update{status: status, note: note}:
+		default:
+			fmt.Fprintf(os.Stderr, "Warning: dropped %s update for issue %s\n", status, t.key)
+		}
+	}
+}
+
+// Close stops accepting events and waits for queued updates to be posted
+func (t *Tracker) Close() {
+	t.mu.Lock()
+	if !t.closed {
+		t.closed = true
+		close(t.updates)
+	}
+	t.mu.Unlock()
+	<-t.done
+}
+
+func (t *Tracker) run() {
+	defer close(t.done)
+	for u := range t.updates {
+		ctx, cancel := context.WithTimeout(context.Background(), trackerTimeout)
+		if err := t.post(ctx, u); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update %s issue %s: %v\n", t.src.Type(), t.key, err)
+		}
+		cancel()
+	}
+}
+
+// post comments on the issue, and closes it once the feature is complete
+func (t *Tracker) post(ctx context.Context, u update) error {
+	if err := t.src.Comment(ctx, t.key, "choo: "+u.note); err != nil {
+		return err
+	}
+	if closer, ok := t.src.(Closer); ok && u.status == feature.StatusComplete {
+		return closer.Close(ctx, t.key)
+	}
+	return nil
+}
+
+// statusFor maps an event to the feature status it reports, and the note
+// posted for it. Only milestones a reader of the issue cares about are
+// reported. Orchestrator events carry no unit or the PRD ID; workflow
+// events carry the PRD ID.
+func statusFor(e events.Event, prdID string) (feature.FeatureStatus, string, bool) {
+	if e.Unit != "" && e.Unit != prdID {
+		return "", "", false
+	}
+
+	var status feature.FeatureStatus
+	switch e.Type {
+	case events.OrchStarted:
+		status = feature.StatusInProgress
+	case events.PRCreated, events.FeaturePROpened:
+		status = feature.StatusPROpen
+	case events.OrchFailed, events.FeatureFailed:
+		status = feature.StatusFailed
+	case events.FeatureCompleted:
+		status = feature.StatusComplete
+	default:
+		s, ok := strings.CutPrefix(string(e.Type), "workflow.")
+		if !ok || e.Unit == "" {
+			return "", "", false
+		}
+		status = feature.FeatureStatus(s)
+	}
+
+	switch status {
+	case feature.StatusGeneratingSpecs:
+		return status, "generating specs for this feature.", true
+	case feature.StatusReviewBlocked:
+		return status, "spec review is blocked and needs a human; run `choo feature resume " + prdID + "`.", true
+	case feature.StatusInProgress:
+		return status, "implementation started.", true
+	case feature.StatusPROpen:
+		if url := payloadString(e, "url"); url != "" {
+			return status, "pull request opened: " + url, true
+		}
+		return status, "pull request opened.", true
+	case feature.StatusComplete:
+		return status, "feature complete.", true
+	case feature.StatusFailed:
+		if e.Error != "" {
+			return status, "feature failed: " + e.Error, true
+		}
+		return status, "feature failed.", true
+	}
+	return "", "", false
+}
+
+// payloadString reads a string field from a map payload
+func payloadString(e events.Event, key string) string {
+	switch p := e.Payload.(type) {
+	case map[string]any:
+		s, _ := p[key].(string)
+		return s
+	case map[string]string:
+		return p[key]
+	}
+	return ""
+}
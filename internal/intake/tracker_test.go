@@ -0,0 +1,46 @@
+package intake
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/feature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracker_ReportsMilestones(t *testing.T) {
+	src := newFakeSource()
+	prd := &feature.PRD{ID: "dark-mode", Source: &feature.PRDSource{Type: "github", Key: "42"}}
+	tracker := NewTracker(src, prd)
+	handle := tracker.Handler()
+
+	handle(events.NewEvent(events.EventType("workflow.generating_specs"), "dark-mode"))
+	handle(events.NewEvent(events.EventType("workflow.reviewing_specs"), "dark-mode")) // not a milestone
+	handle(events.NewEvent(events.OrchStarted, ""))
+	handle(events.NewEvent(events.UnitStarted, "unit-a")) // another unit
+	handle(events.NewEvent(events.PRCreated, "").WithPayload(map[string]any{"url": "https://github.com/o/r/pull/9"}))
+	handle(events.NewEvent(events.PRCreated, "dark-mode")) // same status again
+	handle(events.NewEvent(events.FeatureCompleted, "dark-mode"))
+	tracker.Close()
+
+	assert.Equal(t, []string{
+		"choo: generating specs for this feature.",
+		"choo: implementation started.",
+		"choo: pull request opened: https://github.com/o/r/pull/9",
+		"choo: feature complete.",
+	}, src.comments["42"])
+	assert.Equal(t, []string{"42"}, src.closed)
+}
+
+func TestTracker_ReportsFailure(t *testing.T) {
+	src := newFakeSource()
+	prd := &feature.PRD{ID: "dark-mode", Source: &feature.PRDSource{Type: "github", Key: "42"}}
+	tracker := NewTracker(src, prd)
+
+	tracker.Handler()(events.NewEvent(events.OrchFailed, "").WithError(fmt.Errorf("execution blocked")))
+	tracker.Close()
+
+	assert.Equal(t, []string{"choo: feature failed: execution blocked"}, src.comments["42"])
+	assert.Empty(t, src.closed)
+}
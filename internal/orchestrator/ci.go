@@ -0,0 +1,211 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/escalate"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/forge"
+	"github.com/RevCBH/choo/internal/git"
+	"github.com/RevCBH/choo/internal/provider"
+	"github.com/RevCBH/choo/internal/worker"
+)
+
+// ciFixWorktree is the worktree directory used for CI fix cycles
+const ciFixWorktree = "ci-fix"
+
+// fixCI waits for checks on the feature PR and, while they fail, runs fix
+// cycles on the feature branch until they pass or ci.max_attempts is
+// spent. Exhausting the attempts or timing out is escalated, not an error;
+// errors mean a fix could not be attempted at all.
+func (o *Orchestrator) fixCI(ctx context.Context, prURL string) error {
+	if !o.cfg.CI.Fix || o.forge == nil || prURL == "" {
+		return nil
+	}
+
+	timeout, err := o.cfg.CI.TimeoutDuration()
+	if err != nil || timeout <= 0 {
+		timeout, _ = time.ParseDuration(config.DefaultCITimeout)
+	}
+	interval, err := o.cfg.CI.PollIntervalDuration()
+	if err != nil || interval <= 0 {
+		interval, _ = time.ParseDuration(config.DefaultCIPollInterval)
+	}
+	maxAttempts := o.cfg.CI.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = config.DefaultCIFixAttempts
+	}
+
+	sha, err := o.runGit(ctx, o.cfg.RepoRoot, "rev-parse", o.cfg.FeatureBranch)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		waitCtx, cancel := context.WithTimeout(ctx, timeout)
+		status, err := o.forge.WaitForChecks(waitCtx, sha, interval)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, context.DeadlineExceeded) {
+				o.escalateCI(ctx, prURL, fmt.Sprintf("Checks on %s did not finish within %s", o.cfg.FeatureBranch, timeout))
+				return nil
+			}
+			return fmt.Errorf("failed to wait for checks: %w", err)
+		}
+
+		if status == forge.CheckSuccess {
+			o.emitCI(events.CIChecksPassed, map[string]any{"attempts": attempt})
+			if attempt > 0 {
+				o.updateFeaturePR(ctx)
+			}
+			return nil
+		}
+
+		var failures []forge.FailedCheck
+		if reporter, ok := o.forge.(forge.CheckReporter); ok {
+			failures, err = reporter.GetFailedChecks(ctx, sha)
+			if err != nil {
+				// The fix cycle can still read the failures from CI itself
+				fmt.Fprintf(os.Stderr, "Warning: failed to get failed checks: %v\n", err)
+			}
+		}
+		var names []string
+		for _, f := range failures {
+			names = append(names, f.Name)
+		}
+		o.emitCI(events.CIChecksFailed, map[string]any{"attempt": attempt + 1, "checks": names})
+
+		if attempt >= maxAttempts {
+			o.emitCI(events.CIFixExhausted, map[string]any{"attempts": attempt})
+			o.updateFeaturePR(ctx)
+			o.escalateCI(ctx, prURL, fmt.Sprintf("Checks still failing after %d fix attempts: %s", attempt, strings.Join(names, ", ")))
+			return nil
+		}
+
+		newSHA, err := o.runCIFix(ctx, prURL, sha, failures)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// A failed cycle uses up an attempt; CI is unchanged so the
+			// next wait returns the same failure immediately
+			fmt.Fprintf(os.Stderr, "Warning: CI fix attempt %d failed: %v\n", attempt+1, err)
+			continue
+		}
+		sha = newSHA
+		o.emitCI(events.CIFixPushed, map[string]any{"attempt": attempt + 1, "sha": sha})
+		o.updateFeaturePR(ctx)
+	}
+}
+
+// runCIFix runs one fix cycle in a detached worktree at sha, pushes the
+// resulting commits to the feature branch and returns the new head
+func (o *Orchestrator) runCIFix(ctx context.Context, prURL, sha string, failures []forge.FailedCheck) (string, error) {
+	base := o.cfg.WorktreeBase
+	if base == "" {
+		base = filepath.Join(o.cfg.RepoRoot, ".ralph", "worktrees")
+	}
+	path := filepath.Join(base, ciFixWorktree)
+
+	// Clear a worktree left behind by an interrupted cycle
+	_, _ = o.runGit(ctx, o.cfg.RepoRoot, "worktree", "remove", "--force", path)
+	_ = os.RemoveAll(path)
+	if _, err := o.runGit(ctx, o.cfg.RepoRoot, "worktree", "add", "--detach", path, sha); err != nil {
+		return "", err
+	}
+	defer func() {
+		_, _ = o.runGit(context.Background(), o.cfg.RepoRoot, "worktree", "remove", "--force", path)
+		_ = os.RemoveAll(path)
+	}()
+
+	prov, err := o.ciFixProvider()
+	if err != nil {
+		return "", err
+	}
+	var stdout, stderr io.Writer = os.Stdout, os.Stderr
+	if o.cfg.SuppressOutput {
+		stdout, stderr = io.Discard, io.Discard
+	}
+	prompt := worker.BuildCIFixPrompt(prURL, o.cfg.FeatureBranch, failures)
+	if err := prov.Invoke(ctx, prompt, path, stdout, stderr); err != nil {
+		return "", fmt.Errorf("fix cycle failed: %w", err)
+	}
+
+	head, err := o.runGit(ctx, path, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if head == sha {
+		return "", fmt.Errorf("fix cycle made no commit")
+	}
+	if _, err := o.runGit(ctx, path, "push", "origin", "HEAD:refs/heads/"+o.cfg.FeatureBranch); err != nil {
+		return "", err
+	}
+
+	// Keep the local branch in step, fast-forwarding it if checked out
+	args := []string{"branch", "-f", o.cfg.FeatureBranch, head}
+	if current, err := git.GetCurrentBranch(ctx, o.cfg.RepoRoot); err == nil && current == o.cfg.FeatureBranch {
+		args = []string{"merge", "--ff-only", head}
+	}
+	if _, err := o.runGit(ctx, o.cfg.RepoRoot, args...); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update local %s: %v\n", o.cfg.FeatureBranch, err)
+	}
+	return head, nil
+}
+
+// ciFixProvider returns the provider used for fix cycles. Like conflict
+// resolution, CI fixes always run on Claude.
+func (o *Orchestrator) ciFixProvider() (provider.Provider, error) {
+	if o.ciFixer != nil {
+		return o.ciFixer, nil
+	}
+	return provider.FromConfig(provider.Config{
+		Type:    provider.ProviderClaude,
+		Command: o.cfg.ClaudeCommand,
+	})
+}
+
+// emitCI emits a CI event for the feature branch
+func (o *Orchestrator) emitCI(t events.EventType, payload map[string]any) {
+	if o.bus == nil {
+		return
+	}
+	payload["branch"] = o.cfg.FeatureBranch
+	o.bus.Emit(events.NewEvent(t, "").WithPayload(payload))
+}
+
+// escalateCI hands a CI failure the fix cycles could not resolve to a human
+func (o *Orchestrator) escalateCI(ctx context.Context, prURL, message string) {
+	if o.escalator == nil {
+		return
+	}
+	_ = o.escalator.Escalate(ctx, escalate.Escalation{
+		Severity: escalate.SeverityBlocking,
+		Title:    fmt.Sprintf("CI failing on %s", o.cfg.FeatureBranch),
+		Message:  message,
+		Context:  map[string]string{"pr": prURL, "branch": o.cfg.FeatureBranch},
+	})
+}
+
+// runGit runs a git command in dir and returns its trimmed output
+func (o *Orchestrator) runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,185 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/escalate"
+	"github.com/RevCBH/choo/internal/forge"
+	"github.com/RevCBH/choo/internal/forge/fake"
+	"github.com/RevCBH/choo/internal/github"
+	"github.com/RevCBH/choo/internal/provider"
+	"github.com/RevCBH/choo/internal/testutil"
+)
+
+// scriptedCI reports one scripted conclusion per pushed commit, as a CI
+// system watching the feature branch would
+type scriptedCI struct {
+	*github.PRClient
+	t           *testing.T
+	baseURL     string
+	conclusions []string
+	waits       int
+}
+
+func (c *scriptedCI) WaitForChecks(ctx context.Context, ref string, pollInterval time.Duration) (forge.CheckStatus, error) {
+	c.t.Helper()
+	data, _ := json.Marshal(map[string]any{
+		"name":       "test",
+		"head_sha":   ref,
+		"conclusion": c.conclusions[c.waits],
+		"output":     map[string]any{"title": "go test", "summary": "TestX failed"},
+	})
+	c.waits++
+	req, _ := http.NewRequest("POST", c.baseURL+"/repos/local/repo/check-runs", bytes.NewReader(data))
+	req.Header.Set("Authorization", "Bearer ci")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatalf("post check run: %v", err)
+	}
+	resp.Body.Close()
+	return c.PRClient.WaitForChecks(ctx, ref, pollInterval)
+}
+
+// fixingProvider commits a change in the fix worktree
+type fixingProvider struct {
+	t       *testing.T
+	prompts []string
+}
+
+func (p *fixingProvider) Invoke(ctx context.Context, prompt, workdir string, stdout, stderr io.Writer) error {
+	p.prompts = append(p.prompts, prompt)
+	name := filepath.Join(workdir, "fix.txt")
+	if err := os.WriteFile(name, []byte(strings.Repeat("x", len(p.prompts))), 0644); err != nil {
+		return err
+	}
+	testutil.Git(p.t, workdir, "add", "-A")
+	testutil.Git(p.t, workdir, "commit", "-m", "fix CI: make TestX pass")
+	return nil
+}
+
+func (p *fixingProvider) Name() provider.ProviderType {
+	return provider.ProviderClaude
+}
+
+// setupCIRepo creates a repository whose feature branch is pushed to a
+// bare origin served by the fake forge
+func setupCIRepo(t *testing.T, conclusions ...string) (*Orchestrator, *scriptedCI, string) {
+	t.Helper()
+	testutil.UnsetGitEnv()
+	tmp := t.TempDir()
+	bare := filepath.Join(tmp, "repo.git")
+	repo := filepath.Join(tmp, "repo")
+	testutil.Git(t, tmp, "init", "--bare", "-b", "main", bare)
+	testutil.Git(t, tmp, "init", "-b", "main", repo)
+	testutil.Git(t, repo, "remote", "add", "origin", bare)
+	testutil.Git(t, repo, "commit", "--allow-empty", "-m", "init")
+	testutil.Git(t, repo, "push", "origin", "main")
+	testutil.Git(t, repo, "checkout", "-b", "feature/test")
+	testutil.Git(t, repo, "commit", "--allow-empty", "-m", "feature work")
+	testutil.Git(t, repo, "push", "origin", "feature/test")
+
+	srv, err := fake.New(fake.Config{Owner: "local", Repo: "repo", BarePath: bare})
+	if err != nil {
+		t.Fatalf("fake.New() error = %v", err)
+	}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	client, err := github.NewPRClient(github.PRClientConfig{Owner: "local", Repo: "repo", BaseURL: ts.URL, Token: "choo"})
+	if err != nil {
+		t.Fatalf("NewPRClient() error = %v", err)
+	}
+	ci := &scriptedCI{PRClient: client, t: t, baseURL: ts.URL, conclusions: conclusions}
+
+	o := &Orchestrator{
+		cfg: Config{
+			RepoRoot:       repo,
+			WorktreeBase:   filepath.Join(tmp, "worktrees"),
+			FeatureBranch:  "feature/test",
+			TargetBranch:   "main",
+			FeatureMode:    true,
+			SuppressOutput: true,
+			CI:             config.CIConfig{Fix: true, MaxAttempts: 2, Timeout: "10s", PollInterval: "10ms"},
+		},
+		forge: ci,
+	}
+	return o, ci, bare
+}
+
+func TestFixCI_PushesFixUntilChecksPass(t *testing.T) {
+	o, ci, bare := setupCIRepo(t, "failure", "success")
+	fixer := &fixingProvider{t: t}
+	o.ciFixer = fixer
+
+	if err := o.fixCI(context.Background(), "http://fake/pull/1"); err != nil {
+		t.Fatalf("fixCI() error = %v", err)
+	}
+
+	if len(fixer.prompts) != 1 {
+		t.Fatalf("expected 1 fix cycle, got %d", len(fixer.prompts))
+	}
+	if !strings.Contains(fixer.prompts[0], "TestX failed") {
+		t.Errorf("prompt should include the check output, got:\n%s", fixer.prompts[0])
+	}
+	if ci.waits != 2 {
+		t.Errorf("expected checks to be awaited twice, got %d", ci.waits)
+	}
+	remote := testutil.Git(t, bare, "log", "-1", "--format=%s", "feature/test")
+	if remote != "fix CI: make TestX pass" {
+		t.Errorf("expected fix pushed to the feature branch, got %q", remote)
+	}
+	if local := testutil.Git(t, o.cfg.RepoRoot, "rev-parse", "feature/test"); local != testutil.Git(t, bare, "rev-parse", "feature/test") {
+		t.Errorf("expected local feature branch to follow the fix")
+	}
+	if _, err := os.Stat(filepath.Join(o.cfg.WorktreeBase, ciFixWorktree)); !os.IsNotExist(err) {
+		t.Errorf("expected fix worktree to be removed")
+	}
+}
+
+func TestFixCI_EscalatesWhenAttemptsExhausted(t *testing.T) {
+	o, ci, _ := setupCIRepo(t, "failure", "failure", "failure")
+	fixer := &fixingProvider{t: t}
+	o.ciFixer = fixer
+	var escalations []escalate.Escalation
+	o.escalator = &mockEscalator{escalateFn: func(ctx context.Context, e escalate.Escalation) error {
+		escalations = append(escalations, e)
+		return nil
+	}}
+
+	if err := o.fixCI(context.Background(), "http://fake/pull/1"); err != nil {
+		t.Fatalf("fixCI() error = %v", err)
+	}
+
+	if len(fixer.prompts) != 2 || ci.waits != 3 {
+		t.Errorf("expected 2 fix cycles and 3 waits, got %d and %d", len(fixer.prompts), ci.waits)
+	}
+	if len(escalations) != 1 || escalations[0].Severity != escalate.SeverityBlocking {
+		t.Fatalf("expected one blocking escalation, got %+v", escalations)
+	}
+	if escalations[0].Context["pr"] != "http://fake/pull/1" {
+		t.Errorf("expected escalation to link the PR, got %v", escalations[0].Context)
+	}
+}
+
+func TestFixCI_DisabledDoesNothing(t *testing.T) {
+	o, ci, _ := setupCIRepo(t)
+	o.cfg.CI.Fix = false
+
+	if err := o.fixCI(context.Background(), "http://fake/pull/1"); err != nil {
+		t.Fatalf("fixCI() error = %v", err)
+	}
+	if ci.waits != 0 {
+		t.Errorf("expected no check waits, got %d", ci.waits)
+	}
+}
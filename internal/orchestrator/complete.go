@@ -31,10 +31,15 @@ func (o *Orchestrator) Complete(ctx context.Context) error {
 		return nil
 	}
 
-	if _, err := o.createFeaturePR(ctx); err != nil {
+	prURL, err := o.createFeaturePR(ctx)
+	if err != nil {
 		return fmt.Errorf("PR creation failed: %w", err)
 	}
 
+	if err := o.fixCI(ctx, prURL); err != nil {
+		return fmt.Errorf("CI remediation failed: %w", err)
+	}
+
 	return nil
 }
 
@@ -107,33 +112,6 @@ func (o *Orchestrator) commitArchive(ctx context.Context) error {
 	return nil
 }
 
-// buildPRBody constructs the PR description.
-func (o *Orchestrator) buildPRBody() string {
-	var sb strings.Builder
-
-	sb.WriteString("## Summary\n\n")
-	sb.WriteString(o.cfg.FeatureDescription)
-	sb.WriteString("\n\n")
-
-	sb.WriteString("## Units Implemented\n\n")
-	units := o.completedUnits
-	if len(units) == 0 {
-		for _, unit := range o.units {
-			units = append(units, unit.ID)
-		}
-	}
-	for _, unit := range units {
-		sb.WriteString(fmt.Sprintf("- [x] %s\n", unit))
-	}
-	sb.WriteString("\n")
-
-	sb.WriteString("## Automated Implementation\n\n")
-	sb.WriteString("This PR was created by the choo orchestrator.\n")
-	sb.WriteString("All unit branches have been merged and specs archived.\n")
-
-	return sb.String()
-}
-
 // isNoChangesError checks if an error is due to no changes to commit.
 func isNoChangesError(err error) bool {
 	if exitErr, ok := err.(*exec.ExitError); ok {
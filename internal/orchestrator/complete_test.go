@@ -2,12 +2,17 @@ package orchestrator
 
 import (
 	"context"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/RevCBH/choo/internal/forge/fake"
+	"github.com/RevCBH/choo/internal/github"
+	"github.com/RevCBH/choo/internal/testutil"
 )
 
 func TestComplete_RunsArchive(t *testing.T) {
@@ -119,6 +124,64 @@ func TestComplete_CreatesPR(t *testing.T) {
 	}
 }
 
+func TestComplete_CreatesPRThroughForgeAPI(t *testing.T) {
+	testutil.UnsetGitEnv()
+	repoDir, _, tasksDir := setupSpecsDirs(t)
+	bare := filepath.Join(t.TempDir(), "repo.git")
+
+	for _, args := range [][]string{
+		{"init", "--bare", "-b", "main", bare},
+		{"init", "-b", "main", repoDir},
+		{"-C", repoDir, "remote", "add", "origin", bare},
+		{"-C", repoDir, "commit", "--allow-empty", "-m", "init"},
+		{"-C", repoDir, "push", "origin", "main"},
+		{"-C", repoDir, "checkout", "-b", "feature/test"},
+		{"-C", repoDir, "commit", "--allow-empty", "-m", "feature work"},
+	} {
+		cmd := exec.Command("git", append([]string{"-c", "user.email=test@example.com", "-c", "user.name=Test"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	srv, err := fake.New(fake.Config{Owner: "local", Repo: "repo", BarePath: bare})
+	if err != nil {
+		t.Fatalf("fake.New() error = %v", err)
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	client, err := github.NewPRClient(github.PRClientConfig{Owner: "local", Repo: "repo", BaseURL: ts.URL, Token: "choo"})
+	if err != nil {
+		t.Fatalf("NewPRClient() error = %v", err)
+	}
+
+	o := &Orchestrator{
+		cfg: Config{
+			TasksDir:      tasksDir,
+			RepoRoot:      repoDir,
+			FeatureBranch: "feature/test",
+			TargetBranch:  "main",
+			FeatureTitle:  "Test Feature",
+			FeatureMode:   true,
+		},
+		forge:          client,
+		completedUnits: []string{"unit-a"},
+	}
+
+	if err := o.Complete(context.Background()); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	pulls := srv.Pulls()
+	if len(pulls) != 1 {
+		t.Fatalf("expected 1 PR, got %d", len(pulls))
+	}
+	if pulls[0].Head != "feature/test" || pulls[0].Base != "main" || pulls[0].Title != "feat: Test Feature" {
+		t.Errorf("unexpected PR: %+v", pulls[0])
+	}
+}
+
 func TestComplete_NoPRSkipsCreation(t *testing.T) {
 	repoDir, _, tasksDir := setupSpecsDirs(t)
 
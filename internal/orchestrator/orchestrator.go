@@ -7,16 +7,19 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/conflict"
 	"github.com/RevCBH/choo/internal/discovery"
 	"github.com/RevCBH/choo/internal/escalate"
 	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/forge"
 	"github.com/RevCBH/choo/internal/git"
-	"github.com/RevCBH/choo/internal/github"
 	"github.com/RevCBH/choo/internal/provider"
 	"github.com/RevCBH/choo/internal/scheduler"
+	"github.com/RevCBH/choo/internal/stack"
 	"github.com/RevCBH/choo/internal/worker"
 )
 
@@ -28,13 +31,28 @@ type Orchestrator struct {
 	scheduler *scheduler.Scheduler
 	pool      *worker.Pool
 	git       *git.WorktreeManager
-	github    *github.PRClient
+	forge     forge.Forge
 
 	// Runtime state
 	units   []*discovery.Unit
 	unitMap map[string]*discovery.Unit // unitID -> Unit for quick lookup
 	// completedUnits tracks unit IDs for PR description
 	completedUnits []string
+	// report records the run for the feature PR description
+	report *runReport
+	// featurePR is the feature PR's number once opened
+	featurePR int
+	// paused holds back dispatching new units
+	paused atomic.Bool
+
+	// Stacked PR mode: per-unit PRs added as units merge
+	stack      *stack.Stack
+	stackNodes map[string]stack.Node
+	stackCh    chan stack.UnitChange // nil once the stack is finished
+	stackMu    sync.Mutex
+
+	// ciFixer runs CI fix cycles; nil uses Claude via ClaudeCommand
+	ciFixer provider.Provider
 
 	// Synchronization for background goroutines
 	escalateMu     sync.Mutex
@@ -112,6 +130,27 @@ type Config struct {
 
 	// CodeReview contains code review configuration from .choo.yaml
 	CodeReview config.CodeReviewConfig
+
+	// BaselineChecks are validation commands run after each unit completes
+	// and on each speculative batch. Callers set them only when the merge
+	// queue is enabled (see config.Config.QueueBaselineChecks).
+	BaselineChecks []config.BaselineCheck
+
+	// MergeQueue configures batched merges to the target branch from .choo.yaml
+	MergeQueue config.MergeQueueConfig
+
+	// Conflicts configures conflict pre-detection between parallel units
+	Conflicts config.ConflictConfig
+
+	// PullRequests selects a single feature PR or stacked per-unit PRs
+	PullRequests config.PullRequestConfig
+
+	// CI configures fix cycles for failing checks on the feature PR
+	CI config.CIConfig
+
+	// Recovery is what an interrupted attempt at this run finished; nil
+	// for a fresh run
+	Recovery *Recovery
 }
 
 // Dependencies bundles external dependencies for injection
@@ -119,7 +158,7 @@ type Dependencies struct {
 	Bus       *events.Bus
 	Escalator escalate.Escalator
 	Git       *git.WorktreeManager
-	GitHub    *github.PRClient
+	Forge     forge.Forge
 }
 
 // Result represents the outcome of an orchestration run
@@ -135,6 +174,9 @@ type Result struct {
 // DefaultShutdownTimeout is the default grace period for shutdown
 const DefaultShutdownTimeout = 30 * time.Second
 
+// DefaultBaselineTimeout bounds a single run of all baseline checks
+const DefaultBaselineTimeout = 10 * time.Minute
+
 // MaxConcurrentEscalations limits concurrent escalation goroutines to prevent resource exhaustion
 const MaxConcurrentEscalations = 100
 
@@ -144,13 +186,18 @@ func New(cfg Config, deps Dependencies) *Orchestrator {
 		cfg.ShutdownTimeout = DefaultShutdownTimeout
 	}
 	escalateCtx, escalateCancel := context.WithCancel(context.Background())
+	report := newRunReport()
+	if deps.Bus != nil {
+		deps.Bus.Subscribe(report.record)
+	}
 	return &Orchestrator{
 		cfg:            cfg,
 		bus:            deps.Bus,
 		escalator:      deps.Escalator,
 		git:            deps.Git,
-		github:         deps.GitHub,
+		forge:          deps.Forge,
 		unitMap:        make(map[string]*discovery.Unit),
+		report:         report,
 		escalateCtx:    escalateCtx,
 		escalateCancel: escalateCancel,
 		escalateSem:    make(chan struct{}, MaxConcurrentEscalations),
@@ -301,19 +348,25 @@ func (o *Orchestrator) Run(ctx context.Context) (*Result, error) {
 		}
 	}
 
+	// Units and tasks an interrupted attempt finished are not run again
+	applyRecovery(units, o.cfg.Recovery)
+
 	// Store all discovered units before filtering (for PR creation)
 	allDiscoveredUnits := units
 
 	// Filter out already-complete units (they don't need to be re-executed)
 	units = filterOutCompleteUnits(units)
 	if len(units) == 0 {
-		// All units already complete - create PR if in feature mode
-		if o.cfg.FeatureMode && !o.cfg.NoPR {
-			// Collect unit IDs from the original list
+		// All units already complete - create PR if in feature mode.
+		// Stacked PRs are opened as units merge, so there is nothing left to open.
+		if o.cfg.FeatureMode && !o.cfg.NoPR && !o.stacked() {
+			// Collect unit IDs from the original list; their task specs
+			// describe them in the PR
 			var unitIDs []string
 			for _, u := range allDiscoveredUnits {
 				unitIDs = append(unitIDs, u.ID)
 			}
+			o.unitMap = buildUnitMap(allDiscoveredUnits)
 			prURL, err := o.createFeaturePR(ctx, unitIDs...)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create PR: %w", err)
@@ -321,6 +374,9 @@ func (o *Orchestrator) Run(ctx context.Context) (*Result, error) {
 			if prURL != "" {
 				fmt.Printf("\nPR created: %s\n", prURL)
 			}
+			if err := o.fixCI(ctx, prURL); err != nil {
+				return nil, fmt.Errorf("CI remediation failed: %w", err)
+			}
 		}
 		return &Result{
 			TotalUnits:     len(allDiscoveredUnits),
@@ -350,6 +406,20 @@ func (o *Orchestrator) Run(ctx context.Context) (*Result, error) {
 		"graph":       buildGraphData(units, schedule.Levels),
 	}))
 
+	// 2.5. Flag units likely to conflict before they run in parallel
+	stopConflictMonitor := o.detectConflicts(ctx, units)
+	defer stopConflictMonitor()
+
+	// 2.6. In stacked mode, open a PR per unit as each one merges
+	finishStack := func() {}
+	if o.stacked() {
+		finishStack, err = o.startStack(ctx, units)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start PR stack: %w", err)
+		}
+		defer finishStack()
+	}
+
 	// 3. Initialize worker pool
 	workerCfg := worker.WorkerConfig{
 		RepoRoot:            o.cfg.RepoRoot,
@@ -360,6 +430,9 @@ func (o *Orchestrator) Run(ctx context.Context) (*Result, error) {
 		MaxClaudeRetries:    3,
 		SuppressOutput:      o.cfg.SuppressOutput,
 		ClaudeCommand:       o.cfg.ClaudeCommand,
+		BaselineChecks:      convertBaselineChecks(o.cfg.BaselineChecks),
+		BaselineTimeout:     DefaultBaselineTimeout,
+		MergeQueue:          o.mergeQueueConfig(),
 	}
 
 	// Resolve reviewer for code review (may be nil if disabled)
@@ -371,7 +444,7 @@ func (o *Orchestrator) Run(ctx context.Context) (*Result, error) {
 	workerDeps := worker.WorkerDeps{
 		Events:   o.bus,
 		Git:      o.git,
-		GitHub:   o.github,
+		Forge:    o.forge,
 		Reviewer: reviewer, // Pass reviewer to pool
 		// Note: Provider is not set here - factory handles per-unit resolution
 		// Note: MergeMu is managed by the Pool internally, not passed here
@@ -403,6 +476,12 @@ func (o *Orchestrator) Run(ctx context.Context) (*Result, error) {
 			// Continue - not shutting down
 		}
 
+		// Units running when paused carry on; no new ones start
+		if o.paused.Load() {
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
 		// Attempt to dispatch next ready unit
 		result := o.scheduler.Dispatch()
 
@@ -416,8 +495,11 @@ func (o *Orchestrator) Run(ctx context.Context) (*Result, error) {
 
 		case scheduler.ReasonAllComplete:
 			// All units finished successfully
-			// Create PR if in feature mode
-			if o.cfg.FeatureMode && !o.cfg.NoPR {
+			// Wait for stacked PRs, or create the feature PR in feature mode
+			if o.stacked() {
+				finishStack()
+				o.printStack()
+			} else if o.cfg.FeatureMode && !o.cfg.NoPR {
 				prURL, err := o.createFeaturePR(ctx)
 				if err != nil {
 					o.bus.Emit(events.NewEvent(events.OrchFailed, "").
@@ -427,6 +509,11 @@ func (o *Orchestrator) Run(ctx context.Context) (*Result, error) {
 				if prURL != "" {
 					fmt.Printf("\nPR created: %s\n", prURL)
 				}
+				if err := o.fixCI(ctx, prURL); err != nil {
+					err = fmt.Errorf("CI remediation failed: %w", err)
+					o.bus.Emit(events.NewEvent(events.OrchFailed, "").WithError(err))
+					return o.buildResult(startTime, err), err
+				}
 			}
 
 			o.bus.Emit(events.NewEvent(events.OrchCompleted, ""))
@@ -438,13 +525,26 @@ func (o *Orchestrator) Run(ctx context.Context) (*Result, error) {
 			o.bus.Emit(events.NewEvent(events.OrchFailed, "").WithError(err))
 			return o.buildResult(startTime, err), err
 
-		case scheduler.ReasonAtCapacity, scheduler.ReasonNoReady:
+		case scheduler.ReasonAtCapacity, scheduler.ReasonNoReady, scheduler.ReasonSerialized:
 			// Wait for workers to complete or dependencies to resolve
 			time.Sleep(100 * time.Millisecond)
 		}
 	}
 }
 
+// SetPaused pauses or resumes dispatching units. Units already running
+// carry on while paused. Safe to call from any goroutine.
+func (o *Orchestrator) SetPaused(paused bool) {
+	if !o.paused.CompareAndSwap(!paused, paused) {
+		return
+	}
+	if paused {
+		o.bus.Emit(events.NewEvent(events.OrchPaused, ""))
+	} else {
+		o.bus.Emit(events.NewEvent(events.OrchResumed, ""))
+	}
+}
+
 // buildResult constructs the Result from current scheduler state
 func (o *Orchestrator) buildResult(startTime time.Time, err error) *Result {
 	result := &Result{
@@ -479,6 +579,9 @@ func (o *Orchestrator) handleEvent(e events.Event) {
 	case events.UnitCompleted:
 		o.scheduler.Complete(e.Unit)
 
+	case events.UnitMerged:
+		o.enqueueStack(e)
+
 	case events.UnitFailed:
 		var err error
 		if e.Error != "" {
@@ -790,6 +893,75 @@ func (o *Orchestrator) resolveReviewer() (provider.Reviewer, error) {
 	}
 }
 
+// detectConflicts analyzes task specs for overlapping files and symbols
+// between units that may run in parallel, emitting a unit.conflict_risk
+// event per pair and optionally serializing high-risk pairs. It then starts
+// a monitor comparing running units' diffs. Returns a func that stops it.
+func (o *Orchestrator) detectConflicts(ctx context.Context, units []*discovery.Unit) func() {
+	if !o.cfg.Conflicts.Detect {
+		return func() {}
+	}
+
+	for _, risk := range conflict.Analyze(units) {
+		conflict.Emit(o.bus, risk)
+		if o.cfg.Conflicts.Serialize && risk.Level() == conflict.LevelHigh {
+			o.scheduler.Serialize(risk.UnitA, risk.UnitB)
+		}
+	}
+
+	interval, err := o.cfg.Conflicts.DiffIntervalDuration()
+	if err != nil || interval <= 0 {
+		return func() {}
+	}
+
+	monitorCtx, cancel := context.WithCancel(ctx)
+	monitor := conflict.NewMonitor(o.bus, o.cfg.WorktreeBase, o.cfg.TargetBranch, o.activeUnits)
+	go monitor.Run(monitorCtx, interval)
+	return cancel
+}
+
+// activeUnits returns the IDs of units currently consuming parallelism slots
+func (o *Orchestrator) activeUnits() []string {
+	var ids []string
+	for id, state := range o.scheduler.GetAllStates() {
+		if state.Status.IsActive() {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// mergeQueueConfig converts the merge queue settings for workers.
+// An unparseable batch window falls back to the default.
+func (o *Orchestrator) mergeQueueConfig() worker.MergeQueueConfig {
+	cfg := o.cfg.MergeQueue
+	window, err := cfg.BatchWindowDuration()
+	if err != nil {
+		window, _ = time.ParseDuration(config.DefaultMergeQueueWindow)
+	}
+	return worker.MergeQueueConfig{
+		Enabled:     cfg.Enabled,
+		BatchSize:   cfg.BatchSize,
+		BatchWindow: window,
+	}
+}
+
+// convertBaselineChecks converts configured baseline checks for workers
+func convertBaselineChecks(checks []config.BaselineCheck) []worker.BaselineCheck {
+	if len(checks) == 0 {
+		return nil
+	}
+	converted := make([]worker.BaselineCheck, len(checks))
+	for i, c := range checks {
+		converted[i] = worker.BaselineCheck{
+			Name:    c.Name,
+			Command: c.Command,
+			Pattern: c.Pattern,
+		}
+	}
+	return converted
+}
+
 // dryRun prints the execution plan without running workers
 func (o *Orchestrator) dryRun(units []*discovery.Unit) (*Result, error) {
 	// Build schedule without executing
@@ -859,6 +1031,12 @@ func (o *Orchestrator) createFeaturePR(ctx context.Context, completedUnitIDs ...
 		return "", nil // PR creation disabled
 	}
 
+	// An interrupted attempt already opened it
+	if r := o.cfg.Recovery; r != nil && r.FeaturePRURL != "" {
+		o.featurePR = r.FeaturePR
+		return r.FeaturePRURL, nil
+	}
+
 	// Push the feature branch first
 	if err := o.pushFeatureBranch(ctx); err != nil {
 		return "", fmt.Errorf("failed to push feature branch: %w", err)
@@ -872,9 +1050,23 @@ func (o *Orchestrator) createFeaturePR(ctx context.Context, completedUnitIDs ...
 		}
 	}
 
-	title := fmt.Sprintf("feat: %s", o.cfg.FeatureTitle)
+	if o.bus != nil {
+		o.bus.Wait() // let the report record the run's last events
+	}
+	title := o.featurePRTitle()
 	body := o.buildPRBody()
 
+	// gh only talks to github.com; other forges open the PR through their API
+	if o.forge != nil && !forge.UsesGHCLI(o.forge) {
+		pr, err := o.forge.OpenPR(ctx, o.cfg.FeatureBranch, o.cfg.TargetBranch, title, body)
+		if err != nil {
+			return "", fmt.Errorf("failed to open PR: %w", err)
+		}
+		o.featurePR = pr.Number
+		o.emitPRCreated(pr.URL)
+		return pr.URL, nil
+	}
+
 	prCmd := exec.CommandContext(ctx, "gh", "pr", "create",
 		"--base", o.cfg.TargetBranch,
 		"--head", o.cfg.FeatureBranch,
@@ -896,18 +1088,28 @@ func (o *Orchestrator) createFeaturePR(ctx context.Context, completedUnitIDs ...
 		return "", fmt.Errorf("could not find PR URL in gh output")
 	}
 
-	if o.bus != nil {
-		o.bus.Emit(events.NewEvent(events.PRCreated, "").
-			WithPayload(map[string]any{
-				"url":    prURL,
-				"branch": o.cfg.FeatureBranch,
-				"target": o.cfg.TargetBranch,
-			}))
-	}
-
+	o.featurePR = prNumberFromURL(prURL)
+	o.emitPRCreated(prURL)
 	return prURL, nil
 }
 
+// emitPRCreated announces the feature PR
+func (o *Orchestrator) emitPRCreated(prURL string) {
+	if o.bus == nil {
+		return
+	}
+	e := events.NewEvent(events.PRCreated, "").
+		WithPayload(map[string]any{
+			"url":    prURL,
+			"branch": o.cfg.FeatureBranch,
+			"target": o.cfg.TargetBranch,
+		})
+	if o.featurePR > 0 {
+		e = e.WithPR(o.featurePR)
+	}
+	o.bus.Emit(e)
+}
+
 // pushFeatureBranch pushes the feature branch to remote
 func (o *Orchestrator) pushFeatureBranch(ctx context.Context) error {
 	return git.PushBranch(ctx, o.cfg.RepoRoot, o.cfg.FeatureBranch)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -387,6 +388,30 @@ func TestOrchestrator_DefaultShutdownTimeout(t *testing.T) {
 	}
 }
 
+func TestOrchestrator_SetPaused(t *testing.T) {
+	bus := events.NewBus(100)
+	collector := events.NewEventCollector(bus)
+
+	orch := New(Config{Parallelism: 1}, Dependencies{Bus: bus})
+
+	// Only changes of state are reported
+	orch.SetPaused(false)
+	orch.SetPaused(true)
+	orch.SetPaused(true)
+	orch.SetPaused(false)
+	bus.Wait()
+	bus.Close()
+
+	var got []events.EventType
+	for _, e := range collector.Get() {
+		got = append(got, e.Type)
+	}
+	want := []events.EventType{events.OrchPaused, events.OrchResumed}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
 func TestOrchestrator_DryRun_Basic(t *testing.T) {
 	bus := events.NewBus(100)
 	defer bus.Close()
@@ -1336,3 +1361,82 @@ func TestResolveReviewer_UnknownProvider(t *testing.T) {
 		t.Errorf("unexpected error message: %v", err)
 	}
 }
+
+func TestMergeQueueConfig(t *testing.T) {
+	orch := &Orchestrator{
+		cfg: Config{
+			MergeQueue: config.MergeQueueConfig{
+				Enabled:     true,
+				BatchSize:   3,
+				BatchWindow: "5s",
+			},
+		},
+	}
+
+	cfg := orch.mergeQueueConfig()
+	if !cfg.Enabled || cfg.BatchSize != 3 || cfg.BatchWindow != 5*time.Second {
+		t.Errorf("unexpected merge queue config: %+v", cfg)
+	}
+
+	orch.cfg.MergeQueue.BatchWindow = "bogus"
+	if got := orch.mergeQueueConfig().BatchWindow; got != 30*time.Second {
+		t.Errorf("expected default batch window, got %v", got)
+	}
+}
+
+func TestConvertBaselineChecks(t *testing.T) {
+	if got := convertBaselineChecks(nil); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+
+	got := convertBaselineChecks([]config.BaselineCheck{
+		{Name: "test", Command: "go test ./...", Pattern: "*.go"},
+	})
+	want := []worker.BaselineCheck{{Name: "test", Command: "go test ./...", Pattern: "*.go"}}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("convertBaselineChecks() = %v, want %v", got, want)
+	}
+}
+
+func TestDetectConflicts_SerializesHighRiskPairs(t *testing.T) {
+	bus := events.NewBus(100)
+	defer bus.Close()
+	collector := events.NewEventCollector(bus)
+
+	units := []*discovery.Unit{
+		{ID: "a", Tasks: []*discovery.Task{{Number: 1, Content: "Edit internal/app/app.go"}}},
+		{ID: "b", Tasks: []*discovery.Task{{Number: 1, Content: "Also edit internal/app/app.go"}}},
+	}
+
+	orch := &Orchestrator{
+		cfg: Config{
+			Conflicts: config.ConflictConfig{Detect: true, Serialize: true, DiffInterval: "0"},
+		},
+		bus:       bus,
+		scheduler: scheduler.New(bus, 2),
+	}
+	if _, err := orch.scheduler.Schedule(units); err != nil {
+		t.Fatalf("Schedule failed: %v", err)
+	}
+
+	stop := orch.detectConflicts(context.Background(), units)
+	defer stop()
+
+	if result := orch.scheduler.Dispatch(); !result.Dispatched {
+		t.Fatalf("expected first unit to dispatch, got %q", result.Reason)
+	}
+	if result := orch.scheduler.Dispatch(); result.Reason != scheduler.ReasonSerialized {
+		t.Errorf("expected second unit to be serialized, got %+v", result)
+	}
+
+	bus.Wait()
+	found := false
+	for _, e := range collector.Get() {
+		if e.Type == events.UnitConflictRisk {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected unit.conflict_risk event")
+	}
+}
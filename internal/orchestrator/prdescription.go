@@ -0,0 +1,539 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/discovery"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/provider"
+)
+
+// maxListedFiles caps the files listed per unit in the PR description
+const maxListedFiles = 50
+
+// runReport records what happened to each unit during a run from the event
+// bus, so the feature PR description is built from run data rather than
+// written by an agent
+type runReport struct {
+	mu      sync.Mutex
+	started time.Time
+	last    time.Time
+	units   map[string]*unitRun
+
+	ciRounds  []ciRound
+	ciOutcome events.EventType // CIChecksPassed or CIFixExhausted once known
+}
+
+// unitRun is the recorded history of one unit
+type unitRun struct {
+	started  time.Time
+	finished time.Time
+	taskEnd  time.Time // when the previous task finished; tasks run one at a time
+
+	provider    string
+	invocations int
+	invokedAt   time.Time
+	agentTime   time.Duration
+	costUSD     float64
+	costed      int // invocations that reported their cost
+
+	tasks    map[int]*taskRun
+	baseline *baselineRun
+	review   reviewRun
+	files    []string
+}
+
+type taskRun struct {
+	completed          bool
+	duration           time.Duration
+	validationFailures int
+}
+
+type baselineRun struct {
+	passed      bool
+	checks      []string
+	fixAttempts int
+}
+
+type reviewRun struct {
+	ran         bool
+	summary     string
+	issues      []provider.ReviewIssue
+	fixAttempts int
+	fixed       bool
+	err         string
+}
+
+// ciRound is one failed CI run on the feature PR and the fix pushed for it
+type ciRound struct {
+	attempt int
+	checks  []string
+	fixSHA  string
+}
+
+func newRunReport() *runReport {
+	return &runReport{units: make(map[string]*unitRun)}
+}
+
+// record is an events.Handler
+func (r *runReport) record(e events.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started.IsZero() {
+		r.started = e.Time
+	}
+	if e.Time.After(r.last) {
+		r.last = e.Time
+	}
+
+	switch e.Type {
+	case events.CIChecksFailed:
+		r.ciRounds = append(r.ciRounds, ciRound{
+			attempt: payloadInt(e, "attempt"),
+			checks:  payloadStrings(e, "checks"),
+		})
+		return
+	case events.CIFixPushed:
+		attempt := payloadInt(e, "attempt")
+		for i := range r.ciRounds {
+			if r.ciRounds[i].attempt == attempt {
+				r.ciRounds[i].fixSHA = payloadString(e, "sha")
+			}
+		}
+		return
+	case events.CIChecksPassed, events.CIFixExhausted:
+		r.ciOutcome = e.Type
+		return
+	}
+
+	if e.Unit == "" {
+		return
+	}
+	u := r.unit(e.Unit)
+
+	switch e.Type {
+	case events.UnitStarted:
+		u.started = e.Time
+		u.taskEnd = e.Time
+	case events.TaskClaudeInvoke:
+		u.invocations++
+		u.invokedAt = e.Time
+		if p := payloadString(e, "provider"); p != "" {
+			u.provider = p
+		}
+	case events.TaskClaudeDone:
+		if !u.invokedAt.IsZero() {
+			u.agentTime += e.Time.Sub(u.invokedAt)
+			u.invokedAt = time.Time{}
+		}
+		if cost, ok := payloadFloat(e, "cost_usd"); ok {
+			u.costUSD += cost
+			u.costed++
+		}
+	case events.TaskValidationFail:
+		if e.Task != nil {
+			u.task(*e.Task).validationFailures++
+		}
+	case events.TaskCompleted:
+		if e.Task != nil {
+			t := u.task(*e.Task)
+			t.completed = true
+			if !u.taskEnd.IsZero() {
+				t.duration = e.Time.Sub(u.taskEnd)
+			}
+		}
+		u.taskEnd = e.Time
+	case events.BaselinePassed, events.BaselineFailed:
+		u.baseline = &baselineRun{
+			passed:      e.Type == events.BaselinePassed,
+			checks:      payloadStrings(e, "checks"),
+			fixAttempts: payloadInt(e, "fix_attempts"),
+		}
+	case events.CodeReviewPassed:
+		u.review.ran = true
+		u.review.summary = payloadString(e, "summary")
+	case events.CodeReviewIssuesFound:
+		u.review.ran = true
+		if p, ok := e.Payload.(map[string]any); ok {
+			u.review.issues, _ = p["issues"].([]provider.ReviewIssue)
+		}
+	case events.CodeReviewFixAttempt:
+		u.review.fixAttempts = payloadInt(e, "iteration")
+	case events.CodeReviewFixApplied:
+		u.review.fixed = true
+	case events.CodeReviewFailed:
+		u.review.ran = true
+		u.review.err = payloadString(e, "error")
+	case events.UnitMerged:
+		u.files = payloadStrings(e, "files")
+	case events.UnitCompleted:
+		u.finished = e.Time
+	}
+}
+
+func (r *runReport) unit(id string) *unitRun {
+	u, ok := r.units[id]
+	if !ok {
+		u = &unitRun{tasks: make(map[int]*taskRun)}
+		r.units[id] = u
+	}
+	return u
+}
+
+func (u *unitRun) task(number int) *taskRun {
+	t, ok := u.tasks[number]
+	if !ok {
+		t = &taskRun{}
+		u.tasks[number] = t
+	}
+	return t
+}
+
+// buildPRBody renders the feature PR description: the PRD summary, each
+// unit's tasks with their backpressure results, baseline checks, code
+// review findings and changed files, CI fixes, and the run's duration and
+// agent usage. Units without recorded run data (e.g. completed in an
+// earlier run) are described from their task specs.
+func (o *Orchestrator) buildPRBody() string {
+	r := o.report
+	if r == nil {
+		r = newRunReport()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var sb strings.Builder
+
+	sb.WriteString("## Summary\n\n")
+	if o.cfg.FeatureDescription != "" {
+		sb.WriteString(strings.TrimSpace(o.cfg.FeatureDescription))
+		sb.WriteString("\n\n")
+	}
+
+	unitIDs := o.completedUnits
+	if len(unitIDs) == 0 {
+		for _, unit := range o.units {
+			unitIDs = append(unitIDs, unit.ID)
+		}
+	}
+
+	sb.WriteString("## Units Implemented\n\n")
+	taskCount := 0
+	invocations, costed := 0, 0
+	var agentTime time.Duration
+	var costUSD float64
+	for _, id := range unitIDs {
+		u := r.units[id]
+		if u == nil {
+			u = &unitRun{tasks: make(map[int]*taskRun)}
+		}
+		taskCount += writeUnit(&sb, id, o.unitMap[id], u)
+		invocations += u.invocations
+		agentTime += u.agentTime
+		costUSD += u.costUSD
+		costed += u.costed
+	}
+
+	if len(r.ciRounds) > 0 || r.ciOutcome != "" {
+		sb.WriteString("## CI\n\n")
+		for _, round := range r.ciRounds {
+			fmt.Fprintf(&sb, "- Run %d failed", round.attempt)
+			if len(round.checks) > 0 {
+				fmt.Fprintf(&sb, ": %s", codeList(round.checks))
+			}
+			if round.fixSHA != "" {
+				fmt.Fprintf(&sb, "; fixed in %s", shortSHA(round.fixSHA))
+			}
+			sb.WriteString("\n")
+		}
+		switch r.ciOutcome {
+		case events.CIChecksPassed:
+			if len(r.ciRounds) == 0 {
+				sb.WriteString("- Checks passed\n")
+			} else {
+				fmt.Fprintf(&sb, "- Checks passed %s\n", plural(len(r.ciRounds), "after %d fix", "after %d fixes"))
+			}
+		case events.CIFixExhausted:
+			sb.WriteString("- Checks still failing; escalated for a human to fix\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Run\n\n")
+	fmt.Fprintf(&sb, "- Units: %d, tasks: %d\n", len(unitIDs), taskCount)
+	if !r.started.IsZero() {
+		fmt.Fprintf(&sb, "- Duration: %s\n", formatDuration(r.last.Sub(r.started)))
+	}
+	if invocations > 0 {
+		fmt.Fprintf(&sb, "- Agent invocations: %d (%s of agent time, %s)\n",
+			invocations, formatDuration(agentTime), formatCost(costUSD, costed, invocations))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("---\n")
+	sb.WriteString("This PR was created by the choo orchestrator from the run's records. ")
+	sb.WriteString("All unit branches have been merged and specs archived.\n")
+
+	return sb.String()
+}
+
+// writeUnit writes one unit's section and returns its task count
+func writeUnit(sb *strings.Builder, id string, unit *discovery.Unit, u *unitRun) int {
+	fmt.Fprintf(sb, "### %s\n\n", id)
+
+	var facts []string
+	if !u.started.IsZero() && !u.finished.IsZero() {
+		facts = append(facts, "completed in "+formatDuration(u.finished.Sub(u.started)))
+	}
+	if u.invocations > 0 {
+		usage := plural(u.invocations, "%d agent invocation", "%d agent invocations")
+		cost := formatCost(u.costUSD, u.costed, u.invocations)
+		if u.provider != "" {
+			usage += " (" + u.provider + ", " + cost + ")"
+		} else {
+			usage += " (" + cost + ")"
+		}
+		facts = append(facts, usage)
+	}
+	if len(facts) > 0 {
+		s := strings.Join(facts, ", ")
+		sb.WriteString(strings.ToUpper(s[:1]) + s[1:] + "\n\n")
+	}
+
+	// Tasks come from the specs; unknown units still list what was recorded
+	var tasks []*discovery.Task
+	if unit != nil {
+		tasks = unit.Tasks
+	}
+	if len(tasks) == 0 {
+		for n := range u.tasks {
+			tasks = append(tasks, &discovery.Task{Number: n, Title: fmt.Sprintf("Task %d", n)})
+		}
+		sort.Slice(tasks, func(i, j int) bool { return tasks[i].Number < tasks[j].Number })
+	}
+	for _, task := range tasks {
+		if task == nil {
+			continue
+		}
+		t := u.tasks[task.Number]
+		done := task.Status == discovery.TaskStatusComplete || (t != nil && t.completed)
+		check := " "
+		if done {
+			check = "x"
+		}
+		fmt.Fprintf(sb, "- [%s] #%d %s", check, task.Number, task.Title)
+		if t != nil && t.completed {
+			result := "passed"
+			if task.Backpressure != "" {
+				result = fmt.Sprintf("`%s` passed", task.Backpressure)
+			}
+			if t.validationFailures > 0 {
+				result += fmt.Sprintf(" on attempt %d", t.validationFailures+1)
+			}
+			if t.duration > 0 {
+				result += " in " + formatDuration(t.duration)
+			}
+			sb.WriteString(" — " + result)
+		} else if done {
+			sb.WriteString(" — completed in an earlier run")
+		}
+		sb.WriteString("\n")
+	}
+	if len(tasks) > 0 {
+		sb.WriteString("\n")
+	}
+
+	if b := u.baseline; b != nil {
+		outcome := "passed"
+		if !b.passed {
+			outcome = "failed"
+		}
+		if b.fixAttempts > 0 {
+			outcome += plural(b.fixAttempts, " after %d fix attempt", " after %d fix attempts")
+		}
+		fmt.Fprintf(sb, "**Baseline checks** (%s): %s\n\n", codeList(b.checks), outcome)
+	}
+
+	if rv := u.review; rv.ran {
+		switch {
+		case rv.err != "":
+			fmt.Fprintf(sb, "**Code review:** did not run (%s)\n\n", rv.err)
+		case len(rv.issues) == 0:
+			sb.WriteString("**Code review:** no issues")
+			if rv.summary != "" {
+				sb.WriteString(" — " + rv.summary)
+			}
+			sb.WriteString("\n\n")
+		default:
+			resolution := "left for human review"
+			if rv.fixed {
+				resolution = plural(rv.fixAttempts, "fixed in %d iteration", "fixed in %d iterations")
+			} else if rv.fixAttempts > 0 {
+				resolution = plural(rv.fixAttempts, "not fixed after %d iteration", "not fixed after %d iterations") + "; left for human review"
+			}
+			fmt.Fprintf(sb, "**Code review:** %s, %s\n\n",
+				plural(len(rv.issues), "%d finding", "%d findings"), resolution)
+			for _, issue := range rv.issues {
+				loc := issue.File
+				if issue.Line > 0 {
+					loc = fmt.Sprintf("%s:%d", issue.File, issue.Line)
+				}
+				if loc != "" {
+					loc = "`" + loc + "` "
+				}
+				fmt.Fprintf(sb, "- %s(%s) %s\n", loc, issue.Severity, issue.Message)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(u.files) > 0 {
+		fmt.Fprintf(sb, "<details><summary>Files changed (%d)</summary>\n\n", len(u.files))
+		for i, f := range u.files {
+			if i == maxListedFiles {
+				fmt.Fprintf(sb, "- … and %d more\n", len(u.files)-maxListedFiles)
+				break
+			}
+			fmt.Fprintf(sb, "- `%s`\n", f)
+		}
+		sb.WriteString("\n</details>\n\n")
+	}
+
+	return len(tasks)
+}
+
+// updateFeaturePR rewrites the feature PR description once fixes have
+// landed on the feature branch. Failures are warnings: the PR itself is
+// unaffected.
+func (o *Orchestrator) updateFeaturePR(ctx context.Context) {
+	if o.forge == nil || o.featurePR == 0 {
+		return
+	}
+	if o.bus != nil {
+		o.bus.Wait() // let the report record the events that led here
+	}
+	if err := o.forge.UpdatePR(ctx, o.featurePR, o.featurePRTitle(), o.buildPRBody()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update PR description: %v\n", err)
+	}
+}
+
+// featurePRTitle is the title of the feature PR
+func (o *Orchestrator) featurePRTitle() string {
+	return fmt.Sprintf("feat: %s", o.cfg.FeatureTitle)
+}
+
+// prNumberPattern matches the number at the end of a PR or MR URL
+var prNumberPattern = regexp.MustCompile(`/(?:pull|pulls|merge_requests)/(\d+)/?$`)
+
+// prNumberFromURL returns the PR number in a PR URL, or 0
+func prNumberFromURL(url string) int {
+	m := prNumberPattern.FindStringSubmatch(url)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return "<1s"
+	}
+	return d.Round(time.Second).String()
+}
+
+// formatCost formats the cost of invocations, of which costed reported
+// one; providers that report none have no known cost
+func formatCost(cost float64, costed, invocations int) string {
+	switch {
+	case costed == 0:
+		return "cost n/a"
+	case costed < invocations:
+		return fmt.Sprintf("at least $%.2f", cost)
+	}
+	return fmt.Sprintf("$%.2f", cost)
+}
+
+func codeList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = "`" + item + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// plural formats n with the singular or plural format
+func plural(n int, singular, pluralFormat string) string {
+	if n == 1 {
+		return fmt.Sprintf(singular, n)
+	}
+	return fmt.Sprintf(pluralFormat, n)
+}
+
+func payloadString(e events.Event, key string) string {
+	if p, ok := e.Payload.(map[string]any); ok {
+		s, _ := p[key].(string)
+		return s
+	}
+	return ""
+}
+
+func payloadInt(e events.Event, key string) int {
+	if p, ok := e.Payload.(map[string]any); ok {
+		switch v := p[key].(type) {
+		case int:
+			return v
+		case float64:
+			return int(v)
+		}
+	}
+	return 0
+}
+
+func payloadFloat(e events.Event, key string) (float64, bool) {
+	if p, ok := e.Payload.(map[string]any); ok {
+		switch v := p[key].(type) {
+		case float64:
+			return v, true
+		case int:
+			return float64(v), true
+		}
+	}
+	return 0, false
+}
+
+func payloadStrings(e events.Event, key string) []string {
+	p, ok := e.Payload.(map[string]any)
+	if !ok {
+		return nil
+	}
+	switch v := p[key].(type) {
+	case []string:
+		return v
+	case []any:
+		var out []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/discovery"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/provider"
+	"github.com/RevCBH/choo/internal/testutil"
+)
+
+// replay records events at one-minute intervals
+func replay(r *runReport, evts ...events.Event) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i, e := range evts {
+		e.Time = start.Add(time.Duration(i) * time.Minute)
+		r.record(e)
+	}
+}
+
+func TestBuildPRBody_DescribesRun(t *testing.T) {
+	r := newRunReport()
+	replay(r,
+		events.NewEvent(events.UnitStarted, "unit-a"),
+		events.NewEvent(events.TaskClaudeInvoke, "unit-a").WithTask(1).WithPayload(map[string]any{"provider": "claude"}),
+		events.NewEvent(events.TaskClaudeDone, "unit-a").WithTask(1).WithPayload(map[string]any{"cost_usd": 0.4213}),
+		events.NewEvent(events.TaskValidationFail, "unit-a").WithTask(1),
+		events.NewEvent(events.TaskCompleted, "unit-a").WithTask(1),
+		events.NewEvent(events.BaselinePassed, "unit-a").WithPayload(map[string]any{"checks": []string{"build", "lint"}, "fix_attempts": 1}),
+		events.NewEvent(events.CodeReviewIssuesFound, "unit-a").WithPayload(map[string]any{
+			"count":  1,
+			"issues": []provider.ReviewIssue{{File: "api.go", Line: 12, Severity: "warning", Message: "unchecked error"}},
+		}),
+		events.NewEvent(events.CodeReviewFixAttempt, "unit-a").WithPayload(map[string]any{"iteration": 1}),
+		events.NewEvent(events.CodeReviewFixApplied, "unit-a").WithPayload(map[string]any{"iteration": 1}),
+		events.NewEvent(events.UnitMerged, "unit-a").WithPayload(map[string]any{"files": []string{"api.go", "api_test.go"}}),
+		events.NewEvent(events.UnitCompleted, "unit-a"),
+		events.NewEvent(events.CIChecksFailed, "").WithPayload(map[string]any{"attempt": 1, "checks": []string{"test"}}),
+		events.NewEvent(events.CIFixPushed, "").WithPayload(map[string]any{"attempt": 1, "sha": "0123456789abcdef"}),
+		events.NewEvent(events.CIChecksPassed, "").WithPayload(map[string]any{"attempts": 1}),
+	)
+
+	o := &Orchestrator{
+		cfg: Config{FeatureDescription: "Adds the API."},
+		unitMap: map[string]*discovery.Unit{
+			"unit-a": {ID: "unit-a", Tasks: []*discovery.Task{
+				{Number: 1, Title: "Handlers", Backpressure: "go test ./api/..."},
+				{Number: 2, Title: "Docs", Status: discovery.TaskStatusComplete},
+			}},
+		},
+		completedUnits: []string{"unit-a", "unit-b"},
+		report:         r,
+	}
+
+	body := o.buildPRBody()
+
+	for _, want := range []string{
+		"Adds the API.",
+		"### unit-a",
+		"Completed in 10m0s, 1 agent invocation (claude, $0.42)",
+		"- [x] #1 Handlers — `go test ./api/...` passed on attempt 2 in 4m0s",
+		"- [x] #2 Docs — completed in an earlier run",
+		"**Baseline checks** (`build`, `lint`): passed after 1 fix attempt",
+		"**Code review:** 1 finding, fixed in 1 iteration",
+		"- `api.go:12` (warning) unchecked error",
+		"Files changed (2)",
+		"- `api_test.go`",
+		"### unit-b",
+		"- Run 1 failed: `test`; fixed in 0123456",
+		"- Checks passed after 1 fix",
+		"- Units: 2, tasks: 2",
+		"- Duration: 13m0s",
+		"- Agent invocations: 1 (1m0s of agent time, $0.42)",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("PR body missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestBuildPRBody_IsDeterministic(t *testing.T) {
+	r := newRunReport()
+	replay(r,
+		events.NewEvent(events.UnitStarted, "unit-a"),
+		events.NewEvent(events.TaskCompleted, "unit-a").WithTask(2),
+		events.NewEvent(events.TaskCompleted, "unit-a").WithTask(1),
+		events.NewEvent(events.UnitCompleted, "unit-a"),
+	)
+	o := &Orchestrator{completedUnits: []string{"unit-a"}, report: r}
+
+	first := o.buildPRBody()
+	if second := o.buildPRBody(); first != second {
+		t.Fatalf("expected identical bodies, got:\n%s\n---\n%s", first, second)
+	}
+	if strings.Index(first, "#1 Task 1") > strings.Index(first, "#2 Task 2") {
+		t.Errorf("expected recorded tasks in order:\n%s", first)
+	}
+}
+
+func TestBuildPRBody_UnknownCost(t *testing.T) {
+	r := newRunReport()
+	replay(r,
+		events.NewEvent(events.UnitStarted, "unit-a"),
+		events.NewEvent(events.TaskClaudeInvoke, "unit-a").WithTask(1).WithPayload(map[string]any{"provider": "codex"}),
+		events.NewEvent(events.TaskClaudeDone, "unit-a").WithTask(1),
+		events.NewEvent(events.UnitCompleted, "unit-a"),
+	)
+	o := &Orchestrator{completedUnits: []string{"unit-a"}, report: r}
+
+	body := o.buildPRBody()
+	for _, want := range []string{
+		"1 agent invocation (codex, cost n/a)",
+		"- Agent invocations: 1 (1m0s of agent time, cost n/a)",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("PR body missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestFixCI_UpdatesPRDescription(t *testing.T) {
+	o, ci, bare := setupCIRepo(t, "failure", "success")
+	o.ciFixer = &fixingProvider{t: t}
+	o.bus = events.NewBus(100)
+	defer o.bus.Close()
+	o.report = newRunReport()
+	o.bus.Subscribe(o.report.record)
+	o.cfg.FeatureTitle = "api"
+
+	pr, err := ci.OpenPR(context.Background(), "feature/test", "main", "feat: api", "initial")
+	if err != nil {
+		t.Fatalf("OpenPR() error = %v", err)
+	}
+	o.featurePR = pr.Number
+
+	updates := &updatingCI{scriptedCI: ci}
+	o.forge = updates
+
+	if err := o.fixCI(context.Background(), pr.URL); err != nil {
+		t.Fatalf("fixCI() error = %v", err)
+	}
+
+	if len(updates.bodies) != 2 {
+		t.Fatalf("expected the description updated after the fix and the pass, got %d updates", len(updates.bodies))
+	}
+	sha := testutil.Git(t, bare, "rev-parse", "--short=7", "feature/test")
+	if !strings.Contains(updates.bodies[0], "fixed in "+sha) {
+		t.Errorf("expected first update to name the fix %s:\n%s", sha, updates.bodies[0])
+	}
+	if !strings.Contains(updates.bodies[1], "Checks passed after 1 fix") {
+		t.Errorf("expected last update to report passing checks:\n%s", updates.bodies[1])
+	}
+}
+
+func TestPRNumberFromURL(t *testing.T) {
+	tests := map[string]int{
+		"https://github.com/o/r/pull/42":                    42,
+		"https://gitlab.example.com/g/p/-/merge_requests/7": 7,
+		"https://gitea.example.com/o/r/pulls/3":             3,
+		"https://github.com/o/r/pull/42/files":              0,
+		"not a url":                                         0,
+	}
+	for url, want := range tests {
+		if got := prNumberFromURL(url); got != want {
+			t.Errorf("prNumberFromURL(%q) = %d, want %d", url, got, want)
+		}
+	}
+}
+
+// updatingCI records PR description updates
+type updatingCI struct {
+	*scriptedCI
+	bodies []string
+}
+
+func (c *updatingCI) UpdatePR(ctx context.Context, prNumber int, title, body string) error {
+	c.bodies = append(c.bodies, body)
+	return c.scriptedCI.UpdatePR(ctx, prNumber, title, body)
+}
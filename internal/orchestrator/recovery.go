@@ -0,0 +1,47 @@
+package orchestrator
+
+import (
+	"github.com/RevCBH/choo/internal/discovery"
+)
+
+// Recovery is what a previous attempt at a run had finished, from its
+// journal. It takes precedence over the status frontmatter of the unit and
+// task specs, which may lag behind what was committed and merged.
+type Recovery struct {
+	// CompletedUnits were merged into the feature branch (or, without
+	// PRs, completed)
+	CompletedUnits []string
+
+	// CommittedTasks are the task numbers committed per unit ID
+	CommittedTasks map[string][]int
+
+	// FeaturePR and FeaturePRURL identify the feature PR, if one was opened
+	FeaturePR    int
+	FeaturePRURL string
+}
+
+// applyRecovery marks the units and tasks a previous attempt finished as
+// complete, so they are not run again
+func applyRecovery(units []*discovery.Unit, r *Recovery) {
+	if r == nil {
+		return
+	}
+
+	completed := make(map[string]bool, len(r.CompletedUnits))
+	for _, id := range r.CompletedUnits {
+		completed[id] = true
+	}
+
+	for _, unit := range units {
+		if completed[unit.ID] {
+			unit.Status = discovery.UnitStatusComplete
+		}
+		for _, number := range r.CommittedTasks[unit.ID] {
+			for _, task := range unit.Tasks {
+				if task.Number == number {
+					task.Status = discovery.TaskStatusComplete
+				}
+			}
+		}
+	}
+}
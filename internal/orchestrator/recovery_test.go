@@ -0,0 +1,62 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/RevCBH/choo/internal/discovery"
+)
+
+func TestApplyRecovery(t *testing.T) {
+	units := []*discovery.Unit{
+		{ID: "a", Status: discovery.UnitStatusInProgress, Tasks: []*discovery.Task{
+			{Number: 1, Status: discovery.TaskStatusComplete},
+			{Number: 2, Status: discovery.TaskStatusComplete},
+		}},
+		{ID: "b", Status: discovery.UnitStatusInProgress, Tasks: []*discovery.Task{
+			{Number: 1, Status: discovery.TaskStatusComplete},
+			{Number: 2, Status: discovery.TaskStatusPending},
+		}},
+		{ID: "c", Status: discovery.UnitStatusPending, Tasks: []*discovery.Task{
+			{Number: 1, Status: discovery.TaskStatusPending},
+		}},
+	}
+
+	applyRecovery(units, &Recovery{
+		CompletedUnits: []string{"a"},
+		CommittedTasks: map[string][]int{"b": {1, 2}},
+	})
+
+	if units[0].Status != discovery.UnitStatusComplete {
+		t.Errorf("expected unit a complete, got %s", units[0].Status)
+	}
+	if units[1].Status != discovery.UnitStatusInProgress {
+		t.Errorf("expected unit b still in progress, got %s", units[1].Status)
+	}
+	if units[1].Tasks[1].Status != discovery.TaskStatusComplete {
+		t.Errorf("expected committed task b#2 complete, got %s", units[1].Tasks[1].Status)
+	}
+	if units[2].Status != discovery.UnitStatusPending || units[2].Tasks[0].Status != discovery.TaskStatusPending {
+		t.Errorf("expected unit c untouched, got %s/%s", units[2].Status, units[2].Tasks[0].Status)
+	}
+
+	// A fresh run changes nothing
+	applyRecovery(units, nil)
+}
+
+func TestCreateFeaturePR_RecoveredPR(t *testing.T) {
+	orch := New(Config{
+		FeatureMode:   true,
+		FeatureBranch: "feature/x",
+		TargetBranch:  "main",
+		Recovery:      &Recovery{FeaturePR: 7, FeaturePRURL: "https://github.com/o/r/pull/7"},
+	}, Dependencies{})
+
+	// The branch is neither pushed nor a second PR opened
+	url, err := orch.createFeaturePR(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if url != "https://github.com/o/r/pull/7" || orch.featurePR != 7 {
+		t.Errorf("expected the recovered PR, got %q (#%d)", url, orch.featurePR)
+	}
+}
@@ -0,0 +1,171 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/discovery"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/stack"
+)
+
+// stacked reports whether each unit gets its own stacked PR
+func (o *Orchestrator) stacked() bool {
+	return o.cfg.PullRequests.Mode == config.PRModeStacked && !o.cfg.NoPR
+}
+
+// stackRoot is the branch the bottom of the stack targets: the feature
+// branch in feature mode, otherwise the target branch
+func (o *Orchestrator) stackRoot() string {
+	if o.cfg.FeatureMode && o.cfg.FeatureBranch != "" {
+		return o.cfg.FeatureBranch
+	}
+	return o.cfg.TargetBranch
+}
+
+// startStack opens the stack and starts adding each unit's PR as the unit
+// merges. Units are added in merge order, which respects dependencies.
+// Returns a func that waits for pending units to be stacked.
+func (o *Orchestrator) startStack(ctx context.Context, units []*discovery.Unit) (func(), error) {
+	if o.forge == nil {
+		return nil, fmt.Errorf("stacked pull requests require a forge client")
+	}
+
+	nodes, err := stack.Plan(units)
+	if err != nil {
+		return nil, err
+	}
+
+	// The root must exist remotely before unit work lands on it locally,
+	// otherwise the bottom PRs would already contain every unit
+	if o.cfg.FeatureMode {
+		if err := o.pushFeatureBranch(ctx); err != nil {
+			return nil, fmt.Errorf("failed to push feature branch: %w", err)
+		}
+	}
+
+	o.stack, err = stack.Open(ctx, stack.Config{
+		RepoRoot:     o.cfg.RepoRoot,
+		WorktreeBase: o.cfg.WorktreeBase,
+		Root:         o.stackRoot(),
+		BranchPrefix: o.cfg.PullRequests.BranchPrefix,
+	}, o.forge, o.bus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stack: %w", err)
+	}
+
+	o.stackNodes = make(map[string]stack.Node, len(nodes))
+	for _, n := range nodes {
+		o.stackNodes[n.Unit] = n
+	}
+	changes := make(chan stack.UnitChange, len(nodes))
+	o.stackMu.Lock()
+	o.stackCh = changes
+	o.stackMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for change := range changes {
+			entry, err := o.stack.Add(ctx, change)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to open stacked PR for %s: %v\n", change.Unit, err)
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "Opened stacked PR for %s: %s\n", change.Unit, entry.URL)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			o.stackMu.Lock()
+			o.stackCh = nil
+			o.stackMu.Unlock()
+			close(changes)
+			<-done
+		})
+	}, nil
+}
+
+// enqueueStack queues a merged unit to be added to the stack. Units merged
+// after the stack is finished (e.g. during shutdown) are ignored.
+func (o *Orchestrator) enqueueStack(e events.Event) {
+	o.stackMu.Lock()
+	defer o.stackMu.Unlock()
+	if o.stackCh == nil {
+		return
+	}
+
+	payload, _ := e.Payload.(map[string]any)
+	base, _ := payload["base_commit"].(string)
+	head, _ := payload["head_commit"].(string)
+
+	node, ok := o.stackNodes[e.Unit]
+	if !ok {
+		return
+	}
+
+	o.stackCh <- stack.UnitChange{
+		Node:       node,
+		BaseCommit: base,
+		HeadCommit: head,
+		Title:      o.stackPRTitle(e.Unit),
+		Body:       o.buildStackPRBody(node),
+	}
+}
+
+// stackPRTitle names a unit's PR after its first task
+func (o *Orchestrator) stackPRTitle(unitID string) string {
+	unit := o.unitMap[unitID]
+	if unit != nil && len(unit.Tasks) > 0 && unit.Tasks[0].Title != "" {
+		return fmt.Sprintf("%s: %s", unitID, unit.Tasks[0].Title)
+	}
+	return unitID
+}
+
+// buildStackPRBody describes a unit's tasks and its place in the stack
+func (o *Orchestrator) buildStackPRBody(node stack.Node) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Tasks\n\n")
+	if unit := o.unitMap[node.Unit]; unit != nil {
+		for _, task := range unit.Tasks {
+			sb.WriteString(fmt.Sprintf("- [x] %s\n", task.Title))
+		}
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Stack\n\n")
+	if node.Parent == "" {
+		sb.WriteString(fmt.Sprintf("Based on `%s`.\n", o.stackRoot()))
+	} else {
+		sb.WriteString(fmt.Sprintf("Stacked on `%s`; merge that PR first.\n", node.Parent))
+	}
+	if len(node.Extra) > 0 {
+		sb.WriteString(fmt.Sprintf("Also includes changes from: %s.\n", strings.Join(node.Extra, ", ")))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Automated Implementation\n\n")
+	sb.WriteString("This PR was created by the choo orchestrator.\n")
+	sb.WriteString("Use `choo stack restack` after updating a lower PR and `choo stack merge` to land the stack in order.\n")
+
+	return sb.String()
+}
+
+// printStack lists the PRs in the stack
+func (o *Orchestrator) printStack() {
+	if o.stack == nil {
+		return
+	}
+	state := o.stack.State()
+	fmt.Printf("\nStacked PRs (base: %s):\n", state.Root)
+	for _, e := range state.Entries {
+		fmt.Printf("  %-20s %s -> %s  %s\n", e.Unit, e.Branch, e.Base, e.URL)
+	}
+}
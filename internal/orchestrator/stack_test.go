@@ -0,0 +1,84 @@
+package orchestrator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/config"
+	"github.com/RevCBH/choo/internal/discovery"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/stack"
+)
+
+func TestStacked(t *testing.T) {
+	o := &Orchestrator{cfg: Config{PullRequests: config.PullRequestConfig{Mode: config.PRModeStacked}}}
+	if !o.stacked() {
+		t.Error("expected stacked mode")
+	}
+
+	o.cfg.NoPR = true
+	if o.stacked() {
+		t.Error("--no-pr should disable stacked PRs")
+	}
+
+	o.cfg = Config{PullRequests: config.PullRequestConfig{Mode: config.PRModeSingle}}
+	if o.stacked() {
+		t.Error("single mode should not stack")
+	}
+}
+
+func TestStackRoot(t *testing.T) {
+	o := &Orchestrator{cfg: Config{TargetBranch: "main"}}
+	if got := o.stackRoot(); got != "main" {
+		t.Errorf("expected main, got %q", got)
+	}
+
+	o.cfg.FeatureMode = true
+	o.cfg.FeatureBranch = "feature/auth"
+	if got := o.stackRoot(); got != "feature/auth" {
+		t.Errorf("expected feature/auth, got %q", got)
+	}
+}
+
+func TestBuildStackPRBody(t *testing.T) {
+	o := &Orchestrator{
+		cfg: Config{TargetBranch: "main"},
+		unitMap: map[string]*discovery.Unit{
+			"app": {ID: "app", Tasks: []*discovery.Task{{Title: "Wire handlers"}, {Title: "Add routes"}}},
+		},
+	}
+
+	body := o.buildStackPRBody(stack.Node{Unit: "app", Parent: "db", Extra: []string{"web"}})
+
+	for _, want := range []string{"- [x] Wire handlers", "- [x] Add routes", "Stacked on `db`", "Also includes changes from: web"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body should contain %q:\n%s", want, body)
+		}
+	}
+
+	if got := o.stackPRTitle("app"); got != "app: Wire handlers" {
+		t.Errorf("unexpected title %q", got)
+	}
+}
+
+func TestEnqueueStack(t *testing.T) {
+	o := &Orchestrator{
+		unitMap:    map[string]*discovery.Unit{"db": {ID: "db"}},
+		stackNodes: map[string]stack.Node{"db": {Unit: "db", Parent: "config"}},
+		stackCh:    make(chan stack.UnitChange, 1),
+	}
+
+	o.enqueueStack(events.NewEvent(events.UnitMerged, "db").WithPayload(map[string]any{
+		"base_commit": "aaa",
+		"head_commit": "bbb",
+	}))
+
+	change := <-o.stackCh
+	if change.Parent != "config" || change.BaseCommit != "aaa" || change.HeadCommit != "bbb" {
+		t.Errorf("unexpected change: %+v", change)
+	}
+
+	// After the stack is finished, merges are ignored rather than blocking
+	o.stackCh = nil
+	o.enqueueStack(events.NewEvent(events.UnitMerged, "db"))
+}
@@ -71,11 +71,18 @@ func (p *ClaudeProvider) SetStreamContext(ctx StreamContext) {
 // Returns when the subprocess exits or context is cancelled.
 func (p *ClaudeProvider) Invoke(ctx context.Context, prompt string, workdir string, stdout, stderr io.Writer) error {
 	if p.streamJSON {
-		return p.invokeWithStream(ctx, prompt, workdir, stdout, stderr)
+		return p.invokeWithStream(ctx, prompt, workdir, stdout, stderr, nil)
 	}
 	return p.invokeBasic(ctx, prompt, workdir, stdout, stderr)
 }
 
+// InvokeWithTranscript executes Claude CLI like Invoke, calling onEntry
+// with each step of the agent's transcript. It always streams JSON, and
+// writes the assistant's text to stdout along with the tool calls.
+func (p *ClaudeProvider) InvokeWithTranscript(ctx context.Context, prompt string, workdir string, stdout, stderr io.Writer, onEntry func(TranscriptEntry)) error {
+	return p.invokeWithStream(ctx, prompt, workdir, stdout, stderr, onEntry)
+}
+
 // invokeBasic runs Claude without JSON streaming.
 func (p *ClaudeProvider) invokeBasic(ctx context.Context, prompt string, workdir string, stdout, stderr io.Writer) error {
 	args := []string{
@@ -94,8 +101,9 @@ func (p *ClaudeProvider) invokeBasic(ctx context.Context, prompt string, workdir
 	return nil
 }
 
-// invokeWithStream runs Claude with JSON streaming output.
-func (p *ClaudeProvider) invokeWithStream(ctx context.Context, prompt string, workdir string, stdout, stderr io.Writer) error {
+// invokeWithStream runs Claude with JSON streaming output, passing the
+// transcript to onEntry if set.
+func (p *ClaudeProvider) invokeWithStream(ctx context.Context, prompt string, workdir string, stdout, stderr io.Writer, onEntry func(TranscriptEntry)) error {
 	// Note: --verbose is required when using --print with --output-format=stream-json
 	args := []string{
 		"--dangerously-skip-permissions",
@@ -123,7 +131,7 @@ func (p *ClaudeProvider) invokeWithStream(ctx context.Context, prompt string, wo
 	handler := NewStreamHandler(StreamOptions{
 		Output:         stdout,
 		Verbose:        p.verbose,
-		ShowAssistant:  p.showAssistant,
+		ShowAssistant:  p.showAssistant || onEntry != nil,
 		UseTUI:         isTerminalWriter(stdout),
 		EnableProgress: p.streamCtx.EnableProgress,
 		SpecsDir:       p.streamCtx.SpecsDir,
@@ -133,6 +141,7 @@ func (p *ClaudeProvider) invokeWithStream(ctx context.Context, prompt string, wo
 		PRDPath:        p.streamCtx.PRDPath,
 		InitialItems:   p.streamCtx.InitialItems,
 		Total:          p.streamCtx.Total,
+		OnEntry:        onEntry,
 	})
 	streamErr := handler.ProcessStream(stdoutPipe)
 
@@ -126,3 +126,57 @@ func TestClaudeProvider_Invoke_ContextTimeout(t *testing.T) {
 		t.Error("expected error from context timeout, got nil")
 	}
 }
+
+func TestClaudeProvider_InvokeWithTranscript(t *testing.T) {
+	// A script that ignores its arguments and prints a stream-json transcript
+	tmpDir := t.TempDir()
+	scriptPath := tmpDir + "/claude"
+	script := `#!/bin/sh
+cat <<'JSON'
+{"type":"system","subtype":"init"}
+{"type":"assistant","message":{"content":[{"type":"text","text":"Reading the spec.\n"},{"type":"tool_use","id":"t1","name":"Read","input":{"file_path":"specs/tasks/app/01-setup.md"}}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"t1","content":[{"type":"text","text":"# Setup"}]}]}}
+{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t2","name":"Bash","input":{"command":"go test ./..."}}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"t2","content":"FAIL","is_error":true}]}}
+{"type":"result","subtype":"success","result":"Tests fail.","total_cost_usd":0.42}
+JSON
+`
+	if err := os.WriteFile(scriptPath, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to create test script: %v", err)
+	}
+
+	var entries []TranscriptEntry
+	var stdout bytes.Buffer
+	p := NewClaude(scriptPath)
+	err := p.InvokeWithTranscript(context.Background(), "ignored", tmpDir, &stdout, io.Discard, func(e TranscriptEntry) {
+		entries = append(entries, e)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []TranscriptEntry{
+		{Kind: TranscriptText, Text: "Reading the spec.\n"},
+		{Kind: TranscriptToolUse, Tool: "Read", Input: ".../tasks/app/01-setup.md"},
+		{Kind: TranscriptToolResult, Text: "# Setup"},
+		{Kind: TranscriptToolUse, Tool: "Bash", Input: "go test ./..."},
+		{Kind: TranscriptToolResult, Text: "FAIL", IsError: true},
+	}
+	if len(entries) != len(want)+1 {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(want)+1, entries)
+	}
+	// The transcript ends with the invocation's cost
+	if last := entries[len(want)]; last.Kind != TranscriptResult || last.CostUSD == nil || *last.CostUSD != 0.42 {
+		t.Errorf("last entry = %+v, want a result costing $0.42", last)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+
+	// The log still reads as a transcript
+	if !strings.Contains(stdout.String(), "Reading the spec.") {
+		t.Errorf("stdout is missing the assistant text: %q", stdout.String())
+	}
+}
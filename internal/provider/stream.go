@@ -27,6 +27,9 @@ type StreamEvent struct {
 	ToolUseID string          `json:"tool_use_id,omitempty"`
 	Content   json.RawMessage `json:"content,omitempty"`
 	Timestamp time.Time       `json:"-"`
+
+	// TotalCostUSD is the invocation's cost, on the final "result" message
+	TotalCostUSD *float64 `json:"total_cost_usd,omitempty"`
 }
 
 // MessageEvent contains message-level information.
@@ -39,11 +42,14 @@ type MessageEvent struct {
 
 // ContentBlock represents a content block in a message.
 type ContentBlock struct {
-	Type  string          `json:"type"`
-	Text  string          `json:"text,omitempty"`
-	ID    string          `json:"id,omitempty"`
-	Name  string          `json:"name,omitempty"`
-	Input json.RawMessage `json:"input,omitempty"`
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"` // tool_result blocks
+	Content   json.RawMessage `json:"content,omitempty"`     // tool_result blocks
+	IsError   bool            `json:"is_error,omitempty"`    // tool_result blocks
 }
 
 // DeltaEvent contains incremental updates.
@@ -72,6 +78,17 @@ type ResultEvent struct {
 	Success bool   `json:"success"`
 }
 
+// UnmarshalJSON accepts the final "result" message's result, which is the
+// agent's closing text rather than an object, as an empty ResultEvent.
+func (r *ResultEvent) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*r = ResultEvent{}
+		return nil
+	}
+	type plain ResultEvent
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 type toolKind int
 
 const (
@@ -81,12 +98,13 @@ const (
 )
 
 type toolMeta struct {
-	name     string
-	kind     toolKind
-	path     string  // spec path for direct spec writes
-	specPath string  // parent spec path for task writes
-	taskName string  // task file name for task writes
-	printed  bool
+	name        string
+	kind        toolKind
+	path        string // spec path for direct spec writes
+	specPath    string // parent spec path for task writes
+	taskName    string // task file name for task writes
+	printed     bool
+	transcribed bool
 }
 
 // StreamOptions configures StreamHandler behavior.
@@ -103,6 +121,9 @@ type StreamOptions struct {
 	PRDPath        string
 	InitialItems   []string
 	Total          int
+
+	// OnEntry, if set, is called with each step of the transcript
+	OnEntry func(TranscriptEntry)
 }
 
 // StreamHandler processes streaming events from Claude.
@@ -136,6 +157,8 @@ type StreamHandler struct {
 	messageCount int
 	toolCount    int
 
+	onEntry func(TranscriptEntry)
+
 	renderMu sync.Mutex
 }
 
@@ -158,6 +181,7 @@ func NewStreamHandler(opts StreamOptions) *StreamHandler {
 		counterLabel:    opts.CounterLabel,
 		plainPrinted:    make(map[string]ItemStatus),
 		toolMeta:        make(map[string]*toolMeta),
+		onEntry:         opts.OnEntry,
 	}
 
 	if h.progressEnabled {
@@ -253,12 +277,34 @@ func (h *StreamHandler) handleEvent(event *StreamEvent) {
 	case "tool_result":
 		h.handleToolResult(event)
 
+	case "result":
+		// Final message of the invocation, with its cost
+		h.transcribe(TranscriptEntry{Kind: TranscriptResult, CostUSD: event.TotalCostUSD})
+
 	case "assistant":
 		// Assistant response chunk
 		if event.Message != nil {
 			for _, block := range event.Message.Content {
-				if block.Type == "text" && block.Text != "" {
+				switch {
+				case block.Type == "text" && block.Text != "":
 					h.handleText(block.Text)
+				case block.Type == "tool_use":
+					h.handleToolUse(&ToolUseEvent{ID: block.ID, Name: block.Name, Input: block.Input})
+				}
+			}
+		}
+
+	case "user":
+		// Tool results are returned to the agent as user messages
+		if event.Message != nil {
+			for _, block := range event.Message.Content {
+				if block.Type == "tool_result" {
+					h.completeTool(block.ToolUseID, !block.IsError, "")
+					h.transcribe(TranscriptEntry{
+						Kind:    TranscriptToolResult,
+						Text:    toolResultText(block.Content),
+						IsError: block.IsError,
+					})
 				}
 			}
 		}
@@ -292,9 +338,13 @@ func (h *StreamHandler) handleContentBlockStop() {
 		return
 	}
 
+	var input json.RawMessage
 	if h.currentToolInput.Len() > 0 {
-		h.handleToolStart(h.currentToolID, h.currentToolName, json.RawMessage(h.currentToolInput.String()))
+		input = json.RawMessage(h.currentToolInput.String())
+		h.handleToolStart(h.currentToolID, h.currentToolName, input)
 	}
+	// The input is complete only now
+	h.transcribeToolUse(h.currentToolID, h.currentToolName, input)
 
 	h.completeTool(h.currentToolID, true, "")
 
@@ -326,6 +376,7 @@ func (h *StreamHandler) handleDelta(delta *DeltaEvent) {
 func (h *StreamHandler) handleToolUse(tool *ToolUseEvent) {
 	h.handleToolStart(tool.ID, tool.Name, tool.Input)
 	h.printToolLineOnce(tool.ID, tool.Name, tool.Input)
+	h.transcribeToolUse(tool.ID, tool.Name, tool.Input)
 }
 
 // handleToolResult processes tool completion events.
@@ -342,6 +393,11 @@ func (h *StreamHandler) handleToolResult(event *StreamEvent) {
 		success = event.Result.Success
 	}
 	h.completeTool(id, success, "")
+	h.transcribe(TranscriptEntry{
+		Kind:    TranscriptToolResult,
+		Text:    toolResultText(event.Content),
+		IsError: !success,
+	})
 }
 
 func (h *StreamHandler) handleError(errEvent *ErrorEvent) {
@@ -349,6 +405,7 @@ func (h *StreamHandler) handleError(errEvent *ErrorEvent) {
 	if h.currentToolID != "" {
 		h.completeTool(h.currentToolID, false, msg)
 	}
+	h.transcribe(TranscriptEntry{Kind: TranscriptError, Text: msg})
 	if h.showToolUse {
 		h.writeLog(fmt.Sprintf("⚠ Error: %s\n", msg))
 	}
@@ -358,6 +415,7 @@ func (h *StreamHandler) handleText(text string) {
 	if h.showAssistant {
 		h.writeLog(text)
 	}
+	h.transcribe(TranscriptEntry{Kind: TranscriptText, Text: text})
 	h.textBuf.WriteString(text)
 	for {
 		data := h.textBuf.String()
@@ -812,6 +870,37 @@ func toolIcon(name string) string {
 	}
 }
 
+// transcribe passes entry to the transcript handler, if any
+func (h *StreamHandler) transcribe(entry TranscriptEntry) {
+	if h.onEntry != nil {
+		h.onEntry(entry)
+	}
+}
+
+// transcribeToolUse records a tool call in the transcript once, with its
+// summarized input
+func (h *StreamHandler) transcribeToolUse(id, name string, input json.RawMessage) {
+	if h.onEntry == nil {
+		return
+	}
+	if id != "" {
+		meta, ok := h.toolMeta[id]
+		if !ok {
+			meta = &toolMeta{name: name, kind: classifyTool(name)}
+			h.toolMeta[id] = meta
+		}
+		if meta.transcribed {
+			return
+		}
+		meta.transcribed = true
+	}
+	h.transcribe(TranscriptEntry{
+		Kind:  TranscriptToolUse,
+		Tool:  name,
+		Input: summarizeToolInput(name, input),
+	})
+}
+
 // formatToolName formats a tool name for display.
 func formatToolName(name string) string {
 	return name
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// TranscriptKind identifies what a transcript entry records
+type TranscriptKind string
+
+const (
+	// TranscriptText is text the agent wrote
+	TranscriptText TranscriptKind = "text"
+
+	// TranscriptToolUse is a tool the agent called
+	TranscriptToolUse TranscriptKind = "tool_use"
+
+	// TranscriptToolResult is the output of a tool call
+	TranscriptToolResult TranscriptKind = "tool_result"
+
+	// TranscriptError is an error reported by the provider
+	TranscriptError TranscriptKind = "error"
+
+	// TranscriptResult ends the transcript, with the invocation's cost if
+	// the provider reports one
+	TranscriptResult TranscriptKind = "result"
+)
+
+// maxToolResultText bounds the tool output kept in a transcript entry
+const maxToolResultText = 2000
+
+// TranscriptEntry is one step of an agent's transcript
+type TranscriptEntry struct {
+	Kind TranscriptKind `json:"kind"`
+
+	// Text is the agent's text, the tool's output or the error message
+	Text string `json:"text,omitempty"`
+
+	// Tool and Input are the tool called and its summarized input
+	Tool  string `json:"tool,omitempty"`
+	Input string `json:"input,omitempty"`
+
+	// IsError is set for tool results that report a failure
+	IsError bool `json:"is_error,omitempty"`
+
+	// CostUSD is the invocation's cost, on result entries
+	CostUSD *float64 `json:"cost_usd,omitempty"`
+}
+
+// Transcriber is a Provider that can report the agent's transcript while
+// it runs. Providers that cannot are invoked without one.
+type Transcriber interface {
+	Provider
+
+	// InvokeWithTranscript is Invoke, calling onEntry with each step of
+	// the transcript as it happens
+	InvokeWithTranscript(ctx context.Context, prompt string, workdir string, stdout, stderr io.Writer, onEntry func(TranscriptEntry)) error
+}
+
+// toolResultText returns the text of a tool result's content, which is a
+// string or a list of content blocks, bounded to maxToolResultText
+func toolResultText(content json.RawMessage) string {
+	if len(content) == 0 {
+		return ""
+	}
+	var text string
+	if err := json.Unmarshal(content, &text); err != nil {
+		var blocks []ContentBlock
+		if err := json.Unmarshal(content, &blocks); err != nil {
+			return ""
+		}
+		parts := make([]string, 0, len(blocks))
+		for _, b := range blocks {
+			if b.Type == "text" && b.Text != "" {
+				parts = append(parts, b.Text)
+			}
+		}
+		text = strings.Join(parts, "\n")
+	}
+	// Keep the output's lines, unlike truncateString
+	if len(text) > maxToolResultText {
+		text = strings.ToValidUTF8(text[:maxToolResultText], "") + "..."
+	}
+	return text
+}
@@ -22,6 +22,7 @@ const (
 	ReasonAtCapacity  DispatchBlockReason = "at_capacity"
 	ReasonAllComplete DispatchBlockReason = "all_complete"
 	ReasonAllBlocked  DispatchBlockReason = "all_blocked"
+	ReasonSerialized  DispatchBlockReason = "serialized"
 )
 
 // Dispatch attempts to dispatch the next ready unit
@@ -46,8 +47,14 @@ func (s *Scheduler) Dispatch() DispatchResult {
 		}
 	}
 
-	// Try to pop from ready queue
-	unitID := s.ready.Pop()
+	// Try to take the next ready unit that is not serialized against a running one
+	unitID := s.nextDispatchable()
+	if unitID == "" && s.ready.Len() > 0 {
+		return DispatchResult{
+			Dispatched: false,
+			Reason:     ReasonSerialized,
+		}
+	}
 	if unitID == "" {
 		// No ready units, check why
 		if s.allBlockedOrComplete() {
@@ -86,7 +93,7 @@ func (s *Scheduler) Dispatch() DispatchResult {
 	// Transition to in_progress (this will emit UnitStarted event and update ready queue)
 	state.Status = StatusInProgress
 
-	// Remove from ready queue (already popped above)
+	// Remove from ready queue (already removed above)
 	// Emit UnitStarted event
 	s.events.Emit(events.NewEvent(events.UnitStarted, unitID))
 
@@ -97,6 +104,31 @@ func (s *Scheduler) Dispatch() DispatchResult {
 	}
 }
 
+// nextDispatchable removes and returns the first ready unit that is not
+// serialized against an active unit, or empty if there is none
+// Called with lock held
+func (s *Scheduler) nextDispatchable() string {
+	for _, unitID := range s.ready.List() {
+		if s.conflictsWithActive(unitID) {
+			continue
+		}
+		s.ready.Remove(unitID)
+		return unitID
+	}
+	return ""
+}
+
+// conflictsWithActive reports whether unitID is serialized against a running unit
+// Called with lock held
+func (s *Scheduler) conflictsWithActive(unitID string) bool {
+	for other := range s.serialized[unitID] {
+		if state, ok := s.states[other]; ok && state.Status.IsActive() {
+			return true
+		}
+	}
+	return false
+}
+
 // allBlockedOrComplete checks if remaining units are all blocked/complete
 // Called with lock held
 func (s *Scheduler) allBlockedOrComplete() bool {
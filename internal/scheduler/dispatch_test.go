@@ -320,3 +320,43 @@ func TestDispatch_Sequential(t *testing.T) {
 		}
 	}
 }
+
+func TestDispatch_Serialized(t *testing.T) {
+	bus := events.NewBus(10)
+	defer bus.Close()
+
+	s := New(bus, 3)
+
+	units := []*discovery.Unit{
+		{ID: "unit1", DependsOn: []string{}},
+		{ID: "unit2", DependsOn: []string{}},
+		{ID: "unit3", DependsOn: []string{}},
+	}
+
+	if _, err := s.Schedule(units); err != nil {
+		t.Fatalf("Schedule failed: %v", err)
+	}
+	s.Serialize("unit1", "unit2")
+
+	// unit1 dispatches; unit2 is skipped in favor of unit3
+	if result := s.Dispatch(); result.Unit != "unit1" {
+		t.Fatalf("Expected unit1, got %q", result.Unit)
+	}
+	if result := s.Dispatch(); result.Unit != "unit3" {
+		t.Fatalf("Expected unit3, got %q", result.Unit)
+	}
+
+	// Only unit2 remains ready, but it is serialized against running unit1
+	result := s.Dispatch()
+	if result.Dispatched {
+		t.Fatalf("Expected no dispatch, got %q", result.Unit)
+	}
+	if result.Reason != ReasonSerialized {
+		t.Errorf("Expected Reason=ReasonSerialized, got %q", result.Reason)
+	}
+
+	s.Complete("unit1")
+	if result := s.Dispatch(); result.Unit != "unit2" {
+		t.Errorf("Expected unit2 after unit1 completed, got %q", result.Unit)
+	}
+}
@@ -15,6 +15,7 @@ type Scheduler struct {
 	states         map[string]*UnitState
 	ready          *ReadyQueue
 	events         *events.Bus
+	serialized     map[string]map[string]bool // unit pairs that must not run concurrently
 	mu             sync.RWMutex
 }
 
@@ -32,6 +33,25 @@ func New(events *events.Bus, maxParallelism int) *Scheduler {
 		events:         events,
 		states:         make(map[string]*UnitState),
 		ready:          NewReadyQueue(),
+		serialized:     make(map[string]map[string]bool),
+	}
+}
+
+// Serialize prevents two units from running at the same time.
+// Used for pairs at high risk of conflicting edits; dependencies are
+// unaffected, so either unit may run first.
+func (s *Scheduler) Serialize(a, b string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a == b {
+		return
+	}
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		if s.serialized[pair[0]] == nil {
+			s.serialized[pair[0]] = make(map[string]bool)
+		}
+		s.serialized[pair[0]][pair[1]] = true
 	}
 }
 
@@ -0,0 +1,88 @@
+package stack
+
+import (
+	"fmt"
+
+	"github.com/RevCBH/choo/internal/discovery"
+	"github.com/RevCBH/choo/internal/scheduler"
+)
+
+// Node is a unit's position in the stack
+type Node struct {
+	// Unit is the unit ID
+	Unit string
+
+	// Parent is the dependency whose branch this unit's PR is stacked on.
+	// Empty means the unit sits directly on the stack root.
+	Parent string
+
+	// Extra lists other direct dependencies not already below Parent or
+	// another dependency. Their changes are merged into the unit's branch
+	// since a PR has only one base.
+	Extra []string
+}
+
+// Plan arranges units into a stack following the dependency graph.
+// Nodes are returned in topological order. Each unit is stacked on the
+// dependency that comes last in that order, so its parent's branch already
+// contains as many of its other dependencies as possible.
+func Plan(units []*discovery.Unit) ([]Node, error) {
+	graph, err := scheduler.NewGraph(units)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+
+	order, err := graph.TopologicalSort()
+	if err != nil {
+		return nil, err
+	}
+
+	position := make(map[string]int, len(order))
+	for i, id := range order {
+		position[id] = i
+	}
+
+	nodes := make([]Node, 0, len(order))
+	for _, id := range order {
+		node := Node{Unit: id}
+		for _, dep := range graph.GetDependencies(id) {
+			if node.Parent == "" || position[dep] > position[node.Parent] {
+				node.Parent = dep
+			}
+		}
+		// Dependencies reachable through another dependency come along with it
+		below := ancestors(graph, node.Parent)
+		for _, dep := range graph.GetDependencies(id) {
+			for a := range ancestors(graph, dep) {
+				below[a] = true
+			}
+		}
+		for _, dep := range graph.GetDependencies(id) {
+			if dep != node.Parent && !below[dep] {
+				node.Extra = append(node.Extra, dep)
+			}
+		}
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// ancestors returns every unit the given unit depends on, directly or not
+func ancestors(graph *scheduler.Graph, id string) map[string]bool {
+	seen := make(map[string]bool)
+	if id == "" {
+		return seen
+	}
+	var visit func(string)
+	visit = func(u string) {
+		for _, dep := range graph.GetDependencies(u) {
+			if !seen[dep] {
+				seen[dep] = true
+				visit(dep)
+			}
+		}
+	}
+	visit(id)
+	return seen
+}
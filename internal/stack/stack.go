@@ -0,0 +1,445 @@
+package stack
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/git"
+	"github.com/RevCBH/choo/internal/github"
+)
+
+// scratchDir is the worktree, under the worktree base, where stack branches
+// are built and rebased without touching the repository checkout
+const scratchDir = ".stack"
+
+// PRService is the subset of the GitHub client used to manage stacked PRs
+type PRService interface {
+	OpenPR(ctx context.Context, head, base, title, body string) (*github.PRInfo, error)
+	UpdatePRBase(ctx context.Context, prNumber int, base string) error
+	Merge(ctx context.Context, prNumber int) (*github.MergeResult, error)
+}
+
+// Config identifies where a stack lives
+type Config struct {
+	// RepoRoot is the repository the stack branches are created in
+	RepoRoot string
+
+	// WorktreeBase holds the scratch worktree and the persisted state
+	WorktreeBase string
+
+	// Root is the branch the bottom of the stack targets
+	Root string
+
+	// BranchPrefix is prepended to unit IDs to name stack branches
+	BranchPrefix string
+
+	// Remote is the remote PR branches are pushed to (default: origin)
+	Remote string
+}
+
+// UnitChange is a unit's own commits, as landed on the local target branch
+type UnitChange struct {
+	Node
+
+	// BaseCommit..HeadCommit are the unit's commits
+	BaseCommit string
+	HeadCommit string
+
+	Title string
+	Body  string
+}
+
+// Stack maintains one PR per unit, each based on its parent's branch.
+// All methods are safe for concurrent use; operations run one at a time.
+type Stack struct {
+	cfg    Config
+	prs    PRService
+	bus    *events.Bus
+	runner git.Runner
+	path   string
+
+	mu    sync.Mutex
+	state *State
+}
+
+// Open loads the stack for cfg.Root, or starts a new one on the root's
+// current tip (preferring the remote branch when it exists)
+func Open(ctx context.Context, cfg Config, prs PRService, bus *events.Bus) (*Stack, error) {
+	if cfg.Remote == "" {
+		cfg.Remote = "origin"
+	}
+
+	s := &Stack{
+		cfg:    cfg,
+		prs:    prs,
+		bus:    bus,
+		runner: git.DefaultRunner(),
+		path:   StatePath(cfg.WorktreeBase, cfg.Root),
+	}
+
+	state, err := LoadState(s.path)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		tip, err := s.rootTip(ctx)
+		if err != nil {
+			return nil, err
+		}
+		state = &State{Root: cfg.Root, RootTip: tip}
+	}
+	s.state = state
+	return s, nil
+}
+
+// State returns a copy of the current stack
+func (s *Stack) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := State{Root: s.state.Root, RootTip: s.state.RootTip}
+	for _, e := range s.state.Entries {
+		entry := *e
+		copied.Entries = append(copied.Entries, &entry)
+	}
+	return copied
+}
+
+// Add builds the unit's stack branch by replaying its commits onto its
+// parent's branch, pushes it and opens its PR. Dependencies must be added first.
+func (s *Stack) Add(ctx context.Context, change UnitChange) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing := s.state.Entry(change.Unit); existing != nil && existing.PR != 0 {
+		return existing, nil
+	}
+	if change.BaseCommit == "" || change.HeadCommit == "" {
+		return nil, fmt.Errorf("unit %s has no recorded commits to stack", change.Unit)
+	}
+
+	entry := &Entry{
+		Unit:   change.Unit,
+		Parent: change.Parent,
+		Extra:  change.Extra,
+		Branch: s.cfg.BranchPrefix + change.Unit,
+	}
+
+	base, baseTip, err := s.baseFor(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	entry.Base, entry.BaseTip = base, baseTip
+
+	scratch, err := s.setupScratch(ctx, baseTip)
+	if err != nil {
+		return nil, err
+	}
+	defer s.removeScratch(ctx, scratch)
+
+	for _, dep := range entry.Extra {
+		depEntry := s.state.Entry(dep)
+		if depEntry == nil || depEntry.Merged {
+			continue
+		}
+		if _, err := s.git(ctx, scratch, "merge", "--no-edit", depEntry.Tip, "-m", fmt.Sprintf("Merge %s into %s", depEntry.Branch, entry.Branch)); err != nil {
+			_, _ = s.git(ctx, scratch, "merge", "--abort")
+			return nil, s.fail(entry, fmt.Errorf("failed to merge dependency %s: %w", dep, err))
+		}
+	}
+
+	commits, err := s.ownCommits(ctx, scratch, change)
+	if err != nil {
+		return nil, s.fail(entry, err)
+	}
+	if len(commits) == 0 {
+		return nil, s.fail(entry, fmt.Errorf("unit %s has no changes beyond %s", change.Unit, base))
+	}
+	if _, err := s.git(ctx, scratch, append([]string{"cherry-pick"}, commits...)...); err != nil {
+		_, _ = s.git(ctx, scratch, "cherry-pick", "--abort")
+		return nil, s.fail(entry, fmt.Errorf("failed to replay unit commits onto %s: %w", base, err))
+	}
+
+	if err := s.publish(ctx, scratch, entry); err != nil {
+		return nil, s.fail(entry, err)
+	}
+
+	pr, err := s.prs.OpenPR(ctx, entry.Branch, entry.Base, change.Title, change.Body)
+	if err != nil {
+		return nil, s.fail(entry, fmt.Errorf("failed to open PR: %w", err))
+	}
+	entry.PR, entry.URL = pr.Number, pr.URL
+
+	if existing := s.state.Entry(entry.Unit); existing != nil {
+		*existing = *entry
+	} else {
+		s.state.Entries = append(s.state.Entries, entry)
+	}
+	if err := s.state.Save(s.path); err != nil {
+		return nil, err
+	}
+
+	s.emit(events.NewEvent(events.StackPROpened, entry.Unit).WithPR(entry.PR).WithPayload(map[string]any{
+		"branch": entry.Branch,
+		"base":   entry.Base,
+		"number": entry.PR,
+		"url":    entry.URL,
+	}))
+	return entry, nil
+}
+
+// ownCommits lists, oldest first, the unit's commits that are not already
+// on the scratch HEAD. The unit's range can contain its dependencies'
+// commits (e.g. when it was rebased onto a remote branch that lacks them);
+// those were already replayed onto the parent's branch and are skipped by
+// patch equivalence.
+func (s *Stack) ownCommits(ctx context.Context, scratch string, change UnitChange) ([]string, error) {
+	out, err := s.git(ctx, scratch, "rev-list", "--reverse", "--no-merges", "--cherry-pick", "--right-only",
+		"HEAD..."+change.HeadCommit, "^"+change.BaseCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits of unit %s: %w", change.Unit, err)
+	}
+	return strings.Fields(out), nil
+}
+
+// Restack picks up commits pushed to stack branches (e.g. review fixes),
+// then rebases every branch whose base moved onto the base's new tip and
+// retargets PRs whose parent has merged. Returns the restacked units.
+// A branch that cannot be rebased cleanly is reported and left as is.
+func (s *Stack) Restack(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.restack(ctx)
+}
+
+func (s *Stack) restack(ctx context.Context) ([]string, error) {
+	if _, err := s.git(ctx, s.cfg.RepoRoot, "fetch", s.cfg.Remote); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", s.cfg.Remote, err)
+	}
+
+	var restacked []string
+	for _, entry := range s.state.Entries {
+		if entry.Merged {
+			continue
+		}
+
+		if remoteTip, err := s.git(ctx, s.cfg.RepoRoot, "rev-parse", "--verify", s.cfg.Remote+"/"+entry.Branch); err == nil && remoteTip != entry.Tip {
+			entry.Tip = remoteTip
+			if _, err := s.git(ctx, s.cfg.RepoRoot, "branch", "-f", entry.Branch, remoteTip); err != nil {
+				return restacked, fmt.Errorf("failed to update %s: %w", entry.Branch, err)
+			}
+		}
+
+		base, baseTip, err := s.baseFor(ctx, entry)
+		if err != nil {
+			return restacked, err
+		}
+		if baseTip == entry.BaseTip && base == entry.Base {
+			continue
+		}
+
+		if baseTip != entry.BaseTip {
+			if err := s.rebase(ctx, entry, baseTip); err != nil {
+				_ = s.fail(entry, err)
+				continue
+			}
+		}
+
+		if base != entry.Base {
+			if err := s.prs.UpdatePRBase(ctx, entry.PR, base); err != nil {
+				return restacked, fmt.Errorf("failed to retarget PR #%d onto %s: %w", entry.PR, base, err)
+			}
+			entry.Base = base
+		}
+
+		restacked = append(restacked, entry.Unit)
+		s.emit(events.NewEvent(events.StackRestacked, entry.Unit).WithPR(entry.PR).WithPayload(map[string]any{
+			"branch": entry.Branch,
+			"base":   entry.Base,
+		}))
+	}
+
+	return restacked, s.state.Save(s.path)
+}
+
+// rebase replays the entry's own commits onto newBaseTip and pushes the result
+func (s *Stack) rebase(ctx context.Context, entry *Entry, newBaseTip string) error {
+	scratch, err := s.setupScratch(ctx, entry.Tip)
+	if err != nil {
+		return err
+	}
+	defer s.removeScratch(ctx, scratch)
+
+	if _, err := s.git(ctx, scratch, "rebase", "--onto", newBaseTip, entry.BaseTip); err != nil {
+		_, _ = s.git(ctx, scratch, "rebase", "--abort")
+		return fmt.Errorf("failed to rebase %s onto %s: %w", entry.Branch, newBaseTip, err)
+	}
+
+	entry.BaseTip = newBaseTip
+	return s.publish(ctx, scratch, entry)
+}
+
+// Merge merges PRs bottom-up in topological order, restacking after each
+// merge so the next PR targets the updated root. A PR is merged only once
+// all of its dependencies have merged. Stops at the first PR that cannot
+// be merged (e.g. not yet approved) and returns the units merged so far.
+func (s *Stack) Merge(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var merged []string
+	for {
+		if _, err := s.restack(ctx); err != nil {
+			return merged, err
+		}
+
+		entry := s.nextMergeable()
+		if entry == nil {
+			return merged, nil
+		}
+
+		result, err := s.prs.Merge(ctx, entry.PR)
+		if err != nil {
+			return merged, fmt.Errorf("failed to merge PR #%d (%s): %w", entry.PR, entry.Unit, err)
+		}
+		if !result.Merged {
+			return merged, fmt.Errorf("PR #%d (%s) was not merged: %s", entry.PR, entry.Unit, result.Message)
+		}
+
+		entry.Merged = true
+		merged = append(merged, entry.Unit)
+		if err := s.state.Save(s.path); err != nil {
+			return merged, err
+		}
+		s.emit(events.NewEvent(events.StackPRMerged, entry.Unit).WithPR(entry.PR).WithPayload(map[string]any{
+			"number": entry.PR,
+			"sha":    result.SHA,
+		}))
+	}
+}
+
+// nextMergeable returns the first unmerged entry whose dependencies have
+// all merged and whose PR already targets the root
+func (s *Stack) nextMergeable() *Entry {
+	for _, entry := range s.state.Entries {
+		if entry.Merged || entry.PR == 0 || entry.Base != s.state.Root {
+			continue
+		}
+		ready := true
+		for _, dep := range append([]string{entry.Parent}, entry.Extra...) {
+			if dep == "" {
+				continue
+			}
+			if depEntry := s.state.Entry(dep); depEntry == nil || !depEntry.Merged {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			return entry
+		}
+	}
+	return nil
+}
+
+// baseFor returns the branch an entry should target and that branch's tip:
+// the parent's branch while the parent is open, otherwise the root
+func (s *Stack) baseFor(ctx context.Context, entry *Entry) (string, string, error) {
+	if entry.Parent != "" {
+		parent := s.state.Entry(entry.Parent)
+		if parent == nil || parent.Tip == "" {
+			return "", "", fmt.Errorf("parent %s of unit %s is not in the stack", entry.Parent, entry.Unit)
+		}
+		if !parent.Merged {
+			return parent.Branch, parent.Tip, nil
+		}
+	}
+
+	if s.anyMerged() {
+		tip, err := s.rootTip(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		s.state.RootTip = tip
+	}
+	return s.state.Root, s.state.RootTip, nil
+}
+
+// anyMerged reports whether any PR has landed on the root, moving it
+func (s *Stack) anyMerged() bool {
+	for _, e := range s.state.Entries {
+		if e.Merged {
+			return true
+		}
+	}
+	return false
+}
+
+// rootTip resolves the root branch, preferring the remote-tracking ref
+func (s *Stack) rootTip(ctx context.Context) (string, error) {
+	if tip, err := s.git(ctx, s.cfg.RepoRoot, "rev-parse", "--verify", s.cfg.Remote+"/"+s.cfg.Root); err == nil {
+		return tip, nil
+	}
+	tip, err := s.git(ctx, s.cfg.RepoRoot, "rev-parse", "--verify", s.cfg.Root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve stack root %s: %w", s.cfg.Root, err)
+	}
+	return tip, nil
+}
+
+// publish points the entry's branch at the scratch HEAD and force-pushes it
+func (s *Stack) publish(ctx context.Context, scratch string, entry *Entry) error {
+	tip, err := s.git(ctx, scratch, "rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", entry.Branch, err)
+	}
+	if _, err := s.git(ctx, s.cfg.RepoRoot, "branch", "-f", entry.Branch, tip); err != nil {
+		return fmt.Errorf("failed to update %s: %w", entry.Branch, err)
+	}
+	if _, err := s.git(ctx, s.cfg.RepoRoot, "push", "--force-with-lease", s.cfg.Remote, entry.Branch); err != nil {
+		return fmt.Errorf("failed to push %s: %w", entry.Branch, err)
+	}
+	entry.Tip = tip
+	return nil
+}
+
+// fail reports a stack failure for the entry and returns err
+func (s *Stack) fail(entry *Entry, err error) error {
+	s.emit(events.NewEvent(events.StackFailed, entry.Unit).WithPayload(map[string]any{
+		"branch": entry.Branch,
+	}).WithError(err))
+	return err
+}
+
+// setupScratch creates a fresh detached scratch worktree at ref
+func (s *Stack) setupScratch(ctx context.Context, ref string) (string, error) {
+	scratch := filepath.Join(s.cfg.WorktreeBase, scratchDir)
+	s.removeScratch(ctx, scratch)
+	if _, err := s.git(ctx, s.cfg.RepoRoot, "worktree", "add", "--detach", scratch, ref); err != nil {
+		return "", fmt.Errorf("failed to create stack worktree: %w", err)
+	}
+	return scratch, nil
+}
+
+// removeScratch removes the scratch worktree, ignoring errors if absent
+func (s *Stack) removeScratch(ctx context.Context, scratch string) {
+	_, _ = s.git(ctx, s.cfg.RepoRoot, "worktree", "remove", "--force", scratch)
+	_ = os.RemoveAll(scratch)
+}
+
+func (s *Stack) emit(evt events.Event) {
+	if s.bus != nil {
+		s.bus.Emit(evt)
+	}
+}
+
+// git runs a git command and returns its trimmed output
+func (s *Stack) git(ctx context.Context, dir string, args ...string) (string, error) {
+	out, err := s.runner.Exec(ctx, dir, args...)
+	return strings.TrimSpace(out), err
+}
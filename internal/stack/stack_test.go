@@ -0,0 +1,192 @@
+package stack
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/discovery"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/github"
+	"github.com/RevCBH/choo/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	units := []*discovery.Unit{
+		{ID: "app", DependsOn: []string{"config", "db", "web"}},
+		{ID: "config"},
+		{ID: "db", DependsOn: []string{"config"}},
+		{ID: "web"},
+	}
+
+	nodes, err := Plan(units)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Node{
+		{Unit: "config"},
+		{Unit: "db", Parent: "config"},
+		{Unit: "web"},
+		// config comes along with db, which is merged in
+		{Unit: "app", Parent: "web", Extra: []string{"db"}},
+	}, nodes)
+}
+
+func TestPlan_Cycle(t *testing.T) {
+	_, err := Plan([]*discovery.Unit{
+		{ID: "a", DependsOn: []string{"b"}},
+		{ID: "b", DependsOn: []string{"a"}},
+	})
+	assert.Error(t, err)
+}
+
+// fakePRs records PR operations and squash-merges PRs into the remote's
+// root branch through a separate clone, like the forge would
+type fakePRs struct {
+	t      *testing.T
+	clone  string
+	root   string
+	prs    map[int]*github.PRInfo
+	bases  []string
+	merged []int
+}
+
+func (f *fakePRs) OpenPR(ctx context.Context, head, base, title, body string) (*github.PRInfo, error) {
+	n := len(f.prs) + 1
+	pr := &github.PRInfo{Number: n, URL: fmt.Sprintf("https://github.com/o/r/pull/%d", n), Branch: head, TargetBranch: base, Title: title}
+	f.prs[n] = pr
+	return pr, nil
+}
+
+func (f *fakePRs) UpdatePRBase(ctx context.Context, prNumber int, base string) error {
+	f.prs[prNumber].TargetBranch = base
+	f.bases = append(f.bases, fmt.Sprintf("#%d->%s", prNumber, base))
+	return nil
+}
+
+func (f *fakePRs) Merge(ctx context.Context, prNumber int) (*github.MergeResult, error) {
+	pr := f.prs[prNumber]
+	if pr.TargetBranch != f.root {
+		return &github.MergeResult{Message: "base is not " + f.root}, nil
+	}
+	testutil.Git(f.t, f.clone, "fetch", "origin")
+	testutil.Git(f.t, f.clone, "checkout", "-B", f.root, "origin/"+f.root)
+	testutil.Git(f.t, f.clone, "merge", "--squash", "origin/"+pr.Branch)
+	testutil.Git(f.t, f.clone, "commit", "-m", pr.Title)
+	testutil.Git(f.t, f.clone, "push", "origin", f.root)
+	f.merged = append(f.merged, prNumber)
+	return &github.MergeResult{Merged: true, SHA: testutil.Git(f.t, f.clone, "rev-parse", "HEAD")}, nil
+}
+
+func TestStack_AddRestackMerge(t *testing.T) {
+	testutil.UnsetGitEnv()
+	ctx := context.Background()
+
+	tmp := t.TempDir()
+	remote := filepath.Join(tmp, "remote.git")
+	repo := filepath.Join(tmp, "repo")
+	clone := filepath.Join(tmp, "clone")
+
+	testutil.Git(t, tmp, "init", "--bare", "-b", "main", remote)
+	testutil.Git(t, tmp, "clone", remote, repo)
+	testutil.Git(t, repo, "config", "user.email", "test@example.com")
+	testutil.Git(t, repo, "config", "user.name", "Test")
+	testutil.Git(t, repo, "checkout", "-b", "main")
+	root := testutil.CommitFile(t, repo, "README.md", "hello\n")
+	testutil.Git(t, repo, "push", "origin", "main")
+	testutil.Git(t, tmp, "clone", remote, clone)
+
+	// Units land locally on main: config, then db which depends on it
+	configHead := testutil.CommitFile(t, repo, "config.go", "package config\n")
+	dbHead := testutil.CommitFile(t, repo, "db.go", "package db\n")
+
+	bus := events.NewBus(20)
+	defer bus.Close()
+	collector := events.NewEventCollector(bus)
+
+	prs := &fakePRs{t: t, clone: clone, root: "main", prs: make(map[int]*github.PRInfo)}
+	s, err := Open(ctx, Config{
+		RepoRoot:     repo,
+		WorktreeBase: filepath.Join(repo, ".ralph", "worktrees"),
+		Root:         "main",
+		BranchPrefix: "stack/",
+	}, prs, bus)
+	require.NoError(t, err)
+	assert.Equal(t, root, s.State().RootTip)
+
+	// db's range includes config's commit, as after a rebase onto origin/main
+	configEntry, err := s.Add(ctx, UnitChange{Node: Node{Unit: "config"}, BaseCommit: root, HeadCommit: configHead, Title: "config"})
+	require.NoError(t, err)
+	dbEntry, err := s.Add(ctx, UnitChange{Node: Node{Unit: "db", Parent: "config"}, BaseCommit: root, HeadCommit: dbHead, Title: "db"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "main", configEntry.Base)
+	assert.Equal(t, "stack/config", dbEntry.Base)
+	assert.Equal(t, "stack/config", prs.prs[dbEntry.PR].TargetBranch)
+
+	// db's branch has exactly one commit on top of config's
+	assert.Equal(t, "edit db.go", testutil.Git(t, repo, "log", "--format=%s", "origin/stack/config..origin/stack/db"))
+
+	// A reviewer pushes a fix to the config PR; db is restacked onto it
+	testutil.Git(t, clone, "fetch", "origin")
+	testutil.Git(t, clone, "checkout", "-B", "stack/config", "origin/stack/config")
+	testutil.CommitFile(t, clone, "config.go", "package config\n\n// fixed\n")
+	testutil.Git(t, clone, "push", "origin", "stack/config")
+
+	restacked, err := s.Restack(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db"}, restacked)
+	assert.Contains(t, testutil.Git(t, repo, "show", "origin/stack/db:config.go"), "// fixed")
+
+	// Merging lands config, retargets db onto main, then lands db
+	merged, err := s.Merge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"config", "db"}, merged)
+	assert.Equal(t, []int{configEntry.PR, dbEntry.PR}, prs.merged)
+	assert.Equal(t, []string{fmt.Sprintf("#%d->main", dbEntry.PR)}, prs.bases)
+
+	testutil.Git(t, clone, "fetch", "origin")
+	assert.Equal(t, "db\nconfig\nedit README.md", testutil.Git(t, clone, "log", "--format=%s", "origin/main"))
+
+	// State survives reopening
+	reopened, err := Open(ctx, Config{
+		RepoRoot:     repo,
+		WorktreeBase: filepath.Join(repo, ".ralph", "worktrees"),
+		Root:         "main",
+		BranchPrefix: "stack/",
+	}, prs, nil)
+	require.NoError(t, err)
+	state := reopened.State()
+	require.Len(t, state.Entries, 2)
+	assert.True(t, state.Entries[0].Merged)
+	assert.True(t, state.Entries[1].Merged)
+
+	bus.Wait()
+	var types []events.EventType
+	for _, e := range collector.Get() {
+		types = append(types, e.Type)
+	}
+	assert.Equal(t, []events.EventType{
+		events.StackPROpened, events.StackPROpened,
+		events.StackRestacked,
+		events.StackPRMerged, events.StackRestacked, events.StackPRMerged,
+	}, types)
+}
+
+func TestStack_MergeWaitsForParent(t *testing.T) {
+	prs := &fakePRs{prs: map[int]*github.PRInfo{}, root: "main"}
+	s := &Stack{prs: prs, state: &State{
+		Root: "main",
+		Entries: []*Entry{
+			{Unit: "a", Base: "main", PR: 1, Merged: true},
+			{Unit: "b", Parent: "a", Base: "stack/a", PR: 2},
+			{Unit: "c", Extra: []string{"b"}, Base: "main", PR: 3},
+			{Unit: "d", Parent: "a", Base: "main", PR: 4},
+		},
+	}}
+
+	// b still targets its parent's branch and c waits for b
+	assert.Equal(t, "d", s.nextMergeable().Unit)
+}
@@ -0,0 +1,105 @@
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// stateDir is the directory under the worktree base holding stack state
+const stateDir = "stacks"
+
+// Entry is one unit's branch and PR in the stack
+type Entry struct {
+	Unit   string   `json:"unit"`
+	Parent string   `json:"parent,omitempty"`
+	Extra  []string `json:"extra,omitempty"`
+	Branch string   `json:"branch"`
+
+	// Base is the branch the PR currently targets: the parent's branch, or
+	// the stack root once the parent has merged
+	Base string `json:"base"`
+
+	// BaseTip is the base commit the branch was last built or rebased onto.
+	// Restacking replays BaseTip..Tip onto the base's new tip.
+	BaseTip string `json:"base_tip"`
+
+	// Tip is the last commit pushed to Branch
+	Tip string `json:"tip"`
+
+	PR     int    `json:"pr,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Merged bool   `json:"merged,omitempty"`
+}
+
+// State is the persisted stack for one root branch
+type State struct {
+	// Root is the branch the bottom of the stack targets
+	Root string `json:"root"`
+
+	// RootTip is the root commit units are stacked on until the root moves
+	RootTip string `json:"root_tip"`
+
+	// Entries are in topological order
+	Entries []*Entry `json:"entries"`
+}
+
+// StatePath returns where the stack for root is stored under worktreeBase
+func StatePath(worktreeBase, root string) string {
+	return filepath.Join(worktreeBase, stateDir, strings.ReplaceAll(root, "/", "-")+".json")
+}
+
+// ListStates returns the paths of all stacks stored under worktreeBase
+func ListStates(worktreeBase string) ([]string, error) {
+	return filepath.Glob(filepath.Join(worktreeBase, stateDir, "*.json"))
+}
+
+// LoadState reads a stack from path. A missing file returns nil, nil.
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stack state: %w", err)
+	}
+
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse stack state %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Save writes the stack to path atomically
+func (s *State) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create stack state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal stack state: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write stack state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write stack state: %w", err)
+	}
+	return nil
+}
+
+// Entry returns the entry for a unit, or nil if the unit is not stacked
+func (s *State) Entry(unit string) *Entry {
+	for _, e := range s.Entries {
+		if e.Unit == unit {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package telemetry
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics counts jobs, units and tasks by status and measures provider
+// invocations, backpressure checks and merge conflicts, for Prometheus.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	jobsStarted  prometheus.Counter
+	jobsFinished *prometheus.CounterVec
+	jobsRunning  prometheus.Gauge
+	units        *prometheus.CounterVec
+	unitsRunning prometheus.Gauge
+	tasks        *prometheus.CounterVec
+
+	providerDuration     *prometheus.HistogramVec
+	backpressureChecks   *prometheus.CounterVec
+	backpressureDuration prometheus.Histogram
+	mergeConflicts       prometheus.Counter
+	conflictResolutions  *prometheus.CounterVec
+
+	mu         sync.Mutex
+	jobs       map[string]*jobMetrics
+	queueDepth func() int
+}
+
+// jobMetrics tracks what has been counted for a running job. The
+// scheduler and workers both report unit transitions, so units are
+// counted on their first report only.
+type jobMetrics struct {
+	units        map[string]string // Unit ID -> last counted status
+	tasks        map[taskKey]bool  // Tasks counted as started
+	invocations  map[string]providerInvocation
+	backpressure map[taskKey]time.Time
+}
+
+// taskKey identifies a task within a job
+type taskKey struct {
+	unit string
+	task int
+}
+
+// providerInvocation is a provider invocation in progress for a unit
+type providerInvocation struct {
+	provider string
+	started  time.Time
+}
+
+// Unit and task statuses used as metric labels
+const (
+	statusStarted   = "started"
+	statusCompleted = "completed"
+	statusFailed    = "failed"
+	statusBlocked   = "blocked"
+	statusRetried   = "retried"
+)
+
+// NewMetrics creates the metrics in a registry of their own, along with
+// the Go runtime and process collectors.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		jobs:     make(map[string]*jobMetrics),
+
+		jobsStarted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "choo_jobs_started_total",
+			Help: "Jobs started.",
+		}),
+		jobsFinished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "choo_jobs_finished_total",
+			Help: "Jobs finished, by status.",
+		}, []string{"status"}),
+		jobsRunning: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "choo_jobs_running",
+			Help: "Jobs running.",
+		}),
+		units: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "choo_units_total",
+			Help: "Unit transitions, by status.",
+		}, []string{"status"}),
+		unitsRunning: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "choo_units_running",
+			Help: "Units running across all jobs.",
+		}),
+		tasks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "choo_tasks_total",
+			Help: "Task transitions, by status.",
+		}, []string{"status"}),
+		providerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "choo_provider_invocation_duration_seconds",
+			Help:    "Duration of provider invocations, by provider and outcome.",
+			Buckets: []float64{10, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+		}, []string{"provider", "outcome"}),
+		backpressureChecks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "choo_backpressure_checks_total",
+			Help: "Backpressure checks of completed tasks, by result.",
+		}, []string{"result"}),
+		backpressureDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "choo_backpressure_duration_seconds",
+			Help:    "Duration of backpressure checks.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+		}),
+		mergeConflicts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "choo_merge_conflicts_total",
+			Help: "Merges of unit branches that hit conflicts.",
+		}),
+		conflictResolutions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "choo_conflict_resolutions_total",
+			Help: "Agent conflict resolutions, by result.",
+		}, []string{"result"}),
+	}
+
+	m.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		m.jobsStarted, m.jobsFinished, m.jobsRunning,
+		m.units, m.unitsRunning, m.tasks,
+		m.providerDuration, m.backpressureChecks, m.backpressureDuration,
+		m.mergeConflicts, m.conflictResolutions,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "choo_queue_depth",
+			Help: "Jobs waiting in the daemon's queue.",
+		}, m.readQueueDepth),
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// SetQueueDepth sets the function reporting how many jobs are queued.
+func (m *Metrics) SetQueueDepth(fn func() int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queueDepth = fn
+}
+
+// readQueueDepth reports the queue depth, or 0 without a queue
+func (m *Metrics) readQueueDepth() float64 {
+	m.mu.Lock()
+	fn := m.queueDepth
+	m.mu.Unlock()
+	if fn == nil {
+		return 0
+	}
+	return float64(fn())
+}
+
+// JobStarted counts a job as started and running.
+func (m *Metrics) JobStarted(job Job) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.jobs[job.ID] = &jobMetrics{
+		units:        make(map[string]string),
+		tasks:        make(map[taskKey]bool),
+		invocations:  make(map[string]providerInvocation),
+		backpressure: make(map[taskKey]time.Time),
+	}
+	m.jobsStarted.Inc()
+	m.jobsRunning.Inc()
+}
+
+// JobFinished counts a job as finished with status.
+func (m *Metrics) JobFinished(jobID string, status string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[jobID]
+	if !ok {
+		return
+	}
+	for _, s := range job.units {
+		if s == statusStarted {
+			m.unitsRunning.Dec()
+		}
+	}
+	delete(m.jobs, jobID)
+	m.jobsFinished.WithLabelValues(status).Inc()
+	m.jobsRunning.Dec()
+}
+
+// JobEvent updates the metrics an event of a running job affects.
+func (m *Metrics) JobEvent(jobID string, e events.Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[jobID]
+	if !ok {
+		return
+	}
+
+	switch e.Type {
+	case events.UnitStarted:
+		if _, seen := job.units[e.Unit]; !seen {
+			job.units[e.Unit] = statusStarted
+			m.units.WithLabelValues(statusStarted).Inc()
+			m.unitsRunning.Inc()
+		}
+	case events.UnitCompleted:
+		m.finishUnit(job, e.Unit, statusCompleted)
+	case events.UnitFailed:
+		m.finishUnit(job, e.Unit, statusFailed)
+	case events.UnitBlocked:
+		m.finishUnit(job, e.Unit, statusBlocked)
+
+	case events.TaskStarted:
+		if key, ok := taskKeyOf(e); ok && !job.tasks[key] {
+			job.tasks[key] = true
+			m.tasks.WithLabelValues(statusStarted).Inc()
+		}
+	case events.TaskCompleted:
+		m.tasks.WithLabelValues(statusCompleted).Inc()
+	case events.TaskFailed:
+		m.tasks.WithLabelValues(statusFailed).Inc()
+	case events.TaskRetry:
+		m.tasks.WithLabelValues(statusRetried).Inc()
+
+	case events.TaskClaudeInvoke:
+		job.invocations[e.Unit] = providerInvocation{provider: payloadString(e, "provider"), started: e.Time}
+	case events.TaskClaudeDone:
+		if inv, ok := job.invocations[e.Unit]; ok {
+			delete(job.invocations, e.Unit)
+			provider := inv.provider
+			if provider == "" {
+				provider = "unknown"
+			}
+			outcome := "success"
+			if e.Error != "" {
+				outcome = "error"
+			}
+			m.providerDuration.WithLabelValues(provider, outcome).Observe(e.Time.Sub(inv.started).Seconds())
+		}
+
+	case events.TaskBackpressure:
+		if key, ok := taskKeyOf(e); ok {
+			job.backpressure[key] = e.Time
+		}
+	case events.TaskValidationOK, events.TaskValidationFail:
+		result := "pass"
+		if e.Type == events.TaskValidationFail {
+			result = "fail"
+		}
+		m.backpressureChecks.WithLabelValues(result).Inc()
+		if key, ok := taskKeyOf(e); ok {
+			if started, ok := job.backpressure[key]; ok {
+				delete(job.backpressure, key)
+				m.backpressureDuration.Observe(e.Time.Sub(started).Seconds())
+			}
+		}
+
+	case events.PRConflict:
+		m.mergeConflicts.Inc()
+	case events.ConflictResolved:
+		m.conflictResolutions.WithLabelValues("resolved").Inc()
+	case events.ConflictVerificationFailed:
+		m.conflictResolutions.WithLabelValues("verification_failed").Inc()
+	}
+}
+
+// finishUnit counts a unit's first terminal transition
+func (m *Metrics) finishUnit(job *jobMetrics, unit, status string) {
+	prev, seen := job.units[unit]
+	if seen && prev != statusStarted {
+		return
+	}
+	job.units[unit] = status
+	m.units.WithLabelValues(status).Inc()
+	if prev == statusStarted {
+		m.unitsRunning.Dec()
+	}
+}
+
+// taskKeyOf returns the task an event is about, if any
+func taskKeyOf(e events.Event) (taskKey, bool) {
+	if e.Task == nil {
+		return taskKey{}, false
+	}
+	return taskKey{unit: e.Unit, task: *e.Task}, true
+}
+
+// payloadString returns a string field of an event's payload
+func payloadString(e events.Event, key string) string {
+	payload, ok := e.Payload.(map[string]any)
+	if !ok {
+		return ""
+	}
+	s, _ := payload[key].(string)
+	return s
+}
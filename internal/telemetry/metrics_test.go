@@ -0,0 +1,101 @@
+package telemetry
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/events"
+)
+
+// scrape returns the metrics as served to Prometheus
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+// event builds an event of a unit at a time, with an optional task
+func event(typ events.EventType, unit string, task int, at time.Time) events.Event {
+	e := events.NewEvent(typ, unit)
+	if task > 0 {
+		e = e.WithTask(task)
+	}
+	e.Time = at
+	return e
+}
+
+func TestMetrics_JobLifecycle(t *testing.T) {
+	m := NewMetrics()
+	m.SetQueueDepth(func() int { return 3 })
+	start := time.Now()
+
+	m.JobStarted(Job{ID: "job-1", RepoPath: "/repo", TargetBranch: "main"})
+	for _, e := range []events.Event{
+		// Scheduler and worker both report unit transitions
+		event(events.UnitStarted, "a", 0, start),
+		event(events.UnitStarted, "a", 0, start),
+		event(events.TaskStarted, "a", 1, start),
+		event(events.TaskClaudeInvoke, "a", 1, start).WithPayload(map[string]any{"provider": "claude"}),
+		event(events.TaskClaudeDone, "a", 1, start.Add(90*time.Second)),
+		event(events.TaskBackpressure, "a", 1, start.Add(90*time.Second)),
+		event(events.TaskValidationFail, "a", 1, start.Add(100*time.Second)),
+		event(events.TaskRetry, "a", 1, start.Add(100*time.Second)),
+		event(events.TaskStarted, "a", 1, start.Add(100*time.Second)),
+		event(events.TaskBackpressure, "a", 1, start.Add(200*time.Second)),
+		event(events.TaskValidationOK, "a", 1, start.Add(210*time.Second)),
+		event(events.TaskCompleted, "a", 1, start.Add(210*time.Second)),
+		event(events.PRConflict, "a", 0, start.Add(220*time.Second)),
+		event(events.UnitCompleted, "a", 0, start.Add(230*time.Second)),
+		event(events.UnitCompleted, "a", 0, start.Add(230*time.Second)),
+		event(events.UnitStarted, "b", 0, start),
+	} {
+		m.JobEvent("job-1", e)
+	}
+
+	running := scrape(t, m)
+	for _, want := range []string{
+		"choo_jobs_started_total 1",
+		"choo_jobs_running 1",
+		`choo_units_total{status="started"} 2`,
+		`choo_units_total{status="completed"} 1`,
+		"choo_units_running 1",
+		`choo_tasks_total{status="started"} 1`,
+		`choo_tasks_total{status="completed"} 1`,
+		`choo_tasks_total{status="retried"} 1`,
+		`choo_provider_invocation_duration_seconds_count{outcome="success",provider="claude"} 1`,
+		`choo_provider_invocation_duration_seconds_sum{outcome="success",provider="claude"} 90`,
+		`choo_backpressure_checks_total{result="fail"} 1`,
+		`choo_backpressure_checks_total{result="pass"} 1`,
+		"choo_backpressure_duration_seconds_count 2",
+		"choo_merge_conflicts_total 1",
+		"choo_queue_depth 3",
+	} {
+		if !strings.Contains(running, want+"\n") {
+			t.Errorf("Expected %q in metrics:\n%s", want, running)
+		}
+	}
+
+	m.JobFinished("job-1", JobFailed)
+	// Events after the job finished are ignored
+	m.JobEvent("job-1", event(events.UnitStarted, "c", 0, start))
+
+	finished := scrape(t, m)
+	for _, want := range []string{
+		`choo_jobs_finished_total{status="failed"} 1`,
+		"choo_jobs_running 0",
+		"choo_units_running 0",
+		`choo_units_total{status="started"} 2`,
+	} {
+		if !strings.Contains(finished, want+"\n") {
+			t.Errorf("Expected %q in metrics:\n%s", want, finished)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Package telemetry derives Prometheus metrics and OpenTelemetry traces from
+// the events of the jobs a daemon runs.
+package telemetry
+
+import (
+	"github.com/RevCBH/choo/internal/events"
+)
+
+// Job statuses passed to Observer.JobFinished
+const (
+	JobCompleted = "completed"
+	JobFailed    = "failed"
+	JobCancelled = "cancelled"
+)
+
+// Job describes a job as it starts
+type Job struct {
+	ID            string
+	RepoPath      string
+	FeatureBranch string
+	TargetBranch  string
+	Workspace     string
+}
+
+// Observer receives the lifecycle and events of every job a daemon runs.
+// Events of a job arrive in order, between JobStarted and JobFinished;
+// events of different jobs may arrive concurrently.
+type Observer interface {
+	JobStarted(job Job)
+	JobEvent(jobID string, e events.Event)
+	JobFinished(jobID string, status string)
+}
@@ -0,0 +1,329 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/events"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of choo's spans
+const tracerName = "github.com/RevCBH/choo"
+
+// Span names, from the run down to the steps of a task
+const (
+	spanRun          = "choo.run"
+	spanUnit         = "choo.unit"
+	spanTask         = "choo.task"
+	spanProvider     = "choo.provider.invoke"
+	spanBackpressure = "choo.backpressure"
+	spanCommit       = "choo.commit"
+	spanMerge        = "choo.merge"
+)
+
+// NewOTLPTracerProvider creates a tracer provider that batches spans to the
+// OTLP/HTTP collector at endpoint, e.g. "http://localhost:4318". Spans are
+// sent to the endpoint's /v1/traces. Shut the provider down to flush them.
+func NewOTLPTracerProvider(ctx context.Context, endpoint string, version string) (*sdktrace.TracerProvider, error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(strings.TrimSuffix(endpoint, "/")+"/v1/traces"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res := resource.NewSchemaless(
+		attribute.String("service.name", "choo"),
+		attribute.String("service.version", version),
+	)
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	), nil
+}
+
+// Tracer turns job events into spans: a span per run, with a span per unit
+// under it and a span per task under those. Provider invocations,
+// backpressure checks and commits are spans of their task; merges are
+// spans of their unit.
+type Tracer struct {
+	tracer trace.Tracer
+
+	mu   sync.Mutex
+	runs map[string]*runTrace
+}
+
+// runTrace holds the open spans of a running job
+type runTrace struct {
+	span  trace.Span
+	ctx   context.Context
+	units map[string]*unitTrace
+}
+
+// unitTrace holds the open spans of a unit. A unit runs one provider
+// invocation at a time.
+type unitTrace struct {
+	span   trace.Span
+	ctx    context.Context
+	tasks  map[int]*taskTrace
+	invoke trace.Span
+	merge  trace.Span
+	done   bool
+}
+
+// taskTrace holds the open spans of a task
+type taskTrace struct {
+	span         trace.Span
+	ctx          context.Context
+	backpressure trace.Span
+	commit       trace.Span
+}
+
+// NewTracer creates a tracer that records spans with provider.
+func NewTracer(provider trace.TracerProvider) *Tracer {
+	return &Tracer{
+		tracer: provider.Tracer(tracerName),
+		runs:   make(map[string]*runTrace),
+	}
+}
+
+// JobStarted opens the run span of a job.
+func (t *Tracer) JobStarted(job Job) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	attrs := []attribute.KeyValue{
+		attribute.String("choo.job.id", job.ID),
+		attribute.String("choo.repo", job.RepoPath),
+		attribute.String("choo.target_branch", job.TargetBranch),
+	}
+	if job.FeatureBranch != "" {
+		attrs = append(attrs, attribute.String("choo.feature_branch", job.FeatureBranch))
+	}
+	if job.Workspace != "" {
+		attrs = append(attrs, attribute.String("choo.workspace", job.Workspace))
+	}
+
+	ctx, span := t.tracer.Start(context.Background(), spanRun, trace.WithAttributes(attrs...))
+	t.runs[job.ID] = &runTrace{span: span, ctx: ctx, units: make(map[string]*unitTrace)}
+}
+
+// JobFinished ends every open span of a job, then its run span.
+func (t *Tracer) JobFinished(jobID string, status string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	run, ok := t.runs[jobID]
+	if !ok {
+		return
+	}
+	delete(t.runs, jobID)
+
+	now := time.Now()
+	for _, unit := range run.units {
+		endUnit(unit, now, fmt.Sprintf("job %s", status))
+	}
+	run.span.SetAttributes(attribute.String("choo.status", status))
+	if status != JobCompleted {
+		run.span.SetStatus(codes.Error, "job "+status)
+	}
+	run.span.End(trace.WithTimestamp(now))
+}
+
+// JobEvent opens or ends the spans an event of a running job marks.
+func (t *Tracer) JobEvent(jobID string, e events.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	run, ok := t.runs[jobID]
+	if !ok {
+		return
+	}
+
+	switch e.Type {
+	case events.UnitStarted:
+		t.unit(run, e)
+
+	case events.UnitCompleted, events.UnitFailed, events.UnitBlocked:
+		unit, ok := run.units[e.Unit]
+		if !ok || unit.done {
+			return
+		}
+		if e.Type == events.UnitCompleted {
+			endUnit(unit, e.Time, "")
+		} else {
+			reason := e.Error
+			if reason == "" {
+				reason = string(e.Type)
+			}
+			endUnit(unit, e.Time, reason)
+		}
+
+	case events.UnitMerging:
+		unit := t.unit(run, e)
+		if unit.merge == nil {
+			_, unit.merge = t.start(unit.ctx, spanMerge, e.Time)
+		}
+	case events.PRConflict:
+		if unit, ok := run.units[e.Unit]; ok {
+			span := unit.span
+			if unit.merge != nil {
+				span = unit.merge
+			}
+			span.AddEvent("conflict", trace.WithTimestamp(e.Time))
+		}
+	case events.UnitMerged:
+		if unit, ok := run.units[e.Unit]; ok && unit.merge != nil {
+			unit.merge.End(trace.WithTimestamp(e.Time))
+			unit.merge = nil
+		}
+
+	case events.TaskStarted:
+		if task := t.task(run, e); task != nil {
+			if title := payloadString(e, "title"); title != "" {
+				task.span.SetAttributes(attribute.String("choo.task.title", title))
+			}
+		}
+
+	case events.TaskClaudeInvoke:
+		unit := t.unit(run, e)
+		parent := unit.ctx
+		if task := t.task(run, e); task != nil {
+			parent = task.ctx
+		}
+		endSpan(unit.invoke, e.Time, "superseded")
+		_, unit.invoke = t.start(parent, spanProvider, e.Time,
+			attribute.String("choo.provider", payloadString(e, "provider")))
+	case events.TaskClaudeDone:
+		if unit, ok := run.units[e.Unit]; ok && unit.invoke != nil {
+			endSpan(unit.invoke, e.Time, e.Error)
+			unit.invoke = nil
+		}
+
+	case events.TaskBackpressure:
+		if task := t.task(run, e); task != nil {
+			endSpan(task.backpressure, e.Time, "superseded")
+			_, task.backpressure = t.start(task.ctx, spanBackpressure, e.Time)
+		}
+	case events.TaskValidationOK, events.TaskValidationFail:
+		if task := t.openTask(run, e); task != nil && task.backpressure != nil {
+			reason := ""
+			if e.Type == events.TaskValidationFail {
+				reason = "backpressure failed"
+			}
+			endSpan(task.backpressure, e.Time, reason)
+			task.backpressure = nil
+		}
+
+	case events.TaskCompleted:
+		if task := t.task(run, e); task != nil && task.commit == nil {
+			_, task.commit = t.start(task.ctx, spanCommit, e.Time)
+		}
+	case events.TaskCommitted:
+		if task := t.openTask(run, e); task != nil {
+			endSpan(task.commit, e.Time, "")
+			task.commit = nil
+			endTask(run.units[e.Unit], *e.Task, e.Time, "")
+		}
+	case events.TaskFailed:
+		if task := t.openTask(run, e); task != nil {
+			reason := e.Error
+			if reason == "" {
+				reason = "task failed"
+			}
+			endTask(run.units[e.Unit], *e.Task, e.Time, reason)
+		}
+	}
+}
+
+// unit returns the open span of an event's unit, starting it if needed
+func (t *Tracer) unit(run *runTrace, e events.Event) *unitTrace {
+	if unit, ok := run.units[e.Unit]; ok && !unit.done {
+		return unit
+	}
+	ctx, span := t.start(run.ctx, spanUnit, e.Time, attribute.String("choo.unit.id", e.Unit))
+	unit := &unitTrace{span: span, ctx: ctx, tasks: make(map[int]*taskTrace)}
+	run.units[e.Unit] = unit
+	return unit
+}
+
+// task returns the open span of an event's task, starting it (and its
+// unit's) if needed. Returns nil for events without a task.
+func (t *Tracer) task(run *runTrace, e events.Event) *taskTrace {
+	if e.Task == nil {
+		return nil
+	}
+	unit := t.unit(run, e)
+	if task, ok := unit.tasks[*e.Task]; ok {
+		return task
+	}
+	ctx, span := t.start(unit.ctx, spanTask, e.Time, attribute.Int("choo.task.number", *e.Task))
+	task := &taskTrace{span: span, ctx: ctx}
+	unit.tasks[*e.Task] = task
+	return task
+}
+
+// openTask returns the open span of an event's task, or nil
+func (t *Tracer) openTask(run *runTrace, e events.Event) *taskTrace {
+	if e.Task == nil {
+		return nil
+	}
+	unit, ok := run.units[e.Unit]
+	if !ok || unit.done {
+		return nil
+	}
+	return unit.tasks[*e.Task]
+}
+
+// start opens a span under parent at the time of its event
+func (t *Tracer) start(parent context.Context, name string, at time.Time, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return t.tracer.Start(parent, name, trace.WithTimestamp(at), trace.WithAttributes(attrs...))
+}
+
+// endTask ends a task's open spans and the task span itself
+func endTask(unit *unitTrace, number int, at time.Time, reason string) {
+	task, ok := unit.tasks[number]
+	if !ok {
+		return
+	}
+	delete(unit.tasks, number)
+	endSpan(task.backpressure, at, reason)
+	endSpan(task.commit, at, reason)
+	endSpan(task.span, at, reason)
+}
+
+// endUnit ends a unit's open spans and the unit span itself
+func endUnit(unit *unitTrace, at time.Time, reason string) {
+	if unit.done {
+		return
+	}
+	unit.done = true
+	endSpan(unit.invoke, at, reason)
+	endSpan(unit.merge, at, reason)
+	for number := range unit.tasks {
+		endTask(unit, number, at, reason)
+	}
+	endSpan(unit.span, at, reason)
+}
+
+// endSpan ends span at the given time, marking it failed when reason is
+// set. A nil span is ignored.
+func endSpan(span trace.Span, at time.Time, reason string) {
+	if span == nil {
+		return
+	}
+	if reason != "" {
+		span.SetStatus(codes.Error, reason)
+	}
+	span.End(trace.WithTimestamp(at))
+}
@@ -0,0 +1,176 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/events"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// unitEvents are the events of a unit whose single task passes
+// backpressure on the second attempt and which merges after a conflict
+func unitEvents(unit string, start time.Time) []events.Event {
+	at := func(s int) time.Time { return start.Add(time.Duration(s) * time.Second) }
+	return []events.Event{
+		event(events.UnitStarted, unit, 0, at(0)),
+		event(events.TaskStarted, unit, 1, at(1)),
+		event(events.TaskClaudeInvoke, unit, 1, at(1)).WithPayload(map[string]any{"provider": "claude"}),
+		event(events.TaskClaudeDone, unit, 1, at(60)),
+		event(events.TaskBackpressure, unit, 1, at(60)),
+		event(events.TaskValidationFail, unit, 1, at(70)),
+		event(events.TaskStarted, unit, 1, at(70)),
+		event(events.TaskClaudeInvoke, unit, 1, at(70)).WithPayload(map[string]any{"provider": "claude"}),
+		event(events.TaskClaudeDone, unit, 1, at(100)),
+		event(events.TaskBackpressure, unit, 1, at(100)),
+		event(events.TaskValidationOK, unit, 1, at(110)),
+		event(events.TaskCompleted, unit, 1, at(110)),
+		event(events.TaskCommitted, unit, 1, at(111)),
+		event(events.UnitMerging, unit, 0, at(120)),
+		event(events.PRConflict, unit, 0, at(121)),
+		event(events.UnitMerged, unit, 0, at(150)),
+		event(events.UnitCompleted, unit, 0, at(151)),
+		// Reported again by the scheduler
+		event(events.UnitCompleted, unit, 0, at(151)),
+	}
+}
+
+func TestTracer_SpanHierarchy(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := NewTracer(provider)
+	start := time.Now()
+
+	tracer.JobStarted(Job{ID: "job-1", RepoPath: "/repo", TargetBranch: "main"})
+	for _, e := range unitEvents("a", start) {
+		tracer.JobEvent("job-1", e)
+	}
+	tracer.JobEvent("job-1", event(events.UnitStarted, "b", 0, start))
+	tracer.JobFinished("job-1", JobFailed)
+
+	byName := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		byName[span.Name()] = append(byName[span.Name()], span)
+	}
+	for name, count := range map[string]int{
+		spanRun: 1, spanUnit: 2, spanTask: 1, spanProvider: 2, spanBackpressure: 2, spanCommit: 1, spanMerge: 1,
+	} {
+		if len(byName[name]) != count {
+			t.Errorf("Expected %d %s span(s), got %d", count, name, len(byName[name]))
+		}
+	}
+	if t.Failed() {
+		return
+	}
+
+	run := byName[spanRun][0]
+	if run.Status().Code != codes.Error {
+		t.Errorf("Expected failed run span, got %v", run.Status())
+	}
+
+	parentOf := func(span sdktrace.ReadOnlySpan) string {
+		for _, candidates := range byName {
+			for _, c := range candidates {
+				if c.SpanContext().SpanID() == span.Parent().SpanID() {
+					return c.Name()
+				}
+			}
+		}
+		return ""
+	}
+	for name, parent := range map[string]string{
+		spanUnit: spanRun, spanTask: spanUnit, spanProvider: spanTask,
+		spanBackpressure: spanTask, spanCommit: spanTask, spanMerge: spanUnit,
+	} {
+		for _, span := range byName[name] {
+			if got := parentOf(span); got != parent {
+				t.Errorf("Expected %s under %s, got %q", name, parent, got)
+			}
+		}
+	}
+
+	task := byName[spanTask][0]
+	if !task.StartTime().Equal(start.Add(time.Second)) || !task.EndTime().Equal(start.Add(111*time.Second)) {
+		t.Errorf("Expected task span from 1s to 111s, got %s to %s", task.StartTime(), task.EndTime())
+	}
+	if failed := byName[spanBackpressure][0]; failed.Status().Code != codes.Error {
+		t.Errorf("Expected the first backpressure span to fail, got %v", failed.Status())
+	}
+	merge := byName[spanMerge][0]
+	if len(merge.Events()) != 1 || merge.Events()[0].Name != "conflict" {
+		t.Errorf("Expected a conflict event on the merge span, got %v", merge.Events())
+	}
+
+	// The unit still running when the job ended is ended with it
+	for _, unit := range byName[spanUnit] {
+		if unit.EndTime().Before(unit.StartTime()) {
+			t.Errorf("Unit span ends before it starts: %v", unit)
+		}
+	}
+}
+
+func TestNewOTLPTracerProvider_ExportsToCollector(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		names []string
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			http.NotFound(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var req collectortrace.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					names = append(names, span.Name)
+				}
+			}
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		out, _ := proto.Marshal(&collectortrace.ExportTraceServiceResponse{})
+		_, _ = w.Write(out)
+	}))
+	defer collector.Close()
+
+	provider, err := NewOTLPTracerProvider(context.Background(), collector.URL, "test")
+	if err != nil {
+		t.Fatalf("NewOTLPTracerProvider: %v", err)
+	}
+	tracer := NewTracer(provider)
+	tracer.JobStarted(Job{ID: "job-1", RepoPath: "/repo", TargetBranch: "main"})
+	for _, e := range unitEvents("a", time.Now()) {
+		tracer.JobEvent("job-1", e)
+	}
+	tracer.JobFinished("job-1", JobCompleted)
+
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(names) != 9 {
+		t.Errorf("Expected 9 spans at the collector, got %d: %v", len(names), names)
+	}
+}
@@ -0,0 +1,245 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// JobControl starts and steers jobs for the control endpoints. The daemon
+// implements it; without one the UI is read-only.
+type JobControl interface {
+	// ListJobs returns the jobs, most recent first
+	ListJobs(ctx context.Context) ([]JobInfo, error)
+
+	// StartJob starts a job, returning its ID
+	StartJob(ctx context.Context, req StartJobRequest) (string, error)
+
+	// StopJob cancels a running job
+	StopJob(ctx context.Context, jobID string, force bool) error
+
+	// PauseJob pauses or resumes a running job
+	PauseJob(ctx context.Context, jobID string, paused bool) error
+
+	// RetryJob runs a finished job again with opts, in the job's workspace
+	// if it ran in one. The retry starts now if a slot is free and is
+	// queued otherwise. Units that completed are not run again.
+	RetryJob(ctx context.Context, jobID string, opts RunOptions) (JobResponse, error)
+
+	// RetryUnit runs a failed unit of a finished job again with opts,
+	// returning the new job's ID
+	RetryUnit(ctx context.Context, jobID, unitID string, opts RunOptions) (string, error)
+}
+
+// JobHistory reads the jobs the daemon has recorded, so runs can be viewed
+// after they finish and after the server restarts.
+type JobHistory interface {
+	// ListJobs returns the jobs, most recent first
+	ListJobs(ctx context.Context) ([]JobInfo, error)
+
+	// JobEvents returns a job's recorded events, oldest first
+	JobEvents(ctx context.Context, jobID string) ([]*Event, error)
+}
+
+// JobLister lists jobs; both JobControl and JobHistory do
+type JobLister interface {
+	ListJobs(ctx context.Context) ([]JobInfo, error)
+}
+
+// Errors a JobControl wraps to choose the response's status code
+var (
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrJobNotFound    = errors.New("job not found")
+	ErrJobConflict    = errors.New("job conflict")
+)
+
+// maxControlBody bounds the request bodies of the control endpoints
+const maxControlBody = 64 << 10
+
+// ControlInfoHandler tells the UI whether jobs can be controlled from it.
+// GET /api/control
+func ControlInfoHandler(control JobControl) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, ControlInfo{Enabled: control != nil})
+	}
+}
+
+// JobsHandler lists the jobs jobs knows of, with the state of those the
+// server has seen events for, and the runs it has seen that jobs does not
+// list. jobs may be nil to list only runs.
+// GET /api/jobs
+func JobsHandler(jobs JobLister, runs *Runs) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var listed []JobInfo
+		if jobs != nil {
+			var err error
+			if listed, err = jobs.ListJobs(r.Context()); err != nil {
+				writeControlError(w, err)
+				return
+			}
+		}
+		writeJSON(w, http.StatusOK, mergeJobs(listed, runs.List()))
+	}
+}
+
+// StartJobHandler starts a job from a tasks directory or a PRD.
+// POST /api/jobs
+func StartJobHandler(control JobControl) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req StartJobRequest
+		if !decodeControlRequest(w, r, &req) {
+			return
+		}
+		if req.Workspace == "" && req.RepoPath == "" {
+			writeControlError(w, fmt.Errorf("%w: a workspace or repository path is required", ErrInvalidRequest))
+			return
+		}
+		if req.Parallelism < 0 {
+			writeControlError(w, fmt.Errorf("%w: parallelism must not be negative", ErrInvalidRequest))
+			return
+		}
+		jobID, err := control.StartJob(r.Context(), req)
+		if err != nil {
+			writeControlError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, JobResponse{JobID: jobID})
+	}
+}
+
+// StopJobHandler cancels a running job.
+// POST /api/jobs/{id}/stop
+func StopJobHandler(control JobControl) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req StopJobRequest
+		if !decodeControlRequest(w, r, &req) {
+			return
+		}
+		jobID := r.PathValue("id")
+		if err := control.StopJob(r.Context(), jobID, req.Force); err != nil {
+			writeControlError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, JobResponse{JobID: jobID})
+	}
+}
+
+// PauseJobHandler pauses a running job, or resumes it when paused is false.
+// POST /api/jobs/{id}/pause and POST /api/jobs/{id}/resume
+func PauseJobHandler(control JobControl, paused bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID := r.PathValue("id")
+		if err := control.PauseJob(r.Context(), jobID, paused); err != nil {
+			writeControlError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, JobResponse{JobID: jobID})
+	}
+}
+
+// RetryJobHandler runs a failed or cancelled job again, optionally with
+// other run options. A retry that has to wait for a slot is accepted with
+// its queue entry.
+// POST /api/jobs/{id}/retry
+func RetryJobHandler(control JobControl) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, ok := decodeRunOptions(w, r)
+		if !ok {
+			return
+		}
+		resp, err := control.RetryJob(r.Context(), r.PathValue("id"), opts)
+		if err != nil {
+			writeControlError(w, err)
+			return
+		}
+		if resp.JobID == "" {
+			writeJSON(w, http.StatusAccepted, resp)
+			return
+		}
+		writeJSON(w, http.StatusCreated, resp)
+	}
+}
+
+// RetryUnitHandler runs a failed unit of a failed or cancelled job again,
+// optionally with other run options.
+// POST /api/jobs/{id}/units/{unit}/retry
+func RetryUnitHandler(control JobControl) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, ok := decodeRunOptions(w, r)
+		if !ok {
+			return
+		}
+		jobID, err := control.RetryUnit(r.Context(), r.PathValue("id"), r.PathValue("unit"), opts)
+		if err != nil {
+			writeControlError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, JobResponse{JobID: jobID})
+	}
+}
+
+// decodeRunOptions reads the run options of a retry, writing the error
+// response if they are invalid
+func decodeRunOptions(w http.ResponseWriter, r *http.Request) (RunOptions, bool) {
+	var opts RunOptions
+	if !decodeControlRequest(w, r, &opts) {
+		return opts, false
+	}
+	if opts.Parallelism < 0 {
+		writeControlError(w, fmt.Errorf("%w: parallelism must not be negative", ErrInvalidRequest))
+		return opts, false
+	}
+	return opts, true
+}
+
+// RequireAuthorization admits requests authorize accepts. A nil authorize
+// admits none, so control is never open by accident.
+func RequireAuthorization(authorize func(r *http.Request) error, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authorize == nil {
+			writeJSON(w, http.StatusUnauthorized, ErrorResponse{Error: "job control is not enabled"})
+			return
+		}
+		if err := authorize(r); err != nil {
+			writeJSON(w, http.StatusUnauthorized, ErrorResponse{Error: err.Error()})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// decodeControlRequest decodes a request's JSON body into v. An empty body
+// leaves v as is. Writes the error response and returns false on failure.
+func decodeControlRequest(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxControlBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+// writeControlError writes err with the status code its kind calls for
+func writeControlError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidRequest):
+		code = http.StatusBadRequest
+	case errors.Is(err, ErrJobNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, ErrJobConflict):
+		code = http.StatusConflict
+	}
+	writeJSON(w, code, ErrorResponse{Error: err.Error()})
+}
+
+// writeJSON writes v as the JSON response with status code
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
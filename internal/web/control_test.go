@@ -0,0 +1,256 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeControl records the calls of the control endpoints
+type fakeControl struct {
+	jobs  []JobInfo
+	err   error
+	calls []string
+
+	started StartJobRequest
+	retried RunOptions
+	queue   bool // retries are queued
+}
+
+func (c *fakeControl) ListJobs(ctx context.Context) ([]JobInfo, error) {
+	return c.jobs, c.err
+}
+
+func (c *fakeControl) StartJob(ctx context.Context, req StartJobRequest) (string, error) {
+	c.calls = append(c.calls, "start")
+	c.started = req
+	return "job-new", c.err
+}
+
+func (c *fakeControl) StopJob(ctx context.Context, jobID string, force bool) error {
+	c.calls = append(c.calls, fmt.Sprintf("stop %s force=%v", jobID, force))
+	return c.err
+}
+
+func (c *fakeControl) PauseJob(ctx context.Context, jobID string, paused bool) error {
+	c.calls = append(c.calls, fmt.Sprintf("pause %s %v", jobID, paused))
+	return c.err
+}
+
+func (c *fakeControl) RetryJob(ctx context.Context, jobID string, opts RunOptions) (JobResponse, error) {
+	c.calls = append(c.calls, "retry "+jobID)
+	c.retried = opts
+	if c.queue {
+		return JobResponse{QueueID: "entry-1"}, c.err
+	}
+	return JobResponse{JobID: "job-retry"}, c.err
+}
+
+func (c *fakeControl) RetryUnit(ctx context.Context, jobID, unitID string, opts RunOptions) (string, error) {
+	c.calls = append(c.calls, "retry "+jobID+" unit "+unitID)
+	c.retried = opts
+	return "job-retry", c.err
+}
+
+// tokenAuth admits requests bearing token
+func tokenAuth(token string) func(r *http.Request) error {
+	return func(r *http.Request) error {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			return errors.New("a control token is required")
+		}
+		return nil
+	}
+}
+
+// newControlHandler returns the HTTP handler of a server with cfg
+func newControlHandler(t *testing.T, cfg Config) http.Handler {
+	t.Helper()
+	cfg.SocketPath = t.TempDir() + "/web.sock"
+	srv, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return srv.httpServer.Handler
+}
+
+// do sends a request to h, with the control token when authorized
+func do(h http.Handler, method, path, body string, authorized bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if authorized {
+		req.Header.Set("Authorization", "Bearer secret")
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestControl_Info(t *testing.T) {
+	tests := []struct {
+		name    string
+		control JobControl
+		want    bool
+	}{
+		{"read-only", nil, false},
+		{"enabled", &fakeControl{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newControlHandler(t, Config{Control: tt.control})
+			w := do(h, "GET", "/api/control", "", false)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", w.Code)
+			}
+			var info ControlInfo
+			if err := json.NewDecoder(w.Body).Decode(&info); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if info.Enabled != tt.want {
+				t.Errorf("expected enabled=%v, got %v", tt.want, info.Enabled)
+			}
+		})
+	}
+}
+
+func TestControl_RequiresAuthorization(t *testing.T) {
+	control := &fakeControl{jobs: []JobInfo{{ID: "job-1", Status: "running"}}}
+
+	// Without an authorizer nothing is admitted
+	h := newControlHandler(t, Config{Control: control})
+	if w := do(h, "POST", "/api/jobs/job-1/stop", "", true); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without an authorizer, got %d", w.Code)
+	}
+
+	h = newControlHandler(t, Config{Control: control, Authorize: tokenAuth("secret")})
+	for _, path := range []string{"/api/jobs", "/api/jobs/job-1/stop", "/api/jobs/job-1/pause", "/api/jobs/job-1/resume", "/api/jobs/job-1/retry"} {
+		if w := do(h, "POST", path, "", false); w.Code != http.StatusUnauthorized {
+			t.Errorf("POST %s: expected status 401, got %d", path, w.Code)
+		}
+	}
+	if len(control.calls) != 0 {
+		t.Errorf("expected no calls, got %v", control.calls)
+	}
+
+	// Listing jobs is as open as the rest of the UI
+	w := do(h, "GET", "/api/jobs", "", false)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var jobs []JobInfo
+	if err := json.NewDecoder(w.Body).Decode(&jobs); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != "job-1" {
+		t.Errorf("unexpected jobs: %+v", jobs)
+	}
+}
+
+func TestControl_StartJob(t *testing.T) {
+	control := &fakeControl{}
+	h := newControlHandler(t, Config{Control: control, Authorize: tokenAuth("secret")})
+
+	w := do(h, "POST", "/api/jobs", `{"workspace":"api","prd":"auth","targetBranch":"develop","parallelism":2}`, true)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body)
+	}
+	var resp JobResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.JobID != "job-new" {
+		t.Errorf("expected job ID job-new, got %s", resp.JobID)
+	}
+	want := StartJobRequest{Workspace: "api", PRD: "auth", RunOptions: RunOptions{TargetBranch: "develop", Parallelism: 2}}
+	if !reflect.DeepEqual(control.started, want) {
+		t.Errorf("started %+v, want %+v", control.started, want)
+	}
+
+	for _, body := range []string{
+		`{"tasksDir":"specs/tasks"}`,            // nowhere to run
+		`{"repoPath":"/repo","parallelism":-1}`, // bad option
+		`{"repoPath":"/repo","branch":"typo"}`,  // unknown field
+		`not json`,
+	} {
+		if w := do(h, "POST", "/api/jobs", body, true); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestControl_JobActions(t *testing.T) {
+	control := &fakeControl{}
+	h := newControlHandler(t, Config{Control: control, Authorize: tokenAuth("secret")})
+
+	requests := []struct {
+		path, body string
+		code       int
+	}{
+		{"/api/jobs/job-1/pause", "", http.StatusOK},
+		{"/api/jobs/job-1/resume", "", http.StatusOK},
+		{"/api/jobs/job-1/stop", `{"force":true}`, http.StatusOK},
+		{"/api/jobs/job-1/units/app/retry", "", http.StatusCreated},
+		{"/api/jobs/job-1/retry", `{"parallelism":1}`, http.StatusCreated},
+	}
+	for _, r := range requests {
+		if w := do(h, "POST", r.path, r.body, true); w.Code != r.code {
+			t.Errorf("POST %s: expected status %d, got %d: %s", r.path, r.code, w.Code, w.Body)
+		}
+	}
+
+	want := []string{"pause job-1 true", "pause job-1 false", "stop job-1 force=true", "retry job-1 unit app", "retry job-1"}
+	if !reflect.DeepEqual(control.calls, want) {
+		t.Errorf("calls = %v, want %v", control.calls, want)
+	}
+	if control.retried != (RunOptions{Parallelism: 1}) {
+		t.Errorf("retried with %+v", control.retried)
+	}
+}
+
+func TestControl_QueuedRetry(t *testing.T) {
+	h := newControlHandler(t, Config{Control: &fakeControl{queue: true}, Authorize: tokenAuth("secret")})
+
+	w := do(h, "POST", "/api/jobs/job-1/retry", "", true)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d: %s", w.Code, w.Body)
+	}
+	var resp JobResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp != (JobResponse{QueueID: "entry-1"}) {
+		t.Errorf("response = %+v", resp)
+	}
+}
+
+func TestControl_ErrorStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("%w: job-1", ErrJobNotFound), http.StatusNotFound},
+		{fmt.Errorf("%w: job already stopped", ErrJobConflict), http.StatusConflict},
+		{fmt.Errorf("%w: tasks dir is required", ErrInvalidRequest), http.StatusBadRequest},
+		{errors.New("database is gone"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			h := newControlHandler(t, Config{Control: &fakeControl{err: tt.err}, Authorize: tokenAuth("secret")})
+			w := do(h, "POST", "/api/jobs/job-1/stop", "", true)
+			if w.Code != tt.code {
+				t.Errorf("expected status %d, got %d", tt.code, w.Code)
+			}
+			var resp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Error != tt.err.Error() {
+				t.Errorf("expected error %q, got %q", tt.err.Error(), resp.Error)
+			}
+		})
+	}
+}
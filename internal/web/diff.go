@@ -0,0 +1,307 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/RevCBH/choo/internal/git"
+)
+
+// maxDiffBytes bounds the diff text parsed for one response; the rest is
+// left out and the diff marked truncated
+const maxDiffBytes = 4 << 20
+
+// OpenRepo opens the git worktree at path
+type OpenRepo func(path string) (git.GitOps, error)
+
+// openWorktree opens a unit's worktree for reading
+func openWorktree(path string) (git.GitOps, error) {
+	return git.NewWorktreeGitOps(path, "")
+}
+
+// taskSubject matches the task number in a worker's commit subject,
+// e.g. "feat(web): complete task #2 - Add handlers"
+var taskSubject = regexp.MustCompile(`complete task #(\d+)`)
+
+// UnitCommitsHandler returns the commits on a unit's branch as JSON.
+// GET /api/units/{id}/commits
+// Returns 404 for units that have not started.
+func UnitCommitsHandler(store *Store, open OpenRepo) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		unitID := r.PathValue("id")
+		repo, ops, err := openUnitRepo(store, open, unitID)
+		if err != nil {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		commits, err := unitCommits(r.Context(), ops, repo)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, UnitCommits{
+			Unit:    unitID,
+			Branch:  repo.Branch,
+			Base:    repo.Base,
+			Commits: commits,
+		})
+	}
+}
+
+// UnitDiffHandler returns a unit's diff against its base as JSON, or with
+// ?commit=<hash> the diff of one of its commits.
+// GET /api/units/{id}/diff
+// Returns 404 for units that have not started and commits not on the unit's
+// branch.
+func UnitDiffHandler(store *Store, open OpenRepo) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		unitID := r.PathValue("id")
+		repo, ops, err := openUnitRepo(store, open, unitID)
+		if err != nil {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		base, head := repo.Base, "HEAD"
+		commit := r.URL.Query().Get("commit")
+		if commit != "" {
+			// Only the unit's own commits are diffed, which also keeps the
+			// request from passing arbitrary arguments to git
+			commits, err := unitCommits(r.Context(), ops, repo)
+			if err != nil {
+				writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+				return
+			}
+			if !hasCommit(commits, commit) {
+				writeJSON(w, http.StatusNotFound, ErrorResponse{Error: fmt.Sprintf("commit %s is not on unit %s", commit, unitID)})
+				return
+			}
+			base, head = commit+"^", commit
+		}
+
+		if resolved, err := ops.RevParse(r.Context(), head); err == nil {
+			head = resolved
+		}
+		text, err := ops.Diff(r.Context(), base, head)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("diff unit %s: %v", unitID, err)})
+			return
+		}
+
+		diff := UnitDiff{Unit: unitID, Base: base, Head: head, Commit: commit}
+		if len(text) > maxDiffBytes {
+			text = text[:strings.LastIndexByte(text[:maxDiffBytes], '\n')+1]
+			diff.Truncated = true
+		}
+		diff.Files = parseUnifiedDiff(text)
+		diff.Tree = buildFileTree(diff.Files)
+		writeJSON(w, http.StatusOK, diff)
+	}
+}
+
+// openUnitRepo opens the worktree of a unit that has started
+func openUnitRepo(store *Store, open OpenRepo, unitID string) (UnitRepo, git.GitOps, error) {
+	repo, ok := store.UnitRepo(unitID)
+	if !ok || repo.Worktree == "" || repo.Base == "" {
+		return repo, nil, fmt.Errorf("no changes recorded for unit %s", unitID)
+	}
+	ops, err := open(repo.Worktree)
+	if err != nil {
+		return repo, nil, fmt.Errorf("open worktree of unit %s: %w", unitID, err)
+	}
+	return repo, ops, nil
+}
+
+// unitCommits lists the commits of a unit since its base
+func unitCommits(ctx context.Context, ops git.GitOps, repo UnitRepo) ([]UnitCommit, error) {
+	records, err := ops.Log(ctx, git.LogOpts{Range: repo.Base + "..HEAD"})
+	if err != nil {
+		return nil, fmt.Errorf("list commits: %w", err)
+	}
+
+	commits := make([]UnitCommit, 0, len(records))
+	for _, rec := range records {
+		commit := UnitCommit{
+			Hash:    rec.Hash,
+			Subject: rec.Subject,
+			Author:  rec.Author,
+			Date:    rec.Date,
+		}
+		if m := taskSubject.FindStringSubmatch(rec.Subject); m != nil {
+			if n, err := strconv.Atoi(m[1]); err == nil {
+				commit.Task = &n
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// hasCommit reports whether hash, in full or abbreviated, is one of commits
+func hasCommit(commits []UnitCommit, hash string) bool {
+	if len(hash) < 7 {
+		return false
+	}
+	for _, c := range commits {
+		if strings.HasPrefix(c.Hash, hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseUnifiedDiff splits the output of git diff into files and hunks
+func parseUnifiedDiff(text string) []DiffFile {
+	files := []DiffFile{}
+	var file *DiffFile
+	var hunk *DiffHunk
+	oldLine, newLine := 0, 0
+
+	for _, line := range strings.Split(text, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			files = append(files, DiffFile{Status: "modified", Hunks: []DiffHunk{}})
+			file, hunk = &files[len(files)-1], nil
+			file.OldPath, file.Path = splitGitPaths(strings.TrimPrefix(line, "diff --git "))
+
+		case file == nil:
+			continue
+
+		case hunk == nil && strings.HasPrefix(line, "new file mode"):
+			file.Status = "added"
+		case hunk == nil && strings.HasPrefix(line, "deleted file mode"):
+			file.Status = "deleted"
+		case hunk == nil && strings.HasPrefix(line, "rename from "):
+			file.Status = "renamed"
+			file.OldPath = strings.TrimPrefix(line, "rename from ")
+		case hunk == nil && strings.HasPrefix(line, "rename to "):
+			file.Path = strings.TrimPrefix(line, "rename to ")
+		case hunk == nil && strings.HasPrefix(line, "Binary files "):
+			file.Binary = true
+		case hunk == nil && strings.HasPrefix(line, "--- "):
+			if p := diffPath(line[4:]); p != "" {
+				file.OldPath = p
+			}
+		case hunk == nil && strings.HasPrefix(line, "+++ "):
+			if p := diffPath(line[4:]); p != "" {
+				file.Path = p
+			}
+
+		case strings.HasPrefix(line, "@@ "):
+			oldLine, newLine = parseHunkHeader(line)
+			file.Hunks = append(file.Hunks, DiffHunk{Header: line, Lines: []DiffLine{}})
+			hunk = &file.Hunks[len(file.Hunks)-1]
+
+		case hunk == nil || line == "":
+			continue
+		case line[0] == '+':
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: "add", New: newLine, Text: line[1:]})
+			file.Additions++
+			newLine++
+		case line[0] == '-':
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: "delete", Old: oldLine, Text: line[1:]})
+			file.Deletions++
+			oldLine++
+		case line[0] == ' ':
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: "context", Old: oldLine, New: newLine, Text: line[1:]})
+			oldLine++
+			newLine++
+		}
+	}
+
+	// Only renamed files keep their old path
+	for i := range files {
+		switch files[i].Status {
+		case "renamed":
+		case "deleted":
+			files[i].Path, files[i].OldPath = files[i].OldPath, ""
+		default:
+			files[i].OldPath = ""
+		}
+	}
+	return files
+}
+
+// splitGitPaths splits the "a/old b/new" of a diff --git line
+func splitGitPaths(s string) (string, string) {
+	if i := strings.Index(s, " b/"); i >= 0 {
+		return strings.TrimPrefix(s[:i], "a/"), s[i+3:]
+	}
+	return s, s
+}
+
+// diffPath returns the path of a ---/+++ line, "" for /dev/null
+func diffPath(s string) string {
+	s = strings.TrimSuffix(s, "\t")
+	if s == "/dev/null" {
+		return ""
+	}
+	if len(s) > 2 && (s[:2] == "a/" || s[:2] == "b/") {
+		return s[2:]
+	}
+	return s
+}
+
+// parseHunkHeader returns the first old and new line numbers of a hunk
+// header, e.g. "@@ -12,7 +12,9 @@ func main() {"
+func parseHunkHeader(header string) (int, int) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 {
+		return 0, 0
+	}
+	start := func(field, sign string) int {
+		n, _ := strconv.Atoi(strings.SplitN(strings.TrimPrefix(field, sign), ",", 2)[0])
+		return n
+	}
+	return start(fields[1], "-"), start(fields[2], "+")
+}
+
+// buildFileTree nests files under their directories, directories first
+func buildFileTree(files []DiffFile) []*FileNode {
+	root := &FileNode{}
+	for _, f := range files {
+		node := root
+		parts := strings.Split(f.Path, "/")
+		for i, name := range parts {
+			var child *FileNode
+			for _, c := range node.Children {
+				if c.Name == name && (c.Status == "") == (i < len(parts)-1) {
+					child = c
+					break
+				}
+			}
+			if child == nil {
+				child = &FileNode{Name: name, Path: strings.Join(parts[:i+1], "/")}
+				if i == len(parts)-1 {
+					child.Status = f.Status
+				}
+				node.Children = append(node.Children, child)
+			}
+			node = child
+		}
+	}
+	sortFileTree(root.Children)
+	if root.Children == nil {
+		return []*FileNode{}
+	}
+	return root.Children
+}
+
+func sortFileTree(nodes []*FileNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		iDir, jDir := nodes[i].Status == "", nodes[j].Status == ""
+		if iDir != jDir {
+			return iDir
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	for _, n := range nodes {
+		sortFileTree(n.Children)
+	}
+}
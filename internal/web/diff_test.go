@@ -0,0 +1,248 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/git"
+)
+
+const sampleDiff = `diff --git a/internal/app/main.go b/internal/app/main.go
+index 1111111..2222222 100644
+--- a/internal/app/main.go
++++ b/internal/app/main.go
+@@ -10,4 +10,5 @@ func main() {
+ 	cfg := load()
+-	run(cfg)
++	if err := run(cfg); err != nil {
++		os.Exit(1)
++	}
+ }
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1 @@
++# New
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 4444444..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+diff --git a/internal/app/a.go b/internal/app/b.go
+similarity index 100%
+rename from internal/app/a.go
+rename to internal/app/b.go
+diff --git a/logo.png b/logo.png
+index 5555555..6666666 100644
+Binary files a/logo.png and b/logo.png differ
+`
+
+func TestParseUnifiedDiff(t *testing.T) {
+	files := parseUnifiedDiff(sampleDiff)
+	if len(files) != 5 {
+		t.Fatalf("expected 5 files, got %d", len(files))
+	}
+
+	modified := files[0]
+	if modified.Path != "internal/app/main.go" || modified.Status != "modified" || modified.OldPath != "" {
+		t.Errorf("unexpected modified file %+v", modified)
+	}
+	if modified.Additions != 3 || modified.Deletions != 1 {
+		t.Errorf("expected +3 -1, got +%d -%d", modified.Additions, modified.Deletions)
+	}
+	if len(modified.Hunks) != 1 || len(modified.Hunks[0].Lines) != 6 {
+		t.Fatalf("expected 1 hunk of 6 lines, got %+v", modified.Hunks)
+	}
+	lines := modified.Hunks[0].Lines
+	if lines[0] != (DiffLine{Kind: "context", Old: 10, New: 10, Text: "\tcfg := load()"}) {
+		t.Errorf("unexpected context line %+v", lines[0])
+	}
+	if lines[1] != (DiffLine{Kind: "delete", Old: 11, Text: "\trun(cfg)"}) {
+		t.Errorf("unexpected deleted line %+v", lines[1])
+	}
+	if lines[2] != (DiffLine{Kind: "add", New: 11, Text: "\tif err := run(cfg); err != nil {"}) {
+		t.Errorf("unexpected added line %+v", lines[2])
+	}
+	if lines[5] != (DiffLine{Kind: "context", Old: 12, New: 14, Text: "}"}) {
+		t.Errorf("unexpected last line %+v", lines[5])
+	}
+
+	if files[1].Path != "docs/new.md" || files[1].Status != "added" || files[1].Additions != 1 {
+		t.Errorf("unexpected added file %+v", files[1])
+	}
+	if files[2].Path != "old.txt" || files[2].Status != "deleted" || files[2].Deletions != 1 {
+		t.Errorf("unexpected deleted file %+v", files[2])
+	}
+	if files[3].Path != "internal/app/b.go" || files[3].OldPath != "internal/app/a.go" || files[3].Status != "renamed" {
+		t.Errorf("unexpected renamed file %+v", files[3])
+	}
+	if files[4].Path != "logo.png" || !files[4].Binary {
+		t.Errorf("unexpected binary file %+v", files[4])
+	}
+}
+
+func TestParseUnifiedDiff_Empty(t *testing.T) {
+	files := parseUnifiedDiff("")
+	if files == nil || len(files) != 0 {
+		t.Errorf("expected no files, got %+v", files)
+	}
+}
+
+func TestBuildFileTree(t *testing.T) {
+	tree := buildFileTree(parseUnifiedDiff(sampleDiff))
+
+	// Directories first, then files, each by name
+	var names []string
+	for _, n := range tree {
+		names = append(names, n.Name)
+	}
+	want := []string{"docs", "internal", "logo.png", "old.txt"}
+	if len(names) != len(want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, names)
+		}
+	}
+
+	app := tree[1].Children[0]
+	if app.Path != "internal/app" || len(app.Children) != 2 {
+		t.Fatalf("unexpected directory %+v", app)
+	}
+	if app.Children[0].Path != "internal/app/b.go" || app.Children[0].Status != "renamed" {
+		t.Errorf("unexpected file %+v", app.Children[0])
+	}
+}
+
+// newDiffTestServer serves the diff endpoints for a unit started in a
+// worktree backed by ops
+func newDiffTestServer(t *testing.T, ops *git.MockGitOps) *http.ServeMux {
+	t.Helper()
+	store := NewStore()
+	store.HandleEvent(&Event{
+		Type:    "unit.started",
+		Time:    time.Now(),
+		Unit:    "app",
+		Payload: json.RawMessage(`{"branch":"ralph/app-abc123","worktree":"/wt/app","base_commit":"base000"}`),
+	})
+
+	open := func(path string) (git.GitOps, error) {
+		if path != "/wt/app" {
+			t.Errorf("expected worktree /wt/app, got %s", path)
+		}
+		return ops, nil
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/units/{id}/commits", UnitCommitsHandler(store, open))
+	mux.HandleFunc("GET /api/units/{id}/diff", UnitDiffHandler(store, open))
+	return mux
+}
+
+func TestUnitCommitsHandler(t *testing.T) {
+	ops := git.NewMockGitOps("/wt/app")
+	ops.LogResult = []git.CommitRecord{
+		{Hash: "bbbbbbbbbb", Subject: "fix: baseline checks"},
+		{Hash: "aaaaaaaaaa", Subject: "feat(app): complete task #1 - Add main"},
+	}
+	mux := newDiffTestServer(t, ops)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/units/app/commits", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var commits UnitCommits
+	if err := json.NewDecoder(w.Body).Decode(&commits); err != nil {
+		t.Fatalf("failed to decode JSON: %v", err)
+	}
+	if commits.Branch != "ralph/app-abc123" || commits.Base != "base000" {
+		t.Errorf("unexpected branch and base %+v", commits)
+	}
+	if len(commits.Commits) != 2 {
+		t.Fatalf("expected 2 commits, got %d", len(commits.Commits))
+	}
+	if commits.Commits[0].Task != nil {
+		t.Errorf("expected no task for baseline fix, got %d", *commits.Commits[0].Task)
+	}
+	if commits.Commits[1].Task == nil || *commits.Commits[1].Task != 1 {
+		t.Errorf("expected task 1, got %v", commits.Commits[1].Task)
+	}
+
+	if opts := ops.Calls[0].Args[0].(git.LogOpts); opts.Range != "base000..HEAD" {
+		t.Errorf("expected range base000..HEAD, got %q", opts.Range)
+	}
+}
+
+func TestUnitCommitsHandler_UnknownUnit(t *testing.T) {
+	mux := newDiffTestServer(t, git.NewMockGitOps("/wt/app"))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/units/other/commits", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+func TestUnitDiffHandler_Cumulative(t *testing.T) {
+	ops := git.NewMockGitOps("/wt/app")
+	ops.RevParseResult = "head111"
+	ops.DiffResult = sampleDiff
+	mux := newDiffTestServer(t, ops)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/units/app/diff", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var diff UnitDiff
+	if err := json.NewDecoder(w.Body).Decode(&diff); err != nil {
+		t.Fatalf("failed to decode JSON: %v", err)
+	}
+	if diff.Base != "base000" || diff.Head != "head111" {
+		t.Errorf("expected base000..head111, got %s..%s", diff.Base, diff.Head)
+	}
+	if len(diff.Files) != 5 || len(diff.Tree) != 4 {
+		t.Errorf("expected 5 files in 4 top-level nodes, got %d and %d", len(diff.Files), len(diff.Tree))
+	}
+
+	last := ops.Calls[len(ops.Calls)-1]
+	if last.Method != "Diff" || last.Args[0] != "base000" || last.Args[1] != "head111" {
+		t.Errorf("unexpected call %+v", last)
+	}
+}
+
+func TestUnitDiffHandler_Commit(t *testing.T) {
+	ops := git.NewMockGitOps("/wt/app")
+	ops.LogResult = []git.CommitRecord{{Hash: "aaaaaaaaaa", Subject: "feat(app): complete task #1 - Add main"}}
+	ops.RevParseResult = "aaaaaaaaaa"
+	mux := newDiffTestServer(t, ops)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/units/app/diff?commit=aaaaaaa", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	last := ops.Calls[len(ops.Calls)-1]
+	if last.Method != "Diff" || last.Args[0] != "aaaaaaa^" || last.Args[1] != "aaaaaaaaaa" {
+		t.Errorf("unexpected call %+v", last)
+	}
+
+	// Commits that are not the unit's are refused
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/units/app/diff?commit=--output=x", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"net/http"
+	"os"
+
+	"github.com/RevCBH/choo/internal/feature"
 )
 
 // IndexHandler serves the embedded HTML UI.
@@ -16,6 +20,21 @@ func IndexHandler(staticFS fs.FS) http.Handler {
 	return http.FileServer(http.FS(subFS))
 }
 
+// JobPageHandler serves the UI for a job's page, which shows the job named
+// in its URL.
+// GET /jobs/{job}
+func JobPageHandler(staticFS fs.FS) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, err := fs.ReadFile(staticFS, "static/index.html")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+}
+
 // StateHandler returns the current state snapshot as JSON.
 // GET /api/state
 func StateHandler(store *Store) http.HandlerFunc {
@@ -44,8 +63,72 @@ func GraphHandler(store *Store) http.HandlerFunc {
 	}
 }
 
+// TranscriptHandler returns a unit's provider transcript as JSON.
+// GET /api/units/{id}/transcript
+// Returns an empty transcript for units without one.
+func TranscriptHandler(store *Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(store.Transcript(r.PathValue("id")))
+	}
+}
+
+// RoadmapHandler returns the PRDs in prdDir by status as JSON.
+// GET /api/roadmap
+// PRDs are read on each request so edits show up on refresh. Returns an
+// empty roadmap if prdDir is unset or does not exist.
+func RoadmapHandler(prdDir string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		roadmap, err := buildRoadmap(prdDir)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			roadmap = &RoadmapData{PRDs: []RoadmapPRD{}, Levels: [][]string{}, Error: err.Error()}
+		}
+		json.NewEncoder(w).Encode(roadmap)
+	}
+}
+
+func buildRoadmap(prdDir string) (*RoadmapData, error) {
+	roadmap := &RoadmapData{PRDs: []RoadmapPRD{}, Levels: [][]string{}}
+	if prdDir == "" {
+		return roadmap, nil
+	}
+	prds, err := feature.DiscoverPRDs(prdDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return roadmap, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// A broken graph still shows the PRDs, without dependency state
+	graph, graphErr := feature.NewGraph(prds)
+	if graphErr != nil {
+		roadmap.Error = graphErr.Error()
+	} else {
+		roadmap.Levels = graph.Levels()
+	}
+	for _, prd := range prds {
+		item := RoadmapPRD{
+			ID:             prd.ID,
+			Title:          prd.Title,
+			Status:         prd.RoadmapStatus(),
+			FeatureStatus:  prd.FeatureStatus,
+			DependsOn:      prd.DependsOn,
+			EstimatedUnits: prd.EstimatedUnits,
+			EstimatedTasks: prd.EstimatedTasks,
+		}
+		if graph != nil && !prd.IsComplete() {
+			item.WaitingOn = graph.UnmetDependencies(prd.ID)
+		}
+		roadmap.PRDs = append(roadmap.PRDs, item)
+	}
+	return roadmap, nil
+}
+
 // EventsHandler provides the SSE event stream.
-// GET /api/events
+// GET /api/events, or GET /api/events?job=<id> for one job's events
 // Sets appropriate headers and streams events to browser.
 func EventsHandler(hub *Hub) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -68,6 +151,7 @@ func EventsHandler(hub *Hub) http.HandlerFunc {
 		hub.Register(client)
 		defer hub.Unregister(client)
 
+		job := r.URL.Query().Get("job")
 		ctx := r.Context()
 		for {
 			select {
@@ -77,6 +161,9 @@ func EventsHandler(hub *Hub) http.HandlerFunc {
 				if !ok {
 					return
 				}
+				if job != "" && event.Job != job {
+					continue
+				}
 				data, _ := json.Marshal(event)
 				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
 				flusher.Flush()
@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -43,6 +45,7 @@ func TestIndexHandler_ServesStaticFiles(t *testing.T) {
 	}{
 		{"/style.css", "text/css", "font-family"},
 		{"/app.js", "javascript", "EventSource"},
+		{"/html.js", "javascript", "export function escapeHTML"},
 	}
 
 	for _, tt := range tests {
@@ -231,6 +234,93 @@ func TestGraphHandler_WithGraph(t *testing.T) {
 	}
 }
 
+func TestTranscriptHandler(t *testing.T) {
+	store := NewStore()
+	store.HandleEvent(&Event{
+		Type:    "task.output",
+		Time:    time.Now(),
+		Unit:    "unit-a",
+		Payload: json.RawMessage(`{"entries":[{"kind":"text","text":"hello"}]}`),
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/units/{id}/transcript", TranscriptHandler(store))
+
+	req := httptest.NewRequest("GET", "/api/units/unit-a/transcript", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+	var transcript TranscriptData
+	if err := json.NewDecoder(w.Body).Decode(&transcript); err != nil {
+		t.Fatalf("failed to decode JSON: %v", err)
+	}
+	if transcript.Unit != "unit-a" {
+		t.Errorf("expected unit 'unit-a', got %q", transcript.Unit)
+	}
+	if len(transcript.Entries) != 1 || transcript.Entries[0].Text != "hello" {
+		t.Errorf("unexpected entries %+v", transcript.Entries)
+	}
+}
+
+func TestRoadmapHandler_NoPRDDir(t *testing.T) {
+	handler := RoadmapHandler("")
+
+	req := httptest.NewRequest("GET", "/api/roadmap", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	var roadmap RoadmapData
+	json.NewDecoder(w.Body).Decode(&roadmap)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+	if roadmap.PRDs == nil || len(roadmap.PRDs) != 0 {
+		t.Errorf("expected empty PRD list, got %v", roadmap.PRDs)
+	}
+}
+
+func TestRoadmapHandler_ListsPRDs(t *testing.T) {
+	prdDir := t.TempDir()
+	files := map[string]string{
+		"auth.md":    "---\nprd_id: auth\ntitle: Auth\nstatus: approved\nfeature_status: pr_open\n---\n",
+		"billing.md": "---\nprd_id: billing\ntitle: Billing\nstatus: approved\ndepends_on: [auth]\n---\n",
+	}
+	for name, content := range files {
+		os.WriteFile(filepath.Join(prdDir, name), []byte(content), 0644)
+	}
+
+	handler := RoadmapHandler(prdDir)
+	req := httptest.NewRequest("GET", "/api/roadmap", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	var roadmap RoadmapData
+	json.NewDecoder(w.Body).Decode(&roadmap)
+
+	if len(roadmap.PRDs) != 2 {
+		t.Fatalf("expected 2 PRDs, got %d", len(roadmap.PRDs))
+	}
+	byID := make(map[string]RoadmapPRD)
+	for _, prd := range roadmap.PRDs {
+		byID[prd.ID] = prd
+	}
+	if byID["auth"].Status != "in_progress" {
+		t.Errorf("expected auth in_progress, got %s", byID["auth"].Status)
+	}
+	if waiting := byID["billing"].WaitingOn; len(waiting) != 1 || waiting[0] != "auth" {
+		t.Errorf("expected billing waiting on auth, got %v", waiting)
+	}
+	if len(roadmap.Levels) != 2 {
+		t.Errorf("expected 2 levels, got %d", len(roadmap.Levels))
+	}
+}
+
 func TestEventsHandler_SetsHeaders(t *testing.T) {
 	hub := NewHub()
 	go hub.Run()
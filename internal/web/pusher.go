@@ -38,6 +38,9 @@ func NewSocketPusher(bus *events.Bus, cfg PusherConfig) *SocketPusher {
 	if cfg.MaxReconnectBackoff <= 0 {
 		cfg.MaxReconnectBackoff = 5 * time.Second
 	}
+	if cfg.JobID == "" {
+		cfg.JobID = generateID()
+	}
 
 	return &SocketPusher{
 		cfg:     cfg,
@@ -118,6 +121,7 @@ func (p *SocketPusher) Start(ctx context.Context) error {
 	// Send initial graph payload if set
 	if p.graph != nil {
 		wireEvent := WireEvent{
+			Job:     p.cfg.JobID,
 			Type:    "graph",
 			Time:    time.Now(),
 			Payload: p.graph,
@@ -213,6 +217,7 @@ func (p *SocketPusher) connect() error {
 func (p *SocketPusher) writeEvent(e events.Event) error {
 	// Convert events.Event to WireEvent
 	wireEvent := WireEvent{
+		Job:     p.cfg.JobID,
 		Type:    string(e.Type),
 		Time:    e.Time,
 		Unit:    e.Unit,
@@ -51,6 +51,14 @@ func TestSocketPusher_NewSocketPusher(t *testing.T) {
 		}
 	})
 
+	t.Run("generates a JobID", func(t *testing.T) {
+		cfg := PusherConfig{SocketPath: "/tmp/test.sock"}
+		p := NewSocketPusher(bus, cfg)
+		if p.cfg.JobID == "" {
+			t.Error("expected a generated JobID")
+		}
+	})
+
 	t.Run("respects custom config values", func(t *testing.T) {
 		cfg := PusherConfig{
 			SocketPath:          "/tmp/custom.sock",
@@ -260,6 +268,7 @@ func TestSocketPusher_EventForwarding(t *testing.T) {
 		SocketPath:   socketPath,
 		BufferSize:   100,
 		WriteTimeout: time.Second,
+		JobID:        "job-1",
 	}
 	p := NewSocketPusher(bus, cfg)
 
@@ -304,6 +313,9 @@ func TestSocketPusher_EventForwarding(t *testing.T) {
 	if wireEvent.Unit != "test-unit" {
 		t.Errorf("expected unit=test-unit, got %s", wireEvent.Unit)
 	}
+	if wireEvent.Job != "job-1" {
+		t.Errorf("expected job=job-1, got %s", wireEvent.Job)
+	}
 }
 
 func TestSocketPusher_Reconnect(t *testing.T) {
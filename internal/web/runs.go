@@ -0,0 +1,259 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxRuns bounds the runs kept in memory; the oldest that are no longer
+// connected go first
+const maxRuns = 100
+
+// Runs holds the state of each run the server has seen, by job ID.
+// It is safe for concurrent access.
+type Runs struct {
+	mu   sync.RWMutex
+	runs map[string]*runEntry
+}
+
+type runEntry struct {
+	store     *Store
+	createdAt time.Time
+}
+
+// NewRuns creates an empty set of runs.
+func NewRuns() *Runs {
+	return &Runs{runs: make(map[string]*runEntry)}
+}
+
+// Store returns the store of a run, creating it on the run's first use.
+// Thread-safe.
+func (r *Runs) Store(jobID string) *Store {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.runs[jobID]; ok {
+		return entry.store
+	}
+	r.evict()
+	entry := &runEntry{store: NewStore(), createdAt: time.Now()}
+	r.runs[jobID] = entry
+	return entry.store
+}
+
+// Replayed keeps a store replayed from a run's recorded events, so later
+// requests do not replay the run again, and returns the run's store. The
+// store is not connected, so it is evicted like any finished run. A run
+// seen in the meantime keeps its own store.
+// Thread-safe.
+func (r *Runs) Replayed(jobID string, store *Store) *Store {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.runs[jobID]; ok {
+		return entry.store
+	}
+	r.evict()
+	r.runs[jobID] = &runEntry{store: store, createdAt: time.Now()}
+	return store
+}
+
+// Get returns the store of a run, and false if the run is unknown.
+// Thread-safe.
+func (r *Runs) Get(jobID string) (*Store, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.runs[jobID]
+	if !ok {
+		return nil, false
+	}
+	return entry.store, true
+}
+
+// HandleEvent updates the store of the event's run.
+// Thread-safe.
+func (r *Runs) HandleEvent(e *Event) {
+	r.Store(e.Job).HandleEvent(e)
+}
+
+// Latest returns the ID of the most recently started run, or "" if there
+// are none. Thread-safe.
+func (r *Runs) Latest() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	latest, latestAt := "", time.Time{}
+	for id, entry := range r.runs {
+		if at := entry.startedAt(); latest == "" || at.After(latestAt) {
+			latest, latestAt = id, at
+		}
+	}
+	return latest
+}
+
+// List returns a summary of each run, most recently started first.
+// Thread-safe.
+func (r *Runs) List() []JobInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	jobs := make([]JobInfo, 0, len(r.runs))
+	for id, entry := range r.runs {
+		jobs = append(jobs, entry.info(id))
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].StartedAt.After(*jobs[j].StartedAt)
+	})
+	return jobs
+}
+
+// evict drops the oldest disconnected runs beyond maxRuns. Callers hold
+// r.mu.
+func (r *Runs) evict() {
+	for len(r.runs) >= maxRuns {
+		oldest, oldestAt := "", time.Time{}
+		for id, entry := range r.runs {
+			if entry.store.Snapshot().Connected {
+				continue
+			}
+			if at := entry.startedAt(); oldest == "" || at.Before(oldestAt) {
+				oldest, oldestAt = id, at
+			}
+		}
+		if oldest == "" {
+			return
+		}
+		delete(r.runs, oldest)
+	}
+}
+
+// startedAt is when the run started, or when it was first seen if it has
+// not started yet
+func (e *runEntry) startedAt() time.Time {
+	if started := e.store.Snapshot().StartedAt; started != nil {
+		return *started
+	}
+	return e.createdAt
+}
+
+// info summarizes the run as a job
+func (e *runEntry) info(id string) JobInfo {
+	snapshot := e.store.Snapshot()
+	started := e.startedAt()
+	return JobInfo{
+		ID:          id,
+		Status:      jobStatus(snapshot.Status),
+		Paused:      snapshot.Status == "paused",
+		Parallelism: snapshot.Parallelism,
+		StartedAt:   &started,
+		Live:        snapshot.Connected,
+		Summary:     &snapshot.Summary,
+	}
+}
+
+// jobStatus maps a store status to a job status
+func jobStatus(status string) string {
+	switch status {
+	case "completed", "failed":
+		return status
+	default:
+		return "running"
+	}
+}
+
+// RunHandler serves a request from the store of the job in its path. Jobs
+// the server has not seen are replayed from history.
+// GET /api/jobs/{job}/...
+// Returns 404 for unknown jobs.
+func RunHandler(runs *Runs, history JobHistory, handler func(*Store) http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		store, err := runStore(r.Context(), runs, history, r.PathValue("job"))
+		if err != nil {
+			writeControlError(w, err)
+			return
+		}
+		handler(store)(w, r)
+	}
+}
+
+// LatestRunHandler serves a request from the store of the most recently
+// started run, or an empty store if there is none.
+// GET /api/state and GET /api/graph
+func LatestRunHandler(runs *Runs, handler func(*Store) http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		store, ok := runs.Get(runs.Latest())
+		if !ok {
+			store = NewStore()
+		}
+		handler(store)(w, r)
+	}
+}
+
+// EventLogHandler returns a run's event log as JSON.
+// GET /api/jobs/{job}/events
+func EventLogHandler(store *Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		events, dropped := store.Events()
+		writeJSON(w, http.StatusOK, EventLog{Job: r.PathValue("job"), Events: events, Dropped: dropped})
+	}
+}
+
+// runStore returns the store of a job's run, replaying the job's recorded
+// events into a new store, kept in runs, when the server has not seen it
+func runStore(ctx context.Context, runs *Runs, history JobHistory, jobID string) (*Store, error) {
+	if store, ok := runs.Get(jobID); ok {
+		return store, nil
+	}
+	if history == nil {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+
+	events, err := history.JobEvents(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	store := NewStore()
+	for _, e := range events {
+		store.HandleEvent(e)
+	}
+	return runs.Replayed(jobID, store), nil
+}
+
+// mergeJobs adds the state of the runs seen to the jobs listed, then the
+// runs not listed, most recently started first
+func mergeJobs(listed, seen []JobInfo) []JobInfo {
+	unlisted := make(map[string]JobInfo, len(seen))
+	for _, run := range seen {
+		unlisted[run.ID] = run
+	}
+
+	jobs := make([]JobInfo, 0, len(listed)+len(seen))
+	for _, job := range listed {
+		if run, ok := unlisted[job.ID]; ok {
+			job.Live = run.Live
+			job.Summary = run.Summary
+			delete(unlisted, job.ID)
+		}
+		jobs = append(jobs, job)
+	}
+	for _, run := range seen {
+		if _, ok := unlisted[run.ID]; ok {
+			jobs = append(jobs, run)
+		}
+	}
+
+	// Jobs that have not started yet are the newest
+	sort.SliceStable(jobs, func(i, j int) bool {
+		a, b := jobs[i].StartedAt, jobs[j].StartedAt
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.After(*b)
+	})
+	return jobs
+}
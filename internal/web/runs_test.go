@@ -0,0 +1,230 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeHistory serves recorded jobs and their events
+type fakeHistory struct {
+	jobs    []JobInfo
+	events  map[string][]*Event
+	replays int // JobEvents calls
+}
+
+func (h *fakeHistory) ListJobs(ctx context.Context) ([]JobInfo, error) {
+	return h.jobs, nil
+}
+
+func (h *fakeHistory) JobEvents(ctx context.Context, jobID string) ([]*Event, error) {
+	h.replays++
+	events, ok := h.events[jobID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+	return events, nil
+}
+
+// orchStarted returns an orch.started event for a job with one unit
+func orchStarted(job string, at time.Time) *Event {
+	return &Event{
+		Job:     job,
+		Type:    "orch.started",
+		Time:    at,
+		Payload: json.RawMessage(`{"unit_count":1,"parallelism":2,"graph":{"nodes":[{"id":"unit-a","level":0,"tasks":2}],"edges":[],"levels":[["unit-a"]]}}`),
+	}
+}
+
+func TestRuns_StoreAndList(t *testing.T) {
+	runs := NewRuns()
+	if runs.Latest() != "" {
+		t.Errorf("expected no latest run, got %q", runs.Latest())
+	}
+
+	now := time.Now()
+	runs.HandleEvent(orchStarted("job-1", now.Add(-time.Minute)))
+	runs.HandleEvent(orchStarted("job-2", now))
+	runs.Store("job-2").SetConnected(true)
+
+	if _, ok := runs.Get("job-3"); ok {
+		t.Error("expected no run for job-3")
+	}
+	if runs.Latest() != "job-2" {
+		t.Errorf("expected latest run job-2, got %q", runs.Latest())
+	}
+
+	jobs := runs.List()
+	if len(jobs) != 2 || jobs[0].ID != "job-2" || jobs[1].ID != "job-1" {
+		t.Fatalf("expected job-2 then job-1, got %+v", jobs)
+	}
+	if !jobs[0].Live || jobs[1].Live {
+		t.Errorf("expected only job-2 live, got %+v", jobs)
+	}
+	if jobs[0].Status != "running" || jobs[0].Parallelism != 2 {
+		t.Errorf("unexpected job %+v", jobs[0])
+	}
+	if jobs[0].Summary == nil || jobs[0].Summary.Total != 1 {
+		t.Errorf("expected a summary of 1 unit, got %+v", jobs[0].Summary)
+	}
+}
+
+func TestRuns_EvictsOldestDisconnected(t *testing.T) {
+	runs := NewRuns()
+	start := time.Now()
+
+	// The oldest run stays while it is connected
+	runs.HandleEvent(orchStarted("live", start))
+	runs.Store("live").SetConnected(true)
+	for i := 1; i <= maxRuns; i++ {
+		runs.HandleEvent(orchStarted(fmt.Sprintf("job-%d", i), start.Add(time.Duration(i)*time.Second)))
+	}
+
+	if len(runs.List()) != maxRuns {
+		t.Errorf("expected %d runs, got %d", maxRuns, len(runs.List()))
+	}
+	if _, ok := runs.Get("live"); !ok {
+		t.Error("expected the connected run to be kept")
+	}
+	if _, ok := runs.Get("job-1"); ok {
+		t.Error("expected the oldest disconnected run to be evicted")
+	}
+}
+
+func TestRuns_ReplayedRunsAreEvicted(t *testing.T) {
+	runs := NewRuns()
+	start := time.Now()
+
+	replayed := NewStore()
+	replayed.HandleEvent(orchStarted("old", start))
+	if got := runs.Replayed("old", replayed); got != replayed {
+		t.Fatal("expected the replayed store to be kept")
+	}
+	if got := runs.Replayed("old", NewStore()); got != replayed {
+		t.Error("expected a second replay to get the kept store")
+	}
+
+	for i := 1; i < maxRuns; i++ {
+		runs.HandleEvent(orchStarted(fmt.Sprintf("job-%d", i), start.Add(time.Duration(i)*time.Second)))
+	}
+	runs.HandleEvent(orchStarted("new", start.Add(time.Hour)))
+	if _, ok := runs.Get("old"); ok {
+		t.Error("expected the replayed run to be evicted first")
+	}
+}
+
+func TestMergeJobs(t *testing.T) {
+	at := func(minutes int) *time.Time {
+		t := time.Date(2026, 1, 1, 12, minutes, 0, 0, time.UTC)
+		return &t
+	}
+	summary := &StateSummary{Total: 3, Complete: 1}
+	listed := []JobInfo{
+		{ID: "queued", Status: "running"},
+		{ID: "daemon", Status: "running", RepoPath: "/repo", StartedAt: at(10)},
+		{ID: "old", Status: "completed", StartedAt: at(0)},
+	}
+	seen := []JobInfo{
+		{ID: "cli", Status: "running", StartedAt: at(20), Live: true},
+		{ID: "daemon", Status: "running", StartedAt: at(10), Live: true, Summary: summary},
+	}
+
+	jobs := mergeJobs(listed, seen)
+
+	var ids []string
+	for _, j := range jobs {
+		ids = append(ids, j.ID)
+	}
+	if fmt.Sprint(ids) != "[queued cli daemon old]" {
+		t.Fatalf("unexpected order %v", ids)
+	}
+	if d := jobs[2]; !d.Live || d.Summary != summary || d.RepoPath != "/repo" {
+		t.Errorf("expected the listed job with its run's state, got %+v", d)
+	}
+	if jobs[3].Live || jobs[3].Summary != nil {
+		t.Errorf("expected no run state for old, got %+v", jobs[3])
+	}
+}
+
+func TestRunHandler(t *testing.T) {
+	runs := NewRuns()
+	runs.HandleEvent(orchStarted("live", time.Now()))
+	history := &fakeHistory{events: map[string][]*Event{
+		"old": {
+			orchStarted("old", time.Now()),
+			{Job: "old", Type: "unit.completed", Time: time.Now(), Unit: "unit-a"},
+			{Job: "old", Type: "orch.completed", Time: time.Now()},
+		},
+	}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/jobs/{job}/state", RunHandler(runs, history, StateHandler))
+
+	tests := []struct {
+		job        string
+		wantStatus int
+		wantState  string
+	}{
+		{"live", http.StatusOK, "running"},
+		{"old", http.StatusOK, "completed"},
+		{"missing", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.job, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/jobs/"+tt.job+"/state", nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantState == "" {
+				return
+			}
+			var snapshot StateSnapshot
+			if err := json.NewDecoder(w.Body).Decode(&snapshot); err != nil {
+				t.Fatalf("failed to decode JSON: %v", err)
+			}
+			if snapshot.Status != tt.wantState {
+				t.Errorf("expected status %q, got %q", tt.wantState, snapshot.Status)
+			}
+		})
+	}
+
+	// A replayed job is kept, not live, so it is replayed once
+	store, ok := runs.Get("old")
+	if !ok {
+		t.Fatal("expected the replayed job to be kept")
+	}
+	if store.Snapshot().Connected {
+		t.Error("expected the replayed job not to be connected")
+	}
+	replays := history.replays
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/jobs/old/state", nil))
+	if w.Code != http.StatusOK || history.replays != replays {
+		t.Errorf("expected the kept store to be served, got status %d after %d more replays", w.Code, history.replays-replays)
+	}
+}
+
+func TestEventLogHandler(t *testing.T) {
+	store := NewStore()
+	store.HandleEvent(orchStarted("job-1", time.Now()))
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/jobs/{job}/events", EventLogHandler(store))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/jobs/job-1/events", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var log EventLog
+	if err := json.NewDecoder(w.Body).Decode(&log); err != nil {
+		t.Fatalf("failed to decode JSON: %v", err)
+	}
+	if log.Job != "job-1" || len(log.Events) != 1 || log.Events[0].Type != "orch.started" {
+		t.Errorf("unexpected event log %+v", log)
+	}
+}
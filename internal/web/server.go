@@ -2,6 +2,7 @@ package web
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
@@ -11,9 +12,10 @@ import (
 type Server struct {
 	addr   string
 	socket string
+	tls    *tls.Config
 
-	store *Store
-	hub   *Hub
+	runs *Runs
+	hub  *Hub
 
 	httpServer   *http.Server
 	httpListener net.Listener
@@ -23,17 +25,17 @@ type Server struct {
 }
 
 // New creates a new web server with the given configuration.
-// Initializes store, hub, socket server, and HTTP server.
+// Initializes runs, hub, socket server, and HTTP server.
 // Does not start any servers - call Start() for that.
 func New(cfg Config) (*Server, error) {
-	return NewWithStore(cfg, nil)
+	return NewWithRuns(cfg, nil)
 }
 
-// NewWithStore creates a new web server with an external Store.
-// If store is nil, a new Store is created.
+// NewWithRuns creates a new web server with external Runs.
+// If runs is nil, new Runs are created.
 // This allows the daemon to share state between job manager and web server,
 // enabling late-attaching clients to see current job state.
-func NewWithStore(cfg Config, store *Store) (*Server, error) {
+func NewWithRuns(cfg Config, runs *Runs) (*Server, error) {
 	if cfg.Addr == "" {
 		cfg.Addr = ":8080"
 	}
@@ -41,19 +43,60 @@ func NewWithStore(cfg Config, store *Store) (*Server, error) {
 		cfg.SocketPath = defaultSocketPath()
 	}
 
-	// Use provided store or create new one
-	if store == nil {
-		store = NewStore()
+	// Use provided runs or create new ones
+	if runs == nil {
+		runs = NewRuns()
 	}
 
 	hub := NewHub()
-	socketServer := NewSocketServer(cfg.SocketPath, store, hub)
+	socketServer := NewSocketServer(cfg.SocketPath, runs, hub)
+
+	// Jobs are listed from history, or else from control
+	var jobs JobLister
+	switch {
+	case cfg.History != nil:
+		jobs = cfg.History
+	case cfg.Control != nil:
+		jobs = cfg.Control
+	}
+	run := func(handler func(*Store) http.HandlerFunc) http.HandlerFunc {
+		return RunHandler(runs, cfg.History, handler)
+	}
+	withWorktrees := func(handler func(*Store, OpenRepo) http.HandlerFunc) func(*Store) http.HandlerFunc {
+		return func(store *Store) http.HandlerFunc {
+			return handler(store, openWorktree)
+		}
+	}
 
 	mux := http.NewServeMux()
 	mux.Handle("/", IndexHandler(staticFS))
-	mux.HandleFunc("/api/state", StateHandler(store))
-	mux.HandleFunc("/api/graph", GraphHandler(store))
+	mux.Handle("GET /jobs/{job}", JobPageHandler(staticFS))
+	mux.HandleFunc("/api/state", LatestRunHandler(runs, StateHandler))
+	mux.HandleFunc("/api/graph", LatestRunHandler(runs, GraphHandler))
+	mux.HandleFunc("GET /api/jobs", JobsHandler(jobs, runs))
+	mux.HandleFunc("GET /api/jobs/{job}/state", run(StateHandler))
+	mux.HandleFunc("GET /api/jobs/{job}/graph", run(GraphHandler))
+	mux.HandleFunc("GET /api/jobs/{job}/events", run(EventLogHandler))
+	mux.HandleFunc("GET /api/jobs/{job}/units/{id}/transcript", run(TranscriptHandler))
+	mux.HandleFunc("GET /api/jobs/{job}/units/{id}/commits", run(withWorktrees(UnitCommitsHandler)))
+	mux.HandleFunc("GET /api/jobs/{job}/units/{id}/diff", run(withWorktrees(UnitDiffHandler)))
 	mux.HandleFunc("/api/events", EventsHandler(hub))
+	mux.HandleFunc("/api/roadmap", RoadmapHandler(cfg.PRDDir))
+	if cfg.Metrics != nil {
+		mux.Handle("/metrics", cfg.Metrics)
+	}
+	mux.HandleFunc("GET /api/control", ControlInfoHandler(cfg.Control))
+	if cfg.Control != nil {
+		authorized := func(h http.HandlerFunc) http.Handler {
+			return RequireAuthorization(cfg.Authorize, h)
+		}
+		mux.Handle("POST /api/jobs", authorized(StartJobHandler(cfg.Control)))
+		mux.Handle("POST /api/jobs/{id}/stop", authorized(StopJobHandler(cfg.Control)))
+		mux.Handle("POST /api/jobs/{id}/pause", authorized(PauseJobHandler(cfg.Control, true)))
+		mux.Handle("POST /api/jobs/{id}/resume", authorized(PauseJobHandler(cfg.Control, false)))
+		mux.Handle("POST /api/jobs/{id}/retry", authorized(RetryJobHandler(cfg.Control)))
+		mux.Handle("POST /api/jobs/{id}/units/{unit}/retry", authorized(RetryUnitHandler(cfg.Control)))
+	}
 
 	httpServer := &http.Server{
 		Addr:    cfg.Addr,
@@ -63,7 +106,8 @@ func NewWithStore(cfg Config, store *Store) (*Server, error) {
 	return &Server{
 		addr:         cfg.Addr,
 		socket:       cfg.SocketPath,
-		store:        store,
+		tls:          cfg.TLS,
+		runs:         runs,
 		hub:          hub,
 		httpServer:   httpServer,
 		socketServer: socketServer,
@@ -90,6 +134,9 @@ func (s *Server) Start() error {
 	if err != nil {
 		return fmt.Errorf("HTTP listen: %w", err)
 	}
+	if s.tls != nil {
+		listener = tls.NewListener(listener, s.tls)
+	}
 	s.httpListener = listener
 
 	// Update addr with actual address (important for ephemeral ports)
@@ -141,8 +188,8 @@ func (s *Server) Hub() *Hub {
 	return s.hub
 }
 
-// Store returns the state store for direct event handling.
+// Runs returns the runs for direct event handling.
 // Used by the daemon to bypass socket-based IPC when running in-process.
-func (s *Server) Store() *Store {
-	return s.store
+func (s *Server) Runs() *Runs {
+	return s.runs
 }
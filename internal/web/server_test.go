@@ -3,11 +3,13 @@ package web
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,8 +29,8 @@ func TestServer_New(t *testing.T) {
 		t.Fatalf("New failed: %v", err)
 	}
 
-	if srv.store == nil {
-		t.Error("Store is not initialized")
+	if srv.runs == nil {
+		t.Error("Runs are not initialized")
 	}
 
 	if srv.hub == nil {
@@ -111,6 +113,45 @@ func TestServer_StartStop(t *testing.T) {
 	}
 }
 
+func TestServer_ServesTLS(t *testing.T) {
+	// Borrow a certificate for 127.0.0.1 and a client that trusts it
+	certSrv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer certSrv.Close()
+
+	srv, err := New(Config{
+		Addr:       "127.0.0.1:0",
+		SocketPath: filepath.Join(t.TempDir(), "test.sock"),
+		TLS:        &tls.Config{Certificates: certSrv.TLS.Certificates},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer srv.Stop(context.Background())
+
+	resp, err := certSrv.Client().Get("https://" + srv.Addr() + "/api/control")
+	if err != nil {
+		t.Fatalf("HTTPS request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200, got %d", resp.StatusCode)
+	}
+	if resp.TLS == nil {
+		t.Error("Expected a TLS connection")
+	}
+
+	// Plain HTTP is refused
+	if resp, err := http.Get("http://" + srv.Addr() + "/api/control"); err == nil {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			t.Error("Expected plain HTTP to be refused")
+		}
+	}
+}
+
 func TestServer_HTTPRoutes(t *testing.T) {
 	tmpDir := t.TempDir()
 	sockPath := filepath.Join(tmpDir, "test.sock")
@@ -372,3 +413,108 @@ func TestServer_SocketToSSE(t *testing.T) {
 		t.Error("Did not receive event via SSE")
 	}
 }
+
+func TestServer_JobRoutes(t *testing.T) {
+	runs := NewRuns()
+	runs.HandleEvent(orchStarted("live", time.Now()))
+	yesterday := time.Now().Add(-24 * time.Hour)
+	history := &fakeHistory{
+		jobs:   []JobInfo{{ID: "old", Status: "completed", StartedAt: &yesterday}},
+		events: map[string][]*Event{"old": {orchStarted("old", time.Now())}},
+	}
+	srv, err := NewWithRuns(Config{SocketPath: filepath.Join(t.TempDir(), "web.sock"), History: history}, runs)
+	if err != nil {
+		t.Fatalf("NewWithRuns failed: %v", err)
+	}
+	h := srv.httpServer.Handler
+
+	// Jobs are listed without control, most recent first
+	w := do(h, "GET", "/api/jobs", "", false)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /api/jobs: expected status 200, got %d", w.Code)
+	}
+	var jobs []JobInfo
+	if err := json.NewDecoder(w.Body).Decode(&jobs); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].ID != "live" || jobs[1].ID != "old" {
+		t.Errorf("unexpected jobs: %+v", jobs)
+	}
+
+	for _, path := range []string{
+		"/api/jobs/live/state",
+		"/api/jobs/live/graph",
+		"/api/jobs/old/events",
+		"/api/jobs/old/units/unit-a/transcript",
+	} {
+		if w := do(h, "GET", path, "", false); w.Code != http.StatusOK {
+			t.Errorf("GET %s: expected status 200, got %d", path, w.Code)
+		}
+	}
+	if w := do(h, "GET", "/api/jobs/missing/state", "", false); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for an unknown job, got %d", w.Code)
+	}
+
+	// Job pages are shareable: each serves the UI
+	w = do(h, "GET", "/jobs/old", "", false)
+	if w.Code != http.StatusOK || !strings.Contains(w.Header().Get("Content-Type"), "text/html") {
+		t.Errorf("expected the UI for a job page, got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+}
+
+func TestServer_SSEFiltersByJob(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "test.sock")
+	srv, err := New(Config{Addr: "127.0.0.1:0", SocketPath: sockPath})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer srv.Stop(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("http://%s/api/events?job=job-2", srv.Addr()), nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to connect to SSE: %v", err)
+	}
+	defer resp.Body.Close()
+
+	events := make(chan string, 10)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if line := scanner.Text(); strings.HasPrefix(line, "data: ") {
+				events <- strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+
+	// Give the SSE client time to register
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := net.Dial("unix", sockPath)
+	if err != nil {
+		t.Fatalf("Failed to connect to socket: %v", err)
+	}
+	defer conn.Close()
+	for _, job := range []string{"job-1", "job-2"} {
+		data, _ := json.Marshal(Event{Job: job, Type: "test.event", Time: time.Now()})
+		fmt.Fprintf(conn, "%s\n", data)
+	}
+
+	select {
+	case data := <-events:
+		var e Event
+		if err := json.Unmarshal([]byte(data), &e); err != nil {
+			t.Fatalf("failed to decode event: %v", err)
+		}
+		if e.Job != "job-2" {
+			t.Errorf("expected only job-2's events, got %s's", e.Job)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Did not receive event via SSE")
+	}
+}
@@ -10,23 +10,24 @@ import (
 )
 
 // SocketServer listens for orchestrator connections on a Unix socket.
-// Only one orchestrator connection is handled at a time.
+// Connections are handled concurrently, each event going to the run of its
+// job.
 type SocketServer struct {
 	path     string
 	listener net.Listener
-	store    *Store
+	runs     *Runs
 	hub      *Hub
 	done     chan struct{}
 }
 
 // NewSocketServer creates a Unix socket server.
 // Does not start listening - call Start() for that.
-func NewSocketServer(path string, store *Store, hub *Hub) *SocketServer {
+func NewSocketServer(path string, runs *Runs, hub *Hub) *SocketServer {
 	return &SocketServer{
-		path:  path,
-		store: store,
-		hub:   hub,
-		done:  make(chan struct{}),
+		path: path,
+		runs: runs,
+		hub:  hub,
+		done: make(chan struct{}),
 	}
 }
 
@@ -76,7 +77,7 @@ func (s *SocketServer) Path() string {
 	return s.path
 }
 
-// acceptLoop accepts connections one at a time.
+// acceptLoop accepts connections, handling each in its own goroutine.
 func (s *SocketServer) acceptLoop() {
 	for {
 		select {
@@ -98,19 +99,25 @@ func (s *SocketServer) acceptLoop() {
 			}
 		}
 
-		// Handle connection synchronously (one at a time)
-		s.handleConnection(conn)
+		go s.handleConnection(conn)
 	}
 }
 
 // handleConnection processes a single orchestrator connection.
-// Reads JSON events line by line, updates store, broadcasts to hub.
-// Sets store connected=true on connect, connected=false on disconnect.
+// Reads JSON events line by line, updates the store of each event's run and
+// broadcasts to hub. Events without a job belong to a run of their own for
+// the connection. A run is connected from its first event on the
+// connection until the connection closes.
 func (s *SocketServer) handleConnection(conn net.Conn) {
 	defer conn.Close()
 
-	s.store.SetConnected(true)
-	defer s.store.SetConnected(false)
+	connJob := generateID()
+	connected := make(map[string]*Store)
+	defer func() {
+		for _, store := range connected {
+			store.SetConnected(false)
+		}
+	}()
 
 	scanner := bufio.NewScanner(conn)
 	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
@@ -121,7 +128,17 @@ func (s *SocketServer) handleConnection(conn net.Conn) {
 			log.Printf("invalid event JSON: %v", err)
 			continue
 		}
-		s.store.HandleEvent(&event)
+		if event.Job == "" {
+			event.Job = connJob
+		}
+
+		store, ok := connected[event.Job]
+		if !ok {
+			store = s.runs.Store(event.Job)
+			store.SetConnected(true)
+			connected[event.Job] = store
+		}
+		store.HandleEvent(&event)
 		s.hub.Broadcast(&event)
 	}
 
@@ -15,10 +15,10 @@ func TestSocketServer_NewSocketServer(t *testing.T) {
 	tmpDir := t.TempDir()
 	socketPath := filepath.Join(tmpDir, "test.sock")
 
-	store := NewStore()
+	runs := NewRuns()
 	hub := NewHub()
 
-	server := NewSocketServer(socketPath, store, hub)
+	server := NewSocketServer(socketPath, runs, hub)
 
 	if server.Path() != socketPath {
 		t.Errorf("Path() = %q, want %q", server.Path(), socketPath)
@@ -29,9 +29,9 @@ func TestSocketServer_StartStop(t *testing.T) {
 	tmpDir := t.TempDir()
 	socketPath := filepath.Join(tmpDir, "test.sock")
 
-	store := NewStore()
+	runs := NewRuns()
 	hub := NewHub()
-	server := NewSocketServer(socketPath, store, hub)
+	server := NewSocketServer(socketPath, runs, hub)
 
 	// Start should create socket file
 	if err := server.Start(); err != nil {
@@ -66,9 +66,9 @@ func TestSocketServer_RemovesStaleSocket(t *testing.T) {
 	}
 	staleFile.Close()
 
-	store := NewStore()
+	runs := NewRuns()
 	hub := NewHub()
-	server := NewSocketServer(socketPath, store, hub)
+	server := NewSocketServer(socketPath, runs, hub)
 
 	// Start should remove stale socket and create new one
 	if err := server.Start(); err != nil {
@@ -90,9 +90,9 @@ func TestSocketServer_AcceptsConnection(t *testing.T) {
 	socketPath := filepath.Join("/tmp", fmt.Sprintf("choo-test-%d.sock", time.Now().UnixNano()))
 	defer os.Remove(socketPath)
 
-	store := NewStore()
+	runs := NewRuns()
 	hub := NewHub()
-	server := NewSocketServer(socketPath, store, hub)
+	server := NewSocketServer(socketPath, runs, hub)
 
 	if err := server.Start(); err != nil {
 		t.Fatalf("Start() error = %v", err)
@@ -113,9 +113,9 @@ func TestSocketServer_ParsesEvents(t *testing.T) {
 	tmpDir := t.TempDir()
 	socketPath := filepath.Join(tmpDir, "test.sock")
 
-	store := NewStore()
+	runs := NewRuns()
 	hub := NewHub()
-	server := NewSocketServer(socketPath, store, hub)
+	server := NewSocketServer(socketPath, runs, hub)
 
 	if err := server.Start(); err != nil {
 		t.Fatalf("Start() error = %v", err)
@@ -131,6 +131,7 @@ func TestSocketServer_ParsesEvents(t *testing.T) {
 
 	// Write an orch.started event
 	event := Event{
+		Job:  "job-1",
 		Type: "orch.started",
 		Time: time.Now(),
 		Payload: json.RawMessage(`{
@@ -150,7 +151,11 @@ func TestSocketServer_ParsesEvents(t *testing.T) {
 	// Give it time to process
 	time.Sleep(50 * time.Millisecond)
 
-	// Check store received event
+	// Check the job's store received event
+	store, ok := runs.Get("job-1")
+	if !ok {
+		t.Fatal("expected a run for job-1")
+	}
 	snapshot := store.Snapshot()
 	if snapshot.Status != "running" {
 		t.Errorf("store status = %q, want %q", snapshot.Status, "running")
@@ -164,12 +169,12 @@ func TestSocketServer_BroadcastsEvents(t *testing.T) {
 	tmpDir := t.TempDir()
 	socketPath := filepath.Join(tmpDir, "t.sock")
 
-	store := NewStore()
+	runs := NewRuns()
 	hub := NewHub()
 	go hub.Run()
 	defer hub.Stop()
 
-	server := NewSocketServer(socketPath, store, hub)
+	server := NewSocketServer(socketPath, runs, hub)
 
 	if err := server.Start(); err != nil {
 		t.Fatalf("Start() error = %v", err)
@@ -213,32 +218,37 @@ func TestSocketServer_SetsConnected(t *testing.T) {
 	tmpDir := t.TempDir()
 	socketPath := filepath.Join(tmpDir, "test.sock")
 
-	store := NewStore()
+	runs := NewRuns()
 	hub := NewHub()
-	server := NewSocketServer(socketPath, store, hub)
+	go hub.Run()
+	defer hub.Stop()
+	server := NewSocketServer(socketPath, runs, hub)
 
 	if err := server.Start(); err != nil {
 		t.Fatalf("Start() error = %v", err)
 	}
 	defer server.Stop()
 
-	// Initially not connected
-	if store.Snapshot().Connected {
-		t.Error("store should not be connected initially")
-	}
-
 	// Connect to socket
 	conn, err := net.Dial("unix", socketPath)
 	if err != nil {
 		t.Fatalf("failed to connect: %v", err)
 	}
 
-	// Give connection time to be handled
+	// The job's run is connected from its first event
+	event := Event{Job: "job-1", Type: "test.event", Time: time.Now()}
+	eventJSON, _ := json.Marshal(event)
+	fmt.Fprintf(conn, "%s\n", eventJSON)
+
+	// Give it time to process
 	time.Sleep(50 * time.Millisecond)
 
-	// Should be connected
+	store, ok := runs.Get("job-1")
+	if !ok {
+		t.Fatal("expected a run for job-1")
+	}
 	if !store.Snapshot().Connected {
-		t.Error("store should be connected after connection")
+		t.Error("store should be connected after an event")
 	}
 
 	// Close connection
@@ -253,14 +263,65 @@ func TestSocketServer_SetsConnected(t *testing.T) {
 	}
 }
 
+func TestSocketServer_ConcurrentJobs(t *testing.T) {
+	tmpDir := t.TempDir()
+	socketPath := filepath.Join(tmpDir, "test.sock")
+
+	runs := NewRuns()
+	hub := NewHub()
+	go hub.Run()
+	defer hub.Stop()
+	server := NewSocketServer(socketPath, runs, hub)
+
+	if err := server.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer server.Stop()
+
+	// Two orchestrators stay connected at once, each to its own run
+	for _, job := range []string{"job-1", "job-2"} {
+		conn, err := net.Dial("unix", socketPath)
+		if err != nil {
+			t.Fatalf("failed to connect: %v", err)
+		}
+		defer conn.Close()
+
+		event := Event{
+			Job:     job,
+			Type:    "orch.started",
+			Time:    time.Now(),
+			Payload: json.RawMessage(`{"unit_count":1,"parallelism":1,"graph":{"nodes":[{"id":"` + job + `-unit","level":0}],"edges":[],"levels":[]}}`),
+		}
+		eventJSON, _ := json.Marshal(event)
+		fmt.Fprintf(conn, "%s\n", eventJSON)
+	}
+
+	// Give it time to process
+	time.Sleep(100 * time.Millisecond)
+
+	for _, job := range []string{"job-1", "job-2"} {
+		store, ok := runs.Get(job)
+		if !ok {
+			t.Fatalf("expected a run for %s", job)
+		}
+		snapshot := store.Snapshot()
+		if !snapshot.Connected {
+			t.Errorf("%s should be connected", job)
+		}
+		if len(snapshot.Units) != 1 || snapshot.Units[0].ID != job+"-unit" {
+			t.Errorf("%s: unexpected units %+v", job, snapshot.Units)
+		}
+	}
+}
+
 func TestSocketServer_HandlesMalformedJSON(t *testing.T) {
 	// Use /tmp directly to avoid path length issues on macOS
 	socketPath := filepath.Join("/tmp", fmt.Sprintf("choo-test-%d.sock", time.Now().UnixNano()))
 	defer os.Remove(socketPath)
 
-	store := NewStore()
+	runs := NewRuns()
 	hub := NewHub()
-	server := NewSocketServer(socketPath, store, hub)
+	server := NewSocketServer(socketPath, runs, hub)
 
 	if err := server.Start(); err != nil {
 		t.Fatalf("Start() error = %v", err)
@@ -292,7 +353,8 @@ func TestSocketServer_HandlesMalformedJSON(t *testing.T) {
 	time.Sleep(50 * time.Millisecond)
 
 	// Should still be connected (didn't crash)
-	if !store.Snapshot().Connected {
+	store, ok := runs.Get(runs.Latest())
+	if !ok || !store.Snapshot().Connected {
 		t.Error("server should still be connected after malformed JSON")
 	}
 }
@@ -329,9 +391,9 @@ func TestSocketServer_LargeEvents(t *testing.T) {
 	tmpDir := t.TempDir()
 	socketPath := filepath.Join(tmpDir, "test.sock")
 
-	store := NewStore()
+	runs := NewRuns()
 	hub := NewHub()
-	server := NewSocketServer(socketPath, store, hub)
+	server := NewSocketServer(socketPath, runs, hub)
 
 	if err := server.Start(); err != nil {
 		t.Fatalf("Start() error = %v", err)
@@ -360,7 +422,8 @@ func TestSocketServer_LargeEvents(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 
 	// Should still be connected (event parsed successfully)
-	if !store.Snapshot().Connected {
+	store, ok := runs.Get(runs.Latest())
+	if !ok || !store.Snapshot().Connected {
 		t.Error("server should still be connected after large event")
 	}
 }
@@ -1,9 +1,24 @@
 // app.js - Main application
 
 import { initGraph, updateNodeStatuses, highlightDependencies, updateTaskProgress } from './graph.js';
+import { initRoadmap } from './roadmap.js';
+import { initJobs, refreshJobs, startPRD } from './jobs.js';
+import { initTranscript, openTranscript, handleOutput } from './transcript.js';
+import { initDiffViewer, openDiff } from './diff.js';
+import { initDashboard, refreshDashboard } from './dashboard.js';
+import { renderTimeline } from './timeline.js';
 
-// Application state
+// Events kept for the event log
+const MAX_EVENTS = 200;
+
+// How often the timeline's running bars grow
+const TIMELINE_INTERVAL = 5000;
+
+// Application state. job is the job whose page this is, null on the
+// dashboard.
 const state = {
+    job: jobFromPath(location.pathname),
+    live: false,
     connected: false,
     status: "waiting",
     startedAt: null,
@@ -49,8 +64,9 @@ class SSEClient {
         // Listen for specific event types
         const eventTypes = [
             'unit.started', 'unit.completed', 'unit.failed',
-            'task.started', 'task.completed',
+            'task.started', 'task.completed', 'task.output',
             'orch.started', 'orch.completed', 'orch.failed',
+            'orch.paused', 'orch.resumed',
             'orch.dryrun.started', 'orch.dryrun.completed'
         ];
 
@@ -87,6 +103,8 @@ const eventHandlers = {
         const unit = state.units.find(u => u.id === event.unit);
         if (unit) {
             unit.status = "in_progress";
+            unit.startedAt = event.time;
+            unit.completedAt = null;
             // Extract task info from payload if available
             if (event.payload) {
                 unit.totalTasks = event.payload.total_tasks || unit.totalTasks || 0;
@@ -116,6 +134,8 @@ const eventHandlers = {
             updateGraphStatus(event.unit, "complete");
             // Update graph progress blocks (all complete, none current)
             updateTaskProgress(event.unit, -1, unit.completedTasks);
+            unit.completedAt = event.time;
+            renderJobTimeline();
         }
         addEventLog(event);
     },
@@ -125,8 +145,10 @@ const eventHandlers = {
         if (unit) {
             unit.status = "failed";
             unit.error = event.error;
+            unit.completedAt = event.time;
             updateSummary();
             updateGraphStatus(event.unit, "failed");
+            renderJobTimeline();
         }
         showToast(`Unit "${event.unit}" failed: ${event.error}`, "error");
         addEventLog(event);
@@ -157,26 +179,48 @@ const eventHandlers = {
         addEventLog(event);
     },
 
+    // Transcript output is shown in the transcript pane, not the event log
+    "task.output": (event) => {
+        handleOutput(event);
+    },
+
     "orch.started": (event) => {
         state.status = "running";
         state.startedAt = event.time;
+        state.live = true;
         renderConnectionStatus();
         addEventLog(event);
     },
 
     "orch.completed": (event) => {
         state.status = "complete";
+        state.live = false;
         renderConnectionStatus();
         addEventLog(event);
     },
 
     "orch.failed": (event) => {
         state.status = "failed";
+        state.live = false;
         showToast("Orchestration failed", "error");
         renderConnectionStatus();
         addEventLog(event);
     },
 
+    "orch.paused": (event) => {
+        state.status = "paused";
+        renderConnectionStatus();
+        refreshJobs();
+        addEventLog(event);
+    },
+
+    "orch.resumed": (event) => {
+        state.status = "running";
+        renderConnectionStatus();
+        refreshJobs();
+        addEventLog(event);
+    },
+
     "orch.dryrun.started": (event) => {
         state.status = "running";
         state.startedAt = event.time;
@@ -192,24 +236,61 @@ const eventHandlers = {
 };
 
 function handleEvent(event) {
+    // The dashboard only notes that its jobs changed
+    if (!state.job) {
+        if (event.type.startsWith('orch.') || event.type.startsWith('unit.')) {
+            scheduleDashboardRefresh();
+        }
+        return;
+    }
     const handler = eventHandlers[event.type];
     if (handler) {
         handler(event);
     }
 }
 
+// jobFromPath returns the job of a job page's path, /jobs/<id>
+function jobFromPath(path) {
+    const match = path.match(/^\/jobs\/([^/]+)\/?$/);
+    return match ? decodeURIComponent(match[1]) : null;
+}
+
 // Initialize application
 async function init() {
+    document.body.classList.add(state.job ? 'view-job' : 'view-dashboard');
+    const controlEnabled = await initJobs(document.getElementById('jobs-panel'), { notify: showToast });
+    initRoadmap(document.getElementById('roadmap-list'), controlEnabled ? { onStart: startPRD } : {});
+
+    if (!state.job) {
+        initDashboard(document.getElementById('dashboard-list'), { controlEnabled });
+        renderConnectionStatus();
+        connectSSE('/api/events');
+        return;
+    }
+
+    document.title = `${state.job} - Choo Orchestrator`;
+    initTranscript(document.getElementById('transcript-panel'), state.job);
+    initDiffViewer(document.getElementById('diff-panel'), state.job);
+    const api = `/api/jobs/${encodeURIComponent(state.job)}`;
+
     try {
         // Fetch initial state
-        const [stateResponse, graphResponse] = await Promise.all([
-            fetch('/api/state'),
-            fetch('/api/graph')
+        const [stateResponse, graphResponse, eventsResponse] = await Promise.all([
+            fetch(`${api}/state`),
+            fetch(`${api}/graph`),
+            fetch(`${api}/events`)
         ]);
 
+        if (stateResponse.status === 404) {
+            renderJobHeader();
+            showToast(`Job ${state.job} not found`, 'error');
+            return;
+        }
+
         if (stateResponse.ok) {
             const stateData = await stateResponse.json();
             Object.assign(state, stateData);
+            state.live = stateData.connected;
         }
 
         if (graphResponse.ok) {
@@ -217,6 +298,11 @@ async function init() {
             state.graph = graphData;
         }
 
+        if (eventsResponse.ok) {
+            const log = await eventsResponse.json();
+            state.events = log.events.reverse().slice(0, MAX_EVENTS);
+        }
+
         // Initialize graph visualization
         const container = document.getElementById('graph-container');
         if (container && state.graph.nodes.length > 0) {
@@ -255,12 +341,23 @@ async function init() {
         // Render initial UI
         renderConnectionStatus();
         renderSummary();
+        renderEventLog();
+        renderJobTimeline();
+        setInterval(() => {
+            if (state.status === 'running') renderJobTimeline();
+        }, TIMELINE_INTERVAL);
 
-        // Start SSE connection
-        connectSSE();
+        // Start SSE connection for the job's events
+        connectSSE(`/api/events?job=${encodeURIComponent(state.job)}`);
 
         // Bind event handlers
         document.getElementById('detail-close')?.addEventListener('click', hideDetailPanel);
+        document.getElementById('detail-transcript')?.addEventListener('click', () => {
+            if (state.selectedUnit) openTranscript(state.selectedUnit);
+        });
+        document.getElementById('detail-diff')?.addEventListener('click', () => {
+            if (state.selectedUnit) openDiff(state.selectedUnit);
+        });
 
     } catch (error) {
         console.error('Failed to initialize:', error);
@@ -268,8 +365,8 @@ async function init() {
     }
 }
 
-function connectSSE() {
-    sseClient = new SSEClient('/api/events', {
+function connectSSE(url) {
+    sseClient = new SSEClient(url, {
         onConnect: () => {
             state.connected = true;
             renderConnectionStatus();
@@ -283,151 +380,3 @@ function connectSSE() {
     sseClient.connect();
 }
 
-function handleNodeClick(unitId) {
-    state.selectedUnit = unitId;
-    showDetailPanel(unitId);
-}
-
-function handleNodeHover(unitId) {
-    highlightDependencies(unitId);
-}
-
-function showDetailPanel(unitId) {
-    const unit = state.units.find(u => u.id === unitId);
-    if (!unit) return;
-
-    const panel = document.getElementById('detail-panel');
-    const title = document.getElementById('detail-title');
-    const status = document.getElementById('detail-status');
-    const progress = document.getElementById('detail-progress');
-    const errorDiv = document.getElementById('detail-error');
-
-    if (!panel) return;
-
-    title.textContent = unit.id;
-    status.textContent = unit.status;
-    status.style.backgroundColor = getStatusColor(unit.status);
-    progress.textContent = `Task ${(unit.currentTask || 0) + 1} of ${unit.totalTasks || 0}`;
-
-    if (unit.error) {
-        errorDiv.textContent = unit.error;
-        errorDiv.classList.remove('hidden');
-    } else {
-        errorDiv.classList.add('hidden');
-    }
-
-    panel.classList.remove('hidden');
-}
-
-function hideDetailPanel() {
-    document.getElementById('detail-panel')?.classList.add('hidden');
-    state.selectedUnit = null;
-}
-
-function renderConnectionStatus() {
-    const indicator = document.querySelector('.status-indicator');
-    const text = document.querySelector('.status-text');
-
-    if (!indicator || !text) return;
-
-    indicator.classList.toggle('connected', state.connected);
-    indicator.classList.toggle('disconnected', !state.connected);
-
-    if (!state.connected) {
-        text.textContent = 'Disconnected';
-    } else if (state.status === 'waiting') {
-        text.textContent = 'Waiting for orchestrator';
-    } else if (state.status === 'running') {
-        text.textContent = 'Running';
-    } else if (state.status === 'complete') {
-        text.textContent = 'Complete';
-    } else if (state.status === 'failed') {
-        text.textContent = 'Failed';
-    }
-}
-
-function renderSummary() {
-    Object.entries(state.summary).forEach(([key, value]) => {
-        const el = document.querySelector(`.stat[data-status="${key}"] .stat-value`);
-        if (el) el.textContent = value;
-    });
-}
-
-function updateSummary() {
-    const summary = { total: 0, pending: 0, inProgress: 0, complete: 0, failed: 0, blocked: 0 };
-
-    state.units.forEach(unit => {
-        summary.total++;
-        if (unit.status === 'pending' || unit.status === 'ready') summary.pending++;
-        else if (unit.status === 'in_progress' || unit.status === 'pr_open' ||
-                 unit.status === 'in_review' || unit.status === 'merging') summary.inProgress++;
-        else if (unit.status === 'complete') summary.complete++;
-        else if (unit.status === 'failed') summary.failed++;
-        else if (unit.status === 'blocked') summary.blocked++;
-    });
-
-    state.summary = summary;
-    renderSummary();
-}
-
-function updateGraphStatus(unitId, status) {
-    const statusMap = new Map([[unitId, status]]);
-    updateNodeStatuses(statusMap);
-}
-
-function addEventLog(event) {
-    state.events.unshift(event);
-    if (state.events.length > 100) state.events.pop();
-    renderEventLog();
-}
-
-function renderEventLog() {
-    const list = document.getElementById('event-list');
-    if (!list) return;
-
-    const html = state.events.slice(0, 50).map(event => {
-        const time = new Date(event.time).toLocaleTimeString();
-        const isError = event.type.includes('failed');
-        return `<div class="event-item ${isError ? 'error' : ''}">
-            <span class="time">${time}</span>
-            <span class="type">${event.type}</span>
-            ${event.unit ? `<span class="unit">${event.unit}</span>` : ''}
-        </div>`;
-    }).join('');
-    list.innerHTML = html;
-}
-
-function showToast(message, type = 'info') {
-    const container = document.getElementById('toast-container');
-    if (!container) return;
-
-    const toast = document.createElement('div');
-    toast.className = `toast ${type}`;
-    toast.textContent = message;
-    container.appendChild(toast);
-
-    setTimeout(() => {
-        toast.remove();
-    }, 5000);
-}
-
-function getStatusColor(status) {
-    const colors = {
-        pending: '#9CA3AF',
-        ready: '#FBBF24',
-        in_progress: '#3B82F6',
-        pr_open: '#A855F7',
-        in_review: '#A855F7',
-        merging: '#A855F7',
-        complete: '#22C55E',
-        failed: '#EF4444',
-        blocked: '#F97316'
-    };
-    return colors[status] || colors.pending;
-}
-
-// Start application when DOM is ready
-document.addEventListener('DOMContentLoaded', init);
-
-// Export for testing and external access
-export { state, showToast, updateGraphStatus, handleEvent };
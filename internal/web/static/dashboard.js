@@ -0,0 +1,100 @@
+// dashboard.js - Every job, live and past, linking to its page
+
+import { jobActions, runAction } from './jobs.js';
+import { escapeHTML } from './html.js';
+
+const REFRESH_INTERVAL = 5000;
+
+const dashboard = {
+    list: null,
+    controlEnabled: false,
+    refreshing: false
+};
+
+// List the jobs in list now and periodically; with control enabled each
+// job gets its control buttons
+export function initDashboard(list, options = {}) {
+    if (!list) return;
+    dashboard.list = list;
+    dashboard.controlEnabled = !!options.controlEnabled;
+
+    list.addEventListener('click', async (e) => {
+        const button = e.target.closest('button[data-action]');
+        if (!button) return;
+        await runAction(button.dataset.action, button.dataset.job);
+        refreshDashboard();
+    });
+
+    refreshDashboard();
+    setInterval(refreshDashboard, REFRESH_INTERVAL);
+}
+
+export async function refreshDashboard() {
+    if (!dashboard.list || dashboard.refreshing) return;
+    dashboard.refreshing = true;
+    try {
+        const response = await fetch('/api/jobs');
+        const jobs = await response.json();
+        if (!response.ok) throw new Error(jobs.error || response.statusText);
+        render(jobs);
+    } catch (err) {
+        console.error('Failed to load jobs:', err);
+    } finally {
+        dashboard.refreshing = false;
+    }
+}
+
+function render(jobs) {
+    if (jobs.length === 0) {
+        dashboard.list.innerHTML = '<div class="dashboard-empty">No jobs yet. Run <code>choo run --web</code> or start one from the daemon.</div>';
+        return;
+    }
+    dashboard.list.innerHTML = `<table class="dashboard-table">
+        <thead><tr><th>Job</th><th>Status</th><th>Units</th><th>Started</th><th>Branch</th>${dashboard.controlEnabled ? '<th></th>' : ''}</tr></thead>
+        <tbody>${jobs.map(renderJob).join('')}</tbody>
+    </table>`;
+}
+
+function renderJob(job) {
+    const status = job.paused ? 'paused' : job.status;
+    const live = job.live ? '<span class="live-badge">live</span>' : '';
+    const title = job.featureBranch || job.id;
+    const branch = job.repoPath
+        ? `${escapeHTML(job.repoPath)} &rarr; ${escapeHTML(job.targetBranch)}`
+        : '';
+    const error = job.error ? `<div class="job-error">${escapeHTML(job.error)}</div>` : '';
+    const actions = dashboard.controlEnabled
+        ? `<td class="job-actions">${jobActions(job).join('')}</td>`
+        : '';
+
+    return `<tr class="dashboard-row" data-status="${escapeHTML(status)}">
+        <td><a class="job-link" href="/jobs/${encodeURIComponent(job.id)}" title="${escapeHTML(job.id)}">${escapeHTML(title)}</a>${error}</td>
+        <td><span class="job-status">${escapeHTML(status)}</span>${live}</td>
+        <td>${renderSummary(job.summary)}</td>
+        <td title="${job.startedAt ? escapeHTML(new Date(job.startedAt).toLocaleString()) : ''}">${job.startedAt ? timeAgo(job.startedAt) : 'queued'}</td>
+        <td class="job-meta">${branch}</td>
+        ${actions}
+    </tr>`;
+}
+
+// renderSummary shows a job's units as a bar of complete, running and
+// failed, when the server has seen its events
+function renderSummary(summary) {
+    if (!summary || summary.total === 0) return '<span class="job-meta">&mdash;</span>';
+    const width = n => `${(n / summary.total) * 100}%`;
+    const failed = summary.failed + summary.blocked;
+    return `<div class="summary-bar" title="${summary.complete} complete, ${summary.inProgress} in progress, ${failed} failed or blocked of ${summary.total}">
+        <span class="complete" style="width: ${width(summary.complete)}"></span>
+        <span class="in-progress" style="width: ${width(summary.inProgress)}"></span>
+        <span class="failed" style="width: ${width(failed)}"></span>
+    </div>
+    <span class="job-meta">${summary.complete}/${summary.total}</span>`;
+}
+
+function timeAgo(time) {
+    const seconds = Math.max(0, (Date.now() - new Date(time)) / 1000);
+    if (seconds < 60) return 'just now';
+    if (seconds < 3600) return `${Math.floor(seconds / 60)}m ago`;
+    if (seconds < 86400) return `${Math.floor(seconds / 3600)}h ago`;
+    return new Date(time).toLocaleDateString();
+}
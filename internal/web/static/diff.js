@@ -0,0 +1,196 @@
+// diff.js - Side-by-side diff viewer for a unit's changes
+
+import { escapeHTML } from './html.js';
+
+// Languages highlighted by file extension, when highlight.js has loaded
+const LANGUAGES = {
+    go: 'go', js: 'javascript', mjs: 'javascript', ts: 'typescript', tsx: 'typescript',
+    py: 'python', rb: 'ruby', rs: 'rust', java: 'java', c: 'c', h: 'c', cc: 'cpp', cpp: 'cpp',
+    sh: 'bash', bash: 'bash', json: 'json', yaml: 'yaml', yml: 'yaml', toml: 'ini',
+    md: 'markdown', html: 'xml', xml: 'xml', css: 'css', sql: 'sql', proto: 'protobuf'
+};
+
+const viewer = {
+    panel: null,
+    api: '',
+    unit: null
+};
+
+// initDiffViewer shows the changes of the units of jobID in panel
+export function initDiffViewer(panel, jobID) {
+    if (!panel) return;
+    viewer.panel = panel;
+    viewer.api = `/api/jobs/${encodeURIComponent(jobID)}`;
+
+    panel.querySelector('#diff-close').addEventListener('click', closeDiff);
+    panel.querySelector('#diff-commit').addEventListener('change', (e) => {
+        loadDiff(viewer.unit, e.target.value);
+    });
+    panel.querySelector('#diff-tree').addEventListener('click', (e) => {
+        const link = e.target.closest('[data-file]');
+        if (!link) return;
+        document.getElementById(`diff-file-${link.dataset.file}`)?.scrollIntoView({ block: 'start' });
+    });
+}
+
+// Show unitID's changes since its base, with its commits to pick from
+export async function openDiff(unitID) {
+    const panel = viewer.panel;
+    if (!panel) return;
+
+    viewer.unit = unitID;
+    panel.querySelector('#diff-title').textContent = `Changes: ${unitID}`;
+    panel.classList.remove('hidden');
+
+    const select = panel.querySelector('#diff-commit');
+    select.innerHTML = '<option value="">All changes</option>';
+    try {
+        const response = await fetch(`${viewer.api}/units/${encodeURIComponent(unitID)}/commits`);
+        const data = await response.json();
+        if (viewer.unit !== unitID) return;
+        if (!response.ok) {
+            showMessage(data.error || response.statusText);
+            return;
+        }
+        select.innerHTML += data.commits.map(commit => {
+            const label = commit.task != null ? `Task #${commit.task}` : commit.hash.slice(0, 7);
+            return `<option value="${escapeHTML(commit.hash)}">${escapeHTML(label)}: ${escapeHTML(commit.subject)}</option>`;
+        }).join('');
+    } catch (err) {
+        console.error('Failed to load commits:', err);
+    }
+    loadDiff(unitID, '');
+}
+
+export function closeDiff() {
+    viewer.panel?.classList.add('hidden');
+    viewer.unit = null;
+}
+
+async function loadDiff(unitID, commit) {
+    const query = commit ? `?commit=${encodeURIComponent(commit)}` : '';
+    showMessage('Loading...');
+    try {
+        const response = await fetch(`${viewer.api}/units/${encodeURIComponent(unitID)}/diff${query}`);
+        const diff = await response.json();
+        if (viewer.unit !== unitID) return;
+        if (!response.ok) {
+            showMessage(diff.error || response.statusText);
+            return;
+        }
+        render(diff);
+    } catch (err) {
+        console.error('Failed to load diff:', err);
+        showMessage('Failed to load diff');
+    }
+}
+
+function showMessage(message) {
+    const panel = viewer.panel;
+    panel.querySelector('#diff-tree').innerHTML = '';
+    panel.querySelector('#diff-stats').textContent = '';
+    panel.querySelector('#diff-files').innerHTML = `<div class="diff-empty">${escapeHTML(message)}</div>`;
+}
+
+function render(diff) {
+    const panel = viewer.panel;
+    const index = new Map(diff.files.map((file, i) => [file.path, i]));
+    panel.querySelector('#diff-tree').innerHTML = renderTree(diff.tree, index);
+
+    const additions = diff.files.reduce((n, f) => n + f.additions, 0);
+    const deletions = diff.files.reduce((n, f) => n + f.deletions, 0);
+    panel.querySelector('#diff-stats').innerHTML =
+        `${diff.files.length} files <span class="diff-add">+${additions}</span> <span class="diff-del">-${deletions}</span>`;
+
+    const files = panel.querySelector('#diff-files');
+    if (diff.files.length === 0) {
+        files.innerHTML = '<div class="diff-empty">No changes</div>';
+        return;
+    }
+    const notice = diff.truncated
+        ? '<div class="diff-empty">The diff is too large to show whole; later files are left out</div>'
+        : '';
+    files.innerHTML = notice + diff.files.map(renderFile).join('');
+    files.scrollTop = 0;
+}
+
+// renderTree renders the changed files nested under their directories
+function renderTree(nodes, index) {
+    return '<ul>' + nodes.map(node => {
+        if (node.children) {
+            return `<li><details open><summary>${escapeHTML(node.name)}</summary>${renderTree(node.children, index)}</details></li>`;
+        }
+        return `<li><a class="diff-tree-file" data-status="${escapeHTML(node.status)}" data-file="${index.get(node.path)}" title="${escapeHTML(node.path)}">${escapeHTML(node.name)}</a></li>`;
+    }).join('') + '</ul>';
+}
+
+function renderFile(file, i) {
+    const name = file.oldPath
+        ? `${escapeHTML(file.oldPath)} &rarr; ${escapeHTML(file.path)}`
+        : escapeHTML(file.path);
+    let body;
+    if (file.binary) {
+        body = '<div class="diff-empty">Binary file not shown</div>';
+    } else if (file.hunks.length === 0) {
+        body = '<div class="diff-empty">No content changes</div>';
+    } else {
+        const lang = language(file.path);
+        const cols = '<colgroup><col class="diff-num-col"><col><col class="diff-num-col"><col></colgroup>';
+        body = `<table class="diff-table">${cols}${file.hunks.map(hunk => renderHunk(hunk, lang)).join('')}</table>`;
+    }
+
+    return `<section class="diff-file" id="diff-file-${i}">
+        <div class="diff-file-header">
+            <span class="diff-file-status" data-status="${escapeHTML(file.status)}">${escapeHTML(file.status)}</span>
+            <span class="diff-file-path">${name}</span>
+            <span class="diff-add">+${file.additions}</span> <span class="diff-del">-${file.deletions}</span>
+        </div>
+        ${body}
+    </section>`;
+}
+
+// renderHunk lays a hunk out side by side: deletions on the left beside the
+// additions that replace them on the right
+function renderHunk(hunk, lang) {
+    const rows = [`<tr class="diff-hunk"><td colspan="4">${escapeHTML(hunk.header)}</td></tr>`];
+    const lines = hunk.lines;
+
+    for (let i = 0; i < lines.length;) {
+        if (lines[i].kind === 'context') {
+            rows.push(renderRow(lines[i], lines[i], lang));
+            i++;
+            continue;
+        }
+        const deleted = [];
+        const added = [];
+        while (i < lines.length && lines[i].kind === 'delete') deleted.push(lines[i++]);
+        while (i < lines.length && lines[i].kind === 'add') added.push(lines[i++]);
+        for (let j = 0; j < Math.max(deleted.length, added.length); j++) {
+            rows.push(renderRow(deleted[j], added[j], lang));
+        }
+    }
+    return rows.join('');
+}
+
+function renderRow(left, right, lang) {
+    const side = (line, number, kind) => {
+        if (!line) return '<td class="diff-num empty"></td><td class="diff-code empty"></td>';
+        const cls = line.kind === 'context' ? '' : kind;
+        return `<td class="diff-num ${cls}">${number}</td><td class="diff-code ${cls}">${highlight(line.text, lang)}</td>`;
+    };
+    return `<tr>${side(left, left?.old, 'del')}${side(right, right?.new, 'add')}</tr>`;
+}
+
+function language(path) {
+    const ext = path.split('.').pop().toLowerCase();
+    const lang = LANGUAGES[ext];
+    return lang && window.hljs?.getLanguage(lang) ? lang : null;
+}
+
+// highlight returns a line as HTML, syntax highlighted when its language is
+// known. Lines are highlighted on their own, so constructs spanning lines,
+// such as block comments, are only partly colored.
+function highlight(text, lang) {
+    if (!lang) return escapeHTML(text);
+    return window.hljs.highlight(text, { language: lang, ignoreIllegals: true }).value;
+}
@@ -0,0 +1,11 @@
+// html.js - Helpers for building markup from untrusted strings
+
+// escapeHTML makes s safe to place in element content and quoted
+// attribute values.
+export function escapeHTML(s) {
+    return String(s ?? '')
+        .replace(/&/g, '&amp;')
+        .replace(/</g, '&lt;')
+        .replace(/>/g, '&gt;')
+        .replace(/"/g, '&quot;');
+}
@@ -0,0 +1,155 @@
+// jobs.js - Job control panel
+
+import { escapeHTML } from './html.js';
+
+const REFRESH_INTERVAL = 5000;
+const TOKEN_KEY = 'choo.controlToken';
+
+let notify = () => {};
+
+// Show the panel if the server allows job control, listing jobs now and
+// periodically. Resolves to whether control is enabled.
+export async function initJobs(panel, options = {}) {
+    if (!panel) return false;
+    notify = options.notify || notify;
+
+    try {
+        const response = await fetch('/api/control');
+        const info = await response.json();
+        if (!info.enabled) return false;
+    } catch (err) {
+        console.error('Failed to load control info:', err);
+        return false;
+    }
+    panel.classList.remove('hidden');
+
+    const tokenInput = panel.querySelector('#control-token');
+    tokenInput.value = localStorage.getItem(TOKEN_KEY) || '';
+    tokenInput.addEventListener('change', () => {
+        localStorage.setItem(TOKEN_KEY, tokenInput.value.trim());
+    });
+
+    const list = panel.querySelector('#job-list');
+    list.addEventListener('click', (e) => {
+        const button = e.target.closest('button[data-action]');
+        if (button) runAction(button.dataset.action, button.dataset.job);
+    });
+
+    panel.querySelector('#start-job-form').addEventListener('submit', (e) => {
+        e.preventDefault();
+        startJob(e.target);
+    });
+
+    refreshJobs();
+    setInterval(refreshJobs, REFRESH_INTERVAL);
+    return true;
+}
+
+// Fill in the start form for a PRD and open it
+export function startPRD(prdID) {
+    const form = document.getElementById('start-job-form');
+    if (!form) return;
+    form.elements.prd.value = prdID;
+    form.closest('details').open = true;
+    form.elements.workspace.focus();
+}
+
+export async function refreshJobs() {
+    const list = document.getElementById('job-list');
+    if (!list) return;
+    try {
+        const response = await fetch('/api/jobs');
+        renderJobs(list, await response.json());
+    } catch (err) {
+        console.error('Failed to load jobs:', err);
+    }
+}
+
+export function renderJobs(list, jobs) {
+    if (!jobs || jobs.length === 0) {
+        list.innerHTML = '<div class="job-empty">No jobs yet</div>';
+        return;
+    }
+    list.innerHTML = jobs.map(renderJob).join('');
+}
+
+function renderJob(job) {
+    const status = job.paused ? 'paused' : job.status;
+    const title = job.featureBranch || job.id;
+    const error = job.error
+        ? `<div class="job-error">${escapeHTML(job.error)}</div>`
+        : '';
+    return `<div class="job-item" data-status="${escapeHTML(status)}" title="${escapeHTML(job.id)}">
+        <div class="job-title"><a class="job-link" href="/jobs/${encodeURIComponent(job.id)}">${escapeHTML(title)}</a> <span class="job-status">${escapeHTML(status)}</span></div>
+        ${job.repoPath ? `<div class="job-meta">${escapeHTML(job.repoPath)} &rarr; ${escapeHTML(job.targetBranch)}</div>` : ''}
+        ${error}
+        <div class="job-actions">${jobActions(job).join('')}</div>
+    </div>`;
+}
+
+// jobActions returns the buttons for what can be done to job
+export function jobActions(job) {
+    const button = (action, label) =>
+        `<button class="job-btn" data-action="${action}" data-job="${escapeHTML(job.id)}">${label}</button>`;
+
+    if (job.status === 'running') {
+        return [
+            job.paused ? button('resume', 'Resume') : button('pause', 'Pause'),
+            button('stop', 'Stop')
+        ];
+    }
+    if (job.status === 'failed' || job.status === 'cancelled') {
+        return [button('retry', 'Retry')];
+    }
+    return [];
+}
+
+export async function runAction(action, jobID) {
+    try {
+        const result = await control(`/api/jobs/${encodeURIComponent(jobID)}/${action}`);
+        if (action === 'retry' && result.queueId) {
+            notify(`Retry queued as ${result.queueId}`, 'success');
+        } else if (action === 'retry') {
+            notify(`Retrying as job ${result.jobId}`, 'success');
+        }
+    } catch (err) {
+        notify(`Could not ${action} job: ${err.message}`, 'error');
+    }
+    refreshJobs();
+}
+
+async function startJob(form) {
+    const fields = Object.fromEntries(new FormData(form));
+    const request = {};
+    for (const [key, value] of Object.entries(fields)) {
+        if (value.trim() !== '') request[key] = value.trim();
+    }
+    if (request.parallelism) request.parallelism = Number(request.parallelism);
+
+    try {
+        const result = await control('/api/jobs', request);
+        notify(`Started job ${result.jobId}`, 'success');
+        form.reset();
+        form.closest('details').open = false;
+    } catch (err) {
+        notify(`Could not start job: ${err.message}`, 'error');
+    }
+    refreshJobs();
+}
+
+// control sends a control request with the stored token
+async function control(path, body) {
+    const response = await fetch(path, {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json',
+            'Authorization': `Bearer ${localStorage.getItem(TOKEN_KEY) || ''}`
+        },
+        body: body ? JSON.stringify(body) : undefined
+    });
+    const data = await response.json().catch(() => ({}));
+    if (!response.ok) {
+        throw new Error(data.error || response.statusText);
+    }
+    return data;
+}
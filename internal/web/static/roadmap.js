@@ -0,0 +1,75 @@
+// roadmap.js - PRD roadmap panel
+
+import { escapeHTML } from './html.js';
+
+const STATUSES = [
+    { key: 'in_progress', label: 'In Progress' },
+    { key: 'approved', label: 'Approved' },
+    { key: 'draft', label: 'Draft' },
+    { key: 'complete', label: 'Complete' }
+];
+
+const REFRESH_INTERVAL = 30000;
+
+// Fetch the roadmap now and periodically, rendering it into container.
+// With options.onStart, approved PRDs get a button to start them.
+export function initRoadmap(container, options = {}) {
+    if (!container) return;
+
+    if (options.onStart) {
+        container.addEventListener('click', (e) => {
+            const button = e.target.closest('button[data-prd]');
+            if (button) options.onStart(button.dataset.prd);
+        });
+    }
+
+    const refresh = async () => {
+        try {
+            const response = await fetch('/api/roadmap');
+            renderRoadmap(container, await response.json(), { startable: !!options.onStart });
+        } catch (err) {
+            console.error('Failed to load roadmap:', err);
+        }
+    };
+
+    refresh();
+    setInterval(refresh, REFRESH_INTERVAL);
+}
+
+// Render PRDs grouped by status; archived PRDs are left off
+export function renderRoadmap(container, roadmap, options = {}) {
+    const panel = container.closest('.roadmap-card');
+    const prds = roadmap.prds || [];
+    if (panel) panel.classList.toggle('hidden', prds.length === 0 && !roadmap.error);
+
+    const groups = STATUSES.map(({ key, label }) => {
+        const items = prds.filter(p => p.status === key);
+        if (items.length === 0) return '';
+        return `<div class="roadmap-group" data-status="${key}">
+            <h4>${label} <span class="roadmap-count">${items.length}</span></h4>
+            ${items.map(prd => renderPRD(prd, options.startable && key === 'approved')).join('')}
+        </div>`;
+    }).join('');
+
+    const error = roadmap.error
+        ? `<div class="roadmap-error">${escapeHTML(roadmap.error)}</div>`
+        : '';
+    container.innerHTML = error + groups;
+}
+
+function renderPRD(prd, startable) {
+    const estimate = prd.estimatedUnits
+        ? `<span class="roadmap-estimate">${prd.estimatedUnits}u / ${prd.estimatedTasks || '?'}t</span>`
+        : '';
+    const waiting = prd.waitingOn && prd.waitingOn.length > 0
+        ? `<div class="roadmap-waiting">waiting on ${prd.waitingOn.map(escapeHTML).join(', ')}</div>`
+        : '';
+    const start = startable
+        ? `<button class="job-btn roadmap-start" data-prd="${escapeHTML(prd.id)}">Start</button>`
+        : '';
+    return `<div class="roadmap-item" title="${escapeHTML(prd.id)}">
+        <div class="roadmap-title">${escapeHTML(prd.title)} ${estimate}</div>
+        ${start}
+        ${waiting}
+    </div>`;
+}
@@ -0,0 +1,61 @@
+// timeline.js - When each unit of a job ran
+
+import { escapeHTML } from './html.js';
+
+// renderTimeline draws a bar per unit that has started, from its start to
+// its completion, or to now while it runs. The axis starts when the job
+// started.
+export function renderTimeline(container, units, startedAt, onSelect) {
+    if (!container) return;
+
+    const started = units.filter(u => isSet(u.startedAt));
+    if (started.length === 0) {
+        container.innerHTML = '<div class="timeline-empty">No units have started yet</div>';
+        return;
+    }
+
+    const now = Date.now();
+    const end = u => isSet(u.completedAt) ? new Date(u.completedAt).getTime() : now;
+    const start = isSet(startedAt)
+        ? new Date(startedAt).getTime()
+        : Math.min(...started.map(u => new Date(u.startedAt).getTime()));
+    const last = Math.max(...started.map(end));
+    const span = Math.max(last - start, 1000);
+
+    started.sort((a, b) => new Date(a.startedAt) - new Date(b.startedAt));
+    const rows = started.map(unit => {
+        const from = new Date(unit.startedAt).getTime();
+        const left = ((from - start) / span) * 100;
+        const width = Math.max(((end(unit) - from) / span) * 100, 0.5);
+        const running = !isSet(unit.completedAt);
+        return `<div class="timeline-row">
+            <a class="timeline-label" data-unit="${escapeHTML(unit.id)}" title="${escapeHTML(unit.id)}">${escapeHTML(unit.id)}</a>
+            <div class="timeline-track">
+                <div class="timeline-bar ${running ? 'running' : ''}" data-status="${escapeHTML(unit.status)}"
+                    style="left: ${left}%; width: ${width}%"
+                    title="${escapeHTML(unit.id)}: ${formatDuration(end(unit) - from)}${running ? ' so far' : ''}"></div>
+            </div>
+        </div>`;
+    });
+
+    container.innerHTML = `<div class="timeline-axis"><span>0s</span><span>${formatDuration(span)}</span></div>${rows.join('')}`;
+    if (onSelect) {
+        container.querySelectorAll('[data-unit]').forEach(label => {
+            label.addEventListener('click', () => onSelect(label.dataset.unit));
+        });
+    }
+}
+
+// isSet reports whether a time from the API is set; unset times are
+// Go's zero time
+function isSet(time) {
+    return !!time && !time.startsWith('0001-');
+}
+
+function formatDuration(ms) {
+    const seconds = Math.round(ms / 1000);
+    if (seconds < 60) return `${seconds}s`;
+    const minutes = Math.floor(seconds / 60);
+    if (minutes < 60) return `${minutes}m ${seconds % 60}s`;
+    return `${Math.floor(minutes / 60)}h ${minutes % 60}m`;
+}
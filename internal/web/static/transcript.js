@@ -0,0 +1,163 @@
+// transcript.js - Live provider transcript for a unit
+
+import { escapeHTML } from './html.js';
+
+const MAX_ENTRIES = 2000;
+
+// How close to the bottom, in pixels, counts as following the transcript
+const FOLLOW_THRESHOLD = 40;
+
+const transcript = {
+    panel: null,
+    api: '',
+    unit: null,
+    entries: [],
+    dropped: 0,
+    query: '',
+    following: true
+};
+
+// initTranscript shows the transcripts of the units of jobID in panel
+export function initTranscript(panel, jobID) {
+    if (!panel) return;
+    transcript.panel = panel;
+    transcript.api = `/api/jobs/${encodeURIComponent(jobID)}`;
+
+    panel.querySelector('#transcript-close').addEventListener('click', closeTranscript);
+
+    const search = panel.querySelector('#transcript-search');
+    search.addEventListener('input', () => {
+        transcript.query = search.value.trim().toLowerCase();
+        render();
+    });
+
+    const list = panel.querySelector('#transcript-list');
+    list.addEventListener('scroll', () => {
+        transcript.following =
+            list.scrollHeight - list.scrollTop - list.clientHeight < FOLLOW_THRESHOLD;
+    });
+}
+
+// Show unitID's transcript so far, then follow it live
+export async function openTranscript(unitID) {
+    const panel = transcript.panel;
+    if (!panel) return;
+
+    transcript.unit = unitID;
+    transcript.entries = [];
+    transcript.dropped = 0;
+    transcript.following = true;
+    panel.querySelector('#transcript-title').textContent = `Transcript: ${unitID}`;
+    panel.classList.remove('hidden');
+    render();
+
+    try {
+        const response = await fetch(`${transcript.api}/units/${encodeURIComponent(unitID)}/transcript`);
+        const data = await response.json();
+        // Another unit may have been opened while this one loaded
+        if (transcript.unit !== unitID) return;
+        // The response holds what arrived live while it loaded
+        transcript.entries = data.entries || [];
+        transcript.dropped = data.dropped || 0;
+        trim();
+        render();
+    } catch (err) {
+        console.error('Failed to load transcript:', err);
+    }
+}
+
+export function closeTranscript() {
+    transcript.panel?.classList.add('hidden');
+    transcript.unit = null;
+    transcript.entries = [];
+}
+
+// Append the entries of a task.output event if its unit is open
+export function handleOutput(event) {
+    if (!transcript.unit || event.unit !== transcript.unit) return;
+
+    const payload = event.payload || {};
+    for (const entry of payload.entries || []) {
+        transcript.entries.push({ ...entry, time: event.time, task: event.task });
+    }
+    transcript.dropped += payload.dropped || 0;
+    trim();
+    render();
+}
+
+// trim drops the oldest entries beyond MAX_ENTRIES
+function trim() {
+    const over = transcript.entries.length - MAX_ENTRIES;
+    if (over > 0) {
+        transcript.entries.splice(0, over);
+        transcript.dropped += over;
+    }
+}
+
+function render() {
+    const panel = transcript.panel;
+    if (!panel) return;
+
+    const list = panel.querySelector('#transcript-list');
+    const query = transcript.query;
+    const entries = query
+        ? transcript.entries.filter(entry => entryText(entry).toLowerCase().includes(query))
+        : transcript.entries;
+
+    if (entries.length === 0) {
+        list.innerHTML = `<div class="transcript-empty">${query ? 'No matches' : 'No output yet'}</div>`;
+    } else {
+        list.innerHTML = entries.map(entry => renderEntry(entry, query)).join('');
+    }
+
+    const meta = panel.querySelector('#transcript-meta');
+    const parts = [];
+    if (query) parts.push(`${entries.length} of ${transcript.entries.length}`);
+    if (transcript.dropped > 0) parts.push(`${transcript.dropped} dropped`);
+    meta.textContent = parts.join(' · ');
+
+    if (transcript.following && !query) {
+        list.scrollTop = list.scrollHeight;
+    }
+}
+
+function renderEntry(entry, query) {
+    const time = entry.time ? new Date(entry.time).toLocaleTimeString() : '';
+    const task = entry.task != null ? `<span class="transcript-task">#${entry.task}</span>` : '';
+    const label = {
+        text: 'agent',
+        tool_use: escapeHTML(entry.tool || 'tool'),
+        tool_result: entry.is_error ? 'error' : 'result',
+        error: 'error'
+    }[entry.kind] || escapeHTML(entry.kind);
+    const body = entry.kind === 'tool_use' ? entry.input : entry.text;
+    const failed = entry.kind === 'error' || entry.is_error;
+
+    return `<div class="transcript-entry ${failed ? 'error' : ''}" data-kind="${escapeHTML(entry.kind)}">
+        <div class="transcript-entry-head">
+            <span class="time">${time}</span>${task}
+            <span class="transcript-label">${label}</span>
+        </div>
+        <div class="transcript-text">${highlight(body || '', query)}</div>
+    </div>`;
+}
+
+// entryText is what search matches against
+function entryText(entry) {
+    return [entry.text, entry.tool, entry.input].filter(Boolean).join('\n');
+}
+
+// highlight escapes text and marks the matches of query in it
+function highlight(text, query) {
+    if (!query) return escapeHTML(text);
+
+    const lower = text.toLowerCase();
+    let html = '';
+    let from = 0;
+    for (let at = lower.indexOf(query); at !== -1; at = lower.indexOf(query, from)) {
+        html += escapeHTML(text.slice(from, at));
+        html += `<mark>${escapeHTML(text.slice(at, at + query.length))}</mark>`;
+        from = at + query.length;
+    }
+    return html + escapeHTML(text.slice(from));
+}
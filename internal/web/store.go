@@ -6,41 +6,65 @@ import (
 	"time"
 )
 
-// Store maintains the current orchestration state.
+// maxTranscriptEntries bounds the scrollback kept for each unit; the
+// oldest entries go first
+const maxTranscriptEntries = 2000
+
+// maxStoreEvents bounds the events kept for the event log; the oldest go
+// first. task.output events are kept in the transcripts instead.
+const maxStoreEvents = 1000
+
+// Store maintains the orchestration state of one run.
 // It is safe for concurrent access.
 type Store struct {
 	mu             sync.RWMutex
 	connectedCount int               // number of connected jobs (for concurrent job support)
-	status         string            // "waiting", "running", "completed", "failed"
+	status         string            // "waiting", "running", "paused", "completed", "failed"
 	startedAt      time.Time
 	parallelism    int
 	graph          *GraphData
 	units          map[string]*UnitState
+	transcripts    map[string]*TranscriptData // by unit ID
+	repos          map[string]*UnitRepo       // by unit ID
+	events         []*Event
+	droppedEvents  int
 }
 
 // NewStore creates an empty state store in "waiting" status.
 func NewStore() *Store {
 	return &Store{
-		status: "waiting",
-		units:  make(map[string]*UnitState),
+		status:      "waiting",
+		units:       make(map[string]*UnitState),
+		transcripts: make(map[string]*TranscriptData),
+		repos:       make(map[string]*UnitRepo),
 	}
 }
 
 // HandleEvent processes an event and updates state accordingly.
-// Thread-safe. Event type determines state transition:
+// Thread-safe. The event is added to the event log, and its type
+// determines the state transition:
 //   - orch.started: set status="running", store graph, init units
 //   - unit.queued: set unit status to "ready"
-//   - unit.started: set unit status to "in_progress", set startedAt
+//   - unit.started: set unit status to "in_progress", set startedAt, record
+//     the unit's worktree
 //   - task.started: increment currentTask
-//   - unit.completed: set unit status to "complete"
-//   - unit.failed: set unit status to "failed", store error
+//   - unit.completed: set unit status to "complete", set completedAt
+//   - unit.failed: set unit status to "failed", store error, set completedAt
+//   - unit.merged: record the commit the unit was rebased onto
 //   - unit.blocked: set unit status to "blocked"
+//   - task.output: append to the unit's transcript
+//   - orch.paused: set status="paused"
+//   - orch.resumed: set status="running"
 //   - orch.completed: set status="completed"
 //   - orch.failed: set status="failed"
 func (s *Store) HandleEvent(e *Event) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if e.Type != "task.output" {
+		s.appendEvent(e)
+	}
+
 	switch e.Type {
 	case "orch.started":
 		var payload OrchestratorPayload
@@ -85,6 +109,7 @@ func (s *Store) HandleEvent(e *Event) {
 		}
 
 	case "unit.started":
+		s.recordRepo(e)
 		if unit, ok := s.units[e.Unit]; ok {
 			unit.Status = "in_progress"
 			unit.StartedAt = e.Time
@@ -114,6 +139,7 @@ func (s *Store) HandleEvent(e *Event) {
 	case "unit.completed":
 		if unit, ok := s.units[e.Unit]; ok {
 			unit.Status = "complete"
+			unit.CompletedAt = e.Time
 			// Set to last task (0-indexed) so display shows "N of N"
 			if unit.TotalTasks > 0 {
 				unit.CurrentTask = unit.TotalTasks - 1
@@ -124,13 +150,26 @@ func (s *Store) HandleEvent(e *Event) {
 		if unit, ok := s.units[e.Unit]; ok {
 			unit.Status = "failed"
 			unit.Error = e.Error
+			unit.CompletedAt = e.Time
 		}
 
+	case "unit.merged":
+		s.recordRepo(e)
+
 	case "unit.blocked":
 		if unit, ok := s.units[e.Unit]; ok {
 			unit.Status = "blocked"
 		}
 
+	case "task.output":
+		s.appendTranscript(e)
+
+	case "orch.paused":
+		s.status = "paused"
+
+	case "orch.resumed":
+		s.status = "running"
+
 	case "orch.completed":
 		s.status = "completed"
 
@@ -139,6 +178,107 @@ func (s *Store) HandleEvent(e *Event) {
 	}
 }
 
+// appendEvent adds an event to the event log. Callers hold s.mu.
+func (s *Store) appendEvent(e *Event) {
+	s.events = append(s.events, e)
+	if over := len(s.events) - maxStoreEvents; over > 0 {
+		s.events = append([]*Event(nil), s.events[over:]...)
+		s.droppedEvents += over
+	}
+}
+
+// Events returns a copy of the event log, oldest first, and the number of
+// events left out of it. Thread-safe.
+func (s *Store) Events() ([]*Event, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]*Event{}, s.events...), s.droppedEvents
+}
+
+// recordRepo records where a unit's work is from the branch, worktree and
+// base_commit in a unit.started or unit.merged payload. Callers hold s.mu.
+func (s *Store) recordRepo(e *Event) {
+	var payload struct {
+		Branch     string `json:"branch"`
+		Worktree   string `json:"worktree"`
+		BaseCommit string `json:"base_commit"`
+	}
+	if e.Unit == "" || json.Unmarshal(e.Payload, &payload) != nil {
+		return
+	}
+
+	repo, ok := s.repos[e.Unit]
+	if !ok {
+		repo = &UnitRepo{}
+		s.repos[e.Unit] = repo
+	}
+	if payload.Branch != "" {
+		repo.Branch = payload.Branch
+	}
+	if payload.Worktree != "" {
+		repo.Worktree = payload.Worktree
+	}
+	if payload.BaseCommit != "" {
+		repo.Base = payload.BaseCommit
+	}
+}
+
+// UnitRepo returns where a unit's work is, and false if the unit has not
+// started. Thread-safe.
+func (s *Store) UnitRepo(unitID string) (UnitRepo, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	repo, ok := s.repos[unitID]
+	if !ok {
+		return UnitRepo{}, false
+	}
+	return *repo, true
+}
+
+// appendTranscript adds the entries of a task.output event to its unit's
+// transcript. Callers hold s.mu.
+func (s *Store) appendTranscript(e *Event) {
+	var payload struct {
+		Entries []TranscriptEntry `json:"entries"`
+		Dropped int               `json:"dropped"`
+	}
+	if e.Unit == "" || json.Unmarshal(e.Payload, &payload) != nil {
+		return
+	}
+
+	t, ok := s.transcripts[e.Unit]
+	if !ok {
+		t = &TranscriptData{Unit: e.Unit}
+		s.transcripts[e.Unit] = t
+	}
+	for i := range payload.Entries {
+		payload.Entries[i].Time = e.Time
+		payload.Entries[i].Task = e.Task
+	}
+	t.Entries = append(t.Entries, payload.Entries...)
+	t.Dropped += payload.Dropped
+	if over := len(t.Entries) - maxTranscriptEntries; over > 0 {
+		t.Entries = append([]TranscriptEntry(nil), t.Entries[over:]...)
+		t.Dropped += over
+	}
+}
+
+// Transcript returns a copy of a unit's transcript, empty if it has none.
+// Thread-safe.
+func (s *Store) Transcript(unitID string) *TranscriptData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data := &TranscriptData{Unit: unitID, Entries: []TranscriptEntry{}}
+	if t, ok := s.transcripts[unitID]; ok {
+		data.Entries = append(data.Entries, t.Entries...)
+		data.Dropped = t.Dropped
+	}
+	return data
+}
+
 // Snapshot returns the current state as a StateSnapshot.
 // Thread-safe for concurrent reads.
 func (s *Store) Snapshot() *StateSnapshot {
@@ -156,6 +296,7 @@ func (s *Store) Snapshot() *StateSnapshot {
 			TotalTasks:  unit.TotalTasks,
 			Error:       unit.Error,
 			StartedAt:   unit.StartedAt,
+			CompletedAt: unit.CompletedAt,
 		}
 		units = append(units, unitCopy)
 	}
@@ -230,4 +371,8 @@ func (s *Store) Reset() {
 	s.parallelism = 0
 	s.graph = nil
 	s.units = make(map[string]*UnitState)
+	s.transcripts = make(map[string]*TranscriptData)
+	s.repos = make(map[string]*UnitRepo)
+	s.events = nil
+	s.droppedEvents = 0
 }
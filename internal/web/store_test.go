@@ -223,6 +223,21 @@ func TestStore_HandleOrchFailed(t *testing.T) {
 	}
 }
 
+func TestStore_HandleOrchPausedResumed(t *testing.T) {
+	store := NewStore()
+	store.status = "running"
+
+	store.HandleEvent(&Event{Type: "orch.paused", Time: time.Now()})
+	if store.status != "paused" {
+		t.Errorf("expected status 'paused', got '%s'", store.status)
+	}
+
+	store.HandleEvent(&Event{Type: "orch.resumed", Time: time.Now()})
+	if store.status != "running" {
+		t.Errorf("expected status 'running', got '%s'", store.status)
+	}
+}
+
 func TestStore_SummaryCalculation(t *testing.T) {
 	store := NewStore()
 
@@ -430,3 +445,118 @@ func TestStore_ConcurrentAccess(t *testing.T) {
 		t.Error("expected non-nil snapshot after concurrent access")
 	}
 }
+
+func TestStore_HandleTaskOutput(t *testing.T) {
+	store := NewStore()
+	task := 1
+	now := time.Now()
+
+	store.HandleEvent(&Event{
+		Type:    "task.output",
+		Time:    now,
+		Unit:    "unit-a",
+		Task:    &task,
+		Payload: json.RawMessage(`{"entries":[{"kind":"text","text":"Reading"},{"kind":"tool_use","tool":"Read","input":"main.go"}],"dropped":3}`),
+	})
+
+	transcript := store.Transcript("unit-a")
+	if len(transcript.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(transcript.Entries))
+	}
+	entry := transcript.Entries[1]
+	if entry.Kind != "tool_use" || entry.Tool != "Read" || entry.Input != "main.go" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if !entry.Time.Equal(now) {
+		t.Errorf("expected entry time %v, got %v", now, entry.Time)
+	}
+	if entry.Task == nil || *entry.Task != 1 {
+		t.Errorf("expected entry task 1, got %v", entry.Task)
+	}
+	if transcript.Dropped != 3 {
+		t.Errorf("expected 3 dropped, got %d", transcript.Dropped)
+	}
+
+	if other := store.Transcript("unit-b"); other.Entries == nil || len(other.Entries) != 0 {
+		t.Errorf("expected empty transcript, got %+v", other)
+	}
+}
+
+func TestStore_TranscriptBounded(t *testing.T) {
+	store := NewStore()
+
+	entry := `{"kind":"tool_use","tool":"Bash"}`
+	entries := entry
+	for i := 1; i < maxTranscriptEntries; i++ {
+		entries += "," + entry
+	}
+	payload := json.RawMessage(`{"entries":[` + entries + `]}`)
+	for i := 0; i < 2; i++ {
+		store.HandleEvent(&Event{Type: "task.output", Time: time.Now(), Unit: "unit-a", Payload: payload})
+	}
+
+	transcript := store.Transcript("unit-a")
+	if len(transcript.Entries) != maxTranscriptEntries {
+		t.Errorf("expected %d entries, got %d", maxTranscriptEntries, len(transcript.Entries))
+	}
+	if transcript.Dropped != maxTranscriptEntries {
+		t.Errorf("expected %d dropped, got %d", maxTranscriptEntries, transcript.Dropped)
+	}
+}
+
+func TestStore_UnitRepo(t *testing.T) {
+	store := NewStore()
+
+	if _, ok := store.UnitRepo("unit-a"); ok {
+		t.Error("expected no repo before the unit starts")
+	}
+
+	store.HandleEvent(&Event{
+		Type:    "unit.started",
+		Time:    time.Now(),
+		Unit:    "unit-a",
+		Payload: json.RawMessage(`{"branch":"ralph/unit-a-abc123","worktree":"/wt/unit-a","base_commit":"base1"}`),
+	})
+	repo, ok := store.UnitRepo("unit-a")
+	if !ok {
+		t.Fatal("expected repo after the unit starts")
+	}
+	if repo != (UnitRepo{Worktree: "/wt/unit-a", Branch: "ralph/unit-a-abc123", Base: "base1"}) {
+		t.Errorf("unexpected repo %+v", repo)
+	}
+
+	// Merging rebases the unit onto a new base
+	store.HandleEvent(&Event{
+		Type:    "unit.merged",
+		Time:    time.Now(),
+		Unit:    "unit-a",
+		Payload: json.RawMessage(`{"branch":"ralph/unit-a-abc123","base_commit":"base2","head_commit":"head2"}`),
+	})
+	repo, _ = store.UnitRepo("unit-a")
+	if repo.Base != "base2" || repo.Worktree != "/wt/unit-a" {
+		t.Errorf("expected base2 in /wt/unit-a, got %+v", repo)
+	}
+}
+
+func TestStore_EventLog(t *testing.T) {
+	store := NewStore()
+
+	store.HandleEvent(&Event{Type: "unit.started", Time: time.Now(), Unit: "unit-a"})
+	store.HandleEvent(&Event{Type: "task.output", Time: time.Now(), Unit: "unit-a", Payload: json.RawMessage(`{"entries":[]}`)})
+	for i := 0; i < maxStoreEvents; i++ {
+		store.HandleEvent(&Event{Type: "task.started", Time: time.Now(), Unit: "unit-a"})
+	}
+
+	// task.output is kept in the transcript instead, and the oldest events
+	// go first
+	events, dropped := store.Events()
+	if len(events) != maxStoreEvents {
+		t.Errorf("expected %d events, got %d", maxStoreEvents, len(events))
+	}
+	if dropped != 1 {
+		t.Errorf("expected 1 dropped, got %d", dropped)
+	}
+	if events[0].Type != "task.started" {
+		t.Errorf("expected the unit.started event to be dropped, got %s", events[0].Type)
+	}
+}
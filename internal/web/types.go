@@ -1,7 +1,9 @@
 package web
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	"net/http"
 	"os"
 	"time"
 )
@@ -9,6 +11,7 @@ import (
 // Event represents a message received from the orchestrator via Unix socket.
 // Events are sent as newline-delimited JSON.
 type Event struct {
+	Job     string          `json:"job,omitempty"` // job the event belongs to
 	Type    string          `json:"type"`
 	Time    time.Time       `json:"time"`
 	Unit    string          `json:"unit,omitempty"`
@@ -21,6 +24,7 @@ type Event struct {
 // WireEvent is the JSON structure sent over the socket
 // Maps closely to events.Event but with explicit JSON serialization
 type WireEvent struct {
+	Job     string    `json:"job,omitempty"`
 	Type    string    `json:"type"`
 	Time    time.Time `json:"time"`
 	Unit    string    `json:"unit,omitempty"`
@@ -92,13 +96,14 @@ type UnitState struct {
 	TotalTasks  int       `json:"totalTasks"`
 	Error       string    `json:"error,omitempty"`
 	StartedAt   time.Time `json:"startedAt,omitempty"`
+	CompletedAt time.Time `json:"completedAt,omitempty"` // when the unit completed or failed
 }
 
 // StateSnapshot is the response for GET /api/state.
 // Provides the complete current state of the orchestration.
 type StateSnapshot struct {
 	Connected   bool         `json:"connected"`
-	Status      string       `json:"status"` // "waiting", "running", "completed", "failed"
+	Status      string       `json:"status"` // "waiting", "running", "paused", "completed", "failed"
 	StartedAt   *time.Time   `json:"startedAt,omitempty"`
 	Parallelism int          `json:"parallelism,omitempty"`
 	Units       []*UnitState `json:"units"`
@@ -115,6 +120,183 @@ type StateSummary struct {
 	Blocked    int `json:"blocked"`
 }
 
+// RoadmapData is the response for GET /api/roadmap.
+// Lists the PRDs in the PRD directory with their dependencies.
+type RoadmapData struct {
+	PRDs   []RoadmapPRD `json:"prds"`
+	Levels [][]string   `json:"levels"`
+	Error  string       `json:"error,omitempty"` // e.g. a dependency cycle
+}
+
+// RoadmapPRD is a PRD on the roadmap.
+type RoadmapPRD struct {
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	Status         string   `json:"status"` // draft, approved, in_progress, complete or archived
+	FeatureStatus  string   `json:"featureStatus,omitempty"`
+	DependsOn      []string `json:"dependsOn,omitempty"`
+	WaitingOn      []string `json:"waitingOn,omitempty"` // incomplete dependencies
+	EstimatedUnits int      `json:"estimatedUnits,omitempty"`
+	EstimatedTasks int      `json:"estimatedTasks,omitempty"`
+}
+
+// TranscriptEntry is one step of a unit's provider transcript: text the
+// agent wrote, a tool it called or the tool's result.
+type TranscriptEntry struct {
+	Time    time.Time `json:"time"`
+	Task    *int      `json:"task,omitempty"`
+	Kind    string    `json:"kind"` // "text", "tool_use", "tool_result", "error"
+	Text    string    `json:"text,omitempty"`
+	Tool    string    `json:"tool,omitempty"`
+	Input   string    `json:"input,omitempty"` // summarized tool input
+	IsError bool      `json:"is_error,omitempty"`
+}
+
+// TranscriptData is the response for GET /api/units/{id}/transcript.
+type TranscriptData struct {
+	Unit    string            `json:"unit"`
+	Entries []TranscriptEntry `json:"entries"`
+	Dropped int               `json:"dropped"` // entries left out, to rate limits or the scrollback bound
+}
+
+// EventLog is the response for GET /api/jobs/{job}/events.
+type EventLog struct {
+	Job     string   `json:"job"`
+	Events  []*Event `json:"events"`  // oldest first, without task.output
+	Dropped int      `json:"dropped"` // oldest events left out
+}
+
+// UnitRepo is where a unit's work can be inspected: its commits are
+// Base..HEAD in Worktree.
+type UnitRepo struct {
+	Worktree string `json:"worktree"`
+	Branch   string `json:"branch"`
+	Base     string `json:"base"`
+}
+
+// UnitCommits is the response for GET /api/units/{id}/commits.
+type UnitCommits struct {
+	Unit    string       `json:"unit"`
+	Branch  string       `json:"branch"`
+	Base    string       `json:"base"`
+	Commits []UnitCommit `json:"commits"` // newest first
+}
+
+// UnitCommit is one commit on a unit's branch.
+type UnitCommit struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+	Task    *int      `json:"task,omitempty"` // task the commit completes, if any
+}
+
+// UnitDiff is the response for GET /api/units/{id}/diff: the unit's
+// changes since its base, or those of one of its commits.
+type UnitDiff struct {
+	Unit      string      `json:"unit"`
+	Base      string      `json:"base"`
+	Head      string      `json:"head"`
+	Commit    string      `json:"commit,omitempty"` // set for a single commit's diff
+	Files     []DiffFile  `json:"files"`
+	Tree      []*FileNode `json:"tree"`
+	Truncated bool        `json:"truncated,omitempty"` // the diff was too large to show whole
+}
+
+// DiffFile is the change to one file.
+type DiffFile struct {
+	Path      string     `json:"path"`
+	OldPath   string     `json:"oldPath,omitempty"` // set for renames
+	Status    string     `json:"status"`            // "added", "deleted", "modified", "renamed"
+	Binary    bool       `json:"binary,omitempty"`
+	Additions int        `json:"additions"`
+	Deletions int        `json:"deletions"`
+	Hunks     []DiffHunk `json:"hunks"`
+}
+
+// DiffHunk is a run of changed lines with their context.
+type DiffHunk struct {
+	Header string     `json:"header"`
+	Lines  []DiffLine `json:"lines"`
+}
+
+// DiffLine is one line of a hunk, numbered on the side(s) it appears on.
+type DiffLine struct {
+	Kind string `json:"kind"` // "context", "add", "delete"
+	Old  int    `json:"old,omitempty"`
+	New  int    `json:"new,omitempty"`
+	Text string `json:"text"`
+}
+
+// FileNode is a directory or changed file in the tree of a diff's files.
+type FileNode struct {
+	Name     string      `json:"name"`
+	Path     string      `json:"path"`
+	Status   string      `json:"status,omitempty"` // files only
+	Children []*FileNode `json:"children,omitempty"`
+}
+
+// ControlInfo is the response for GET /api/control.
+type ControlInfo struct {
+	Enabled bool `json:"enabled"` // jobs can be started and steered from the UI
+}
+
+// JobInfo is a job in the response for GET /api/jobs.
+type JobInfo struct {
+	ID            string     `json:"id"`
+	Status        string     `json:"status"` // running, completed, failed or cancelled
+	Paused        bool       `json:"paused,omitempty"`
+	RepoPath      string     `json:"repoPath"`
+	TasksDir      string     `json:"tasksDir"`
+	FeatureBranch string     `json:"featureBranch,omitempty"`
+	TargetBranch  string     `json:"targetBranch"`
+	Parallelism   int        `json:"parallelism,omitempty"`
+	StartedAt     *time.Time `json:"startedAt,omitempty"`
+	CompletedAt   *time.Time `json:"completedAt,omitempty"`
+	Error         string     `json:"error,omitempty"`
+
+	// Live is set while the job's orchestrator is connected
+	Live bool `json:"live,omitempty"`
+
+	// Summary counts the job's units by status, when the server has seen
+	// its events
+	Summary *StateSummary `json:"summary,omitempty"`
+}
+
+// RunOptions are the options of a job that can be set when starting or
+// retrying it. Zero values take the defaults, or the retried job's.
+type RunOptions struct {
+	TargetBranch string `json:"targetBranch,omitempty"`
+	Parallelism  int    `json:"parallelism,omitempty"`
+}
+
+// StartJobRequest is the body of POST /api/jobs. A job runs a tasks
+// directory, or the feature branch of a PRD.
+type StartJobRequest struct {
+	Workspace string `json:"workspace,omitempty"` // registered workspace, instead of repoPath
+	RepoPath  string `json:"repoPath,omitempty"`
+	TasksDir  string `json:"tasksDir,omitempty"`
+	PRD       string `json:"prd,omitempty"`
+	RunOptions
+}
+
+// StopJobRequest is the body of POST /api/jobs/{id}/stop.
+type StopJobRequest struct {
+	Force bool `json:"force,omitempty"`
+}
+
+// JobResponse is the response of the job control endpoints. A retry
+// waiting for a slot has no job yet, only its queue entry.
+type JobResponse struct {
+	JobID   string `json:"jobId"`
+	QueueID string `json:"queueId,omitempty"`
+}
+
+// ErrorResponse is the response of a failed API request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
 // Config holds server configuration.
 type Config struct {
 	// Addr is the HTTP listen address (default ":8080")
@@ -122,6 +304,26 @@ type Config struct {
 
 	// SocketPath is the Unix socket path (default ~/.choo/web.sock)
 	SocketPath string
+
+	// PRDDir is the PRD directory shown on the roadmap (empty: no roadmap)
+	PRDDir string
+
+	// Metrics serves /metrics in the Prometheus format (nil: no endpoint)
+	Metrics http.Handler
+
+	// Control serves the job control endpoints (nil: read-only UI)
+	Control JobControl
+
+	// History lists past jobs and their events, so they can be viewed
+	// after the server restarts (nil: only runs seen since it started)
+	History JobHistory
+
+	// Authorize admits requests to the mutating job control endpoints,
+	// e.g. by their bearer token (nil: none are admitted)
+	Authorize func(r *http.Request) error
+
+	// TLS serves the HTTP listener over TLS (nil: plain HTTP)
+	TLS *tls.Config
 }
 
 // PusherConfig holds configuration for SocketPusher
@@ -140,6 +342,9 @@ type PusherConfig struct {
 
 	// MaxReconnectBackoff is the maximum retry delay (default: 5s)
 	MaxReconnectBackoff time.Duration
+
+	// JobID identifies the run's events to the server (default: random)
+	JobID string
 }
 
 // DefaultPusherConfig returns sensible defaults
@@ -0,0 +1,431 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/RevCBH/choo/internal/escalate"
+	"github.com/RevCBH/choo/internal/events"
+)
+
+// defaultVerifyTimeout bounds each verification command when the worker
+// has no backpressure or baseline timeout configured
+const defaultVerifyTimeout = 10 * time.Minute
+
+// ConflictResolution is the audit record of one conflict resolution.
+// It is written as JSON under <worktree base>/logs/conflicts/.
+type ConflictResolution struct {
+	Unit         string               `json:"unit"`
+	Branch       string               `json:"branch"`
+	TargetBranch string               `json:"target_branch"`
+	Stage        string               `json:"stage"` // "rebase" or "merge_to_target"
+	StartedAt    time.Time            `json:"started_at"`
+	ResolvedAt   time.Time            `json:"resolved_at"`
+	Files        []ConflictFileRecord `json:"files"`
+	Verification ResolutionCheck      `json:"verification"`
+}
+
+// ConflictFileRecord captures both sides of a conflicted file and the
+// content chosen by the resolution, at one stop of a rebase or merge
+type ConflictFileRecord struct {
+	Path    string         `json:"path"`
+	Commit  string         `json:"commit,omitempty"` // Commit being replayed or merged at this stop
+	Base    string         `json:"base,omitempty"`   // Common ancestor (stage 1)
+	Ours    string         `json:"ours,omitempty"`   // Target branch side (stage 2)
+	Theirs  string         `json:"theirs,omitempty"` // Unit side (stage 3)
+	Hunks   []ConflictHunk `json:"hunks"`
+	Result  string         `json:"result"`
+	Deleted bool           `json:"deleted,omitempty"` // File removed by the resolution
+}
+
+// ConflictHunk is a single conflict marker region and which sides survived
+type ConflictHunk struct {
+	Line       int    `json:"line"` // 1-based line of the <<<<<<< marker
+	Ours       string `json:"ours"`
+	Base       string `json:"base,omitempty"` // Present with diff3 conflict style
+	Theirs     string `json:"theirs"`
+	KeptOurs   bool   `json:"kept_ours"`
+	KeptTheirs bool   `json:"kept_theirs"`
+
+	// The lines around the hunk, which locate its resolution
+	before, after  string
+	atStart, atEnd bool
+}
+
+// Dropped reports whether the resolution kept neither side of the hunk
+// verbatim, which often means a change was lost
+func (h ConflictHunk) Dropped() bool {
+	return !h.KeptOurs && !h.KeptTheirs
+}
+
+// ResolutionCheck records the post-resolution verification
+type ResolutionCheck struct {
+	Passed       bool                `json:"passed"`
+	Backpressure []VerificationEntry `json:"backpressure,omitempty"`
+	Baseline     *VerificationEntry  `json:"baseline,omitempty"`
+}
+
+// VerificationEntry is the outcome of one verification command
+type VerificationEntry struct {
+	Command string `json:"command"`
+	Passed  bool   `json:"passed"`
+	Output  string `json:"output,omitempty"`
+}
+
+// newConflictAudit starts the audit record of a rebase or merge
+func (w *Worker) newConflictAudit(stage string) *ConflictResolution {
+	return &ConflictResolution{
+		Unit:         w.unit.ID,
+		Branch:       w.branch,
+		TargetBranch: w.config.TargetBranch,
+		Stage:        stage,
+		StartedAt:    time.Now(),
+	}
+}
+
+// captureConflicts records the conflicted hunks and both sides of each
+// file in dir at the current stop, before the agent touches them. head is
+// the ref of the commit being applied, REBASE_HEAD or MERGE_HEAD.
+func (w *Worker) captureConflicts(ctx context.Context, dir, head string, files []string) []ConflictFileRecord {
+	commit := ""
+	if out, err := w.runner().Exec(ctx, dir, "rev-parse", head); err == nil {
+		commit = strings.TrimSpace(out)
+	}
+
+	records := make([]ConflictFileRecord, 0, len(files))
+	for _, path := range files {
+		record := ConflictFileRecord{
+			Path:   path,
+			Commit: commit,
+			Base:   w.conflictStage(ctx, dir, 1, path),
+			Ours:   w.conflictStage(ctx, dir, 2, path),
+			Theirs: w.conflictStage(ctx, dir, 3, path),
+		}
+		if content, err := os.ReadFile(filepath.Join(dir, path)); err == nil {
+			record.Hunks = parseConflictHunks(string(content))
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// conflictStage returns the index stage of a conflicted file, or empty if
+// the side does not exist (e.g. added on only one side)
+func (w *Worker) conflictStage(ctx context.Context, dir string, stage int, path string) string {
+	out, err := w.runner().Exec(ctx, dir, "show", fmt.Sprintf(":%d:%s", stage, path))
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+// recordResolution fills in the resolved content of a stop's files in dir
+// and which sides of each hunk were kept, and adds them to the audit. It
+// must run before the rebase continues, since later commits may change
+// the same lines.
+func (w *Worker) recordResolution(audit *ConflictResolution, dir string, records []ConflictFileRecord) {
+	audit.ResolvedAt = time.Now()
+	for i := range records {
+		f := &records[i]
+		content, err := os.ReadFile(filepath.Join(dir, f.Path))
+		if err != nil {
+			f.Deleted = os.IsNotExist(err)
+			continue
+		}
+		f.Result = string(content)
+		for j := range f.Hunks {
+			h := &f.Hunks[j]
+			h.KeptOurs = h.kept(f.Result, h.Ours)
+			h.KeptTheirs = h.kept(f.Result, h.Theirs)
+		}
+	}
+	audit.Files = append(audit.Files, records...)
+}
+
+// kept reports whether result kept side of the hunk verbatim. An empty side
+// is part of any result, so it counts as kept only if the hunk resolved to
+// nothing as well.
+func (h ConflictHunk) kept(result, side string) bool {
+	if side != "" {
+		return strings.Contains(result, side)
+	}
+	resolved, ok := h.resolution(result)
+	return ok && resolved == ""
+}
+
+// resolution returns what the hunk resolved to in result: the lines between
+// the ones around it. It returns false if they are no longer in result.
+func (h ConflictHunk) resolution(result string) (string, bool) {
+	lines := strings.Split(result, "\n")
+	start := 0
+	if !h.atStart {
+		i := slices.Index(lines, h.before)
+		if i < 0 {
+			return "", false
+		}
+		start = i + 1
+	}
+	end := len(lines)
+	if !h.atEnd {
+		i := slices.Index(lines[start:], h.after)
+		if i < 0 {
+			return "", false
+		}
+		end = start + i
+	}
+	return strings.Join(lines[start:end], "\n"), true
+}
+
+// paths returns the conflicted files, once each, in the order they conflicted
+func (a *ConflictResolution) paths() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, f := range a.Files {
+		if !seen[f.Path] {
+			seen[f.Path] = true
+			paths = append(paths, f.Path)
+		}
+	}
+	return paths
+}
+
+// droppedHunks returns "path:line" for each hunk whose resolution kept neither side
+func (a *ConflictResolution) droppedHunks() []string {
+	var dropped []string
+	for _, f := range a.Files {
+		for _, h := range f.Hunks {
+			if h.Dropped() {
+				dropped = append(dropped, fmt.Sprintf("%s:%d", f.Path, h.Line))
+			}
+		}
+	}
+	return dropped
+}
+
+// verifyResolution re-runs the backpressure commands of tasks affected by
+// the conflicted files, then the baseline checks, against the resolved tree
+// in dir
+func (w *Worker) verifyResolution(ctx context.Context, dir string, audit *ConflictResolution) error {
+	check := ResolutionCheck{Passed: true}
+
+	bpTimeout := w.config.BackpressureTimeout
+	if bpTimeout <= 0 {
+		bpTimeout = defaultVerifyTimeout
+	}
+	for _, cmd := range w.affectedBackpressure(audit.Files) {
+		result := RunBackpressure(ctx, cmd, dir, bpTimeout)
+		check.Backpressure = append(check.Backpressure, VerificationEntry{
+			Command: cmd,
+			Passed:  result.Success,
+			Output:  result.Output,
+		})
+		if !result.Success {
+			check.Passed = false
+		}
+	}
+
+	if len(w.config.BaselineChecks) > 0 {
+		baselineTimeout := w.config.BaselineTimeout
+		if baselineTimeout <= 0 {
+			baselineTimeout = defaultVerifyTimeout
+		}
+		passed, output := RunBaselineChecks(ctx, w.config.BaselineChecks, dir, baselineTimeout)
+		names := make([]string, len(w.config.BaselineChecks))
+		for i, c := range w.config.BaselineChecks {
+			names[i] = c.Name
+		}
+		check.Baseline = &VerificationEntry{
+			Command: strings.Join(names, ", "),
+			Passed:  passed,
+			Output:  output,
+		}
+		if !passed {
+			check.Passed = false
+		}
+	}
+
+	audit.Verification = check
+	if check.Passed {
+		return nil
+	}
+
+	var failed []string
+	for _, e := range check.Backpressure {
+		if !e.Passed {
+			failed = append(failed, e.Command)
+		}
+	}
+	if check.Baseline != nil && !check.Baseline.Passed {
+		failed = append(failed, "baseline checks")
+	}
+	return fmt.Errorf("conflict resolution failed verification: %s", strings.Join(failed, "; "))
+}
+
+// affectedBackpressure returns the unique backpressure commands of tasks
+// whose specs mention a conflicted file. When no task mentions one, every
+// task's command is used since the affected task cannot be determined.
+func (w *Worker) affectedBackpressure(files []ConflictFileRecord) []string {
+	var affected, all []string
+	seenAffected := make(map[string]bool)
+	seenAll := make(map[string]bool)
+
+	for _, task := range w.unit.Tasks {
+		cmd := strings.TrimSpace(task.Backpressure)
+		if cmd == "" {
+			continue
+		}
+		if !seenAll[cmd] {
+			seenAll[cmd] = true
+			all = append(all, cmd)
+		}
+		for _, f := range files {
+			if strings.Contains(task.Content, f.Path) && !seenAffected[cmd] {
+				seenAffected[cmd] = true
+				affected = append(affected, cmd)
+			}
+		}
+	}
+
+	if len(affected) == 0 {
+		return all
+	}
+	return affected
+}
+
+// writeConflictArtifact saves the audit record and returns its path
+func (w *Worker) writeConflictArtifact(audit *ConflictResolution) (string, error) {
+	dir := filepath.Join(w.config.WorktreeBase, "logs", "conflicts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create conflict artifact directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(audit, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal conflict artifact: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s-%d.json", w.unit.ID, audit.Stage, audit.StartedAt.UnixNano()))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write conflict artifact: %w", err)
+	}
+	return path, nil
+}
+
+// auditResolution verifies the recorded resolution against the tree in
+// dir and saves the artifact. On verification failure the unit is
+// escalated and an error returned so the branch is not merged.
+func (w *Worker) auditResolution(ctx context.Context, dir string, audit *ConflictResolution) error {
+	verifyErr := w.verifyResolution(ctx, dir, audit)
+
+	artifact, err := w.writeConflictArtifact(audit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	files := audit.paths()
+	dropped := audit.droppedHunks()
+
+	if w.events != nil {
+		evtType := events.ConflictResolved
+		if verifyErr != nil {
+			evtType = events.ConflictVerificationFailed
+		}
+		evt := events.NewEvent(evtType, w.unit.ID).WithPayload(map[string]any{
+			"stage":         audit.Stage,
+			"files":         files,
+			"dropped_hunks": dropped,
+			"artifact":      artifact,
+		}).WithError(verifyErr)
+		w.events.Emit(evt)
+	}
+
+	if verifyErr == nil {
+		if len(dropped) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: conflict resolution kept neither side of %d hunk(s): %s\n",
+				len(dropped), strings.Join(dropped, ", "))
+		}
+		return nil
+	}
+
+	if w.escalator != nil {
+		_ = w.escalator.Escalate(ctx, escalate.Escalation{
+			Severity: escalate.SeverityBlocking,
+			Unit:     w.unit.ID,
+			Title:    "Conflict resolution failed verification",
+			Message:  "Resolved conflicts broke backpressure or baseline checks; the unit was not merged",
+			Context: map[string]string{
+				"files":         strings.Join(files, ", "),
+				"dropped_hunks": strings.Join(dropped, ", "),
+				"artifact":      artifact,
+				"target":        w.config.TargetBranch,
+				"error":         verifyErr.Error(),
+			},
+		})
+	}
+	return verifyErr
+}
+
+// parseConflictHunks extracts conflict marker regions from file content.
+// Supports both the default and diff3 conflict styles.
+func parseConflictHunks(content string) []ConflictHunk {
+	const (
+		outside = iota
+		inOurs
+		inBase
+		inTheirs
+	)
+
+	var hunks []ConflictHunk
+	var current ConflictHunk
+	var ours, base, theirs []string
+	state := outside
+
+	// The last line outside a hunk, and the hunks waiting for the next one
+	var prev string
+	hasPrev := false
+	pending := 0
+
+	for i, line := range strings.Split(content, "\n") {
+		switch {
+		case strings.HasPrefix(line, "<<<<<<<") && state == outside:
+			current = ConflictHunk{Line: i + 1, before: prev, atStart: !hasPrev}
+			ours, base, theirs = nil, nil, nil
+			state = inOurs
+		case strings.HasPrefix(line, "|||||||") && state == inOurs:
+			state = inBase
+		case strings.HasPrefix(line, "=======") && (state == inOurs || state == inBase):
+			state = inTheirs
+		case strings.HasPrefix(line, ">>>>>>>") && state == inTheirs:
+			current.Ours = strings.Join(ours, "\n")
+			current.Base = strings.Join(base, "\n")
+			current.Theirs = strings.Join(theirs, "\n")
+			hunks = append(hunks, current)
+			pending++
+			state = outside
+		case state == inOurs:
+			ours = append(ours, line)
+		case state == inBase:
+			base = append(base, line)
+		case state == inTheirs:
+			theirs = append(theirs, line)
+		default:
+			for j := len(hunks) - pending; j < len(hunks); j++ {
+				hunks[j].after = line
+			}
+			pending = 0
+			prev, hasPrev = line, true
+		}
+	}
+	for j := len(hunks) - pending; j < len(hunks); j++ {
+		hunks[j].atEnd = true
+	}
+
+	return hunks
+}
@@ -0,0 +1,358 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/discovery"
+	"github.com/RevCBH/choo/internal/escalate"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/git"
+	"github.com/RevCBH/choo/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type auditEscalator struct {
+	escalations []escalate.Escalation
+}
+
+func (m *auditEscalator) Escalate(ctx context.Context, e escalate.Escalation) error {
+	m.escalations = append(m.escalations, e)
+	return nil
+}
+
+func (m *auditEscalator) Name() string {
+	return "audit"
+}
+
+func TestParseConflictHunks(t *testing.T) {
+	content := "package x\n" +
+		"<<<<<<< HEAD\n" +
+		"a := 1\n" +
+		"=======\n" +
+		"a := 2\n" +
+		">>>>>>> feat\n" +
+		"mid\n" +
+		"<<<<<<< HEAD\n" +
+		"b := 1\n" +
+		"||||||| base\n" +
+		"b := 0\n" +
+		"=======\n" +
+		"b := 2\n" +
+		"c := 3\n" +
+		">>>>>>> feat\n"
+
+	hunks := parseConflictHunks(content)
+
+	require.Len(t, hunks, 2)
+	assert.Equal(t, ConflictHunk{Line: 2, Ours: "a := 1", Theirs: "a := 2", before: "package x", after: "mid"}, hunks[0])
+	assert.Equal(t, ConflictHunk{Line: 8, Ours: "b := 1", Base: "b := 0", Theirs: "b := 2\nc := 3", before: "mid"}, hunks[1])
+	assert.Empty(t, parseConflictHunks("no conflicts\n"))
+}
+
+// newAuditWorker creates a worker in a temp worktree with the given tasks
+func newAuditWorker(t *testing.T, tasks []*discovery.Task, checks []BaselineCheck) *Worker {
+	t.Helper()
+	base := t.TempDir()
+	worktree := filepath.Join(base, "unit-a")
+	require.NoError(t, os.MkdirAll(worktree, 0755))
+
+	return &Worker{
+		unit:         &discovery.Unit{ID: "unit-a", Tasks: tasks},
+		branch:       "ralph/unit-a-123456",
+		worktreePath: worktree,
+		config: WorkerConfig{
+			TargetBranch:   "main",
+			WorktreeBase:   base,
+			BaselineChecks: checks,
+		},
+	}
+}
+
+func TestCaptureAndRecordResolution(t *testing.T) {
+	w := newAuditWorker(t, nil, nil)
+	fake := newFakeGitRunner()
+	fake.stub("rev-parse REBASE_HEAD", "abc123\n", nil)
+	fake.stub("show :1:app.go", "base\n", nil)
+	fake.stub("show :2:app.go", "ours\n", nil)
+	fake.stub("show :3:app.go", "theirs\n", nil)
+	w.gitRunner = fake
+
+	path := filepath.Join(w.worktreePath, "app.go")
+	require.NoError(t, os.WriteFile(path, []byte("<<<<<<< HEAD\nkeep ours\n=======\nkeep theirs\n>>>>>>> x\n"), 0644))
+
+	stop := w.captureConflicts(context.Background(), w.worktreePath, "REBASE_HEAD", []string{"app.go"})
+	require.Len(t, stop, 1)
+	assert.Equal(t, "abc123", stop[0].Commit)
+	assert.Equal(t, "base\n", stop[0].Base)
+	assert.Equal(t, "ours\n", stop[0].Ours)
+	assert.Equal(t, "theirs\n", stop[0].Theirs)
+	require.Len(t, stop[0].Hunks, 1)
+
+	// Resolution keeps only the target side, dropping the unit's change
+	require.NoError(t, os.WriteFile(path, []byte("keep ours\n"), 0644))
+	audit := w.newConflictAudit("rebase")
+	w.recordResolution(audit, w.worktreePath, append([]ConflictFileRecord(nil), stop...))
+	require.Len(t, audit.Files, 1)
+
+	hunk := audit.Files[0].Hunks[0]
+	assert.True(t, hunk.KeptOurs)
+	assert.False(t, hunk.KeptTheirs)
+	assert.Equal(t, "keep ours\n", audit.Files[0].Result)
+	assert.Empty(t, audit.droppedHunks())
+
+	// Resolution that keeps neither side is flagged
+	require.NoError(t, os.WriteFile(path, []byte("something else\n"), 0644))
+	audit = w.newConflictAudit("rebase")
+	w.recordResolution(audit, w.worktreePath, stop)
+	assert.Equal(t, []string{"app.go:1"}, audit.droppedHunks())
+}
+
+func TestRecordResolution_EmptySide(t *testing.T) {
+	w := newAuditWorker(t, nil, nil)
+	path := filepath.Join(w.worktreePath, "app.go")
+	conflicted := "package x\n<<<<<<< HEAD\n=======\nvar v = 1\n>>>>>>> x\n\nfunc f() {}\n"
+	stop := []ConflictFileRecord{{Path: "app.go", Hunks: parseConflictHunks(conflicted)}}
+	require.Len(t, stop[0].Hunks, 1)
+
+	tests := []struct {
+		name       string
+		result     string
+		keptOurs   bool
+		keptTheirs bool
+	}{
+		{"resolved to nothing", "package x\n\nfunc f() {}\n", true, false},
+		{"resolved to their side", "package x\nvar v = 1\n\nfunc f() {}\n", false, true},
+		{"resolved to neither", "package x\nvar v = 2\n\nfunc f() {}\n", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(path, []byte(tt.result), 0644))
+			audit := w.newConflictAudit("rebase")
+			w.recordResolution(audit, w.worktreePath, append([]ConflictFileRecord(nil), stop...))
+
+			hunk := audit.Files[0].Hunks[0]
+			assert.Equal(t, tt.keptOurs, hunk.KeptOurs, "kept ours")
+			assert.Equal(t, tt.keptTheirs, hunk.KeptTheirs, "kept theirs")
+		})
+	}
+}
+
+func TestAffectedBackpressure(t *testing.T) {
+	tasks := []*discovery.Task{
+		{Number: 1, Backpressure: "go test ./internal/app/...", Content: "Edit internal/app/app.go"},
+		{Number: 2, Backpressure: "go test ./internal/db/...", Content: "Edit internal/db/db.go"},
+		{Number: 3, Backpressure: "", Content: "Docs only"},
+	}
+	w := newAuditWorker(t, tasks, nil)
+
+	assert.Equal(t, []string{"go test ./internal/app/..."},
+		w.affectedBackpressure([]ConflictFileRecord{{Path: "internal/app/app.go"}}))
+
+	// Unknown file falls back to every task's command
+	assert.Equal(t, []string{"go test ./internal/app/...", "go test ./internal/db/..."},
+		w.affectedBackpressure([]ConflictFileRecord{{Path: "README.md"}}))
+}
+
+func TestAuditResolution_PassingVerification(t *testing.T) {
+	tasks := []*discovery.Task{{Number: 1, Backpressure: "exit 0", Content: "Edit app.go"}}
+	w := newAuditWorker(t, tasks, []BaselineCheck{{Name: "build", Command: "exit 0"}})
+	bus := events.NewBus(10)
+	defer bus.Close()
+	collector := events.NewEventCollector(bus)
+	w.events = bus
+
+	audit := w.newConflictAudit("rebase")
+	require.NoError(t, os.WriteFile(filepath.Join(w.worktreePath, "app.go"), []byte("resolved\n"), 0644))
+	w.recordResolution(audit, w.worktreePath, []ConflictFileRecord{{Path: "app.go"}})
+
+	require.NoError(t, w.auditResolution(context.Background(), w.worktreePath, audit))
+	assert.True(t, audit.Verification.Passed)
+	require.Len(t, audit.Verification.Backpressure, 1)
+	require.NotNil(t, audit.Verification.Baseline)
+
+	bus.Wait()
+	evts := collector.Get()
+	require.Len(t, evts, 1)
+	assert.Equal(t, events.ConflictResolved, evts[0].Type)
+
+	artifact := evts[0].Payload.(map[string]any)["artifact"].(string)
+	data, err := os.ReadFile(artifact)
+	require.NoError(t, err)
+	var saved ConflictResolution
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, "resolved\n", saved.Files[0].Result)
+	assert.True(t, saved.Verification.Passed)
+}
+
+func TestAuditResolution_FailingVerificationEscalates(t *testing.T) {
+	tasks := []*discovery.Task{{Number: 1, Backpressure: "echo broken && exit 1", Content: "Edit app.go"}}
+	w := newAuditWorker(t, tasks, nil)
+	esc := &auditEscalator{}
+	w.escalator = esc
+
+	audit := &ConflictResolution{Unit: "unit-a", Stage: "rebase", Files: []ConflictFileRecord{{Path: "app.go"}}}
+
+	err := w.auditResolution(context.Background(), w.worktreePath, audit)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "echo broken && exit 1")
+	assert.False(t, audit.Verification.Passed)
+	assert.Contains(t, audit.Verification.Backpressure[0].Output, "broken")
+
+	require.Len(t, esc.escalations, 1)
+	assert.Equal(t, escalate.SeverityBlocking, esc.escalations[0].Severity)
+	assert.NotEmpty(t, esc.escalations[0].Context["artifact"])
+
+	matches, globErr := filepath.Glob(filepath.Join(w.config.WorktreeBase, "logs", "conflicts", "unit-a-rebase-*.json"))
+	require.NoError(t, globErr)
+	assert.Len(t, matches, 1)
+}
+
+// takeTheirsClaude is a Claude command that resolves every conflict in its
+// working directory with the unit's side and stages it
+const takeTheirsClaude = `#!/bin/sh
+for f in $(git diff --name-only --diff-filter=U); do
+	git checkout --theirs -- "$f" && git add "$f"
+done
+`
+
+// newConflictRepo creates a repo whose main branch and ralph/unit-a branch
+// both changed app.txt and b.txt. The unit branch changes app.txt twice,
+// then b.txt, so rebasing it stops on its first and last commits.
+func newConflictRepo(t *testing.T) string {
+	t.Helper()
+	repo := setupMergeQueueRepo(t, nil)
+	write := func(name, body string) {
+		require.NoError(t, os.WriteFile(filepath.Join(repo, name), []byte(body), 0644))
+	}
+	commit := func(msg string) {
+		testutil.Git(t, repo, "add", "-A")
+		testutil.Git(t, repo, "commit", "-m", msg)
+	}
+
+	write("app.txt", "start\n")
+	write("b.txt", "start\n")
+	commit("add files")
+
+	testutil.Git(t, repo, "checkout", "-b", "ralph/unit-a")
+	write("app.txt", "unit one\n")
+	commit("unit: first app change")
+	write("app.txt", "unit two\n")
+	commit("unit: second app change")
+	write("b.txt", "unit b\n")
+	commit("unit: b change")
+
+	testutil.Git(t, repo, "checkout", "main")
+	write("app.txt", "main\n")
+	write("b.txt", "main b\n")
+	commit("main changes")
+	return repo
+}
+
+// newConflictWorker creates a worker on repo whose Claude command is script
+func newConflictWorker(t *testing.T, repo, script string, checks []BaselineCheck) *Worker {
+	t.Helper()
+	base := t.TempDir()
+	claude := filepath.Join(base, "claude")
+	require.NoError(t, os.WriteFile(claude, []byte(script), 0755))
+
+	return &Worker{
+		unit:         &discovery.Unit{ID: "unit-a"},
+		branch:       "ralph/unit-a",
+		worktreePath: repo,
+		config: WorkerConfig{
+			RepoRoot:       repo,
+			TargetBranch:   "main",
+			WorktreeBase:   base,
+			ClaudeCommand:  claude,
+			SuppressOutput: true,
+			BaselineChecks: checks,
+		},
+	}
+}
+
+// readConflictArtifact reads the only conflict artifact the worker wrote
+func readConflictArtifact(t *testing.T, w *Worker) ConflictResolution {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(w.config.WorktreeBase, "logs", "conflicts", "*.json"))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	data, err := os.ReadFile(matches[0])
+	require.NoError(t, err)
+	var audit ConflictResolution
+	require.NoError(t, json.Unmarshal(data, &audit))
+	return audit
+}
+
+func TestResolveConflictsWithClaude_RecordsEachStop(t *testing.T) {
+	repo := newConflictRepo(t)
+	testutil.Git(t, repo, "checkout", "ralph/unit-a")
+	w := newConflictWorker(t, repo, takeTheirsClaude, nil)
+
+	hasConflicts, err := git.Rebase(context.Background(), repo, "main")
+	require.NoError(t, err)
+	require.True(t, hasConflicts)
+	require.NoError(t, w.resolveConflictsWithClaude(context.Background()))
+
+	inRebase, err := git.IsRebaseInProgress(context.Background(), repo)
+	require.NoError(t, err)
+	assert.False(t, inRebase)
+	assert.Equal(t, "unit two", testutil.Git(t, repo, "show", "HEAD:app.txt"))
+
+	audit := readConflictArtifact(t, w)
+	require.Len(t, audit.Files, 2)
+	app, b := audit.Files[0], audit.Files[1]
+	assert.Equal(t, "app.txt", app.Path)
+	assert.Equal(t, "b.txt", b.Path)
+	assert.NotEqual(t, app.Commit, b.Commit)
+
+	// The first stop kept the unit's change, though a later commit replaced it
+	assert.Equal(t, "unit one\n", app.Result)
+	require.Len(t, app.Hunks, 1)
+	assert.True(t, app.Hunks[0].KeptTheirs)
+	assert.Empty(t, audit.droppedHunks())
+	assert.True(t, audit.Verification.Passed)
+}
+
+func TestMergeWithCleanup_AuditsResolution(t *testing.T) {
+	repo := newConflictRepo(t)
+	w := newConflictWorker(t, repo, takeTheirsClaude, []BaselineCheck{{Name: "build", Command: "exit 0"}})
+
+	require.NoError(t, w.mergeWithCleanup(context.Background()))
+
+	// The verified resolution is committed as the merge
+	assert.Equal(t, "unit b", testutil.Git(t, repo, "show", "HEAD:b.txt"))
+	assert.Len(t, strings.Fields(testutil.Git(t, repo, "rev-list", "--parents", "-n", "1", "HEAD")), 3)
+
+	audit := readConflictArtifact(t, w)
+	assert.Equal(t, "merge_to_target", audit.Stage)
+	assert.Equal(t, []string{"app.txt", "b.txt"}, audit.paths())
+	assert.Equal(t, testutil.Git(t, repo, "rev-parse", "ralph/unit-a"), audit.Files[0].Commit)
+	assert.True(t, audit.Verification.Passed)
+}
+
+func TestMergeWithCleanup_FailingVerificationAbortsMerge(t *testing.T) {
+	repo := newConflictRepo(t)
+	w := newConflictWorker(t, repo, takeTheirsClaude, []BaselineCheck{{Name: "build", Command: "exit 1"}})
+	esc := &auditEscalator{}
+	w.escalator = esc
+	head := testutil.Git(t, repo, "rev-parse", "HEAD")
+
+	err := w.mergeWithCleanup(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "baseline checks")
+
+	// The broken resolution never reaches the target branch
+	assert.Equal(t, head, testutil.Git(t, repo, "rev-parse", "HEAD"))
+	inMerge, mergeErr := git.IsMergeInProgress(context.Background(), repo)
+	require.NoError(t, mergeErr)
+	assert.False(t, inMerge)
+
+	require.Len(t, esc.escalations, 1)
+	assert.False(t, readConflictArtifact(t, w).Verification.Passed)
+}
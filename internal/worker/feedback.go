@@ -2,12 +2,16 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/RevCBH/choo/internal/events"
-	"github.com/RevCBH/choo/internal/github"
+	"github.com/RevCBH/choo/internal/forge"
 )
 
 // FeedbackConfig holds configuration for feedback handling
@@ -54,7 +58,7 @@ const (
 
 // FeedbackDeps holds dependencies for FeedbackHandler
 type FeedbackDeps struct {
-	GitHub    *github.PRClient
+	Forge     forge.Forge
 	Events    *events.Bus
 	Claude    ClaudeInvoker
 	Escalator Escalator
@@ -62,7 +66,7 @@ type FeedbackDeps struct {
 
 // FeedbackHandler manages responding to PR feedback via Claude
 type FeedbackHandler struct {
-	github    *github.PRClient
+	forge     forge.Forge
 	events    *events.Bus
 	claude    ClaudeInvoker
 	escalator Escalator
@@ -79,7 +83,7 @@ func NewFeedbackHandler(cfg FeedbackConfig, deps FeedbackDeps) *FeedbackHandler
 	}
 
 	return &FeedbackHandler{
-		github:    deps.GitHub,
+		forge:     deps.Forge,
 		events:    deps.Events,
 		claude:    deps.Claude,
 		escalator: deps.Escalator,
@@ -90,12 +94,24 @@ func NewFeedbackHandler(cfg FeedbackConfig, deps FeedbackDeps) *FeedbackHandler
 // HandleFeedback addresses PR feedback by delegating to Claude
 // Returns nil on success, error if Claude cannot address feedback
 func (h *FeedbackHandler) HandleFeedback(ctx context.Context, prNumber int, prURL string, worktreePath string, branch string) error {
-	comments, err := h.github.GetPRComments(ctx, prNumber)
+	comments, err := h.forge.GetPRComments(ctx, prNumber)
 	if err != nil {
 		return fmt.Errorf("failed to get PR comments: %w", err)
 	}
+	threads := pendingThreads(comments)
+	if len(threads) == 0 {
+		return nil
+	}
+
+	// The response file lives outside the worktree so it is never committed
+	responseDir, err := os.MkdirTemp("", "choo-feedback-")
+	if err != nil {
+		return fmt.Errorf("failed to create feedback response directory: %w", err)
+	}
+	defer os.RemoveAll(responseDir)
+	responsePath := filepath.Join(responseDir, "response.json")
 
-	prompt := BuildFeedbackPrompt(prURL, comments)
+	prompt := BuildFeedbackPrompt(prURL, threads, responsePath)
 
 	// Retry loop for Claude invocation
 	var lastErr error
@@ -135,8 +151,138 @@ func (h *FeedbackHandler) HandleFeedback(ctx context.Context, prNumber int, prUR
 		return fmt.Errorf("branch not updated on remote after feedback: %w", err)
 	}
 
+	h.respondToThreads(ctx, prNumber, worktreePath, threads, responsePath)
+
 	if h.events != nil {
 		h.events.Emit(events.NewEvent(events.PRFeedbackAddressed, "").WithPR(prNumber))
 	}
 	return nil
 }
+
+// feedbackReplyMarker tags replies choo posts. It renders as nothing, and a
+// thread whose latest comment carries it is waiting on the reviewer.
+const feedbackReplyMarker = "<!-- choo:feedback -->"
+
+// FeedbackThread is a review thread awaiting a response: the comment that
+// opened it and any replies since
+type FeedbackThread struct {
+	Root    forge.PRComment
+	Replies []forge.PRComment
+}
+
+// FeedbackStatus records what was done about a thread
+type FeedbackStatus string
+
+const (
+	FeedbackFixed    FeedbackStatus = "fixed"
+	FeedbackDeclined FeedbackStatus = "declined"
+)
+
+// FeedbackResponse is Claude's account of one thread, read from the
+// response file named in the feedback prompt
+type FeedbackResponse struct {
+	Thread  int64          `json:"thread"` // ID of the thread's opening comment
+	Status  FeedbackStatus `json:"status"`
+	Summary string         `json:"summary"`
+}
+
+// pendingThreads groups comments into threads and keeps those whose latest
+// comment is not one of choo's replies
+func pendingThreads(comments []forge.PRComment) []FeedbackThread {
+	var order []int64
+	byRoot := make(map[int64]*FeedbackThread)
+	for _, c := range comments {
+		if t, ok := byRoot[c.InReplyTo]; ok && c.InReplyTo != 0 {
+			t.Replies = append(t.Replies, c)
+			continue
+		}
+		// Replies whose opening comment is gone stand on their own
+		byRoot[c.ID] = &FeedbackThread{Root: c}
+		order = append(order, c.ID)
+	}
+
+	var pending []FeedbackThread
+	for _, id := range order {
+		t := byRoot[id]
+		last := t.Root
+		if len(t.Replies) > 0 {
+			last = t.Replies[len(t.Replies)-1]
+		}
+		if !strings.Contains(last.Body, feedbackReplyMarker) {
+			pending = append(pending, *t)
+		}
+	}
+	return pending
+}
+
+// respondToThreads replies to each thread Claude reported on, citing the
+// pushed commit, and resolves the ones it fixed. Declined threads stay open
+// for the reviewer. Failures are reported but do not fail the feedback
+// round, since the fixes have already been pushed.
+func (h *FeedbackHandler) respondToThreads(ctx context.Context, prNumber int, worktreePath string, threads []FeedbackThread, responsePath string) {
+	responder, ok := h.forge.(forge.ThreadResponder)
+	if !ok {
+		return
+	}
+
+	responses, err := readFeedbackResponses(responsePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not replying to review threads: %v\n", err)
+		return
+	}
+
+	out, err := exec.CommandContext(ctx, "git", "-C", worktreePath, "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not replying to review threads: failed to read HEAD: %v\n", err)
+		return
+	}
+	sha := strings.TrimSpace(string(out))
+
+	known := make(map[int64]bool, len(threads))
+	for _, t := range threads {
+		known[t.Root.ID] = true
+	}
+
+	for _, resp := range responses {
+		if !known[resp.Thread] {
+			continue
+		}
+
+		var body string
+		switch resp.Status {
+		case FeedbackFixed:
+			body = fmt.Sprintf("Fixed in %s", sha)
+		case FeedbackDeclined:
+			body = "Not changed"
+		default:
+			continue
+		}
+		if resp.Summary != "" {
+			body += ": " + resp.Summary
+		}
+		body += "\n\n" + feedbackReplyMarker
+
+		if err := responder.ReplyToComment(ctx, prNumber, resp.Thread, body); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to reply to review thread %d: %v\n", resp.Thread, err)
+			continue
+		}
+		if resp.Status == FeedbackFixed {
+			if err := responder.ResolveThread(ctx, prNumber, resp.Thread); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to resolve review thread %d: %v\n", resp.Thread, err)
+			}
+		}
+	}
+}
+
+// readFeedbackResponses parses the response file Claude wrote
+func readFeedbackResponses(path string) ([]FeedbackResponse, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no feedback response written: %w", err)
+	}
+	var responses []FeedbackResponse
+	if err := json.Unmarshal(data, &responses); err != nil {
+		return nil, fmt.Errorf("invalid feedback response: %w", err)
+	}
+	return responses, nil
+}
@@ -1,11 +1,25 @@
 package worker
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/RevCBH/choo/internal/forge"
+	"github.com/RevCBH/choo/internal/forge/fake"
+	"github.com/RevCBH/choo/internal/github"
+	"github.com/RevCBH/choo/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -145,3 +159,108 @@ func TestEscalationSeverity_Values(t *testing.T) {
 	assert.Equal(t, EscalationSeverity("warning"), SeverityWarning)
 	assert.Equal(t, EscalationSeverity("blocking"), SeverityBlocking)
 }
+
+func TestPendingThreads(t *testing.T) {
+	comments := []forge.PRComment{
+		{ID: 1, Body: "Rename this"},
+		{ID: 2, Body: "Add a test"},
+		{ID: 3, Body: "Renamed\n\n" + feedbackReplyMarker, InReplyTo: 1},
+		{ID: 4, Body: "Why not?\n\n" + feedbackReplyMarker, InReplyTo: 2},
+		{ID: 5, Body: "Because it is flaky", InReplyTo: 2},
+		{ID: 6, Body: "Orphaned reply", InReplyTo: 99},
+	}
+
+	threads := pendingThreads(comments)
+	require.Len(t, threads, 2)
+	assert.Equal(t, int64(2), threads[0].Root.ID)
+	assert.Len(t, threads[0].Replies, 2)
+	assert.Equal(t, int64(6), threads[1].Root.ID)
+}
+
+// postComment leaves a review comment on x.go as alice
+func postComment(t *testing.T, baseURL string, prNumber int, body string) {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"body": body, "path": "x.go", "line": 3})
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/repos/local/choo/pulls/%d/comments", baseURL, prNumber), bytes.NewReader(data))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer alice")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+var responsePathPattern = regexp.MustCompile(`Write (\S+response\.json)`)
+
+// TestHandleFeedback_RepliesAndResolvesThreads runs a feedback round against
+// the fake forge: one comment is fixed and one declined
+func TestHandleFeedback_RepliesAndResolvesThreads(t *testing.T) {
+	testutil.UnsetGitEnv()
+	tmp := t.TempDir()
+	bare := filepath.Join(tmp, "repo.git")
+	clone := filepath.Join(tmp, "clone")
+	testutil.Git(t, tmp, "init", "--bare", "-b", "main", bare)
+	testutil.Git(t, tmp, "clone", bare, clone)
+	testutil.Git(t, clone, "checkout", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(clone, "x.go"), []byte("package x\n"), 0644))
+	testutil.Git(t, clone, "add", "-A")
+	testutil.Git(t, clone, "commit", "-m", "init")
+	testutil.Git(t, clone, "push", "origin", "main")
+	testutil.Git(t, clone, "checkout", "-b", "feature/x")
+	require.NoError(t, os.WriteFile(filepath.Join(clone, "x.go"), []byte("package x\n\nvar v = 1\n"), 0644))
+	testutil.Git(t, clone, "commit", "-am", "add v")
+	testutil.Git(t, clone, "push", "origin", "feature/x")
+
+	srv, err := fake.New(fake.Config{Owner: "local", Repo: "choo", BarePath: bare})
+	require.NoError(t, err)
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	reviewer, err := github.NewPRClient(github.PRClientConfig{Owner: "local", Repo: "choo", BaseURL: ts.URL, Token: "alice"})
+	require.NoError(t, err)
+	client, err := github.NewPRClient(github.PRClientConfig{Owner: "local", Repo: "choo", BaseURL: ts.URL, Token: "choo"})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	pr, err := client.OpenPR(ctx, "feature/x", "main", "Add v", "")
+	require.NoError(t, err)
+	postComment(t, ts.URL, pr.Number, "Rename v to value")
+	postComment(t, ts.URL, pr.Number, "Make this a constant")
+	comments, err := reviewer.GetPRComments(ctx, pr.Number)
+	require.NoError(t, err)
+	renameID, constID := comments[0].ID, comments[1].ID
+
+	claude := &feedbackClaudeInvoker{invokeFunc: func(ctx context.Context, prompt, workdir string) error {
+		require.NoError(t, os.WriteFile(filepath.Join(workdir, "x.go"), []byte("package x\n\nvar value = 1\n"), 0644))
+		testutil.Git(t, workdir, "commit", "-am", "address review feedback")
+		testutil.Git(t, workdir, "push", "origin", "feature/x")
+
+		m := responsePathPattern.FindStringSubmatch(prompt)
+		require.NotNil(t, m, "prompt should name a response file")
+		assert.False(t, strings.HasPrefix(m[1], workdir), "response file must be outside the worktree")
+		return os.WriteFile(m[1], []byte(`[
+			{"thread": `+strconv.FormatInt(renameID, 10)+`, "status": "fixed", "summary": "renamed v to value"},
+			{"thread": `+strconv.FormatInt(constID, 10)+`, "status": "declined", "summary": "it is reassigned in tests"}]`), 0644)
+	}}
+
+	handler := NewFeedbackHandler(FeedbackConfig{}, FeedbackDeps{Forge: client, Claude: claude})
+	require.NoError(t, handler.HandleFeedback(ctx, pr.Number, pr.URL, clone, "feature/x"))
+
+	sha := testutil.Git(t, clone, "rev-parse", "--short", "HEAD")
+	comments, err = reviewer.GetPRComments(ctx, pr.Number)
+	require.NoError(t, err)
+	require.Len(t, comments, 4)
+	assert.Equal(t, renameID, comments[2].InReplyTo)
+	assert.Equal(t, "Fixed in "+sha+": renamed v to value\n\n"+feedbackReplyMarker, comments[2].Body)
+	assert.Equal(t, constID, comments[3].InReplyTo)
+	assert.Equal(t, "Not changed: it is reassigned in tests\n\n"+feedbackReplyMarker, comments[3].Body)
+
+	threads := srv.Comments(pr.Number)
+	assert.True(t, threads[0].Resolved, "fixed thread is resolved")
+	assert.False(t, threads[1].Resolved, "declined thread stays open")
+
+	// Both threads now wait on the reviewer, so another round does nothing
+	require.NoError(t, handler.HandleFeedback(ctx, pr.Number, pr.URL, clone, "feature/x"))
+	assert.Equal(t, 1, claude.calls)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/RevCBH/choo/internal/discovery"
 	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/provider"
 )
 
 // LoopState tracks the Ralph loop execution state
@@ -94,8 +95,9 @@ func (w *Worker) invokeProvider(ctx context.Context, prompt TaskPrompt) error {
 		w.events.Emit(evt)
 	}
 
-	// Track error to emit in TaskClaudeDone event
+	// Track error and cost to emit in TaskClaudeDone event
 	var runErr error
+	var cost *float64
 	defer func() {
 		// Always emit TaskClaudeDone event (name unchanged for backward compatibility)
 		if w.events != nil {
@@ -103,6 +105,9 @@ func (w *Worker) invokeProvider(ctx context.Context, prompt TaskPrompt) error {
 			if w.currentTask != nil {
 				evt = evt.WithTask(w.currentTask.Number)
 			}
+			if cost != nil {
+				evt = evt.WithPayload(map[string]any{"cost_usd": *cost})
+			}
 			if runErr != nil {
 				evt = evt.WithError(runErr)
 			}
@@ -128,9 +133,9 @@ func (w *Worker) invokeProvider(ctx context.Context, prompt TaskPrompt) error {
 		fmt.Fprintf(os.Stderr, "Warning: failed to create log file: %v\n", err)
 		// Fall back to stdout/stderr (unless suppressed)
 		if !w.config.SuppressOutput {
-			runErr = w.provider.Invoke(ctx, prompt.Content, w.worktreePath, os.Stdout, os.Stderr)
+			cost, runErr = w.invokeWithTranscript(ctx, prompt.Content, os.Stdout, os.Stderr)
 		} else {
-			runErr = w.provider.Invoke(ctx, prompt.Content, w.worktreePath, io.Discard, io.Discard)
+			cost, runErr = w.invokeWithTranscript(ctx, prompt.Content, io.Discard, io.Discard)
 		}
 		return runErr
 	}
@@ -152,7 +157,7 @@ func (w *Worker) invokeProvider(ctx context.Context, prompt TaskPrompt) error {
 	}
 
 	// Invoke provider
-	runErr = w.provider.Invoke(ctx, prompt.Content, w.worktreePath, stdout, stderr)
+	cost, runErr = w.invokeWithTranscript(ctx, prompt.Content, stdout, stderr)
 
 	// Write completion status to log
 	fmt.Fprintf(logFile, "\n=== END PROVIDER OUTPUT ===\n")
@@ -165,6 +170,37 @@ func (w *Worker) invokeProvider(ctx context.Context, prompt TaskPrompt) error {
 	return runErr
 }
 
+// invokeWithTranscript invokes the provider in the worktree, streaming its
+// transcript to the event bus if it can report one. Returns the
+// invocation's cost, or nil if the provider did not report it.
+func (w *Worker) invokeWithTranscript(ctx context.Context, prompt string, stdout, stderr io.Writer) (*float64, error) {
+	transcriber, ok := w.provider.(provider.Transcriber)
+	if !ok || w.events == nil {
+		return nil, w.provider.Invoke(ctx, prompt, w.worktreePath, stdout, stderr)
+	}
+
+	var task *int
+	if w.currentTask != nil {
+		number := w.currentTask.Number
+		task = &number
+	}
+	transcript := newTranscriptStream(w.events, w.unit.ID, task)
+	defer transcript.Close()
+
+	// The result entry carries the cost; it has nothing to show
+	var cost *float64
+	err := transcriber.InvokeWithTranscript(ctx, prompt, w.worktreePath, stdout, stderr, func(entry provider.TranscriptEntry) {
+		if entry.Kind == provider.TranscriptResult {
+			if entry.CostUSD != nil {
+				cost = entry.CostUSD
+			}
+			return
+		}
+		transcript.Add(entry)
+	})
+	return cost, err
+}
+
 // verifyTaskComplete re-parses task file to check if status was updated
 func (w *Worker) verifyTaskComplete(task *discovery.Task) (bool, error) {
 	// unit.Path may be relative (e.g., specs/tasks/web) or absolute
@@ -119,7 +119,7 @@ func (w *Worker) mergeWithConflictResolution(ctx context.Context) error {
 	return w.forcePushAndMerge(ctx)
 }
 
-// forcePushAndMerge pushes the rebased branch and merges via GitHub API
+// forcePushAndMerge pushes the rebased branch and merges via the forge API
 //
 //nolint:unused // WIP: called by mergeWithConflictResolution
 func (w *Worker) forcePushAndMerge(ctx context.Context) error {
@@ -128,8 +128,8 @@ func (w *Worker) forcePushAndMerge(ctx context.Context) error {
 		return fmt.Errorf("force push failed: %w", err)
 	}
 
-	// Merge via GitHub API
-	if _, err := w.github.Merge(ctx, w.prNumber); err != nil {
+	// Merge via the forge API
+	if _, err := w.forge.Merge(ctx, w.prNumber); err != nil {
 		return fmt.Errorf("merge failed: %w", err)
 	}
 
@@ -0,0 +1,364 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/git"
+)
+
+// mergeQueueDir is the scratch worktree used for speculative integration.
+// It lives under the worktree base so it is cleaned up with other worktrees.
+const mergeQueueDir = ".merge-queue"
+
+// ErrMergeQueueConflict is returned when a unit branch conflicts with
+// other units in its batch. Rebasing onto the updated target and
+// resubmitting usually resolves it.
+var ErrMergeQueueConflict = errors.New("merge queue: unit conflicts with batch")
+
+// ErrMergeQueueBaseline is returned when bisection identifies a unit as
+// the cause of a baseline check failure on the combined result.
+var ErrMergeQueueBaseline = errors.New("merge queue: baseline checks failed")
+
+// MergeQueueConfig controls batching behavior of the merge queue
+type MergeQueueConfig struct {
+	Enabled     bool
+	BatchSize   int           // Max units integrated together (0 = 1)
+	BatchWindow time.Duration // How long to wait for more units before integrating
+}
+
+// MergeRequest identifies a completed unit branch waiting to land
+type MergeRequest struct {
+	UnitID string
+	Branch string
+}
+
+// queueEntry is a pending request and the channel its submitter waits on
+type queueEntry struct {
+	req  MergeRequest
+	done chan error
+}
+
+// MergeQueue batches completed units, merges them together in a scratch
+// worktree, runs baseline checks once on the combined result, and bisects
+// failing batches to find the offending unit. Only verified commits are
+// fast-forwarded onto the target branch in RepoRoot.
+type MergeQueue struct {
+	config  WorkerConfig
+	queue   MergeQueueConfig
+	events  *events.Bus
+	runner  git.Runner
+	mergeMu *sync.Mutex // Shared with the legacy merge path
+	ctx     context.Context
+
+	// runChecks runs baseline checks in dir; overridable for testing
+	runChecks func(ctx context.Context, dir string) (bool, string)
+
+	mu      sync.Mutex
+	pending []*queueEntry
+	active  bool          // A batch processor goroutine is running
+	full    chan struct{} // Signals that a full batch is waiting
+}
+
+// NewMergeQueue creates a merge queue. Batches are processed with ctx,
+// which should outlive individual units (typically the pool context).
+func NewMergeQueue(ctx context.Context, cfg WorkerConfig, bus *events.Bus, mergeMu *sync.Mutex) *MergeQueue {
+	if mergeMu == nil {
+		mergeMu = &sync.Mutex{}
+	}
+	q := &MergeQueue{
+		config:  cfg,
+		queue:   cfg.MergeQueue,
+		events:  bus,
+		runner:  git.DefaultRunner(),
+		mergeMu: mergeMu,
+		ctx:     ctx,
+		full:    make(chan struct{}, 1),
+	}
+	q.runChecks = func(ctx context.Context, dir string) (bool, string) {
+		return RunBaselineChecks(ctx, q.config.BaselineChecks, dir, q.config.BaselineTimeout)
+	}
+	return q
+}
+
+// Submit enqueues a unit branch and blocks until it has landed on the
+// target branch or been rejected. Returns ErrMergeQueueConflict or
+// ErrMergeQueueBaseline (wrapped) when the unit was rejected.
+func (q *MergeQueue) Submit(ctx context.Context, req MergeRequest) error {
+	entry := &queueEntry{req: req, done: make(chan error, 1)}
+
+	q.mu.Lock()
+	q.pending = append(q.pending, entry)
+	if len(q.pending) >= q.batchSize() {
+		select {
+		case q.full <- struct{}{}:
+		default:
+		}
+	}
+	if !q.active {
+		q.active = true
+		go q.process()
+	}
+	q.mu.Unlock()
+
+	select {
+	case err := <-entry.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// batchSize returns the effective maximum batch size
+func (q *MergeQueue) batchSize() int {
+	if q.queue.BatchSize < 1 {
+		return 1
+	}
+	return q.queue.BatchSize
+}
+
+// process drains the queue one batch at a time, exiting when it is empty
+func (q *MergeQueue) process() {
+	for {
+		q.waitForBatch()
+
+		q.mu.Lock()
+		n := min(len(q.pending), q.batchSize())
+		batch := q.pending[:n:n]
+		q.pending = q.pending[n:]
+		select {
+		case <-q.full: // Consume any signal for the batch just taken
+		default:
+		}
+		if len(batch) == 0 {
+			q.active = false
+			q.mu.Unlock()
+			return
+		}
+		q.mu.Unlock()
+
+		q.runBatch(q.ctx, batch)
+	}
+}
+
+// waitForBatch waits up to BatchWindow for a full batch to accumulate
+func (q *MergeQueue) waitForBatch() {
+	q.mu.Lock()
+	ready := len(q.pending) >= q.batchSize()
+	q.mu.Unlock()
+	if ready || q.queue.BatchWindow <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(q.queue.BatchWindow)
+	defer timer.Stop()
+	select {
+	case <-q.full:
+	case <-timer.C:
+	case <-q.ctx.Done():
+	}
+}
+
+// runBatch integrates a batch and reports the outcome to each submitter
+func (q *MergeQueue) runBatch(ctx context.Context, batch []*queueEntry) {
+	q.mergeMu.Lock()
+	defer q.mergeMu.Unlock()
+
+	results := make(map[*queueEntry]error, len(batch))
+	if err := q.integrate(ctx, batch, results); err != nil {
+		for _, e := range batch {
+			if _, decided := results[e]; !decided {
+				results[e] = err
+			}
+		}
+	}
+
+	for _, e := range batch {
+		e.done <- results[e]
+	}
+}
+
+// integrate speculatively merges the batch in the scratch worktree,
+// bisecting on baseline failure, then fast-forwards the target branch to
+// the verified result. Per-unit outcomes are recorded in results; a
+// returned error applies to every unit without an outcome.
+func (q *MergeQueue) integrate(ctx context.Context, batch []*queueEntry, results map[*queueEntry]error) error {
+	base, err := q.git(ctx, q.config.RepoRoot, "rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("merge queue: failed to resolve %s: %w", q.config.TargetBranch, err)
+	}
+
+	scratch := filepath.Join(q.config.WorktreeBase, mergeQueueDir)
+	if err := q.setupScratch(ctx, scratch, base); err != nil {
+		return err
+	}
+	defer q.removeScratch(ctx, scratch)
+
+	q.emit(events.NewEvent(events.MergeQueueBatchStarted, "").WithPayload(map[string]any{
+		"units": unitIDs(batch),
+		"base":  base,
+	}))
+
+	checksRun := 0
+	head, err := q.bisect(ctx, scratch, base, batch, results, &checksRun)
+	if err != nil {
+		return err
+	}
+
+	var landed []*queueEntry
+	for _, e := range batch {
+		if _, rejected := results[e]; !rejected {
+			landed = append(landed, e)
+		}
+	}
+	if len(landed) == 0 {
+		return nil
+	}
+
+	// Only this queue (or the legacy path, excluded by mergeMu) moves the
+	// target branch, so it is still at base and the verified head is a
+	// descendant of it.
+	if _, err := q.git(ctx, q.config.RepoRoot, "merge", "--ff-only", head); err != nil {
+		return fmt.Errorf("merge queue: failed to fast-forward %s: %w", q.config.TargetBranch, err)
+	}
+
+	for _, e := range landed {
+		results[e] = nil
+	}
+
+	q.emit(events.NewEvent(events.MergeQueueBatchLanded, "").WithPayload(map[string]any{
+		"units":      unitIDs(landed),
+		"commit":     head,
+		"checks_run": checksRun,
+	}))
+	fmt.Fprintf(os.Stderr, "Merge queue landed %d unit(s) on %s after %d baseline run(s)\n",
+		len(landed), q.config.TargetBranch, checksRun)
+	return nil
+}
+
+// bisect merges entries onto base and runs baseline checks once. On failure
+// the batch is split in half and each half is tested in turn, the right half
+// on top of whatever the left half landed, until the offending units are
+// isolated. Returns the head commit containing every accepted entry.
+func (q *MergeQueue) bisect(ctx context.Context, scratch, base string, entries []*queueEntry, results map[*queueEntry]error, checksRun *int) (string, error) {
+	head, merged, err := q.speculate(ctx, scratch, base, entries, results)
+	if err != nil {
+		return "", err
+	}
+	if len(merged) == 0 {
+		return base, nil
+	}
+
+	*checksRun++
+	passed, output := q.runChecks(ctx, scratch)
+	if passed {
+		return head, nil
+	}
+
+	if len(merged) == 1 {
+		e := merged[0]
+		results[e] = fmt.Errorf("%w: unit %s breaks %s: %s", ErrMergeQueueBaseline, e.req.UnitID, q.config.TargetBranch, output)
+		q.reject(e, "baseline", output)
+		return base, nil
+	}
+
+	q.emit(events.NewEvent(events.MergeQueueBisect, "").WithPayload(map[string]any{
+		"units":  unitIDs(merged),
+		"output": output,
+	}))
+
+	mid := len(merged) / 2
+	head, err = q.bisect(ctx, scratch, base, merged[:mid], results, checksRun)
+	if err != nil {
+		return "", err
+	}
+	return q.bisect(ctx, scratch, head, merged[mid:], results, checksRun)
+}
+
+// speculate resets the scratch worktree to base and merges each entry in
+// order. Entries that conflict are rejected and skipped. Returns the
+// resulting head and the entries that merged cleanly.
+func (q *MergeQueue) speculate(ctx context.Context, scratch, base string, entries []*queueEntry, results map[*queueEntry]error) (string, []*queueEntry, error) {
+	if _, err := q.git(ctx, scratch, "reset", "--hard", base); err != nil {
+		return "", nil, fmt.Errorf("merge queue: failed to reset scratch worktree: %w", err)
+	}
+	if _, err := q.git(ctx, scratch, "clean", "-fdx"); err != nil {
+		return "", nil, fmt.Errorf("merge queue: failed to clean scratch worktree: %w", err)
+	}
+
+	var merged []*queueEntry
+	for _, e := range entries {
+		_, err := q.git(ctx, scratch, "merge", e.req.Branch, "-m", fmt.Sprintf("Merge unit %s", e.req.UnitID))
+		if err == nil {
+			merged = append(merged, e)
+			continue
+		}
+
+		files, _ := git.GetConflictedFiles(ctx, scratch)
+		_, _ = q.git(ctx, scratch, "merge", "--abort")
+		if len(files) == 0 {
+			return "", nil, fmt.Errorf("merge queue: failed to merge unit %s: %w", e.req.UnitID, err)
+		}
+		results[e] = fmt.Errorf("%w: unit %s conflicts in %s", ErrMergeQueueConflict, e.req.UnitID, strings.Join(files, ", "))
+		q.reject(e, "conflict", strings.Join(files, "\n"))
+	}
+
+	head, err := q.git(ctx, scratch, "rev-parse", "HEAD")
+	if err != nil {
+		return "", nil, fmt.Errorf("merge queue: failed to resolve scratch head: %w", err)
+	}
+	return head, merged, nil
+}
+
+// setupScratch creates a fresh detached scratch worktree at base
+func (q *MergeQueue) setupScratch(ctx context.Context, scratch, base string) error {
+	q.removeScratch(ctx, scratch)
+	if _, err := q.git(ctx, q.config.RepoRoot, "worktree", "add", "--detach", scratch, base); err != nil {
+		return fmt.Errorf("merge queue: failed to create scratch worktree: %w", err)
+	}
+	return nil
+}
+
+// removeScratch removes the scratch worktree, ignoring errors if absent
+func (q *MergeQueue) removeScratch(ctx context.Context, scratch string) {
+	_, _ = q.git(ctx, q.config.RepoRoot, "worktree", "remove", "--force", scratch)
+	_ = os.RemoveAll(scratch)
+	_, _ = q.git(ctx, q.config.RepoRoot, "worktree", "prune")
+}
+
+// reject emits a MergeQueueUnitRejected event for an entry
+func (q *MergeQueue) reject(e *queueEntry, reason, output string) {
+	q.emit(events.NewEvent(events.MergeQueueUnitRejected, e.req.UnitID).WithPayload(map[string]any{
+		"branch": e.req.Branch,
+		"reason": reason,
+		"output": output,
+	}))
+}
+
+func (q *MergeQueue) emit(evt events.Event) {
+	if q.events != nil {
+		q.events.Emit(evt)
+	}
+}
+
+// git runs a git command and returns its trimmed output
+func (q *MergeQueue) git(ctx context.Context, dir string, args ...string) (string, error) {
+	out, err := q.runner.Exec(ctx, dir, args...)
+	return strings.TrimSpace(out), err
+}
+
+// unitIDs returns the unit IDs of the given entries in order
+func unitIDs(entries []*queueEntry) []string {
+	ids := make([]string, len(entries))
+	for i, e := range entries {
+		ids[i] = e.req.UnitID
+	}
+	return ids
+}
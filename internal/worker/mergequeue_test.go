@@ -0,0 +1,201 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupMergeQueueRepo creates a repo on main with one unit branch per entry
+// in files, each committing its own set of files.
+func setupMergeQueueRepo(t *testing.T, files map[string]map[string]string) string {
+	t.Helper()
+	testutil.UnsetGitEnv()
+
+	repo := t.TempDir()
+	testutil.Git(t, repo, "init", "-b", "main")
+	testutil.Git(t, repo, "config", "user.email", "test@example.com")
+	testutil.Git(t, repo, "config", "user.name", "Test")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("# test\n"), 0644))
+	testutil.Git(t, repo, "add", "-A")
+	testutil.Git(t, repo, "commit", "-m", "initial")
+
+	for unit, contents := range files {
+		testutil.Git(t, repo, "checkout", "-b", "ralph/"+unit, "main")
+		for name, body := range contents {
+			require.NoError(t, os.WriteFile(filepath.Join(repo, name), []byte(body), 0644))
+		}
+		testutil.Git(t, repo, "add", "-A")
+		testutil.Git(t, repo, "commit", "-m", "unit "+unit)
+	}
+	testutil.Git(t, repo, "checkout", "main")
+	return repo
+}
+
+// newTestMergeQueue creates a queue whose baseline check fails when broken
+// returns true for the scratch worktree contents
+func newTestMergeQueue(t *testing.T, repo string, batchSize int, bus *events.Bus, broken func(dir string) bool) (*MergeQueue, *int) {
+	t.Helper()
+	q := NewMergeQueue(context.Background(), WorkerConfig{
+		RepoRoot:     repo,
+		TargetBranch: "main",
+		WorktreeBase: filepath.Join(repo, ".ralph", "worktrees"),
+		MergeQueue: MergeQueueConfig{
+			Enabled:     true,
+			BatchSize:   batchSize,
+			BatchWindow: time.Minute,
+		},
+	}, bus, nil)
+
+	var mu sync.Mutex
+	runs := 0
+	q.runChecks = func(ctx context.Context, dir string) (bool, string) {
+		mu.Lock()
+		runs++
+		mu.Unlock()
+		if broken(dir) {
+			return false, "=== test ===\nbroken"
+		}
+		return true, ""
+	}
+	return q, &runs
+}
+
+// submitAll submits the units concurrently and returns each unit's result
+func submitAll(q *MergeQueue, units ...string) map[string]error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]error)
+	for _, unit := range units {
+		wg.Add(1)
+		go func(unit string) {
+			defer wg.Done()
+			err := q.Submit(context.Background(), MergeRequest{UnitID: unit, Branch: "ralph/" + unit})
+			mu.Lock()
+			results[unit] = err
+			mu.Unlock()
+		}(unit)
+	}
+	wg.Wait()
+	return results
+}
+
+func fileExists(dir, name string) bool {
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
+
+func TestMergeQueue_LandsBatchWithSingleCheck(t *testing.T) {
+	repo := setupMergeQueueRepo(t, map[string]map[string]string{
+		"a": {"a.txt": "a"},
+		"b": {"b.txt": "b"},
+		"c": {"c.txt": "c"},
+	})
+	bus := events.NewBus(100)
+	defer bus.Close()
+	collector := events.NewEventCollector(bus)
+
+	q, runs := newTestMergeQueue(t, repo, 3, bus, func(string) bool { return false })
+	results := submitAll(q, "a", "b", "c")
+
+	for unit, err := range results {
+		assert.NoError(t, err, "unit %s", unit)
+	}
+	assert.Equal(t, 1, *runs, "baseline checks should run once for the batch")
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		assert.True(t, fileExists(repo, name), "%s should be on main", name)
+	}
+	assert.NoDirExists(t, filepath.Join(repo, ".ralph", "worktrees", mergeQueueDir))
+
+	bus.Wait()
+	landed := collector.Get()
+	var types []events.EventType
+	for _, e := range landed {
+		types = append(types, e.Type)
+	}
+	assert.Contains(t, types, events.MergeQueueBatchStarted)
+	assert.Contains(t, types, events.MergeQueueBatchLanded)
+	assert.NotContains(t, types, events.MergeQueueBisect)
+}
+
+func TestMergeQueue_BisectsToOffendingUnit(t *testing.T) {
+	repo := setupMergeQueueRepo(t, map[string]map[string]string{
+		"a":   {"a.txt": "a"},
+		"b":   {"b.txt": "b"},
+		"bad": {"BROKEN": "x"},
+		"d":   {"d.txt": "d"},
+	})
+
+	q, runs := newTestMergeQueue(t, repo, 4, nil, func(dir string) bool {
+		return fileExists(dir, "BROKEN")
+	})
+	results := submitAll(q, "a", "b", "bad", "d")
+
+	require.Error(t, results["bad"])
+	assert.True(t, errors.Is(results["bad"], ErrMergeQueueBaseline))
+	for _, unit := range []string{"a", "b", "d"} {
+		assert.NoError(t, results[unit], "unit %s", unit)
+	}
+	assert.Greater(t, *runs, 1)
+	assert.False(t, fileExists(repo, "BROKEN"))
+	for _, name := range []string{"a.txt", "b.txt", "d.txt"} {
+		assert.True(t, fileExists(repo, name), "%s should be on main", name)
+	}
+}
+
+func TestMergeQueue_CatchesCrossUnitBreakage(t *testing.T) {
+	repo := setupMergeQueueRepo(t, map[string]map[string]string{
+		"a": {"a.txt": "a"},
+		"b": {"b.txt": "b"},
+	})
+
+	// Each unit passes alone; only the combination is broken
+	q, _ := newTestMergeQueue(t, repo, 2, nil, func(dir string) bool {
+		return fileExists(dir, "a.txt") && fileExists(dir, "b.txt")
+	})
+	results := submitAll(q, "a", "b")
+
+	failed := 0
+	for _, err := range results {
+		if err != nil {
+			assert.True(t, errors.Is(err, ErrMergeQueueBaseline))
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed, "exactly one unit should be rejected")
+	assert.NotEqual(t, fileExists(repo, "a.txt"), fileExists(repo, "b.txt"))
+}
+
+func TestMergeQueue_RejectsConflictingUnit(t *testing.T) {
+	repo := setupMergeQueueRepo(t, map[string]map[string]string{
+		"a": {"shared.txt": "from a\n"},
+		"b": {"shared.txt": "from b\n"},
+	})
+
+	q, _ := newTestMergeQueue(t, repo, 2, nil, func(string) bool { return false })
+	results := submitAll(q, "a", "b")
+
+	var conflicted, landed int
+	for _, err := range results {
+		switch {
+		case err == nil:
+			landed++
+		case errors.Is(err, ErrMergeQueueConflict):
+			conflicted++
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	assert.Equal(t, 1, landed)
+	assert.Equal(t, 1, conflicted)
+	assert.Empty(t, testutil.Git(t, repo, "status", "--porcelain"))
+}
@@ -7,8 +7,8 @@ import (
 
 	"github.com/RevCBH/choo/internal/discovery"
 	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/forge"
 	"github.com/RevCBH/choo/internal/git"
-	"github.com/RevCBH/choo/internal/github"
 	"github.com/RevCBH/choo/internal/provider"
 )
 
@@ -22,12 +22,13 @@ type Pool struct {
 	config          WorkerConfig
 	events          *events.Bus
 	git             *git.WorktreeManager
-	github          *github.PRClient
+	forge           forge.Forge
 	providerFactory ProviderFactory   // NEW: factory for creating providers per-unit
 	reviewer        provider.Reviewer // Shared reviewer for code review (may be nil)
 	workers         map[string]*Worker
 	mu              sync.Mutex
-	mergeMu         sync.Mutex // Serializes merge operations to prevent conflicts
+	mergeMu         sync.Mutex  // Serializes merge operations to prevent conflicts
+	mergeQueue      *MergeQueue // Batches merges when enabled (nil otherwise)
 	wg              sync.WaitGroup
 	sem             chan struct{} // Semaphore for concurrency control
 	firstErr        error         // First error encountered
@@ -47,12 +48,12 @@ type PoolStats struct {
 // NewPoolWithFactory creates a worker pool with a custom provider factory
 func NewPoolWithFactory(maxWorkers int, cfg WorkerConfig, deps WorkerDeps, factory ProviderFactory) *Pool {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &Pool{
+	p := &Pool{
 		maxWorkers:      maxWorkers,
 		config:          cfg,
 		events:          deps.Events,
 		git:             deps.Git,
-		github:          deps.GitHub,
+		forge:           deps.Forge,
 		providerFactory: factory,
 		reviewer:        deps.Reviewer, // Store reviewer from deps
 		workers:         make(map[string]*Worker),
@@ -60,6 +61,10 @@ func NewPoolWithFactory(maxWorkers int, cfg WorkerConfig, deps WorkerDeps, facto
 		cancelCtx:       ctx,
 		cancelFunc:      cancel,
 	}
+	if cfg.MergeQueue.Enabled {
+		p.mergeQueue = NewMergeQueue(ctx, cfg, deps.Events, &p.mergeMu)
+	}
+	return p
 }
 
 // NewPool creates a worker pool with the specified parallelism
@@ -98,12 +103,13 @@ func (p *Pool) Submit(unit *discovery.Unit) error {
 
 	// Create worker with resolved provider
 	worker, err := NewWorker(unit, p.config, WorkerDeps{
-		Events:   p.events,
-		Git:      p.git,
-		GitHub:   p.github,
-		Provider: prov,
-		MergeMu:  &p.mergeMu,
-		Reviewer: p.reviewer, // Pass reviewer to worker
+		Events:     p.events,
+		Git:        p.git,
+		Forge:      p.forge,
+		Provider:   prov,
+		MergeMu:    &p.mergeMu,
+		MergeQueue: p.mergeQueue,
+		Reviewer:   p.reviewer, // Pass reviewer to worker
 	})
 	if err != nil {
 		p.mu.Unlock()
@@ -67,7 +67,7 @@ func mockDeps(t *testing.T) WorkerDeps {
 	return WorkerDeps{
 		Events:   events.NewBus(100),
 		Git:      git.NewWorktreeManager(repoDir, nil),
-		GitHub:   nil,
+		Forge:    nil,
 		Provider: nil,
 	}
 }
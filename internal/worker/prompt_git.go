@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/RevCBH/choo/internal/github"
+	"github.com/RevCBH/choo/internal/forge"
 )
 
 // BuildCommitPrompt creates a prompt for Claude to commit changes
@@ -70,9 +70,9 @@ Please resolve all conflicts:
 2. Find the conflict markers (<<<<<<, =======, >>>>>>>)
 3. Edit to resolve - keep the correct code, remove markers
 4. Stage resolved files: git add <file>
-5. Continue the rebase: git rebase --continue
 
-If the rebase continues successfully, do NOT push - the orchestrator will handle that.
+Do NOT run git rebase --continue and do NOT push - the orchestrator records
+your resolution, continues the rebase and handles the rest.
 
 If you cannot resolve a conflict, explain why in your response.`, targetBranch, formatFileList(conflictedFiles))
 }
@@ -89,18 +89,24 @@ Please resolve all conflicts:
 2. Find the conflict markers (<<<<<<, =======, >>>>>>>)
 3. Edit to resolve - keep the correct code, remove markers
 4. Stage resolved files: git add <file>
-5. Complete the merge: git commit -m "Merge %s"
 
-If you cannot resolve a conflict, explain why in your response.`, sourceBranch, targetBranch, formatFileList(conflictedFiles), sourceBranch)
+Do NOT commit the merge - the orchestrator verifies your resolution and commits it.
+
+If you cannot resolve a conflict, explain why in your response.`, sourceBranch, targetBranch, formatFileList(conflictedFiles))
 }
 
-// BuildFeedbackPrompt constructs the Claude prompt for addressing PR feedback
-func BuildFeedbackPrompt(prURL string, comments []github.PRComment) string {
+// BuildFeedbackPrompt constructs the Claude prompt for addressing PR feedback.
+// Claude records how it handled each thread in responsePath so the handler
+// can reply on the PR.
+func BuildFeedbackPrompt(prURL string, threads []FeedbackThread, responsePath string) string {
 	var commentText strings.Builder
-	for _, c := range comments {
-		fmt.Fprintf(&commentText, "- @%s: %s\n", c.Author, c.Body)
-		if c.Path != "" {
-			fmt.Fprintf(&commentText, "  (on %s:%d)\n", c.Path, c.Line)
+	for _, t := range threads {
+		fmt.Fprintf(&commentText, "- [thread %d] @%s: %s\n", t.Root.ID, t.Root.Author, t.Root.Body)
+		if t.Root.Path != "" {
+			fmt.Fprintf(&commentText, "  (on %s:%d)\n", t.Root.Path, t.Root.Line)
+		}
+		for _, r := range t.Replies {
+			fmt.Fprintf(&commentText, "  - @%s: %s\n", r.Author, r.Body)
 		}
 	}
 
@@ -110,47 +116,52 @@ func BuildFeedbackPrompt(prURL string, comments []github.PRComment) string {
 After making changes:
 1. Stage and commit with a message like "address review feedback"
 2. Push the changes
+3. Write %s (do not commit it) as a JSON array with one entry per thread:
+   [{"thread": <id>, "status": "fixed", "summary": "<what changed>"},
+    {"thread": <id>, "status": "declined", "summary": "<why no change was made>"}]
+   Use "declined" for comments you deliberately did not act on.
 
-The orchestrator will continue polling for approval.`, prURL, commentText.String())
+Your summaries are posted as replies on the PR, and fixed threads are resolved.
+The orchestrator will continue polling for approval.`, prURL, commentText.String(), responsePath)
 }
 
-// BuildFeaturePRPrompt creates a prompt for Claude to create a PR for a feature branch
-func BuildFeaturePRPrompt(featureBranch, targetBranch string, completedUnits []string) string {
-	return fmt.Sprintf(`All units for this feature are complete and merged into the feature branch.
-
-Create a pull request to merge the feature into %s:
-- Source branch: %s
-- Target branch: %s
-
-Completed units:
-%s
+// BuildCIFixPrompt creates the prompt for fixing failed CI checks on a
+// feature branch. The orchestrator pushes the resulting commits.
+func BuildCIFixPrompt(prURL, branch string, failures []forge.FailedCheck) string {
+	var checks strings.Builder
+	if len(failures) == 0 {
+		checks.WriteString("(no details available from the CI provider)\n")
+	}
+	for _, f := range failures {
+		fmt.Fprintf(&checks, "### %s\n", f.Name)
+		if f.URL != "" {
+			fmt.Fprintf(&checks, "%s\n", f.URL)
+		}
+		for _, text := range []string{f.Title, f.Summary, f.Text} {
+			if text != "" {
+				fmt.Fprintf(&checks, "%s\n", text)
+			}
+		}
+		for _, a := range f.Annotations {
+			fmt.Fprintf(&checks, "- %s:%d [%s] %s\n", a.Path, a.Line, a.Level, a.Message)
+		}
+		if f.Log != "" {
+			fmt.Fprintf(&checks, "\nEnd of log:\n```\n%s\n```\n", strings.TrimRight(f.Log, "\n"))
+		}
+		checks.WriteString("\n")
+	}
 
-Use the gh CLI:
-  gh pr create --base %s --head %s --title "..." --body "..."
+	return fmt.Sprintf(`CI failed on PR %s (branch %s).
 
-Guidelines for the PR:
-- Title: Descriptive summary of the entire feature
-- Body:
-  - Overview of what was implemented across all units
-  - List of units that were completed
-  - Key changes and design decisions
-  - Any notes for reviewers
+Failed checks:
 
-Print the PR URL when done so the orchestrator can capture it.`, targetBranch, featureBranch, targetBranch, formatUnitList(completedUnits), targetBranch, featureBranch)
-}
+%s
+Fix the failures:
+1. Reproduce each failure locally where possible (build, tests, linters)
+2. Make the smallest change that fixes the cause; do not disable or skip checks
+3. Stage and commit with a message like "fix CI: <what was wrong>"
 
-// formatUnitList formats a list of completed units for the PR prompt
-func formatUnitList(units []string) string {
-	if len(units) == 0 {
-		return "(no units)"
-	}
-	var result strings.Builder
-	for _, u := range units {
-		result.WriteString("- ")
-		result.WriteString(u)
-		result.WriteString("\n")
-	}
-	return result.String()
+Do not push; the orchestrator pushes your commit and re-runs CI.`, prURL, branch, checks.String())
 }
 
 // formatFileList formats a list of files for inclusion in prompts
@@ -134,8 +134,8 @@ func TestBuildConflictPrompt_SingleFile(t *testing.T) {
 	if !strings.Contains(prompt, "src/config.go") {
 		t.Error("prompt should contain conflicted file")
 	}
-	if !strings.Contains(prompt, "git rebase --continue") {
-		t.Error("prompt should instruct to continue rebase")
+	if !strings.Contains(prompt, "Do NOT run git rebase --continue") {
+		t.Error("prompt should leave continuing the rebase to the orchestrator")
 	}
 }
 
@@ -164,7 +164,7 @@ func TestBuildConflictPrompt_ContainsInstructions(t *testing.T) {
 		"=======",
 		">>>>>>>",
 		"git add",
-		"git rebase --continue",
+		"Do NOT run git rebase --continue",
 		"do NOT push",
 	}
 
@@ -176,12 +176,12 @@ func TestBuildConflictPrompt_ContainsInstructions(t *testing.T) {
 }
 
 func TestBuildFeedbackPrompt_IncludesAllComments(t *testing.T) {
-	comments := []github.PRComment{
-		{Author: "alice", Body: "Fix the null check", Path: "main.go", Line: 42},
-		{Author: "bob", Body: "Add tests"},
+	threads := []FeedbackThread{
+		{Root: github.PRComment{Author: "alice", Body: "Fix the null check", Path: "main.go", Line: 42}},
+		{Root: github.PRComment{Author: "bob", Body: "Add tests"}},
 	}
 
-	prompt := BuildFeedbackPrompt("https://github.com/org/repo/pull/123", comments)
+	prompt := BuildFeedbackPrompt("https://github.com/org/repo/pull/123", threads, "/tmp/response.json")
 
 	if !strings.Contains(prompt, "@alice: Fix the null check") {
 		t.Error("prompt should contain @alice: Fix the null check")
@@ -198,11 +198,11 @@ func TestBuildFeedbackPrompt_IncludesAllComments(t *testing.T) {
 }
 
 func TestBuildFeedbackPrompt_HandlesNoPath(t *testing.T) {
-	comments := []github.PRComment{
-		{Author: "reviewer", Body: "General comment about the PR"},
+	threads := []FeedbackThread{
+		{Root: github.PRComment{Author: "reviewer", Body: "General comment about the PR"}},
 	}
 
-	prompt := BuildFeedbackPrompt("https://github.com/org/repo/pull/456", comments)
+	prompt := BuildFeedbackPrompt("https://github.com/org/repo/pull/456", threads, "/tmp/response.json")
 
 	if !strings.Contains(prompt, "@reviewer: General comment about the PR") {
 		t.Error("prompt should contain @reviewer: General comment about the PR")
@@ -213,7 +213,7 @@ func TestBuildFeedbackPrompt_HandlesNoPath(t *testing.T) {
 }
 
 func TestBuildFeedbackPrompt_EmptyComments(t *testing.T) {
-	prompt := BuildFeedbackPrompt("https://github.com/org/repo/pull/789", []github.PRComment{})
+	prompt := BuildFeedbackPrompt("https://github.com/org/repo/pull/789", nil, "/tmp/response.json")
 
 	if !strings.Contains(prompt, "pull/789") {
 		t.Error("prompt should contain pull/789")
@@ -224,13 +224,13 @@ func TestBuildFeedbackPrompt_EmptyComments(t *testing.T) {
 }
 
 func TestBuildFeedbackPrompt_MultipleCommentsOnSameFile(t *testing.T) {
-	comments := []github.PRComment{
-		{Author: "alice", Body: "Fix line 10", Path: "main.go", Line: 10},
-		{Author: "alice", Body: "Fix line 20", Path: "main.go", Line: 20},
-		{Author: "bob", Body: "Fix util.go", Path: "util.go", Line: 5},
+	threads := []FeedbackThread{
+		{Root: github.PRComment{Author: "alice", Body: "Fix line 10", Path: "main.go", Line: 10}},
+		{Root: github.PRComment{Author: "alice", Body: "Fix line 20", Path: "main.go", Line: 20}},
+		{Root: github.PRComment{Author: "bob", Body: "Fix util.go", Path: "util.go", Line: 5}},
 	}
 
-	prompt := BuildFeedbackPrompt("https://github.com/org/repo/pull/100", comments)
+	prompt := BuildFeedbackPrompt("https://github.com/org/repo/pull/100", threads, "/tmp/response.json")
 
 	if !strings.Contains(prompt, "(on main.go:10)") {
 		t.Error("prompt should contain (on main.go:10)")
@@ -244,11 +244,11 @@ func TestBuildFeedbackPrompt_MultipleCommentsOnSameFile(t *testing.T) {
 }
 
 func TestBuildFeedbackPrompt_ContainsInstructions(t *testing.T) {
-	comments := []github.PRComment{
-		{Author: "reviewer", Body: "Please fix"},
+	threads := []FeedbackThread{
+		{Root: github.PRComment{Author: "reviewer", Body: "Please fix"}},
 	}
 
-	prompt := BuildFeedbackPrompt("https://github.com/org/repo/pull/1", comments)
+	prompt := BuildFeedbackPrompt("https://github.com/org/repo/pull/1", threads, "/tmp/response.json")
 
 	if !strings.Contains(prompt, "Stage and commit") {
 		t.Error("prompt should contain 'Stage and commit'")
@@ -260,3 +260,51 @@ func TestBuildFeedbackPrompt_ContainsInstructions(t *testing.T) {
 		t.Error("prompt should contain 'orchestrator will continue polling'")
 	}
 }
+
+func TestBuildFeedbackPrompt_IncludesThreadsAndResponseFile(t *testing.T) {
+	threads := []FeedbackThread{{
+		Root:    github.PRComment{ID: 101, Author: "alice", Body: "Rename this"},
+		Replies: []github.PRComment{{ID: 102, Author: "bob", Body: "Agreed", InReplyTo: 101}},
+	}}
+
+	prompt := BuildFeedbackPrompt("https://github.com/org/repo/pull/1", threads, "/tmp/choo-feedback/response.json")
+
+	if !strings.Contains(prompt, "[thread 101] @alice: Rename this") {
+		t.Error("prompt should identify the thread by its opening comment")
+	}
+	if !strings.Contains(prompt, "  - @bob: Agreed") {
+		t.Error("prompt should include replies under their thread")
+	}
+	if !strings.Contains(prompt, "/tmp/choo-feedback/response.json") {
+		t.Error("prompt should name the response file")
+	}
+	if !strings.Contains(prompt, `"declined"`) {
+		t.Error("prompt should explain how to decline a comment")
+	}
+}
+
+func TestBuildCIFixPrompt_IncludesFailureDetail(t *testing.T) {
+	failures := []github.FailedCheck{{
+		Name:        "test",
+		URL:         "https://github.com/org/repo/runs/7",
+		Summary:     "2 tests failed",
+		Annotations: []github.CheckAnnotation{{Path: "x.go", Line: 12, Level: "failure", Message: "undefined: y"}},
+		Log:         "--- FAIL: TestX\n",
+	}}
+
+	prompt := BuildCIFixPrompt("https://github.com/org/repo/pull/1", "feature/x", failures)
+
+	for _, want := range []string{"feature/x", "### test", "2 tests failed", "- x.go:12 [failure] undefined: y", "--- FAIL: TestX", "Do not push"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt should contain %q", want)
+		}
+	}
+}
+
+func TestBuildCIFixPrompt_NoDetails(t *testing.T) {
+	prompt := BuildCIFixPrompt("https://github.com/org/repo/pull/1", "feature/x", nil)
+
+	if !strings.Contains(prompt, "no details available") {
+		t.Error("prompt should say when no failure detail is available")
+	}
+}
@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/provider"
+)
+
+const (
+	// transcriptInterval is how often a transcript is emitted, at most
+	transcriptInterval = 250 * time.Millisecond
+
+	// maxTranscriptPending bounds the entries held between emits; entries
+	// beyond it are dropped and counted
+	maxTranscriptPending = 200
+
+	// maxTranscriptText bounds the text merged into one entry
+	maxTranscriptText = 8 << 10
+)
+
+// transcriptStream emits a provider's transcript as TaskOutput events,
+// batched so a chatty agent cannot flood the event bus
+type transcriptStream struct {
+	bus  *events.Bus
+	unit string
+	task *int
+
+	mu      sync.Mutex
+	pending []provider.TranscriptEntry
+	dropped int
+
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// newTranscriptStream starts emitting the transcript of unit's task, which
+// may be nil. Close it when the provider returns.
+func newTranscriptStream(bus *events.Bus, unit string, task *int) *transcriptStream {
+	s := &transcriptStream{
+		bus:     bus,
+		unit:    unit,
+		task:    task,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Add queues entry for the next emit. Text arrives in small pieces, so it
+// is merged into the text entry before it.
+func (s *transcriptStream) Add(entry provider.TranscriptEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n := len(s.pending); n > 0 && entry.Kind == provider.TranscriptText {
+		last := &s.pending[n-1]
+		if last.Kind == provider.TranscriptText && len(last.Text)+len(entry.Text) <= maxTranscriptText {
+			last.Text += entry.Text
+			return
+		}
+	}
+	if len(s.pending) >= maxTranscriptPending {
+		s.dropped++
+		return
+	}
+	s.pending = append(s.pending, entry)
+}
+
+// Close emits what is left of the transcript and stops
+func (s *transcriptStream) Close() {
+	close(s.done)
+	<-s.stopped
+}
+
+func (s *transcriptStream) run() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(transcriptInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush()
+		case <-s.done:
+			s.flush()
+			return
+		}
+	}
+}
+
+// flush emits the pending entries, if any
+func (s *transcriptStream) flush() {
+	s.mu.Lock()
+	entries, dropped := s.pending, s.dropped
+	s.pending, s.dropped = nil, 0
+	s.mu.Unlock()
+
+	if len(entries) == 0 && dropped == 0 {
+		return
+	}
+	if entries == nil {
+		entries = []provider.TranscriptEntry{}
+	}
+	evt := events.NewEvent(events.TaskOutput, s.unit).WithPayload(map[string]any{
+		"entries": entries,
+		"dropped": dropped,
+	})
+	if s.task != nil {
+		evt = evt.WithTask(*s.task)
+	}
+	s.bus.Emit(evt)
+}
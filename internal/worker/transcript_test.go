@@ -0,0 +1,138 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/RevCBH/choo/internal/discovery"
+	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transcriptPayloads returns the payloads of the TaskOutput events emitted
+func transcriptPayloads(t *testing.T, collector *events.EventCollector) []map[string]any {
+	t.Helper()
+	var payloads []map[string]any
+	for _, e := range collector.Get() {
+		require.Equal(t, events.TaskOutput, e.Type)
+		payloads = append(payloads, e.Payload.(map[string]any))
+	}
+	return payloads
+}
+
+func TestTranscriptStream_MergesText(t *testing.T) {
+	bus := events.NewBus(10)
+	defer bus.Close()
+	collector := events.NewEventCollector(bus)
+
+	task := 2
+	s := newTranscriptStream(bus, "unit-a", &task)
+	s.Add(provider.TranscriptEntry{Kind: provider.TranscriptText, Text: "Looking "})
+	s.Add(provider.TranscriptEntry{Kind: provider.TranscriptText, Text: "around.\n"})
+	s.Add(provider.TranscriptEntry{Kind: provider.TranscriptToolUse, Tool: "Bash", Input: "ls"})
+	s.Add(provider.TranscriptEntry{Kind: provider.TranscriptText, Text: "Done."})
+	s.Close()
+	bus.Wait()
+
+	evts := collector.Get()
+	require.Len(t, evts, 1)
+	assert.Equal(t, "unit-a", evts[0].Unit)
+	require.NotNil(t, evts[0].Task)
+	assert.Equal(t, 2, *evts[0].Task)
+	assert.Equal(t, []provider.TranscriptEntry{
+		{Kind: provider.TranscriptText, Text: "Looking around.\n"},
+		{Kind: provider.TranscriptToolUse, Tool: "Bash", Input: "ls"},
+		{Kind: provider.TranscriptText, Text: "Done."},
+	}, transcriptPayloads(t, collector)[0]["entries"])
+}
+
+func TestTranscriptStream_RateLimits(t *testing.T) {
+	bus := events.NewBus(10)
+	defer bus.Close()
+	collector := events.NewEventCollector(bus)
+
+	s := newTranscriptStream(bus, "unit-a", nil)
+	for i := 0; i < maxTranscriptPending+50; i++ {
+		s.Add(provider.TranscriptEntry{Kind: provider.TranscriptToolUse, Tool: "Read"})
+	}
+	s.Close()
+	bus.Wait()
+
+	// A burst is emitted in a batch or two, never an event per entry, and
+	// what did not fit is counted
+	payloads := transcriptPayloads(t, collector)
+	require.NotEmpty(t, payloads)
+	assert.LessOrEqual(t, len(payloads), 2)
+	total, dropped := 0, 0
+	for _, p := range payloads {
+		total += len(p["entries"].([]provider.TranscriptEntry))
+		dropped += p["dropped"].(int)
+	}
+	assert.Equal(t, maxTranscriptPending+50, total+dropped)
+	assert.Positive(t, dropped)
+}
+
+func TestTranscriptStream_EmitsNothingWhenQuiet(t *testing.T) {
+	bus := events.NewBus(10)
+	defer bus.Close()
+	collector := events.NewEventCollector(bus)
+
+	s := newTranscriptStream(bus, "unit-a", nil)
+	s.Close()
+	bus.Wait()
+
+	assert.Empty(t, collector.Get())
+}
+
+// costingProvider writes a short transcript ending with its cost
+type costingProvider struct {
+	cost *float64
+}
+
+func (p *costingProvider) Invoke(ctx context.Context, prompt string, workdir string, stdout, stderr io.Writer) error {
+	return nil
+}
+
+func (p *costingProvider) InvokeWithTranscript(ctx context.Context, prompt string, workdir string, stdout, stderr io.Writer, onEntry func(provider.TranscriptEntry)) error {
+	onEntry(provider.TranscriptEntry{Kind: provider.TranscriptText, Text: "Done."})
+	onEntry(provider.TranscriptEntry{Kind: provider.TranscriptResult, CostUSD: p.cost})
+	return nil
+}
+
+func (p *costingProvider) Name() provider.ProviderType {
+	return provider.ProviderClaude
+}
+
+func TestInvokeWithTranscript_ReportsCost(t *testing.T) {
+	bus := events.NewBus(10)
+	defer bus.Close()
+	collector := events.NewEventCollector(bus)
+
+	cost := 0.42
+	w := &Worker{
+		unit:     &discovery.Unit{ID: "unit-a"},
+		events:   bus,
+		provider: &costingProvider{cost: &cost},
+	}
+	got, err := w.invokeWithTranscript(context.Background(), "prompt", io.Discard, io.Discard)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, 0.42, *got)
+	bus.Wait()
+
+	// The result entry is not part of the visible transcript
+	payloads := transcriptPayloads(t, collector)
+	require.Len(t, payloads, 1)
+	assert.Equal(t, []provider.TranscriptEntry{
+		{Kind: provider.TranscriptText, Text: "Done."},
+	}, payloads[0]["entries"])
+
+	// Providers that report no cost leave it unknown
+	w.provider = &costingProvider{}
+	got, err = w.invokeWithTranscript(context.Background(), "prompt", io.Discard, io.Discard)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
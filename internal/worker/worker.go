@@ -16,33 +16,35 @@ import (
 	"github.com/RevCBH/choo/internal/discovery"
 	"github.com/RevCBH/choo/internal/escalate"
 	"github.com/RevCBH/choo/internal/events"
+	"github.com/RevCBH/choo/internal/forge"
 	"github.com/RevCBH/choo/internal/git"
-	"github.com/RevCBH/choo/internal/github"
 	"github.com/RevCBH/choo/internal/provider"
 	"gopkg.in/yaml.v3"
 )
 
 // Worker executes a single unit in an isolated worktree
 type Worker struct {
-	unit         *discovery.Unit
-	config       WorkerConfig
-	events       *events.Bus
-	git          *git.WorktreeManager
+	unit   *discovery.Unit
+	config WorkerConfig
+	events *events.Bus
+	git    *git.WorktreeManager
 
 	// Phase 1: GitOps added alongside gitRunner
 	// Phase 3: gitRunner removed, only gitOps remains
 	gitOps    git.GitOps // Safe git operations interface
 	gitRunner git.Runner // Deprecated: raw runner for unmigrated code
 
-	github       *github.PRClient
-	provider     provider.Provider
-	escalator    escalate.Escalator
-	mergeMu      *sync.Mutex // Shared mutex for serializing merge operations
+	forge      forge.Forge
+	provider   provider.Provider
+	escalator  escalate.Escalator
+	mergeMu    *sync.Mutex // Shared mutex for serializing merge operations
+	mergeQueue *MergeQueue // Optional: batches merges with speculative baseline checks
 
 	// Keep raw path for provider invocation (providers need filesystem path)
 	worktreePath string
 	branch       string
 	currentTask  *discovery.Task
+	rebasedOnto  string // Target commit the branch was last rebased onto
 
 	//nolint:unused // WIP: used when PR merge workflow is integrated
 	prNumber int
@@ -67,9 +69,10 @@ type WorkerConfig struct {
 	BackpressureTimeout time.Duration
 	BaselineTimeout     time.Duration
 	NoPR                bool
-	SuppressOutput      bool            // When true, don't tee Claude output to stdout (TUI mode)
-	ClaudeCommand       string          // Claude CLI command for non-task operations (conflict resolution, etc.)
-	AuditLogger         git.AuditLogger // Optional: log all git operations
+	SuppressOutput      bool             // When true, don't tee Claude output to stdout (TUI mode)
+	ClaudeCommand       string           // Claude CLI command for non-task operations (conflict resolution, etc.)
+	AuditLogger         git.AuditLogger  // Optional: log all git operations
+	MergeQueue          MergeQueueConfig // Optional: batch merges through a merge queue
 }
 
 // BaselineCheck represents a single baseline validation command
@@ -89,10 +92,11 @@ type WorkerDeps struct {
 	GitOps    git.GitOps // Preferred: safe git interface
 	GitRunner git.Runner // Deprecated: raw runner
 
-	GitHub       *github.PRClient
+	Forge        forge.Forge
 	Provider     provider.Provider
 	Escalator    escalate.Escalator
 	MergeMu      *sync.Mutex              // Shared mutex for serializing merge operations
+	MergeQueue   *MergeQueue              // Optional: merge queue shared by the pool
 	Reviewer     provider.Reviewer        // Optional: for code review
 	ReviewConfig *config.CodeReviewConfig // Optional: review settings
 }
@@ -141,10 +145,11 @@ func NewWorker(unit *discovery.Unit, cfg WorkerConfig, deps WorkerDeps) (*Worker
 		git:          deps.Git,
 		gitOps:       gitOps,
 		gitRunner:    gitRunner,
-		github:       deps.GitHub,
+		forge:        deps.Forge,
 		provider:     deps.Provider,
 		escalator:    deps.Escalator,
 		mergeMu:      deps.MergeMu,
+		mergeQueue:   deps.MergeQueue,
 		reviewer:     deps.Reviewer,
 		reviewConfig: deps.ReviewConfig,
 	}, nil
@@ -204,11 +209,17 @@ func (w *Worker) Run(ctx context.Context) error {
 				completedTasks++
 			}
 		}
-		evt := events.NewEvent(events.UnitStarted, w.unit.ID).WithPayload(map[string]any{
+		payload := map[string]any{
 			"total_tasks":     len(w.unit.Tasks),
 			"completed_tasks": completedTasks,
-		})
-		w.events.Emit(evt)
+			"branch":          w.branch,
+			"worktree":        w.worktreePath,
+		}
+		// The unit's work is base_commit..HEAD, which the web UI diffs
+		if base, err := w.runner().Exec(ctx, w.worktreePath, "merge-base", w.config.TargetBranch, "HEAD"); err == nil {
+			payload["base_commit"] = strings.TrimSpace(base)
+		}
+		w.events.Emit(events.NewEvent(events.UnitStarted, w.unit.ID).WithPayload(payload))
 	}
 
 	// Phase 2: Task Loop
@@ -347,6 +358,7 @@ func (w *Worker) runBaselinePhase(ctx context.Context) error {
 	// Run baseline checks
 	passed, output := RunBaselineChecks(ctx, w.config.BaselineChecks, w.worktreePath, w.config.BaselineTimeout)
 	if passed {
+		w.emitBaseline(events.BaselinePassed, 0, "")
 		return nil
 	}
 
@@ -385,39 +397,138 @@ func (w *Worker) runBaselinePhase(ctx context.Context) error {
 		// Re-run baseline checks
 		passed, output = RunBaselineChecks(ctx, w.config.BaselineChecks, w.worktreePath, w.config.BaselineTimeout)
 		if passed {
+			w.emitBaseline(events.BaselinePassed, attempt+1, "")
 			return nil
 		}
 	}
 
 	// Return error if still failing
+	w.emitBaseline(events.BaselineFailed, maxRetries, output)
 	return fmt.Errorf("baseline checks failed after %d retries: %s", maxRetries, output)
 }
 
+// emitBaseline reports the outcome of the baseline phase. Nothing is
+// reported when no baseline checks are configured.
+func (w *Worker) emitBaseline(t events.EventType, fixAttempts int, output string) {
+	if w.events == nil || len(w.config.BaselineChecks) == 0 {
+		return
+	}
+	var checks []string
+	for _, check := range w.config.BaselineChecks {
+		checks = append(checks, check.Name)
+	}
+	payload := map[string]any{
+		"checks":       checks,
+		"fix_attempts": fixAttempts,
+	}
+	if output != "" {
+		payload["output"] = output
+	}
+	w.events.Emit(events.NewEvent(t, w.unit.ID).WithPayload(payload))
+}
+
 // mergeToFeatureBranch performs local merge to the feature branch (replaces PR workflow)
 // This ensures dependent units have access to their predecessors' code
 func (w *Worker) mergeToFeatureBranch(ctx context.Context) error {
-	// 1. Determine if we're working with a remote or local-only target branch
-	// Check if the remote tracking branch exists locally
-	targetRef, useRemote := w.getTargetRef(ctx)
-
-	// 2. Run code review (advisory - doesn't block merge)
+	// 1. Run code review (advisory - doesn't block merge)
 	w.runCodeReview(ctx)
 
-	// 3-6 are serialized via mergeMu to prevent concurrent merge conflicts
+	// 2. Land the unit, either through the merge queue or directly
+	if w.events != nil {
+		w.events.Emit(events.NewEvent(events.UnitMerging, w.unit.ID))
+	}
+	if w.mergeQueue != nil {
+		if err := w.mergeViaQueue(ctx); err != nil {
+			return err
+		}
+	} else if err := w.mergeDirect(ctx); err != nil {
+		return err
+	}
+
+	// 3. Emit UnitMerged event. The unit's own commits are base_commit..head_commit,
+	// which stacked PR mode replays onto the unit's stack branch.
+	if w.events != nil {
+		payload := map[string]any{
+			"branch":        w.branch,
+			"target_branch": w.config.TargetBranch,
+		}
+		if head, err := w.runner().Exec(ctx, w.worktreePath, "rev-parse", "HEAD"); err == nil && w.rebasedOnto != "" {
+			payload["base_commit"] = w.rebasedOnto
+			payload["head_commit"] = strings.TrimSpace(head)
+			if out, err := w.runner().Exec(ctx, w.worktreePath, "diff", "--name-only", w.rebasedOnto, strings.TrimSpace(head)); err == nil && strings.TrimSpace(out) != "" {
+				payload["files"] = strings.Split(strings.TrimSpace(out), "\n")
+			}
+		}
+		w.events.Emit(events.NewEvent(events.UnitMerged, w.unit.ID).WithPayload(payload))
+	}
+
+	fmt.Fprintf(os.Stderr, "Successfully merged unit %s to %s (local)\n", w.unit.ID, w.config.TargetBranch)
+	return nil
+}
+
+// mergeDirect rebases and merges this unit on its own, serialized via mergeMu
+func (w *Worker) mergeDirect(ctx context.Context) error {
 	// Acquire merge lock - only one worker can merge at a time
 	if w.mergeMu != nil {
 		w.mergeMu.Lock()
 		defer w.mergeMu.Unlock()
 	}
 
-	// 3. Fetch latest feature branch if working with remote
+	if err := w.rebaseOntoTarget(ctx); err != nil {
+		return err
+	}
+
+	// Merge unit branch into target branch in the RepoRoot
+	// This updates the local target branch so dependent units see the changes.
+	//
+	// Context assumption: RepoRoot must have TargetBranch checked out. This is satisfied when:
+	// - Feature mode with worktree: RepoRoot is the feature worktree (feature branch checked out)
+	// - Feature mode from repo root: RepoRoot is the main repo (must have feature branch checked out)
+	// - Non-feature mode: RepoRoot is the main repo (main branch checked out)
+	//
+	// The orchestrator is responsible for ensuring RepoRoot is in the correct state before
+	// starting workers. No implicit checkout is performed here.
+	return w.mergeWithCleanup(ctx)
+}
+
+// mergeViaQueue rebases this unit and submits it to the merge queue, which
+// lands it together with other completed units once the combined result
+// passes baseline checks. A unit that conflicts with its batch is rebased
+// onto the updated target and resubmitted once.
+func (w *Worker) mergeViaQueue(ctx context.Context) error {
+	const maxSubmits = 2
+
+	var err error
+	for attempt := 0; attempt < maxSubmits; attempt++ {
+		if err = w.rebaseOntoTarget(ctx); err != nil {
+			return err
+		}
+
+		err = w.mergeQueue.Submit(ctx, MergeRequest{UnitID: w.unit.ID, Branch: w.branch})
+		if err == nil || !errors.Is(err, ErrMergeQueueConflict) {
+			break
+		}
+		fmt.Fprintf(os.Stderr, "Unit %s conflicted with its merge batch, rebasing and resubmitting...\n", w.unit.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("merge queue rejected unit: %w", err)
+	}
+	return nil
+}
+
+// rebaseOntoTarget rebases the unit branch onto the latest target branch,
+// resolving any conflicts with Claude
+func (w *Worker) rebaseOntoTarget(ctx context.Context) error {
+	// Determine if we're working with a remote or local-only target branch
+	targetRef, useRemote := w.getTargetRef(ctx)
+
+	// Fetch latest feature branch if working with remote
 	if useRemote {
 		if _, err := w.runner().Exec(ctx, w.worktreePath, "fetch", "origin", w.config.TargetBranch); err != nil {
 			return fmt.Errorf("failed to fetch target branch: %w", err)
 		}
 	}
 
-	// 4. Rebase unit branch onto feature branch with conflict resolution
 	hasConflicts, err := git.Rebase(ctx, w.worktreePath, targetRef)
 	if err != nil {
 		return fmt.Errorf("rebase failed: %w", err)
@@ -432,41 +543,60 @@ func (w *Worker) mergeToFeatureBranch(ctx context.Context) error {
 		}
 	}
 
-	// 4. Merge unit branch into target branch in the RepoRoot
-	// This updates the local target branch so dependent units see the changes.
-	//
-	// Context assumption: RepoRoot must have TargetBranch checked out. This is satisfied when:
-	// - Feature mode with worktree: RepoRoot is the feature worktree (feature branch checked out)
-	// - Feature mode from repo root: RepoRoot is the main repo (must have feature branch checked out)
-	// - Non-feature mode: RepoRoot is the main repo (main branch checked out)
-	//
-	// The orchestrator is responsible for ensuring RepoRoot is in the correct state before
-	// starting workers. No implicit checkout is performed here.
-	if err := w.mergeWithCleanup(ctx); err != nil {
-		return err
+	if out, err := w.runner().Exec(ctx, w.worktreePath, "rev-parse", targetRef); err == nil {
+		w.rebasedOnto = strings.TrimSpace(out)
 	}
 
-	// 5. Emit UnitMerged event
-	if w.events != nil {
-		evt := events.NewEvent(events.UnitMerged, w.unit.ID).WithPayload(map[string]any{
-			"branch":        w.branch,
-			"target_branch": w.config.TargetBranch,
-		})
-		w.events.Emit(evt)
-	}
-
-	fmt.Fprintf(os.Stderr, "Successfully merged unit %s to %s (local)\n", w.unit.ID, w.config.TargetBranch)
 	return nil
 }
 
-// resolveConflictsWithClaude uses Claude to resolve merge conflicts during rebase
+// resolveConflictsWithClaude uses Claude to resolve merge conflicts during rebase.
+// Each stop of the rebase is resolved and recorded before the rebase continues,
+// so the audit sees every resolution before later commits change it. The
+// recorded resolutions are verified against the affected tasks' backpressure
+// commands and the baseline checks once the rebase completes.
 func (w *Worker) resolveConflictsWithClaude(ctx context.Context) error {
-	// Get list of conflicted files
-	conflictedFiles, err := git.GetConflictedFiles(ctx, w.worktreePath)
-	if err != nil {
-		return fmt.Errorf("failed to get conflicted files: %w", err)
+	audit := w.newConflictAudit("rebase")
+
+	for {
+		conflictedFiles, err := git.GetConflictedFiles(ctx, w.worktreePath)
+		if err != nil {
+			return fmt.Errorf("failed to get conflicted files: %w", err)
+		}
+		if len(conflictedFiles) > 0 {
+			if err := w.resolveConflictStop(ctx, audit, conflictedFiles); err != nil {
+				return err
+			}
+		}
+
+		inRebase, err := git.IsRebaseInProgress(ctx, w.worktreePath)
+		if err != nil {
+			return err
+		}
+		if !inRebase {
+			break
+		}
+
+		// A failed continue that leaves new conflicts is the next stop
+		if _, err := w.runner().Exec(ctx, w.worktreePath, "-c", "core.editor=true", "rebase", "--continue"); err != nil {
+			if next, _ := git.GetConflictedFiles(ctx, w.worktreePath); len(next) == 0 {
+				return fmt.Errorf("failed to continue rebase: %w", err)
+			}
+		}
+	}
+
+	// Verify the resolution didn't break anything before it can be merged
+	if err := w.auditResolution(ctx, w.worktreePath, audit); err != nil {
+		return err
 	}
 
+	fmt.Fprintf(os.Stderr, "Successfully resolved merge conflicts\n")
+	return nil
+}
+
+// resolveConflictStop has Claude resolve and stage the conflicts of the
+// current rebase stop, then records the resolution in audit
+func (w *Worker) resolveConflictStop(ctx context.Context, audit *ConflictResolution, conflictedFiles []string) error {
 	// Emit conflict event for observability
 	if w.events != nil {
 		evt := events.NewEvent(events.PRConflict, w.unit.ID).
@@ -478,6 +608,9 @@ func (w *Worker) resolveConflictsWithClaude(ctx context.Context) error {
 
 	fmt.Fprintf(os.Stderr, "Merge conflicts detected in %d files, invoking Claude to resolve...\n", len(conflictedFiles))
 
+	// Capture both sides before Claude edits anything so the resolution can be audited
+	stop := w.captureConflicts(ctx, w.worktreePath, "REBASE_HEAD", conflictedFiles)
+
 	// Build conflict resolution prompt
 	prompt := BuildConflictPrompt(w.config.TargetBranch, conflictedFiles)
 
@@ -487,18 +620,13 @@ func (w *Worker) resolveConflictsWithClaude(ctx context.Context) error {
 			return err
 		}
 
-		// Verify rebase completed (no longer in rebase state)
-		inRebase, err := git.IsRebaseInProgress(ctx, w.worktreePath)
+		// Verify every conflict was resolved and staged
+		stillConflicted, err := git.GetConflictedFiles(ctx, w.worktreePath)
 		if err != nil {
 			return err
 		}
-		if inRebase {
-			// Claude didn't complete the rebase - check if there are still conflicts
-			stillConflicted, _ := git.GetConflictedFiles(ctx, w.worktreePath)
-			if len(stillConflicted) > 0 {
-				return fmt.Errorf("claude did not resolve all conflicts: %v", stillConflicted)
-			}
-			return fmt.Errorf("claude did not complete rebase (git rebase --continue)")
+		if len(stillConflicted) > 0 {
+			return fmt.Errorf("claude did not resolve all conflicts: %v", stillConflicted)
 		}
 		return nil
 	})
@@ -524,11 +652,13 @@ func (w *Worker) resolveConflictsWithClaude(ctx context.Context) error {
 		return retryResult.LastErr
 	}
 
-	fmt.Fprintf(os.Stderr, "Successfully resolved merge conflicts\n")
+	w.recordResolution(audit, w.worktreePath, stop)
 	return nil
 }
 
-// mergeWithCleanup performs the merge to RepoRoot with conflict resolution and cleanup
+// mergeWithCleanup performs the merge to RepoRoot with conflict resolution and cleanup.
+// Conflicts resolved by Claude are audited and verified like rebase conflicts,
+// and the merge is committed only if the resolution passes.
 func (w *Worker) mergeWithCleanup(ctx context.Context) error {
 	// Try fast-forward merge first
 	_, err := w.runner().Exec(ctx, w.config.RepoRoot, "merge", w.branch, "--ff-only")
@@ -562,6 +692,10 @@ func (w *Worker) mergeWithCleanup(ctx context.Context) error {
 
 	fmt.Fprintf(os.Stderr, "Merge conflicts in RepoRoot (%d files), invoking Claude to resolve...\n", len(conflictedFiles))
 
+	// Capture both sides before Claude edits anything so the resolution can be audited
+	audit := w.newConflictAudit("merge_to_target")
+	stop := w.captureConflicts(ctx, w.config.RepoRoot, "MERGE_HEAD", conflictedFiles)
+
 	// Build merge conflict resolution prompt (different from rebase - we're in RepoRoot now)
 	prompt := BuildMergeConflictPrompt(w.branch, w.config.TargetBranch, conflictedFiles)
 
@@ -572,17 +706,13 @@ func (w *Worker) mergeWithCleanup(ctx context.Context) error {
 			return err
 		}
 
-		// Verify merge completed (no longer in merge state)
-		inMerge, err := git.IsMergeInProgress(ctx, w.config.RepoRoot)
+		// Verify every conflict was resolved and staged
+		stillConflicted, err := git.GetConflictedFiles(ctx, w.config.RepoRoot)
 		if err != nil {
 			return err
 		}
-		if inMerge {
-			stillConflicted, _ := git.GetConflictedFiles(ctx, w.config.RepoRoot)
-			if len(stillConflicted) > 0 {
-				return fmt.Errorf("claude did not resolve all merge conflicts: %v", stillConflicted)
-			}
-			return fmt.Errorf("claude did not complete merge (need to commit)")
+		if len(stillConflicted) > 0 {
+			return fmt.Errorf("claude did not resolve all merge conflicts: %v", stillConflicted)
 		}
 		return nil
 	})
@@ -612,6 +742,20 @@ func (w *Worker) mergeWithCleanup(ctx context.Context) error {
 		return fmt.Errorf("failed to resolve merge conflicts: %w", retryResult.LastErr)
 	}
 
+	// Verify the resolved tree before the merge is committed
+	w.recordResolution(audit, w.config.RepoRoot, stop)
+	if err := w.auditResolution(ctx, w.config.RepoRoot, audit); err != nil {
+		_, _ = w.runner().Exec(ctx, w.config.RepoRoot, "merge", "--abort")
+		return fmt.Errorf("failed to resolve merge conflicts: %w", err)
+	}
+
+	if inMerge, _ := git.IsMergeInProgress(ctx, w.config.RepoRoot); inMerge {
+		if _, err := w.runner().Exec(ctx, w.config.RepoRoot, "commit", "--no-edit"); err != nil {
+			_, _ = w.runner().Exec(ctx, w.config.RepoRoot, "merge", "--abort")
+			return fmt.Errorf("failed to commit resolved merge: %w", err)
+		}
+	}
+
 	fmt.Fprintf(os.Stderr, "Successfully resolved merge conflicts in target branch\n")
 	return nil
 }
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
+// 	protoc-gen-go v1.36.11
 // 	protoc        v5.29.3
 // source: proto/choo/v1/daemon.proto
 
@@ -30,6 +30,7 @@ type StartJobRequest struct {
 	FeatureBranch string                 `protobuf:"bytes,3,opt,name=feature_branch,json=featureBranch,proto3" json:"feature_branch,omitempty"` // Optional: for feature mode, omit for PR mode
 	Parallelism   int32                  `protobuf:"varint,4,opt,name=parallelism,proto3" json:"parallelism,omitempty"`                         // Max concurrent units (0 = default from config)
 	RepoPath      string                 `protobuf:"bytes,5,opt,name=repo_path,json=repoPath,proto3" json:"repo_path,omitempty"`                // Absolute path to git repository
+	Workspace     string                 `protobuf:"bytes,6,opt,name=workspace,proto3" json:"workspace,omitempty"`                              // Registered workspace to run in, instead of repo_path
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -99,6 +100,13 @@ func (x *StartJobRequest) GetRepoPath() string {
 	return ""
 }
 
+func (x *StartJobRequest) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
 type StartJobResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // Unique identifier for the created job
@@ -965,158 +973,1712 @@ func (x *HealthResponse) GetVersion() string {
 	return ""
 }
 
+// RunGC performs a worktree garbage collection pass
+type RunGCRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // If true, report what would be removed without deleting
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunGCRequest) Reset() {
+	*x = RunGCRequest{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunGCRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunGCRequest) ProtoMessage() {}
+
+func (x *RunGCRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunGCRequest.ProtoReflect.Descriptor instead.
+func (*RunGCRequest) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RunGCRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type RunGCResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DryRun           bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	UsageBeforeBytes int64                  `protobuf:"varint,2,opt,name=usage_before_bytes,json=usageBeforeBytes,proto3" json:"usage_before_bytes,omitempty"` // Worktree disk usage before the pass
+	UsageAfterBytes  int64                  `protobuf:"varint,3,opt,name=usage_after_bytes,json=usageAfterBytes,proto3" json:"usage_after_bytes,omitempty"`    // Projected usage for dry runs
+	Removed          []*GCWorktree          `protobuf:"bytes,4,rep,name=removed,proto3" json:"removed,omitempty"`                                              // Worktrees removed (or that would be removed)
+	PrunedBranches   []*GCBranch            `protobuf:"bytes,5,rep,name=pruned_branches,json=prunedBranches,proto3" json:"pruned_branches,omitempty"`          // Unit branches deleted (or that would be deleted)
+	Errors           []string               `protobuf:"bytes,6,rep,name=errors,proto3" json:"errors,omitempty"`                                                // Non-fatal errors encountered during the pass
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RunGCResponse) Reset() {
+	*x = RunGCResponse{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunGCResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunGCResponse) ProtoMessage() {}
+
+func (x *RunGCResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunGCResponse.ProtoReflect.Descriptor instead.
+func (*RunGCResponse) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RunGCResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *RunGCResponse) GetUsageBeforeBytes() int64 {
+	if x != nil {
+		return x.UsageBeforeBytes
+	}
+	return 0
+}
+
+func (x *RunGCResponse) GetUsageAfterBytes() int64 {
+	if x != nil {
+		return x.UsageAfterBytes
+	}
+	return 0
+}
+
+func (x *RunGCResponse) GetRemoved() []*GCWorktree {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *RunGCResponse) GetPrunedBranches() []*GCBranch {
+	if x != nil {
+		return x.PrunedBranches
+	}
+	return nil
+}
+
+func (x *RunGCResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type GCWorktree struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoPath      string                 `protobuf:"bytes,1,opt,name=repo_path,json=repoPath,proto3" json:"repo_path,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Branch        string                 `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	Bytes         int64                  `protobuf:"varint,4,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	LastUsed      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"` // "retention" or "quota"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GCWorktree) Reset() {
+	*x = GCWorktree{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GCWorktree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCWorktree) ProtoMessage() {}
+
+func (x *GCWorktree) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCWorktree.ProtoReflect.Descriptor instead.
+func (*GCWorktree) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GCWorktree) GetRepoPath() string {
+	if x != nil {
+		return x.RepoPath
+	}
+	return ""
+}
+
+func (x *GCWorktree) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GCWorktree) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *GCWorktree) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *GCWorktree) GetLastUsed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsed
+	}
+	return nil
+}
+
+func (x *GCWorktree) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GCBranch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoPath      string                 `protobuf:"bytes,1,opt,name=repo_path,json=repoPath,proto3" json:"repo_path,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GCBranch) Reset() {
+	*x = GCBranch{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GCBranch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCBranch) ProtoMessage() {}
+
+func (x *GCBranch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCBranch.ProtoReflect.Descriptor instead.
+func (*GCBranch) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GCBranch) GetRepoPath() string {
+	if x != nil {
+		return x.RepoPath
+	}
+	return ""
+}
+
+func (x *GCBranch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Workspace is a repository registered with the daemon. The daemon keeps a
+// clone of it and fetches it before each job.
+type Workspace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RepoUrl       string                 `protobuf:"bytes,2,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
+	MirrorPath    string                 `protobuf:"bytes,3,opt,name=mirror_path,json=mirrorPath,proto3" json:"mirror_path,omitempty"`       // Daemon-managed clone; set by the daemon
+	TargetBranch  string                 `protobuf:"bytes,4,opt,name=target_branch,json=targetBranch,proto3" json:"target_branch,omitempty"` // Default base branch for jobs
+	TasksDir      string                 `protobuf:"bytes,5,opt,name=tasks_dir,json=tasksDir,proto3" json:"tasks_dir,omitempty"`             // Default tasks directory, relative to the repository root
+	Parallelism   int32                  `protobuf:"varint,6,opt,name=parallelism,proto3" json:"parallelism,omitempty"`                      // Default max concurrent units (0 = default from config)
+	MaxJobs       int32                  `protobuf:"varint,7,opt,name=max_jobs,json=maxJobs,proto3" json:"max_jobs,omitempty"`               // Max concurrent jobs in this workspace (0 = daemon default)
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FetchedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"` // Zero if never fetched
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workspace) Reset() {
+	*x = Workspace{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workspace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workspace) ProtoMessage() {}
+
+func (x *Workspace) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workspace.ProtoReflect.Descriptor instead.
+func (*Workspace) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Workspace) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Workspace) GetRepoUrl() string {
+	if x != nil {
+		return x.RepoUrl
+	}
+	return ""
+}
+
+func (x *Workspace) GetMirrorPath() string {
+	if x != nil {
+		return x.MirrorPath
+	}
+	return ""
+}
+
+func (x *Workspace) GetTargetBranch() string {
+	if x != nil {
+		return x.TargetBranch
+	}
+	return ""
+}
+
+func (x *Workspace) GetTasksDir() string {
+	if x != nil {
+		return x.TasksDir
+	}
+	return ""
+}
+
+func (x *Workspace) GetParallelism() int32 {
+	if x != nil {
+		return x.Parallelism
+	}
+	return 0
+}
+
+func (x *Workspace) GetMaxJobs() int32 {
+	if x != nil {
+		return x.MaxJobs
+	}
+	return 0
+}
+
+func (x *Workspace) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Workspace) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+// AddWorkspace registers a workspace and clones its repository
+type AddWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspace     *Workspace             `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWorkspaceRequest) Reset() {
+	*x = AddWorkspaceRequest{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWorkspaceRequest) ProtoMessage() {}
+
+func (x *AddWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*AddWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AddWorkspaceRequest) GetWorkspace() *Workspace {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+type AddWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspace     *Workspace             `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWorkspaceResponse) Reset() {
+	*x = AddWorkspaceResponse{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWorkspaceResponse) ProtoMessage() {}
+
+func (x *AddWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*AddWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AddWorkspaceResponse) GetWorkspace() *Workspace {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+type ListWorkspacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesRequest) Reset() {
+	*x = ListWorkspacesRequest{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesRequest) ProtoMessage() {}
+
+func (x *ListWorkspacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{23}
+}
+
+type ListWorkspacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspaces    []*Workspace           `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesResponse) Reset() {
+	*x = ListWorkspacesResponse{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesResponse) ProtoMessage() {}
+
+func (x *ListWorkspacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListWorkspacesResponse) GetWorkspaces() []*Workspace {
+	if x != nil {
+		return x.Workspaces
+	}
+	return nil
+}
+
+// RemoveWorkspace unregisters a workspace
+type RemoveWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DeleteClone   bool                   `protobuf:"varint,2,opt,name=delete_clone,json=deleteClone,proto3" json:"delete_clone,omitempty"` // If true, also delete the daemon's clone
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWorkspaceRequest) Reset() {
+	*x = RemoveWorkspaceRequest{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWorkspaceRequest) ProtoMessage() {}
+
+func (x *RemoveWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RemoveWorkspaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RemoveWorkspaceRequest) GetDeleteClone() bool {
+	if x != nil {
+		return x.DeleteClone
+	}
+	return false
+}
+
+type RemoveWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWorkspaceResponse) Reset() {
+	*x = RemoveWorkspaceResponse{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWorkspaceResponse) ProtoMessage() {}
+
+func (x *RemoveWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*RemoveWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{26}
+}
+
+// QueuedJob is a job waiting in the daemon's queue for a free slot, or one
+// that has left it
+type QueuedJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Job           *StartJobRequest       `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	Priority      int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`       // Higher priorities start first
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`            // "queued", "started", "finished", "cancelled"
+	JobId         string                 `protobuf:"bytes,5,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // Job started from this entry
+	Schedule      string                 `protobuf:"bytes,6,opt,name=schedule,proto3" json:"schedule,omitempty"`        // Schedule that queued it, if any
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`              // Why the job could not start
+	QueuedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=queued_at,json=queuedAt,proto3" json:"queued_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueuedJob) Reset() {
+	*x = QueuedJob{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueuedJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueuedJob) ProtoMessage() {}
+
+func (x *QueuedJob) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueuedJob.ProtoReflect.Descriptor instead.
+func (*QueuedJob) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *QueuedJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *QueuedJob) GetJob() *StartJobRequest {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *QueuedJob) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *QueuedJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *QueuedJob) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *QueuedJob) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *QueuedJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *QueuedJob) GetQueuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.QueuedAt
+	}
+	return nil
+}
+
+func (x *QueuedJob) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *QueuedJob) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+// QueueJob adds a job to the queue. It starts as soon as a slot is free.
+type QueueJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *StartJobRequest       `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Priority      int32                  `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueJobRequest) Reset() {
+	*x = QueueJobRequest{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueJobRequest) ProtoMessage() {}
+
+func (x *QueueJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueJobRequest.ProtoReflect.Descriptor instead.
+func (*QueueJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *QueueJobRequest) GetJob() *StartJobRequest {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *QueueJobRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type QueueJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queued        *QueuedJob             `protobuf:"bytes,1,opt,name=queued,proto3" json:"queued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueJobResponse) Reset() {
+	*x = QueueJobResponse{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueJobResponse) ProtoMessage() {}
+
+func (x *QueueJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueJobResponse.ProtoReflect.Descriptor instead.
+func (*QueueJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *QueueJobResponse) GetQueued() *QueuedJob {
+	if x != nil {
+		return x.Queued
+	}
+	return nil
+}
+
+// ListQueue returns queued and started entries, oldest first within a
+// priority
+type ListQueueRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeFinished bool                   `protobuf:"varint,1,opt,name=include_finished,json=includeFinished,proto3" json:"include_finished,omitempty"` // Also list finished and cancelled entries
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListQueueRequest) Reset() {
+	*x = ListQueueRequest{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQueueRequest) ProtoMessage() {}
+
+func (x *ListQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQueueRequest.ProtoReflect.Descriptor instead.
+func (*ListQueueRequest) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListQueueRequest) GetIncludeFinished() bool {
+	if x != nil {
+		return x.IncludeFinished
+	}
+	return false
+}
+
+type ListQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*QueuedJob           `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQueueResponse) Reset() {
+	*x = ListQueueResponse{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQueueResponse) ProtoMessage() {}
+
+func (x *ListQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQueueResponse.ProtoReflect.Descriptor instead.
+func (*ListQueueResponse) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListQueueResponse) GetJobs() []*QueuedJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// CancelQueuedJob removes a job from the queue before it starts
+type CancelQueuedJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelQueuedJobRequest) Reset() {
+	*x = CancelQueuedJobRequest{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelQueuedJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelQueuedJobRequest) ProtoMessage() {}
+
+func (x *CancelQueuedJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelQueuedJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelQueuedJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CancelQueuedJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelQueuedJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelQueuedJobResponse) Reset() {
+	*x = CancelQueuedJobResponse{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelQueuedJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelQueuedJobResponse) ProtoMessage() {}
+
+func (x *CancelQueuedJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelQueuedJobResponse.ProtoReflect.Descriptor instead.
+func (*CancelQueuedJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{33}
+}
+
+// Schedule queues a job at the times given by a cron expression
+type Schedule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cron          string                 `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"` // "minute hour day-of-month month day-of-week", e.g. "0 1 * * *"
+	Job           *StartJobRequest       `protobuf:"bytes,3,opt,name=job,proto3" json:"job,omitempty"`
+	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	NextRunAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	LastRunAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"` // Zero if never run
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Schedule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Schedule) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *Schedule) GetJob() *StartJobRequest {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *Schedule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Schedule) GetNextRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return nil
+}
+
+func (x *Schedule) GetLastRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return nil
+}
+
+type AddScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddScheduleRequest) Reset() {
+	*x = AddScheduleRequest{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddScheduleRequest) ProtoMessage() {}
+
+func (x *AddScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddScheduleRequest.ProtoReflect.Descriptor instead.
+func (*AddScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AddScheduleRequest) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type AddScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddScheduleResponse) Reset() {
+	*x = AddScheduleResponse{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddScheduleResponse) ProtoMessage() {}
+
+func (x *AddScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddScheduleResponse.ProtoReflect.Descriptor instead.
+func (*AddScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AddScheduleResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type ListSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{37}
+}
+
+type ListSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*Schedule            `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesResponse) Reset() {
+	*x = ListSchedulesResponse{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListSchedulesResponse) GetSchedules() []*Schedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type RemoveScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveScheduleRequest) Reset() {
+	*x = RemoveScheduleRequest{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveScheduleRequest) ProtoMessage() {}
+
+func (x *RemoveScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveScheduleRequest.ProtoReflect.Descriptor instead.
+func (*RemoveScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RemoveScheduleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RemoveScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveScheduleResponse) Reset() {
+	*x = RemoveScheduleResponse{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveScheduleResponse) ProtoMessage() {}
+
+func (x *RemoveScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveScheduleResponse.ProtoReflect.Descriptor instead.
+func (*RemoveScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{40}
+}
+
+// ExportJob streams a job's archive: its run, units, event log, provider
+// logs and diffs as a gzipped tar
+type ExportJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportJobRequest) Reset() {
+	*x = ExportJobRequest{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportJobRequest) ProtoMessage() {}
+
+func (x *ExportJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportJobRequest.ProtoReflect.Descriptor instead.
+func (*ExportJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ExportJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// JobArchiveChunk is a piece of a job archive
+type JobArchiveChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobArchiveChunk) Reset() {
+	*x = JobArchiveChunk{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobArchiveChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobArchiveChunk) ProtoMessage() {}
+
+func (x *JobArchiveChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobArchiveChunk.ProtoReflect.Descriptor instead.
+func (*JobArchiveChunk) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *JobArchiveChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ImportJob loads a job archive streamed by the client
+type ImportJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Units         int32                  `protobuf:"varint,2,opt,name=units,proto3" json:"units,omitempty"`
+	Events        int32                  `protobuf:"varint,3,opt,name=events,proto3" json:"events,omitempty"`
+	FilesDir      string                 `protobuf:"bytes,4,opt,name=files_dir,json=filesDir,proto3" json:"files_dir,omitempty"` // Where the archive's logs and diffs were extracted, if any
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportJobResponse) Reset() {
+	*x = ImportJobResponse{}
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJobResponse) ProtoMessage() {}
+
+func (x *ImportJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_choo_v1_daemon_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJobResponse.ProtoReflect.Descriptor instead.
+func (*ImportJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_choo_v1_daemon_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ImportJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ImportJobResponse) GetUnits() int32 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *ImportJobResponse) GetEvents() int32 {
+	if x != nil {
+		return x.Events
+	}
+	return 0
+}
+
+func (x *ImportJobResponse) GetFilesDir() string {
+	if x != nil {
+		return x.FilesDir
+	}
+	return ""
+}
+
 var File_proto_choo_v1_daemon_proto protoreflect.FileDescriptor
 
-var file_proto_choo_v1_daemon_proto_rawDesc = string([]byte{
-	0x0a, 0x1a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x68, 0x6f, 0x6f, 0x2f, 0x76, 0x31, 0x2f,
-	0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x63, 0x68,
-	0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb9, 0x01, 0x0a, 0x0f, 0x53, 0x74, 0x61, 0x72, 0x74,
-	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61,
-	0x73, 0x6b, 0x73, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74,
-	0x61, 0x73, 0x6b, 0x73, 0x44, 0x69, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x25, 0x0a, 0x0e,
-	0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x72, 0x61,
-	0x6e, 0x63, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69,
-	0x73, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c,
-	0x65, 0x6c, 0x69, 0x73, 0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x70, 0x61,
-	0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x50, 0x61,
-	0x74, 0x68, 0x22, 0x41, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x3d, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x14,
-	0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66,
-	0x6f, 0x72, 0x63, 0x65, 0x22, 0x45, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
-	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2c, 0x0a, 0x13, 0x47,
-	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x22, 0x80, 0x02, 0x0a, 0x14, 0x47, 0x65,
-	0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3d, 0x0a, 0x0c,
-	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b,
-	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x12, 0x29, 0x0a, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x13, 0x2e, 0x63, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x22, 0xa2, 0x01, 0x0a,
-	0x0a, 0x55, 0x6e, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75,
-	0x6e, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x6e,
-	0x69, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e,
-	0x74, 0x61, 0x73, 0x6b, 0x73, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
-	0x65, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x5f, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x54,
-	0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x22, 0x36, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x66,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x3b, 0x0a, 0x10, 0x4c, 0x69, 0x73,
-	0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a,
-	0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x68,
-	0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
-	0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x22, 0xe5, 0x01, 0x0a, 0x0a, 0x4a, 0x6f, 0x62, 0x53, 0x75,
-	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e,
-	0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x72, 0x61,
-	0x6e, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x73,
-	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x5f,
-	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
-	0x75, 0x6e, 0x69, 0x74, 0x73, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1f, 0x0a,
-	0x0b, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x0a, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x4d,
-	0x0a, 0x0f, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d,
-	0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xbb, 0x01,
-	0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
-	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65,
-	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f,
-	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x74, 0x49, 0x64, 0x12, 0x21,
-	0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x4a, 0x73, 0x6f,
-	0x6e, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x5e, 0x0a, 0x0f, 0x53,
-	0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22,
-	0x0a, 0x0d, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x77, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x4a, 0x6f,
-	0x62, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65,
-	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x74, 0x69, 0x6d,
-	0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x4f, 0x0a, 0x10, 0x53,
-	0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6a, 0x6f, 0x62,
-	0x73, 0x5f, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x0b, 0x6a, 0x6f, 0x62, 0x73, 0x53, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0x0f, 0x0a, 0x0d,
-	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x65, 0x0a,
-	0x0e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x74,
-	0x69, 0x76, 0x65, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
-	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x32, 0xd3, 0x03, 0x0a, 0x0d, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4a,
-	0x6f, 0x62, 0x12, 0x18, 0x2e, 0x63, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63,
-	0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x53, 0x74, 0x6f, 0x70, 0x4a,
-	0x6f, 0x62, 0x12, 0x17, 0x2e, 0x63, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f,
-	0x70, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x68,
-	0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x2e, 0x63, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
-	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
-	0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x3f, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x18,
-	0x2e, 0x63, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x68, 0x6f, 0x6f, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x12,
-	0x18, 0x2e, 0x63, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4a,
-	0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x63, 0x68, 0x6f, 0x6f,
-	0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x3f,
-	0x0a, 0x08, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x18, 0x2e, 0x63, 0x68, 0x6f,
-	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x39, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x16, 0x2e, 0x63, 0x68, 0x6f, 0x6f,
-	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x17, 0x2e, 0x63, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c,
-	0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x52, 0x65, 0x76, 0x43, 0x42, 0x48, 0x2f,
-	0x63, 0x68, 0x6f, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b,
-	0x61, 0x70, 0x69, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+const file_proto_choo_v1_daemon_proto_rawDesc = "" +
+	"\n" +
+	"\x1aproto/choo/v1/daemon.proto\x12\achoo.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd7\x01\n" +
+	"\x0fStartJobRequest\x12\x1b\n" +
+	"\ttasks_dir\x18\x01 \x01(\tR\btasksDir\x12#\n" +
+	"\rtarget_branch\x18\x02 \x01(\tR\ftargetBranch\x12%\n" +
+	"\x0efeature_branch\x18\x03 \x01(\tR\rfeatureBranch\x12 \n" +
+	"\vparallelism\x18\x04 \x01(\x05R\vparallelism\x12\x1b\n" +
+	"\trepo_path\x18\x05 \x01(\tR\brepoPath\x12\x1c\n" +
+	"\tworkspace\x18\x06 \x01(\tR\tworkspace\"A\n" +
+	"\x10StartJobResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"=\n" +
+	"\x0eStopJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"E\n" +
+	"\x0fStopJobResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\",\n" +
+	"\x13GetJobStatusRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\x80\x02\n" +
+	"\x14GetJobStatusResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12)\n" +
+	"\x05units\x18\x06 \x03(\v2\x13.choo.v1.UnitStatusR\x05units\"\xa2\x01\n" +
+	"\n" +
+	"UnitStatus\x12\x17\n" +
+	"\aunit_id\x18\x01 \x01(\tR\x06unitId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12%\n" +
+	"\x0etasks_complete\x18\x03 \x01(\x05R\rtasksComplete\x12\x1f\n" +
+	"\vtasks_total\x18\x04 \x01(\x05R\n" +
+	"tasksTotal\x12\x1b\n" +
+	"\tpr_number\x18\x05 \x01(\x05R\bprNumber\"6\n" +
+	"\x0fListJobsRequest\x12#\n" +
+	"\rstatus_filter\x18\x01 \x03(\tR\fstatusFilter\";\n" +
+	"\x10ListJobsResponse\x12'\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x13.choo.v1.JobSummaryR\x04jobs\"\xe5\x01\n" +
+	"\n" +
+	"JobSummary\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12%\n" +
+	"\x0efeature_branch\x18\x02 \x01(\tR\rfeatureBranch\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"started_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12%\n" +
+	"\x0eunits_complete\x18\x05 \x01(\x05R\runitsComplete\x12\x1f\n" +
+	"\vunits_total\x18\x06 \x01(\x05R\n" +
+	"unitsTotal\"M\n" +
+	"\x0fWatchJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12#\n" +
+	"\rfrom_sequence\x18\x02 \x01(\x05R\ffromSequence\"\xbb\x01\n" +
+	"\bJobEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x05R\bsequence\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x17\n" +
+	"\aunit_id\x18\x03 \x01(\tR\x06unitId\x12!\n" +
+	"\fpayload_json\x18\x04 \x01(\tR\vpayloadJson\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"^\n" +
+	"\x0fShutdownRequest\x12\"\n" +
+	"\rwait_for_jobs\x18\x01 \x01(\bR\vwaitForJobs\x12'\n" +
+	"\x0ftimeout_seconds\x18\x02 \x01(\x05R\x0etimeoutSeconds\"O\n" +
+	"\x10ShutdownResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12!\n" +
+	"\fjobs_stopped\x18\x02 \x01(\x05R\vjobsStopped\"\x0f\n" +
+	"\rHealthRequest\"e\n" +
+	"\x0eHealthResponse\x12\x18\n" +
+	"\ahealthy\x18\x01 \x01(\bR\ahealthy\x12\x1f\n" +
+	"\vactive_jobs\x18\x02 \x01(\x05R\n" +
+	"activeJobs\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\"'\n" +
+	"\fRunGCRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"\x85\x02\n" +
+	"\rRunGCResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12,\n" +
+	"\x12usage_before_bytes\x18\x02 \x01(\x03R\x10usageBeforeBytes\x12*\n" +
+	"\x11usage_after_bytes\x18\x03 \x01(\x03R\x0fusageAfterBytes\x12-\n" +
+	"\aremoved\x18\x04 \x03(\v2\x13.choo.v1.GCWorktreeR\aremoved\x12:\n" +
+	"\x0fpruned_branches\x18\x05 \x03(\v2\x11.choo.v1.GCBranchR\x0eprunedBranches\x12\x16\n" +
+	"\x06errors\x18\x06 \x03(\tR\x06errors\"\xbc\x01\n" +
+	"\n" +
+	"GCWorktree\x12\x1b\n" +
+	"\trepo_path\x18\x01 \x01(\tR\brepoPath\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06branch\x18\x03 \x01(\tR\x06branch\x12\x14\n" +
+	"\x05bytes\x18\x04 \x01(\x03R\x05bytes\x127\n" +
+	"\tlast_used\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blastUsed\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\";\n" +
+	"\bGCBranch\x12\x1b\n" +
+	"\trepo_path\x18\x01 \x01(\tR\brepoPath\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xd0\x02\n" +
+	"\tWorkspace\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\brepo_url\x18\x02 \x01(\tR\arepoUrl\x12\x1f\n" +
+	"\vmirror_path\x18\x03 \x01(\tR\n" +
+	"mirrorPath\x12#\n" +
+	"\rtarget_branch\x18\x04 \x01(\tR\ftargetBranch\x12\x1b\n" +
+	"\ttasks_dir\x18\x05 \x01(\tR\btasksDir\x12 \n" +
+	"\vparallelism\x18\x06 \x01(\x05R\vparallelism\x12\x19\n" +
+	"\bmax_jobs\x18\a \x01(\x05R\amaxJobs\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"fetched_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tfetchedAt\"G\n" +
+	"\x13AddWorkspaceRequest\x120\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x12.choo.v1.WorkspaceR\tworkspace\"H\n" +
+	"\x14AddWorkspaceResponse\x120\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x12.choo.v1.WorkspaceR\tworkspace\"\x17\n" +
+	"\x15ListWorkspacesRequest\"L\n" +
+	"\x16ListWorkspacesResponse\x122\n" +
+	"\n" +
+	"workspaces\x18\x01 \x03(\v2\x12.choo.v1.WorkspaceR\n" +
+	"workspaces\"O\n" +
+	"\x16RemoveWorkspaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fdelete_clone\x18\x02 \x01(\bR\vdeleteClone\"\x19\n" +
+	"\x17RemoveWorkspaceResponse\"\xf5\x02\n" +
+	"\tQueuedJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x03job\x18\x02 \x01(\v2\x18.choo.v1.StartJobRequestR\x03job\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x15\n" +
+	"\x06job_id\x18\x05 \x01(\tR\x05jobId\x12\x1a\n" +
+	"\bschedule\x18\x06 \x01(\tR\bschedule\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x127\n" +
+	"\tqueued_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bqueuedAt\x129\n" +
+	"\n" +
+	"started_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"Y\n" +
+	"\x0fQueueJobRequest\x12*\n" +
+	"\x03job\x18\x01 \x01(\v2\x18.choo.v1.StartJobRequestR\x03job\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\x05R\bpriority\">\n" +
+	"\x10QueueJobResponse\x12*\n" +
+	"\x06queued\x18\x01 \x01(\v2\x12.choo.v1.QueuedJobR\x06queued\"=\n" +
+	"\x10ListQueueRequest\x12)\n" +
+	"\x10include_finished\x18\x01 \x01(\bR\x0fincludeFinished\";\n" +
+	"\x11ListQueueResponse\x12&\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x12.choo.v1.QueuedJobR\x04jobs\"(\n" +
+	"\x16CancelQueuedJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x19\n" +
+	"\x17CancelQueuedJobResponse\"\xf2\x01\n" +
+	"\bSchedule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04cron\x18\x02 \x01(\tR\x04cron\x12*\n" +
+	"\x03job\x18\x03 \x01(\v2\x18.choo.v1.StartJobRequestR\x03job\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12:\n" +
+	"\vnext_run_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tnextRunAt\x12:\n" +
+	"\vlast_run_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tlastRunAt\"C\n" +
+	"\x12AddScheduleRequest\x12-\n" +
+	"\bschedule\x18\x01 \x01(\v2\x11.choo.v1.ScheduleR\bschedule\"D\n" +
+	"\x13AddScheduleResponse\x12-\n" +
+	"\bschedule\x18\x01 \x01(\v2\x11.choo.v1.ScheduleR\bschedule\"\x16\n" +
+	"\x14ListSchedulesRequest\"H\n" +
+	"\x15ListSchedulesResponse\x12/\n" +
+	"\tschedules\x18\x01 \x03(\v2\x11.choo.v1.ScheduleR\tschedules\"+\n" +
+	"\x15RemoveScheduleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x18\n" +
+	"\x16RemoveScheduleResponse\")\n" +
+	"\x10ExportJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"%\n" +
+	"\x0fJobArchiveChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"u\n" +
+	"\x11ImportJobResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x14\n" +
+	"\x05units\x18\x02 \x01(\x05R\x05units\x12\x16\n" +
+	"\x06events\x18\x03 \x01(\x05R\x06events\x12\x1b\n" +
+	"\tfiles_dir\x18\x04 \x01(\tR\bfilesDir2\xd2\n" +
+	"\n" +
+	"\rDaemonService\x12?\n" +
+	"\bStartJob\x12\x18.choo.v1.StartJobRequest\x1a\x19.choo.v1.StartJobResponse\x12<\n" +
+	"\aStopJob\x12\x17.choo.v1.StopJobRequest\x1a\x18.choo.v1.StopJobResponse\x12K\n" +
+	"\fGetJobStatus\x12\x1c.choo.v1.GetJobStatusRequest\x1a\x1d.choo.v1.GetJobStatusResponse\x12?\n" +
+	"\bListJobs\x12\x18.choo.v1.ListJobsRequest\x1a\x19.choo.v1.ListJobsResponse\x129\n" +
+	"\bWatchJob\x12\x18.choo.v1.WatchJobRequest\x1a\x11.choo.v1.JobEvent0\x01\x12?\n" +
+	"\bShutdown\x12\x18.choo.v1.ShutdownRequest\x1a\x19.choo.v1.ShutdownResponse\x129\n" +
+	"\x06Health\x12\x16.choo.v1.HealthRequest\x1a\x17.choo.v1.HealthResponse\x126\n" +
+	"\x05RunGC\x12\x15.choo.v1.RunGCRequest\x1a\x16.choo.v1.RunGCResponse\x12K\n" +
+	"\fAddWorkspace\x12\x1c.choo.v1.AddWorkspaceRequest\x1a\x1d.choo.v1.AddWorkspaceResponse\x12Q\n" +
+	"\x0eListWorkspaces\x12\x1e.choo.v1.ListWorkspacesRequest\x1a\x1f.choo.v1.ListWorkspacesResponse\x12T\n" +
+	"\x0fRemoveWorkspace\x12\x1f.choo.v1.RemoveWorkspaceRequest\x1a .choo.v1.RemoveWorkspaceResponse\x12?\n" +
+	"\bQueueJob\x12\x18.choo.v1.QueueJobRequest\x1a\x19.choo.v1.QueueJobResponse\x12B\n" +
+	"\tListQueue\x12\x19.choo.v1.ListQueueRequest\x1a\x1a.choo.v1.ListQueueResponse\x12T\n" +
+	"\x0fCancelQueuedJob\x12\x1f.choo.v1.CancelQueuedJobRequest\x1a .choo.v1.CancelQueuedJobResponse\x12H\n" +
+	"\vAddSchedule\x12\x1b.choo.v1.AddScheduleRequest\x1a\x1c.choo.v1.AddScheduleResponse\x12N\n" +
+	"\rListSchedules\x12\x1d.choo.v1.ListSchedulesRequest\x1a\x1e.choo.v1.ListSchedulesResponse\x12Q\n" +
+	"\x0eRemoveSchedule\x12\x1e.choo.v1.RemoveScheduleRequest\x1a\x1f.choo.v1.RemoveScheduleResponse\x12B\n" +
+	"\tExportJob\x12\x19.choo.v1.ExportJobRequest\x1a\x18.choo.v1.JobArchiveChunk0\x01\x12C\n" +
+	"\tImportJob\x12\x18.choo.v1.JobArchiveChunk\x1a\x1a.choo.v1.ImportJobResponse(\x01B)Z'github.com/RevCBH/choo/pkg/api/v1;apiv1b\x06proto3"
 
 var (
 	file_proto_choo_v1_daemon_proto_rawDescOnce sync.Once
@@ -1130,52 +2692,125 @@ func file_proto_choo_v1_daemon_proto_rawDescGZIP() []byte {
 	return file_proto_choo_v1_daemon_proto_rawDescData
 }
 
-var file_proto_choo_v1_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_proto_choo_v1_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_proto_choo_v1_daemon_proto_goTypes = []any{
-	(*StartJobRequest)(nil),       // 0: choo.v1.StartJobRequest
-	(*StartJobResponse)(nil),      // 1: choo.v1.StartJobResponse
-	(*StopJobRequest)(nil),        // 2: choo.v1.StopJobRequest
-	(*StopJobResponse)(nil),       // 3: choo.v1.StopJobResponse
-	(*GetJobStatusRequest)(nil),   // 4: choo.v1.GetJobStatusRequest
-	(*GetJobStatusResponse)(nil),  // 5: choo.v1.GetJobStatusResponse
-	(*UnitStatus)(nil),            // 6: choo.v1.UnitStatus
-	(*ListJobsRequest)(nil),       // 7: choo.v1.ListJobsRequest
-	(*ListJobsResponse)(nil),      // 8: choo.v1.ListJobsResponse
-	(*JobSummary)(nil),            // 9: choo.v1.JobSummary
-	(*WatchJobRequest)(nil),       // 10: choo.v1.WatchJobRequest
-	(*JobEvent)(nil),              // 11: choo.v1.JobEvent
-	(*ShutdownRequest)(nil),       // 12: choo.v1.ShutdownRequest
-	(*ShutdownResponse)(nil),      // 13: choo.v1.ShutdownResponse
-	(*HealthRequest)(nil),         // 14: choo.v1.HealthRequest
-	(*HealthResponse)(nil),        // 15: choo.v1.HealthResponse
-	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(*StartJobRequest)(nil),         // 0: choo.v1.StartJobRequest
+	(*StartJobResponse)(nil),        // 1: choo.v1.StartJobResponse
+	(*StopJobRequest)(nil),          // 2: choo.v1.StopJobRequest
+	(*StopJobResponse)(nil),         // 3: choo.v1.StopJobResponse
+	(*GetJobStatusRequest)(nil),     // 4: choo.v1.GetJobStatusRequest
+	(*GetJobStatusResponse)(nil),    // 5: choo.v1.GetJobStatusResponse
+	(*UnitStatus)(nil),              // 6: choo.v1.UnitStatus
+	(*ListJobsRequest)(nil),         // 7: choo.v1.ListJobsRequest
+	(*ListJobsResponse)(nil),        // 8: choo.v1.ListJobsResponse
+	(*JobSummary)(nil),              // 9: choo.v1.JobSummary
+	(*WatchJobRequest)(nil),         // 10: choo.v1.WatchJobRequest
+	(*JobEvent)(nil),                // 11: choo.v1.JobEvent
+	(*ShutdownRequest)(nil),         // 12: choo.v1.ShutdownRequest
+	(*ShutdownResponse)(nil),        // 13: choo.v1.ShutdownResponse
+	(*HealthRequest)(nil),           // 14: choo.v1.HealthRequest
+	(*HealthResponse)(nil),          // 15: choo.v1.HealthResponse
+	(*RunGCRequest)(nil),            // 16: choo.v1.RunGCRequest
+	(*RunGCResponse)(nil),           // 17: choo.v1.RunGCResponse
+	(*GCWorktree)(nil),              // 18: choo.v1.GCWorktree
+	(*GCBranch)(nil),                // 19: choo.v1.GCBranch
+	(*Workspace)(nil),               // 20: choo.v1.Workspace
+	(*AddWorkspaceRequest)(nil),     // 21: choo.v1.AddWorkspaceRequest
+	(*AddWorkspaceResponse)(nil),    // 22: choo.v1.AddWorkspaceResponse
+	(*ListWorkspacesRequest)(nil),   // 23: choo.v1.ListWorkspacesRequest
+	(*ListWorkspacesResponse)(nil),  // 24: choo.v1.ListWorkspacesResponse
+	(*RemoveWorkspaceRequest)(nil),  // 25: choo.v1.RemoveWorkspaceRequest
+	(*RemoveWorkspaceResponse)(nil), // 26: choo.v1.RemoveWorkspaceResponse
+	(*QueuedJob)(nil),               // 27: choo.v1.QueuedJob
+	(*QueueJobRequest)(nil),         // 28: choo.v1.QueueJobRequest
+	(*QueueJobResponse)(nil),        // 29: choo.v1.QueueJobResponse
+	(*ListQueueRequest)(nil),        // 30: choo.v1.ListQueueRequest
+	(*ListQueueResponse)(nil),       // 31: choo.v1.ListQueueResponse
+	(*CancelQueuedJobRequest)(nil),  // 32: choo.v1.CancelQueuedJobRequest
+	(*CancelQueuedJobResponse)(nil), // 33: choo.v1.CancelQueuedJobResponse
+	(*Schedule)(nil),                // 34: choo.v1.Schedule
+	(*AddScheduleRequest)(nil),      // 35: choo.v1.AddScheduleRequest
+	(*AddScheduleResponse)(nil),     // 36: choo.v1.AddScheduleResponse
+	(*ListSchedulesRequest)(nil),    // 37: choo.v1.ListSchedulesRequest
+	(*ListSchedulesResponse)(nil),   // 38: choo.v1.ListSchedulesResponse
+	(*RemoveScheduleRequest)(nil),   // 39: choo.v1.RemoveScheduleRequest
+	(*RemoveScheduleResponse)(nil),  // 40: choo.v1.RemoveScheduleResponse
+	(*ExportJobRequest)(nil),        // 41: choo.v1.ExportJobRequest
+	(*JobArchiveChunk)(nil),         // 42: choo.v1.JobArchiveChunk
+	(*ImportJobResponse)(nil),       // 43: choo.v1.ImportJobResponse
+	(*timestamppb.Timestamp)(nil),   // 44: google.protobuf.Timestamp
 }
 var file_proto_choo_v1_daemon_proto_depIdxs = []int32{
-	16, // 0: choo.v1.GetJobStatusResponse.started_at:type_name -> google.protobuf.Timestamp
-	16, // 1: choo.v1.GetJobStatusResponse.completed_at:type_name -> google.protobuf.Timestamp
+	44, // 0: choo.v1.GetJobStatusResponse.started_at:type_name -> google.protobuf.Timestamp
+	44, // 1: choo.v1.GetJobStatusResponse.completed_at:type_name -> google.protobuf.Timestamp
 	6,  // 2: choo.v1.GetJobStatusResponse.units:type_name -> choo.v1.UnitStatus
 	9,  // 3: choo.v1.ListJobsResponse.jobs:type_name -> choo.v1.JobSummary
-	16, // 4: choo.v1.JobSummary.started_at:type_name -> google.protobuf.Timestamp
-	16, // 5: choo.v1.JobEvent.timestamp:type_name -> google.protobuf.Timestamp
-	0,  // 6: choo.v1.DaemonService.StartJob:input_type -> choo.v1.StartJobRequest
-	2,  // 7: choo.v1.DaemonService.StopJob:input_type -> choo.v1.StopJobRequest
-	4,  // 8: choo.v1.DaemonService.GetJobStatus:input_type -> choo.v1.GetJobStatusRequest
-	7,  // 9: choo.v1.DaemonService.ListJobs:input_type -> choo.v1.ListJobsRequest
-	10, // 10: choo.v1.DaemonService.WatchJob:input_type -> choo.v1.WatchJobRequest
-	12, // 11: choo.v1.DaemonService.Shutdown:input_type -> choo.v1.ShutdownRequest
-	14, // 12: choo.v1.DaemonService.Health:input_type -> choo.v1.HealthRequest
-	1,  // 13: choo.v1.DaemonService.StartJob:output_type -> choo.v1.StartJobResponse
-	3,  // 14: choo.v1.DaemonService.StopJob:output_type -> choo.v1.StopJobResponse
-	5,  // 15: choo.v1.DaemonService.GetJobStatus:output_type -> choo.v1.GetJobStatusResponse
-	8,  // 16: choo.v1.DaemonService.ListJobs:output_type -> choo.v1.ListJobsResponse
-	11, // 17: choo.v1.DaemonService.WatchJob:output_type -> choo.v1.JobEvent
-	13, // 18: choo.v1.DaemonService.Shutdown:output_type -> choo.v1.ShutdownResponse
-	15, // 19: choo.v1.DaemonService.Health:output_type -> choo.v1.HealthResponse
-	13, // [13:20] is the sub-list for method output_type
-	6,  // [6:13] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	44, // 4: choo.v1.JobSummary.started_at:type_name -> google.protobuf.Timestamp
+	44, // 5: choo.v1.JobEvent.timestamp:type_name -> google.protobuf.Timestamp
+	18, // 6: choo.v1.RunGCResponse.removed:type_name -> choo.v1.GCWorktree
+	19, // 7: choo.v1.RunGCResponse.pruned_branches:type_name -> choo.v1.GCBranch
+	44, // 8: choo.v1.GCWorktree.last_used:type_name -> google.protobuf.Timestamp
+	44, // 9: choo.v1.Workspace.created_at:type_name -> google.protobuf.Timestamp
+	44, // 10: choo.v1.Workspace.fetched_at:type_name -> google.protobuf.Timestamp
+	20, // 11: choo.v1.AddWorkspaceRequest.workspace:type_name -> choo.v1.Workspace
+	20, // 12: choo.v1.AddWorkspaceResponse.workspace:type_name -> choo.v1.Workspace
+	20, // 13: choo.v1.ListWorkspacesResponse.workspaces:type_name -> choo.v1.Workspace
+	0,  // 14: choo.v1.QueuedJob.job:type_name -> choo.v1.StartJobRequest
+	44, // 15: choo.v1.QueuedJob.queued_at:type_name -> google.protobuf.Timestamp
+	44, // 16: choo.v1.QueuedJob.started_at:type_name -> google.protobuf.Timestamp
+	44, // 17: choo.v1.QueuedJob.finished_at:type_name -> google.protobuf.Timestamp
+	0,  // 18: choo.v1.QueueJobRequest.job:type_name -> choo.v1.StartJobRequest
+	27, // 19: choo.v1.QueueJobResponse.queued:type_name -> choo.v1.QueuedJob
+	27, // 20: choo.v1.ListQueueResponse.jobs:type_name -> choo.v1.QueuedJob
+	0,  // 21: choo.v1.Schedule.job:type_name -> choo.v1.StartJobRequest
+	44, // 22: choo.v1.Schedule.next_run_at:type_name -> google.protobuf.Timestamp
+	44, // 23: choo.v1.Schedule.last_run_at:type_name -> google.protobuf.Timestamp
+	34, // 24: choo.v1.AddScheduleRequest.schedule:type_name -> choo.v1.Schedule
+	34, // 25: choo.v1.AddScheduleResponse.schedule:type_name -> choo.v1.Schedule
+	34, // 26: choo.v1.ListSchedulesResponse.schedules:type_name -> choo.v1.Schedule
+	0,  // 27: choo.v1.DaemonService.StartJob:input_type -> choo.v1.StartJobRequest
+	2,  // 28: choo.v1.DaemonService.StopJob:input_type -> choo.v1.StopJobRequest
+	4,  // 29: choo.v1.DaemonService.GetJobStatus:input_type -> choo.v1.GetJobStatusRequest
+	7,  // 30: choo.v1.DaemonService.ListJobs:input_type -> choo.v1.ListJobsRequest
+	10, // 31: choo.v1.DaemonService.WatchJob:input_type -> choo.v1.WatchJobRequest
+	12, // 32: choo.v1.DaemonService.Shutdown:input_type -> choo.v1.ShutdownRequest
+	14, // 33: choo.v1.DaemonService.Health:input_type -> choo.v1.HealthRequest
+	16, // 34: choo.v1.DaemonService.RunGC:input_type -> choo.v1.RunGCRequest
+	21, // 35: choo.v1.DaemonService.AddWorkspace:input_type -> choo.v1.AddWorkspaceRequest
+	23, // 36: choo.v1.DaemonService.ListWorkspaces:input_type -> choo.v1.ListWorkspacesRequest
+	25, // 37: choo.v1.DaemonService.RemoveWorkspace:input_type -> choo.v1.RemoveWorkspaceRequest
+	28, // 38: choo.v1.DaemonService.QueueJob:input_type -> choo.v1.QueueJobRequest
+	30, // 39: choo.v1.DaemonService.ListQueue:input_type -> choo.v1.ListQueueRequest
+	32, // 40: choo.v1.DaemonService.CancelQueuedJob:input_type -> choo.v1.CancelQueuedJobRequest
+	35, // 41: choo.v1.DaemonService.AddSchedule:input_type -> choo.v1.AddScheduleRequest
+	37, // 42: choo.v1.DaemonService.ListSchedules:input_type -> choo.v1.ListSchedulesRequest
+	39, // 43: choo.v1.DaemonService.RemoveSchedule:input_type -> choo.v1.RemoveScheduleRequest
+	41, // 44: choo.v1.DaemonService.ExportJob:input_type -> choo.v1.ExportJobRequest
+	42, // 45: choo.v1.DaemonService.ImportJob:input_type -> choo.v1.JobArchiveChunk
+	1,  // 46: choo.v1.DaemonService.StartJob:output_type -> choo.v1.StartJobResponse
+	3,  // 47: choo.v1.DaemonService.StopJob:output_type -> choo.v1.StopJobResponse
+	5,  // 48: choo.v1.DaemonService.GetJobStatus:output_type -> choo.v1.GetJobStatusResponse
+	8,  // 49: choo.v1.DaemonService.ListJobs:output_type -> choo.v1.ListJobsResponse
+	11, // 50: choo.v1.DaemonService.WatchJob:output_type -> choo.v1.JobEvent
+	13, // 51: choo.v1.DaemonService.Shutdown:output_type -> choo.v1.ShutdownResponse
+	15, // 52: choo.v1.DaemonService.Health:output_type -> choo.v1.HealthResponse
+	17, // 53: choo.v1.DaemonService.RunGC:output_type -> choo.v1.RunGCResponse
+	22, // 54: choo.v1.DaemonService.AddWorkspace:output_type -> choo.v1.AddWorkspaceResponse
+	24, // 55: choo.v1.DaemonService.ListWorkspaces:output_type -> choo.v1.ListWorkspacesResponse
+	26, // 56: choo.v1.DaemonService.RemoveWorkspace:output_type -> choo.v1.RemoveWorkspaceResponse
+	29, // 57: choo.v1.DaemonService.QueueJob:output_type -> choo.v1.QueueJobResponse
+	31, // 58: choo.v1.DaemonService.ListQueue:output_type -> choo.v1.ListQueueResponse
+	33, // 59: choo.v1.DaemonService.CancelQueuedJob:output_type -> choo.v1.CancelQueuedJobResponse
+	36, // 60: choo.v1.DaemonService.AddSchedule:output_type -> choo.v1.AddScheduleResponse
+	38, // 61: choo.v1.DaemonService.ListSchedules:output_type -> choo.v1.ListSchedulesResponse
+	40, // 62: choo.v1.DaemonService.RemoveSchedule:output_type -> choo.v1.RemoveScheduleResponse
+	42, // 63: choo.v1.DaemonService.ExportJob:output_type -> choo.v1.JobArchiveChunk
+	43, // 64: choo.v1.DaemonService.ImportJob:output_type -> choo.v1.ImportJobResponse
+	46, // [46:65] is the sub-list for method output_type
+	27, // [27:46] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_proto_choo_v1_daemon_proto_init() }
@@ -1189,7 +2824,7 @@ func file_proto_choo_v1_daemon_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_choo_v1_daemon_proto_rawDesc), len(file_proto_choo_v1_daemon_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,13 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DaemonService_StartJob_FullMethodName     = "/choo.v1.DaemonService/StartJob"
-	DaemonService_StopJob_FullMethodName      = "/choo.v1.DaemonService/StopJob"
-	DaemonService_GetJobStatus_FullMethodName = "/choo.v1.DaemonService/GetJobStatus"
-	DaemonService_ListJobs_FullMethodName     = "/choo.v1.DaemonService/ListJobs"
-	DaemonService_WatchJob_FullMethodName     = "/choo.v1.DaemonService/WatchJob"
-	DaemonService_Shutdown_FullMethodName     = "/choo.v1.DaemonService/Shutdown"
-	DaemonService_Health_FullMethodName       = "/choo.v1.DaemonService/Health"
+	DaemonService_StartJob_FullMethodName        = "/choo.v1.DaemonService/StartJob"
+	DaemonService_StopJob_FullMethodName         = "/choo.v1.DaemonService/StopJob"
+	DaemonService_GetJobStatus_FullMethodName    = "/choo.v1.DaemonService/GetJobStatus"
+	DaemonService_ListJobs_FullMethodName        = "/choo.v1.DaemonService/ListJobs"
+	DaemonService_WatchJob_FullMethodName        = "/choo.v1.DaemonService/WatchJob"
+	DaemonService_Shutdown_FullMethodName        = "/choo.v1.DaemonService/Shutdown"
+	DaemonService_Health_FullMethodName          = "/choo.v1.DaemonService/Health"
+	DaemonService_RunGC_FullMethodName           = "/choo.v1.DaemonService/RunGC"
+	DaemonService_AddWorkspace_FullMethodName    = "/choo.v1.DaemonService/AddWorkspace"
+	DaemonService_ListWorkspaces_FullMethodName  = "/choo.v1.DaemonService/ListWorkspaces"
+	DaemonService_RemoveWorkspace_FullMethodName = "/choo.v1.DaemonService/RemoveWorkspace"
+	DaemonService_QueueJob_FullMethodName        = "/choo.v1.DaemonService/QueueJob"
+	DaemonService_ListQueue_FullMethodName       = "/choo.v1.DaemonService/ListQueue"
+	DaemonService_CancelQueuedJob_FullMethodName = "/choo.v1.DaemonService/CancelQueuedJob"
+	DaemonService_AddSchedule_FullMethodName     = "/choo.v1.DaemonService/AddSchedule"
+	DaemonService_ListSchedules_FullMethodName   = "/choo.v1.DaemonService/ListSchedules"
+	DaemonService_RemoveSchedule_FullMethodName  = "/choo.v1.DaemonService/RemoveSchedule"
+	DaemonService_ExportJob_FullMethodName       = "/choo.v1.DaemonService/ExportJob"
+	DaemonService_ImportJob_FullMethodName       = "/choo.v1.DaemonService/ImportJob"
 )
 
 // DaemonServiceClient is the client API for DaemonService service.
@@ -44,6 +56,22 @@ type DaemonServiceClient interface {
 	// Daemon lifecycle
 	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
 	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	// Maintenance
+	RunGC(ctx context.Context, in *RunGCRequest, opts ...grpc.CallOption) (*RunGCResponse, error)
+	// Workspaces
+	AddWorkspace(ctx context.Context, in *AddWorkspaceRequest, opts ...grpc.CallOption) (*AddWorkspaceResponse, error)
+	ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (*ListWorkspacesResponse, error)
+	RemoveWorkspace(ctx context.Context, in *RemoveWorkspaceRequest, opts ...grpc.CallOption) (*RemoveWorkspaceResponse, error)
+	// Queueing and scheduling
+	QueueJob(ctx context.Context, in *QueueJobRequest, opts ...grpc.CallOption) (*QueueJobResponse, error)
+	ListQueue(ctx context.Context, in *ListQueueRequest, opts ...grpc.CallOption) (*ListQueueResponse, error)
+	CancelQueuedJob(ctx context.Context, in *CancelQueuedJobRequest, opts ...grpc.CallOption) (*CancelQueuedJobResponse, error)
+	AddSchedule(ctx context.Context, in *AddScheduleRequest, opts ...grpc.CallOption) (*AddScheduleResponse, error)
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	RemoveSchedule(ctx context.Context, in *RemoveScheduleRequest, opts ...grpc.CallOption) (*RemoveScheduleResponse, error)
+	// Archives
+	ExportJob(ctx context.Context, in *ExportJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobArchiveChunk], error)
+	ImportJob(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[JobArchiveChunk, ImportJobResponse], error)
 }
 
 type daemonServiceClient struct {
@@ -133,6 +161,138 @@ func (c *daemonServiceClient) Health(ctx context.Context, in *HealthRequest, opt
 	return out, nil
 }
 
+func (c *daemonServiceClient) RunGC(ctx context.Context, in *RunGCRequest, opts ...grpc.CallOption) (*RunGCResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunGCResponse)
+	err := c.cc.Invoke(ctx, DaemonService_RunGC_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) AddWorkspace(ctx context.Context, in *AddWorkspaceRequest, opts ...grpc.CallOption) (*AddWorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddWorkspaceResponse)
+	err := c.cc.Invoke(ctx, DaemonService_AddWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (*ListWorkspacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkspacesResponse)
+	err := c.cc.Invoke(ctx, DaemonService_ListWorkspaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) RemoveWorkspace(ctx context.Context, in *RemoveWorkspaceRequest, opts ...grpc.CallOption) (*RemoveWorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveWorkspaceResponse)
+	err := c.cc.Invoke(ctx, DaemonService_RemoveWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) QueueJob(ctx context.Context, in *QueueJobRequest, opts ...grpc.CallOption) (*QueueJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueueJobResponse)
+	err := c.cc.Invoke(ctx, DaemonService_QueueJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) ListQueue(ctx context.Context, in *ListQueueRequest, opts ...grpc.CallOption) (*ListQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQueueResponse)
+	err := c.cc.Invoke(ctx, DaemonService_ListQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) CancelQueuedJob(ctx context.Context, in *CancelQueuedJobRequest, opts ...grpc.CallOption) (*CancelQueuedJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelQueuedJobResponse)
+	err := c.cc.Invoke(ctx, DaemonService_CancelQueuedJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) AddSchedule(ctx context.Context, in *AddScheduleRequest, opts ...grpc.CallOption) (*AddScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddScheduleResponse)
+	err := c.cc.Invoke(ctx, DaemonService_AddSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, DaemonService_ListSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) RemoveSchedule(ctx context.Context, in *RemoveScheduleRequest, opts ...grpc.CallOption) (*RemoveScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveScheduleResponse)
+	err := c.cc.Invoke(ctx, DaemonService_RemoveSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) ExportJob(ctx context.Context, in *ExportJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobArchiveChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[1], DaemonService_ExportJob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportJobRequest, JobArchiveChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_ExportJobClient = grpc.ServerStreamingClient[JobArchiveChunk]
+
+func (c *daemonServiceClient) ImportJob(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[JobArchiveChunk, ImportJobResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[2], DaemonService_ImportJob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[JobArchiveChunk, ImportJobResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_ImportJobClient = grpc.ClientStreamingClient[JobArchiveChunk, ImportJobResponse]
+
 // DaemonServiceServer is the server API for DaemonService service.
 // All implementations must embed UnimplementedDaemonServiceServer
 // for forward compatibility.
@@ -149,6 +309,22 @@ type DaemonServiceServer interface {
 	// Daemon lifecycle
 	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
 	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	// Maintenance
+	RunGC(context.Context, *RunGCRequest) (*RunGCResponse, error)
+	// Workspaces
+	AddWorkspace(context.Context, *AddWorkspaceRequest) (*AddWorkspaceResponse, error)
+	ListWorkspaces(context.Context, *ListWorkspacesRequest) (*ListWorkspacesResponse, error)
+	RemoveWorkspace(context.Context, *RemoveWorkspaceRequest) (*RemoveWorkspaceResponse, error)
+	// Queueing and scheduling
+	QueueJob(context.Context, *QueueJobRequest) (*QueueJobResponse, error)
+	ListQueue(context.Context, *ListQueueRequest) (*ListQueueResponse, error)
+	CancelQueuedJob(context.Context, *CancelQueuedJobRequest) (*CancelQueuedJobResponse, error)
+	AddSchedule(context.Context, *AddScheduleRequest) (*AddScheduleResponse, error)
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	RemoveSchedule(context.Context, *RemoveScheduleRequest) (*RemoveScheduleResponse, error)
+	// Archives
+	ExportJob(*ExportJobRequest, grpc.ServerStreamingServer[JobArchiveChunk]) error
+	ImportJob(grpc.ClientStreamingServer[JobArchiveChunk, ImportJobResponse]) error
 	mustEmbedUnimplementedDaemonServiceServer()
 }
 
@@ -180,6 +356,42 @@ func (UnimplementedDaemonServiceServer) Shutdown(context.Context, *ShutdownReque
 func (UnimplementedDaemonServiceServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
 }
+func (UnimplementedDaemonServiceServer) RunGC(context.Context, *RunGCRequest) (*RunGCResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunGC not implemented")
+}
+func (UnimplementedDaemonServiceServer) AddWorkspace(context.Context, *AddWorkspaceRequest) (*AddWorkspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWorkspace not implemented")
+}
+func (UnimplementedDaemonServiceServer) ListWorkspaces(context.Context, *ListWorkspacesRequest) (*ListWorkspacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkspaces not implemented")
+}
+func (UnimplementedDaemonServiceServer) RemoveWorkspace(context.Context, *RemoveWorkspaceRequest) (*RemoveWorkspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWorkspace not implemented")
+}
+func (UnimplementedDaemonServiceServer) QueueJob(context.Context, *QueueJobRequest) (*QueueJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueueJob not implemented")
+}
+func (UnimplementedDaemonServiceServer) ListQueue(context.Context, *ListQueueRequest) (*ListQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQueue not implemented")
+}
+func (UnimplementedDaemonServiceServer) CancelQueuedJob(context.Context, *CancelQueuedJobRequest) (*CancelQueuedJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelQueuedJob not implemented")
+}
+func (UnimplementedDaemonServiceServer) AddSchedule(context.Context, *AddScheduleRequest) (*AddScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSchedule not implemented")
+}
+func (UnimplementedDaemonServiceServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedDaemonServiceServer) RemoveSchedule(context.Context, *RemoveScheduleRequest) (*RemoveScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSchedule not implemented")
+}
+func (UnimplementedDaemonServiceServer) ExportJob(*ExportJobRequest, grpc.ServerStreamingServer[JobArchiveChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportJob not implemented")
+}
+func (UnimplementedDaemonServiceServer) ImportJob(grpc.ClientStreamingServer[JobArchiveChunk, ImportJobResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportJob not implemented")
+}
 func (UnimplementedDaemonServiceServer) mustEmbedUnimplementedDaemonServiceServer() {}
 func (UnimplementedDaemonServiceServer) testEmbeddedByValue()                       {}
 
@@ -320,6 +532,204 @@ func _DaemonService_Health_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_RunGC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunGCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).RunGC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_RunGC_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).RunGC(ctx, req.(*RunGCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_AddWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).AddWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_AddWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).AddWorkspace(ctx, req.(*AddWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_ListWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkspacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ListWorkspaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_ListWorkspaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ListWorkspaces(ctx, req.(*ListWorkspacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_RemoveWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).RemoveWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_RemoveWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).RemoveWorkspace(ctx, req.(*RemoveWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_QueueJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).QueueJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_QueueJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).QueueJob(ctx, req.(*QueueJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_ListQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ListQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_ListQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ListQueue(ctx, req.(*ListQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_CancelQueuedJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelQueuedJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).CancelQueuedJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_CancelQueuedJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).CancelQueuedJob(ctx, req.(*CancelQueuedJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_AddSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).AddSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_AddSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).AddSchedule(ctx, req.(*AddScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_ListSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_RemoveSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).RemoveSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_RemoveSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).RemoveSchedule(ctx, req.(*RemoveScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_ExportJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServiceServer).ExportJob(m, &grpc.GenericServerStream[ExportJobRequest, JobArchiveChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_ExportJobServer = grpc.ServerStreamingServer[JobArchiveChunk]
+
+func _DaemonService_ImportJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DaemonServiceServer).ImportJob(&grpc.GenericServerStream[JobArchiveChunk, ImportJobResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_ImportJobServer = grpc.ClientStreamingServer[JobArchiveChunk, ImportJobResponse]
+
 // DaemonService_ServiceDesc is the grpc.ServiceDesc for DaemonService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -351,6 +761,46 @@ var DaemonService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Health",
 			Handler:    _DaemonService_Health_Handler,
 		},
+		{
+			MethodName: "RunGC",
+			Handler:    _DaemonService_RunGC_Handler,
+		},
+		{
+			MethodName: "AddWorkspace",
+			Handler:    _DaemonService_AddWorkspace_Handler,
+		},
+		{
+			MethodName: "ListWorkspaces",
+			Handler:    _DaemonService_ListWorkspaces_Handler,
+		},
+		{
+			MethodName: "RemoveWorkspace",
+			Handler:    _DaemonService_RemoveWorkspace_Handler,
+		},
+		{
+			MethodName: "QueueJob",
+			Handler:    _DaemonService_QueueJob_Handler,
+		},
+		{
+			MethodName: "ListQueue",
+			Handler:    _DaemonService_ListQueue_Handler,
+		},
+		{
+			MethodName: "CancelQueuedJob",
+			Handler:    _DaemonService_CancelQueuedJob_Handler,
+		},
+		{
+			MethodName: "AddSchedule",
+			Handler:    _DaemonService_AddSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _DaemonService_ListSchedules_Handler,
+		},
+		{
+			MethodName: "RemoveSchedule",
+			Handler:    _DaemonService_RemoveSchedule_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -358,6 +808,16 @@ var DaemonService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _DaemonService_WatchJob_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportJob",
+			Handler:       _DaemonService_ExportJob_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportJob",
+			Handler:       _DaemonService_ImportJob_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "proto/choo/v1/daemon.proto",
 }